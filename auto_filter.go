@@ -0,0 +1,90 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+	"strings"
+	_ "unsafe"
+)
+
+//go:linkname fromFilterInfo github.com/plandem/xlsx/types.fromFilterInfo
+func fromFilterInfo(info *types.FilterInfo) (*ml.FilterColumn, error)
+
+//go:linkname toFilterInfo github.com/plandem/xlsx/types.toFilterInfo
+func toFilterInfo(column *ml.FilterColumn) *types.FilterInfo
+
+//N.B.: Excel uses hidden defined name to store bounds of auto filter
+const definedNameFilterDatabase = "_xlnm._FilterDatabase"
+
+type autoFilter struct {
+	sheet *sheetInfo
+}
+
+//newAutoFilter creates an object that implements auto filter functionality
+func newAutoFilter(sheet *sheetInfo) *autoFilter {
+	return &autoFilter{sheet: sheet}
+}
+
+//Set sets auto filter for bounds with optional criteria for columns. Existing auto filter will be replaced
+func (f *autoFilter) Set(bounds types.Bounds, filters []*types.FilterInfo) error {
+	if bounds.IsEmpty() {
+		return errors.New("no bounds for auto filter")
+	}
+
+	width, _ := bounds.Dimension()
+	autoFilter := &ml.AutoFilter{Bounds: bounds}
+	for _, info := range filters {
+		if info == nil {
+			continue
+		}
+
+		column, err := fromFilterInfo(info)
+		if err != nil {
+			return err
+		}
+
+		if column.ColID >= width {
+			return errors.New(fmt.Sprintf("column #%d is out of bounds of auto filter %s", column.ColID, bounds))
+		}
+
+		for _, existing := range autoFilter.FilterColumns {
+			if existing.ColID == column.ColID {
+				return errors.New(fmt.Sprintf("column #%d already has criteria", column.ColID))
+			}
+		}
+
+		autoFilter.FilterColumns = append(autoFilter.FilterColumns, column)
+	}
+
+	formula := fmt.Sprintf("'%s'!%s", strings.Replace(f.sheet.Name(), `'`, `''`, -1), bounds.ToRef().ToAbsolute())
+	if err := f.sheet.workbook.definedNames.addHidden(definedNameFilterDatabase, formula, f.sheet.index); err != nil {
+		return err
+	}
+
+	f.sheet.ml.AutoFilter = autoFilter
+	return nil
+}
+
+//Get returns bounds and criteria of auto filter
+func (f *autoFilter) Get() (types.Bounds, []*types.FilterInfo) {
+	if f.sheet.ml.AutoFilter == nil {
+		return types.Bounds{}, nil
+	}
+
+	filters := make([]*types.FilterInfo, 0, len(f.sheet.ml.AutoFilter.FilterColumns))
+	for _, column := range f.sheet.ml.AutoFilter.FilterColumns {
+		filters = append(filters, toFilterInfo(column))
+	}
+
+	return f.sheet.ml.AutoFilter.Bounds, filters
+}
+
+//Remove removes auto filter
+func (f *autoFilter) Remove() {
+	if f.sheet.ml.AutoFilter != nil {
+		f.sheet.ml.AutoFilter = nil
+		f.sheet.workbook.definedNames.Remove(definedNameFilterDatabase, f.sheet.index)
+	}
+}
@@ -0,0 +1,64 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAutoFilter(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Data")
+
+	//invalid auto filters
+	require.NotNil(t, sheet.SetAutoFilter(types.Bounds{}))
+	require.NotNil(t, sheet.SetAutoFilter(types.BoundsFromIndexes(0, 0, 3, 10), types.NewFilter(4, types.Filter.Equals("a"))))
+	require.NotNil(t, sheet.SetAutoFilter(types.BoundsFromIndexes(0, 0, 3, 10), types.NewFilter(1, types.Filter.Equals("a")), types.NewFilter(1, types.Filter.Top(10))))
+	require.Nil(t, sheet.info().ml.AutoFilter)
+
+	//set auto filter
+	require.Nil(t, sheet.SetAutoFilter(types.BoundsFromIndexes(0, 0, 3, 10),
+		types.NewFilter(0, types.Filter.Equals("North", "South")),
+		types.NewFilter(2, types.Filter.Between(100, 200)),
+	))
+
+	bounds, filters := sheet.AutoFilter()
+	require.Equal(t, types.BoundsFromIndexes(0, 0, 3, 10), bounds)
+	require.Equal(t, 2, len(filters))
+	require.Equal(t, []string{"North", "South"}, filters[0].Values())
+	require.Equal(t, 2, filters[1].Column())
+	require.Equal(t, "'Data'!$A$1:$D$11", sheet.DefinedName("_xlnm._FilterDatabase"))
+
+	//replace auto filter
+	require.Nil(t, sheet.SetAutoFilter(types.BoundsFromIndexes(1, 1, 2, 5)))
+	bounds, filters = sheet.AutoFilter()
+	require.Equal(t, types.BoundsFromIndexes(1, 1, 2, 5), bounds)
+	require.Equal(t, 0, len(filters))
+	require.Equal(t, "'Data'!$B$2:$C$6", sheet.DefinedName("_xlnm._FilterDatabase"))
+	require.Equal(t, 1, len(xl.workbook.ml.DefinedNames.Items))
+	require.Equal(t, true, xl.workbook.ml.DefinedNames.Items[0].Hidden)
+
+	require.Nil(t, sheet.SetAutoFilter(types.BoundsFromIndexes(0, 0, 3, 10), types.NewFilter(1, types.Filter.Top(5))))
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	bounds, filters = sheet.AutoFilter()
+	require.Equal(t, types.BoundsFromIndexes(0, 0, 3, 10), bounds)
+	require.Equal(t, 1, len(filters))
+	require.Equal(t, 1, filters[0].Column())
+
+	//remove auto filter
+	sheet.DeleteAutoFilter()
+	bounds, filters = sheet.AutoFilter()
+	require.Equal(t, true, bounds.IsEmpty())
+	require.Nil(t, filters)
+	require.Equal(t, "", sheet.DefinedName("_xlnm._FilterDatabase"))
+}
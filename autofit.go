@@ -0,0 +1,115 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+	"math"
+	"strings"
+)
+
+//FontMetrics is a table of character widths that is used to measure content of cells during auto-fitting of columns.
+//Widths are in units of width of '0' character for font with FontSize, i.e. in units of column width
+type FontMetrics struct {
+	FontSize     float64
+	DefaultWidth float64
+	WideWidth    float64
+	Padding      float64
+	Widths       map[rune]float64
+}
+
+//DefaultFontMetrics is an approximate metrics of default font - Calibri 11
+var DefaultFontMetrics = &FontMetrics{
+	FontSize:     11,
+	DefaultWidth: 1,
+	WideWidth:    2,
+	Padding:      0.71,
+	Widths: map[rune]float64{
+		' ': 0.43, '!': 0.43, '"': 0.57, '\'': 0.29, '(': 0.43, ')': 0.43, '*': 0.71, ',': 0.43, '-': 0.57, '.': 0.43, '/': 0.57,
+		':': 0.43, ';': 0.43, '[': 0.43, ']': 0.43, '`': 0.43, '{': 0.43, '|': 0.43, '}': 0.43, '%': 1.29, '@': 1.57,
+		'I': 0.43, 'J': 0.57, 'M': 1.43, 'W': 1.71, 'm': 1.43, 'w': 1.29,
+		'f': 0.57, 'i': 0.43, 'j': 0.43, 'l': 0.43, 'r': 0.71, 's': 0.71, 't': 0.57,
+	},
+}
+
+//Measure returns width of text in units of column width. Width of multiline text is a width of the longest line
+func (m *FontMetrics) Measure(text string) float64 {
+	max := 0.0
+	for _, line := range strings.Split(text, "\n") {
+		width := 0.0
+		for _, r := range line {
+			if w, ok := m.Widths[r]; ok {
+				width += w
+			} else if r >= 0x2e80 {
+				//east asian characters are twice wider
+				width += m.WideWidth
+			} else {
+				width += m.DefaultWidth
+			}
+		}
+
+		if width > max {
+			max = width
+		}
+	}
+
+	return max
+}
+
+//AutoFitColumns sets approximate best-fit widths for cols of bounds to fit content of cells in bounds. DefaultFontMetrics is used if there is no metrics
+func (s *sheetInfo) AutoFitColumns(bounds types.Bounds, metrics ...*FontMetrics) error {
+	if bounds.FromCol < 0 || bounds.FromRow < 0 || bounds.FromCol > bounds.ToCol || bounds.FromRow > bounds.ToRow || bounds.ToCol >= internal.ExcelColumnLimit {
+		return errors.New(fmt.Sprintf("invalid bounds for auto-fitting of columns: %s", bounds))
+	}
+
+	m := DefaultFontMetrics
+	if len(metrics) > 0 && metrics[0] != nil {
+		m = metrics[0]
+	}
+
+	widths := make(map[int]float64)
+	s.walkStoredCells(func(cIdx, rIdx int, data *ml.Cell) {
+		if !bounds.Contains(cIdx, rIdx) {
+			return
+		}
+
+		//as Excel, ignore merged cells that span few columns
+		if merged, ok := s.mergedCells.Get(cIdx, rIdx); ok && merged.FromCol != merged.ToCol {
+			return
+		}
+
+		c := &Cell{ml: data, sheet: s}
+		width := m.Measure(c.String())
+		if width == 0 {
+			return
+		}
+
+		//scale width for font of cell
+		if font := s.workbook.doc.styleSheet.resolveFont(data.Style); font != nil {
+			if font.Size > 0 && m.FontSize > 0 {
+				width *= float64(font.Size) / m.FontSize
+			}
+
+			if font.Bold {
+				width *= 1.1
+			}
+		}
+
+		if width > widths[cIdx] {
+			widths[cIdx] = width
+		}
+	})
+
+	for cIdx, width := range widths {
+		width = math.Min(math.Ceil((width+m.Padding)*100)/100, internal.ExcelColumnWidthLimit)
+
+		col := s.columns.Resolve(cIdx)
+		col.Width = float32(width)
+		col.CustomWidth = true
+		col.BestFit = true
+	}
+
+	return nil
+}
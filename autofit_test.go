@@ -0,0 +1,48 @@
+package xlsx
+
+import (
+	"bytes"
+	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAutoFitColumns(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Report")
+
+	sheet.CellByRef("A1").SetValue("Hi")
+	sheet.CellByRef("A2").SetValue("Hello world")
+	sheet.CellByRef("B1").SetValue("00")
+	sheet.CellByRef("B1").SetFormatting(xl.AddFormatting(format.NewStyles(format.Font.Size(22))))
+	sheet.CellByRef("C1").SetValue("Title of merged cells")
+	require.Nil(t, sheet.Range("C1:D1").Merge())
+	sheet.CellByRef("E1").SetValue("first line\nend")
+
+	require.NotNil(t, sheet.AutoFitColumns(types.BoundsFromIndexes(-1, 0, 1, 1)))
+	require.Nil(t, sheet.AutoFitColumns(types.BoundsFromIndexes(0, 0, 10, 10)))
+	require.Equal(t, float32(9.43), sheet.ColOptions(0).Width)
+	require.Equal(t, float32(4.71), sheet.ColOptions(1).Width)
+	require.Equal(t, float32(0), sheet.ColOptions(2).Width)
+	require.Equal(t, float32(6.99), sheet.ColOptions(4).Width)
+	require.Equal(t, true, sheet.ColOptions(0).BestFit)
+
+	//custom metrics
+	require.Nil(t, sheet.AutoFitColumns(types.BoundsFromIndexes(0, 0, 0, 10), &FontMetrics{DefaultWidth: 2}))
+	require.Equal(t, float32(22), sheet.ColOptions(0).Width)
+}
+
+func TestAutoFitColumnsSaved(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Report")
+	sheet.CellByRef("C5").SetValue("Hello world")
+
+	//grid of sheet is shrunk after saving
+	require.Nil(t, xl.SaveAs(&bytes.Buffer{}))
+	require.Nil(t, sheet.AutoFitColumns(types.BoundsFromIndexes(0, 0, 10, 10)))
+	require.Equal(t, float32(0), sheet.ColOptions(0).Width)
+	require.Equal(t, float32(9.43), sheet.ColOptions(2).Width)
+}
@@ -0,0 +1,41 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"io"
+)
+
+//removeBackground removes background of sheet, relationship with image of it and image itself if it is not used anymore
+func (s *sheetInfo) removeBackground() {
+	if s.ml.Picture == nil {
+		return
+	}
+
+	s.attachRelationshipsIfRequired()
+	fileName := s.relationships.GetTargetById(string(s.ml.Picture.RID))
+	s.relationships.Remove(s.ml.Picture.RID)
+	s.ml.Picture = nil
+	if len(fileName) > 0 {
+		s.workbook.doc.removeMediaIfUnused(fileName)
+	}
+}
+
+//SetBackground sets image as background of sheet, that is tiled across sheet on screen, but is not printed. Nil image removes background
+func (s *sheetInfo) SetBackground(image io.Reader) error {
+	if image == nil {
+		s.removeBackground()
+		return nil
+	}
+
+	content, format, _, err := readImage(image)
+	if err != nil {
+		return err
+	}
+
+	s.removeBackground()
+	s.attachRelationshipsIfRequired()
+	_, rid := s.relationships.AddFile(internal.RelationTypeImage, s.drawings.addMedia(content, format))
+	s.ml.Picture = &ml.SheetBackgroundPicture{RID: rid}
+	return nil
+}
@@ -0,0 +1,55 @@
+package xlsx
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestSetBackground(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	buf := &bytes.Buffer{}
+	require.Nil(t, png.Encode(buf, img))
+
+	xl := New()
+	sheet := xl.AddSheet("Sheet1")
+	sheet.CellByRef("A1").SetValue("background")
+
+	//invalid image must not be applied
+	require.NotNil(t, sheet.SetBackground(strings.NewReader("not an image")))
+	require.Nil(t, sheet.info().ml.Picture)
+
+	require.Nil(t, sheet.SetBackground(bytes.NewReader(buf.Bytes())))
+	require.NotNil(t, sheet.info().ml.Picture)
+	require.Equal(t, "xl/media/image1.png", sheet.info().relationships.GetTargetById(string(sheet.info().ml.Picture.RID)))
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	require.NotNil(t, sheet.info().ml.Picture)
+	sheet.info().attachRelationshipsIfRequired()
+	require.Equal(t, "xl/media/image1.png", sheet.info().relationships.GetTargetById(string(sheet.info().ml.Picture.RID)))
+
+	//replace background, image of previous background is removed
+	total := sheet.info().relationships.Total()
+	require.Nil(t, sheet.SetBackground(bytes.NewReader(buf.Bytes())))
+	require.Equal(t, total, sheet.info().relationships.Total())
+	require.Equal(t, "xl/media/image1.png", sheet.info().relationships.GetTargetById(string(sheet.info().ml.Picture.RID)))
+	require.Equal(t, []string{"xl/media/image1.png"}, mediaFiles(xl))
+
+	//remove background
+	require.Nil(t, sheet.SetBackground(nil))
+	require.Nil(t, sheet.info().ml.Picture)
+	require.Equal(t, total-1, sheet.info().relationships.Total())
+	require.Equal(t, 0, len(mediaFiles(xl)))
+}
@@ -0,0 +1,76 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/options"
+)
+
+//SetCalcOptions sets calculation settings of workbook, e.g.: SetCalcOptions(options.NewCalcOptions(options.Calc.ForceFullRecalculation(true))). If options is nil, then default options are used. Settings that are not affected by options are kept as is
+func (xl *Spreadsheet) SetCalcOptions(o *options.CalcOptions) {
+	if o == nil {
+		o = options.NewCalcOptions()
+	}
+
+	if xl.workbook.ml.CalcPr == nil {
+		xl.workbook.ml.CalcPr = &ml.CalcPr{}
+	}
+
+	pr := xl.workbook.ml.CalcPr
+
+	//auto is a default mode
+	pr.CalcMode = ""
+	if o.Mode != options.CalcModeAuto {
+		pr.CalcMode = string(o.Mode)
+	}
+
+	pr.Iterate = o.Iterate
+	pr.IterateCount, pr.IterateDelta = 0, 0
+	if o.Iterate {
+		pr.IterateCount, pr.IterateDelta = o.IterateCount, o.IterateDelta
+	}
+
+	//true is a default value
+	pr.FullPrecision, pr.CalcOnSave = nil, nil
+	if !o.FullPrecision {
+		pr.FullPrecision = &o.FullPrecision
+	}
+
+	if !o.CalcOnSave {
+		pr.CalcOnSave = &o.CalcOnSave
+	}
+
+	pr.FullCalcOnLoad = o.ForceFullRecalculation
+	xl.workbook.file.MarkAsUpdated()
+}
+
+//CalcOptions returns calculation settings of workbook
+func (xl *Spreadsheet) CalcOptions() *options.CalcOptions {
+	o := options.NewCalcOptions()
+
+	if pr := xl.workbook.ml.CalcPr; pr != nil {
+		if len(pr.CalcMode) > 0 {
+			o.Mode = options.CalcMode(pr.CalcMode)
+		}
+
+		o.Iterate = pr.Iterate
+		if pr.IterateCount > 0 {
+			o.IterateCount = pr.IterateCount
+		}
+
+		if pr.IterateDelta > 0 {
+			o.IterateDelta = pr.IterateDelta
+		}
+
+		if pr.FullPrecision != nil {
+			o.FullPrecision = *pr.FullPrecision
+		}
+
+		if pr.CalcOnSave != nil {
+			o.CalcOnSave = *pr.CalcOnSave
+		}
+
+		o.ForceFullRecalculation = pr.FullCalcOnLoad
+	}
+
+	return o
+}
@@ -0,0 +1,48 @@
+package xlsx
+
+import (
+	"encoding/xml"
+	"github.com/plandem/xlsx/options"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCalcOptions(t *testing.T) {
+	xl := New()
+	xl.AddSheet("Sheet1")
+
+	require.Equal(t, options.NewCalcOptions(), xl.CalcOptions())
+
+	o := options.NewCalcOptions(
+		options.Calc.Mode(options.CalcModeManual),
+		options.Calc.Iterate(50, 0.01),
+		options.Calc.FullPrecision(false),
+		options.Calc.ForceFullRecalculation(true),
+	)
+
+	xl.SetCalcOptions(o)
+	encoded, err := xml.Marshal(xl.workbook.ml.CalcPr)
+	require.Nil(t, err)
+	require.Equal(t, `<CalcPr calcMode="manual" fullCalcOnLoad="true" iterate="true" iterateCount="50" iterateDelta="0.01" fullPrecision="false"></CalcPr>`, string(encoded))
+	require.Equal(t, o, xl.CalcOptions())
+
+	//defaults, settings that are not affected by options are kept as is
+	xl.workbook.ml.CalcPr.CalcID = 191029
+	xl.SetCalcOptions(nil)
+	encoded, err = xml.Marshal(xl.workbook.ml.CalcPr)
+	require.Nil(t, err)
+	require.Equal(t, `<CalcPr calcId="191029"></CalcPr>`, string(encoded))
+
+	xl.SetCalcOptions(o)
+
+	//save and reopen
+	err = xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	require.Equal(t, o, xl.CalcOptions())
+}
@@ -4,13 +4,16 @@ import (
 	"errors"
 	"fmt"
 	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/formula"
 	"github.com/plandem/xlsx/internal"
 	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/internal/ml/primitives"
 	"github.com/plandem/xlsx/internal/number_format"
 	"github.com/plandem/xlsx/internal/number_format/convert"
 	"github.com/plandem/xlsx/types"
 	"math"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -29,8 +32,19 @@ func (c *Cell) Type() types.CellType {
 	return c.ml.Type
 }
 
-//Value returns current raw value of cell
+//Value returns current raw value of cell. If spreadsheet has evaluator, then value of formula will be computed rather than cached value
 func (c *Cell) Value() string {
+	if c.HasFormula() && c.sheet.workbook.doc.evaluator != nil {
+		if value, err := c.evaluate(newFormulaContext(c.sheet)); err == nil {
+			return fromFormulaValue(value)
+		}
+	}
+
+	return c.rawValue()
+}
+
+//rawValue returns current raw value of cell as is
+func (c *Cell) rawValue() string {
 	var value string
 
 	switch c.ml.Type {
@@ -55,27 +69,52 @@ func (c *Cell) Value() string {
 
 //String returns formatted value as string respecting cell number format and type. Any errors ignored to conform String() interface.
 func (c *Cell) String() string {
+	return c.FormattedValue()
+}
+
+//NumberFormat returns code of number format that is used by cell
+func (c *Cell) NumberFormat() string {
+	return c.sheet.workbook.doc.styleSheet.resolveNumberFormat(c.ml.Style)
+}
+
+//FormattedValue returns value as string formatted according to the number format of cell, e.g. same as Excel displays it
+func (c *Cell) FormattedValue() string {
 	//if cell has error, then just return value that Excel put here
 	if c.ml.Type == types.CellTypeError {
 		return c.ml.Value
 	}
 
-	code := c.sheet.workbook.doc.styleSheet.resolveNumberFormat(c.ml.Style)
+	//N.B.: serial dates of 1904 date system must be converted to serial dates of 1900 date system before formatting
+	value := c.Value()
+	if date, t := c.hasDateFormat(); c.sheet.workbook.isDate1904() && c.isNumeric() && (date || t) {
+		if f, err := convert.ToFloat(value); err == nil {
+			value = strconv.FormatFloat(f+convert.Date1904Offset, 'f', -1, 64)
+		}
+	}
 
 	//N.B.: Maybe it's not a good idea to use resolved value (e.g. inline string) for conversion?!
-	return numberFormat.Format(c.Value(), code, c.ml.Type)
+	return numberFormat.Format(value, c.NumberFormat(), c.ml.Type)
 }
 
 //Date try to convert and return current raw value as time.Time
+//
+//Deprecated: use Time() that validates type and number format of cell
 func (c *Cell) Date() (time.Time, error) {
 	if c.ml.Type == types.CellTypeDate || c.ml.Type == types.CellTypeNumber || c.ml.Type == types.CellTypeGeneral {
-		return convert.ToDate(c.ml.Value)
+		value := c.ml.Value
+		if f, err := convert.ToFloat(value); err == nil && c.sheet.workbook.isDate1904() {
+			value = strconv.FormatFloat(f+convert.Date1904Offset, 'f', -1, 64)
+		}
+
+		return convert.ToDate(value)
 	}
 
 	return time.Now(), errTypeMismatch
 }
 
 //Int try to convert and return current raw value as int
+//
+//Deprecated: use Int64() that validates type and number format of cell
 func (c *Cell) Int() (int, error) {
 	if c.ml.Type == types.CellTypeNumber || c.ml.Type == types.CellTypeGeneral {
 		return convert.ToInt(c.ml.Value)
@@ -85,6 +124,8 @@ func (c *Cell) Int() (int, error) {
 }
 
 //Float try to convert and return current raw value as float64
+//
+//Deprecated: use Float64() that validates type and number format of cell
 func (c *Cell) Float() (float64, error) {
 	if c.ml.Type == types.CellTypeNumber || c.ml.Type == types.CellTypeGeneral {
 		return convert.ToFloat(c.ml.Value)
@@ -95,18 +136,106 @@ func (c *Cell) Float() (float64, error) {
 
 //Bool try to convert and return current raw value as bool
 func (c *Cell) Bool() (bool, error) {
-	if c.ml.Type == types.CellTypeBool || c.ml.Type == types.CellTypeGeneral || c.ml.Type == types.CellTypeNumber {
-		return convert.ToBool(c.ml.Value)
+	if c.ml.Type == types.CellTypeBool || c.isNumeric() {
+		if b, err := convert.ToBool(c.ml.Value); err == nil {
+			return b, nil
+		}
+	}
+
+	return false, c.typeMismatch("bool")
+}
+
+//Int64 returns value of cell as int64. Number must have integer value and must not have date or time number format
+func (c *Cell) Int64() (int64, error) {
+	if date, t := c.hasDateFormat(); c.isNumeric() && !date && !t {
+		if i, err := strconv.ParseInt(c.ml.Value, 10, 64); err == nil {
+			return i, nil
+		}
+
+		//N.B.: Excel can store integers in exponential notation, e.g.: 1E+3
+		if f, err := convert.ToFloat(c.ml.Value); err == nil && f == math.Trunc(f) && math.Abs(f) < math.MaxInt64 {
+			return int64(f), nil
+		}
+	}
+
+	return 0, c.typeMismatch("int64")
+}
+
+//Float64 returns value of cell as float64. Number must not have date or time number format
+func (c *Cell) Float64() (float64, error) {
+	if date, t := c.hasDateFormat(); c.isNumeric() && !date && !t {
+		if f, err := convert.ToFloat(c.ml.Value); err == nil {
+			return f, nil
+		}
+	}
+
+	return math.NaN(), c.typeMismatch("float64")
+}
+
+//Time returns value of cell as time.Time in UTC. Cell must have date type or number with date or time number format
+func (c *Cell) Time() (time.Time, error) {
+	if c.ml.Type == types.CellTypeDate {
+		if d, err := time.Parse(convert.ISO8601, c.ml.Value); err == nil {
+			return d, nil
+		}
+	}
+
+	if date, t := c.hasDateFormat(); c.isNumeric() && (date || t) {
+		if f, err := convert.ToFloat(c.ml.Value); err == nil {
+			return c.sheet.workbook.fromSerial(f), nil
+		}
+	}
+
+	return time.Time{}, c.typeMismatch("time")
+}
+
+//Duration returns value of cell as time.Duration. Cell must have time number format without date part, e.g.: [h]:mm:ss
+func (c *Cell) Duration() (time.Duration, error) {
+	if date, t := c.hasDateFormat(); t && !date {
+		switch {
+		case c.ml.Type == types.CellTypeDate:
+			if d, err := time.Parse(convert.ISO8601, c.ml.Value); err == nil {
+				return convert.ToSerialDuration(convert.ToSerial(d)), nil
+			}
+		case c.isNumeric():
+			if f, err := convert.ToFloat(c.ml.Value); err == nil {
+				return convert.ToSerialDuration(f), nil
+			}
+		}
+	}
+
+	return 0, c.typeMismatch("duration")
+}
+
+//typeMismatch returns error for value of cell that can not be converted to requested type
+func (c *Cell) typeMismatch(target string) error {
+	return errors.New(fmt.Sprintf("type mismatch: value %q of cell %s with type %q and number format %q can't be used as %s", c.ml.Value, c.ml.Ref, c.ml.Type, c.NumberFormat(), target))
+}
+
+//isNumeric returns true if cell holds a numeric value
+func (c *Cell) isNumeric() bool {
+	return (c.ml.Type == types.CellTypeNumber || c.ml.Type == types.CellTypeGeneral) && len(c.ml.Value) > 0
+}
+
+//hasDateFormat returns flags for date and time parts of number format of cell
+func (c *Cell) hasDateFormat() (date bool, time bool) {
+	code := c.NumberFormat()
+
+	//N.B.: locale specific built-in formats have no known code, so only ID can be used to detect dates
+	if ss := c.sheet.workbook.doc.styleSheet; int(c.ml.Style) < len(ss.ml.CellXfs.Items) {
+		if id := ss.ml.CellXfs.Items[c.ml.Style].NumFmtId; numberFormat.IsBuiltIn(id) && numberFormat.IsDateID(id) && !numberFormat.IsDate(code) && !numberFormat.IsTime(code) {
+			return true, false
+		}
 	}
 
-	return false, errTypeMismatch
+	return numberFormat.IsDate(code), numberFormat.IsTime(code)
 }
 
 //setGeneral sets the value as general type
 func (c *Cell) setGeneral(value string) {
 	c.ml.Type = types.CellTypeGeneral
 	c.ml.Value = value
-	c.ml.Formula = nil
+	c.resetFormula()
 	c.ml.InlineStr = nil
 }
 
@@ -123,19 +252,22 @@ func (c *Cell) truncateIfRequired(value string) string {
 func (c *Cell) SetInlineString(value string) {
 	if len(value) == 0 {
 		c.setGeneral(value)
+		c.sheet.changes.add(c)
 		return
 	}
 
 	c.ml.Type = types.CellTypeInlineString
 	c.ml.Value = ""
-	c.ml.Formula = nil
+	c.resetFormula()
 	c.ml.InlineStr = &ml.StringItem{Text: types.Text(c.truncateIfRequired(value))}
+	c.sheet.changes.add(c)
 }
 
-//SetString sets value as shared string
+//SetString sets value as shared or inline string, depending on strings mode of document
 func (c *Cell) SetString(value string) {
 	if len(value) == 0 {
 		c.setGeneral(value)
+		c.sheet.changes.add(c)
 		return
 	}
 
@@ -144,27 +276,39 @@ func (c *Cell) SetString(value string) {
 		panic(errorNotSupportedWrite)
 	}
 
+	c.setStringItem(&ml.StringItem{Text: primitives.Text(c.truncateIfRequired(value))})
+	c.sheet.changes.add(c)
+}
+
+//setStringItem sets string item as shared or inline string, depending on strings mode of document
+func (c *Cell) setStringItem(text *ml.StringItem) {
+	c.resetFormula()
+
+	if c.sheet.workbook.doc.stringsMode == StringsInline {
+		c.ml.Type = types.CellTypeInlineString
+		c.ml.Value = ""
+		c.ml.InlineStr = text
+		return
+	}
+
 	//sharedStrings is the only place that can be mutated from the 'sheet' perspective
-	sid := c.sheet.workbook.doc.sharedStrings.addString(c.truncateIfRequired(value))
-	c.ml.Formula = nil
+	sid := c.sheet.workbook.doc.sharedStrings.addText(text)
 	c.ml.Type = types.CellTypeSharedString
 	c.ml.Value = strconv.Itoa(sid)
+	c.ml.InlineStr = nil
 }
 
-//SetString sets shared rich text
+//SetText sets shared or inline rich text, depending on strings mode of document
 func (c *Cell) SetText(parts ...interface{}) error {
 	//we can update sharedStrings only when sheet is in write mode, to prevent pollution of sharedStrings with fake values
 	if (c.sheet.mode() & sheetModeWrite) == 0 {
 		panic(errorNotSupportedWrite)
 	}
 
-	//sharedStrings is the only place that can be mutated from the 'sheet' perspective
 	text, err := toRichText(parts...)
 	if err == nil {
-		sid := c.sheet.workbook.doc.sharedStrings.addText(text)
-		c.ml.Formula = nil
-		c.ml.Type = types.CellTypeSharedString
-		c.ml.Value = strconv.Itoa(sid)
+		c.setStringItem(text)
+		c.sheet.changes.add(c)
 	}
 
 	return err
@@ -177,13 +321,48 @@ func (c *Cell) SetInlineText(parts ...interface{}) error {
 	if err == nil {
 		c.ml.Type = types.CellTypeInlineString
 		c.ml.Value = ""
-		c.ml.Formula = nil
+		c.resetFormula()
 		c.ml.InlineStr = text
+		c.sheet.changes.add(c)
 	}
 
 	return err
 }
 
+//SetRichText sets rich text that mixes fragments with different font settings
+func (c *Cell) SetRichText(runs ...RichTextRun) error {
+	//we can update sharedStrings only when sheet is in write mode, to prevent pollution of sharedStrings with fake values
+	if (c.sheet.mode() & sheetModeWrite) == 0 {
+		panic(errorNotSupportedWrite)
+	}
+
+	text, err := toRichTextRuns(runs...)
+	if err == nil {
+		c.setStringItem(text)
+		c.sheet.changes.add(c)
+	}
+
+	return err
+}
+
+//RichText returns fragments of text with font settings for shared or inline string and nil for other types of cell
+func (c *Cell) RichText() []RichTextRun {
+	switch c.ml.Type {
+	case types.CellTypeInlineString:
+		return fromRichTextRuns(c.ml.InlineStr, c.sheet.workbook.doc.ThemeColors()...)
+	case types.CellTypeSharedString:
+		var sid int
+
+		if len(c.ml.Value) > 0 {
+			sid, _ = strconv.Atoi(c.ml.Value)
+		}
+
+		return fromRichTextRuns(c.sheet.workbook.doc.sharedStrings.get(sid), c.sheet.workbook.doc.ThemeColors()...)
+	}
+
+	return nil
+}
+
 //SetInt sets an integer value
 func (c *Cell) SetInt(value int) {
 	c.ml.Type = types.CellTypeNumber
@@ -193,8 +372,9 @@ func (c *Cell) SetInt(value int) {
 		c.ml.Style = c.sheet.workbook.doc.styleSheet.typedStyles[numberFormat.Integer]
 	}
 
-	c.ml.Formula = nil
+	c.resetFormula()
 	c.ml.InlineStr = nil
+	c.sheet.changes.add(c)
 }
 
 //SetFloat sets a float value
@@ -206,14 +386,15 @@ func (c *Cell) SetFloat(value float64) {
 		c.ml.Style = c.sheet.workbook.doc.styleSheet.typedStyles[numberFormat.Float]
 	}
 
-	c.ml.Formula = nil
+	c.resetFormula()
 	c.ml.InlineStr = nil
+	c.sheet.changes.add(c)
 }
 
 //SetBool sets a bool value
 func (c *Cell) SetBool(value bool) {
 	c.ml.Type = types.CellTypeBool
-	c.ml.Formula = nil
+	c.resetFormula()
 	c.ml.InlineStr = nil
 
 	if value {
@@ -221,6 +402,31 @@ func (c *Cell) SetBool(value bool) {
 	} else {
 		c.ml.Value = "0"
 	}
+
+	c.sheet.changes.add(c)
+}
+
+//SetError sets an error value, e.g.: SetError(types.ErrorNA)
+func (c *Cell) SetError(value types.CellError) error {
+	if !value.IsValid() {
+		return errors.New(fmt.Sprintf("unknown error value %q", value))
+	}
+
+	c.ml.Type = types.CellTypeError
+	c.ml.Value = string(value)
+	c.resetFormula()
+	c.ml.InlineStr = nil
+	c.sheet.changes.add(c)
+	return nil
+}
+
+//Error returns error value of cell. Cell must have error type, e.g. cached value of formula
+func (c *Cell) Error() (types.CellError, error) {
+	if c.ml.Type == types.CellTypeError {
+		return types.CellError(c.ml.Value), nil
+	}
+
+	return "", c.typeMismatch("error")
 }
 
 //setDate is a general setter for date types
@@ -232,8 +438,9 @@ func (c *Cell) setDate(value time.Time, t numberFormat.Type) {
 		c.ml.Style = c.sheet.workbook.doc.styleSheet.typedStyles[t]
 	}
 
-	c.ml.Formula = nil
+	c.resetFormula()
 	c.ml.InlineStr = nil
+	c.sheet.changes.add(c)
 }
 
 //SetDateTime sets a time value with number format for datetime
@@ -279,6 +486,14 @@ func (c *Cell) SetValue(value interface{}) {
 		c.SetString(string(v))
 	case bool:
 		c.SetBool(v)
+	case types.CellError:
+		if c.SetError(v) != nil {
+			c.SetString(string(v))
+		}
+	case formula.Error:
+		if c.SetError(types.CellError(v)) != nil {
+			c.SetString(string(v))
+		}
 	case time.Time:
 		c.setDate(v, numberFormat.DateTime)
 	case []interface{}:
@@ -292,12 +507,22 @@ func (c *Cell) SetValue(value interface{}) {
 
 //Reset resets current current cell information
 func (c *Cell) Reset() {
+	c.resetFormula()
 	*c.ml = ml.Cell{Ref: c.ml.Ref}
+	c.sheet.changes.add(c)
 }
 
-//Clear clears cell's value
+//Clear clears cell's value, but keeps style and formula
 func (c *Cell) Clear() {
 	c.ml.Value = ""
+	c.ml.InlineStr = nil
+
+	//N.B.: empty value of shared string is a reference to the first string
+	if c.ml.Type == types.CellTypeSharedString || c.ml.Type == types.CellTypeInlineString {
+		c.ml.Type = types.CellTypeGeneral
+	}
+
+	c.sheet.changes.add(c)
 }
 
 //HasFormula returns true if cell has formula
@@ -305,6 +530,101 @@ func (c *Cell) HasFormula() bool {
 	return c.ml.Formula != nil && (*c.ml.Formula != ml.CellFormula{})
 }
 
+//Formula returns formula of cell or empty string if there is no formula. For cells of shared formula it is formula of master cell with shifted references
+func (c *Cell) Formula() string {
+	return c.sheet.formulas.resolve(c.ml)
+}
+
+//resetFormula removes formula of cell
+func (c *Cell) resetFormula() {
+	if c.ml.Formula != nil {
+		c.sheet.formulas.release(c.ml)
+		c.ml.Formula = nil
+	}
+}
+
+//SetFormula sets formula without cached value, e.g.: SetFormula("SUM(A1:A10)")
+func (c *Cell) SetFormula(formula string) {
+	c.setFormula(formula)
+	c.sheet.changes.add(c)
+}
+
+//setFormula sets formula without cached value and without tracking of changes
+func (c *Cell) setFormula(formula string) {
+	c.setGeneral("")
+
+	if formula = strings.TrimPrefix(formula, "="); len(formula) > 0 {
+		c.ml.Formula = &ml.CellFormula{Content: formula}
+	}
+}
+
+//SetFormulaWithValue sets formula with cached value of type, so viewers can show value without recalculation, e.g.: SetFormulaWithValue("A1+A2", 42, types.CellTypeNumber)
+func (c *Cell) SetFormulaWithValue(expression string, value interface{}, t types.CellType) error {
+	var cached string
+
+	switch t {
+	case types.CellTypeGeneral:
+		if value != nil {
+			cached = fmt.Sprintf("%v", value)
+		}
+	case types.CellTypeNumber, types.CellTypeDate:
+		//N.B.: formulas have no dates, only serial numbers
+		t = types.CellTypeNumber
+
+		switch v := value.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			cached = fmt.Sprintf("%d", v)
+		case float32:
+			cached = strconv.FormatFloat(float64(v), 'f', -1, 32)
+		case float64:
+			cached = strconv.FormatFloat(v, 'f', -1, 64)
+		case time.Time:
+			cached = strconv.FormatFloat(c.sheet.workbook.toSerial(v), 'f', -1, 64)
+		case string:
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				return errTypeMismatch
+			}
+
+			cached = v
+		default:
+			return errTypeMismatch
+		}
+	case types.CellTypeBool:
+		v, ok := value.(bool)
+		if !ok {
+			return errTypeMismatch
+		}
+
+		cached = "0"
+		if v {
+			cached = "1"
+		}
+	case types.CellTypeError:
+		if cellError := types.CellError(fmt.Sprintf("%v", value)); cellError.IsValid() {
+			cached = string(cellError)
+		} else {
+			return errTypeMismatch
+		}
+	case types.CellTypeFormula, types.CellTypeSharedString, types.CellTypeInlineString:
+		//N.B.: string result of formula is always stored as is
+		t = types.CellTypeFormula
+		cached = c.truncateIfRequired(fmt.Sprintf("%v", value))
+	default:
+		return errTypeMismatch
+	}
+
+	c.setFormula(expression)
+	c.ml.Type = t
+	c.ml.Value = cached
+	c.sheet.changes.add(c)
+	return nil
+}
+
+//CachedValue returns value of cell as is, i.e. for formula it is a value that was computed during last recalculation
+func (c *Cell) CachedValue() string {
+	return c.rawValue()
+}
+
 //Formatting returns DirectStyleID of active format for cell
 func (c *Cell) Formatting() format.DirectStyleID {
 	return c.ml.Style
@@ -315,6 +635,24 @@ func (c *Cell) SetFormatting(styleID format.DirectStyleID) {
 	c.ml.Style = styleID
 }
 
+//AddStyles adds style format to document, where settings of style are layered on top of current style of cell instead of replacing it, sets it to cell and returns ID of added style
+func (c *Cell) AddStyles(style *format.StyleFormat) format.DirectStyleID {
+	styleID := c.sheet.workbook.doc.styleSheet.mergeStyle(c.ml.Style, style)
+	c.SetFormatting(styleID)
+	return styleID
+}
+
+//SetNamedStyle sets style format that is based on named style with name or returns error if there is no such named style
+func (c *Cell) SetNamedStyle(name string) error {
+	styleID, err := c.sheet.workbook.doc.NamedStyle(name)
+	if err != nil {
+		return err
+	}
+
+	c.SetFormatting(styleID)
+	return nil
+}
+
 //SetValueWithFormat is helper function that internally works as SetValue and SetFormatting with NumberFormat
 func (c *Cell) SetValueWithFormat(value interface{}, formatCode string) {
 	//we can update styleSheet only when sheet is in write mode, to prevent pollution of styleSheet with fake values
@@ -338,7 +676,7 @@ func (c *Cell) SetHyperlink(link interface{}) error {
 	if styleID, err := c.sheet.hyperlinks.Add(types.RefFromIndexes(c.ml.Ref.ToIndexes()).ToBounds(), link); err != nil {
 		return err
 	} else {
-		c.SetFormatting(styleID)
+		c.sheet.hyperlinks.applyStyle(c, styleID)
 	}
 
 	return nil
@@ -359,3 +697,26 @@ func (c *Cell) SetValueWithHyperlink(value interface{}, link interface{}) error
 func (c *Cell) RemoveHyperlink() {
 	c.sheet.hyperlinks.Remove(types.RefFromIndexes(c.ml.Ref.ToIndexes()).ToBounds())
 }
+
+//Comment returns resolved CommentInfo if there is any comment or nil otherwise
+func (c *Cell) Comment() *types.CommentInfo {
+	return c.sheet.comments.Get(c.ml.Ref)
+}
+
+//SetComment sets comment for cell, where comment can be string or CommentInfo
+func (c *Cell) SetComment(comment interface{}) error {
+	if (c.sheet.mode() & sheetModeWrite) == 0 {
+		panic(errorNotSupportedWrite)
+	}
+
+	return c.sheet.comments.Add(c.ml.Ref, comment)
+}
+
+//RemoveComment removes comment from cell
+func (c *Cell) RemoveComment() {
+	if (c.sheet.mode() & sheetModeWrite) == 0 {
+		panic(errorNotSupportedWrite)
+	}
+
+	c.sheet.comments.Remove(c.ml.Ref)
+}
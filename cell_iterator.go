@@ -0,0 +1,263 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/types"
+	"math"
+	"sort"
+)
+
+//cellPosition is a stored cell with 0-based indexes
+type cellPosition struct {
+	cIdx int
+	rIdx int
+	data *ml.Cell
+}
+
+//cellIterator is object that holds required information for iterator of cells of sheet. Bounds are limited by used range of sheet and only stored cells are visited if empty cells are skipped, so grid of sheet is never expanded
+type cellIterator struct {
+	sheet     *sheetReadWrite
+	bounds    types.Bounds
+	empty     bool
+	byColumn  bool
+	skipEmpty bool
+	cells     []cellPosition
+	index     int
+	cIdx      int
+	rIdx      int
+}
+
+var _ RangeIterator = (*cellIterator)(nil)
+
+func newCellIterator(sheet *sheetReadWrite, o *options.IterateOptions) RangeIterator {
+	if o == nil {
+		o = options.NewIterateOptions()
+	}
+
+	i := &cellIterator{sheet: sheet, byColumn: o.ByColumn, skipEmpty: o.SkipEmpty}
+
+	//cells outside of used range are empty, so bounds are limited by used range
+	used, found := sheet.usedRange()
+	i.bounds, i.empty = used, !found
+	if !o.Range.IsEmpty() {
+		if i.empty = i.empty || !o.Range.Overlaps(used); !i.empty {
+			i.bounds = types.BoundsFromIndexes(
+				int(math.Max(float64(o.Range.FromCol), float64(used.FromCol))),
+				int(math.Max(float64(o.Range.FromRow), float64(used.FromRow))),
+				int(math.Min(float64(o.Range.ToCol), float64(used.ToCol))),
+				int(math.Min(float64(o.Range.ToRow), float64(used.ToRow))),
+			)
+		}
+	}
+
+	if i.skipEmpty && !i.empty {
+		i.cells = storedCells(sheet, i.bounds, i.byColumn)
+	}
+
+	i.cIdx, i.rIdx = i.bounds.FromCol, i.bounds.FromRow
+	return i
+}
+
+//storedCells returns non empty cells of sheet inside of bounds in order of rows or columns
+func storedCells(sheet *sheetReadWrite, bounds types.Bounds, byColumn bool) []cellPosition {
+	var cells []cellPosition
+	sheet.walkStoredCells(func(cIdx, rIdx int, data *ml.Cell) {
+		if bounds.Contains(cIdx, rIdx) {
+			cells = append(cells, cellPosition{cIdx: cIdx, rIdx: rIdx, data: data})
+		}
+	})
+
+	sort.SliceStable(cells, func(a, b int) bool {
+		if byColumn && cells[a].cIdx != cells[b].cIdx {
+			return cells[a].cIdx < cells[b].cIdx
+		}
+
+		if cells[a].rIdx != cells[b].rIdx {
+			return cells[a].rIdx < cells[b].rIdx
+		}
+
+		return cells[a].cIdx < cells[b].cIdx
+	})
+
+	return cells
+}
+
+//Next returns next Cell in range and corresponding indexes. Cells that are not stored are returned as empty cells that are not added to the sheet, use Sheet.Cell to get a cell for writing
+func (i *cellIterator) Next() (cIdx int, rIdx int, cell *Cell) {
+	if i.skipEmpty {
+		next := i.cells[i.index]
+		i.index++
+		return next.cIdx, next.rIdx, &Cell{ml: next.data, sheet: i.sheet.sheetInfo}
+	}
+
+	cIdx, rIdx = i.cIdx, i.rIdx
+	mcIdx, mrIdx, _ := i.sheet.mergedCells.Resolve(cIdx, rIdx)
+	data := i.sheet.storedCell(mcIdx, mrIdx)
+	if data == nil {
+		data = &ml.Cell{Ref: types.CellRefFromIndexes(mcIdx, mrIdx)}
+	}
+
+	cell = &Cell{ml: data, sheet: i.sheet.sheetInfo}
+
+	if i.byColumn {
+		if i.rIdx++; i.rIdx > i.bounds.ToRow {
+			i.cIdx, i.rIdx = i.cIdx+1, i.bounds.FromRow
+		}
+	} else {
+		if i.cIdx++; i.cIdx > i.bounds.ToCol {
+			i.cIdx, i.rIdx = i.bounds.FromCol, i.rIdx+1
+		}
+	}
+
+	return
+}
+
+//HasNext returns true if there are cells to iterate or false in other case
+func (i *cellIterator) HasNext() bool {
+	switch {
+	case i.skipEmpty:
+		return i.index < len(i.cells)
+	case i.empty:
+		return false
+	case i.byColumn:
+		return i.cIdx <= i.bounds.ToCol
+	}
+
+	return i.rIdx <= i.bounds.ToRow
+}
+
+//walkStoredCells calls callback for each stored non empty cell of sheet with 0-based indexes. Only stored rows and cells are visited, so grid of sheet is not expanded
+func (s *sheetInfo) walkStoredCells(callback func(cIdx, rIdx int, data *ml.Cell)) {
+	for _, row := range s.ml.SheetData {
+		if row == nil {
+			continue
+		}
+
+		for i, data := range row.Cells {
+			if isCellEmpty(data) {
+				continue
+			}
+
+			//expanded grid holds cells at positions of columns, other rows hold stored cells only
+			cIdx, rIdx := i, row.Ref-1
+			if !s.isInitialized {
+				cIdx, rIdx = data.Ref.ToIndexes()
+			}
+
+			callback(cIdx, rIdx, data)
+		}
+	}
+}
+
+//storedRow returns stored row of sheet with 0-based index without expanding of grid or nil if there is no such row. Grid that was not expanded yet or was shrunk for saving holds stored rows and cells in ascending order
+func (s *sheetInfo) storedRow(rIdx int) *ml.Row {
+	rows := s.ml.SheetData
+	if s.isInitialized {
+		if rIdx < len(rows) {
+			return rows[rIdx]
+		}
+
+		return nil
+	}
+
+	i := sort.Search(len(rows), func(i int) bool { return rows[i] != nil && rows[i].Ref >= rIdx+1 })
+	if i < len(rows) && rows[i].Ref == rIdx+1 {
+		return rows[i]
+	}
+
+	return nil
+}
+
+//walkStoredRow calls callback for each stored non empty cell of row with 0-based index. Grid of sheet is not expanded
+func (s *sheetInfo) walkStoredRow(rIdx int, callback func(cIdx int, data *ml.Cell)) {
+	row := s.storedRow(rIdx)
+	if row == nil {
+		return
+	}
+
+	for i, data := range row.Cells {
+		if isCellEmpty(data) {
+			continue
+		}
+
+		cIdx := i
+		if !s.isInitialized {
+			cIdx, _ = data.Ref.ToIndexes()
+		}
+
+		callback(cIdx, data)
+	}
+}
+
+//storedCell returns stored cell of sheet with 0-based indexes without expanding of grid or nil if there is no such cell
+func (s *sheetInfo) storedCell(cIdx, rIdx int) *ml.Cell {
+	row := s.storedRow(rIdx)
+	if row == nil {
+		return nil
+	}
+
+	cells := row.Cells
+	if s.isInitialized {
+		if cIdx < len(cells) {
+			return cells[cIdx]
+		}
+
+		return nil
+	}
+
+	j := sort.Search(len(cells), func(j int) bool {
+		if cells[j] == nil {
+			return false
+		}
+
+		col, _ := cells[j].Ref.ToIndexes()
+		return col >= cIdx
+	})
+
+	if j < len(cells) && cells[j].Ref == types.CellRefFromIndexes(cIdx, rIdx) {
+		return cells[j]
+	}
+
+	return nil
+}
+
+//storedCellsIndex returns stored non empty cells of sheet by 0-based indexes, so cells can be looked up without expanding of grid and regardless of grid was shrunk for saving or not
+func (s *sheetInfo) storedCellsIndex() map[[2]int]*ml.Cell {
+	cells := make(map[[2]int]*ml.Cell)
+	s.walkStoredCells(func(cIdx, rIdx int, data *ml.Cell) {
+		cells[[2]int{cIdx, rIdx}] = data
+	})
+
+	return cells
+}
+
+//UsedRange returns bounds of stored non empty cells (with value, formula or style) of sheet, regardless of dimension of sheet. Bounds are empty if there are no such cells
+func (s *sheetReadWrite) UsedRange() types.Bounds {
+	used, _ := s.usedRange()
+	return used
+}
+
+//usedRange returns bounds of stored non empty cells of sheet and flag indicating if there are such cells, because bounds of single cell A1 are same as empty bounds
+func (s *sheetReadWrite) usedRange() (types.Bounds, bool) {
+	used := types.Bounds{}
+	found := false
+	s.walkStoredCells(func(cIdx, rIdx int, data *ml.Cell) {
+		if !found {
+			used, found = types.BoundsFromIndexes(cIdx, rIdx, cIdx, rIdx), true
+			return
+		}
+
+		used.FromCol = int(math.Min(float64(used.FromCol), float64(cIdx)))
+		used.FromRow = int(math.Min(float64(used.FromRow), float64(rIdx)))
+		used.ToCol = int(math.Max(float64(used.ToCol), float64(cIdx)))
+		used.ToRow = int(math.Max(float64(used.ToRow), float64(rIdx)))
+	})
+
+	return used, found
+}
+
+//Cells returns iterator for cells of used range of sheet or bounds of options limited by used range. Empty cells can be skipped and cells can be iterated column by column. If options is nil, then default options are used
+func (s *sheetReadWrite) Cells(o *options.IterateOptions) RangeIterator {
+	return newCellIterator(s, o)
+}
@@ -0,0 +1,122 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCellIterator(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Sheet1")
+	require.Equal(t, types.Bounds{}, sheet.UsedRange())
+	require.False(t, sheet.Cells(options.NewIterateOptions(options.Iterate.SkipEmpty(true))).HasNext())
+
+	sheet.CellByRef("B2").SetValue("b2")
+	sheet.CellByRef("D2").SetInt(4)
+	sheet.CellByRef("C4").SetFormatting(xl.AddFormatting(format.NewStyles(format.Font.Bold)))
+	sheet.SetDimension(10, 10)
+	require.Equal(t, types.BoundsFromIndexes(1, 1, 3, 3), sheet.UsedRange())
+
+	collect := func(it RangeIterator) []string {
+		var refs []string
+		for it.HasNext() {
+			cIdx, rIdx, c := it.Next()
+			require.Equal(t, sheet.Cell(cIdx, rIdx).ml, c.ml)
+			refs = append(refs, string(types.CellRefFromIndexes(cIdx, rIdx)))
+		}
+
+		return refs
+	}
+
+	//all cells of used range
+	require.Equal(t, []string{"B2", "C2", "D2", "B3", "C3", "D3", "B4", "C4", "D4"}, collect(sheet.Cells(nil)))
+	require.Equal(t, []string{"B2", "B3", "B4", "C2", "C3", "C4", "D2", "D3", "D4"}, collect(sheet.Cells(options.NewIterateOptions(
+		options.Iterate.ByColumn(true),
+	))))
+
+	//non empty cells only
+	require.Equal(t, []string{"B2", "D2", "C4"}, collect(sheet.Cells(options.NewIterateOptions(
+		options.Iterate.SkipEmpty(true),
+	))))
+	require.Equal(t, []string{"B2", "C4", "D2"}, collect(sheet.Cells(options.NewIterateOptions(
+		options.Iterate.SkipEmpty(true),
+		options.Iterate.ByColumn(true),
+	))))
+
+	//bounded
+	require.Equal(t, []string{"C4", "D2"}, collect(sheet.Cells(options.NewIterateOptions(
+		options.Iterate.SkipEmpty(true),
+		options.Iterate.ByColumn(true),
+		options.Iterate.Range(types.BoundsFromIndexes(2, 0, 20, 20)),
+	))))
+	require.Equal(t, []string{"B2", "C2", "B3", "C3"}, collect(sheet.Cells(options.NewIterateOptions(
+		options.Iterate.Range(types.BoundsFromIndexes(0, 0, 2, 2)),
+	))))
+	require.Nil(t, collect(sheet.Cells(options.NewIterateOptions(
+		options.Iterate.Range(types.BoundsFromIndexes(5, 5, 9, 9)),
+	))))
+	require.Nil(t, collect(sheet.Cells(options.NewIterateOptions(
+		options.Iterate.SkipEmpty(true),
+		options.Iterate.Range(types.BoundsFromIndexes(5, 5, 9, 9)),
+	))))
+}
+
+func TestCellIterator_wholeDimension(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Sheet1")
+	sheet.CellByRef("A1").SetValue("a1")
+	sheet.CellByRef("C3").SetValue("c3")
+
+	//simulate loaded sheet with dimension that covers whole sheet
+	sheet.(*sheetReadWrite).BeforeMarshalXML()
+	sheet.info().ml.Dimension.Bounds = types.BoundsFromIndexes(0, 0, 16383, 1048575)
+
+	require.Equal(t, types.BoundsFromIndexes(0, 0, 2, 2), sheet.UsedRange())
+
+	var values []string
+	for it := sheet.Cells(options.NewIterateOptions(options.Iterate.SkipEmpty(true))); it.HasNext(); {
+		_, _, c := it.Next()
+		values = append(values, c.Value())
+	}
+
+	require.Equal(t, []string{"a1", "c3"}, values)
+
+	//only stored rows and cells were visited, so grid was not expanded
+	require.False(t, sheet.info().isInitialized)
+	require.Equal(t, 2, len(sheet.info().ml.SheetData))
+	require.Equal(t, 1, len(sheet.info().ml.SheetData[1].Cells))
+
+	//all cells of used range without expanding of grid
+	values = nil
+	for it := sheet.Cells(nil); it.HasNext(); {
+		_, _, c := it.Next()
+		values = append(values, c.Value())
+	}
+
+	require.Equal(t, []string{"a1", "", "", "", "", "", "", "", "c3"}, values)
+	require.False(t, sheet.info().isInitialized)
+	require.Equal(t, 2, len(sheet.info().ml.SheetData))
+}
+
+func TestCellIterator_singleCell(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Sheet1")
+	sheet.CellByRef("A1").SetValue("a1")
+
+	var refs []string
+	for it := sheet.Cells(nil); it.HasNext(); {
+		_, _, c := it.Next()
+		refs = append(refs, string(c.ml.Ref))
+	}
+
+	require.Equal(t, []string{"A1"}, refs)
+}
@@ -0,0 +1,107 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/internal/color"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+//BorderInfo is a resolved side of border. Color is in #RGB format with applied theme colors and tints
+type BorderInfo struct {
+	Type  primitives.BorderStyleType
+	Color string
+}
+
+//CellStyle is a resolved style of cell. Colors are in #RGB format with applied theme and indexed colors and tints
+type CellStyle struct {
+	Font         string
+	Size         float64
+	Color        string
+	Bold         bool
+	Italic       bool
+	Strike       bool
+	Underline    primitives.UnderlineType
+	Fill         string
+	Left         BorderInfo
+	Right        BorderInfo
+	Top          BorderInfo
+	Bottom       BorderInfo
+	HAlign       primitives.HAlignType
+	VAlign       primitives.VAlignType
+	WrapText     bool
+	Indent       int
+	Rotation     int
+	NumberFormat string
+}
+
+//fillColor returns #RGB of pattern fill or empty string if there is no fill
+func fillColor(fill *ml.Fill, palette []string) string {
+	if fill == nil || fill.Pattern == nil {
+		return ""
+	}
+
+	if pattern := fill.Pattern.Type.String(); len(pattern) == 0 || pattern == "none" {
+		return ""
+	}
+
+	if rgb := color.ToThemedRGB(fill.Pattern.Color, palette); len(rgb) > 0 {
+		return rgb
+	}
+
+	return color.ToThemedRGB(fill.Pattern.Background, palette)
+}
+
+//borderInfo returns resolved side of border
+func borderInfo(segment *ml.BorderSegment, palette []string) BorderInfo {
+	if segment == nil {
+		return BorderInfo{}
+	}
+
+	return BorderInfo{Type: segment.Type, Color: color.ToThemedRGB(segment.Color, palette)}
+}
+
+//resolveCellStyle returns resolved style for styleID with colors of palette
+func resolveCellStyle(ss *StyleSheet, id ml.DirectStyleID, palette []string) *CellStyle {
+	font, fill, border, alignment := ss.resolveStyle(id)
+	return newCellStyle(font, fill, border, alignment, ss.resolveNumberFormat(id), palette)
+}
+
+//newCellStyle returns style for settings with colors of palette, settings can be nil
+func newCellStyle(font *ml.Font, fill *ml.Fill, border *ml.Border, alignment *ml.CellAlignment, numberFormat string, palette []string) *CellStyle {
+	style := &CellStyle{
+		Fill:         fillColor(fill, palette),
+		NumberFormat: numberFormat,
+	}
+
+	if font != nil {
+		style.Font = string(font.Name)
+		style.Size = float64(font.Size)
+		style.Color = color.ToThemedRGB(font.Color, palette)
+		style.Bold = bool(font.Bold)
+		style.Italic = bool(font.Italic)
+		style.Strike = bool(font.Strike)
+		style.Underline = font.Underline
+	}
+
+	if border != nil {
+		style.Left = borderInfo(border.Left, palette)
+		style.Right = borderInfo(border.Right, palette)
+		style.Top = borderInfo(border.Top, palette)
+		style.Bottom = borderInfo(border.Bottom, palette)
+	}
+
+	if alignment != nil {
+		style.HAlign = alignment.Horizontal
+		style.VAlign = alignment.Vertical
+		style.WrapText = alignment.WrapText
+		style.Indent = alignment.Indent
+		style.Rotation = alignment.TextRotation
+	}
+
+	return style
+}
+
+//Styles returns resolved style of cell, e.g. font, fill, borders, alignment and number format, instead of DirectStyleID
+func (c *Cell) Styles() *CellStyle {
+	return resolveCellStyle(c.sheet.workbook.doc.styleSheet, c.ml.Style, c.sheet.workbook.doc.ThemeColors())
+}
@@ -0,0 +1,48 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/format"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCell_Styles(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Sheet1")
+	sheet.CellByRef("A1").SetFloat(1.5)
+	sheet.CellByRef("A1").SetFormatting(xl.AddFormatting(format.NewStyles(
+		format.Font.Name("Arial"),
+		format.Font.Size(14),
+		format.Font.Bold,
+		format.Font.Color("#FF0000"),
+		format.Fill.Type(format.PatternTypeSolid),
+		format.Fill.Color("#FFFF00"),
+		format.Border.Left.Type(format.BorderStyleThin),
+		format.Border.Left.Color("#0000FF"),
+		format.Alignment.HAlign(format.HAlignCenter),
+		format.Alignment.VAlign(format.VAlignTop),
+		format.Alignment.WrapText,
+		format.NumberFormat("0.00%"),
+	)))
+
+	style := sheet.CellByRef("A1").Styles()
+	require.Equal(t, "Arial", style.Font)
+	require.Equal(t, 14.0, style.Size)
+	require.True(t, style.Bold)
+	require.False(t, style.Italic)
+	require.Equal(t, "#FF0000", style.Color)
+	require.Equal(t, "#FFFF00", style.Fill)
+	require.Equal(t, BorderInfo{Type: format.BorderStyleThin, Color: "#0000FF"}, style.Left)
+	require.Equal(t, BorderInfo{}, style.Right)
+	require.Equal(t, format.HAlignCenter, style.HAlign)
+	require.Equal(t, format.VAlignTop, style.VAlign)
+	require.True(t, style.WrapText)
+	require.Equal(t, "0.00%", style.NumberFormat)
+
+	style = sheet.CellByRef("B1").Styles()
+	require.False(t, style.Bold)
+	require.Equal(t, "", style.Fill)
+	require.Equal(t, "@", style.NumberFormat)
+}
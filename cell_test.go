@@ -1,7 +1,13 @@
 package xlsx
 
 import (
+	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/formula"
+	"github.com/plandem/xlsx/internal/number_format"
 	"github.com/plandem/xlsx/internal/number_format/convert"
+	"github.com/plandem/xlsx/numfmt"
+	"github.com/plandem/xlsx/numfmt/locale"
+	"github.com/plandem/xlsx/types"
 	"github.com/stretchr/testify/require"
 	"testing"
 	"time"
@@ -156,3 +162,200 @@ func TestCell_reopen(t *testing.T) {
 
 	xl.Close()
 }
+
+func TestCell_formula(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Formulas")
+	sheet.CellByRef("A1").SetValue(40)
+	sheet.CellByRef("A2").SetValue(2)
+
+	//invalid cached values
+	require.NotNil(t, sheet.CellByRef("B1").SetFormulaWithValue("A1+A2", "abc", types.CellTypeNumber))
+	require.NotNil(t, sheet.CellByRef("B1").SetFormulaWithValue("A1>A2", 1, types.CellTypeBool))
+	require.NotNil(t, sheet.CellByRef("B1").SetFormulaWithValue("1/0", "#ERR", types.CellTypeError))
+	require.Equal(t, false, sheet.CellByRef("B1").HasFormula())
+
+	require.Nil(t, sheet.CellByRef("B1").SetFormulaWithValue("=A1+A2", 42, types.CellTypeNumber))
+	require.Nil(t, sheet.CellByRef("B2").SetFormulaWithValue("A1>A2", true, types.CellTypeBool))
+	require.Nil(t, sheet.CellByRef("B3").SetFormulaWithValue(`"a"&A2`, "a2", types.CellTypeSharedString))
+	require.Nil(t, sheet.CellByRef("B4").SetFormulaWithValue("A1/0", "#DIV/0!", types.CellTypeError))
+	require.Nil(t, sheet.CellByRef("B5").SetFormulaWithValue("DATE(2018,1,2)", time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC), types.CellTypeDate))
+	require.Equal(t, types.CellTypeNumber, sheet.CellByRef("B5").Type())
+	require.Equal(t, "43102", sheet.CellByRef("B5").Value())
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	require.Equal(t, "A1+A2", sheet.CellByRef("B1").Formula())
+	require.Equal(t, "42", sheet.CellByRef("B1").Value())
+	i, err := sheet.CellByRef("B1").Int()
+	require.Nil(t, err)
+	require.Equal(t, 42, i)
+
+	b, err := sheet.CellByRef("B2").Bool()
+	require.Nil(t, err)
+	require.Equal(t, true, b)
+
+	require.Equal(t, types.CellTypeFormula, sheet.CellByRef("B3").Type())
+	require.Equal(t, "a2", sheet.CellByRef("B3").Value())
+	require.Equal(t, types.CellTypeError, sheet.CellByRef("B4").Type())
+	require.Equal(t, "#DIV/0!", sheet.CellByRef("B4").String())
+
+	//cached value is still available with evaluator
+	xl.SetEvaluator(formula.New())
+	sheet.CellByRef("A1").SetValue(50)
+	require.Equal(t, "52", sheet.CellByRef("B1").Value())
+	require.Equal(t, "42", sheet.CellByRef("B1").CachedValue())
+}
+
+func TestCell_formattedValue(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Report")
+
+	sheet.CellByRef("A1").SetValueWithFormat(1234.5, "#,##0.00")
+	require.Equal(t, "#,##0.00", sheet.CellByRef("A1").NumberFormat())
+	require.Equal(t, "1,234.50", sheet.CellByRef("A1").FormattedValue())
+	require.Equal(t, "1,234.50", sheet.CellByRef("A1").String())
+
+	sheet.CellByRef("A2").SetDate(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.Equal(t, "1-1-20", sheet.CellByRef("A2").FormattedValue())
+
+	sheet.CellByRef("A3").SetBool(true)
+	require.Equal(t, "TRUE", sheet.CellByRef("A3").FormattedValue())
+
+	sheet.CellByRef("A4").SetValue(0.5)
+	require.Equal(t, "0.50", sheet.CellByRef("A4").FormattedValue())
+
+	//custom number formats
+	id := xl.AddNumberFormat(`0.0%`)
+	require.Equal(t, true, id > numberFormat.LastReservedID)
+	require.Equal(t, id, xl.AddNumberFormat(`0.0%`))
+	require.Equal(t, 2, xl.AddNumberFormat(`0.00`))
+
+	sheet.CellByRef("A4").SetFormatting(xl.AddFormatting(format.NewStyles(format.NumberFormatID(id))))
+	require.Equal(t, "50.0%", sheet.CellByRef("A4").FormattedValue())
+	require.Equal(t, 1, len(xl.styleSheet.ml.NumberFormats.Items))
+
+	//locale specific number formats
+	euro := xl.AddNumberFormat(numfmt.Currency("EUR", locale.DE))
+	require.Equal(t, id+1, euro)
+	require.Equal(t, euro, xl.AddNumberFormat(numfmt.Currency("EUR", locale.DE)))
+
+	sheet.CellByRef("A5").SetValue(1234.5)
+	sheet.CellByRef("A5").SetFormatting(xl.AddFormatting(format.NewStyles(format.NumberFormatID(euro))))
+	require.Equal(t, "1,234.50 €", sheet.CellByRef("A5").FormattedValue())
+}
+
+func TestCell_errors(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Errors")
+	require.Nil(t, sheet.CellByRef("A1").SetError(types.ErrorNA))
+	require.NotNil(t, sheet.CellByRef("A2").SetError("#UNKNOWN"))
+	sheet.CellByRef("A3").SetValue(formula.ErrorDivide)
+	sheet.CellByRef("A4").SetValue(true)
+
+	require.Equal(t, types.CellTypeError, sheet.CellByRef("A1").Type())
+	require.Equal(t, "#N/A", sheet.CellByRef("A1").FormattedValue())
+	require.Equal(t, types.CellTypeError, sheet.CellByRef("A3").Type())
+
+	_, err := sheet.CellByRef("A4").Error()
+	require.NotNil(t, err)
+
+	//round trip
+	content, err := xl.Bytes()
+	require.Nil(t, err)
+
+	xl2, err := OpenBytes(content)
+	require.Nil(t, err)
+	defer xl2.Close()
+
+	sheet = xl2.Sheet(0)
+	value, err := sheet.CellByRef("A1").Error()
+	require.Nil(t, err)
+	require.Equal(t, types.ErrorNA, value)
+
+	value, err = sheet.CellByRef("A3").Error()
+	require.Nil(t, err)
+	require.Equal(t, types.ErrorDivide, value)
+
+	b, err := sheet.CellByRef("A4").Bool()
+	require.Nil(t, err)
+	require.Equal(t, true, b)
+	require.Equal(t, types.CellTypeBool, sheet.CellByRef("A4").Type())
+}
+
+func TestCell_typedGetters(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Report")
+
+	sheet.CellByRef("A1").SetInt(12345)
+	sheet.CellByRef("A2").SetFloat(123.5)
+	sheet.CellByRef("A3").SetBool(true)
+	sheet.CellByRef("A4").SetDate(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	sheet.CellByRef("A5").SetValueWithFormat(43831.5, "yyyy-mm-dd hh:mm")
+	sheet.CellByRef("A6").SetValueWithFormat(1.5, "[h]:mm:ss")
+	sheet.CellByRef("A7").SetValue("text")
+	sheet.CellByRef("A8").SetInt(0)
+	sheet.CellByRef("A8").ml.Value = "1E+3"
+
+	i, err := sheet.CellByRef("A1").Int64()
+	require.Nil(t, err)
+	require.Equal(t, int64(12345), i)
+
+	i, err = sheet.CellByRef("A8").Int64()
+	require.Nil(t, err)
+	require.Equal(t, int64(1000), i)
+
+	_, err = sheet.CellByRef("A2").Int64()
+	require.NotNil(t, err)
+
+	f, err := sheet.CellByRef("A2").Float64()
+	require.Nil(t, err)
+	require.Equal(t, 123.5, f)
+
+	//number with date format is not a number
+	_, err = sheet.CellByRef("A5").Float64()
+	require.NotNil(t, err)
+
+	b, err := sheet.CellByRef("A3").Bool()
+	require.Nil(t, err)
+	require.Equal(t, true, b)
+
+	_, err = sheet.CellByRef("A7").Bool()
+	require.NotNil(t, err)
+
+	d, err := sheet.CellByRef("A4").Time()
+	require.Nil(t, err)
+	require.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), d)
+
+	d, err = sheet.CellByRef("A5").Time()
+	require.Nil(t, err)
+	require.Equal(t, time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC), d)
+
+	//number without date format is not a date
+	_, err = sheet.CellByRef("A1").Time()
+	require.NotNil(t, err)
+
+	duration, err := sheet.CellByRef("A6").Duration()
+	require.Nil(t, err)
+	require.Equal(t, 36*time.Hour, duration)
+
+	_, err = sheet.CellByRef("A5").Duration()
+	require.NotNil(t, err)
+
+	_, err = sheet.CellByRef("A7").Float64()
+	require.Equal(t, `type mismatch: value "0" of cell A7 with type "s" and number format "@" can't be used as float64`, err.Error())
+}
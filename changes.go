@@ -0,0 +1,90 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/types"
+	"math"
+)
+
+//ChangeCallback is a callback that is called after changing of value or formula of cell with 0-based indexes col and row
+type ChangeCallback func(col, row int, c *Cell)
+
+type changes struct {
+	sheet    *sheetInfo
+	bounds   types.Bounds
+	callback ChangeCallback
+}
+
+//newChanges creates an object that implements tracking of changed cells
+func newChanges(sheet *sheetInfo) *changes {
+	return &changes{sheet: sheet}
+}
+
+//extend extends bounds of changed cells to include bounds b
+func (ch *changes) extend(b types.Bounds) {
+	if !ch.bounds.IsEmpty() {
+		b = types.BoundsFromIndexes(
+			int(math.Min(float64(b.FromCol), float64(ch.bounds.FromCol))),
+			int(math.Min(float64(b.FromRow), float64(ch.bounds.FromRow))),
+			int(math.Max(float64(b.ToCol), float64(ch.bounds.ToCol))),
+			int(math.Max(float64(b.ToRow), float64(ch.bounds.ToRow))),
+		)
+	}
+
+	ch.bounds = b
+}
+
+//add marks cell c as changed and notifies callback if required
+func (ch *changes) add(c *Cell) {
+	col, row := c.ml.Ref.ToIndexes()
+	ch.extend(types.BoundsFromIndexes(col, row, col, row))
+
+	if ch.callback != nil {
+		ch.callback(col, row, c)
+	}
+}
+
+//shift marks cells that were moved after inserting (n > 0) or deleting (n < 0) of n rows or cols at 0-based index at as changed
+func (ch *changes) shift(at, n int, cols bool) {
+	width, height := ch.sheet.Dimension()
+
+	//N.B.: deleted rows or cols were moved out of dimension, but cells that were there are changed too
+	if n < 0 {
+		if cols {
+			width -= n
+		} else {
+			height -= n
+		}
+	}
+
+	if cols && at < width {
+		ch.extend(types.BoundsFromIndexes(at, 0, width-1, int(math.Max(float64(height-1), 0))))
+	} else if !cols && at < height {
+		ch.extend(types.BoundsFromIndexes(0, at, int(math.Max(float64(width-1), 0)), height-1))
+	}
+
+	//changed bounds must stay inside of limits
+	if !ch.bounds.IsEmpty() {
+		ch.bounds = types.BoundsFromIndexes(
+			ch.bounds.FromCol,
+			ch.bounds.FromRow,
+			int(math.Min(float64(ch.bounds.ToCol), internal.ExcelColumnLimit-1)),
+			int(math.Min(float64(ch.bounds.ToRow), internal.ExcelRowLimit-1)),
+		)
+	}
+}
+
+//DirtyBounds returns bounds of cells with values or formulas that were changed since opening of sheet or last call of ResetDirtyBounds. Bounds are empty if there are no changes
+func (s *sheetInfo) DirtyBounds() types.Bounds {
+	return s.changes.bounds
+}
+
+//ResetDirtyBounds forgets about changed cells, e.g. after syncing of changes with downstream storage
+func (s *sheetInfo) ResetDirtyBounds() {
+	s.changes.bounds = types.Bounds{}
+}
+
+//OnChange sets callback that is called after changing of value or formula of any cell of sheet. Use nil to remove callback. Cells that were moved by inserting, deleting or sorting of rows and cols are not reported, but added to dirty bounds
+func (s *sheetInfo) OnChange(callback ChangeCallback) {
+	s.changes.callback = callback
+}
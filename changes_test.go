@@ -0,0 +1,43 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestChanges(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Data")
+	require.Equal(t, true, sheet.DirtyBounds().IsEmpty())
+
+	var changed []types.CellRef
+	sheet.OnChange(func(col, row int, c *Cell) {
+		require.Equal(t, types.CellRefFromIndexes(col, row), c.ml.Ref)
+		changed = append(changed, c.ml.Ref)
+	})
+
+	sheet.CellByRef("B2").SetValue(1)
+	sheet.CellByRef("D5").SetString("text")
+	require.Nil(t, sheet.CellByRef("C3").SetFormulaWithValue("B2*2", 2, types.CellTypeNumber))
+	require.Equal(t, []types.CellRef{"B2", "D5", "C3"}, changed)
+	require.Equal(t, "B2:D5", sheet.DirtyBounds().String())
+
+	//reading of cells and styling are not changes
+	_ = sheet.CellByRef("F10").Value()
+	sheet.CellByRef("F10").SetFormatting(1)
+	require.Equal(t, "B2:D5", sheet.DirtyBounds().String())
+
+	sheet.ResetDirtyBounds()
+	require.Equal(t, true, sheet.DirtyBounds().IsEmpty())
+
+	sheet.CellByRef("A7").Clear()
+	require.Equal(t, "A7", sheet.DirtyBounds().String())
+
+	//moved cells are marked as changed without notifying
+	sheet.OnChange(nil)
+	sheet.ResetDirtyBounds()
+	sheet.DeleteRows(2, 1)
+	require.Equal(t, "A3:F10", sheet.DirtyBounds().String())
+	require.Equal(t, []types.CellRef{"B2", "D5", "C3", "A7"}, changed)
+}
@@ -0,0 +1,302 @@
+package chart
+
+import (
+	"errors"
+	"fmt"
+	sharedML "github.com/plandem/ooxml/ml"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+	"strings"
+)
+
+//Type is a type to define type of chart
+type Type byte
+
+//List of all possible values for Type
+const (
+	_       Type = iota
+	Bar          //horizontal bars
+	Column       //vertical bars
+	Line         //lines
+	Pie          //pie
+	Scatter      //scatter with markers only
+)
+
+//LegendPosition is a type to define position of legend
+type LegendPosition byte
+
+//List of all possible values for LegendPosition
+const (
+	LegendRight LegendPosition = iota
+	LegendLeft
+	LegendTop
+	LegendBottom
+	LegendNone
+)
+
+//N.B.: ids of axes are used only to link chart with axes, so can be same for all charts
+const (
+	categoryAxisID = 100000001
+	valueAxisID    = 100000002
+)
+
+type series struct {
+	name       string
+	categories types.Ref
+	values     types.Ref
+}
+
+//Info is objects that holds information about chart
+type Info struct {
+	kind   Type
+	title  string
+	legend LegendPosition
+	series []*series
+}
+
+type option func(i *Info)
+
+//New creates and returns a new Info object that holds settings for chart of type t
+func New(t Type, options ...option) *Info {
+	i := &Info{kind: t}
+	i.Set(options...)
+	return i
+}
+
+//Set sets new options for chart
+func (i *Info) Set(options ...option) {
+	for _, o := range options {
+		o(i)
+	}
+}
+
+//Validate validates chart info and return error in case of invalid settings
+func (i *Info) Validate() error {
+	if i.kind < Bar || i.kind > Scatter {
+		return errors.New("unknown type of chart")
+	}
+
+	if len(i.series) == 0 {
+		return errors.New("chart requires at least one series")
+	}
+
+	for idx, s := range i.series {
+		if len(s.values) == 0 {
+			return errors.New(fmt.Sprintf("no values for series #%d", idx))
+		}
+	}
+
+	return nil
+}
+
+//Type returns type of chart
+func (i *Info) Type() Type {
+	return i.kind
+}
+
+//Title returns title of chart
+func (i *Info) Title() string {
+	return i.title
+}
+
+//Title sets title of chart
+func Title(title string) option {
+	return func(i *Info) {
+		i.title = title
+	}
+}
+
+//Legend sets position of legend. Use LegendNone to hide legend
+func Legend(position LegendPosition) option {
+	return func(i *Info) {
+		i.legend = position
+	}
+}
+
+//Series adds a new series with name, categories (or x values for scatter chart) and values. Refs without sheet name will use sheet of chart
+func Series(name string, categories types.Ref, values types.Ref) option {
+	return func(i *Info) {
+		i.series = append(i.series, &series{name: name, categories: categories, values: values})
+	}
+}
+
+//toFormula converts ref into absolute ref of sheet with sheetName, e.g.: A1:B2 => 'Sheet1'!$A$1:$B$2
+func toFormula(ref types.Ref, sheetName string) string {
+	if strings.Contains(string(ref), "!") {
+		return string(ref)
+	}
+
+	return fmt.Sprintf("'%s'!%s", strings.Replace(sheetName, `'`, `''`, -1), ref.ToAbsolute())
+}
+
+//toSeries converts settings of series into ml
+func (i *Info) toSeries(sheetName string) []*ml.ChartSeries {
+	list := make([]*ml.ChartSeries, 0, len(i.series))
+
+	for idx, s := range i.series {
+		item := &ml.ChartSeries{
+			Index: ml.ChartInt{Val: idx},
+			Order: ml.ChartInt{Val: idx},
+		}
+
+		if len(s.name) > 0 {
+			item.Text = &ml.ChartSeriesText{Value: s.name}
+		}
+
+		var categories *ml.ChartDataSource
+		if len(s.categories) > 0 {
+			categories = &ml.ChartDataSource{StringRef: &ml.ChartFormula{Formula: toFormula(s.categories, sheetName)}}
+		}
+
+		values := &ml.ChartNumberSource{NumberRef: &ml.ChartFormula{Formula: toFormula(s.values, sheetName)}}
+
+		if i.kind == Scatter {
+			if categories != nil {
+				categories = &ml.ChartDataSource{NumberRef: categories.StringRef}
+			}
+
+			item.XValues = categories
+			item.YValues = values
+			item.Smooth = &ml.ChartBool{Val: false}
+		} else {
+			item.Categories = categories
+			item.Values = values
+
+			if i.kind == Line {
+				item.Smooth = &ml.ChartBool{Val: false}
+			}
+		}
+
+		list = append(list, item)
+	}
+
+	return list
+}
+
+//toChartSpace converts chart info into ml
+func (i *Info) toChartSpace(sheetName string) *ml.ChartSpace {
+	axisIDs := []*ml.ChartInt{{Val: categoryAxisID}, {Val: valueAxisID}}
+	plotArea := ml.PlotArea{}
+	series := i.toSeries(sheetName)
+
+	switch i.kind {
+	case Bar, Column:
+		direction := "bar"
+		if i.kind == Column {
+			direction = "col"
+		}
+
+		plotArea.BarCharts = []*ml.BarChart{{
+			BarDirection: ml.ChartString{Val: direction},
+			Grouping:     &ml.ChartString{Val: "clustered"},
+			VaryColors:   &ml.ChartBool{Val: false},
+			Series:       series,
+			GapWidth:     &ml.ChartInt{Val: 150},
+			AxisIDs:      axisIDs,
+		}}
+	case Line:
+		plotArea.LineCharts = []*ml.LineChart{{
+			Grouping:   ml.ChartString{Val: "standard"},
+			VaryColors: &ml.ChartBool{Val: false},
+			Series:     series,
+			Marker:     &ml.ChartBool{Val: true},
+			AxisIDs:    axisIDs,
+		}}
+	case Pie:
+		plotArea.PieCharts = []*ml.PieChart{{
+			VaryColors:      &ml.ChartBool{Val: true},
+			Series:          series,
+			FirstSliceAngle: &ml.ChartInt{Val: 0},
+		}}
+	case Scatter:
+		plotArea.ScatterCharts = []*ml.ScatterChart{{
+			ScatterStyle: ml.ChartString{Val: "lineMarker"},
+			VaryColors:   &ml.ChartBool{Val: false},
+			Series:       series,
+			AxisIDs:      axisIDs,
+		}}
+	}
+
+	//N.B.: pie chart has no axes
+	if i.kind == Scatter {
+		plotArea.ValueAxes = []*ml.ChartAxis{
+			toAxis(categoryAxisID, valueAxisID, "b", false),
+			toAxis(valueAxisID, categoryAxisID, "l", false),
+		}
+
+		for _, axis := range plotArea.ValueAxes {
+			axis.CrossBetween.Val = "midCat"
+		}
+	} else if i.kind != Pie {
+		categoryPosition, valuePosition := "b", "l"
+		if i.kind == Bar {
+			categoryPosition, valuePosition = "l", "b"
+		}
+
+		plotArea.CategoryAxes = []*ml.ChartAxis{toAxis(categoryAxisID, valueAxisID, categoryPosition, true)}
+		plotArea.ValueAxes = []*ml.ChartAxis{toAxis(valueAxisID, categoryAxisID, valuePosition, false)}
+	}
+
+	chartSpace := &ml.ChartSpace{
+		RoundedCorners: &ml.ChartBool{Val: false},
+		Chart: ml.Chart{
+			AutoTitleDeleted: &ml.ChartBool{Val: len(i.title) == 0},
+			PlotArea:         plotArea,
+			PlotVisOnly:      &ml.ChartBool{Val: true},
+			DisplayBlanksAs:  &ml.ChartString{Val: "gap"},
+		},
+	}
+
+	if len(i.title) > 0 {
+		chartSpace.Chart.Title = &ml.ChartTitle{
+			Text: &ml.ChartText{
+				Rich: &ml.ChartRichText{
+					Paragraphs: []*ml.TextParagraph{{Runs: []*ml.TextRun{{Text: i.title}}}},
+				},
+			},
+			Overlay: &ml.ChartBool{Val: false},
+		}
+	}
+
+	if i.legend < LegendNone {
+		chartSpace.Chart.Legend = &ml.ChartLegend{
+			Position: &ml.ChartString{Val: [...]string{"r", "l", "t", "b"}[i.legend]},
+			Overlay:  &ml.ChartBool{Val: false},
+		}
+	}
+
+	return chartSpace
+}
+
+//toAxis creates a new axis for chart
+func toAxis(id, crossID int, position string, isCategory bool) *ml.ChartAxis {
+	axis := &ml.ChartAxis{
+		AxisID:       ml.ChartInt{Val: id},
+		Scaling:      ml.ChartScaling{Orientation: &ml.ChartString{Val: "minMax"}},
+		Delete:       &ml.ChartBool{Val: false},
+		AxisPosition: ml.ChartString{Val: position},
+		TickLabelPos: &ml.ChartString{Val: "nextTo"},
+		CrossAxisID:  ml.ChartInt{Val: crossID},
+		Crosses:      &ml.ChartString{Val: "autoZero"},
+	}
+
+	if isCategory {
+		axis.Auto = &ml.ChartBool{Val: true}
+		axis.LabelAlign = &ml.ChartString{Val: "ctr"}
+		axis.LabelOffset = &ml.ChartInt{Val: 100}
+	} else {
+		axis.MajorGridlines = &sharedML.Reserved{}
+		axis.CrossBetween = &ml.ChartString{Val: "between"}
+	}
+
+	return axis
+}
+
+//private method used by drawings manager to unpack Info
+func fromChartInfo(info *Info, sheetName string) (*ml.ChartSpace, error) {
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+
+	return info.toChartSpace(sheetName), nil
+}
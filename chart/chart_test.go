@@ -0,0 +1,70 @@
+package chart
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestChart(t *testing.T) {
+	//invalid settings
+	_, err := fromChartInfo(New(0, Series("", "A1:A5", "B1:B5")), "Sheet1")
+	require.NotNil(t, err)
+
+	_, err = fromChartInfo(New(Bar), "Sheet1")
+	require.NotNil(t, err)
+
+	_, err = fromChartInfo(New(Bar, Series("", "A1:A5", "")), "Sheet1")
+	require.NotNil(t, err)
+
+	//column chart
+	info := New(Column,
+		Title("Sales"),
+		Legend(LegendBottom),
+		Series("2018", "A2:A5", "B2:B5"),
+		Series("2019", "A2:A5", "'Other Sheet'!$C$2:$C$5"),
+	)
+	require.Equal(t, Column, info.Type())
+	require.Equal(t, "Sales", info.Title())
+
+	chartSpace, err := fromChartInfo(info, "Sheet's")
+	require.Nil(t, err)
+	require.Equal(t, 1, len(chartSpace.Chart.PlotArea.BarCharts))
+	require.Equal(t, "col", chartSpace.Chart.PlotArea.BarCharts[0].BarDirection.Val)
+	require.Equal(t, 1, len(chartSpace.Chart.PlotArea.CategoryAxes))
+	require.Equal(t, 1, len(chartSpace.Chart.PlotArea.ValueAxes))
+	require.Equal(t, "b", chartSpace.Chart.Legend.Position.Val)
+	require.Equal(t, "Sales", chartSpace.Chart.Title.Text.Rich.Paragraphs[0].Runs[0].Text)
+
+	series := chartSpace.Chart.PlotArea.BarCharts[0].Series
+	require.Equal(t, 2, len(series))
+	require.Equal(t, &ml.ChartSeries{
+		Index:      ml.ChartInt{Val: 0},
+		Order:      ml.ChartInt{Val: 0},
+		Text:       &ml.ChartSeriesText{Value: "2018"},
+		Categories: &ml.ChartDataSource{StringRef: &ml.ChartFormula{Formula: "'Sheet''s'!$A$2:$A$5"}},
+		Values:     &ml.ChartNumberSource{NumberRef: &ml.ChartFormula{Formula: "'Sheet''s'!$B$2:$B$5"}},
+	}, series[0])
+	require.Equal(t, "'Other Sheet'!$C$2:$C$5", series[1].Values.NumberRef.Formula)
+
+	//pie chart without legend
+	chartSpace, err = fromChartInfo(New(Pie, Legend(LegendNone), Series("", "", "B2:B5")), "Sheet1")
+	require.Nil(t, err)
+	require.Equal(t, 1, len(chartSpace.Chart.PlotArea.PieCharts))
+	require.Nil(t, chartSpace.Chart.Legend)
+	require.Nil(t, chartSpace.Chart.Title)
+	require.Equal(t, 0, len(chartSpace.Chart.PlotArea.CategoryAxes))
+	require.Equal(t, 0, len(chartSpace.Chart.PlotArea.ValueAxes))
+	require.Nil(t, chartSpace.Chart.PlotArea.PieCharts[0].Series[0].Categories)
+
+	//scatter chart
+	chartSpace, err = fromChartInfo(New(Scatter, Series("", "A2:A5", "B2:B5")), "Sheet1")
+	require.Nil(t, err)
+	require.Equal(t, 1, len(chartSpace.Chart.PlotArea.ScatterCharts))
+	require.Equal(t, 2, len(chartSpace.Chart.PlotArea.ValueAxes))
+	scatter := chartSpace.Chart.PlotArea.ScatterCharts[0].Series[0]
+	require.Equal(t, "'Sheet1'!$A$2:$A$5", scatter.XValues.NumberRef.Formula)
+	require.Equal(t, "'Sheet1'!$B$2:$B$5", scatter.YValues.NumberRef.Formula)
+	require.Nil(t, scatter.Categories)
+	require.Nil(t, scatter.Values)
+}
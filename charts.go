@@ -0,0 +1,180 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/plandem/ooxml"
+	sharedML "github.com/plandem/ooxml/ml"
+	"github.com/plandem/xlsx/chart"
+	"github.com/plandem/xlsx/formula"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+	"html"
+	"regexp"
+	"strings"
+	_ "unsafe"
+)
+
+var regExpChart = regexp.MustCompile(`^xl/charts/chart\d+\.xml$`)
+
+var regExpChartFormula = regexp.MustCompile(`(<(?:\w+:)?f>)([^<]*)(</(?:\w+:)?f>)`)
+
+//chartFormulaEscaper escapes formula for XML and keeps quotes as is
+var chartFormulaEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+//go:linkname fromChartInfo github.com/plandem/xlsx/chart.fromChartInfo
+func fromChartInfo(info *chart.Info, sheetName string) (*ml.ChartSpace, error)
+
+//AddChart adds a new chart that fits bounds
+func (d *drawings) AddChart(bounds types.Bounds, info *chart.Info) error {
+	if info == nil {
+		return errors.New("no chart info")
+	}
+
+	if bounds.IsEmpty() {
+		return errors.New("no bounds for chart")
+	}
+
+	chartSpace, err := fromChartInfo(info, d.sheet.Name())
+	if err != nil {
+		return err
+	}
+
+	d.initIfRequired()
+	d.attachRelationshipsIfRequired()
+
+	doc := d.sheet.workbook.doc
+	fileName := doc.uniqueFileName("xl/charts/chart%d.xml")
+	file := ooxml.NewPackageFile(doc.pkg, fileName, chartSpace, nil)
+	file.MarkAsUpdated()
+	d.charts = append(d.charts, chartSpace)
+	doc.pkg.ContentTypes().RegisterContent(fileName, internal.ContentTypeChart)
+	_, rid := d.relationships.AddFile(internal.RelationTypeChart, fileName)
+
+	id := d.nextID()
+	name := info.Title()
+	if len(name) == 0 {
+		name = fmt.Sprintf("Chart %d", id)
+	}
+
+	d.ml.TwoCellAnchors = append(d.ml.TwoCellAnchors, &ml.TwoCellAnchor{
+		From: &ml.DrawingMarker{Col: bounds.FromCol, Row: bounds.FromRow},
+		To:   &ml.DrawingMarker{Col: bounds.ToCol + 1, Row: bounds.ToRow + 1},
+		GraphicFrame: &ml.GraphicFrame{
+			NonVisual: ml.GraphicFrameNonVisual{
+				DrawingProperties:      ml.NonVisualDrawingProperties{ID: id, Name: name},
+				GraphicFrameProperties: &sharedML.Reserved{},
+			},
+			Transform: ml.Transform2D{
+				Offset: &ml.Point2D{},
+				Ext:    &ml.PositiveSize2D{},
+			},
+			Graphic: ml.Graphic{
+				Data: ml.GraphicData{
+					URI:   "http://schemas.openxmlformats.org/drawingml/2006/chart",
+					Chart: &ml.GraphicChart{RID: rid},
+				},
+			},
+		},
+	})
+
+	d.file.MarkAsUpdated()
+	return nil
+}
+
+//chartFormulas returns formulas of all series of chart
+func chartFormulas(chartSpace *ml.ChartSpace) []*ml.ChartFormula {
+	var series []*ml.ChartSeries
+	plotArea := chartSpace.Chart.PlotArea
+	for _, c := range plotArea.BarCharts {
+		series = append(series, c.Series...)
+	}
+
+	for _, c := range plotArea.LineCharts {
+		series = append(series, c.Series...)
+	}
+
+	for _, c := range plotArea.PieCharts {
+		series = append(series, c.Series...)
+	}
+
+	for _, c := range plotArea.ScatterCharts {
+		series = append(series, c.Series...)
+	}
+
+	var refs []*ml.ChartFormula
+	for _, s := range series {
+		if s.Text != nil {
+			refs = append(refs, s.Text.StringRef)
+		}
+
+		for _, source := range []*ml.ChartDataSource{s.Categories, s.XValues} {
+			if source != nil {
+				refs = append(refs, source.NumberRef, source.StringRef)
+			}
+		}
+
+		for _, source := range []*ml.ChartNumberSource{s.Values, s.YValues} {
+			if source != nil {
+				refs = append(refs, source.NumberRef)
+			}
+		}
+	}
+
+	formulas := make([]*ml.ChartFormula, 0, len(refs))
+	for _, f := range refs {
+		if f != nil {
+			formulas = append(formulas, f)
+		}
+	}
+
+	return formulas
+}
+
+//renameCharts updates formulas of series of charts that refer to sheet after renaming it from name from to name to. Existing charts are updated as is without unmarshaling, so unsupported settings of charts are kept
+func (xl *Spreadsheet) renameCharts(from, to string) {
+	for _, sheet := range xl.sheets {
+		if sheet == nil {
+			continue
+		}
+
+		for _, chartSpace := range sheet.drawings.charts {
+			for _, f := range chartFormulas(chartSpace) {
+				f.Formula = formula.RenameSheet(f.Formula, from, to)
+			}
+		}
+	}
+
+	for fileName, file := range xl.pkg.Files() {
+		if !regExpChart.MatchString(fileName) {
+			continue
+		}
+
+		var content []byte
+		switch f := file.(type) {
+		case *zip.File:
+			content = readZipFile(f)
+		case []byte:
+			content = f
+		default:
+			continue
+		}
+
+		updated := regExpChartFormula.ReplaceAllFunc(content, func(match []byte) []byte {
+			m := regExpChartFormula.FindSubmatch(match)
+			value := html.UnescapeString(string(m[2]))
+			if renamed := formula.RenameSheet(value, from, to); renamed != value {
+				return append(append(append([]byte{}, m[1]...), chartFormulaEscaper.Replace(renamed)...), m[3]...)
+			}
+
+			return match
+		})
+
+		if !bytes.Equal(updated, content) {
+			xl.pkg.Add(fileName, updated)
+		}
+	}
+}
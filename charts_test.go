@@ -0,0 +1,59 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/chart"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCharts(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Sheet1")
+
+	//invalid charts
+	require.NotNil(t, sheet.AddChart(types.BoundsFromIndexes(0, 0, 5, 10), nil))
+	require.NotNil(t, sheet.AddChart(types.BoundsFromIndexes(0, 0, 5, 10), chart.New(chart.Bar)))
+	require.Nil(t, sheet.info().ml.Drawing)
+
+	require.Nil(t, sheet.AddChart(types.BoundsFromIndexes(2, 1, 7, 15), chart.New(chart.Line,
+		chart.Title("Sales"),
+		chart.Series("Total", "A2:A5", "B2:B5"),
+	)))
+
+	require.Nil(t, sheet.AddChart(types.BoundsFromIndexes(8, 1, 12, 15), chart.New(chart.Pie,
+		chart.Series("Total", "A2:A5", "B2:B5"),
+	)))
+
+	drawings := sheet.info().drawings
+	require.NotNil(t, sheet.info().ml.Drawing)
+	require.Equal(t, 2, len(drawings.ml.TwoCellAnchors))
+	require.Equal(t, 2, drawings.relationships.Total())
+
+	anchor := drawings.ml.TwoCellAnchors[0]
+	require.Equal(t, 2, anchor.From.Col)
+	require.Equal(t, 1, anchor.From.Row)
+	require.Equal(t, 8, anchor.To.Col)
+	require.Equal(t, 16, anchor.To.Row)
+	require.Equal(t, "Sales", anchor.GraphicFrame.NonVisual.DrawingProperties.Name)
+	require.Equal(t, "Chart 2", drawings.ml.TwoCellAnchors[1].GraphicFrame.NonVisual.DrawingProperties.Name)
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	require.Nil(t, sheet.AddChart(types.BoundsFromIndexes(0, 20, 5, 30), chart.New(chart.Column,
+		chart.Series("Total", "A2:A5", "B2:B5"),
+	)))
+
+	drawings = sheet.info().drawings
+	require.Equal(t, 3, len(drawings.ml.TwoCellAnchors))
+	require.NotNil(t, drawings.ml.TwoCellAnchors[0].GraphicFrame.Graphic.Data.Chart)
+	require.Equal(t, 3, drawings.ml.TwoCellAnchors[2].GraphicFrame.NonVisual.DrawingProperties.ID)
+}
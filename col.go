@@ -10,6 +10,7 @@ import (
 type Col struct {
 	ml *ml.Col
 	*Range
+	styles *resolvedStyles
 }
 
 //Cell returns cell of col at row with rowIndex
@@ -17,17 +18,60 @@ func (c *Col) Cell(rowIndex int) *Cell {
 	return c.sheet.Cell(c.bounds.FromCol, rowIndex)
 }
 
+//StyledCells returns iterator for non empty cells of column with resolved styles and detected kinds of values. Cells covered by merged cells are skipped
+func (c *Col) StyledCells() StyledCellIterator {
+	return newStyledCellIterator(c.Range, c.styles)
+}
+
 //Set sets options for column
 func (c *Col) Set(o *options.ColumnOptions) {
+	setColOptions(c.ml, o)
+}
+
+//Options returns options of column
+func (c *Col) Options() *options.ColumnOptions {
+	return colOptions(c.ml)
+}
+
+//setColOptions applies options to the column's settings
+func setColOptions(data *ml.Col, o *options.ColumnOptions) {
 	if o.Width > 0 {
-		c.ml.Width = o.Width
-		c.ml.CustomWidth = true
+		data.Width = o.Width
+		data.CustomWidth = true
+	}
+
+	if o.Style > 0 {
+		data.Style = o.Style
 	}
 
-	c.ml.OutlineLevel = o.OutlineLevel
-	c.ml.Hidden = o.Hidden
-	c.ml.Collapsed = o.Collapsed
-	c.ml.Phonetic = o.Phonetic
+	data.OutlineLevel = o.OutlineLevel
+	data.Hidden = o.Hidden
+	data.Collapsed = o.Collapsed
+	data.Phonetic = o.Phonetic
+	data.BestFit = o.BestFit
+}
+
+//colOptions returns options for the column's settings
+func colOptions(data *ml.Col) *options.ColumnOptions {
+	return &options.ColumnOptions{
+		OutlineLevel: data.OutlineLevel,
+		Collapsed:    data.Collapsed,
+		Phonetic:     data.Phonetic,
+		Hidden:       data.Hidden,
+		BestFit:      data.BestFit,
+		Width:        data.Width,
+		Style:        data.Style,
+	}
+}
+
+//OutlineLevel returns outline level of column
+func (c *Col) OutlineLevel() uint8 {
+	return c.ml.OutlineLevel
+}
+
+//IsCollapsed returns true if outlining of group that is summarized by column is in the collapsed state
+func (c *Col) IsCollapsed() bool {
+	return c.ml.Collapsed
 }
 
 //Formatting returns DirectStyleID of default format for column
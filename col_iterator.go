@@ -11,9 +11,10 @@ type ColIterator interface {
 
 //colIterator is object that holds required information for common col's iterator
 type colIterator struct {
-	idx   int
-	max   int
-	sheet Sheet
+	idx    int
+	max    int
+	sheet  Sheet
+	styles *resolvedStyles
 }
 
 var _ ColIterator = (*colIterator)(nil)
@@ -21,16 +22,21 @@ var _ ColIterator = (*colIterator)(nil)
 func newColIterator(sheet Sheet) ColIterator {
 	cols, _ := sheet.Dimension()
 	return &colIterator{
-		idx:   -1,
-		max:   cols - 1,
-		sheet: sheet,
+		idx:    -1,
+		max:    cols - 1,
+		sheet:  sheet,
+		styles: &resolvedStyles{},
 	}
 }
 
 //Next returns next Col in sheet and corresponding index
 func (i *colIterator) Next() (int, *Col) {
 	i.idx++
-	return i.idx, i.sheet.Col(i.idx)
+
+	//resolved styles are shared between cols of sheet
+	col := i.sheet.Col(i.idx)
+	col.styles = i.styles
+	return i.idx, col
 }
 
 //HasNext returns true if there are cols to iterate or false in other case
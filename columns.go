@@ -1,6 +1,7 @@
 package xlsx
 
 import (
+	"github.com/plandem/xlsx/internal"
 	"github.com/plandem/xlsx/internal/ml"
 )
 
@@ -68,3 +69,107 @@ func (cols *columns) Delete(index int) {
 		}
 	}
 }
+
+//Shift updates settings of columns after inserting (n > 0) or deleting (n < 0) of n columns at 0-based index at. Inserted columns inside of grouped columns get same settings
+func (cols *columns) Shift(at, n int) {
+	items := make([]*ml.Col, 0, len(cols.sheet.ml.Cols.Items))
+	for _, c := range cols.sheet.ml.Cols.Items {
+		//Cols has 1-based index, but we are using 0-based to unify all indexes at library
+		if from, to, ok := internal.ShiftIndexes(c.Min-1, c.Max-1, at, n); ok && from < internal.ExcelColumnLimit {
+			if to >= internal.ExcelColumnLimit {
+				to = internal.ExcelColumnLimit - 1
+			}
+
+			c.Min, c.Max = from+1, to+1
+			items = append(items, c)
+		}
+	}
+
+	cols.sheet.ml.Cols.Items = items
+}
+
+//Get returns settings of column with index or nil if there is no any settings for column. Unlike Resolve, grouped columns are not split
+func (cols *columns) Get(index int) *ml.Col {
+	var data *ml.Col
+
+	//Cols has 1-based index, but we are using 0-based to unify all indexes at library
+	index++
+
+	for _, c := range cols.sheet.ml.Cols.Items {
+		if index >= c.Min && index <= c.Max {
+			data = c
+
+			//non-grouped column has priority
+			if c.Min == c.Max {
+				break
+			}
+		}
+	}
+
+	return data
+}
+
+//Update updates settings of columns between fromIndex and toIndex via callback, columns with same settings are stored as a single range
+func (cols *columns) Update(fromIndex, toIndex int, update func(c *ml.Col)) {
+	//Cols has 1-based index, but we are using 0-based to unify all indexes at library
+	fromIndex++
+	toIndex++
+
+	//after packing, columns are sorted and not intersected
+	cols.sheet.ml.Cols.Pack()
+
+	items := make([]*ml.Col, 0, len(cols.sheet.ml.Cols.Items)+2)
+	addRange := func(from, to int, data *ml.Col) {
+		c := &ml.Col{}
+		if data != nil {
+			*c = *data
+		}
+
+		c.Min, c.Max = from, to
+		items = append(items, c)
+	}
+
+	next := fromIndex
+	for _, c := range cols.sheet.ml.Cols.Items {
+		if c.Max < fromIndex || c.Min > toIndex {
+			items = append(items, c)
+			continue
+		}
+
+		//split range to parts before, inside and after of updated range
+		if c.Min < fromIndex {
+			addRange(c.Min, fromIndex-1, c)
+		}
+
+		if c.Max > toIndex {
+			addRange(toIndex+1, c.Max, c)
+		}
+
+		min, max := c.Min, c.Max
+		if min < fromIndex {
+			min = fromIndex
+		}
+
+		if max > toIndex {
+			max = toIndex
+		}
+
+		//columns without settings between existing ranges
+		if min > next {
+			addRange(next, min-1, nil)
+			update(items[len(items)-1])
+		}
+
+		addRange(min, max, c)
+		update(items[len(items)-1])
+		next = max + 1
+	}
+
+	if next <= toIndex {
+		addRange(next, toIndex, nil)
+		update(items[len(items)-1])
+	}
+
+	cols.sheet.ml.Cols.Items = items
+	cols.sheet.ml.Cols.Pack()
+}
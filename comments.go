@@ -0,0 +1,585 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/plandem/ooxml"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/plandem/xlsx/types"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	_ "unsafe"
+)
+
+//go:linkname fromCommentInfo github.com/plandem/xlsx/types.fromCommentInfo
+func fromCommentInfo(info *types.CommentInfo) (author string, text []interface{}, visible bool, err error)
+
+//go:linkname toCommentInfo github.com/plandem/xlsx/types.toCommentInfo
+func toCommentInfo(author string, text string, visible bool) *types.CommentInfo
+
+//go:linkname fromCommentThread github.com/plandem/xlsx/types.fromCommentThread
+func fromCommentThread(info *types.CommentInfo) (threaded bool, replies []*types.CommentInfo)
+
+//go:linkname toCommentThread github.com/plandem/xlsx/types.toCommentThread
+func toCommentThread(info *types.CommentInfo, replies []*types.CommentInfo) *types.CommentInfo
+
+//threadedTimeLayout is a layout of time when threaded comment was created
+const threadedTimeLayout = "2006-01-02T15:04:05.00"
+
+var (
+	regExpComments         = regexp.MustCompile(`^xl/comments\d+\.xml$`)
+	regExpThreadedComments = regexp.MustCompile(`^xl/threadedComments/threadedComment\d+\.xml$`)
+	regExpVmlNote          = regexp.MustCompile(`ObjectType\s*=\s*["']Note["']`)
+	regExpVmlShapeNumber   = regexp.MustCompile(`^_x0000_s(\d+)$`)
+)
+
+type comments struct {
+	sheet        *sheetInfo
+	ml           ml.Comments
+	threaded     ml.ThreadedComments
+	vml          ml.VmlDrawing
+	file         *ooxml.PackageFile
+	threadedFile *ooxml.PackageFile
+	vmlFile      *ooxml.PackageFile
+	visible      map[primitives.CellRef]bool
+	refs         map[primitives.CellRef]int
+	shapes       []string
+	shapeTypes   map[string]bool
+	shapeIDs     []int
+	isLoaded     bool
+	isUpdated    bool
+}
+
+//vmlElement is a top level element of legacy drawing
+type vmlElement struct {
+	name    string
+	attrs   map[string]string
+	content string
+}
+
+//newComments creates an object that implements comments functionality
+func newComments(sheet *sheetInfo) *comments {
+	return &comments{
+		sheet:      sheet,
+		visible:    make(map[primitives.CellRef]bool),
+		shapeTypes: make(map[string]bool),
+	}
+}
+
+//loadIfRequired lookups for existing comments and related legacy drawing of sheet and loads it
+func (c *comments) loadIfRequired() {
+	if c.isLoaded {
+		return
+	}
+
+	c.isLoaded = true
+	doc := c.sheet.workbook.doc
+
+	//only existing sheets can have existing comments
+	if c.sheet.file.IsNew() {
+		return
+	}
+
+	c.sheet.attachRelationshipsIfRequired()
+
+	//lookup for comments and threaded comments via relationships of sheet
+	for _, f := range doc.pkg.Files() {
+		if zf, ok := f.(*zip.File); ok && len(c.sheet.relationships.GetIdByTarget(zf.Name)) > 0 {
+			switch {
+			case c.file == nil && regExpComments.MatchString(zf.Name):
+				c.file = ooxml.NewPackageFile(doc.pkg, zf, &c.ml, nil)
+				c.file.LoadIfRequired(nil)
+			case c.threadedFile == nil && regExpThreadedComments.MatchString(zf.Name):
+				c.threadedFile = ooxml.NewPackageFile(doc.pkg, zf, &c.threaded, nil)
+				c.threadedFile.LoadIfRequired(nil)
+			}
+		}
+	}
+
+	//lookup for legacy drawing
+	if c.sheet.ml.LegacyDrawing != nil {
+		fileName := c.sheet.relationships.GetTargetById(string(c.sheet.ml.LegacyDrawing.RID))
+		if zf, ok := doc.pkg.File(fileName).(*zip.File); ok {
+			c.vmlFile = ooxml.NewPackageFile(doc.pkg, zf, &c.vml, nil)
+			c.vmlFile.LoadIfRequired(c.afterLoadVml)
+		}
+	}
+}
+
+//splitVml returns top level elements of legacy drawing
+func splitVml(content string) []vmlElement {
+	var elements []vmlElement
+	var element vmlElement
+	var start int64
+	depth := 0
+
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				start = offset
+				element = vmlElement{name: t.Name.Local, attrs: make(map[string]string)}
+				for _, attr := range t.Attr {
+					element.attrs[attr.Name.Local] = attr.Value
+				}
+			}
+
+			depth++
+		case xml.EndElement:
+			if depth--; depth == 0 {
+				element.content = content[start:decoder.InputOffset()]
+				elements = append(elements, element)
+			}
+		}
+	}
+
+	return elements
+}
+
+//afterLoadVml resolves visibility of existing comments and keeps other shapes of legacy drawing as is. Shapes of comments and layout of shapes are rebuilt on update
+func (c *comments) afterLoadVml() {
+	for _, element := range splitVml(c.vml.InnerXML) {
+		switch {
+		case element.name == "shapelayout":
+		case element.name == "shape" && regExpVmlNote.MatchString(element.content):
+			c.afterLoadNote(element.content)
+		case element.name == "shapetype":
+			c.shapeTypes[element.attrs["id"]] = true
+			c.shapes = append(c.shapes, element.content)
+		default:
+			c.shapes = append(c.shapes, element.content)
+			for _, attr := range []string{"id", "spid"} {
+				if id := regExpVmlShapeNumber.FindStringSubmatch(element.attrs[attr]); id != nil {
+					value, _ := strconv.Atoi(id[1])
+					c.shapeIDs = append(c.shapeIDs, value)
+				}
+			}
+		}
+	}
+}
+
+//afterLoadNote resolves visibility of comment with shape
+func (c *comments) afterLoadNote(shape string) {
+	decoder := xml.NewDecoder(strings.NewReader(shape))
+	var row, col, element string
+	visible := false
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			element = t.Name.Local
+			if element == "ClientData" {
+				row, col, visible = "", "", false
+			} else if element == "Visible" {
+				visible = true
+			}
+		case xml.CharData:
+			if element == "Row" {
+				row = strings.TrimSpace(string(t))
+			} else if element == "Column" {
+				col = strings.TrimSpace(string(t))
+			}
+		case xml.EndElement:
+			element = ""
+			if t.Name.Local == "ClientData" && visible {
+				rIdx, errRow := strconv.Atoi(row)
+				cIdx, errCol := strconv.Atoi(col)
+				if errRow == nil && errCol == nil {
+					c.visible[types.CellRefFromIndexes(cIdx, rIdx)] = true
+				}
+			}
+		}
+	}
+}
+
+//initIfRequired creates a new comments and legacy drawing files if required
+func (c *comments) initIfRequired() {
+	c.loadIfRequired()
+
+	if c.file == nil {
+		doc := c.sheet.workbook.doc
+		fileName := doc.uniqueFileName("xl/comments%d.xml")
+		c.file = ooxml.NewPackageFile(doc.pkg, fileName, &c.ml, nil)
+		doc.pkg.ContentTypes().RegisterContent(fileName, internal.ContentTypeComments)
+		c.sheet.attachRelationshipsIfRequired()
+		c.sheet.relationships.AddFile(internal.RelationTypeComments, fileName)
+	}
+
+	c.initVmlIfRequired()
+}
+
+//initThreadedIfRequired creates a new threaded comments file if required
+func (c *comments) initThreadedIfRequired() {
+	c.loadIfRequired()
+
+	if c.threadedFile == nil {
+		doc := c.sheet.workbook.doc
+		fileName := doc.uniqueFileName("xl/threadedComments/threadedComment%d.xml")
+		c.threadedFile = ooxml.NewPackageFile(doc.pkg, fileName, &c.threaded, nil)
+		doc.pkg.ContentTypes().RegisterContent(fileName, internal.ContentTypeThreadedComments)
+		c.sheet.attachRelationshipsIfRequired()
+		c.sheet.relationships.AddFile(internal.RelationTypeThreadedComments, fileName)
+	}
+}
+
+//initVmlIfRequired creates a new legacy drawing file if required. Legacy drawing is shared by comments, form controls and embedded objects
+func (c *comments) initVmlIfRequired() {
+	c.loadIfRequired()
+
+	if c.vmlFile == nil {
+		doc := c.sheet.workbook.doc
+		fileName := doc.uniqueFileName("xl/drawings/vmlDrawing%d.vml")
+		c.vmlFile = ooxml.NewPackageFile(doc.pkg, fileName, &c.vml, nil)
+		doc.pkg.ContentTypes().RegisterType("vml", internal.ContentTypeVmlDrawing)
+		c.sheet.attachRelationshipsIfRequired()
+		_, rid := c.sheet.relationships.AddFile(internal.RelationTypeVmlDrawing, fileName)
+		c.sheet.ml.LegacyDrawing = &ml.LegacyDrawing{RID: rid}
+	}
+}
+
+//pack refreshes legacy drawing if required, removes comments parts if there are no comments anymore and legacy drawing if there are no comments, form controls, embedded objects and other shapes
+func (c *comments) pack() {
+	if !c.isLoaded {
+		return
+	}
+
+	c.updateIfRequired()
+
+	doc := c.sheet.workbook.doc
+	if c.threadedFile != nil && len(c.threaded.Items) == 0 {
+		fileName := c.threadedFile.FileName()
+		c.sheet.attachRelationshipsIfRequired()
+		c.sheet.relationships.Remove(c.sheet.relationships.GetIdByTarget(fileName))
+		doc.pkg.Remove(fileName)
+		doc.pkg.ContentTypes().Remove(fileName)
+		c.threaded = ml.ThreadedComments{}
+		c.threadedFile = nil
+	}
+
+	if c.file != nil && len(c.ml.CommentList) == 0 {
+		fileName := c.file.FileName()
+		c.sheet.attachRelationshipsIfRequired()
+		c.sheet.relationships.Remove(c.sheet.relationships.GetIdByTarget(fileName))
+		doc.pkg.Remove(fileName)
+		doc.pkg.ContentTypes().Remove(fileName)
+		c.ml = ml.Comments{}
+		c.refs = nil
+		c.file = nil
+	}
+
+	if c.vmlFile != nil && c.file == nil && c.sheet.ml.Controls == nil && c.sheet.ml.OleObjects == nil && len(c.shapes) == len(c.shapeTypes) {
+		fileName := c.vmlFile.FileName()
+		if c.sheet.ml.LegacyDrawing != nil {
+			c.sheet.relationships.Remove(c.sheet.ml.LegacyDrawing.RID)
+			c.sheet.ml.LegacyDrawing = nil
+		}
+
+		doc.pkg.Remove(fileName)
+		doc.pkg.Remove(fmt.Sprintf("xl/drawings/_rels/%s.rels", path.Base(fileName)))
+		c.vml = ml.VmlDrawing{}
+		c.vmlFile = nil
+		c.sheet.file.MarkAsUpdated()
+	}
+}
+
+//authorID returns id of author and adds a new author if required
+func (c *comments) authorID(author string) int {
+	for id, a := range c.ml.Authors {
+		if a == author {
+			return id
+		}
+	}
+
+	c.ml.Authors = append(c.ml.Authors, author)
+	return len(c.ml.Authors) - 1
+}
+
+//Add adds a new comment for cell ref, where comment can be string or CommentInfo
+func (c *comments) Add(ref types.CellRef, comment interface{}) error {
+	//resolve CommentInfo if required
+	var object *types.CommentInfo
+	if text, ok := comment.(string); ok {
+		object = types.NewComment(types.Comment.Text(text))
+	} else if pointer, ok := comment.(*types.CommentInfo); ok {
+		object = pointer
+	} else if value, ok := comment.(types.CommentInfo); ok {
+		object = &value
+	} else {
+		return errors.New("unsupported type of comment, only string or types.CommentInfo is allowed")
+	}
+
+	author, parts, visible, err := fromCommentInfo(object)
+	if err != nil {
+		return err
+	}
+
+	c.initIfRequired()
+	c.removeThread(ref)
+
+	//N.B.: threaded comment has a legacy comment with plain text of thread for older versions
+	if threaded, replies := fromCommentThread(object); threaded {
+		author, parts = "tc="+c.addThread(ref, author, object.String(), replies), []interface{}{threadText(object.String(), replies)}
+	}
+
+	text, err := toRichText(parts...)
+	if err != nil {
+		return err
+	}
+
+	info := &ml.Comment{
+		Ref:      ref,
+		AuthorID: c.authorID(author),
+		Text:     text,
+	}
+
+	//update existing comment or add a new one
+	if c.refs == nil {
+		c.refs = make(map[primitives.CellRef]int, len(c.ml.CommentList))
+		for i, existing := range c.ml.CommentList {
+			c.refs[existing.Ref] = i
+		}
+	}
+
+	if commentIndex, ok := c.refs[ref]; ok {
+		c.ml.CommentList[commentIndex] = info
+	} else {
+		c.refs[ref] = len(c.ml.CommentList)
+		c.ml.CommentList = append(c.ml.CommentList, info)
+	}
+
+	if visible {
+		c.visible[ref] = true
+	} else {
+		delete(c.visible, ref)
+	}
+
+	c.markAsUpdated()
+	return nil
+}
+
+//threadText returns plain text of thread for legacy comment
+func threadText(text string, replies []*types.CommentInfo) string {
+	thread := "[Threaded comment]\n\nComment:\n    " + text
+	for _, reply := range replies {
+		thread += "\nReply:\n    " + reply.String()
+	}
+
+	return thread
+}
+
+//addThread adds threaded comment with replies for cell ref and returns id of thread
+func (c *comments) addThread(ref types.CellRef, author string, text string, replies []*types.CommentInfo) string {
+	c.initThreadedIfRequired()
+
+	persons := c.sheet.workbook.doc.persons
+	created := time.Now().UTC().Format(threadedTimeLayout)
+	id := newGUID()
+
+	c.threaded.Items = append(c.threaded.Items, &ml.ThreadedComment{Ref: ref, DT: created, PersonID: persons.ID(author), ID: id, Text: text})
+	for _, reply := range replies {
+		c.threaded.Items = append(c.threaded.Items, &ml.ThreadedComment{Ref: ref, DT: created, PersonID: persons.ID(reply.Author()), ID: newGUID(), ParentID: id, Text: reply.String()})
+	}
+
+	return id
+}
+
+//removeThread removes threaded comment with replies for cell ref
+func (c *comments) removeThread(ref types.CellRef) {
+	if c.threadedFile == nil {
+		return
+	}
+
+	items := make([]*ml.ThreadedComment, 0, len(c.threaded.Items))
+	for _, item := range c.threaded.Items {
+		if item.Ref != ref {
+			items = append(items, item)
+		}
+	}
+
+	c.threaded.Items = items
+}
+
+//getThread returns a resolved threaded comment info for provided ref or nil if there is no any threaded comment
+func (c *comments) getThread(ref types.CellRef) *types.CommentInfo {
+	persons := c.sheet.workbook.doc.persons
+
+	for _, root := range c.threaded.Items {
+		if root.Ref == ref && len(root.ParentID) == 0 {
+			var replies []*types.CommentInfo
+			for _, item := range c.threaded.Items {
+				if item.ParentID == root.ID {
+					replies = append(replies, toCommentThread(toCommentInfo(persons.Name(item.PersonID), item.Text, false), nil))
+				}
+			}
+
+			return toCommentThread(toCommentInfo(persons.Name(root.PersonID), root.Text, c.visible[ref]), replies)
+		}
+	}
+
+	return nil
+}
+
+//Get returns a resolved comment info for provided ref or nil if there is no any comment
+func (c *comments) Get(ref types.CellRef) *types.CommentInfo {
+	c.loadIfRequired()
+
+	if info := c.getThread(ref); info != nil {
+		return info
+	}
+
+	for _, comment := range c.ml.CommentList {
+		if comment.Ref == ref {
+			var author string
+			if comment.AuthorID >= 0 && comment.AuthorID < len(c.ml.Authors) {
+				author = c.ml.Authors[comment.AuthorID]
+			}
+
+			return toCommentInfo(author, fromRichText(comment.Text), c.visible[ref])
+		}
+	}
+
+	return nil
+}
+
+//Remove removes comment for cell ref
+func (c *comments) Remove(ref types.CellRef) {
+	c.loadIfRequired()
+
+	if c.file == nil || len(c.ml.CommentList) == 0 {
+		return
+	}
+
+	newComments := make([]*ml.Comment, 0, len(c.ml.CommentList))
+	for _, comment := range c.ml.CommentList {
+		if comment.Ref != ref {
+			newComments = append(newComments, comment)
+		}
+	}
+
+	c.ml.CommentList = newComments
+	c.refs = nil
+	c.removeThread(ref)
+	delete(c.visible, ref)
+	c.initIfRequired()
+	c.markAsUpdated()
+}
+
+//writeShapeType adds shape type with id into legacy drawing, if there is no existing shape type with same id
+func (c *comments) writeShapeType(vml *bytes.Buffer, id string, shapeType string) {
+	if !c.shapeTypes[id] {
+		vml.WriteString(shapeType)
+	}
+}
+
+//markAsUpdated marks legacy drawing as required to be refreshed. Legacy drawing is refreshed once before saving, rather than after each change
+func (c *comments) markAsUpdated() {
+	c.isUpdated = true
+}
+
+//updateIfRequired refreshes legacy drawing, list of form controls and list of embedded objects if there were changes
+func (c *comments) updateIfRequired() {
+	if c.isUpdated {
+		c.update()
+	}
+}
+
+//update marks files as updated and refreshes legacy drawing with shapes of comments, form controls and embedded objects. Other existing shapes are kept as is
+func (c *comments) update() {
+	shapes := &bytes.Buffer{}
+	for _, shape := range c.shapes {
+		shapes.WriteString(shape)
+	}
+
+	//ids of new shapes start after ids of existing shapes
+	shapeIdx := c.sheet.index + 1
+	lastID := shapeIdx * 1024
+	for _, id := range c.shapeIDs {
+		if id > lastID {
+			lastID = id
+		}
+	}
+
+	c.writeShapeType(shapes, "_x0000_t202", `<v:shapetype id="_x0000_t202" coordsize="21600,21600" o:spt="202" path="m,l,21600r21600,l21600,xe"><v:stroke joinstyle="miter"/><v:path gradientshapeok="t" o:connecttype="rect"/></v:shapetype>`)
+
+	for i, comment := range c.ml.CommentList {
+		cIdx, rIdx := comment.Ref.ToIndexes()
+		topRow := rIdx
+		if topRow > 0 {
+			topRow--
+		}
+
+		visibility, visibleTag := "hidden", ""
+		if c.visible[comment.Ref] {
+			visibility, visibleTag = "visible", "<x:Visible/>"
+		}
+
+		shapes.WriteString(fmt.Sprintf(`<v:shape id="_x0000_s%d" type="#_x0000_t202" style="position:absolute;margin-left:59.25pt;margin-top:1.5pt;width:108pt;height:59.25pt;z-index:%d;visibility:%s" fillcolor="#ffffe1" o:insetmode="auto">`, lastID+i+1, i+1, visibility))
+		shapes.WriteString(`<v:fill color2="#ffffe1"/><v:shadow on="t" color="black" obscured="t"/><v:path o:connecttype="none"/><v:textbox style="mso-direction-alt:auto"><div style="text-align:left"></div></v:textbox>`)
+		shapes.WriteString(fmt.Sprintf(`<x:ClientData ObjectType="Note"><x:MoveWithCells/><x:SizeWithCells/><x:Anchor>%d, 15, %d, 10, %d, 15, %d, 4</x:Anchor><x:AutoFill>False</x:AutoFill><x:Row>%d</x:Row><x:Column>%d</x:Column>%s</x:ClientData>`, cIdx+1, topRow, cIdx+3, topRow+4, rIdx, cIdx, visibleTag))
+		shapes.WriteString(`</v:shape>`)
+	}
+
+	shapeID := lastID + len(c.ml.CommentList) + 1
+	c.sheet.controls.update(shapes, shapeID)
+	c.sheet.objects.update(shapes, shapeID+len(c.sheet.controls.items))
+
+	//layout of shapes has blocks of ids for all shapes, 1024 ids per block
+	blocks := map[int]bool{shapeIdx: true}
+	for _, id := range c.shapeIDs {
+		blocks[id/1024] = true
+	}
+
+	for id := lastID + 1; id < shapeID+len(c.sheet.controls.items)+len(c.sheet.objects.items); id++ {
+		blocks[id/1024] = true
+	}
+
+	ids := make([]int, 0, len(blocks))
+	for block := range blocks {
+		ids = append(ids, block)
+	}
+
+	sort.Ints(ids)
+	layout := make([]string, len(ids))
+	for i, block := range ids {
+		layout[i] = strconv.Itoa(block)
+	}
+
+	c.vml = ml.VmlDrawing{
+		XmlnsV:   "urn:schemas-microsoft-com:vml",
+		XmlnsO:   "urn:schemas-microsoft-com:office:office",
+		XmlnsX:   "urn:schemas-microsoft-com:office:excel",
+		InnerXML: fmt.Sprintf(`<o:shapelayout v:ext="edit"><o:idmap v:ext="edit" data="%s"/></o:shapelayout>`, strings.Join(layout, ",")) + shapes.String(),
+	}
+
+	if c.file != nil {
+		c.file.MarkAsUpdated()
+	}
+
+	if c.threadedFile != nil {
+		c.threadedFile.MarkAsUpdated()
+	}
+
+	c.vmlFile.MarkAsUpdated()
+	c.isUpdated = false
+}
@@ -0,0 +1,217 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestComments(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Sheet1")
+
+	//add comments
+	require.Nil(t, sheet.CellByRef("A1").SetComment("simple comment"))
+	require.Nil(t, sheet.CellByRef("B2").SetComment(types.NewComment(
+		types.Comment.Author("John Doe"),
+		types.Comment.Text("rich ", format.NewStyles(format.Font.Bold), "comment"),
+		types.Comment.Visible,
+	)))
+	require.NotNil(t, sheet.CellByRef("C3").SetComment(types.NewComment()))
+	require.NotNil(t, sheet.CellByRef("C3").SetComment(100))
+
+	comments := sheet.info().comments
+	require.Equal(t, 2, len(comments.ml.CommentList))
+	require.Equal(t, []string{"", "John Doe"}, comments.ml.Authors)
+	require.NotNil(t, sheet.info().ml.LegacyDrawing)
+
+	//get comments
+	comment := sheet.CellByRef("B2").Comment()
+	require.NotNil(t, comment)
+	require.Equal(t, "John Doe", comment.Author())
+	require.Equal(t, "rich comment", comment.String())
+	require.Equal(t, true, comment.Visible())
+	require.Nil(t, sheet.CellByRef("C3").Comment())
+
+	//update comment
+	require.Nil(t, sheet.CellByRef("A1").SetComment("updated comment"))
+	require.Equal(t, 2, len(comments.ml.CommentList))
+	require.Equal(t, "updated comment", sheet.CellByRef("A1").Comment().String())
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	comment = sheet.CellByRef("B2").Comment()
+	require.NotNil(t, comment)
+	require.Equal(t, "John Doe", comment.Author())
+	require.Equal(t, "rich comment", comment.String())
+	require.Equal(t, true, comment.Visible())
+	require.Equal(t, false, sheet.CellByRef("A1").Comment().Visible())
+
+	//remove comment
+	sheet.CellByRef("A1").RemoveComment()
+	require.Nil(t, sheet.CellByRef("A1").Comment())
+	require.NotNil(t, sheet.CellByRef("B2").Comment())
+}
+
+func TestComments_pack(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Sheet1")
+
+	require.Nil(t, sheet.CellByRef("A1").SetComment("first"))
+	require.Nil(t, sheet.CellByRef("B2").SetComment("second"))
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	comments := sheet.info().comments
+	sheet.CellByRef("A1").RemoveComment()
+	require.Nil(t, xl.beforeSave())
+	require.NotNil(t, comments.file)
+	require.NotNil(t, sheet.info().ml.LegacyDrawing)
+
+	//comments and legacy drawing are removed with last comment
+	commentsName, vmlName := comments.file.FileName(), comments.vmlFile.FileName()
+	sheet.CellByRef("B2").RemoveComment()
+	require.Nil(t, xl.beforeSave())
+	require.Nil(t, comments.file)
+	require.Nil(t, comments.vmlFile)
+	require.Nil(t, sheet.info().ml.LegacyDrawing)
+	require.Equal(t, "", string(sheet.info().relationships.GetIdByTarget(commentsName)))
+	require.Equal(t, "", string(sheet.info().relationships.GetIdByTarget(vmlName)))
+
+	//round trip
+	content, err := xl.Bytes()
+	require.Nil(t, err)
+
+	xl2, err := OpenBytes(content)
+	require.Nil(t, err)
+	defer xl2.Close()
+
+	require.Nil(t, xl2.pkg.File(commentsName))
+	require.Nil(t, xl2.pkg.File(vmlName))
+	require.Nil(t, xl2.Sheet(0).CellByRef("B2").Comment())
+	require.Nil(t, xl2.Sheet(0).info().ml.LegacyDrawing)
+}
+
+func TestComments_update(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Sheet1")
+	comments := sheet.info().comments
+
+	for i := 0; i < 100; i++ {
+		require.Nil(t, sheet.Cell(0, i).SetComment("comment"))
+	}
+
+	//existing comment is updated rather than added
+	require.Nil(t, sheet.Cell(0, 50).SetComment("updated comment"))
+	require.Equal(t, 100, len(comments.ml.CommentList))
+	require.Equal(t, "updated comment", sheet.Cell(0, 50).Comment().String())
+
+	sheet.Cell(0, 0).RemoveComment()
+	require.Nil(t, sheet.Cell(0, 99).SetComment("updated comment"))
+	require.Equal(t, 99, len(comments.ml.CommentList))
+	require.Equal(t, "updated comment", sheet.Cell(0, 99).Comment().String())
+
+	//legacy drawing is refreshed once before saving
+	require.True(t, comments.isUpdated)
+	require.Equal(t, "", comments.vml.InnerXML)
+	require.Nil(t, xl.beforeSave())
+	require.False(t, comments.isUpdated)
+	require.Equal(t, 99, strings.Count(comments.vml.InnerXML, `ObjectType="Note"`))
+}
+
+func TestComments_threaded(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Sheet1")
+
+	require.Nil(t, sheet.CellByRef("A1").SetComment(types.NewComment(
+		types.Comment.Author("John Doe"),
+		types.Comment.Text("threaded comment"),
+		types.Comment.Reply("Jane Doe", "first reply"),
+		types.Comment.Reply("John Doe", "second reply"),
+	)))
+	require.NotNil(t, sheet.CellByRef("B2").SetComment(types.NewComment(
+		types.Comment.Threaded,
+		types.Comment.Text("rich ", format.NewStyles(format.Font.Bold), "comment"),
+	)))
+	require.NotNil(t, sheet.CellByRef("B2").SetComment(types.NewComment(
+		types.Comment.Text("comment"),
+		types.Comment.Reply("Jane Doe", ""),
+	)))
+
+	comments := sheet.info().comments
+	require.NotNil(t, comments.threadedFile)
+	require.Equal(t, 3, len(comments.threaded.Items))
+
+	//legacy comment holds text of thread for previous versions of Excel
+	require.Equal(t, 1, len(comments.ml.CommentList))
+	require.Equal(t, []string{"tc=" + comments.threaded.Items[0].ID}, comments.ml.Authors)
+
+	//existing shapes of legacy drawing are kept
+	comments.shapes = append(comments.shapes, `<v:shape id="_x0000_s3000" type="#_x0000_t75" style="position:absolute"><v:imagedata o:title="picture"/></v:shape>`)
+	comments.shapeIDs = append(comments.shapeIDs, 3000)
+	require.Nil(t, sheet.CellByRef("B2").SetComment("simple comment"))
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	require.NotNil(t, xl.pkg.File("xl/persons/person.xml"))
+
+	sheet = xl.Sheet(0)
+	comment := sheet.CellByRef("A1").Comment()
+	require.NotNil(t, comment)
+	require.Equal(t, true, comment.Threaded())
+	require.Equal(t, "John Doe", comment.Author())
+	require.Equal(t, "threaded comment", comment.String())
+	require.Equal(t, 2, len(comment.Replies()))
+	require.Equal(t, "Jane Doe", comment.Replies()[0].Author())
+	require.Equal(t, "first reply", comment.Replies()[0].String())
+	require.Equal(t, "John Doe", comment.Replies()[1].Author())
+	require.Equal(t, "second reply", comment.Replies()[1].String())
+
+	comment = sheet.CellByRef("B2").Comment()
+	require.NotNil(t, comment)
+	require.Equal(t, false, comment.Threaded())
+	require.Equal(t, "simple comment", comment.String())
+
+	comments = sheet.info().comments
+	require.Equal(t, 2, len(comments.shapes))
+	require.Contains(t, comments.shapes[0], `id="_x0000_s3000"`)
+	require.Contains(t, comments.shapes[1], `id="_x0000_t202"`)
+	require.Equal(t, []int{3000}, comments.shapeIDs)
+
+	//shapes of new comments do not reuse ids of existing shapes
+	require.Nil(t, sheet.CellByRef("C3").SetComment("new comment"))
+	comments.updateIfRequired()
+	require.Contains(t, comments.vml.InnerXML, `id="_x0000_s3001"`)
+
+	//thread is removed with comment
+	sheet.CellByRef("A1").RemoveComment()
+	require.Nil(t, sheet.CellByRef("A1").Comment())
+	require.Equal(t, 0, len(comments.threaded.Items))
+}
@@ -0,0 +1,296 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/internal/hash"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+	"strconv"
+)
+
+//firstCustomNumberFormat is an ID of first custom number format, IDs below are reserved for built-in number formats
+const firstCustomNumberFormat = 164
+
+//compactIndexes returns map of old to new indexes for used items, where new indexes keep order of old indexes
+func compactIndexes(total int, used map[int]bool) map[int]int {
+	indexes := make(map[int]int, len(used))
+	for i := 0; i < total; i++ {
+		if used[i] {
+			indexes[i] = len(indexes)
+		}
+	}
+
+	return indexes
+}
+
+//compact removes strings that are not used by cells of sheets and updates cells with new indexes of strings
+func (ss *SharedStrings) compact(sheets []*sheetInfo) {
+	ss.doc.lock()
+	defer ss.doc.unlock()
+
+	ss.file.LoadIfRequired(ss.afterLoad)
+
+	indexes := make(map[int]int)
+	items := make([]*ml.StringItem, 0, len(ss.ml.StringItem))
+	count := 0
+	for _, sheet := range sheets {
+		for _, row := range sheet.ml.SheetData {
+			if row == nil {
+				continue
+			}
+
+			for _, c := range row.Cells {
+				if c == nil || c.Type != types.CellTypeSharedString {
+					continue
+				}
+
+				sid, err := strconv.Atoi(c.Value)
+				if err != nil || sid < 0 || sid >= len(ss.ml.StringItem) {
+					continue
+				}
+
+				newSid, ok := indexes[sid]
+				if !ok {
+					newSid = len(items)
+					indexes[sid] = newSid
+					items = append(items, ss.ml.StringItem[sid])
+				}
+
+				c.Value = strconv.Itoa(newSid)
+				count++
+			}
+		}
+	}
+
+	ss.ml.StringItem = items
+	ss.ml.Count, ss.ml.UniqueCount = uint(count), uint(len(items))
+	ss.index = make(map[hash.Code]int)
+	ss.afterLoad()
+	ss.file.MarkAsUpdated()
+}
+
+//compact removes direct styles that are not used by cells, rows and columns of sheets, as well as fonts, fills, borders and number formats that are not used by any style. Sheets are updated with new IDs of styles
+func (ss *StyleSheet) compact(sheets []*sheetInfo, sharedStrings *SharedStrings) {
+	ss.doc.lock()
+	defer ss.doc.unlock()
+
+	ss.file.LoadIfRequired(ss.buildIndexes)
+
+	//default style and styles for typed values are always kept
+	used := map[int]bool{int(format.DefaultDirectStyle): true}
+	for _, styleID := range ss.typedStyles {
+		used[int(styleID)] = true
+	}
+
+	for _, sheet := range sheets {
+		if sheet.hyperlinks.defaultStyleID != -1 {
+			used[int(sheet.hyperlinks.defaultStyleID)] = true
+		}
+
+		for _, c := range sheet.ml.Cols.Items {
+			used[int(c.Style)] = true
+		}
+
+		for _, row := range sheet.ml.SheetData {
+			if row == nil {
+				continue
+			}
+
+			used[int(row.Style)] = true
+			for _, c := range row.Cells {
+				if c != nil {
+					used[int(c.Style)] = true
+				}
+			}
+		}
+	}
+
+	//remap direct styles
+	styles := compactIndexes(len(ss.ml.CellXfs.Items), used)
+	cellXfs := make([]*ml.DirectStyle, len(styles))
+	for id, xf := range ss.ml.CellXfs.Items {
+		if newID, ok := styles[id]; ok {
+			cellXfs[newID] = xf
+		}
+	}
+
+	ss.ml.CellXfs.Items = cellXfs
+	for t, styleID := range ss.typedStyles {
+		ss.typedStyles[t] = format.DirectStyleID(styles[int(styleID)])
+	}
+
+	for _, sheet := range sheets {
+		if sheet.hyperlinks.defaultStyleID != -1 {
+			sheet.hyperlinks.defaultStyleID = format.DirectStyleID(styles[int(sheet.hyperlinks.defaultStyleID)])
+		}
+
+		for _, c := range sheet.ml.Cols.Items {
+			c.Style = ml.DirectStyleID(styles[int(c.Style)])
+		}
+
+		for _, row := range sheet.ml.SheetData {
+			if row == nil {
+				continue
+			}
+
+			row.Style = ml.DirectStyleID(styles[int(row.Style)])
+			for _, c := range row.Cells {
+				if c != nil {
+					c.Style = ml.DirectStyleID(styles[int(c.Style)])
+				}
+			}
+		}
+
+		sheet.file.MarkAsUpdated()
+	}
+
+	//collect fonts, fills, borders and number formats that are used by direct and named styles
+	list := make([]*ml.Style, 0, len(ss.ml.CellXfs.Items)+len(ss.ml.CellStyleXfs.Items))
+	for _, xf := range ss.ml.CellXfs.Items {
+		list = append(list, &xf.Style)
+	}
+
+	for _, xf := range ss.ml.CellStyleXfs.Items {
+		list = append(list, (*ml.Style)(xf))
+	}
+
+	usedFonts, usedFills, usedBorders, usedNumbers := map[int]bool{0: true}, map[int]bool{0: true, 1: true}, map[int]bool{0: true}, make(map[int]bool)
+	for _, style := range list {
+		usedFonts[style.FontId] = true
+		usedFills[style.FillId] = true
+		usedBorders[style.BorderId] = true
+		usedNumbers[style.NumFmtId] = true
+	}
+
+	for _, dxf := range ss.ml.Dxfs.Items {
+		if dxf.NumberFormat != nil {
+			usedNumbers[dxf.NumberFormat.ID] = true
+		}
+	}
+
+	//fonts can be used by phonetic properties of strings too. N.B.: inline strings can share properties with shared strings
+	phonetics := make(map[*ml.PhoneticProperties]bool)
+	if sharedStrings != nil {
+		sharedStrings.file.LoadIfRequired(sharedStrings.afterLoad)
+		for _, si := range sharedStrings.ml.StringItem {
+			if si.PhoneticPr != nil {
+				phonetics[si.PhoneticPr] = true
+			}
+		}
+	}
+
+	for _, sheet := range sheets {
+		for _, row := range sheet.ml.SheetData {
+			if row == nil {
+				continue
+			}
+
+			for _, c := range row.Cells {
+				if c != nil && c.InlineStr != nil && c.InlineStr.PhoneticPr != nil {
+					phonetics[c.InlineStr.PhoneticPr] = true
+				}
+			}
+		}
+	}
+
+	for phonetic := range phonetics {
+		usedFonts[phonetic.FontID] = true
+	}
+
+	//remap fonts, fills and borders
+	fonts := compactIndexes(len(ss.ml.Fonts.Items), usedFonts)
+	fontItems := make([]*ml.Font, len(fonts))
+	for id, font := range ss.ml.Fonts.Items {
+		if newID, ok := fonts[id]; ok {
+			fontItems[newID] = font
+		}
+	}
+
+	fills := compactIndexes(len(ss.ml.Fills.Items), usedFills)
+	fillItems := make([]*ml.Fill, len(fills))
+	for id, fill := range ss.ml.Fills.Items {
+		if newID, ok := fills[id]; ok {
+			fillItems[newID] = fill
+		}
+	}
+
+	borders := compactIndexes(len(ss.ml.Borders.Items), usedBorders)
+	borderItems := make([]*ml.Border, len(borders))
+	for id, border := range ss.ml.Borders.Items {
+		if newID, ok := borders[id]; ok {
+			borderItems[newID] = border
+		}
+	}
+
+	ss.ml.Fonts.Items, ss.ml.Fills.Items, ss.ml.Borders.Items = fontItems, fillItems, borderItems
+	for _, style := range list {
+		style.FontId, style.FillId, style.BorderId = fonts[style.FontId], fills[style.FillId], borders[style.BorderId]
+	}
+
+	for phonetic := range phonetics {
+		phonetic.FontID = fonts[phonetic.FontID]
+	}
+
+	if sharedStrings != nil && len(phonetics) > 0 {
+		sharedStrings.file.MarkAsUpdated()
+	}
+
+	//remove custom number formats, IDs of number formats are kept as is
+	numbers := make([]*ml.NumberFormat, 0, len(ss.ml.NumberFormats.Items))
+	for _, number := range ss.ml.NumberFormats.Items {
+		if number.ID < firstCustomNumberFormat || usedNumbers[number.ID] {
+			numbers = append(numbers, number)
+		}
+	}
+
+	ss.ml.NumberFormats.Items = numbers
+
+	//rebuild indexes
+	ss.directStyleIndex = make(map[hash.Code]format.DirectStyleID)
+	ss.namedStyleIndex = make(map[hash.Code]format.NamedStyleID)
+	ss.diffStyleIndex = make(map[hash.Code]format.DiffStyleID)
+	ss.borderIndex = make(map[hash.Code]int)
+	ss.fillIndex = make(map[hash.Code]int)
+	ss.fontIndex = make(map[hash.Code]int)
+	ss.numberIndex = make(map[hash.Code]int)
+	ss.buildIndexes()
+	ss.file.MarkAsUpdated()
+}
+
+//Compact removes styles, fonts, fills, borders and custom number formats that are not used by cells, rows and columns, as well as shared strings that are not used by cells. All sheets are opened in normal mode for it
+//N.B.: IDs of styles that were added before become invalid, so styles must be added again after compacting. Sheets that were opened in disk cache or stream mode are not supported
+func (xl *Spreadsheet) Compact() error {
+	for _, sheet := range xl.sheets {
+		if (sheet.sheetMode & SheetModeDiskCache) != 0 {
+			return errors.New(fmt.Sprintf("sheet '%s' was opened in disk cache mode, compacting is not supported", sheet.Name()))
+		}
+
+		//stream reads IDs of styles and shared strings from the source, so these IDs must not be changed
+		if sheet.isStreamed {
+			return errors.New(fmt.Sprintf("sheet '%s' was opened in stream mode, compacting is not supported", sheet.Name()))
+		}
+	}
+
+	sheets := make([]*sheetInfo, len(xl.sheets))
+	for i := range xl.sheets {
+		xl.Sheet(i)
+		sheets[i] = xl.sheets[i]
+	}
+
+	//all rows of sheets must be in memory, so references to styles and shared strings can be updated
+	for _, sheet := range sheets {
+		if _, ok := sheet.sheet.(*sheetReadWrite); !ok {
+			return errors.New(fmt.Sprintf("sheet '%s' is not fully loaded, compacting is not supported", sheet.Name()))
+		}
+	}
+
+	//shared strings must be compacted first, so fonts of phonetic properties of removed strings are removed too
+	if xl.sharedStrings != nil {
+		xl.sharedStrings.compact(sheets)
+	}
+
+	xl.styleSheet.compact(sheets, xl.sharedStrings)
+	return nil
+}
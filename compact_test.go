@@ -0,0 +1,83 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/format"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCompact(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Sheet1")
+
+	unused := xl.AddFormatting(format.NewStyles(
+		format.Font.Italic,
+		format.Fill.Solid("#FF6600"),
+		format.NumberFormat("0.000%"),
+	))
+
+	bold := xl.AddFormatting(format.NewStyles(format.Font.Bold))
+	require.True(t, unused < bold)
+
+	sheet.CellByRef("A1").SetValue("one")
+	sheet.CellByRef("A1").SetFormatting(bold)
+	sheet.CellByRef("A2").SetValue("two")
+	sheet.CellByRef("A2").SetValue("three")
+	sheet.Col(1).SetFormatting(bold)
+
+	ss := xl.styleSheet
+	styles, fonts, fills, numbers := len(ss.ml.CellXfs.Items), len(ss.ml.Fonts.Items), len(ss.ml.Fills.Items), len(ss.ml.NumberFormats.Items)
+	require.Equal(t, 3, len(xl.sharedStrings.ml.StringItem))
+
+	require.Nil(t, xl.Compact())
+	require.Equal(t, styles-1, len(ss.ml.CellXfs.Items))
+	require.Equal(t, fonts-1, len(ss.ml.Fonts.Items))
+	require.Equal(t, fills-1, len(ss.ml.Fills.Items))
+	require.Equal(t, numbers-1, len(ss.ml.NumberFormats.Items))
+	require.Equal(t, 2, len(xl.sharedStrings.ml.StringItem))
+
+	//references are updated
+	require.Equal(t, bold-1, sheet.CellByRef("A1").Formatting())
+	require.Equal(t, bold-1, sheet.Col(1).Formatting())
+	require.Equal(t, true, sheet.CellByRef("A1").Styles().Bold)
+	require.Equal(t, "one", sheet.CellByRef("A1").Value())
+	require.Equal(t, "three", sheet.CellByRef("A2").Value())
+
+	//new styles and strings can be added as usual
+	require.Equal(t, bold-1, xl.AddFormatting(format.NewStyles(format.Font.Bold)))
+	require.Equal(t, format.DirectStyleID(styles-1), xl.AddFormatting(format.NewStyles(format.Font.Italic)))
+	sheet.CellByRef("A3").SetValue("one")
+	require.Equal(t, "0", sheet.CellByRef("A3").ml.Value)
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	require.Equal(t, true, sheet.CellByRef("A1").Styles().Bold)
+	require.Equal(t, "three", sheet.CellByRef("A2").Value())
+
+	//disk cache mode is not supported
+	xl2, err := Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl2.Close()
+
+	sheet = xl2.Sheet(0, SheetModeDiskCache)
+	defer sheet.Close()
+	require.NotNil(t, xl2.Compact())
+
+	//stream mode is not supported
+	xl3, err := Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl3.Close()
+
+	sheet = xl3.Sheet(0, SheetModeStream)
+	defer sheet.Close()
+	require.NotNil(t, xl3.Compact())
+	require.Equal(t, "three", sheet.CellByRef("A2").Value())
+}
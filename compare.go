@@ -0,0 +1,194 @@
+package xlsx
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/types"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//DiffKind is a type of difference between workbooks
+type DiffKind byte
+
+//List of all possible values for DiffKind
+const (
+	DiffSheetAdded   DiffKind = iota //sheet exists only in the second workbook
+	DiffSheetRemoved                 //sheet exists only in the first workbook
+	DiffValue                        //value or type of value of cell was changed
+	DiffFormula                      //formula of cell was changed
+	DiffStyle                        //resolved style of cell was changed
+)
+
+//Difference is information about a single difference between workbooks. Ref is empty for differences of sheets
+type Difference struct {
+	Kind  DiffKind
+	Sheet string
+	Ref   types.CellRef
+	Old   string
+	New   string
+}
+
+//String returns human readable representation of difference, e.g.: Sheet1!A1: value "1" -> "2"
+func (d Difference) String() string {
+	switch d.Kind {
+	case DiffSheetAdded:
+		return fmt.Sprintf("%s: sheet added", d.Sheet)
+	case DiffSheetRemoved:
+		return fmt.Sprintf("%s: sheet removed", d.Sheet)
+	case DiffFormula:
+		return fmt.Sprintf("%s!%s: formula %q -> %q", d.Sheet, d.Ref, d.Old, d.New)
+	case DiffStyle:
+		return fmt.Sprintf("%s!%s: style changed", d.Sheet, d.Ref)
+	}
+
+	return fmt.Sprintf("%s!%s: value %q -> %q", d.Sheet, d.Ref, d.Old, d.New)
+}
+
+//compareCell returns cell of sheet for stored data, empty cell is returned if there is no stored data
+func compareCell(s *sheetInfo, data *ml.Cell) *Cell {
+	if data == nil {
+		data = &ml.Cell{}
+	}
+
+	return &Cell{ml: data, sheet: s}
+}
+
+//compareType returns normalized type of cell, so strings of any kind are same type
+func compareType(c *Cell) types.CellType {
+	switch c.ml.Type {
+	case types.CellTypeSharedString, types.CellTypeInlineString, types.CellTypeFormula:
+		return types.CellTypeSharedString
+	case types.CellTypeGeneral:
+		return types.CellTypeNumber
+	}
+
+	return c.ml.Type
+}
+
+//compareValues returns true if values of cells are equal. Numbers are compared as numbers, e.g.: 1.0 and 1 are equal
+func compareValues(a, b *Cell) bool {
+	va, vb := a.Value(), b.Value()
+	if len(va) == 0 && len(vb) == 0 {
+		return true
+	}
+
+	if compareType(a) != compareType(b) {
+		return false
+	}
+
+	if va == vb {
+		return true
+	}
+
+	if compareType(a) == types.CellTypeNumber {
+		fa, errA := strconv.ParseFloat(va, 64)
+		fb, errB := strconv.ParseFloat(vb, 64)
+		return errA == nil && errB == nil && fa == fb
+	}
+
+	return false
+}
+
+//styleSignatures is a cache of signatures of resolved styles by id for a workbook
+type styleSignatures map[ml.DirectStyleID]string
+
+//get returns signature of resolved style of cell, so styles of different workbooks can be compared
+func (signatures styleSignatures) get(c *Cell) string {
+	if signature, ok := signatures[c.ml.Style]; ok {
+		return signature
+	}
+
+	font, fill, border, alignment := c.sheet.workbook.doc.styleSheet.resolveStyle(c.ml.Style)
+	signature := []string{c.NumberFormat()}
+	for _, part := range []interface{}{font, fill, border, alignment} {
+		encoded, _ := xml.Marshal(part)
+		signature = append(signature, string(encoded))
+	}
+
+	signatures[c.ml.Style] = strings.Join(signature, "|")
+	return signatures[c.ml.Style]
+}
+
+//compareSheets returns differences of cells between sheets a and b. Only stored cells of sheets are compared, because other cells are empty at both sheets
+func compareSheets(name string, a, b *sheetInfo, o *options.CompareOptions, stylesA, stylesB styleSignatures) []Difference {
+	cellsA, cellsB := a.storedCellsIndex(), b.storedCellsIndex()
+	indexes := make([][2]int, 0, len(cellsA)+len(cellsB))
+	for index := range cellsA {
+		indexes = append(indexes, index)
+	}
+
+	for index := range cellsB {
+		if _, ok := cellsA[index]; !ok {
+			indexes = append(indexes, index)
+		}
+	}
+
+	sort.Slice(indexes, func(i, j int) bool {
+		if indexes[i][1] != indexes[j][1] {
+			return indexes[i][1] < indexes[j][1]
+		}
+
+		return indexes[i][0] < indexes[j][0]
+	})
+
+	var diff []Difference
+	for _, index := range indexes {
+		ca, cb := compareCell(a, cellsA[index]), compareCell(b, cellsB[index])
+		ref := types.CellRefFromIndexes(index[0], index[1])
+
+		if !compareValues(ca, cb) {
+			diff = append(diff, Difference{Kind: DiffValue, Sheet: name, Ref: ref, Old: ca.Value(), New: cb.Value()})
+		}
+
+		if !o.IgnoreFormulas {
+			if fa, fb := ca.Formula(), cb.Formula(); fa != fb {
+				diff = append(diff, Difference{Kind: DiffFormula, Sheet: name, Ref: ref, Old: fa, New: fb})
+			}
+		}
+
+		if !o.IgnoreStyles && stylesA.get(ca) != stylesB.get(cb) {
+			diff = append(diff, Difference{Kind: DiffStyle, Sheet: name, Ref: ref})
+		}
+	}
+
+	return diff
+}
+
+//Compare returns differences of values, formulas and styles of cells between workbooks a and b, sheets are matched by names. Styles are compared by resolved settings, so same styles of workbooks with different ids are equal. If options is nil, then default options are used
+func Compare(a, b *Spreadsheet, o *options.CompareOptions) []Difference {
+	if o == nil {
+		o = options.NewCompareOptions()
+	}
+
+	names := o.Sheets
+	if len(names) == 0 {
+		names = a.GetSheetNames()
+		for _, name := range b.GetSheetNames() {
+			if a.sheetByName(name) == nil {
+				names = append(names, name)
+			}
+		}
+	}
+
+	var diff []Difference
+	stylesA, stylesB := make(styleSignatures), make(styleSignatures)
+	for _, name := range names {
+		sa, sb := a.sheetByName(name), b.sheetByName(name)
+		switch {
+		case sa == nil && sb == nil:
+			continue
+		case sa == nil:
+			diff = append(diff, Difference{Kind: DiffSheetAdded, Sheet: name})
+		case sb == nil:
+			diff = append(diff, Difference{Kind: DiffSheetRemoved, Sheet: name})
+		default:
+			diff = append(diff, compareSheets(name, sa, sb, o, stylesA, stylesB)...)
+		}
+	}
+
+	return diff
+}
@@ -0,0 +1,87 @@
+package xlsx
+
+import (
+	"bytes"
+	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/options"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	a := New()
+	defer a.Close()
+
+	b := New()
+	defer b.Close()
+
+	sheetA := a.AddSheet("Report")
+	sheetA.CellByRef("A1").SetValue("Total")
+	sheetA.CellByRef("B1").SetFloat(10)
+	sheetA.CellByRef("C1").SetFormula("B1*2")
+	a.AddSheet("Removed")
+
+	sheetB := b.AddSheet("Report")
+	sheetB.CellByRef("A1").SetValue("Total")
+	sheetB.CellByRef("B1").SetFloat(10)
+	sheetB.CellByRef("C1").SetFormula("B1*3")
+	sheetB.CellByRef("A2").SetValue("Note")
+	b.AddSheet("Added")
+
+	require.Nil(t, Compare(a, a, nil))
+
+	diff := Compare(a, b, nil)
+	require.Equal(t, []Difference{
+		{Kind: DiffFormula, Sheet: "Report", Ref: "C1", Old: "B1*2", New: "B1*3"},
+		{Kind: DiffValue, Sheet: "Report", Ref: "A2", Old: "", New: "Note"},
+		{Kind: DiffSheetRemoved, Sheet: "Removed"},
+		{Kind: DiffSheetAdded, Sheet: "Added"},
+	}, diff)
+	require.Equal(t, `Report!C1: formula "B1*2" -> "B1*3"`, diff[0].String())
+	require.Equal(t, "Removed: sheet removed", diff[2].String())
+
+	//same styles with different ids are equal
+	b.AddFormatting(format.NewStyles(format.Font.Italic))
+	sheetA.CellByRef("A1").SetFormatting(a.AddFormatting(format.NewStyles(format.Font.Bold)))
+	sheetB.CellByRef("A1").SetFormatting(b.AddFormatting(format.NewStyles(format.Font.Bold)))
+	sheetB.CellByRef("B1").SetFormatting(b.AddFormatting(format.NewStyles(format.Font.Italic)))
+
+	diff = Compare(a, b, options.NewCompareOptions(
+		options.Compare.IgnoreFormulas(true),
+		options.Compare.Sheets("Report"),
+	))
+	require.Equal(t, []Difference{
+		{Kind: DiffStyle, Sheet: "Report", Ref: "B1"},
+		{Kind: DiffValue, Sheet: "Report", Ref: "A2", Old: "", New: "Note"},
+	}, diff)
+
+	diff = Compare(a, b, options.NewCompareOptions(
+		options.Compare.IgnoreFormulas(true),
+		options.Compare.IgnoreStyles(true),
+		options.Compare.Sheets("Report"),
+	))
+	require.Equal(t, []Difference{
+		{Kind: DiffValue, Sheet: "Report", Ref: "A2", Old: "", New: "Note"},
+	}, diff)
+}
+
+func TestCompareSaved(t *testing.T) {
+	a := New()
+	defer a.Close()
+
+	b := New()
+	defer b.Close()
+
+	a.AddSheet("S").CellByRef("C5").SetValue("x")
+	b.AddSheet("S").CellByRef("C5").SetValue("x")
+	require.Nil(t, Compare(a, b, nil))
+
+	//grid of sheet is shrunk after saving
+	require.Nil(t, a.SaveAs(&bytes.Buffer{}))
+	require.Nil(t, Compare(a, b, nil))
+
+	b.Sheet(0).CellByRef("C5").SetValue("y")
+	require.Equal(t, []Difference{
+		{Kind: DiffValue, Sheet: "S", Ref: "C5", Old: "x", New: "y"},
+	}, Compare(a, b, nil))
+}
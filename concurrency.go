@@ -0,0 +1,30 @@
+package xlsx
+
+import (
+	"sync"
+)
+
+//SetConcurrent enables or disables concurrent access mode. In concurrent mode shared strings and styles of document are guarded by lock, so different sheets can be written by different goroutines at same time.
+//Each sheet must be used by one goroutine only, and structural changes of document (e.g.: adding of sheets, images, comments, tables, charts or defined names) must be done before or after concurrent access.
+//Mode must be changed when document is not used by other goroutines.
+func (xl *Spreadsheet) SetConcurrent(enabled bool) {
+	if enabled {
+		xl.mu = &sync.Mutex{}
+	} else {
+		xl.mu = nil
+	}
+}
+
+//lock locks shared data of document if concurrent access mode is enabled
+func (xl *Spreadsheet) lock() {
+	if xl.mu != nil {
+		xl.mu.Lock()
+	}
+}
+
+//unlock unlocks shared data of document if concurrent access mode is enabled
+func (xl *Spreadsheet) unlock() {
+	if xl.mu != nil {
+		xl.mu.Unlock()
+	}
+}
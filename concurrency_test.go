@@ -0,0 +1,53 @@
+package xlsx
+
+import (
+	"fmt"
+	"github.com/plandem/xlsx/format"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+)
+
+func TestSetConcurrent(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	xl.SetConcurrent(true)
+
+	const sheetsTotal = 4
+	sheets := make([]Sheet, sheetsTotal)
+	for i := range sheets {
+		sheets[i] = xl.AddSheet(fmt.Sprintf("Concurrent%d", i))
+	}
+
+	wg := sync.WaitGroup{}
+	for i, sheet := range sheets {
+		wg.Add(1)
+		go func(i int, sheet Sheet) {
+			defer wg.Done()
+			styleID := xl.AddFormatting(format.NewStyles(format.Font.Size(float64(10 + i))))
+			for rIdx := 0; rIdx < 100; rIdx++ {
+				sheet.Cell(0, rIdx).SetValue(fmt.Sprintf("value %d", rIdx))
+				sheet.Cell(1, rIdx).SetValue(fmt.Sprintf("sheet %d, value %d", i, rIdx))
+				sheet.Cell(1, rIdx).SetFormatting(styleID)
+			}
+		}(i, sheet)
+	}
+
+	wg.Wait()
+
+	for i, sheet := range sheets {
+		for rIdx := 0; rIdx < 100; rIdx++ {
+			require.Equal(t, fmt.Sprintf("value %d", rIdx), sheet.Cell(0, rIdx).Value())
+			require.Equal(t, fmt.Sprintf("sheet %d, value %d", i, rIdx), sheet.Cell(1, rIdx).Value())
+		}
+
+		require.Equal(t, float64(10+i), float64(xl.styleSheet.resolveFont(sheet.Cell(1, 0).Formatting()).Size))
+	}
+
+	//shared strings are not duplicated
+	require.Equal(t, 100+100*sheetsTotal, len(xl.sharedStrings.ml.StringItem))
+
+	xl.SetConcurrent(false)
+	require.Nil(t, xl.mu)
+}
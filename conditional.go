@@ -1,14 +1,58 @@
 package xlsx
 
 import (
+	"crypto/rand"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	sharedML "github.com/plandem/ooxml/ml"
 	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/internal/color"
 	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/plandem/xlsx/internal/number_format"
 	"github.com/plandem/xlsx/types"
 	_ "unsafe"
 )
 
 //go:linkname fromConditionalFormat github.com/plandem/xlsx/format.fromConditionalFormat
-func fromConditionalFormat(f *format.ConditionalFormat) (*ml.ConditionalFormatting, []*format.StyleFormat)
+func fromConditionalFormat(f *format.ConditionalFormat) (*ml.ConditionalFormatting, []*format.StyleFormat, []*ml.X14ConditionalRule)
+
+//ConditionValueInfo is a threshold of color scale, data bar or icon set, e.g.: min, max or percentile with value
+type ConditionValueInfo struct {
+	Type  primitives.ConditionValueType
+	Value string
+}
+
+//ConditionalRuleInfo is a rule of conditional formatting. Colors are in #RGB format with applied theme colors and tints
+type ConditionalRuleInfo struct {
+	Type         primitives.ConditionType
+	Operator     primitives.ConditionOperatorType
+	Priority     int
+	StopIfTrue   bool
+	Formula      string   //first formula of rule
+	Formulas     []string //all formulas of rule, e.g.: 2 formulas for between operator
+	Text         string
+	TimePeriod   primitives.TimePeriodType
+	Rank         uint
+	Percent      bool
+	Bottom       bool
+	AboveAverage bool
+	EqualAverage bool
+	StdDev       int
+	Values       []ConditionValueInfo   //thresholds of color scale, data bar or icon set
+	Colors       []string               //colors of color scale or data bar
+	IconSet      primitives.IconSetType //type of icons for icon set
+	StyleID      *format.DiffStyleID    //id of differential style or nil if there is no style
+	Style        *CellStyle             //resolved differential style, settings that are not changed by style are empty
+}
+
+//ConditionalInfo is a conditional formatting of sheet with bounds of cells and rules
+type ConditionalInfo struct {
+	Bounds types.BoundsList
+	Pivot  bool
+	Rules  []*ConditionalRuleInfo
+}
 
 type conditionals struct {
 	sheet *sheetInfo
@@ -40,28 +84,185 @@ func (c *conditionals) Add(conditional *format.ConditionalFormat, refs []types.R
 		return err
 	}
 
-	info, styles := fromConditionalFormat(conditional)
+	info, styles, extensions := fromConditionalFormat(conditional)
 	if info != nil && len(styles) > 0 && len(info.Bounds) > 0 {
 		for i, styleInfo := range styles {
 			if styleInfo != nil {
 				//add a new diff styles
 				styleID := c.sheet.workbook.doc.styleSheet.addDiffStyle(styleInfo)
 				info.Rules[i].Style = &styleID
+			} else if styleID := info.Rules[i].Style; styleID != nil && c.sheet.workbook.doc.styleSheet.resolveDiffStyle(*styleID) == nil {
+				return errors.New(fmt.Sprintf("conditional rule#%d: there is no differential style with id %d", i, *styleID))
 			}
+		}
+
+		if err := c.addExtensions(info, extensions); err != nil {
+			return err
+		}
+
+		//add a new conditional
+		*c.sheet.ml.ConditionalFormatting = append(*c.sheet.ml.ConditionalFormatting, info)
+	}
+
+	return nil
+}
+
+//addExtensions links rules with x14 rules and adds x14 rules into extensions of sheet
+func (c *conditionals) addExtensions(info *ml.ConditionalFormatting, extensions []*ml.X14ConditionalRule) error {
+	formatting := &ml.X14ConditionalFormatting{
+		NamespaceXM: ml.NamespaceXM,
+		Bounds:      info.Bounds.String(),
+	}
+
+	for i, ext := range extensions {
+		if ext == nil {
+			continue
+		}
+
+		ext.ID = newGUID()
+		link, err := xml.Marshal(&ml.X14ConditionalRuleID{
+			URI:          ml.ExtURIConditionalFormattingID,
+			NamespaceX14: ml.NamespaceX14,
+			ID:           ext.ID,
+		})
 
-			//add a new conditional
-			*c.sheet.ml.ConditionalFormatting = append(*c.sheet.ml.ConditionalFormatting, info)
+		if err != nil {
+			return err
 		}
+
+		info.Rules[i].ExtLst = &sharedML.Reserved{InnerXML: &sharedML.InnerXML{XML: string(link)}}
+		formatting.Rules = append(formatting.Rules, ext)
+	}
+
+	if len(formatting.Rules) == 0 {
+		return nil
 	}
 
+	encoded, err := xml.Marshal(formatting)
+	if err != nil {
+		return err
+	}
+
+	c.sheet.addExtension(ml.ExtURIConditionalFormattings, "x14:conditionalFormattings", "", string(encoded))
 	return nil
 }
 
+//newGUID returns a random GUID in registry format, e.g.: {3F2504E0-4F89-41D3-9A0C-0305E82C3301}
+func newGUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("{%X-%X-%X-%X-%X}", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
 //Remove deletes a conditional formatting from refs
 func (c *conditionals) Remove(refs []types.Ref) {
 	panic(errorNotSupported)
 }
 
+//diffCellStyle returns resolved differential style with colors of palette
+func diffCellStyle(dxf *ml.DiffStyle, palette []string) *CellStyle {
+	//N.B.: pattern of differential style is solid by default and uses background color
+	fill := dxf.Fill
+	if fill != nil && fill.Pattern != nil && fill.Pattern.Type == 0 {
+		pattern := *fill.Pattern
+		pattern.Type = format.PatternTypeSolid
+		fill = &ml.Fill{Pattern: &pattern}
+	}
+
+	var code string
+	if dxf.NumberFormat != nil {
+		code = dxf.NumberFormat.Code
+		if len(code) == 0 {
+			code = numberFormat.Normalize(ml.NumberFormat{ID: dxf.NumberFormat.ID}).Code
+		}
+	}
+
+	return newCellStyle(dxf.Font, fill, dxf.Border, dxf.Alignment, code, palette)
+}
+
+//conditionValues returns thresholds of color scale, data bar or icon set
+func conditionValues(values []*ml.ConditionValue) []ConditionValueInfo {
+	var result []ConditionValueInfo
+	for _, v := range values {
+		result = append(result, ConditionValueInfo{Type: v.Type, Value: v.Value})
+	}
+
+	return result
+}
+
+//List returns all conditional formatting of sheet with resolved rules
+func (c *conditionals) List() []*ConditionalInfo {
+	if c.sheet.ml.ConditionalFormatting == nil {
+		return nil
+	}
+
+	ss := c.sheet.workbook.doc.styleSheet
+	palette := c.sheet.workbook.doc.ThemeColors()
+
+	var list []*ConditionalInfo
+	for _, conditional := range *c.sheet.ml.ConditionalFormatting {
+		info := &ConditionalInfo{
+			Bounds: append(types.BoundsList{}, conditional.Bounds...),
+			Pivot:  conditional.Pivot,
+		}
+
+		for _, r := range conditional.Rules {
+			rule := &ConditionalRuleInfo{
+				Type:         r.Type,
+				Operator:     r.Operator,
+				Priority:     r.Priority,
+				StopIfTrue:   r.StopIfTrue,
+				Text:         r.Text,
+				TimePeriod:   r.TimePeriod,
+				Rank:         r.Rank,
+				Percent:      r.Percent,
+				Bottom:       r.Bottom,
+				AboveAverage: r.AboveAverage,
+				EqualAverage: r.EqualAverage,
+				StdDev:       r.StdDev,
+			}
+
+			for _, f := range r.Formula {
+				rule.Formulas = append(rule.Formulas, string(f))
+			}
+
+			if len(rule.Formulas) > 0 {
+				rule.Formula = rule.Formulas[0]
+			}
+
+			switch {
+			case r.ColorScale != nil:
+				rule.Values = conditionValues(r.ColorScale.Values)
+				for _, clr := range r.ColorScale.Colors {
+					rule.Colors = append(rule.Colors, color.ToThemedRGB(clr, palette))
+				}
+			case r.DataBar != nil:
+				rule.Values = conditionValues(r.DataBar.Values)
+				rule.Colors = []string{color.ToThemedRGB(r.DataBar.Color, palette)}
+			case r.IconSet != nil:
+				rule.Values = conditionValues(r.IconSet.Values)
+				rule.IconSet = r.IconSet.Type
+			}
+
+			if r.Style != nil {
+				styleID := *r.Style
+				rule.StyleID = &styleID
+				if dxf := ss.resolveDiffStyle(styleID); dxf != nil {
+					rule.Style = diffCellStyle(dxf, palette)
+				}
+			}
+
+			info.Rules = append(info.Rules, rule)
+		}
+
+		list = append(list, info)
+	}
+
+	return list
+}
+
 //Resolve checks if requested cIdx and rIdx related to any conditionals formatting and returns it
 func (c *conditionals) Resolve(cIdx, rIdx int) *format.ConditionalFormat {
 	//TODO: Populate format.ConditionalFormat with required information
@@ -1 +1,149 @@
 package xlsx
+
+import (
+	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestConditionals_ExtendedDataBar(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Data")
+
+	for i := 0; i < 10; i++ {
+		sheet.Cell(0, i).SetInt(i - 5)
+	}
+
+	require.Nil(t, sheet.AddConditional(format.NewConditions(
+		format.Conditions.Rule(
+			format.Condition.Priority(1),
+			format.Condition.ExtendedDataBar(
+				format.DataBar.Solid,
+				format.DataBar.Axis(format.DataBarAxisMiddle, "#000000"),
+			),
+		),
+	), "A1:A10"))
+
+	require.Nil(t, sheet.AddConditional(format.NewConditions(
+		format.Conditions.Rule(
+			format.Condition.Priority(2),
+			format.Condition.ExtendedDataBar(format.DataBar.Color("#112233")),
+		),
+	), "B1:B10"))
+
+	info := sheet.info()
+	require.Equal(t, 2, len(*info.ml.ConditionalFormatting))
+
+	//x14 rules are linked with rules and stored in a single extension
+	content := info.ml.ExtLst.InnerXML.XML
+	require.Equal(t, 1, strings.Count(content, ml.ExtURIConditionalFormattings))
+	require.Equal(t, 2, strings.Count(content, "<x14:conditionalFormatting "))
+	require.Contains(t, content, `<xm:sqref>A1:A10</xm:sqref>`)
+	require.Contains(t, content, `axisPosition="middle"`)
+	require.Contains(t, content, `gradient="false"`)
+
+	guid := regexp.MustCompile(`^\{[0-9A-F]{8}-[0-9A-F]{4}-4[0-9A-F]{3}-[89AB][0-9A-F]{3}-[0-9A-F]{12}\}$`)
+	for _, conditional := range *info.ml.ConditionalFormatting {
+		link := conditional.Rules[0].ExtLst.InnerXML.XML
+		id := regexp.MustCompile(`<x14:id>(.*)</x14:id>`).FindStringSubmatch(link)
+		require.NotNil(t, id)
+		require.True(t, guid.MatchString(id[1]))
+		require.Contains(t, content, `id="`+id[1]+`"`)
+	}
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	info = xl.Sheet(0).info()
+	require.Equal(t, 2, len(*info.ml.ConditionalFormatting))
+	require.Equal(t, 2, strings.Count(info.ml.ExtLst.InnerXML.XML, "<x14:conditionalFormatting "))
+}
+
+func TestConditionals_List(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Data")
+	require.Nil(t, sheet.ConditionalFormats())
+
+	for i := 0; i < 10; i++ {
+		sheet.Cell(0, i).SetInt(i)
+	}
+
+	require.Nil(t, sheet.AddConditional(format.NewConditions(
+		format.Conditions.Rule(
+			format.Condition.Type(format.ConditionTypeCellIs),
+			format.Condition.Operator(format.ConditionOperatorGreaterThan),
+			format.Condition.Formula("5"),
+			format.Condition.Priority(1),
+			format.Condition.StopIfTrue,
+			format.Condition.Style(format.NewStyles(
+				format.Font.Bold,
+				format.Font.Color("#FF0000"),
+			)),
+		),
+	), "A1:A10"))
+
+	require.Nil(t, sheet.AddConditional(format.NewConditions(
+		format.Conditions.Rule(
+			format.Condition.Priority(2),
+			format.Condition.ColorScale2(nil, "#FFFFFF", nil, "#00FF00"),
+		),
+	), "B1:B10", "D1:D10"))
+
+	require.Nil(t, sheet.AddConditional(format.NewConditions(
+		format.Conditions.Rule(
+			format.Condition.Type(format.ConditionTypeCellIs),
+			format.Condition.Operator(format.ConditionOperatorBetween),
+			format.Condition.Formula("2", "4"),
+			format.Condition.Priority(3),
+		),
+	), "C1:C10"))
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	list := xl.Sheet(0).ConditionalFormats()
+	require.Equal(t, 3, len(list))
+
+	require.Equal(t, "A1:A10", list[0].Bounds.String())
+	require.Equal(t, 1, len(list[0].Rules))
+	rule := list[0].Rules[0]
+	require.Equal(t, format.ConditionTypeCellIs, rule.Type)
+	require.Equal(t, format.ConditionOperatorGreaterThan, rule.Operator)
+	require.Equal(t, "5", rule.Formula)
+	require.Equal(t, []string{"5"}, rule.Formulas)
+	require.Equal(t, 1, rule.Priority)
+	require.True(t, rule.StopIfTrue)
+	require.NotNil(t, rule.StyleID)
+	require.NotNil(t, rule.Style)
+	require.True(t, rule.Style.Bold)
+	require.Equal(t, "#FF0000", rule.Style.Color)
+
+	require.Equal(t, "B1:B10 D1:D10", list[1].Bounds.String())
+	rule = list[1].Rules[0]
+	require.Equal(t, format.ConditionTypeColorScale, rule.Type)
+	require.Equal(t, []ConditionValueInfo{{Type: format.ConditionValueTypeMin}, {Type: format.ConditionValueTypeMax}}, rule.Values)
+	require.Equal(t, []string{"#FFFFFF", "#00FF00"}, rule.Colors)
+	require.Nil(t, rule.StyleID)
+	require.Nil(t, rule.Style)
+
+	require.Equal(t, "C1:C10", list[2].Bounds.String())
+	rule = list[2].Rules[0]
+	require.Equal(t, format.ConditionOperatorBetween, rule.Operator)
+	require.Equal(t, "2", rule.Formula)
+	require.Equal(t, []string{"2", "4"}, rule.Formulas)
+}
@@ -0,0 +1,87 @@
+package xlsx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/plandem/ooxml"
+	"io"
+)
+
+//progressRowsStep is a number of processed rows between reports of progress for row based operations
+const progressRowsStep = 1000
+
+//Progress is information about progress of long-running operation, e.g. saving of document or importing of CSV
+type Progress struct {
+	Parts      int //number of read or written parts of package
+	TotalParts int //total number of parts of package to read or write
+	Rows       int //number of processed rows
+}
+
+//ProgressCallback is a callback that is called with progress of long-running operation
+type ProgressCallback func(progress Progress)
+
+//OnProgress sets callback that is called during long-running operations, e.g.: saving of document via SaveAs after writing of each part or importing of CSV after each 1000 rows. Use nil to remove callback
+func (xl *Spreadsheet) OnProgress(callback ProgressCallback) {
+	xl.progress = callback
+}
+
+//reportProgress notifies callback about progress if required
+func (xl *Spreadsheet) reportProgress(progress Progress) {
+	if xl.progress != nil {
+		xl.progress(progress)
+	}
+}
+
+//contextReader is io.Reader that stops reading when context is done
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return r.reader.Read(p)
+}
+
+//SaveAsContext saves document into file with name or io.Writer same way as SaveAs, but saving is canceled when ctx is done. Progress is reported after writing of each part.
+//N.B.: partially written file with name is removed if saving was canceled or failed
+func (xl *Spreadsheet) SaveAsContext(ctx context.Context, f interface{}) error {
+	return xl.savePackage(ctx, f, false)
+}
+
+//OpenContext opens a XLSX file with name or io.Reader, where opening is canceled when ctx is done and progress is reported to callback after reading of each part. Callback is kept for later long-running operations, see OnProgress. Use nil to open without callback
+//N.B.: file with name is read on demand same way as by Open, but content of io.Reader is read into memory, because zip requires random access. Use file with name to open big documents
+func OpenContext(ctx context.Context, f interface{}, callback ProgressCallback) (*Spreadsheet, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var source interface{}
+	switch s := f.(type) {
+	case string:
+		source = s
+	case io.Reader:
+		source = &contextReader{ctx: ctx, reader: s}
+	default:
+		return nil, errors.New(fmt.Sprintf("unsupported type of source = %T", f))
+	}
+
+	doc, err := ooxml.Open(source, newSpreadsheetContext(ctx, callback))
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+
+		return nil, err
+	}
+
+	if xl, ok := doc.(*Spreadsheet); ok {
+		return xl, nil
+	}
+
+	return nil, ooxml.ErrorUnknownPackage(Spreadsheet{})
+}
@@ -0,0 +1,140 @@
+package xlsx
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestOpenContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := OpenContext(ctx, "./test_files/example_simple.xlsx", nil)
+	require.Equal(t, context.Canceled, err)
+
+	_, err = OpenContext(context.Background(), 100, nil)
+	require.NotNil(t, err)
+
+	var list []Progress
+	xl, err := OpenContext(context.Background(), "./test_files/example_simple.xlsx", func(progress Progress) {
+		list = append(list, progress)
+	})
+
+	require.Nil(t, err)
+	defer xl.Close()
+	require.Equal(t, "8", xl.Sheet(0).CellByRef("F11").Value())
+	require.True(t, len(list) > 1)
+	require.Equal(t, len(list), list[len(list)-1].TotalParts)
+	require.Equal(t, list[len(list)-1].TotalParts, list[len(list)-1].Parts)
+
+	//reading of parts is canceled too
+	ctx, cancel = context.WithCancel(context.Background())
+	_, err = OpenContext(ctx, "./test_files/example_simple.xlsx", func(progress Progress) {
+		cancel()
+	})
+
+	require.Equal(t, context.Canceled, err)
+}
+
+func TestSaveAsContext(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Sheet1")
+	for i := 0; i < 10; i++ {
+		sheet.Cell(0, i).SetInt(i)
+	}
+
+	var list []Progress
+	xl.OnProgress(func(progress Progress) {
+		list = append(list, progress)
+	})
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, xl.SaveAsContext(context.Background(), buf))
+	require.True(t, len(list) > 1)
+	require.Equal(t, len(list), list[len(list)-1].TotalParts)
+	require.Equal(t, list[len(list)-1].TotalParts, list[len(list)-1].Parts)
+	require.Equal(t, 10, list[len(list)-1].Rows)
+
+	xl2, err := OpenBytes(buf.Bytes())
+	require.Nil(t, err)
+	defer xl2.Close()
+	require.Equal(t, "9", xl2.Sheet(0).Cell(0, 9).Value())
+
+	//canceled saving removes partially written file
+	ctx, cancel := context.WithCancel(context.Background())
+	xl.OnProgress(func(progress Progress) {
+		cancel()
+	})
+
+	err = xl.SaveAsContext(ctx, "./test_files/tmp_canceled.xlsx")
+	require.Equal(t, context.Canceled, err)
+	_, err = os.Stat("./test_files/tmp_canceled.xlsx")
+	require.True(t, os.IsNotExist(err))
+
+	require.Equal(t, context.Canceled, xl.SaveAsContext(ctx, &bytes.Buffer{}))
+	xl.OnProgress(nil)
+
+	//progress is reported by normal saving too
+	list = nil
+	xl.OnProgress(func(progress Progress) {
+		list = append(list, progress)
+	})
+
+	require.Nil(t, xl.SaveAs(&bytes.Buffer{}))
+	require.Equal(t, list[len(list)-1].TotalParts, list[len(list)-1].Parts)
+	require.Equal(t, 10, list[len(list)-1].Rows)
+	xl.OnProgress(nil)
+}
+
+func TestSaveAsContext_sameFile(t *testing.T) {
+	source, err := ioutil.ReadFile("./test_files/example_simple.xlsx")
+	require.Nil(t, err)
+	require.Nil(t, ioutil.WriteFile("./test_files/tmp_same.xlsx", source, 0644))
+	defer os.Remove("./test_files/tmp_same.xlsx")
+
+	//document can be saved into file that it was opened from
+	xl, err := OpenContext(context.Background(), "./test_files/tmp_same.xlsx", nil)
+	require.Nil(t, err)
+	xl.Sheet(0).CellByRef("A1").SetValue("updated")
+	require.Nil(t, xl.SaveAsContext(context.Background(), "./test_files/tmp_same.xlsx"))
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp_same.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+	require.Equal(t, "updated", xl.Sheet(0).CellByRef("A1").Value())
+	require.Equal(t, "8", xl.Sheet(0).CellByRef("F11").Value())
+}
+
+func TestSheetFromCSVContext(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	csv := strings.Repeat("a,1\n", 2500)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := xl.SheetFromCSVContext(ctx, "Canceled", strings.NewReader(csv), nil)
+	require.Equal(t, context.Canceled, err)
+	require.Equal(t, 0, len(xl.GetSheetNames()))
+
+	var rows []int
+	xl.OnProgress(func(progress Progress) {
+		rows = append(rows, progress.Rows)
+	})
+
+	sheet, err := xl.SheetFromCSVContext(context.Background(), "Data", strings.NewReader(csv), nil)
+	require.Nil(t, err)
+	require.Equal(t, []int{1000, 2000, 2500}, rows)
+
+	_, height := sheet.Dimension()
+	require.Equal(t, 2500, height)
+}
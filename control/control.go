@@ -0,0 +1,164 @@
+package control
+
+import (
+	"errors"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+	"strings"
+)
+
+//Type is a type to define type of form control
+type Type byte
+
+//List of all possible values for Type
+const (
+	_            Type = iota
+	CheckBox          //check box
+	OptionButton      //option button, aka radio button
+	DropDown          //drop-down list, aka combo box
+	Button            //button that runs a macro
+)
+
+//objectTypes is a list of types of form control with related types of objects
+var objectTypes = map[Type]string{
+	CheckBox:     "CheckBox",
+	OptionButton: "Radio",
+	DropDown:     "Drop",
+	Button:       "Button",
+}
+
+//Info is objects that holds information about form control
+type Info struct {
+	kind    Type
+	text    string
+	control *ml.FormControlPr
+}
+
+//Option is a type of option for form control
+type Option func(i *Info)
+
+//New creates and returns a new Info object that holds settings for form control of type t
+func New(t Type, options ...Option) *Info {
+	i := &Info{
+		kind:    t,
+		control: &ml.FormControlPr{ObjectType: objectTypes[t]},
+	}
+
+	switch t {
+	case CheckBox, OptionButton:
+		i.control.NoThreeD = true
+	case DropDown:
+		i.control.DropStyle = "combo"
+		i.control.DropLines = 8
+		i.control.NoThreeD = true
+	case Button:
+		i.control.LockText = true
+	}
+
+	i.Set(options...)
+	return i
+}
+
+//Set sets new options for form control
+func (i *Info) Set(options ...Option) {
+	for _, o := range options {
+		o(i)
+	}
+}
+
+//Type returns type of form control
+func (i *Info) Type() Type {
+	return i.kind
+}
+
+//Text returns text of form control
+func (i *Info) Text() string {
+	return i.text
+}
+
+//Validate validates settings of form control
+func (i *Info) Validate() error {
+	if _, ok := objectTypes[i.kind]; !ok {
+		return errors.New("unknown type of form control")
+	}
+
+	if i.kind == DropDown && len(i.control.FmlaRange) == 0 {
+		return errors.New("drop-down requires a range with items")
+	}
+
+	return nil
+}
+
+//absolute converts reference into the absolute reference, e.g.: Sheet1!A1:A3 into Sheet1!$A$1:$A$3
+func absolute(ref types.Ref) string {
+	sheet, bounds := "", string(ref)
+	if idx := strings.LastIndex(bounds, "!"); idx != -1 {
+		sheet, bounds = bounds[:idx+1], bounds[idx+1:]
+	}
+
+	return sheet + types.Ref(bounds).ToAbsolute()
+}
+
+//Text sets text of form control, e.g.: caption of check box or button
+func Text(text string) Option {
+	return func(i *Info) {
+		i.text = text
+	}
+}
+
+//Checked sets check box or option button as selected
+func Checked(i *Info) {
+	i.control.Checked = "Checked"
+}
+
+//LinkedCell sets cell that holds state of form control, e.g.: TRUE/FALSE for check box or index of selected item for option buttons and drop-down
+func LinkedCell(ref types.CellRef) Option {
+	return func(i *Info) {
+		i.control.FmlaLink = ref.ToAbsolute()
+	}
+}
+
+//Items sets range with items for drop-down, e.g.: Items("Lists!A1:A5")
+func Items(ref types.Ref) Option {
+	return func(i *Info) {
+		i.control.FmlaRange = absolute(ref)
+	}
+}
+
+//Selected sets 1-based index of selected item of drop-down
+func Selected(index uint) Option {
+	return func(i *Info) {
+		i.control.Sel = index
+	}
+}
+
+//DropLines sets number of lines that are visible in drop-down list
+func DropLines(n uint) Option {
+	return func(i *Info) {
+		if n > 0 {
+			i.control.DropLines = n
+		}
+	}
+}
+
+//FirstButton marks option button as the first button of group
+func FirstButton(i *Info) {
+	i.control.FirstButton = true
+}
+
+//Macro assigns macro with name to form control, e.g.: Macro("Module1.Run"). Macros require a workbook with VBA project
+func Macro(name string) Option {
+	return func(i *Info) {
+		i.control.FmlaMacro = "[0]!" + name
+	}
+}
+
+//private method used by form controls manager to unpack Info
+func fromControlInfo(info *Info) (*ml.FormControlPr, string, error) {
+	if err := info.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	control := *info.control
+	return &control, info.text, nil
+}
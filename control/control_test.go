@@ -0,0 +1,42 @@
+package control
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestControl(t *testing.T) {
+	//invalid settings
+	_, _, err := fromControlInfo(New(Type(0)))
+	require.NotNil(t, err)
+
+	_, _, err = fromControlInfo(New(DropDown))
+	require.NotNil(t, err)
+
+	//check box
+	info := New(CheckBox, Text("Done"), Checked, LinkedCell("B2"))
+	require.Equal(t, CheckBox, info.Type())
+	require.Equal(t, "Done", info.Text())
+
+	control, text, err := fromControlInfo(info)
+	require.Nil(t, err)
+	require.Equal(t, "Done", text)
+	require.Equal(t, &ml.FormControlPr{ObjectType: "CheckBox", Checked: "Checked", FmlaLink: "$B$2", NoThreeD: true}, control)
+
+	//option button
+	control, _, err = fromControlInfo(New(OptionButton, FirstButton, LinkedCell("C1")))
+	require.Nil(t, err)
+	require.Equal(t, &ml.FormControlPr{ObjectType: "Radio", FirstButton: true, FmlaLink: "$C$1", NoThreeD: true}, control)
+
+	//drop-down
+	control, _, err = fromControlInfo(New(DropDown, Items("'My Lists'!A1:A5"), LinkedCell("D1"), Selected(2), DropLines(0), DropLines(5)))
+	require.Nil(t, err)
+	require.Equal(t, &ml.FormControlPr{ObjectType: "Drop", DropStyle: "combo", DropLines: 5, FmlaRange: "'My Lists'!$A$1:$A$5", FmlaLink: "$D$1", Sel: 2, NoThreeD: true}, control)
+
+	//button
+	control, text, err = fromControlInfo(New(Button, Text("Run"), Macro("Module1.Run")))
+	require.Nil(t, err)
+	require.Equal(t, "Run", text)
+	require.Equal(t, &ml.FormControlPr{ObjectType: "Button", FmlaMacro: "[0]!Module1.Run", LockText: true}, control)
+}
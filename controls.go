@@ -0,0 +1,201 @@
+package xlsx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/plandem/ooxml"
+	sharedML "github.com/plandem/ooxml/ml"
+	"github.com/plandem/xlsx/control"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+	"regexp"
+	_ "unsafe"
+)
+
+//go:linkname fromControlInfo github.com/plandem/xlsx/control.fromControlInfo
+func fromControlInfo(info *control.Info) (*ml.FormControlPr, string, error)
+
+//vmlObjectTypes is a list of types of form controls with related types of objects of legacy drawing
+var vmlObjectTypes = map[string]string{
+	"CheckBox": "Checkbox",
+	"Radio":    "Radio",
+	"Drop":     "Drop",
+	"Button":   "Button",
+}
+
+var regExpControl = regexp.MustCompile(`<control\s`)
+
+//controlNames is a list of types of form controls with related default names
+var controlNames = map[string]string{
+	"CheckBox": "Check Box",
+	"Radio":    "Option Button",
+	"Drop":     "Drop Down",
+	"Button":   "Button",
+}
+
+type controlItem struct {
+	ml     *ml.FormControlPr
+	text   string
+	name   string
+	bounds types.Bounds
+	rid    sharedML.RID
+}
+
+type controls struct {
+	sheet    *sheetInfo
+	items    []*controlItem
+	existing string
+	count    int
+	isLoaded bool
+}
+
+//newControls creates an object that implements form controls functionality
+func newControls(sheet *sheetInfo) *controls {
+	return &controls{sheet: sheet}
+}
+
+//loadIfRequired keeps existing list of form controls of sheet, so new form controls are appended to it. Shapes of existing form controls are kept by legacy drawing as is
+func (c *controls) loadIfRequired() {
+	if c.isLoaded {
+		return
+	}
+
+	c.isLoaded = true
+	if c.sheet.ml.Controls != nil && c.sheet.ml.Controls.InnerXML != nil {
+		c.existing = c.sheet.ml.Controls.InnerXML.XML
+		c.count = len(regExpControl.FindAllStringIndex(c.existing, -1))
+	}
+}
+
+//Add adds a new form control that fits bounds
+func (c *controls) Add(bounds types.Bounds, info *control.Info) error {
+	if info == nil {
+		return errors.New("no form control info")
+	}
+
+	if bounds.IsEmpty() {
+		return errors.New("no bounds for form control")
+	}
+
+	properties, text, err := fromControlInfo(info)
+	if err != nil {
+		return err
+	}
+
+	c.loadIfRequired()
+
+	doc := c.sheet.workbook.doc
+	fileName := doc.uniqueFileName("xl/ctrlProps/ctrlProp%d.xml")
+	ooxml.NewPackageFile(doc.pkg, fileName, properties, nil).MarkAsUpdated()
+	doc.pkg.ContentTypes().RegisterContent(fileName, internal.ContentTypeControlProp)
+	c.sheet.attachRelationshipsIfRequired()
+	_, rid := c.sheet.relationships.AddFile(internal.RelationTypeControlProp, fileName)
+
+	c.items = append(c.items, &controlItem{
+		ml:     properties,
+		text:   text,
+		name:   fmt.Sprintf("%s %d", controlNames[properties.ObjectType], c.count+len(c.items)+1),
+		bounds: bounds,
+		rid:    rid,
+	})
+
+	c.sheet.comments.initVmlIfRequired()
+	c.sheet.comments.markAsUpdated()
+	return nil
+}
+
+//escapeVml returns text with escaped special characters of XML
+func escapeVml(text string) string {
+	escaped := &bytes.Buffer{}
+	_ = xml.EscapeText(escaped, []byte(text))
+	return escaped.String()
+}
+
+//update adds shapes of new form controls into legacy drawing with ids that start from shapeID and appends them to list of controls of sheet
+func (c *controls) update(vml *bytes.Buffer, shapeID int) {
+	if len(c.items) == 0 {
+		return
+	}
+
+	c.sheet.comments.writeShapeType(vml, "_x0000_t201", `<v:shapetype id="_x0000_t201" coordsize="21600,21600" o:spt="201" path="m,l,21600r21600,l21600,xe"><v:stroke joinstyle="miter"/><v:path shadowok="f" o:extrusionok="f" strokeok="f" fillok="f" o:connecttype="rect"/><o:lock v:ext="edit" shapetype="t"/></v:shapetype>`)
+
+	list := bytes.NewBufferString(c.existing)
+	for i, item := range c.items {
+		id, b, text := shapeID+i, item.bounds, escapeVml(item.text)
+		style := fmt.Sprintf("position:absolute;margin-left:0;margin-top:0;width:48pt;height:15pt;z-index:%d;mso-wrap-style:tight", shapeID+i)
+
+		switch item.ml.ObjectType {
+		case "Button":
+			vml.WriteString(fmt.Sprintf(`<v:shape id="_x0000_s%d" type="#_x0000_t201" style="%s" o:button="t" fillcolor="buttonFace [67]" strokecolor="windowText [64]" o:insetmode="auto">`, id, style))
+			vml.WriteString(`<v:fill color2="buttonFace [67]" o:detectmouseclick="t"/><o:lock v:ext="edit" rotation="t"/>`)
+			vml.WriteString(fmt.Sprintf(`<v:textbox style="mso-direction-alt:auto" o:singleclick="f"><div style="text-align:center"><font face="Calibri" size="220" color="#000000">%s</font></div></v:textbox>`, text))
+		case "Drop":
+			vml.WriteString(fmt.Sprintf(`<v:shape id="_x0000_s%d" type="#_x0000_t201" style="%s" stroked="f" strokecolor="windowText [64]" o:insetmode="auto">`, id, style))
+			vml.WriteString(`<o:lock v:ext="edit" rotation="t" text="t"/>`)
+		default:
+			vml.WriteString(fmt.Sprintf(`<v:shape id="_x0000_s%d" type="#_x0000_t201" style="%s" filled="f" stroked="f" strokecolor="windowText [64]" o:insetmode="auto">`, id, style))
+			vml.WriteString(`<v:path shadowok="f" o:extrusionok="f" strokeok="f" fillok="f" o:connecttype="rect"/><o:lock v:ext="edit" shapetype="t"/>`)
+			vml.WriteString(fmt.Sprintf(`<v:textbox style="mso-direction-alt:auto" o:singleclick="f"><div style="text-align:left"><font face="Tahoma" size="160" color="auto">%s</font></div></v:textbox>`, text))
+		}
+
+		vml.WriteString(fmt.Sprintf(`<x:ClientData ObjectType="%s"><x:Anchor>%d, 0, %d, 0, %d, 0, %d, 0</x:Anchor><x:AutoFill>False</x:AutoFill>`, vmlObjectTypes[item.ml.ObjectType], b.FromCol, b.FromRow, b.ToCol+1, b.ToRow+1))
+		switch item.ml.ObjectType {
+		case "Button":
+			vml.WriteString(`<x:TextHAlign>Center</x:TextHAlign><x:TextVAlign>Center</x:TextVAlign>`)
+		case "CheckBox", "Radio":
+			vml.WriteString(`<x:AutoLine>False</x:AutoLine><x:TextVAlign>Center</x:TextVAlign>`)
+		}
+
+		if len(item.ml.FmlaMacro) > 0 {
+			vml.WriteString(fmt.Sprintf(`<x:FmlaMacro>%s</x:FmlaMacro>`, escapeVml(item.ml.FmlaMacro)))
+		}
+
+		if len(item.ml.FmlaLink) > 0 {
+			vml.WriteString(fmt.Sprintf(`<x:FmlaLink>%s</x:FmlaLink>`, escapeVml(item.ml.FmlaLink)))
+		}
+
+		if len(item.ml.FmlaRange) > 0 {
+			vml.WriteString(fmt.Sprintf(`<x:FmlaRange>%s</x:FmlaRange>`, escapeVml(item.ml.FmlaRange)))
+		}
+
+		if item.ml.Sel > 0 {
+			vml.WriteString(fmt.Sprintf(`<x:Sel>%d</x:Sel>`, item.ml.Sel))
+		}
+
+		if item.ml.Checked == "Checked" {
+			vml.WriteString(`<x:Checked>1</x:Checked>`)
+		}
+
+		if item.ml.FirstButton {
+			vml.WriteString(`<x:FirstButton/>`)
+		}
+
+		if item.ml.NoThreeD {
+			vml.WriteString(`<x:NoThreeD/>`)
+		}
+
+		if len(item.ml.DropStyle) > 0 {
+			vml.WriteString(fmt.Sprintf(`<x:DropStyle>Combo</x:DropStyle><x:DropLines>%d</x:DropLines>`, item.ml.DropLines))
+		}
+
+		vml.WriteString(`</x:ClientData></v:shape>`)
+
+		//Excel 2010 and later uses list of controls of sheet together with properties of controls
+		macro := ""
+		if len(item.ml.FmlaMacro) > 0 {
+			macro = fmt.Sprintf(` macro="%s"`, escapeVml(item.ml.FmlaMacro))
+		}
+
+		list.WriteString(fmt.Sprintf(`<mc:AlternateContent xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" xmlns:x14="%s"><mc:Choice Requires="x14">`, ml.NamespaceX14))
+		list.WriteString(fmt.Sprintf(`<control shapeId="%d" r:id="%s" name="%s"><controlPr defaultSize="0" autoFill="0" autoLine="0" autoPict="0"%s>`, id, item.rid, escapeVml(item.name), macro))
+		list.WriteString(`<anchor moveWithCells="1" xmlns:xdr="http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing">`)
+		list.WriteString(fmt.Sprintf(`<from><xdr:col>%d</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>%d</xdr:row><xdr:rowOff>0</xdr:rowOff></from>`, b.FromCol, b.FromRow))
+		list.WriteString(fmt.Sprintf(`<to><xdr:col>%d</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>%d</xdr:row><xdr:rowOff>0</xdr:rowOff></to>`, b.ToCol+1, b.ToRow+1))
+		list.WriteString(`</anchor></controlPr></control></mc:Choice></mc:AlternateContent>`)
+	}
+
+	c.sheet.ml.Controls = &sharedML.Reserved{InnerXML: &sharedML.InnerXML{XML: list.String()}}
+}
@@ -0,0 +1,102 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/control"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestControls(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Sheet1")
+
+	//invalid form controls
+	require.NotNil(t, sheet.AddControl(types.BoundsFromIndexes(0, 0, 0, 0), control.Type(0)))
+	require.NotNil(t, sheet.AddControl(types.BoundsFromIndexes(0, 0, 0, 0), control.DropDown))
+	require.Nil(t, sheet.info().ml.LegacyDrawing)
+	require.Nil(t, sheet.info().ml.Controls)
+
+	//checklist without comments
+	for i := 1; i <= 3; i++ {
+		sheet.Cell(1, i).SetValue(i)
+		require.Nil(t, sheet.AddControl(types.BoundsFromIndexes(0, i, 0, i), control.CheckBox, control.LinkedCell(types.CellRefFromIndexes(2, i))))
+	}
+
+	require.NotNil(t, sheet.info().ml.LegacyDrawing)
+	require.Nil(t, sheet.info().comments.file)
+	require.Equal(t, 3, len(sheet.info().controls.items))
+	require.Equal(t, "Check Box 3", sheet.info().controls.items[2].name)
+	require.Equal(t, internal.RelationTypeControlProp, sheet.info().relationships.GetTypeById(string(sheet.info().relationships.GetIdByTarget("xl/ctrlProps/ctrlProp1.xml"))))
+
+	//legacy drawing and list of form controls are refreshed once before saving
+	sheet.info().comments.updateIfRequired()
+	vml := sheet.info().comments.vml.InnerXML
+	require.True(t, strings.Contains(vml, `<v:shape id="_x0000_s1025" type="#_x0000_t201"`))
+	require.True(t, strings.Contains(vml, `<x:ClientData ObjectType="Checkbox"><x:Anchor>0, 0, 3, 0, 1, 0, 4, 0</x:Anchor>`))
+	require.True(t, strings.Contains(vml, `<x:FmlaLink>$C$4</x:FmlaLink>`))
+	require.True(t, strings.Contains(sheet.info().ml.Controls.InnerXML.XML, `<control shapeId="1027" r:id="`))
+
+	//comments are placed before form controls, so shapes of controls get new ids
+	require.Nil(t, sheet.CellByRef("E1").SetComment("Checklist"))
+	require.NotNil(t, sheet.info().comments.file)
+	sheet.info().comments.updateIfRequired()
+	require.True(t, strings.Contains(sheet.info().ml.Controls.InnerXML.XML, `<control shapeId="1026" r:id="`))
+	require.False(t, strings.Contains(sheet.info().ml.Controls.InnerXML.XML, `<control shapeId="1025" r:id="`))
+
+	//other types of form controls
+	require.Nil(t, sheet.AddControl(types.BoundsFromIndexes(4, 2, 5, 2), control.OptionButton, control.Text("A & B"), control.FirstButton, control.Checked))
+	require.Nil(t, sheet.AddControl(types.BoundsFromIndexes(4, 3, 5, 3), control.DropDown, control.Items("B2:B4"), control.Selected(1)))
+	require.Nil(t, sheet.AddControl(types.BoundsFromIndexes(4, 4, 5, 5), control.Button, control.Text("Run"), control.Macro("Module1.Run")))
+
+	sheet.info().comments.updateIfRequired()
+	vml = sheet.info().comments.vml.InnerXML
+	require.True(t, strings.Contains(vml, `<font face="Tahoma" size="160" color="auto">A &amp; B</font>`))
+	require.True(t, strings.Contains(vml, `<x:Checked>1</x:Checked><x:FirstButton/>`))
+	require.True(t, strings.Contains(vml, `<x:FmlaRange>$B$2:$B$4</x:FmlaRange><x:Sel>1</x:Sel>`))
+	require.True(t, strings.Contains(vml, `<x:FmlaMacro>[0]!Module1.Run</x:FmlaMacro>`))
+	require.True(t, strings.Contains(sheet.info().ml.Controls.InnerXML.XML, `name="Button 6"><controlPr defaultSize="0" autoFill="0" autoLine="0" autoPict="0" macro="[0]!Module1.Run">`))
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	require.NotNil(t, sheet.info().ml.Controls)
+	require.NotNil(t, sheet.info().ml.LegacyDrawing)
+	require.Equal(t, "Checklist", sheet.CellByRef("E1").Comment().String())
+
+	//existing form controls and their shapes are kept
+	require.Nil(t, sheet.AddControl(types.BoundsFromIndexes(6, 0, 6, 0), control.CheckBox, control.Text("New")))
+	require.Equal(t, "Check Box 7", sheet.info().controls.items[0].name)
+
+	sheet.info().comments.updateIfRequired()
+	list := sheet.info().ml.Controls.InnerXML.XML
+	require.Equal(t, 7, strings.Count(list, "<control "))
+	require.True(t, strings.Contains(list, `name="Button 6"`))
+	require.True(t, strings.Contains(list, `<control shapeId="1033" r:id="`))
+
+	vml = sheet.info().comments.vml.InnerXML
+	require.Equal(t, 1, strings.Count(vml, `<v:shapetype id="_x0000_t201"`))
+	require.True(t, strings.Contains(vml, `<x:FmlaMacro>[0]!Module1.Run</x:FmlaMacro>`))
+	require.True(t, strings.Contains(vml, `<v:shape id="_x0000_s1033" type="#_x0000_t201"`))
+	require.Equal(t, 8, strings.Count(vml, `<v:shape `))
+
+	//round trip
+	content, err := xl.Bytes()
+	require.Nil(t, err)
+
+	xl2, err := OpenBytes(content)
+	require.Nil(t, err)
+	defer xl2.Close()
+
+	require.Equal(t, 7, strings.Count(xl2.Sheet(0).info().ml.Controls.InnerXML.XML, "<control "))
+	require.Equal(t, "Checklist", xl2.Sheet(0).CellByRef("E1").Comment().String())
+}
@@ -0,0 +1,197 @@
+package xlsx
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/number_format/convert"
+	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/types"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var csvNumber = regexp.MustCompile(`^[-+]?(\d+\.?\d*|\.\d+)([eE][-+]?\d+)?$`)
+
+//csvValue sets value of cell with detection of type if required
+func csvValue(c *Cell, value string, o *options.CSVOptions) {
+	if !o.InferTypes || len(value) == 0 {
+		c.SetString(value)
+		return
+	}
+
+	//N.B.: numbers with leading zeros are identifiers in most cases, e.g.: zip codes, so keep it as is
+	unsigned := strings.TrimLeft(value, "-+")
+	leadingZero := len(unsigned) > 1 && unsigned[0] == '0' && unsigned[1] != '.'
+	if csvNumber.MatchString(value) && !leadingZero {
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			c.SetInt(int(i))
+			return
+		}
+
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			c.SetFloat(f)
+			return
+		}
+	}
+
+	if strings.EqualFold(value, "true") || strings.EqualFold(value, "false") {
+		c.SetBool(strings.EqualFold(value, "true"))
+		return
+	}
+
+	if cellError := types.CellError(value); cellError.IsValid() {
+		_ = c.SetError(cellError)
+		return
+	}
+
+	layouts := []string{convert.ISO8601, "2006-01-02"}
+	if len(o.DateFormat) > 0 {
+		layouts = []string{o.DateFormat}
+	}
+
+	for _, layout := range layouts {
+		if d, err := time.Parse(layout, value); err == nil {
+			if d.Hour() == 0 && d.Minute() == 0 && d.Second() == 0 {
+				c.SetDate(d)
+			} else {
+				c.SetDateTime(d)
+			}
+
+			return
+		}
+	}
+
+	c.SetString(value)
+}
+
+//SheetFromCSV adds a new sheet with name and fills it with records of CSV/TSV. If options is nil, then default options are used
+func (xl *Spreadsheet) SheetFromCSV(name string, r io.Reader, o *options.CSVOptions) (Sheet, error) {
+	return xl.SheetFromCSVContext(context.Background(), name, r, o)
+}
+
+//SheetFromCSVContext adds a new sheet with name and fills it with records of CSV/TSV same way as SheetFromCSV, but importing is canceled when ctx is done. Progress is reported after each 1000 rows. Sheet is not added if importing was canceled
+func (xl *Spreadsheet) SheetFromCSVContext(ctx context.Context, name string, r io.Reader, o *options.CSVOptions) (Sheet, error) {
+	if o == nil {
+		o = options.NewCSVOptions()
+	}
+
+	reader := csv.NewReader(&contextReader{ctx: ctx, reader: r})
+	reader.Comma = o.Delimiter
+	reader.Comment = o.Comment
+	reader.LazyQuotes = o.LazyQuotes
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	var records [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+
+			return nil, err
+		}
+
+		if len(records) >= internal.ExcelRowLimit || len(record) > internal.ExcelColumnLimit {
+			return nil, errors.New(fmt.Sprintf("csv does not fit into the sheet, limits are %d rows and %d columns", internal.ExcelRowLimit, internal.ExcelColumnLimit))
+		}
+
+		records = append(records, append([]string(nil), record...))
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sheet := xl.AddSheet(name)
+	for rIdx, record := range records {
+		for cIdx, value := range record {
+			csvValue(sheet.Cell(cIdx, rIdx), value, o)
+		}
+
+		if rows := rIdx + 1; rows%progressRowsStep == 0 || rows == len(records) {
+			xl.reportProgress(Progress{Rows: rows})
+		}
+	}
+
+	return sheet, nil
+}
+
+//csvCell returns value of cell for export
+func csvCell(c *Cell, o *options.CSVOptions) string {
+	if len(o.DateFormat) > 0 {
+		if date, t := c.hasDateFormat(); date || t || c.ml.Type == types.CellTypeDate {
+			if d, err := c.Time(); err == nil {
+				return d.Format(o.DateFormat)
+			}
+		}
+	}
+
+	if o.FormattedValues {
+		return c.FormattedValue()
+	}
+
+	return c.Value()
+}
+
+//csvQuote returns quoted field
+func csvQuote(field string) string {
+	return `"` + strings.Replace(field, `"`, `""`, -1) + `"`
+}
+
+//ToCSV writes all rows of sheet as records of CSV/TSV. If options is nil, then default options are used
+func (s *sheetInfo) ToCSV(w io.Writer, o *options.CSVOptions) error {
+	if o == nil {
+		o = options.NewCSVOptions()
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = o.Delimiter
+	writer.UseCRLF = o.UseCRLF
+
+	lineBreak := "\n"
+	if o.UseCRLF {
+		lineBreak = "\r\n"
+	}
+
+	cols, _ := s.sheet.Dimension()
+	record := make([]string, cols)
+	for rows := s.sheet.Rows(); rows.HasNext(); {
+		_, row := rows.Next()
+		for cIdx := 0; cIdx < cols; cIdx++ {
+			record[cIdx] = csvCell(row.Cell(cIdx), o)
+		}
+
+		//N.B.: csv.Writer quotes fields only if it's required
+		if !o.QuoteAll {
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		quoted := make([]string, cols)
+		for i, field := range record {
+			quoted[i] = csvQuote(field)
+		}
+
+		if _, err := io.WriteString(w, strings.Join(quoted, string(o.Delimiter))+lineBreak); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,64 @@
+package xlsx
+
+import (
+	"bytes"
+	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestCSV(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	//must be valid csv
+	_, err := xl.SheetFromCSV("Invalid", strings.NewReader("a,\"b\nc"), nil)
+	require.NotNil(t, err)
+
+	//all values are strings by default
+	data := "Name,Zip,Total,Paid,Date\n\"Doe, John\",01234,1234.5,true,2020-01-31\n#comment\nSmith,,10,FALSE,31.01.2020\n"
+	sheet, err := xl.SheetFromCSV("Raw", strings.NewReader(data), nil)
+	require.Nil(t, err)
+	require.Equal(t, types.CellTypeSharedString, sheet.CellByRef("C2").Type())
+	require.Equal(t, "#comment", sheet.CellByRef("A3").Value())
+
+	//detection of types
+	sheet, err = xl.SheetFromCSV("Typed", strings.NewReader(data), options.NewCSVOptions(
+		options.CSV.InferTypes(true),
+		options.CSV.Comment('#'),
+	))
+	require.Nil(t, err)
+	require.Equal(t, "Doe, John", sheet.CellByRef("A2").Value())
+	require.Equal(t, "01234", sheet.CellByRef("B2").Value())
+	require.Equal(t, types.CellTypeSharedString, sheet.CellByRef("B2").Type())
+	require.Equal(t, types.CellTypeNumber, sheet.CellByRef("C2").Type())
+	require.Equal(t, types.CellTypeBool, sheet.CellByRef("D2").Type())
+	require.Equal(t, types.CellTypeDate, sheet.CellByRef("E2").Type())
+	require.Equal(t, types.CellTypeBool, sheet.CellByRef("D3").Type())
+	require.Equal(t, types.CellTypeSharedString, sheet.CellByRef("E3").Type())
+
+	//export with formatted values
+	buf := &bytes.Buffer{}
+	require.Nil(t, sheet.ToCSV(buf, nil))
+	require.Equal(t, "Name,Zip,Total,Paid,Date\n\"Doe, John\",01234,1234.50,TRUE,1-31-20\nSmith,,10,FALSE,31.01.2020\n", buf.String())
+
+	//export of TSV with raw values and layout of dates
+	buf.Reset()
+	require.Nil(t, sheet.ToCSV(buf, options.NewCSVOptions(
+		options.CSV.Delimiter('\t'),
+		options.CSV.FormattedValues(false),
+		options.CSV.DateFormat("02.01.2006"),
+		options.CSV.QuoteAll(true),
+		options.CSV.UseCRLF(true),
+	)))
+	require.Equal(t, "\"Name\"\t\"Zip\"\t\"Total\"\t\"Paid\"\t\"Date\"\r\n\"Doe, John\"\t\"01234\"\t\"1234.5\"\t\"1\"\t\"31.01.2020\"\r\n\"Smith\"\t\"\"\t\"10\"\t\"0\"\t\"31.01.2020\"\r\n", buf.String())
+
+	//error values
+	sheet, err = xl.SheetFromCSV("Errors", strings.NewReader("#N/A,#DIV/0!,#hashtag\n"), options.NewCSVOptions(options.CSV.InferTypes(true)))
+	require.Nil(t, err)
+	require.Equal(t, types.CellTypeError, sheet.CellByRef("A1").Type())
+	require.Equal(t, types.CellTypeError, sheet.CellByRef("B1").Type())
+	require.Equal(t, types.CellTypeSharedString, sheet.CellByRef("C1").Type())
+}
@@ -0,0 +1,189 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/plandem/ooxml"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"io"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var regExpCustomXML = regexp.MustCompile(`^customXml/item(\d+)\.xml$`)
+
+//customXMLProps is a content of properties for a new custom XML part
+const customXMLProps = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>` + "\n" +
+	`<ds:datastoreItem ds:itemID="%s" xmlns:ds="http://schemas.openxmlformats.org/officeDocument/2006/customXml"><ds:schemaRefs/></ds:datastoreItem>`
+
+type customXMLItem struct {
+	id       string
+	fileName string
+	content  []byte
+}
+
+//CustomXML is a higher level object that provides access to custom XML parts of document, e.g.: data of add-ins or document management systems. Parts are stored as is
+type CustomXML struct {
+	doc      *Spreadsheet
+	items    []*customXMLItem
+	isLoaded bool
+}
+
+//newCustomXML creates an object that implements custom XML parts functionality
+func newCustomXML(doc *Spreadsheet) *CustomXML {
+	return &CustomXML{doc: doc}
+}
+
+//propsFileName returns name of file with properties for custom XML part, e.g.: customXml/itemProps1.xml for customXml/item1.xml
+func propsFileName(fileName string) string {
+	return path.Join(path.Dir(fileName), strings.Replace(path.Base(fileName), "item", "itemProps", 1))
+}
+
+//readZipFile returns content of file or nil if there is no such file
+func readZipFile(f interface{}) []byte {
+	if zf, ok := f.(*zip.File); ok {
+		if reader, err := zf.Open(); err == nil {
+			defer reader.Close()
+			if content, err := ioutil.ReadAll(reader); err == nil {
+				return content
+			}
+		}
+	}
+
+	return nil
+}
+
+//loadIfRequired lookups for existing custom XML parts and loads them in order of indexes of files
+func (c *CustomXML) loadIfRequired() {
+	if c.isLoaded {
+		return
+	}
+
+	c.isLoaded = true
+
+	indexes := make(map[*customXMLItem]int)
+	for _, f := range c.doc.pkg.Files() {
+		if zf, ok := f.(*zip.File); ok {
+			if m := regExpCustomXML.FindStringSubmatch(zf.Name); m != nil {
+				item := &customXMLItem{fileName: zf.Name, content: readZipFile(zf)}
+				if props := readZipFile(c.doc.pkg.File(propsFileName(zf.Name))); props != nil {
+					info := ml.DatastoreItem{}
+					if err := xml.Unmarshal(props, &info); err == nil {
+						item.id = info.ItemID
+					}
+				}
+
+				indexes[item], _ = strconv.Atoi(m[1])
+				c.items = append(c.items, item)
+			}
+		}
+	}
+
+	sort.SliceStable(c.items, func(i, j int) bool {
+		return indexes[c.items[i]] < indexes[c.items[j]]
+	})
+}
+
+//Add adds a new custom XML part with content and returns GUID of part
+func (c *CustomXML) Add(content io.Reader) (string, error) {
+	c.loadIfRequired()
+
+	if content == nil {
+		return "", errors.New("no content for custom XML part")
+	}
+
+	data, err := ioutil.ReadAll(content)
+	if err != nil {
+		return "", err
+	}
+
+	//content must be a well-formed XML
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	hasRoot := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return "", errors.New(fmt.Sprintf("invalid content of custom XML part: %s", err))
+		}
+
+		if _, ok := token.(xml.StartElement); ok {
+			hasRoot = true
+		}
+	}
+
+	if !hasRoot {
+		return "", errors.New("custom XML part requires a root element")
+	}
+
+	doc := c.doc
+	item := &customXMLItem{id: newGUID(), fileName: doc.uniqueFileName("customXml/item%d.xml"), content: data}
+	propsName := propsFileName(item.fileName)
+
+	doc.pkg.Add(item.fileName, item.content)
+	doc.pkg.ContentTypes().RegisterContent(item.fileName, internal.ContentTypeCustomXML)
+	doc.relationships.AddFile(internal.RelationTypeCustomXML, item.fileName)
+
+	doc.pkg.Add(propsName, []byte(fmt.Sprintf(customXMLProps, item.id)))
+	doc.pkg.ContentTypes().RegisterContent(propsName, internal.ContentTypeCustomXMLProp)
+	ooxml.NewRelationships(fmt.Sprintf("customXml/_rels/%s.rels", path.Base(item.fileName)), doc.pkg).AddFile(internal.RelationTypeCustomXMLProp, propsName)
+
+	c.items = append(c.items, item)
+	return item.id, nil
+}
+
+//List returns GUIDs of all custom XML parts. Part without properties has empty GUID
+func (c *CustomXML) List() []string {
+	c.loadIfRequired()
+
+	ids := make([]string, 0, len(c.items))
+	for _, item := range c.items {
+		ids = append(ids, item.id)
+	}
+
+	return ids
+}
+
+//Get returns content of custom XML part with 0-based index or nil if there is no such part
+func (c *CustomXML) Get(i int) []byte {
+	c.loadIfRequired()
+
+	if i < 0 || i >= len(c.items) {
+		return nil
+	}
+
+	return c.items[i].content
+}
+
+//Delete deletes custom XML part with 0-based index
+func (c *CustomXML) Delete(i int) {
+	c.loadIfRequired()
+
+	if i < 0 || i >= len(c.items) {
+		return
+	}
+
+	doc, item := c.doc, c.items[i]
+	if rid := doc.relationships.GetIdByTarget(item.fileName); rid != "" {
+		doc.relationships.Remove(rid)
+	}
+
+	for _, fileName := range []string{item.fileName, propsFileName(item.fileName)} {
+		doc.pkg.Remove(fileName)
+		doc.pkg.ContentTypes().Remove(fileName)
+	}
+
+	doc.pkg.Remove(fmt.Sprintf("customXml/_rels/%s.rels", path.Base(item.fileName)))
+	c.items = append(c.items[:i], c.items[i+1:]...)
+}
@@ -0,0 +1,64 @@
+package xlsx
+
+import (
+	"bytes"
+	sharedML "github.com/plandem/ooxml/ml"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestCustomXML(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Sheet1")
+
+	custom := xl.CustomXML()
+	require.Equal(t, []string{}, custom.List())
+
+	//invalid content
+	_, err := custom.Add(nil)
+	require.NotNil(t, err)
+	_, err = custom.Add(strings.NewReader("<root>"))
+	require.NotNil(t, err)
+	_, err = custom.Add(strings.NewReader(""))
+	require.NotNil(t, err)
+
+	first, err := custom.Add(strings.NewReader(`<invoice xmlns="urn:invoice"><id>42</id></invoice>`))
+	require.Nil(t, err)
+	second, err := custom.Add(strings.NewReader(`<meta/>`))
+	require.Nil(t, err)
+	require.Equal(t, []string{first, second}, custom.List())
+	require.Equal(t, []byte(`<meta/>`), custom.Get(1))
+	require.Nil(t, custom.Get(2))
+
+	//unknown parts and extensions are kept as is
+	xl.pkg.Add("xl/unknown/part1.bin", []byte("unknown"))
+	sheet.info().ml.ExtLst = &sharedML.Reserved{InnerXML: &sharedML.InnerXML{XML: `<ext uri="{00000000-0000-0000-0000-000000000000}"><unknown/></ext>`}}
+
+	require.Nil(t, xl.SaveAs("./test_files/tmp.xlsx"))
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+
+	custom = xl.CustomXML()
+	require.Equal(t, []string{first, second}, custom.List())
+	require.Equal(t, []byte(`<invoice xmlns="urn:invoice"><id>42</id></invoice>`), custom.Get(0))
+	require.Equal(t, []byte("unknown"), readZipFile(xl.pkg.File("xl/unknown/part1.bin")))
+	require.Contains(t, xl.Sheet(0).info().ml.ExtLst.InnerXML.XML, "{00000000-0000-0000-0000-000000000000}")
+
+	custom.Delete(0)
+	require.Equal(t, []string{second}, custom.List())
+	xl.Sheet(0).CellByRef("A1").SetValue(1)
+
+	require.Nil(t, xl.SaveAs("./test_files/tmp2.xlsx"))
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp2.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	require.Equal(t, []string{second}, xl.CustomXML().List())
+	require.True(t, bytes.Equal([]byte(`<meta/>`), xl.CustomXML().Get(0)))
+	require.Contains(t, xl.Sheet(0).info().ml.ExtLst.InnerXML.XML, "{00000000-0000-0000-0000-000000000000}")
+}
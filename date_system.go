@@ -0,0 +1,59 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/internal/number_format/convert"
+	"time"
+)
+
+//DateSystem is a type of base date for serial dates of workbook
+type DateSystem byte
+
+//List of all possible date systems
+const (
+	DateSystem1900 DateSystem = iota //serial dates are number of days since 1900-01-01 (default)
+	DateSystem1904                   //serial dates are number of days since 1904-01-01 (used by classic Mac Excel)
+)
+
+//SetDateSystem sets base date for serial dates of workbook, e.g.: SetDateSystem(xlsx.DateSystem1904). N.B.: numbers that are already stored as serial dates are not converted
+func (xl *Spreadsheet) SetDateSystem(system DateSystem) {
+	if xl.workbook.ml.WorkbookPr == nil {
+		xl.workbook.ml.WorkbookPr = &ml.WorkbookPr{}
+	}
+
+	xl.workbook.ml.WorkbookPr.Date1904 = system == DateSystem1904
+	xl.workbook.file.MarkAsUpdated()
+}
+
+//DateSystem returns base date for serial dates of workbook
+func (xl *Spreadsheet) DateSystem() DateSystem {
+	if xl.workbook.isDate1904() {
+		return DateSystem1904
+	}
+
+	return DateSystem1900
+}
+
+//isDate1904 returns true if workbook uses 1904 date system
+func (wb *Workbook) isDate1904() bool {
+	return wb.ml.WorkbookPr != nil && wb.ml.WorkbookPr.Date1904
+}
+
+//toSerial converts date into serial date of workbook's date system
+func (wb *Workbook) toSerial(t time.Time) float64 {
+	serial := convert.ToSerial(t)
+	if wb.isDate1904() {
+		serial -= convert.Date1904Offset
+	}
+
+	return serial
+}
+
+//fromSerial converts serial date of workbook's date system into time.Time in UTC
+func (wb *Workbook) fromSerial(serial float64) time.Time {
+	if wb.isDate1904() {
+		serial += convert.Date1904Offset
+	}
+
+	return convert.FromSerial(serial)
+}
@@ -0,0 +1,52 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDateSystem(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	require.Equal(t, DateSystem1900, xl.DateSystem())
+
+	sheet := xl.AddSheet("Dates")
+	sheet.CellByRef("A1").SetValueWithFormat(43831, "yyyy-mm-dd")
+
+	d, err := sheet.CellByRef("A1").Time()
+	require.Nil(t, err)
+	require.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), d)
+	require.Equal(t, "2020-01-01", sheet.CellByRef("A1").FormattedValue())
+
+	//same serial date is 4 years later in 1904 date system
+	xl.SetDateSystem(DateSystem1904)
+	require.Equal(t, DateSystem1904, xl.DateSystem())
+
+	d, err = sheet.CellByRef("A1").Time()
+	require.Nil(t, err)
+	require.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), d)
+	require.Equal(t, "2024-01-02", sheet.CellByRef("A1").FormattedValue())
+
+	//cached values of formulas use date system of workbook
+	require.Nil(t, sheet.CellByRef("B1").SetFormulaWithValue("A1", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), types.CellTypeDate))
+	require.Equal(t, "43831", sheet.CellByRef("B1").CachedValue())
+
+	//round trip
+	content, err := xl.Bytes()
+	require.Nil(t, err)
+
+	xl2, err := OpenBytes(content)
+	require.Nil(t, err)
+	defer xl2.Close()
+
+	require.Equal(t, DateSystem1904, xl2.DateSystem())
+	d, err = xl2.Sheet(0).CellByRef("A1").Time()
+	require.Nil(t, err)
+	require.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), d)
+
+	xl2.SetDateSystem(DateSystem1900)
+	require.Equal(t, DateSystem1900, xl2.DateSystem())
+}
@@ -0,0 +1,194 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/formula"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"regexp"
+	"strings"
+)
+
+//N.B.: names that look like cell refs in A1 or R1C1 notation are not allowed
+var regExpDefinedName = regexp.MustCompile(`^[\p{L}_\\][\p{L}\p{N}_.\\]*$`)
+var regExpDefinedNameA1 = regexp.MustCompile(`(?i)^[a-z]{1,3}\d+$`)
+var regExpDefinedNameR1C1 = regexp.MustCompile(`(?i)^(r\d*c\d*|r\d*|c\d*)$`)
+
+type definedNames struct {
+	workbook *Workbook
+}
+
+//newDefinedNames creates an object that implements defined names functionality
+func newDefinedNames(workbook *Workbook) *definedNames {
+	return &definedNames{workbook: workbook}
+}
+
+//validateName validates name and returns error if name is not allowed by Excel
+func (dn *definedNames) validateName(name string) error {
+	if len(name) == 0 {
+		return errors.New("name is empty")
+	}
+
+	if len(name) > internal.ExcelDefinedNameLimit {
+		return errors.New(fmt.Sprintf("name exceeded maximum allowed length (%d chars)", internal.ExcelDefinedNameLimit))
+	}
+
+	if !regExpDefinedName.MatchString(name) || regExpDefinedNameA1.MatchString(name) || regExpDefinedNameR1C1.MatchString(name) {
+		return errors.New(fmt.Sprintf("invalid name: %s", name))
+	}
+
+	return nil
+}
+
+//inScope returns true if defined name has scope of sheet with sheetIndex (-1 for workbook)
+func inScope(definedName *ml.DefinedName, sheetIndex int) bool {
+	if definedName.LocalSheetID == nil {
+		return sheetIndex == -1
+	}
+
+	return *definedName.LocalSheetID == sheetIndex
+}
+
+//index returns index of defined name with scope of sheet with sheetIndex (-1 for workbook) or -1 if there is no such name
+func (dn *definedNames) index(name string, sheetIndex int) int {
+	for i, definedName := range dn.workbook.ml.DefinedNames.Items {
+		if inScope(definedName, sheetIndex) && strings.EqualFold(definedName.Name, name) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+//Add adds a new or updates existing defined name with formula for scope of sheet with sheetIndex (-1 for workbook)
+func (dn *definedNames) Add(name string, formula string, sheetIndex int) error {
+	if err := dn.validateName(name); err != nil {
+		return err
+	}
+
+	formula = strings.TrimPrefix(formula, "=")
+	if len(formula) == 0 {
+		return errors.New("formula is empty")
+	}
+
+	if idx := dn.index(name, sheetIndex); idx != -1 {
+		dn.workbook.ml.DefinedNames.Items[idx].Formula = formula
+	} else {
+		definedName := &ml.DefinedName{Name: name, Formula: formula}
+		if sheetIndex >= 0 {
+			localSheetID := sheetIndex
+			definedName.LocalSheetID = &localSheetID
+		}
+
+		dn.workbook.ml.DefinedNames.Items = append(dn.workbook.ml.DefinedNames.Items, definedName)
+	}
+
+	dn.workbook.file.MarkAsUpdated()
+	return nil
+}
+
+//addHidden adds a new or updates existing hidden defined name, e.g. built-in names that are used by Excel internally
+func (dn *definedNames) addHidden(name string, formula string, sheetIndex int) error {
+	if err := dn.Add(name, formula, sheetIndex); err != nil {
+		return err
+	}
+
+	dn.workbook.ml.DefinedNames.Items[dn.index(name, sheetIndex)].Hidden = true
+	return nil
+}
+
+//Get returns formula of defined name for scope of sheet with sheetIndex (-1 for workbook) or empty string if there is no such name
+func (dn *definedNames) Get(name string, sheetIndex int) string {
+	if idx := dn.index(name, sheetIndex); idx != -1 {
+		return dn.workbook.ml.DefinedNames.Items[idx].Formula
+	}
+
+	return ""
+}
+
+//Remove removes defined name for scope of sheet with sheetIndex (-1 for workbook)
+func (dn *definedNames) Remove(name string, sheetIndex int) {
+	if idx := dn.index(name, sheetIndex); idx != -1 {
+		dn.workbook.ml.DefinedNames.Items = append(dn.workbook.ml.DefinedNames.Items[:idx], dn.workbook.ml.DefinedNames.Items[idx+1:]...)
+		dn.workbook.file.MarkAsUpdated()
+	}
+}
+
+//List returns names of all defined names for scope of sheet with sheetIndex (-1 for workbook)
+func (dn *definedNames) List(sheetIndex int) []string {
+	var names []string
+
+	for _, definedName := range dn.workbook.ml.DefinedNames.Items {
+		if inScope(definedName, sheetIndex) {
+			names = append(names, definedName.Name)
+		}
+	}
+
+	return names
+}
+
+//removeSheet removes defined names for scope of sheet with sheetIndex and updates scope of names for sheets after it
+func (dn *definedNames) removeSheet(sheetIndex int) {
+	if len(dn.workbook.ml.DefinedNames.Items) == 0 {
+		return
+	}
+
+	items := make([]*ml.DefinedName, 0, len(dn.workbook.ml.DefinedNames.Items))
+	for _, definedName := range dn.workbook.ml.DefinedNames.Items {
+		if definedName.LocalSheetID != nil {
+			if *definedName.LocalSheetID == sheetIndex {
+				continue
+			}
+
+			if *definedName.LocalSheetID > sheetIndex {
+				localSheetID := *definedName.LocalSheetID - 1
+				definedName.LocalSheetID = &localSheetID
+			}
+		}
+
+		items = append(items, definedName)
+	}
+
+	dn.workbook.ml.DefinedNames.Items = items
+	dn.workbook.file.MarkAsUpdated()
+}
+
+//shift updates references to sheet with name after inserting (n > 0) or deleting (n < 0) of n rows or cols at 0-based index at
+func (dn *definedNames) shift(name string, at, n int, cols bool) {
+	for _, definedName := range dn.workbook.ml.DefinedNames.Items {
+		var content string
+		if cols {
+			content = formula.ShiftCols(definedName.Formula, "", name, at, n)
+		} else {
+			content = formula.ShiftRows(definedName.Formula, "", name, at, n)
+		}
+
+		if content != definedName.Formula {
+			definedName.Formula = content
+			dn.workbook.file.MarkAsUpdated()
+		}
+	}
+}
+
+//rename updates references to sheet after renaming it from name from to name to
+func (dn *definedNames) rename(from, to string) {
+	for _, definedName := range dn.workbook.ml.DefinedNames.Items {
+		if content := formula.RenameSheet(definedName.Formula, from, to); content != definedName.Formula {
+			definedName.Formula = content
+			dn.workbook.file.MarkAsUpdated()
+		}
+	}
+}
+
+//moveSheet updates sheet-level defined names after moving of sheet from 0-based index from to 0-based index to
+func (dn *definedNames) moveSheet(from, to int) {
+	for _, definedName := range dn.workbook.ml.DefinedNames.Items {
+		if definedName.LocalSheetID != nil {
+			localSheetID := movedIndex(*definedName.LocalSheetID, from, to)
+			definedName.LocalSheetID = &localSheetID
+		}
+	}
+
+	dn.workbook.file.MarkAsUpdated()
+}
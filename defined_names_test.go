@@ -0,0 +1,73 @@
+package xlsx
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestDefinedNames(t *testing.T) {
+	xl := New()
+	sheet1 := xl.AddSheet("Sheet1")
+	sheet2 := xl.AddSheet("Sheet2")
+
+	//invalid names
+	require.NotNil(t, xl.DefineName("", "Sheet1!$B$2"))
+	require.NotNil(t, xl.DefineName("A1", "Sheet1!$B$2"))
+	require.NotNil(t, xl.DefineName("R1C1", "Sheet1!$B$2"))
+	require.NotNil(t, xl.DefineName("c", "Sheet1!$B$2"))
+	require.NotNil(t, xl.DefineName("1Rate", "Sheet1!$B$2"))
+	require.NotNil(t, xl.DefineName("Tax Rate", "Sheet1!$B$2"))
+	require.NotNil(t, xl.DefineName(strings.Repeat("a", 256), "Sheet1!$B$2"))
+	require.NotNil(t, xl.DefineName("TaxRate", ""))
+
+	//workbook-level names
+	require.Nil(t, xl.DefineName("TaxRate", "Sheet1!$B$2"))
+	require.Nil(t, xl.DefineName("_Total", "=SUM(Sheet1!$A$1:$A$10)"))
+	require.Equal(t, "Sheet1!$B$2", xl.DefinedName("TaxRate"))
+	require.Equal(t, "Sheet1!$B$2", xl.DefinedName("taxrate"))
+	require.Equal(t, "SUM(Sheet1!$A$1:$A$10)", xl.DefinedName("_Total"))
+	require.Equal(t, []string{"TaxRate", "_Total"}, xl.DefinedNames())
+
+	//update name
+	require.Nil(t, xl.DefineName("TAXRATE", "Sheet1!$B$3"))
+	require.Equal(t, "Sheet1!$B$3", xl.DefinedName("TaxRate"))
+	require.Equal(t, 2, len(xl.DefinedNames()))
+
+	//sheet-level names
+	require.Nil(t, sheet1.DefineName("TaxRate", "Sheet1!$C$2"))
+	require.Nil(t, sheet2.DefineName("TaxRate", "Sheet2!$C$2"))
+	require.Nil(t, sheet2.DefineName("Discount", "Sheet2!$D$2"))
+	require.Equal(t, "Sheet1!$C$2", sheet1.DefinedName("TaxRate"))
+	require.Equal(t, "Sheet2!$C$2", sheet2.DefinedName("TaxRate"))
+	require.Equal(t, "Sheet1!$B$3", xl.DefinedName("TaxRate"))
+	require.Equal(t, "", sheet1.DefinedName("Discount"))
+	require.Equal(t, []string{"TaxRate", "Discount"}, sheet2.DefinedNames())
+
+	//remove name
+	xl.DeleteName("_Total")
+	require.Equal(t, "", xl.DefinedName("_Total"))
+	require.Equal(t, []string{"TaxRate"}, xl.DefinedNames())
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	require.Equal(t, "Sheet1!$B$3", xl.DefinedName("TaxRate"))
+	require.Equal(t, "Sheet1!$C$2", xl.Sheet(0).DefinedName("TaxRate"))
+	require.Equal(t, "Sheet2!$D$2", xl.Sheet(1).DefinedName("Discount"))
+
+	//delete sheet with names
+	xl.DeleteSheet(0)
+	require.Equal(t, "Sheet1!$B$3", xl.DefinedName("TaxRate"))
+	require.Equal(t, "Sheet2!$C$2", xl.Sheet(0).DefinedName("TaxRate"))
+	require.Equal(t, []string{"TaxRate", "Discount"}, xl.Sheet(0).DefinedNames())
+
+	xl.Sheet(0).DeleteName("TaxRate")
+	require.Equal(t, []string{"Discount"}, xl.Sheet(0).DefinedNames())
+}
@@ -0,0 +1,321 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/ooxml"
+	sharedML "github.com/plandem/ooxml/ml"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//w3cDateLayout is a layout of dates that is used by document properties
+const w3cDateLayout = "2006-01-02T15:04:05Z"
+
+//DocProperties is a higher level object that wraps core, extended and custom properties of document
+type DocProperties struct {
+	doc        *Spreadsheet
+	core       ml.CoreProperties
+	coreFile   *ooxml.PackageFile
+	app        ml.ExtendedProperties
+	appFile    *ooxml.PackageFile
+	custom     ml.CustomProperties
+	customFile *ooxml.PackageFile
+}
+
+//newDocProperties creates an object that implements document properties functionality
+func newDocProperties(doc *Spreadsheet) *DocProperties {
+	return &DocProperties{doc: doc}
+}
+
+//attach attaches existing file with properties
+func (p *DocProperties) attach(f interface{}, name string) {
+	switch name {
+	case "docProps/core.xml":
+		p.coreFile = ooxml.NewPackageFile(p.doc.pkg, f, &p.core, nil)
+	case "docProps/app.xml":
+		p.appFile = ooxml.NewPackageFile(p.doc.pkg, f, &p.app, nil)
+	case "docProps/custom.xml":
+		p.customFile = ooxml.NewPackageFile(p.doc.pkg, f, &p.custom, nil)
+	}
+}
+
+//fileIfRequired loads file with properties or adds a new one if required and marks it as updated if update is true
+func (p *DocProperties) fileIfRequired(file **ooxml.PackageFile, target interface{}, name string, contentType sharedML.ContentType, relationType sharedML.RelationType, update bool) {
+	if *file == nil {
+		if !update {
+			return
+		}
+
+		*file = ooxml.NewPackageFile(p.doc.pkg, name, target, nil)
+		p.doc.pkg.ContentTypes().RegisterContent(name, contentType)
+		p.doc.pkg.Relationships().AddFile(relationType, name)
+	}
+
+	(*file).LoadIfRequired(nil)
+	if update {
+		(*file).MarkAsUpdated()
+	}
+}
+
+//coreIfRequired returns core properties, file will be added if required
+func (p *DocProperties) coreIfRequired(update bool) *ml.CoreProperties {
+	p.fileIfRequired(&p.coreFile, &p.core, "docProps/core.xml", internal.ContentTypeCoreProps, internal.RelationTypeCoreProps, update)
+	return &p.core
+}
+
+//appIfRequired returns extended properties, file will be added if required
+func (p *DocProperties) appIfRequired(update bool) *ml.ExtendedProperties {
+	p.fileIfRequired(&p.appFile, &p.app, "docProps/app.xml", internal.ContentTypeExtendedProps, internal.RelationTypeExtendedProps, update)
+	p.app.NamespaceVT = ml.NamespaceDocPropsVTypes
+	return &p.app
+}
+
+//customIfRequired returns custom properties, file will be added if required
+func (p *DocProperties) customIfRequired(update bool) *ml.CustomProperties {
+	p.fileIfRequired(&p.customFile, &p.custom, "docProps/custom.xml", internal.ContentTypeCustomProps, internal.RelationTypeCustomProps, update)
+	p.custom.NamespaceVT = ml.NamespaceDocPropsVTypes
+	return &p.custom
+}
+
+//Title returns title of document
+func (p *DocProperties) Title() string {
+	return p.coreIfRequired(false).Title
+}
+
+//SetTitle sets title of document
+func (p *DocProperties) SetTitle(title string) {
+	p.coreIfRequired(true).Title = title
+}
+
+//Subject returns subject of document
+func (p *DocProperties) Subject() string {
+	return p.coreIfRequired(false).Subject
+}
+
+//SetSubject sets subject of document
+func (p *DocProperties) SetSubject(subject string) {
+	p.coreIfRequired(true).Subject = subject
+}
+
+//Creator returns author of document
+func (p *DocProperties) Creator() string {
+	return p.coreIfRequired(false).Creator
+}
+
+//SetCreator sets author of document
+func (p *DocProperties) SetCreator(creator string) {
+	p.coreIfRequired(true).Creator = creator
+}
+
+//Keywords returns keywords of document
+func (p *DocProperties) Keywords() string {
+	return p.coreIfRequired(false).Keywords
+}
+
+//SetKeywords sets keywords of document, e.g.: SetKeywords("report; finance")
+func (p *DocProperties) SetKeywords(keywords string) {
+	p.coreIfRequired(true).Keywords = keywords
+}
+
+//Description returns description of document
+func (p *DocProperties) Description() string {
+	return p.coreIfRequired(false).Description
+}
+
+//SetDescription sets description of document
+func (p *DocProperties) SetDescription(description string) {
+	p.coreIfRequired(true).Description = description
+}
+
+//Category returns category of document
+func (p *DocProperties) Category() string {
+	return p.coreIfRequired(false).Category
+}
+
+//SetCategory sets category of document
+func (p *DocProperties) SetCategory(category string) {
+	p.coreIfRequired(true).Category = category
+}
+
+//LastModifiedBy returns name of user who modified document last time
+func (p *DocProperties) LastModifiedBy() string {
+	return p.coreIfRequired(false).LastModifiedBy
+}
+
+//SetLastModifiedBy sets name of user who modified document last time
+func (p *DocProperties) SetLastModifiedBy(name string) {
+	p.coreIfRequired(true).LastModifiedBy = name
+}
+
+//Created returns time of creation of document or zero time if there is no such information
+func (p *DocProperties) Created() time.Time {
+	created, _ := time.Parse(time.RFC3339, p.coreIfRequired(false).Created)
+	return created
+}
+
+//SetCreated sets time of creation of document
+func (p *DocProperties) SetCreated(created time.Time) {
+	p.coreIfRequired(true).Created = created.UTC().Format(w3cDateLayout)
+}
+
+//Modified returns time of last modification of document or zero time if there is no such information
+func (p *DocProperties) Modified() time.Time {
+	modified, _ := time.Parse(time.RFC3339, p.coreIfRequired(false).Modified)
+	return modified
+}
+
+//SetModified sets time of last modification of document
+func (p *DocProperties) SetModified(modified time.Time) {
+	p.coreIfRequired(true).Modified = modified.UTC().Format(w3cDateLayout)
+}
+
+//Application returns name of application that created document
+func (p *DocProperties) Application() string {
+	return p.appIfRequired(false).Application
+}
+
+//SetApplication sets name of application that created document
+func (p *DocProperties) SetApplication(application string) {
+	p.appIfRequired(true).Application = application
+}
+
+//Company returns name of company of document
+func (p *DocProperties) Company() string {
+	return p.appIfRequired(false).Company
+}
+
+//SetCompany sets name of company of document
+func (p *DocProperties) SetCompany(company string) {
+	p.appIfRequired(true).Company = company
+}
+
+//Manager returns name of manager of document
+func (p *DocProperties) Manager() string {
+	return p.appIfRequired(false).Manager
+}
+
+//SetManager sets name of manager of document
+func (p *DocProperties) SetManager(manager string) {
+	p.appIfRequired(true).Manager = manager
+}
+
+//Custom returns value of custom property with name or nil if there is no such property. Value can be string, int, float64, bool or time.Time
+func (p *DocProperties) Custom(name string) interface{} {
+	for _, property := range p.customIfRequired(false).Items {
+		if property.Name == name {
+			return fromVariant(property.Value)
+		}
+	}
+
+	return nil
+}
+
+//SetCustom adds a new or updates existing custom property with name, where value can be string, integer, float, bool or time.Time, e.g.: SetCustom("Reviewed", true)
+func (p *DocProperties) SetCustom(name string, value interface{}) error {
+	if len(name) == 0 {
+		return errors.New("name of custom property can't be empty")
+	}
+
+	variant, err := toVariant(value)
+	if err != nil {
+		return err
+	}
+
+	custom := p.customIfRequired(true)
+	pid := 1
+	for _, property := range custom.Items {
+		if property.Name == name {
+			property.Value = variant
+			return nil
+		}
+
+		if property.PID > pid {
+			pid = property.PID
+		}
+	}
+
+	//N.B.: pid of custom properties starts with 2
+	custom.Items = append(custom.Items, &ml.CustomProperty{
+		FmtID: ml.CustomPropertiesFmtID,
+		PID:   pid + 1,
+		Name:  name,
+		Value: variant,
+	})
+
+	return nil
+}
+
+//DeleteCustom deletes custom property with name
+func (p *DocProperties) DeleteCustom(name string) {
+	custom := p.customIfRequired(false)
+	for i, property := range custom.Items {
+		if property.Name == name {
+			custom.Items = append(custom.Items[:i], custom.Items[i+1:]...)
+			p.customFile.MarkAsUpdated()
+			return
+		}
+	}
+}
+
+//CustomNames returns sorted names of all custom properties
+func (p *DocProperties) CustomNames() []string {
+	var names []string
+	for _, property := range p.customIfRequired(false).Items {
+		names = append(names, property.Name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+//toVariant returns variant for value of custom property
+func toVariant(value interface{}) (ml.Variant, error) {
+	switch v := value.(type) {
+	case string:
+		return ml.Variant{Type: "lpwstr", Value: v}, nil
+	case bool:
+		return ml.Variant{Type: "bool", Value: strconv.FormatBool(v)}, nil
+	case int, int8, int16, int32, int64, uint8, uint16, uint32:
+		i, _ := strconv.ParseInt(fmt.Sprint(v), 10, 64)
+		if i < math.MinInt32 || i > math.MaxInt32 {
+			return ml.Variant{Type: "r8", Value: strconv.FormatInt(i, 10)}, nil
+		}
+
+		return ml.Variant{Type: "i4", Value: strconv.FormatInt(i, 10)}, nil
+	case float32:
+		return ml.Variant{Type: "r8", Value: strconv.FormatFloat(float64(v), 'f', -1, 32)}, nil
+	case float64:
+		return ml.Variant{Type: "r8", Value: strconv.FormatFloat(v, 'f', -1, 64)}, nil
+	case time.Time:
+		return ml.Variant{Type: "filetime", Value: v.UTC().Format(w3cDateLayout)}, nil
+	}
+
+	return ml.Variant{}, errors.New(fmt.Sprintf("unsupported type of custom property value: %T", value))
+}
+
+//fromVariant returns value of custom property for variant. Unknown types are returned as string
+func fromVariant(variant ml.Variant) interface{} {
+	switch variant.Type {
+	case "i1", "i2", "i4", "i8", "int", "ui1", "ui2", "ui4", "ui8", "uint":
+		if i, err := strconv.Atoi(variant.Value); err == nil {
+			return i
+		}
+	case "r4", "r8", "decimal":
+		if f, err := strconv.ParseFloat(variant.Value, 64); err == nil {
+			return f
+		}
+	case "bool":
+		return variant.Value == "true" || variant.Value == "1"
+	case "filetime", "date":
+		if t, err := time.Parse(time.RFC3339, variant.Value); err == nil {
+			return t
+		}
+	}
+
+	return variant.Value
+}
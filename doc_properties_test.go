@@ -0,0 +1,52 @@
+package xlsx
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDocProperties(t *testing.T) {
+	created := time.Date(2019, 3, 1, 10, 20, 30, 0, time.UTC)
+
+	xl := New()
+	xl.AddSheet("Properties")
+
+	props := xl.Properties()
+	require.Equal(t, "", props.Title())
+	require.Equal(t, true, props.Created().IsZero())
+	require.Nil(t, props.Custom("Reviewed"))
+
+	props.SetTitle("Report")
+	props.SetCreator("plandem")
+	props.SetCreated(created)
+	props.SetCompany("Company")
+	require.Nil(t, props.SetCustom("Reviewed", true))
+	require.Nil(t, props.SetCustom("Revision", 2))
+	require.Nil(t, props.SetCustom("Rate", 0.5))
+	require.Nil(t, props.SetCustom("Owner", "finance"))
+	require.Nil(t, props.SetCustom("Owner", "compliance"))
+	require.Nil(t, props.SetCustom("Date", created))
+	require.NotNil(t, props.SetCustom("Unknown", struct{}{}))
+	require.NotNil(t, props.SetCustom("", 1))
+	props.DeleteCustom("Rate")
+
+	require.Nil(t, xl.SaveAs("./test_files/tmp.xlsx"))
+	xl.Close()
+
+	xl, err := Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	props = xl.Properties()
+	require.Equal(t, "Report", props.Title())
+	require.Equal(t, "plandem", props.Creator())
+	require.Equal(t, created, props.Created())
+	require.Equal(t, "Company", props.Company())
+	require.Equal(t, []string{"Date", "Owner", "Reviewed", "Revision"}, props.CustomNames())
+	require.Equal(t, true, props.Custom("Reviewed"))
+	require.Equal(t, 2, props.Custom("Revision"))
+	require.Equal(t, "compliance", props.Custom("Owner"))
+	require.Equal(t, created, props.Custom("Date"))
+	require.Nil(t, props.Custom("Rate"))
+}
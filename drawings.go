@@ -0,0 +1,408 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/plandem/ooxml"
+	sharedML "github.com/plandem/ooxml/ml"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/types"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+)
+
+//imageContentTypes is a list of supported formats of image with related content types
+var imageContentTypes = map[string]sharedML.ContentType{
+	"png":  internal.ContentTypePng,
+	"jpeg": internal.ContentTypeJpeg,
+	"gif":  internal.ContentTypeGif,
+}
+
+const (
+	emuPerPixel          = 9525
+	defaultColumnWidthPx = 64
+	defaultRowHeightPx   = 20
+)
+
+type drawings struct {
+	sheet         *sheetInfo
+	ml            ml.SpreadsheetDrawing
+	file          *ooxml.PackageFile
+	relationships *ooxml.Relationships
+	charts        []*ml.ChartSpace
+	isLoaded      bool
+}
+
+//newDrawings creates an object that implements drawings functionality
+func newDrawings(sheet *sheetInfo) *drawings {
+	return &drawings{sheet: sheet}
+}
+
+//loadIfRequired lookups for existing drawing of sheet and loads it
+func (d *drawings) loadIfRequired() {
+	if d.isLoaded {
+		return
+	}
+
+	d.isLoaded = true
+
+	//only existing sheets can have existing drawing
+	if d.sheet.file.IsNew() || d.sheet.ml.Drawing == nil {
+		return
+	}
+
+	doc := d.sheet.workbook.doc
+	d.sheet.attachRelationshipsIfRequired()
+	fileName := d.sheet.relationships.GetTargetById(string(d.sheet.ml.Drawing.RID))
+	if zf, ok := doc.pkg.File(fileName).(*zip.File); ok {
+		d.file = ooxml.NewPackageFile(doc.pkg, zf, &d.ml, nil)
+		d.file.LoadIfRequired(nil)
+	}
+}
+
+//initIfRequired creates a new drawing file if required
+func (d *drawings) initIfRequired() {
+	d.loadIfRequired()
+
+	if d.file == nil {
+		doc := d.sheet.workbook.doc
+		fileName := doc.uniqueFileName("xl/drawings/drawing%d.xml")
+		d.file = ooxml.NewPackageFile(doc.pkg, fileName, &d.ml, nil)
+		doc.pkg.ContentTypes().RegisterContent(fileName, internal.ContentTypeDrawing)
+		d.sheet.attachRelationshipsIfRequired()
+		_, rid := d.sheet.relationships.AddFile(internal.RelationTypeDrawing, fileName)
+		d.sheet.ml.Drawing = &ml.Drawing{RID: rid}
+	}
+}
+
+//attachRelationshipsIfRequired attaches relationships of drawing
+func (d *drawings) attachRelationshipsIfRequired() {
+	if d.relationships == nil {
+		doc := d.sheet.workbook.doc
+		fileName := fmt.Sprintf("xl/drawings/_rels/%s.rels", filepath.Base(d.file.FileName()))
+
+		if file := doc.pkg.File(fileName); file != nil {
+			d.relationships = ooxml.NewRelationships(file, doc.pkg)
+		} else {
+			d.relationships = ooxml.NewRelationships(fileName, doc.pkg)
+		}
+	}
+}
+
+//nextID returns a next unique id of drawing object
+func (d *drawings) nextID() int {
+	id := 0
+	resolve := func(picture *ml.Picture, frame *ml.GraphicFrame) {
+		if picture != nil && picture.NonVisual.DrawingProperties.ID > id {
+			id = picture.NonVisual.DrawingProperties.ID
+		}
+
+		if frame != nil && frame.NonVisual.DrawingProperties.ID > id {
+			id = frame.NonVisual.DrawingProperties.ID
+		}
+	}
+
+	for _, anchor := range d.ml.TwoCellAnchors {
+		resolve(anchor.Picture, anchor.GraphicFrame)
+	}
+
+	for _, anchor := range d.ml.OneCellAnchors {
+		resolve(anchor.Picture, anchor.GraphicFrame)
+	}
+
+	return id + 1
+}
+
+//columnWidth returns width of column with 0-based index in pixels
+func (d *drawings) columnWidth(index int) int {
+	//Cols has 1-based index
+	index++
+
+	for _, c := range d.sheet.ml.Cols.Items {
+		if index >= c.Min && index <= c.Max && c.Width > 0 {
+			return int(float64(c.Width)*7+0.5) + 5
+		}
+	}
+
+	return defaultColumnWidthPx
+}
+
+//rowHeight returns height of row with 0-based index in pixels
+func (d *drawings) rowHeight(index int) int {
+	for _, r := range d.sheet.ml.SheetData {
+		if r != nil && r.Ref == index+1 && r.Height > 0 {
+			return int(float64(r.Height)*4/3 + 0.5)
+		}
+	}
+
+	return defaultRowHeightPx
+}
+
+//toMarker converts position in pixels relative to top left corner of cell into marker
+func (d *drawings) toMarker(colIndex, rowIndex, x, y int) *ml.DrawingMarker {
+	for width := d.columnWidth(colIndex); x >= width && colIndex < internal.ExcelColumnLimit; width = d.columnWidth(colIndex) {
+		x -= width
+		colIndex++
+	}
+
+	for height := d.rowHeight(rowIndex); y >= height && rowIndex < internal.ExcelRowLimit; height = d.rowHeight(rowIndex) {
+		y -= height
+		rowIndex++
+	}
+
+	return &ml.DrawingMarker{
+		Col:       colIndex,
+		ColOffset: x * emuPerPixel,
+		Row:       rowIndex,
+		RowOffset: y * emuPerPixel,
+	}
+}
+
+//addMedia adds content of image to package and returns name of file
+func (d *drawings) addMedia(content []byte, format string) string {
+	doc := d.sheet.workbook.doc
+	fileName := doc.uniqueFileName(fmt.Sprintf("xl/media/image%%d.%s", format))
+	doc.pkg.Add(fileName, content)
+	doc.pkg.ContentTypes().RegisterType(format, imageContentTypes[format])
+	return fileName
+}
+
+//removeMediaIfUnused removes media file, e.g.: image, if there are no relationships with it anymore
+func (xl *Spreadsheet) removeMediaIfUnused(fileName string) {
+	target := []byte("media/" + filepath.Base(fileName) + `"`)
+	for name, file := range xl.pkg.Files() {
+		if filepath.Ext(name) != ".rels" {
+			continue
+		}
+
+		var content []byte
+		switch f := file.(type) {
+		case *zip.File:
+			content = readZipFile(f)
+		case []byte:
+			content = f
+		default:
+			content, _ = xml.Marshal(f)
+		}
+
+		if bytes.Contains(content, target) {
+			return
+		}
+	}
+
+	xl.pkg.Remove(fileName)
+}
+
+//readImage reads content of image and returns it with format and config of image if format is supported
+func readImage(reader io.Reader) ([]byte, string, image.Config, error) {
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, "", image.Config{}, err
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, "", image.Config{}, errors.New(fmt.Sprintf("unsupported format of image: %s", err))
+	}
+
+	if _, ok := imageContentTypes[format]; !ok {
+		return nil, "", image.Config{}, errors.New(fmt.Sprintf("unsupported format of image: %s", format))
+	}
+
+	return content, format, config, nil
+}
+
+//AddImage adds a new image with top left corner at cell ref
+func (d *drawings) AddImage(ref types.CellRef, reader io.Reader, settings *options.ImageOptions) error {
+	if reader == nil {
+		return errors.New("no image")
+	}
+
+	if settings == nil {
+		settings = options.NewImageOptions()
+	}
+
+	content, format, config, err := readImage(reader)
+	if err != nil {
+		return err
+	}
+
+	scaleX, scaleY := settings.ScaleX, settings.ScaleY
+	if scaleX <= 0 {
+		scaleX = 1
+	}
+
+	if scaleY <= 0 {
+		scaleY = 1
+	}
+
+	width := int(float64(config.Width)*scaleX + 0.5)
+	height := int(float64(config.Height)*scaleY + 0.5)
+
+	d.initIfRequired()
+	d.attachRelationshipsIfRequired()
+	_, rid := d.relationships.AddFile(internal.RelationTypeImage, d.addMedia(content, format))
+
+	id := d.nextID()
+	name := settings.Name
+	if len(name) == 0 {
+		name = fmt.Sprintf("Picture %d", id)
+	}
+
+	picture := &ml.Picture{
+		NonVisual: ml.PictureNonVisual{
+			DrawingProperties: ml.NonVisualDrawingProperties{
+				ID:          id,
+				Name:        name,
+				Description: settings.Description,
+			},
+			PictureProperties: ml.NonVisualPictureProperties{
+				PictureLocks: &ml.PictureLocks{NoChangeAspect: true},
+			},
+		},
+		BlipFill: ml.BlipFill{
+			Blip:    &ml.Blip{Embed: rid},
+			Stretch: &ml.Stretch{FillRect: &sharedML.Reserved{}},
+		},
+		ShapeProperties: ml.ShapeProperties{
+			Transform: &ml.Transform2D{
+				Offset: &ml.Point2D{},
+				Ext:    &ml.PositiveSize2D{Width: width * emuPerPixel, Height: height * emuPerPixel},
+			},
+			PresetGeometry: &ml.PresetGeometry{
+				AdjustValues: &sharedML.Reserved{},
+				Preset:       "rect",
+			},
+		},
+	}
+
+	cIdx, rIdx := ref.ToIndexes()
+	from := d.toMarker(cIdx, rIdx, settings.OffsetX, settings.OffsetY)
+
+	if settings.Anchor == options.ImageAnchorTwoCell {
+		toX := from.ColOffset/emuPerPixel + width
+		toY := from.RowOffset/emuPerPixel + height
+		d.ml.TwoCellAnchors = append(d.ml.TwoCellAnchors, &ml.TwoCellAnchor{
+			From:    from,
+			To:      d.toMarker(from.Col, from.Row, toX, toY),
+			Picture: picture,
+			EditAs:  "twoCell",
+		})
+	} else {
+		d.ml.OneCellAnchors = append(d.ml.OneCellAnchors, &ml.OneCellAnchor{
+			From:    from,
+			Ext:     &ml.PositiveSize2D{Width: width * emuPerPixel, Height: height * emuPerPixel},
+			Picture: picture,
+		})
+	}
+
+	d.file.MarkAsUpdated()
+	return nil
+}
+
+//copyImages copies images of other drawings with same anchors
+func (d *drawings) copyImages(from *drawings) error {
+	from.loadIfRequired()
+	if from.file == nil {
+		return nil
+	}
+
+	from.attachRelationshipsIfRequired()
+	copyPicture := func(picture *ml.Picture) (*ml.Picture, error) {
+		if picture == nil || picture.BlipFill.Blip == nil {
+			return nil, nil
+		}
+
+		fileName := from.relationships.GetTargetById(string(picture.BlipFill.Blip.Embed))
+
+		var content []byte
+		switch file := from.sheet.workbook.doc.pkg.File(fileName).(type) {
+		case *zip.File:
+			reader, err := file.Open()
+			if err != nil {
+				return nil, err
+			}
+
+			content, err = ioutil.ReadAll(reader)
+			_ = reader.Close()
+			if err != nil {
+				return nil, err
+			}
+		case []byte:
+			content = file
+		default:
+			return nil, nil
+		}
+
+		//only supported formats of images can be copied
+		_, format, err := image.DecodeConfig(bytes.NewReader(content))
+		if _, ok := imageContentTypes[format]; err != nil || !ok {
+			return nil, nil
+		}
+
+		d.initIfRequired()
+		d.attachRelationshipsIfRequired()
+		_, rid := d.relationships.AddFile(internal.RelationTypeImage, d.addMedia(content, format))
+
+		copied := *picture
+		copied.NonVisual.DrawingProperties.ID = d.nextID()
+		blip := *picture.BlipFill.Blip
+		blip.Embed = rid
+		copied.BlipFill.Blip = &blip
+		return &copied, nil
+	}
+
+	copyMarker := func(marker *ml.DrawingMarker) *ml.DrawingMarker {
+		if marker == nil {
+			return nil
+		}
+
+		copied := *marker
+		return &copied
+	}
+
+	for _, anchor := range from.ml.TwoCellAnchors {
+		picture, err := copyPicture(anchor.Picture)
+		if err != nil {
+			return err
+		}
+
+		if picture != nil {
+			copied := *anchor
+			copied.From = copyMarker(anchor.From)
+			copied.To = copyMarker(anchor.To)
+			copied.Picture = picture
+			d.ml.TwoCellAnchors = append(d.ml.TwoCellAnchors, &copied)
+		}
+	}
+
+	for _, anchor := range from.ml.OneCellAnchors {
+		picture, err := copyPicture(anchor.Picture)
+		if err != nil {
+			return err
+		}
+
+		if picture != nil {
+			copied := *anchor
+			copied.From = copyMarker(anchor.From)
+			copied.Picture = picture
+			d.ml.OneCellAnchors = append(d.ml.OneCellAnchors, &copied)
+		}
+	}
+
+	if d.file != nil {
+		d.file.MarkAsUpdated()
+	}
+
+	return nil
+}
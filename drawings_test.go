@@ -0,0 +1,70 @@
+package xlsx
+
+import (
+	"bytes"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/options"
+	"github.com/stretchr/testify/require"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestDrawings(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 100, 50))
+	buf := &bytes.Buffer{}
+	require.Nil(t, png.Encode(buf, img))
+
+	xl := New()
+	sheet := xl.AddSheet("Sheet1")
+
+	//unsupported images
+	require.NotNil(t, sheet.AddImage("A1", nil, nil))
+	require.NotNil(t, sheet.AddImage("A1", strings.NewReader("not an image"), nil))
+	require.Nil(t, sheet.info().ml.Drawing)
+
+	//one cell anchor
+	require.Nil(t, sheet.AddImage("B2", bytes.NewReader(buf.Bytes()), options.NewImageOptions(
+		options.Image.Offset(10, 5),
+		options.Image.Name("Logo"),
+	)))
+
+	drawings := sheet.info().drawings
+	require.NotNil(t, sheet.info().ml.Drawing)
+	require.Equal(t, 1, len(drawings.ml.OneCellAnchors))
+	anchor := drawings.ml.OneCellAnchors[0]
+	require.Equal(t, &ml.DrawingMarker{Col: 1, ColOffset: 10 * 9525, Row: 1, RowOffset: 5 * 9525}, anchor.From)
+	require.Equal(t, &ml.PositiveSize2D{Width: 100 * 9525, Height: 50 * 9525}, anchor.Ext)
+	require.Equal(t, "Logo", anchor.Picture.NonVisual.DrawingProperties.Name)
+	require.Equal(t, 1, anchor.Picture.NonVisual.DrawingProperties.ID)
+
+	//two cell anchor with scaling
+	require.Nil(t, sheet.AddImage("A1", bytes.NewReader(buf.Bytes()), options.NewImageOptions(
+		options.Image.Anchor(options.ImageAnchorTwoCell),
+		options.Image.Scale(2, 2),
+	)))
+
+	require.Equal(t, 1, len(drawings.ml.TwoCellAnchors))
+	twoCellAnchor := drawings.ml.TwoCellAnchors[0]
+	require.Equal(t, &ml.DrawingMarker{Col: 0, Row: 0}, twoCellAnchor.From)
+	require.Equal(t, &ml.DrawingMarker{Col: 3, ColOffset: (200 - 3*64) * 9525, Row: 5, RowOffset: 0}, twoCellAnchor.To)
+	require.Equal(t, "Picture 2", twoCellAnchor.Picture.NonVisual.DrawingProperties.Name)
+	require.Equal(t, 2, drawings.relationships.Total())
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	require.Nil(t, sheet.AddImage("D4", bytes.NewReader(buf.Bytes()), nil))
+	drawings = sheet.info().drawings
+	require.Equal(t, 2, len(drawings.ml.OneCellAnchors))
+	require.Equal(t, 1, len(drawings.ml.TwoCellAnchors))
+	require.Equal(t, 3, drawings.ml.OneCellAnchors[1].Picture.NonVisual.DrawingProperties.ID)
+}
@@ -0,0 +1,80 @@
+package xlsx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal/cfb"
+	"github.com/plandem/xlsx/internal/crypto"
+	"io"
+	"io/ioutil"
+)
+
+//OpenWithPassword opens an encrypted XLSX file with name or io.Reader
+func OpenWithPassword(f interface{}, password string) (*Spreadsheet, error) {
+	var data []byte
+	var err error
+
+	switch source := f.(type) {
+	case string:
+		data, err = ioutil.ReadFile(source)
+	case io.Reader:
+		data, err = ioutil.ReadAll(source)
+	default:
+		err = errors.New(fmt.Sprintf("unsupported type of source = %T", f))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !cfb.IsCompoundFile(data) {
+		return nil, errors.New("file is not encrypted")
+	}
+
+	streams, err := cfb.Read(data)
+	if err != nil {
+		return nil, err
+	}
+
+	info, infoOk := streams[crypto.StreamEncryptionInfo]
+	encrypted, encryptedOk := streams[crypto.StreamEncryptedPackage]
+	if !infoOk || !encryptedOk {
+		return nil, errors.New("there is no encrypted package")
+	}
+
+	pkg, err := crypto.DecryptPackage(info, encrypted, password)
+	if err != nil {
+		return nil, err
+	}
+
+	return Open(bytes.NewReader(pkg))
+}
+
+//SaveAsEncrypted saves document encrypted with password into file with name or io.Writer
+func (xl *Spreadsheet) SaveAsEncrypted(f interface{}, password string) error {
+	var pkg bytes.Buffer
+	if err := xl.SaveAs(&pkg); err != nil {
+		return err
+	}
+
+	info, encrypted, err := crypto.EncryptPackage(pkg.Bytes(), password)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err = cfb.Write(&buf, &cfb.Stream{Name: crypto.StreamEncryptionInfo, Data: info}, &cfb.Stream{Name: crypto.StreamEncryptedPackage, Data: encrypted}); err != nil {
+		return err
+	}
+
+	switch target := f.(type) {
+	case string:
+		return ioutil.WriteFile(target, buf.Bytes(), 0644)
+	case io.Writer:
+		_, err = target.Write(buf.Bytes())
+		return err
+	}
+
+	return errors.New(fmt.Sprintf("unsupported type of target = %T", f))
+}
@@ -0,0 +1,45 @@
+package xlsx
+
+import (
+	"bytes"
+	"github.com/plandem/xlsx/internal/crypto"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestEncryption(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Secret")
+	sheet.CellByRef("A1").SetValue("top secret")
+	sheet.CellByRef("B1").SetValue(42)
+
+	require.Nil(t, xl.SaveAsEncrypted("./test_files/tmp_encrypted.xlsx", "password"))
+	xl.Close()
+
+	//encrypted file can't be opened as regular file
+	_, err := Open("./test_files/tmp_encrypted.xlsx")
+	require.NotNil(t, err)
+
+	_, err = OpenWithPassword("./test_files/tmp_encrypted.xlsx", "wrong")
+	require.Equal(t, crypto.ErrorInvalidPassword, err)
+
+	xl, err = OpenWithPassword("./test_files/tmp_encrypted.xlsx", "password")
+	require.Nil(t, err)
+	require.Equal(t, []string{"Secret"}, xl.GetSheetNames())
+	require.Equal(t, "top secret", xl.Sheet(0).CellByRef("A1").Value())
+	require.Equal(t, "42", xl.Sheet(0).CellByRef("B1").Value())
+
+	//save to writer and open from reader
+	var buf bytes.Buffer
+	require.Nil(t, xl.SaveAsEncrypted(&buf, "other"))
+	xl.Close()
+
+	xl, err = OpenWithPassword(bytes.NewReader(buf.Bytes()), "other")
+	require.Nil(t, err)
+	defer xl.Close()
+	require.Equal(t, "top secret", xl.Sheet(0).CellByRef("A1").Value())
+
+	//regular file is not encrypted
+	_, err = OpenWithPassword("./test_files/example_simple.xlsx", "password")
+	require.NotNil(t, err)
+}
@@ -0,0 +1,58 @@
+package errtype
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+)
+
+//Type is a type of error that Excel checks for cells and marks with green triangle, types can be combined, e.g.: errtype.NumberStoredAsText | errtype.FormulaRange
+type Type uint16
+
+//List of all possible values for Type
+const (
+	EvalError          Type = 1 << iota //formula results in an error
+	TwoDigitTextYear                    //formula contains text date with 2-digit year
+	NumberStoredAsText                  //number is formatted as text or preceded by an apostrophe
+	Formula                             //formula is inconsistent with formulas of neighbouring cells
+	FormulaRange                        //formula omits adjacent cells
+	UnlockedFormula                     //cell with formula is unlocked
+	EmptyCellReference                  //formula refers to empty cells
+	ListDataValidation                  //value is not valid for data validation with list
+	CalculatedColumn                    //formula is inconsistent with formula of calculated column of table
+)
+
+//private method used by sheet to unpack Type
+func fromType(t Type) *ml.IgnoredError {
+	return &ml.IgnoredError{
+		EvalError:          t&EvalError != 0,
+		TwoDigitTextYear:   t&TwoDigitTextYear != 0,
+		NumberStoredAsText: t&NumberStoredAsText != 0,
+		Formula:            t&Formula != 0,
+		FormulaRange:       t&FormulaRange != 0,
+		UnlockedFormula:    t&UnlockedFormula != 0,
+		EmptyCellReference: t&EmptyCellReference != 0,
+		ListDataValidation: t&ListDataValidation != 0,
+		CalculatedColumn:   t&CalculatedColumn != 0,
+	}
+}
+
+//private method used by sheet to pack Type
+func toType(e *ml.IgnoredError) Type {
+	var t Type
+	for flag, ignored := range map[Type]bool{
+		EvalError:          e.EvalError,
+		TwoDigitTextYear:   e.TwoDigitTextYear,
+		NumberStoredAsText: e.NumberStoredAsText,
+		Formula:            e.Formula,
+		FormulaRange:       e.FormulaRange,
+		UnlockedFormula:    e.UnlockedFormula,
+		EmptyCellReference: e.EmptyCellReference,
+		ListDataValidation: e.ListDataValidation,
+		CalculatedColumn:   e.CalculatedColumn,
+	} {
+		if ignored {
+			t |= flag
+		}
+	}
+
+	return t
+}
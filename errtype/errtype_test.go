@@ -0,0 +1,17 @@
+package errtype
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestType(t *testing.T) {
+	require.Equal(t, &ml.IgnoredError{}, fromType(0))
+	require.Equal(t, &ml.IgnoredError{NumberStoredAsText: true, FormulaRange: true}, fromType(NumberStoredAsText|FormulaRange))
+
+	all := EvalError | TwoDigitTextYear | NumberStoredAsText | Formula | FormulaRange | UnlockedFormula | EmptyCellReference | ListDataValidation | CalculatedColumn
+	require.Equal(t, all, toType(fromType(all)))
+	require.Equal(t, NumberStoredAsText, toType(&ml.IgnoredError{NumberStoredAsText: true}))
+	require.Equal(t, Type(0), toType(&ml.IgnoredError{}))
+}
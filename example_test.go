@@ -457,8 +457,8 @@ func Example_delete() {
 	// 14 28
 	// ,,,,,,,,,1,6,11,16,,,,,,,,,,,,,,,
 	// 13 28
-	// ,merged cols,,merged rows+cols,,,,,,2,7,12,17,,,,,,,,,,,,,,,
-	// ,,merged rows,merged rows+cols,,,,,,,,,
+	// ,merged cols,,merged rows+cols,merged rows+cols,,,,,2,7,12,17,,,,,,,,,,,,,,,
+	// ,,merged rows,merged rows+cols,merged rows+cols,merged rows+cols,,,,,,,
 	// 13 27
 	// with trailing space   ,,merged rows,,,,,,,,,,
 	// Sheet1
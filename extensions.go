@@ -0,0 +1,236 @@
+package xlsx
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	sharedML "github.com/plandem/ooxml/ml"
+	"github.com/plandem/xlsx/internal/ml"
+	"io"
+	"strings"
+)
+
+//ExtensionMarshaler is a custom marshaler of extension with uri for extLst of workbook or sheet, e.g. vendor-specific x14/x15 data that is not modeled by library
+type ExtensionMarshaler interface {
+	//ExtensionURI returns uri of extension, e.g.: {78C0D931-6437-407d-A8EE-F0AAD7539E65}
+	ExtensionURI() string
+	//MarshalExtension returns inner markup of extension. Namespaces that are used by markup must be declared by markup itself. Empty markup removes extension
+	MarshalExtension() ([]byte, error)
+	//UnmarshalExtension decodes inner markup of existing extension
+	UnmarshalExtension(data []byte) error
+}
+
+//addExtension adds encoded content into container of extension with uri, e.g.: x14:conditionalFormattings. Existing extension will be reused if possible
+func addExtension(extLst **sharedML.Reserved, uri string, container string, attrs string, content string) {
+	if *extLst == nil {
+		*extLst = &sharedML.Reserved{}
+	}
+
+	if (*extLst).InnerXML == nil {
+		(*extLst).InnerXML = &sharedML.InnerXML{}
+	}
+
+	list := (*extLst).InnerXML.XML
+	closing := "</" + container + ">"
+	if start := strings.Index(list, uri); start != -1 {
+		if end := strings.Index(list[start:], closing); end != -1 {
+			(*extLst).InnerXML.XML = list[:start+end] + content + list[start+end:]
+			return
+		}
+	}
+
+	(*extLst).InnerXML.XML = list + fmt.Sprintf(`<ext uri="%s" xmlns:x14="%s"><%s%s>%s%s</ext>`, uri, ml.NamespaceX14, container, attrs, content, closing)
+}
+
+//addExtension adds encoded content into container of extension of sheet with uri
+func (s *sheetInfo) addExtension(uri string, container string, attrs string, content string) {
+	addExtension(&s.ml.ExtLst, uri, container, attrs, content)
+}
+
+//addExtension adds encoded content into container of extension of workbook with uri
+func (wb *Workbook) addExtension(uri string, container string, attrs string, content string) {
+	addExtension(&wb.ml.ExtLst, uri, container, attrs, content)
+	wb.file.MarkAsUpdated()
+}
+
+//removeExtension removes extension with uri, e.g.: extension that refers to files of another sheet
+func removeExtension(extLst *sharedML.Reserved, uri string) {
+	if extLst == nil || extLst.InnerXML == nil {
+		return
+	}
+
+	list := extLst.InnerXML.XML
+	if start := strings.Index(list, fmt.Sprintf(`<ext uri="%s"`, uri)); start != -1 {
+		if end := strings.Index(list[start:], "</ext>"); end != -1 {
+			extLst.InnerXML.XML = list[:start] + list[start+end+len("</ext>"):]
+		}
+	}
+}
+
+//extensions returns known extensions of sheet
+func (s *sheetInfo) extensions() (*ml.ExtensionList, error) {
+	if s.ml.ExtLst == nil || s.ml.ExtLst.InnerXML == nil {
+		return &ml.ExtensionList{}, nil
+	}
+
+	return ml.UnmarshalExtensions(s.ml.ExtLst.InnerXML.XML)
+}
+
+//findExtension returns position of extension with uri: start of element, end of opening tag, start of closing tag and end of element. For empty element, end of opening tag and start of closing tag are equal
+func findExtension(list string, uri string) (start, from, to, end int, ok bool) {
+	if start = strings.Index(list, fmt.Sprintf(`<ext uri="%s"`, uri)); start == -1 {
+		return
+	}
+
+	opening := strings.Index(list[start:], ">")
+	if opening == -1 {
+		return
+	}
+
+	if from = start + opening + 1; list[from-2] == '/' {
+		return start, from, from, from, true
+	}
+
+	closing := strings.Index(list[from:], "</ext>")
+	if closing == -1 {
+		return
+	}
+
+	to = from + closing
+	return start, from, to, to + len("</ext>"), true
+}
+
+//extensionContent returns inner markup of extension with uri or false if there is no such extension
+func extensionContent(extLst *sharedML.Reserved, uri string) (string, bool) {
+	if extLst == nil || extLst.InnerXML == nil {
+		return "", false
+	}
+
+	list := extLst.InnerXML.XML
+	if _, from, to, _, ok := findExtension(list, uri); ok {
+		return list[from:to], true
+	}
+
+	return "", false
+}
+
+//setExtension replaces inner markup of extension with uri or adds a new extension if required. Empty content removes extension. Returns true if extensions were changed
+func setExtension(extLst **sharedML.Reserved, uri string, content string) bool {
+	if existing, ok := extensionContent(*extLst, uri); ok && existing == content || !ok && len(content) == 0 {
+		return false
+	}
+
+	if *extLst == nil {
+		*extLst = &sharedML.Reserved{}
+	}
+
+	if (*extLst).InnerXML == nil {
+		(*extLst).InnerXML = &sharedML.InnerXML{}
+	}
+
+	list := (*extLst).InnerXML.XML
+	if start, from, to, end, ok := findExtension(list, uri); !ok {
+		list += fmt.Sprintf(`<ext uri="%s">%s</ext>`, uri, content)
+	} else if len(content) == 0 {
+		list = list[:start] + list[end:]
+	} else if from == end {
+		//empty element must be expanded to keep attributes of element, e.g. declarations of namespaces
+		list = list[:start] + strings.TrimSpace(list[start:from-2]) + ">" + content + "</ext>" + list[end:]
+	} else {
+		list = list[:from] + content + list[to:]
+	}
+
+	if len(list) == 0 {
+		*extLst = nil
+	} else {
+		(*extLst).InnerXML.XML = list
+	}
+
+	return true
+}
+
+//registerExtension unmarshals existing extension with uri of marshaler and adds marshaler into list. Marshaler with same uri will be replaced
+func registerExtension(list []ExtensionMarshaler, extLst *sharedML.Reserved, marshaler ExtensionMarshaler) ([]ExtensionMarshaler, error) {
+	if marshaler == nil || len(marshaler.ExtensionURI()) == 0 {
+		return list, errors.New("marshaler of extension with non empty uri is required")
+	}
+
+	uri := marshaler.ExtensionURI()
+	if content, ok := extensionContent(extLst, uri); ok {
+		if err := marshaler.UnmarshalExtension([]byte(content)); err != nil {
+			return list, errors.New(fmt.Sprintf("can't unmarshal extension %s: %s", uri, err))
+		}
+	}
+
+	for i, m := range list {
+		if m.ExtensionURI() == uri {
+			list[i] = marshaler
+			return list, nil
+		}
+	}
+
+	return append(list, marshaler), nil
+}
+
+//marshalExtensions updates extensions with markup of marshalers and returns true if extensions were changed
+func marshalExtensions(list []ExtensionMarshaler, extLst **sharedML.Reserved) (bool, error) {
+	changed := false
+	for _, marshaler := range list {
+		uri := marshaler.ExtensionURI()
+		content, err := marshaler.MarshalExtension()
+		if err != nil {
+			return changed, errors.New(fmt.Sprintf("can't marshal extension %s: %s", uri, err))
+		}
+
+		//markup must be well-formed to keep package valid
+		decoder := xml.NewDecoder(strings.NewReader(fmt.Sprintf("<ext>%s</ext>", content)))
+		for err == nil {
+			_, err = decoder.Token()
+		}
+
+		if err != io.EOF {
+			return changed, errors.New(fmt.Sprintf("markup of extension %s is not well-formed: %s", uri, err))
+		}
+
+		if setExtension(extLst, uri, string(content)) {
+			changed = true
+		}
+	}
+
+	return changed, nil
+}
+
+//RegisterExtension registers custom marshaler of extension for sheet. Existing extension with uri of marshaler is unmarshaled right away and markup of extension is updated with marshaler during saving
+func (s *sheetInfo) RegisterExtension(marshaler ExtensionMarshaler) error {
+	list, err := registerExtension(s.extensionMarshalers, s.ml.ExtLst, marshaler)
+	s.extensionMarshalers = list
+	return err
+}
+
+//marshalExtensions updates extensions of sheet with registered marshalers
+func (s *sheetInfo) marshalExtensions() error {
+	changed, err := marshalExtensions(s.extensionMarshalers, &s.ml.ExtLst)
+	if changed {
+		s.file.MarkAsUpdated()
+	}
+
+	return err
+}
+
+//RegisterExtension registers custom marshaler of extension for workbook. Existing extension with uri of marshaler is unmarshaled right away and markup of extension is updated with marshaler during saving
+func (xl *Spreadsheet) RegisterExtension(marshaler ExtensionMarshaler) error {
+	wb := xl.workbook
+	list, err := registerExtension(wb.extensionMarshalers, wb.ml.ExtLst, marshaler)
+	wb.extensionMarshalers = list
+	return err
+}
+
+//marshalExtensions updates extensions of workbook with registered marshalers
+func (wb *Workbook) marshalExtensions() error {
+	changed, err := marshalExtensions(wb.extensionMarshalers, &wb.ml.ExtLst)
+	if changed {
+		wb.file.MarkAsUpdated()
+	}
+
+	return err
+}
@@ -0,0 +1,110 @@
+package xlsx
+
+import (
+	sharedML "github.com/plandem/ooxml/ml"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type testExtension struct {
+	uri  string
+	data string
+}
+
+func (e *testExtension) ExtensionURI() string {
+	return e.uri
+}
+
+func (e *testExtension) MarshalExtension() ([]byte, error) {
+	return []byte(e.data), nil
+}
+
+func (e *testExtension) UnmarshalExtension(data []byte) error {
+	e.data = string(data)
+	return nil
+}
+
+func TestSetExtension(t *testing.T) {
+	var extLst *sharedML.Reserved
+
+	//add
+	require.Equal(t, false, setExtension(&extLst, "{A}", ""))
+	require.Nil(t, extLst)
+	require.Equal(t, true, setExtension(&extLst, "{A}", "<a/>"))
+	require.Equal(t, true, setExtension(&extLst, "{B}", "<b/>"))
+	require.Equal(t, `<ext uri="{A}"><a/></ext><ext uri="{B}"><b/></ext>`, extLst.InnerXML.XML)
+
+	content, ok := extensionContent(extLst, "{B}")
+	require.Equal(t, true, ok)
+	require.Equal(t, "<b/>", content)
+
+	_, ok = extensionContent(extLst, "{C}")
+	require.Equal(t, false, ok)
+
+	//replace
+	require.Equal(t, false, setExtension(&extLst, "{A}", "<a/>"))
+	require.Equal(t, true, setExtension(&extLst, "{A}", "<a>1</a>"))
+	require.Equal(t, `<ext uri="{A}"><a>1</a></ext><ext uri="{B}"><b/></ext>`, extLst.InnerXML.XML)
+
+	//empty element keeps attributes
+	extLst.InnerXML.XML = `<ext uri="{C}" xmlns:x15="http://schemas.microsoft.com/office/spreadsheetml/2010/11/main" />` + extLst.InnerXML.XML
+	content, ok = extensionContent(extLst, "{C}")
+	require.Equal(t, true, ok)
+	require.Equal(t, "", content)
+	require.Equal(t, true, setExtension(&extLst, "{C}", "<x15:c/>"))
+	require.Equal(t, `<ext uri="{C}" xmlns:x15="http://schemas.microsoft.com/office/spreadsheetml/2010/11/main"><x15:c/></ext><ext uri="{A}"><a>1</a></ext><ext uri="{B}"><b/></ext>`, extLst.InnerXML.XML)
+
+	//remove
+	require.Equal(t, true, setExtension(&extLst, "{A}", ""))
+	require.Equal(t, true, setExtension(&extLst, "{C}", ""))
+	require.Equal(t, `<ext uri="{B}"><b/></ext>`, extLst.InnerXML.XML)
+	require.Equal(t, true, setExtension(&extLst, "{B}", ""))
+	require.Nil(t, extLst)
+}
+
+func TestRegisterExtension(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Sheet1")
+	sheet.CellByRef("A1").SetValue("extensions")
+
+	//invalid marshalers
+	require.NotNil(t, sheet.RegisterExtension(nil))
+	require.NotNil(t, xl.RegisterExtension(&testExtension{}))
+
+	sheetExt := &testExtension{uri: "{7E03D99C-DC04-49d9-9315-930204A7B6E9}", data: `<x15:timelineRefs xmlns:x15="http://schemas.microsoft.com/office/spreadsheetml/2010/11/main"/>`}
+	bookExt := &testExtension{uri: "{D0CA8CA8-9F24-4464-BF8E-62219DCF47F9}", data: `<x15:timelineStyles xmlns:x15="http://schemas.microsoft.com/office/spreadsheetml/2010/11/main" defaultTimelineStyle="TimeSlicerStyleLight1"/>`}
+	require.Nil(t, sheet.RegisterExtension(sheetExt))
+	require.Nil(t, xl.RegisterExtension(bookExt))
+
+	//marshaler with same uri replaces previous one
+	require.Nil(t, sheet.RegisterExtension(sheetExt))
+	require.Equal(t, 1, len(sheet.info().extensionMarshalers))
+
+	//round trip
+	content, err := xl.Bytes()
+	require.Nil(t, err)
+
+	xl2, err := OpenBytes(content)
+	require.Nil(t, err)
+	defer xl2.Close()
+
+	loadedSheetExt := &testExtension{uri: sheetExt.uri}
+	loadedBookExt := &testExtension{uri: bookExt.uri}
+	require.Nil(t, xl2.Sheet(0).RegisterExtension(loadedSheetExt))
+	require.Nil(t, xl2.RegisterExtension(loadedBookExt))
+	require.Equal(t, sheetExt.data, loadedSheetExt.data)
+	require.Equal(t, bookExt.data, loadedBookExt.data)
+
+	//empty markup removes extension
+	loadedSheetExt.data = ""
+	_, err = xl2.Bytes()
+	require.Nil(t, err)
+	require.Nil(t, xl2.Sheet(0).info().ml.ExtLst)
+
+	//markup must be well-formed
+	loadedBookExt.data = "<x15:timelineStyles"
+	_, err = xl2.Bytes()
+	require.NotNil(t, err)
+}
@@ -0,0 +1,222 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"github.com/plandem/ooxml"
+	sharedML "github.com/plandem/ooxml/ml"
+	"github.com/plandem/xlsx/formula"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+	"path"
+)
+
+//ExternalLink is information about external workbook that is referenced by formulas, e.g.: [1]Sheet1!A1 refers to the first external link
+type ExternalLink struct {
+	Path   string
+	Sheets []string
+}
+
+type externalLinkItem struct {
+	ml            ml.ExternalLink
+	file          *ooxml.PackageFile
+	relationships *ooxml.Relationships
+	rid           sharedML.RID
+}
+
+type externalLinks struct {
+	doc      *Spreadsheet
+	items    []*externalLinkItem
+	isLoaded bool
+}
+
+//newExternalLinks creates an object that implements external links functionality
+func newExternalLinks(doc *Spreadsheet) *externalLinks {
+	return &externalLinks{doc: doc}
+}
+
+//loadIfRequired loads external links of workbook in order of references, so position of link is a 0-based index of link in formulas
+func (e *externalLinks) loadIfRequired() {
+	if e.isLoaded {
+		return
+	}
+
+	e.isLoaded = true
+
+	doc := e.doc
+	for _, ref := range doc.workbook.ml.ExternalReferences.Items {
+		item := &externalLinkItem{rid: ref.RID}
+		fileName := doc.relationships.GetTargetById(string(ref.RID))
+		if zf, ok := doc.pkg.File(fileName).(*zip.File); ok {
+			item.file = ooxml.NewPackageFile(doc.pkg, zf, &item.ml, nil)
+			item.file.LoadIfRequired(nil)
+
+			relsFileName := fmt.Sprintf("%s/_rels/%s.rels", path.Dir(fileName), path.Base(fileName))
+			if file := doc.pkg.File(relsFileName); file != nil {
+				item.relationships = ooxml.NewRelationships(file, doc.pkg)
+			} else {
+				item.relationships = ooxml.NewRelationships(relsFileName, doc.pkg)
+			}
+		}
+
+		e.items = append(e.items, item)
+	}
+}
+
+//item returns loaded external link with 0-based index or error if there is no such link
+func (e *externalLinks) item(i int) (*externalLinkItem, error) {
+	e.loadIfRequired()
+
+	if i < 0 || i >= len(e.items) {
+		return nil, errors.New(fmt.Sprintf("there is no external link with index %d", i))
+	}
+
+	return e.items[i], nil
+}
+
+//List returns information about all external links
+func (e *externalLinks) List() []ExternalLink {
+	e.loadIfRequired()
+
+	links := make([]ExternalLink, 0, len(e.items))
+	for _, item := range e.items {
+		link := ExternalLink{}
+		if book := item.ml.ExternalBook; book != nil {
+			link.Path = item.relationships.GetTargetById(string(book.RID))
+			if book.SheetNames != nil {
+				for _, name := range book.SheetNames.Items {
+					link.Sheets = append(link.Sheets, name.Val)
+				}
+			}
+		}
+
+		links = append(links, link)
+	}
+
+	return links
+}
+
+//SetPath updates path to external workbook of link with 0-based index
+func (e *externalLinks) SetPath(i int, target string) error {
+	item, err := e.item(i)
+	if err != nil {
+		return err
+	}
+
+	if item.ml.ExternalBook == nil {
+		return errors.New(fmt.Sprintf("external link with index %d is not a link to workbook", i))
+	}
+
+	if len(target) == 0 {
+		return errors.New("path to external workbook is required")
+	}
+
+	item.relationships.Remove(item.ml.ExternalBook.RID)
+	_, item.ml.ExternalBook.RID = item.relationships.AddLink(internal.RelationTypeExternalPath, target)
+	item.file.MarkAsUpdated()
+	return nil
+}
+
+//Break replaces formulas that refer to link with 0-based index with cached values, removes defined names that refer to link and removes link itself
+func (e *externalLinks) Break(i int) error {
+	item, err := e.item(i)
+	if err != nil {
+		return err
+	}
+
+	doc := e.doc
+	index := i + 1
+	refersTo := func(content string) bool {
+		for _, idx := range formula.ExternalLinks(content) {
+			if idx == index {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	renumber := func(content string) string {
+		return formula.ReplaceExternalLinks(content, func(idx int) int {
+			if idx > index {
+				return idx - 1
+			}
+
+			return idx
+		})
+	}
+
+	for sIdx := range doc.sheets {
+		sheet := doc.Sheet(sIdx).info()
+
+		//lookup for cells first, because cells of shared formula are resolved via master cell
+		var broken []*ml.Cell
+		for _, row := range sheet.ml.SheetData {
+			for _, c := range row.Cells {
+				if c != nil && c.Formula != nil && refersTo(sheet.formulas.resolve(c)) {
+					broken = append(broken, c)
+				}
+			}
+		}
+
+		for _, c := range broken {
+			cell := &Cell{ml: c, sheet: sheet}
+			if c.Type == types.CellTypeFormula {
+				//cached string of formula becomes a regular string
+				cell.SetString(c.Value)
+			} else {
+				cell.resetFormula()
+			}
+		}
+
+		for _, row := range sheet.ml.SheetData {
+			for _, c := range row.Cells {
+				if c != nil && c.Formula != nil && len(c.Formula.Content) > 0 {
+					c.Formula.Content = renumber(c.Formula.Content)
+				}
+			}
+		}
+	}
+
+	definedNames := make([]*ml.DefinedName, 0, len(doc.workbook.ml.DefinedNames.Items))
+	for _, definedName := range doc.workbook.ml.DefinedNames.Items {
+		if !refersTo(definedName.Formula) {
+			definedName.Formula = renumber(definedName.Formula)
+			definedNames = append(definedNames, definedName)
+		}
+	}
+
+	doc.workbook.ml.DefinedNames.Items = definedNames
+
+	//remove link
+	refs := doc.workbook.ml.ExternalReferences.Items
+	doc.workbook.ml.ExternalReferences.Items = append(refs[:i], refs[i+1:]...)
+	e.items = append(e.items[:i], e.items[i+1:]...)
+	doc.relationships.Remove(item.rid)
+	if item.file != nil {
+		fileName := item.file.FileName()
+		doc.pkg.Remove(fileName)
+		doc.pkg.Remove(fmt.Sprintf("%s/_rels/%s.rels", path.Dir(fileName), path.Base(fileName)))
+		doc.pkg.ContentTypes().Remove(fileName)
+	}
+
+	doc.workbook.file.MarkAsUpdated()
+	return nil
+}
+
+//ExternalLinks returns information about all external workbooks that are referenced by formulas. Formulas refer to link with 0-based index i as [i+1], e.g.: [1]Sheet1!A1
+func (xl *Spreadsheet) ExternalLinks() []ExternalLink {
+	return xl.externalLinks.List()
+}
+
+//SetExternalLinkPath updates path to external workbook of link with 0-based index, e.g.: SetExternalLinkPath(0, "file:///C:/Reports/2019.xlsx")
+func (xl *Spreadsheet) SetExternalLinkPath(i int, path string) error {
+	return xl.externalLinks.SetPath(i, path)
+}
+
+//BreakExternalLink breaks link with 0-based index - formulas that refer to external workbook are replaced with cached values and defined names that refer to external workbook are removed. N.B.: all sheets are loaded to update formulas
+func (xl *Spreadsheet) BreakExternalLink(i int) error {
+	return xl.externalLinks.Break(i)
+}
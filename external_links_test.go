@@ -0,0 +1,77 @@
+package xlsx
+
+import (
+	"fmt"
+	"github.com/plandem/ooxml"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+//addExternalLink adds a link to external workbook with path and sheet, there is no public API to create links
+func addExternalLink(xl *Spreadsheet, path string, sheet string) {
+	fileName := xl.uniqueFileName("xl/externalLinks/externalLink%d.xml")
+	_, linkRID := ooxml.NewRelationships(fmt.Sprintf("xl/externalLinks/_rels/%s.rels", filepath.Base(fileName)), xl.pkg).AddLink(internal.RelationTypeExternalPath, path)
+	xl.pkg.Add(fileName, []byte(fmt.Sprintf(`<externalLink xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><externalBook r:id="%s"><sheetNames><sheetName val="%s"/></sheetNames></externalBook></externalLink>`, linkRID, sheet)))
+	xl.pkg.ContentTypes().RegisterContent(fileName, internal.ContentTypeExternalLink)
+	_, rid := xl.relationships.AddFile(internal.RelationTypeExternalLink, fileName)
+	xl.workbook.ml.ExternalReferences.Items = append(xl.workbook.ml.ExternalReferences.Items, &ml.ExternalReference{RID: rid})
+}
+
+func TestExternalLinks(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Sheet1")
+	require.Equal(t, []ExternalLink{}, xl.ExternalLinks())
+
+	addExternalLink(xl, "prices.xlsx", "Prices")
+	addExternalLink(xl, "rates.xlsx", "Rates")
+
+	require.Nil(t, sheet.CellByRef("A1").SetFormulaWithValue("[1]Prices!A1*2", 10, types.CellTypeNumber))
+	require.Nil(t, sheet.CellByRef("A2").SetFormulaWithValue(`[1]Prices!B1&"$"`, "5$", types.CellTypeFormula))
+	require.Nil(t, sheet.CellByRef("A3").SetFormulaWithValue("[2]Rates!A1+A1", 12, types.CellTypeNumber))
+	require.Nil(t, xl.DefineName("Price", "[1]Prices!$A$1"))
+	require.Nil(t, xl.DefineName("Rate", "[2]Rates!$A$1"))
+
+	require.Nil(t, xl.SaveAs("./test_files/tmp.xlsx"))
+	xl.Close()
+
+	xl, err := Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+
+	require.Equal(t, []ExternalLink{
+		{Path: "prices.xlsx", Sheets: []string{"Prices"}},
+		{Path: "rates.xlsx", Sheets: []string{"Rates"}},
+	}, xl.ExternalLinks())
+
+	//invalid links
+	require.NotNil(t, xl.SetExternalLinkPath(2, "other.xlsx"))
+	require.NotNil(t, xl.SetExternalLinkPath(0, ""))
+	require.NotNil(t, xl.BreakExternalLink(-1))
+
+	require.Nil(t, xl.SetExternalLinkPath(1, "rates_2019.xlsx"))
+	require.Nil(t, xl.BreakExternalLink(0))
+
+	sheet = xl.Sheet(0)
+	require.Equal(t, false, sheet.CellByRef("A1").HasFormula())
+	require.Equal(t, "10", sheet.CellByRef("A1").Value())
+	require.Equal(t, false, sheet.CellByRef("A2").HasFormula())
+	require.Equal(t, "5$", sheet.CellByRef("A2").Value())
+	require.Equal(t, "[1]Rates!A1+A1", sheet.CellByRef("A3").Formula())
+	require.Equal(t, "", xl.DefinedName("Price"))
+	require.Equal(t, "[1]Rates!$A$1", xl.DefinedName("Rate"))
+
+	require.Nil(t, xl.SaveAs("./test_files/tmp2.xlsx"))
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp2.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	require.Equal(t, []ExternalLink{
+		{Path: "rates_2019.xlsx", Sheets: []string{"Rates"}},
+	}, xl.ExternalLinks())
+	require.Equal(t, "5$", xl.Sheet(0).CellByRef("A2").Value())
+}
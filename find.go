@@ -0,0 +1,165 @@
+package xlsx
+
+import (
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/types"
+	"regexp"
+	"strconv"
+)
+
+//finder holds information required to search cells
+type finder struct {
+	sheet   *sheetInfo
+	re      *regexp.Regexp
+	literal bool
+	options *options.FindOptions
+}
+
+//newFinder creates an object that searches cells for value, where value can be string, *regexp.Regexp or any other value that will be converted into string
+func newFinder(sheet *sheetInfo, value interface{}, o *options.FindOptions) (*finder, error) {
+	if o == nil {
+		o = options.NewFindOptions()
+	}
+
+	f := &finder{sheet: sheet, options: o}
+
+	var pattern string
+	switch v := value.(type) {
+	case *regexp.Regexp:
+		pattern = v.String()
+	case string:
+		pattern = regexp.QuoteMeta(v)
+		f.literal = true
+	default:
+		pattern = regexp.QuoteMeta(fmt.Sprint(v))
+		f.literal = true
+	}
+
+	if o.WholeCell {
+		pattern = "^(?:" + pattern + ")$"
+	}
+
+	if !o.MatchCase {
+		pattern = "(?i)" + pattern
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	f.re = re
+	return f, nil
+}
+
+//bounds returns bounds of cells to search
+func (f *finder) bounds() (types.Bounds, bool) {
+	if !f.options.Range.IsEmpty() {
+		return f.options.Range, true
+	}
+
+	cols, rows := f.sheet.sheet.Dimension()
+	if cols == 0 || rows == 0 {
+		return types.Bounds{}, false
+	}
+
+	return types.BoundsFromIndexes(0, 0, cols-1, rows-1), true
+}
+
+//content returns content of cell to search and true if it's a formula
+func (f *finder) content(c *Cell) (string, bool) {
+	if f.options.InFormulas && c.HasFormula() {
+		return "=" + c.Formula(), true
+	}
+
+	return c.Value(), false
+}
+
+//walk calls callback cb for each matched cell
+func (f *finder) walk(cb func(cIdx, rIdx int, c *Cell, content string, isFormula bool)) {
+	bounds, ok := f.bounds()
+	if !ok {
+		return
+	}
+
+	//all cells of merged range refer to the top left cell, so such cell must be visited once only
+	visited := make(map[*ml.Cell]bool)
+	for rIdx := bounds.FromRow; rIdx <= bounds.ToRow; rIdx++ {
+		for cIdx := bounds.FromCol; cIdx <= bounds.ToCol; cIdx++ {
+			c := f.sheet.sheet.Cell(cIdx, rIdx)
+			if visited[c.ml] {
+				continue
+			}
+
+			visited[c.ml] = true
+			if content, isFormula := f.content(c); len(content) > 0 && f.re.MatchString(content) {
+				mcIdx, mrIdx, _ := f.sheet.mergedCells.Resolve(cIdx, rIdx)
+				cb(mcIdx, mrIdx, c, content, isFormula)
+			}
+		}
+	}
+}
+
+//replace returns content with replaced matches. For literal search, replacement is used as is, for regexp, replacement can refer submatches, e.g.: $1
+func (f *finder) replace(content string, replacement string) string {
+	if f.literal {
+		return f.re.ReplaceAllLiteralString(content, replacement)
+	}
+
+	return f.re.ReplaceAllString(content, replacement)
+}
+
+//Find returns refs of cells with values that match value, row by row. Value can be string, *regexp.Regexp or any other value that will be converted into string. If options is nil, then default options are used, i.e. case insensitive search of values of all cells
+func (s *sheetInfo) Find(value interface{}, o *options.FindOptions) ([]types.CellRef, error) {
+	f, err := newFinder(s, value, o)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []types.CellRef
+	f.walk(func(cIdx, rIdx int, c *Cell, content string, isFormula bool) {
+		refs = append(refs, types.CellRefFromIndexes(cIdx, rIdx))
+	})
+
+	return refs, nil
+}
+
+//Replace replaces matches of old in cells with replacement and returns number of updated cells. Old can be string, *regexp.Regexp or any other value that will be converted into string. If options is nil, then default options are used.
+//Cells with formulas are updated only if search in formulas is enabled. Style of cells is kept, numeric cells are kept numeric if result is a number
+func (s *sheetInfo) Replace(old interface{}, replacement string, o *options.FindOptions) (int, error) {
+	f, err := newFinder(s, old, o)
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	f.walk(func(cIdx, rIdx int, c *Cell, content string, isFormula bool) {
+		value := f.replace(content, replacement)
+		if value == content {
+			return
+		}
+
+		switch {
+		case isFormula:
+			c.SetFormula(value)
+		case c.HasFormula():
+			//values of formulas can't be replaced
+			return
+		case c.ml.Type == types.CellTypeSharedString || c.ml.Type == types.CellTypeInlineString:
+			c.SetString(value)
+		default:
+			if _, err := strconv.ParseFloat(value, 64); err == nil && c.ml.Type != types.CellTypeBool {
+				c.ml.Value = value
+				c.sheet.changes.add(c)
+			} else {
+				c.SetString(value)
+			}
+		}
+
+		updated++
+	})
+
+	return updated, nil
+}
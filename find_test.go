@@ -0,0 +1,101 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Find")
+	sheet.CellByRef("A1").SetValue("Apple")
+	sheet.CellByRef("B1").SetValue("pineapple")
+	sheet.CellByRef("A2").SetValue(123)
+	sheet.CellByRef("B2").SetFormula("SUM(A2:A3)")
+	sheet.CellByRef("A3").SetValue("apple")
+
+	refs, err := sheet.Find("apple", nil)
+	require.Nil(t, err)
+	require.Equal(t, []types.CellRef{"A1", "B1", "A3"}, refs)
+
+	refs, err = sheet.Find("apple", options.NewFindOptions(options.Find.MatchCase(true)))
+	require.Nil(t, err)
+	require.Equal(t, []types.CellRef{"B1", "A3"}, refs)
+
+	refs, err = sheet.Find("apple", options.NewFindOptions(options.Find.WholeCell(true)))
+	require.Nil(t, err)
+	require.Equal(t, []types.CellRef{"A1", "A3"}, refs)
+
+	refs, err = sheet.Find(12, nil)
+	require.Nil(t, err)
+	require.Equal(t, []types.CellRef{"A2"}, refs)
+
+	refs, err = sheet.Find(regexp.MustCompile(`^[A-Z]`), options.NewFindOptions(options.Find.MatchCase(true)))
+	require.Nil(t, err)
+	require.Equal(t, []types.CellRef{"A1"}, refs)
+
+	refs, err = sheet.Find("A2", options.NewFindOptions(options.Find.InFormulas(true)))
+	require.Nil(t, err)
+	require.Equal(t, []types.CellRef{"B2"}, refs)
+
+	refs, err = sheet.Find("apple", options.NewFindOptions(options.Find.Range(types.BoundsFromIndexes(1, 0, 1, 2))))
+	require.Nil(t, err)
+	require.Equal(t, []types.CellRef{"B1"}, refs)
+
+	//merged cells are reported once with ref of the top left cell
+	sheet.CellByRef("C1").SetValue("Green apple")
+	require.Nil(t, sheet.Range("C1:D3").Merge())
+	refs, err = sheet.Find("apple", nil)
+	require.Nil(t, err)
+	require.Equal(t, []types.CellRef{"A1", "B1", "C1", "A3"}, refs)
+
+	refs, err = sheet.Find("apple", options.NewFindOptions(options.Find.Range(types.BoundsFromIndexes(3, 1, 3, 2))))
+	require.Nil(t, err)
+	require.Equal(t, []types.CellRef{"C1"}, refs)
+}
+
+func TestReplace(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Replace")
+	bold := xl.AddFormatting(format.NewStyles(format.Font.Bold))
+	sheet.CellByRef("A1").SetValue("Apple")
+	sheet.CellByRef("A1").SetFormatting(bold)
+	sheet.CellByRef("B1").SetValue("pineapple")
+	sheet.CellByRef("A2").SetValue(123)
+	sheet.CellByRef("B2").SetFormula("SUM(A2:A3)")
+
+	updated, err := sheet.Replace("apple", "pear", nil)
+	require.Nil(t, err)
+	require.Equal(t, 2, updated)
+	require.Equal(t, "pear", sheet.CellByRef("A1").Value())
+	require.Equal(t, bold, sheet.CellByRef("A1").Formatting())
+	require.Equal(t, "pinepear", sheet.CellByRef("B1").Value())
+
+	//numeric cell is kept numeric
+	numberType := sheet.CellByRef("A2").Type()
+	updated, err = sheet.Replace("2", "5", nil)
+	require.Nil(t, err)
+	require.Equal(t, 1, updated)
+	require.Equal(t, "153", sheet.CellByRef("A2").Value())
+	require.Equal(t, numberType, sheet.CellByRef("A2").Type())
+
+	//formulas
+	updated, err = sheet.Replace("A3", "A10", options.NewFindOptions(options.Find.InFormulas(true)))
+	require.Nil(t, err)
+	require.Equal(t, 1, updated)
+	require.Equal(t, "SUM(A2:A10)", sheet.CellByRef("B2").Formula())
+
+	//regexp with submatches
+	updated, err = sheet.Replace(regexp.MustCompile(`(pine)(pear)`), "$2$1", nil)
+	require.Nil(t, err)
+	require.Equal(t, 1, updated)
+	require.Equal(t, "pearpine", sheet.CellByRef("B1").Value())
+}
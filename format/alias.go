@@ -43,3 +43,9 @@ type ConditionValueType = primitives.ConditionValueType
 
 //IconSetType is alias of original primitives.IconSetType
 type IconSetType = primitives.IconSetType
+
+//DataBarAxisPosition is alias of original primitives.DataBarAxisPosition
+type DataBarAxisPosition = primitives.DataBarAxisPosition
+
+//DataBarDirection is alias of original primitives.DataBarDirection
+type DataBarDirection = primitives.DataBarDirection
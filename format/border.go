@@ -2,6 +2,7 @@ package format
 
 import (
 	"github.com/plandem/xlsx/internal/color"
+	"github.com/plandem/xlsx/internal/ml"
 	"github.com/plandem/xlsx/internal/ml/primitives"
 )
 
@@ -57,6 +58,43 @@ func (b *borderOption) Color(rgb string) styleOption {
 	}
 }
 
+//All sets type and color for left, right, top and bottom borders, e.g.: Border.All(BorderStyleThin, "#000000")
+func (b *borderOption) All(t primitives.BorderStyleType, rgb string) styleOption {
+	return func(s *StyleFormat) {
+		b.Type(t)(s)
+		b.Color(rgb)(s)
+	}
+}
+
+//Inside sets type and color for inner vertical and horizontal borders of range, e.g.: to style a table
+func (b *borderOption) Inside(t primitives.BorderStyleType, rgb string) styleOption {
+	return func(s *StyleFormat) {
+		rgb := color.New(rgb)
+		s.styleInfo.Border.Vertical.Type = t
+		s.styleInfo.Border.Vertical.Color = rgb
+		s.styleInfo.Border.Horizontal.Type = t
+		s.styleInfo.Border.Horizontal.Color = rgb
+	}
+}
+
+//None explicitly removes all borders, e.g.: to override borders of another style
+func (b *borderOption) None(s *StyleFormat) {
+	for _, segment := range []*ml.BorderSegment{
+		s.styleInfo.Border.Left,
+		s.styleInfo.Border.Right,
+		s.styleInfo.Border.Top,
+		s.styleInfo.Border.Bottom,
+		s.styleInfo.Border.Diagonal,
+		s.styleInfo.Border.Vertical,
+		s.styleInfo.Border.Horizontal,
+	} {
+		*segment = ml.BorderSegment{Type: BorderStyleNone}
+	}
+
+	s.styleInfo.Border.DiagonalUp = false
+	s.styleInfo.Border.DiagonalDown = false
+}
+
 func (b *borderTopSegmentOption) Type(t primitives.BorderStyleType) styleOption {
 	return func(s *StyleFormat) {
 		s.styleInfo.Border.Top.Type = t
@@ -117,6 +155,32 @@ func (b *borderDiagonalSegmentOption) Color(rgb string) styleOption {
 	}
 }
 
+//Up sets type and color of diagonal border that goes from the bottom left corner to the top right corner of cell
+func (b *borderDiagonalSegmentOption) Up(t primitives.BorderStyleType, rgb string) styleOption {
+	return func(s *StyleFormat) {
+		s.styleInfo.Border.Diagonal.Type = t
+		s.styleInfo.Border.Diagonal.Color = color.New(rgb)
+		s.styleInfo.Border.DiagonalUp = true
+	}
+}
+
+//Down sets type and color of diagonal border that goes from the top left corner to the bottom right corner of cell
+func (b *borderDiagonalSegmentOption) Down(t primitives.BorderStyleType, rgb string) styleOption {
+	return func(s *StyleFormat) {
+		s.styleInfo.Border.Diagonal.Type = t
+		s.styleInfo.Border.Diagonal.Color = color.New(rgb)
+		s.styleInfo.Border.DiagonalDown = true
+	}
+}
+
+//Cross sets type and color of both diagonal borders, i.e. cell is crossed out
+func (b *borderDiagonalSegmentOption) Cross(t primitives.BorderStyleType, rgb string) styleOption {
+	return func(s *StyleFormat) {
+		b.Up(t, rgb)(s)
+		b.Down(t, rgb)(s)
+	}
+}
+
 func (b *borderVerticalSegmentOption) Type(t primitives.BorderStyleType) styleOption {
 	return func(s *StyleFormat) {
 		s.styleInfo.Border.Vertical.Type = t
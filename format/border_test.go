@@ -88,3 +88,43 @@ func TestBorder(t *testing.T) {
 		}
 	}), style)
 }
+
+func TestBorderPresets(t *testing.T) {
+	style := NewStyles(
+		Border.All(BorderStyleThin, "#000000"),
+		Border.Inside(BorderStyleHair, "#FF0000"),
+		Border.Diagonal.Up(BorderStyleDashed, "#00FF00"),
+	)
+
+	thin := &ml.BorderSegment{Type: BorderStyleThin, Color: color.New("#000000")}
+	hair := &ml.BorderSegment{Type: BorderStyleHair, Color: color.New("#FF0000")}
+	require.Equal(t, createStylesAndFill(func(f *StyleFormat) {
+		f.styleInfo.Border = &ml.Border{
+			Left:       thin,
+			Right:      thin,
+			Top:        thin,
+			Bottom:     thin,
+			Diagonal:   &ml.BorderSegment{Type: BorderStyleDashed, Color: color.New("#00FF00")},
+			Vertical:   hair,
+			Horizontal: hair,
+			DiagonalUp: true,
+		}
+	}), style)
+
+	style = NewStyles(Border.Diagonal.Cross(BorderStyleThin, "#000000"))
+	require.Equal(t, true, style.styleInfo.Border.DiagonalUp)
+	require.Equal(t, true, style.styleInfo.Border.DiagonalDown)
+	require.Equal(t, &ml.BorderSegment{Type: BorderStyleThin, Color: color.New("#000000")}, style.styleInfo.Border.Diagonal)
+
+	style.Set(Border.None)
+	none := &ml.BorderSegment{Type: BorderStyleNone}
+	require.Equal(t, &ml.Border{
+		Left:       none,
+		Right:      none,
+		Top:        none,
+		Bottom:     none,
+		Diagonal:   none,
+		Vertical:   none,
+		Horizontal: none,
+	}, style.styleInfo.Border)
+}
@@ -14,7 +14,7 @@ func ConditionValue(t ConditionValueType, value string, gte bool) *conditionValu
 		ml.ConditionValue{
 			Type:           t,
 			Value:          value,
-			GreaterOrEqual: gte,
+			GreaterOrEqual: &gte,
 		},
 	}
 }
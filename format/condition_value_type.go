@@ -13,6 +13,8 @@ const (
 	ConditionValueTypeMin
 	ConditionValueTypeFormula
 	ConditionValueTypePercentile
+	ConditionValueTypeAutoMin
+	ConditionValueTypeAutoMax
 )
 
 func init() {
@@ -23,6 +25,8 @@ func init() {
 		ConditionValueTypeMin:        "min",
 		ConditionValueTypeFormula:    "formula",
 		ConditionValueTypePercentile: "percentile",
+		ConditionValueTypeAutoMin:    "autoMin",
+		ConditionValueTypeAutoMax:    "autoMax",
 	}
 
 	primitives.ToConditionValueType = make(map[string]primitives.ConditionValueType, len(primitives.FromConditionValueType))
@@ -1,18 +1,37 @@
 package format
 
 import (
+	"fmt"
 	"github.com/plandem/xlsx/internal/color"
 	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"strings"
 )
 
 //conditionalRule is objects that holds combined information about conditional rule
 type conditionalRule struct {
-	rule  *ml.ConditionalRule
-	style *StyleFormat
+	rule    *ml.ConditionalRule
+	style   *StyleFormat
+	dataBar *ml.X14DataBar
+	formula string //template of formula that depends on top left cell of refs, e.g.: %[1]s is a cell and %[2]s is a quoted text
 }
 
 type conditionalRuleOption func(o *conditionalRule)
 
+//timePeriodFormulas is a list of formulas that Excel uses for time periods
+var timePeriodFormulas = map[TimePeriodType]string{
+	TimePeriodToday:     `FLOOR(%[1]s,1)=TODAY()`,
+	TimePeriodYesterday: `FLOOR(%[1]s,1)=TODAY()-1`,
+	TimePeriodTomorrow:  `FLOOR(%[1]s,1)=TODAY()+1`,
+	TimePeriodLast7Days: `AND(TODAY()-FLOOR(%[1]s,1)<=6,FLOOR(%[1]s,1)<=TODAY())`,
+	TimePeriodThisMonth: `AND(MONTH(%[1]s)=MONTH(TODAY()),YEAR(%[1]s)=YEAR(TODAY()))`,
+	TimePeriodLastMonth: `AND(MONTH(%[1]s)=MONTH(EDATE(TODAY(),0-1)),YEAR(%[1]s)=YEAR(EDATE(TODAY(),0-1)))`,
+	TimePeriodNextMonth: `AND(MONTH(%[1]s)=MONTH(EDATE(TODAY(),0+1)),YEAR(%[1]s)=YEAR(EDATE(TODAY(),0+1)))`,
+	TimePeriodThisWeek:  `AND(TODAY()-ROUNDDOWN(%[1]s,0)<=WEEKDAY(TODAY())-1,ROUNDDOWN(%[1]s,0)-TODAY()<=7-WEEKDAY(TODAY()))`,
+	TimePeriodLastWeek:  `AND(TODAY()-ROUNDDOWN(%[1]s,0)>=(WEEKDAY(TODAY())),TODAY()-ROUNDDOWN(%[1]s,0)<(WEEKDAY(TODAY())+7))`,
+	TimePeriodNextWeek:  `AND(ROUNDDOWN(%[1]s,0)-TODAY()>(7-WEEKDAY(TODAY())),ROUNDDOWN(%[1]s,0)-TODAY()<(15-WEEKDAY(TODAY())))`,
+}
+
 //Condition is a 'namespace' for all possible settings for conditional rule
 var Condition conditionalRuleOption
 
@@ -62,6 +81,15 @@ func (co *conditionalRuleOption) Priority(priority int) conditionalRuleOption {
 func (co *conditionalRuleOption) Style(style *StyleFormat) conditionalRuleOption {
 	return func(r *conditionalRule) {
 		r.style = style
+		r.rule.Style = nil
+	}
+}
+
+//StyleID sets already existing differential style, e.g. returned by Spreadsheet.AddDiffFormatting, instead of adding a new one
+func (co *conditionalRuleOption) StyleID(styleID DiffStyleID) conditionalRuleOption {
+	return func(r *conditionalRule) {
+		r.style = nil
+		r.rule.Style = &styleID
 	}
 }
 
@@ -95,9 +123,10 @@ func (co *conditionalRuleOption) Rank(rank uint) conditionalRuleOption {
 	}
 }
 
-func (co *conditionalRuleOption) Formula(formula Formula) conditionalRuleOption {
+//Formula sets formulas of rule, e.g.: 2 formulas for between operator
+func (co *conditionalRuleOption) Formula(formulas ...Formula) conditionalRuleOption {
 	return func(r *conditionalRule) {
-		r.rule.Formula = formula
+		r.rule.Formula = formulas
 	}
 }
 
@@ -118,13 +147,112 @@ func (co *conditionalRuleOption) ColorScale(pairs ...interface{}) conditionalRul
 	}
 }
 
+//ContainsText adds rule for cells that contain text
+func (co *conditionalRuleOption) ContainsText(text string) conditionalRuleOption {
+	return textRule(ConditionTypeContainsText, ConditionOperatorContainsText, text, `NOT(ISERROR(SEARCH(%[2]s,%[1]s)))`)
+}
+
+//NotContainsText adds rule for cells that don't contain text
+func (co *conditionalRuleOption) NotContainsText(text string) conditionalRuleOption {
+	return textRule(ConditionTypeNotContainsText, ConditionOperatorNotContains, text, `ISERROR(SEARCH(%[2]s,%[1]s))`)
+}
+
+//BeginsWith adds rule for cells that begin with text
+func (co *conditionalRuleOption) BeginsWith(text string) conditionalRuleOption {
+	return textRule(ConditionTypeBeginsWith, ConditionOperatorBeginsWith, text, `LEFT(%[1]s,LEN(%[2]s))=%[2]s`)
+}
+
+//EndsWith adds rule for cells that end with text
+func (co *conditionalRuleOption) EndsWith(text string) conditionalRuleOption {
+	return textRule(ConditionTypeEndsWith, ConditionOperatorEndsWith, text, `RIGHT(%[1]s,LEN(%[2]s))=%[2]s`)
+}
+
+//textRule returns option for text rule with required formula
+func textRule(t ConditionType, operator ConditionOperatorType, text string, formula string) conditionalRuleOption {
+	return func(r *conditionalRule) {
+		r.rule.Type = t
+		r.rule.Operator = operator
+		r.rule.Text = text
+		r.formula = formula
+	}
+}
+
+//DateOccurring adds rule for cells with dates that occur in time period
+func (co *conditionalRuleOption) DateOccurring(period TimePeriodType) conditionalRuleOption {
+	return func(r *conditionalRule) {
+		r.rule.Type = ConditionTypeTimePeriod
+		r.rule.TimePeriod = period
+		r.formula = timePeriodFormulas[period]
+	}
+}
+
+//resolveFormula sets formula for rule that depends on top left cell of bounds
+func (r *conditionalRule) resolveFormula(bounds primitives.Bounds) {
+	if len(r.formula) == 0 {
+		return
+	}
+
+	cell := primitives.CellRefFromIndexes(bounds.FromCol, bounds.FromRow)
+	text := `"` + strings.Replace(r.rule.Text, `"`, `""`, -1) + `"`
+	r.rule.Formula = []primitives.Formula{primitives.Formula(fmt.Sprintf(r.formula, cell, text))}
+}
+
+//ColorScale2 adds 2-color scale, nil values mean lowest and highest values
+func (co *conditionalRuleOption) ColorScale2(min *conditionValue, minColor string, max *conditionValue, maxColor string) conditionalRuleOption {
+	return func(r *conditionalRule) {
+		r.rule.Type = ConditionTypeColorScale
+		r.rule.ColorScale = &ml.ColorScale{
+			Values: []*ml.ConditionValue{
+				scaleValue(min, ConditionValueTypeMin),
+				scaleValue(max, ConditionValueTypeMax),
+			},
+			Colors: []*ml.Color{
+				color.New(minColor),
+				color.New(maxColor),
+			},
+		}
+	}
+}
+
+//ColorScale3 adds 3-color scale, nil values mean lowest value, 50 percentile and highest value
+func (co *conditionalRuleOption) ColorScale3(min *conditionValue, minColor string, mid *conditionValue, midColor string, max *conditionValue, maxColor string) conditionalRuleOption {
+	return func(r *conditionalRule) {
+		if mid == nil {
+			mid = &conditionValue{ml.ConditionValue{Type: ConditionValueTypePercentile, Value: "50"}}
+		}
+
+		r.rule.Type = ConditionTypeColorScale
+		r.rule.ColorScale = &ml.ColorScale{
+			Values: []*ml.ConditionValue{
+				scaleValue(min, ConditionValueTypeMin),
+				&mid.value,
+				scaleValue(max, ConditionValueTypeMax),
+			},
+			Colors: []*ml.Color{
+				color.New(minColor),
+				color.New(midColor),
+				color.New(maxColor),
+			},
+		}
+	}
+}
+
+//scaleValue returns value for color scale or value of type t if there is no value
+func scaleValue(v *conditionValue, t ConditionValueType) *ml.ConditionValue {
+	if v == nil {
+		return &ml.ConditionValue{Type: t}
+	}
+
+	return &v.value
+}
+
 func (co *conditionalRuleOption) IconSet(t IconSetType, percent bool, reverse bool, showValue bool, values ...*conditionValue) conditionalRuleOption {
 	return func(r *conditionalRule) {
 		iconSet := &ml.IconSet{
 			Type:      t,
-			Percent:   percent,
+			Percent:   &percent,
 			Reverse:   reverse,
-			ShowValue: showValue,
+			ShowValue: &showValue,
 		}
 
 		for _, v := range values {
@@ -159,3 +287,39 @@ func (co *conditionalRuleOption) DataBar(min *conditionValue, minLength uint, ma
 		r.rule.DataBar = dataBar
 	}
 }
+
+//Icons adds icon set of type with default thresholds of Excel, i.e. evenly distributed percents
+func (co *conditionalRuleOption) Icons(t IconSetType, options ...iconSetOption) conditionalRuleOption {
+	return func(r *conditionalRule) {
+		r.rule.Type = ConditionTypeIconSet
+		r.rule.IconSet = newIconSet(t, options...)
+	}
+}
+
+//ExtendedDataBar adds data bar with x14 extensions, e.g. gradient or solid fill, colors for negative values and position of axis
+func (co *conditionalRuleOption) ExtendedDataBar(options ...dataBarOption) conditionalRuleOption {
+	return func(r *conditionalRule) {
+		dataBar := newDataBar(options...)
+
+		//data bar for applications that don't support x14 extensions
+		values := make([]*ml.ConditionValue, len(dataBar.Values))
+		for i, v := range dataBar.Values {
+			values[i] = &ml.ConditionValue{Type: v.Type, Value: v.Formula}
+
+			switch v.Type {
+			case ConditionValueTypeAutoMin:
+				values[i].Type = ConditionValueTypeMin
+			case ConditionValueTypeAutoMax:
+				values[i].Type = ConditionValueTypeMax
+			}
+		}
+
+		r.rule.Type = ConditionTypeDataBar
+		r.rule.DataBar = &ml.DataBar{
+			Values: values,
+			Color:  dataBar.FillColor,
+		}
+
+		r.dataBar = dataBar
+	}
+}
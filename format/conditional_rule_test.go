@@ -1,12 +1,14 @@
 package format
 
 import (
+	"encoding/xml"
 	"github.com/plandem/xlsx/internal/ml"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
 
 func TestConditionalRule_Set(t *testing.T) {
+	yes, no := true, false
 	rule := newConditionalRule(
 		Condition.AboveAverage,
 		Condition.StopIfTrue,
@@ -46,22 +48,23 @@ func TestConditionalRule_Set(t *testing.T) {
 
 	require.Equal(t, &conditionalRule{
 		rule: &ml.ConditionalRule{
-			Formula:      "formula",
+			Formula: []Formula{"formula"},
 			ColorScale: &ml.ColorScale{
 				Values: []*ml.ConditionValue{
 					{
 						Type: ConditionValueTypePercent,
 						Value: "10",
+						GreaterOrEqual: &no,
 					},
 					{
 						Type: ConditionValueTypePercent,
 						Value: "50",
-						GreaterOrEqual: false,
+						GreaterOrEqual: &no,
 					},
 					{
 						Type: ConditionValueTypePercent,
 						Value: "90",
-						GreaterOrEqual: true,
+						GreaterOrEqual: &yes,
 					},
 				},
 				Colors: []*ml.Color{
@@ -81,11 +84,12 @@ func TestConditionalRule_Set(t *testing.T) {
 					{
 						Type: ConditionValueTypeMin,
 						Value: "10",
+						GreaterOrEqual: &no,
 					},
 					{
 						Type: ConditionValueTypeMax,
 						Value: "90",
-						GreaterOrEqual: true,
+						GreaterOrEqual: &yes,
 					},
 				},
 				MinLength: 10,
@@ -97,23 +101,24 @@ func TestConditionalRule_Set(t *testing.T) {
 			},
 			IconSet: &ml.IconSet{
 				Type: IconSetType3Arrows,
-				Percent: true,
-				ShowValue: true,
+				Percent: &yes,
+				ShowValue: &yes,
 				Reverse: true,
 				Values: []*ml.ConditionValue{
 					{
 						Type: ConditionValueTypePercent,
 						Value: "10",
+						GreaterOrEqual: &no,
 					},
 					{
 						Type: ConditionValueTypePercent,
 						Value: "50",
-						GreaterOrEqual: false,
+						GreaterOrEqual: &no,
 					},
 					{
 						Type: ConditionValueTypePercent,
 						Value: "90",
-						GreaterOrEqual: true,
+						GreaterOrEqual: &yes,
 					},
 				},
 			},
@@ -156,3 +161,54 @@ func TestConditionalRule_Set(t *testing.T) {
 		},
 	}, rule)
 }
+
+func TestConditionalRule_ColorScale2(t *testing.T) {
+	rule := newConditionalRule(Condition.Priority(1), Condition.ColorScale2(nil, "#F8696B", nil, "#63BE7B"))
+	require.Equal(t, ConditionTypeColorScale, rule.rule.Type)
+	require.Equal(t, &ml.ColorScale{
+		Values: []*ml.ConditionValue{
+			{Type: ConditionValueTypeMin},
+			{Type: ConditionValueTypeMax},
+		},
+		Colors: []*ml.Color{
+			{RGB: "FFF8696B"},
+			{RGB: "FF63BE7B"},
+		},
+	}, rule.rule.ColorScale)
+
+	encoded, err := xml.Marshal(rule.rule.ColorScale)
+	require.Nil(t, err)
+	require.Equal(t, `<ColorScale><cfvo type="min"></cfvo><cfvo type="max"></cfvo><color rgb="FFF8696B"></color><color rgb="FF63BE7B"></color></ColorScale>`, string(encoded))
+
+	rule = newConditionalRule(Condition.ColorScale2(
+		ConditionValue(ConditionValueTypeNum, "10", true), "#F8696B",
+		ConditionValue(ConditionValueTypeFormula, "$A$1", true), "#63BE7B",
+	))
+	require.Equal(t, ConditionValueTypeNum, rule.rule.ColorScale.Values[0].Type)
+	require.Equal(t, "$A$1", rule.rule.ColorScale.Values[1].Value)
+}
+
+func TestConditionalRule_ColorScale3(t *testing.T) {
+	rule := newConditionalRule(Condition.Priority(1), Condition.ColorScale3(nil, "#F8696B", nil, "#FFEB84", nil, "#63BE7B"))
+	require.Equal(t, ConditionTypeColorScale, rule.rule.Type)
+
+	encoded, err := xml.Marshal(rule.rule.ColorScale)
+	require.Nil(t, err)
+	require.Equal(t, `<ColorScale><cfvo type="min"></cfvo><cfvo type="percentile" val="50"></cfvo><cfvo type="max"></cfvo><color rgb="FFF8696B"></color><color rgb="FFFFEB84"></color><color rgb="FF63BE7B"></color></ColorScale>`, string(encoded))
+
+	conditions := NewConditions(Conditions.Refs("A1:A10"), Conditions.Rule(
+		Condition.Priority(1),
+		Condition.ColorScale3(
+			ConditionValue(ConditionValueTypePercentile, "10", true), "#F8696B",
+			ConditionValue(ConditionValueTypeNum, "0", true), "#FFEB84",
+			ConditionValue(ConditionValueTypePercent, "90", true), "#63BE7B",
+		),
+	))
+	require.Nil(t, conditions.Validate())
+
+	conditions = NewConditions(Conditions.Refs("A1:A10"), Conditions.Rule(
+		Condition.Priority(1),
+		Condition.ColorScale3(nil, "#F8696B", ConditionValue(ConditionValueTypeNum, "", true), "#FFEB84", nil, "#63BE7B"),
+	))
+	require.NotNil(t, conditions.Validate())
+}
@@ -62,7 +62,7 @@ func (f *ConditionalFormat) Validate() error {
 			return errors.New(fmt.Sprintf("conditional rule#%d: wrong rank", i))
 		}
 
-		if r.rule.Type == ConditionTypeContainsText && len(r.rule.Text) == 0 {
+		if (r.rule.Type == ConditionTypeContainsText || r.rule.Type == ConditionTypeNotContainsText || r.rule.Type == ConditionTypeBeginsWith || r.rule.Type == ConditionTypeEndsWith) && len(r.rule.Text) == 0 {
 			return errors.New(fmt.Sprintf("conditional rule#%d: no text", i))
 		}
 
@@ -78,11 +78,39 @@ func (f *ConditionalFormat) Validate() error {
 			if len(r.rule.ColorScale.Values) < 2 {
 				return errors.New(fmt.Sprintf("conditional rule#%d: color scale should have at least 2 values", i))
 			}
+
+			for _, v := range r.rule.ColorScale.Values {
+				if v.Type != ConditionValueTypeMin && v.Type != ConditionValueTypeMax && len(v.Value) == 0 {
+					return errors.New(fmt.Sprintf("conditional rule#%d: color scale value of type %s requires value", i, v.Type))
+				}
+			}
 		}
 
 		if r.rule.IconSet != nil && (len(r.rule.IconSet.Values) < 2) {
 			return errors.New(fmt.Sprintf("conditional rule#%d: icon set should have at least 2 values", i))
 		}
+
+		if r.rule.IconSet != nil && r.rule.Type == ConditionTypeIconSet {
+			if count := iconsCount(r.rule.IconSet.Type); count != len(r.rule.IconSet.Values) {
+				return errors.New(fmt.Sprintf("conditional rule#%d: icon set should have %d values", i, count))
+			}
+		}
+
+		if r.dataBar != nil {
+			if r.rule.Type != ConditionTypeDataBar {
+				return errors.New(fmt.Sprintf("conditional rule#%d: data bar requires type %s", i, ConditionTypeDataBar))
+			}
+
+			for _, v := range r.dataBar.Values {
+				switch v.Type {
+				case ConditionValueTypeMin, ConditionValueTypeMax, ConditionValueTypeAutoMin, ConditionValueTypeAutoMax:
+				default:
+					if len(v.Formula) == 0 {
+						return errors.New(fmt.Sprintf("conditional rule#%d: data bar value of type %s requires value", i, v.Type))
+					}
+				}
+			}
+		}
 	}
 
 	return nil
@@ -106,20 +134,29 @@ func (co *conditionalOption) Rule(options ...conditionalRuleOption) conditionalO
 	}
 }
 
-//private method used to unpack ConditionalFormat
-func fromConditionalFormat(f *ConditionalFormat) (*ml.ConditionalFormatting, []*StyleFormat) {
+//private method used to unpack ConditionalFormat, x14 rules are returned in same order as rules with nil for rules without extensions
+func fromConditionalFormat(f *ConditionalFormat) (*ml.ConditionalFormatting, []*StyleFormat, []*ml.X14ConditionalRule) {
 	if len(f.rules) == 0 {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	rules := make([]*ml.ConditionalRule, len(f.rules))
 	styles := make([]*StyleFormat, len(f.rules))
+	extensions := make([]*ml.X14ConditionalRule, len(f.rules))
 
 	for i, r := range f.rules {
+		if len(f.info.Bounds) > 0 {
+			r.resolveFormula(f.info.Bounds[0])
+		}
+
 		rules[i] = r.rule
 		styles[i] = r.style
+
+		if r.dataBar != nil {
+			extensions[i] = &ml.X14ConditionalRule{Type: r.rule.Type, DataBar: r.dataBar}
+		}
 	}
 
 	f.info.Rules = rules
-	return f.info, styles
+	return f.info, styles, extensions
 }
@@ -245,3 +245,48 @@ func TestConditionalFormat_Validate(t *testing.T) {
 		),
 	).Validate())
 }
+
+func TestConditionalFormat_ImplicitFormulas(t *testing.T) {
+	conditions := NewConditions(
+		Conditions.Refs("B2:C10", "E1:E5"),
+		Conditions.Rule(Condition.Priority(1), Condition.ContainsText(`say "hi"`)),
+		Conditions.Rule(Condition.Priority(2), Condition.NotContainsText("100%")),
+		Conditions.Rule(Condition.Priority(3), Condition.BeginsWith("abc")),
+		Conditions.Rule(Condition.Priority(4), Condition.EndsWith("xyz")),
+		Conditions.Rule(Condition.Priority(5), Condition.DateOccurring(TimePeriodYesterday)),
+		Conditions.Rule(Condition.Priority(6), Condition.DateOccurring(TimePeriodLastMonth)),
+	)
+
+	require.Nil(t, conditions.Validate())
+	info, _, _ := fromConditionalFormat(conditions)
+
+	require.Equal(t, ConditionTypeContainsText, info.Rules[0].Type)
+	require.Equal(t, ConditionOperatorContainsText, info.Rules[0].Operator)
+	require.Equal(t, `say "hi"`, info.Rules[0].Text)
+	require.Equal(t, []Formula{Formula(`NOT(ISERROR(SEARCH("say ""hi""",B2)))`)}, info.Rules[0].Formula)
+
+	require.Equal(t, ConditionTypeNotContainsText, info.Rules[1].Type)
+	require.Equal(t, ConditionOperatorNotContains, info.Rules[1].Operator)
+	require.Equal(t, []Formula{Formula(`ISERROR(SEARCH("100%",B2))`)}, info.Rules[1].Formula)
+
+	require.Equal(t, ConditionTypeBeginsWith, info.Rules[2].Type)
+	require.Equal(t, []Formula{Formula(`LEFT(B2,LEN("abc"))="abc"`)}, info.Rules[2].Formula)
+
+	require.Equal(t, ConditionTypeEndsWith, info.Rules[3].Type)
+	require.Equal(t, []Formula{Formula(`RIGHT(B2,LEN("xyz"))="xyz"`)}, info.Rules[3].Formula)
+
+	require.Equal(t, ConditionTypeTimePeriod, info.Rules[4].Type)
+	require.Equal(t, TimePeriodYesterday, info.Rules[4].TimePeriod)
+	require.Equal(t, []Formula{Formula(`FLOOR(B2,1)=TODAY()-1`)}, info.Rules[4].Formula)
+	require.Equal(t, []Formula{Formula(`AND(MONTH(B2)=MONTH(EDATE(TODAY(),0-1)),YEAR(B2)=YEAR(EDATE(TODAY(),0-1)))`)}, info.Rules[5].Formula)
+
+	require.NotNil(t, NewConditions(
+		Conditions.Refs("A1"),
+		Conditions.Rule(Condition.Priority(1), Condition.BeginsWith("")),
+	).Validate())
+
+	require.NotNil(t, NewConditions(
+		Conditions.Refs("A1"),
+		Conditions.Rule(Condition.Priority(1), Condition.DateOccurring(0)),
+	).Validate())
+}
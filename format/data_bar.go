@@ -0,0 +1,120 @@
+package format
+
+import (
+	"github.com/plandem/xlsx/internal/color"
+	"github.com/plandem/xlsx/internal/ml"
+)
+
+type dataBarOption func(b *ml.X14DataBar)
+
+//DataBar is a 'namespace' for all possible settings for data bar with extended options
+var DataBar dataBarOption
+
+//newDataBar creates and returns data bar with defaults of Excel and requested options
+func newDataBar(options ...dataBarOption) *ml.X14DataBar {
+	gradient := true
+	b := &ml.X14DataBar{
+		Values: []*ml.X14ConditionValue{
+			{Type: ConditionValueTypeAutoMin},
+			{Type: ConditionValueTypeAutoMax},
+		},
+		FillColor:         color.New("#638EC6"),
+		NegativeFillColor: color.New("#FF0000"),
+		AxisColor:         color.New("#000000"),
+		MaxLength:         100,
+		Gradient:          &gradient,
+	}
+
+	for _, o := range options {
+		o(b)
+	}
+
+	return b
+}
+
+//Min sets type and value for lowest value of data bar
+func (do *dataBarOption) Min(t ConditionValueType, value string) dataBarOption {
+	return func(b *ml.X14DataBar) {
+		b.Values[0] = &ml.X14ConditionValue{Type: t, Formula: value}
+	}
+}
+
+//Max sets type and value for highest value of data bar
+func (do *dataBarOption) Max(t ConditionValueType, value string) dataBarOption {
+	return func(b *ml.X14DataBar) {
+		b.Values[1] = &ml.X14ConditionValue{Type: t, Formula: value}
+	}
+}
+
+//Length sets minimal and maximal length of data bar in percents of cell width
+func (do *dataBarOption) Length(min, max uint) dataBarOption {
+	return func(b *ml.X14DataBar) {
+		b.MinLength = min
+		b.MaxLength = max
+	}
+}
+
+//Color sets fill color of data bar
+func (do *dataBarOption) Color(rgb string) dataBarOption {
+	return func(b *ml.X14DataBar) {
+		b.FillColor = color.New(rgb)
+	}
+}
+
+//Solid sets solid fill for data bar instead of gradient fill
+func (do *dataBarOption) Solid(b *ml.X14DataBar) {
+	gradient := false
+	b.Gradient = &gradient
+}
+
+//Border adds border with color to data bar
+func (do *dataBarOption) Border(rgb string) dataBarOption {
+	return func(b *ml.X14DataBar) {
+		b.Border = true
+		b.BorderColor = color.New(rgb)
+	}
+}
+
+//NegativeColor sets fill color of data bar for negative values
+func (do *dataBarOption) NegativeColor(rgb string) dataBarOption {
+	return func(b *ml.X14DataBar) {
+		b.NegativeBarColorSameAsPositive = false
+		b.NegativeFillColor = color.New(rgb)
+	}
+}
+
+//NegativeBorder sets border color of data bar for negative values
+func (do *dataBarOption) NegativeBorder(rgb string) dataBarOption {
+	return func(b *ml.X14DataBar) {
+		sameAsPositive := false
+		b.NegativeBarBorderColorSameAsPositive = &sameAsPositive
+		b.NegativeBorderColor = color.New(rgb)
+	}
+}
+
+//NegativeSameAsPositive uses colors of positive values for negative values
+func (do *dataBarOption) NegativeSameAsPositive(b *ml.X14DataBar) {
+	b.NegativeBarColorSameAsPositive = true
+	b.NegativeBarBorderColorSameAsPositive = nil
+	b.NegativeFillColor = nil
+	b.NegativeBorderColor = nil
+}
+
+//Axis sets position and color of axis for data bar
+func (do *dataBarOption) Axis(position DataBarAxisPosition, rgb string) dataBarOption {
+	return func(b *ml.X14DataBar) {
+		b.AxisPosition = position
+		if position == DataBarAxisNone {
+			b.AxisColor = nil
+		} else {
+			b.AxisColor = color.New(rgb)
+		}
+	}
+}
+
+//Direction sets direction of data bar
+func (do *dataBarOption) Direction(direction DataBarDirection) dataBarOption {
+	return func(b *ml.X14DataBar) {
+		b.Direction = direction
+	}
+}
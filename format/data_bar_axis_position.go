@@ -0,0 +1,26 @@
+package format
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+//List of all possible values for DataBarAxisPosition
+const (
+	_ primitives.DataBarAxisPosition = iota
+	DataBarAxisAutomatic
+	DataBarAxisMiddle
+	DataBarAxisNone
+)
+
+func init() {
+	primitives.FromDataBarAxisPosition = map[primitives.DataBarAxisPosition]string{
+		DataBarAxisAutomatic: "automatic",
+		DataBarAxisMiddle:    "middle",
+		DataBarAxisNone:      "none",
+	}
+
+	primitives.ToDataBarAxisPosition = make(map[string]primitives.DataBarAxisPosition, len(primitives.FromDataBarAxisPosition))
+	for k, v := range primitives.FromDataBarAxisPosition {
+		primitives.ToDataBarAxisPosition[v] = k
+	}
+}
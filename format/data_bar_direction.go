@@ -0,0 +1,26 @@
+package format
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+//List of all possible values for DataBarDirection
+const (
+	_ primitives.DataBarDirection = iota
+	DataBarDirectionContext
+	DataBarDirectionLeftToRight
+	DataBarDirectionRightToLeft
+)
+
+func init() {
+	primitives.FromDataBarDirection = map[primitives.DataBarDirection]string{
+		DataBarDirectionContext:     "context",
+		DataBarDirectionLeftToRight: "leftToRight",
+		DataBarDirectionRightToLeft: "rightToLeft",
+	}
+
+	primitives.ToDataBarDirection = make(map[string]primitives.DataBarDirection, len(primitives.FromDataBarDirection))
+	for k, v := range primitives.FromDataBarDirection {
+		primitives.ToDataBarDirection[v] = k
+	}
+}
@@ -0,0 +1,115 @@
+package format
+
+import (
+	"github.com/plandem/xlsx/internal/color"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDataBar(t *testing.T) {
+	gradient := false
+	sameAsPositive := false
+
+	//defaults
+	require.Equal(t, &ml.X14DataBar{
+		Values: []*ml.X14ConditionValue{
+			{Type: ConditionValueTypeAutoMin},
+			{Type: ConditionValueTypeAutoMax},
+		},
+		FillColor:         &ml.Color{RGB: "FF638EC6"},
+		NegativeFillColor: color.New("#FF0000"),
+		AxisColor:         color.New("#000000"),
+		MaxLength:         100,
+		Gradient:          &[]bool{true}[0],
+	}, newDataBar())
+
+	require.Equal(t, &ml.X14DataBar{
+		Values: []*ml.X14ConditionValue{
+			{Type: ConditionValueTypeNum, Formula: "-10"},
+			{Type: ConditionValueTypePercentile, Formula: "90"},
+		},
+		FillColor:                            &ml.Color{RGB: "FF112233"},
+		BorderColor:                          &ml.Color{RGB: "FF223344"},
+		NegativeFillColor:                    &ml.Color{RGB: "FF334455"},
+		NegativeBorderColor:                  &ml.Color{RGB: "FF445566"},
+		AxisColor:                            &ml.Color{RGB: "FF556677"},
+		MinLength:                            10,
+		MaxLength:                            90,
+		Border:                               true,
+		Gradient:                             &gradient,
+		Direction:                            DataBarDirectionRightToLeft,
+		NegativeBarBorderColorSameAsPositive: &sameAsPositive,
+		AxisPosition:                         DataBarAxisMiddle,
+	}, newDataBar(
+		DataBar.Min(ConditionValueTypeNum, "-10"),
+		DataBar.Max(ConditionValueTypePercentile, "90"),
+		DataBar.Length(10, 90),
+		DataBar.Color("#112233"),
+		DataBar.Solid,
+		DataBar.Border("#223344"),
+		DataBar.NegativeColor("#334455"),
+		DataBar.NegativeBorder("#445566"),
+		DataBar.Axis(DataBarAxisMiddle, "#556677"),
+		DataBar.Direction(DataBarDirectionRightToLeft),
+	))
+
+	bar := newDataBar(DataBar.NegativeSameAsPositive, DataBar.Axis(DataBarAxisNone, "#112233"))
+	require.Equal(t, true, bar.NegativeBarColorSameAsPositive)
+	require.Nil(t, bar.NegativeFillColor)
+	require.Nil(t, bar.AxisColor)
+	require.Equal(t, DataBarAxisNone, bar.AxisPosition)
+}
+
+func TestConditionalRule_ExtendedDataBar(t *testing.T) {
+	rule := newConditionalRule(
+		Condition.Priority(1),
+		Condition.ExtendedDataBar(
+			DataBar.Max(ConditionValueTypePercent, "80"),
+			DataBar.Color("#112233"),
+		),
+	)
+
+	require.Equal(t, &ml.ConditionalRule{
+		Type:     ConditionTypeDataBar,
+		Priority: 1,
+		DataBar: &ml.DataBar{
+			Values: []*ml.ConditionValue{
+				{Type: ConditionValueTypeMin},
+				{Type: ConditionValueTypePercent, Value: "80"},
+			},
+			Color: &ml.Color{RGB: "FF112233"},
+		},
+	}, rule.rule)
+
+	require.Equal(t, newDataBar(DataBar.Max(ConditionValueTypePercent, "80"), DataBar.Color("#112233")), rule.dataBar)
+
+	conditions := NewConditions(Conditions.Refs("A1:A10"), Conditions.Rule(
+		Condition.Priority(1),
+		Condition.ExtendedDataBar(DataBar.Min(ConditionValueTypeNum, "")),
+	))
+	require.NotNil(t, conditions.Validate())
+
+	conditions = NewConditions(Conditions.Refs("A1:A10"), Conditions.Rule(
+		Condition.Priority(1),
+		Condition.ExtendedDataBar(),
+		Condition.Type(ConditionTypeCellIs),
+	))
+	require.NotNil(t, conditions.Validate())
+
+	conditions = NewConditions(Conditions.Refs("A1:A10"), Conditions.Rule(
+		Condition.Priority(1),
+		Condition.Type(ConditionTypeCellIs),
+		Condition.Operator(ConditionOperatorGreaterThan),
+		Condition.Formula("1"),
+	), Conditions.Rule(
+		Condition.Priority(2),
+		Condition.ExtendedDataBar(),
+	))
+	require.Nil(t, conditions.Validate())
+
+	info, styles, extensions := fromConditionalFormat(conditions)
+	require.Equal(t, 2, len(info.Rules))
+	require.Equal(t, 2, len(styles))
+	require.Equal(t, []*ml.X14ConditionalRule{nil, {Type: ConditionTypeDataBar, DataBar: conditions.rules[1].dataBar}}, extensions)
+}
@@ -39,6 +39,53 @@ func (f *fillOption) Type(pt primitives.PatternType) styleOption {
 	}
 }
 
+//Solid sets solid fill with color, e.g.: Fill.Solid("#FFFF00")
+func (f *fillOption) Solid(rgb string) styleOption {
+	return func(s *StyleFormat) {
+		s.styleInfo.Fill.Pattern = &ml.PatternFill{Type: PatternTypeSolid, Color: color.New(rgb)}
+		s.styleInfo.Fill.Gradient = &ml.GradientFill{}
+	}
+}
+
+//Patterned sets pattern fill with type, color of pattern and background color, e.g.: Fill.Patterned(PatternTypeLightGrid, "#FF0000", "#FFFFFF")
+func (f *fillOption) Patterned(pt primitives.PatternType, rgb string, background string) styleOption {
+	return func(s *StyleFormat) {
+		s.styleInfo.Fill.Pattern = &ml.PatternFill{Type: pt, Color: color.New(rgb), Background: color.New(background)}
+		s.styleInfo.Fill.Gradient = &ml.GradientFill{}
+	}
+}
+
+//LinearGradient sets linear gradient fill with angle in degrees and colors of stops that are evenly distributed, e.g.: Fill.LinearGradient(90, "#FFFFFF", "#4472C4", "#FFFFFF")
+func (f *fillOption) LinearGradient(degree float64, colors ...string) styleOption {
+	return func(s *StyleFormat) {
+		s.styleInfo.Fill.Gradient = &ml.GradientFill{Type: GradientTypeLinear, Degree: degree, Stop: gradientStops(colors)}
+		s.styleInfo.Fill.Pattern = &ml.PatternFill{}
+	}
+}
+
+//PathGradient sets path gradient fill with inner rectangle in 0-1 range and colors of stops that are evenly distributed from rectangle to edges of cell, e.g.: Fill.PathGradient(0.5, 0.5, 0.5, 0.5, "#FFFFFF", "#4472C4")
+func (f *fillOption) PathGradient(left, right, top, bottom float64, colors ...string) styleOption {
+	return func(s *StyleFormat) {
+		s.styleInfo.Fill.Gradient = &ml.GradientFill{Type: GradientTypePath, Left: left, Right: right, Top: top, Bottom: bottom, Stop: gradientStops(colors)}
+		s.styleInfo.Fill.Pattern = &ml.PatternFill{}
+	}
+}
+
+//gradientStops returns stops for colors with positions that are evenly distributed in 0-1 range
+func gradientStops(colors []string) []*ml.GradientStop {
+	stops := make([]*ml.GradientStop, 0, len(colors))
+	for i, rgb := range colors {
+		position := 0.0
+		if len(colors) > 1 {
+			position = float64(i) / float64(len(colors)-1)
+		}
+
+		stops = append(stops, &ml.GradientStop{Position: position, Color: color.New(rgb)})
+	}
+
+	return stops
+}
+
 func (p *patternOption) Color(rgb string) styleOption {
 	return func(s *StyleFormat) {
 		s.styleInfo.Fill.Pattern.Color = color.New(rgb)
@@ -3,6 +3,7 @@ package format
 import (
 	"github.com/plandem/xlsx/internal/color"
 	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/internal/ml/primitives"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -108,3 +109,66 @@ func TestFill(t *testing.T) {
 		}
 	}), style)
 }
+
+func TestFillPresets(t *testing.T) {
+	style := NewStyles(Fill.Solid("#FFFF00"))
+	require.Equal(t, createStylesAndFill(func(f *StyleFormat) {
+		f.styleInfo.Fill.Pattern = &ml.PatternFill{
+			Color: color.New("FFFFFF00"),
+			Type:  PatternTypeSolid,
+		}
+	}), style)
+
+	style = NewStyles(
+		Fill.Gradient.Degree(90),
+		Fill.Patterned(PatternTypeLightGrid, "#FF0000", "#FFFFFF"),
+	)
+	require.Equal(t, createStylesAndFill(func(f *StyleFormat) {
+		f.styleInfo.Fill.Pattern = &ml.PatternFill{
+			Color:      color.New("FFFF0000"),
+			Background: color.New("FFFFFFFF"),
+			Type:       PatternTypeLightGrid,
+		}
+	}), style)
+
+	style = NewStyles(
+		Fill.Solid("#FFFF00"),
+		Fill.LinearGradient(90, "#FFFFFF", "#FF0000", "#FFFFFF"),
+	)
+	require.Equal(t, createStylesAndFill(func(f *StyleFormat) {
+		f.styleInfo.Fill.Gradient = &ml.GradientFill{
+			Type:   GradientTypeLinear,
+			Degree: 90,
+			Stop: []*ml.GradientStop{
+				{Position: 0, Color: color.New("FFFFFFFF")},
+				{Position: 0.5, Color: color.New("FFFF0000")},
+				{Position: 1, Color: color.New("FFFFFFFF")},
+			},
+		}
+	}), style)
+
+	style = NewStyles(Fill.PathGradient(0.5, 0.5, 0.5, 0.5, "#FFFFFF", "#0000FF"))
+	require.Equal(t, createStylesAndFill(func(f *StyleFormat) {
+		f.styleInfo.Fill.Gradient = &ml.GradientFill{
+			Type:   GradientTypePath,
+			Left:   0.5,
+			Right:  0.5,
+			Top:    0.5,
+			Bottom: 0.5,
+			Stop: []*ml.GradientStop{
+				{Position: 0, Color: color.New("FFFFFFFF")},
+				{Position: 1, Color: color.New("FF0000FF")},
+			},
+		}
+	}), style)
+
+	//all patterns of ECMA-376
+	for _, name := range []string{
+		"none", "solid", "mediumGray", "darkGray", "lightGray", "darkHorizontal", "darkVertical", "darkDown", "darkUp", "darkGrid",
+		"darkTrellis", "lightHorizontal", "lightVertical", "lightDown", "lightUp", "lightGrid", "lightTrellis", "gray125", "gray0625",
+	} {
+		pt, ok := primitives.ToPatternType[name]
+		require.Equal(t, true, ok)
+		require.Equal(t, name, pt.String())
+	}
+}
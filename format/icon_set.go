@@ -0,0 +1,63 @@
+package format
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+	"strconv"
+)
+
+type iconSetOption func(s *ml.IconSet)
+
+//IconSet is a 'namespace' for all possible settings for icon set
+var IconSet iconSetOption
+
+//newIconSet creates and returns icon set of type with default thresholds and requested options
+func newIconSet(t IconSetType, options ...iconSetOption) *ml.IconSet {
+	s := &ml.IconSet{Type: t}
+
+	count := iconsCount(t)
+	for i := 0; i < count; i++ {
+		s.Values = append(s.Values, &ConditionValue(ConditionValueTypePercent, strconv.Itoa(i*100/count), true).value)
+	}
+
+	//Excel rounds thresholds up, e.g.: 0, 33, 67
+	if count == 3 {
+		s.Values[2].Value = "67"
+	}
+
+	for _, o := range options {
+		o(s)
+	}
+
+	return s
+}
+
+//iconsCount returns number of icons for type of icon set, e.g.: 3 for 3Arrows
+func iconsCount(t IconSetType) int {
+	if name := t.String(); len(name) > 0 {
+		return int(name[0] - '0')
+	}
+
+	//3TrafficLights1 is a default type
+	return 3
+}
+
+//Reverse reverses order of icons
+func (io *iconSetOption) Reverse(s *ml.IconSet) {
+	s.Reverse = true
+}
+
+//IconOnly hides values of cells and shows icons only
+func (io *iconSetOption) IconOnly(s *ml.IconSet) {
+	showValue := false
+	s.ShowValue = &showValue
+}
+
+//Thresholds sets values for each icon, the first value is a lowest threshold and usually is 0 percent
+func (io *iconSetOption) Thresholds(values ...*conditionValue) iconSetOption {
+	return func(s *ml.IconSet) {
+		s.Values = nil
+		for _, v := range values {
+			s.Values = append(s.Values, &v.value)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package format
+
+import (
+	"encoding/xml"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIconSet(t *testing.T) {
+	yes, no := true, false
+
+	require.Equal(t, 3, iconsCount(0))
+	require.Equal(t, 3, iconsCount(IconSetType3Flags))
+	require.Equal(t, 4, iconsCount(IconSetType4Rating))
+	require.Equal(t, 5, iconsCount(IconSetType5Quarters))
+
+	//defaults
+	require.Equal(t, &ml.IconSet{
+		Type: IconSetType3Arrows,
+		Values: []*ml.ConditionValue{
+			{Type: ConditionValueTypePercent, Value: "0", GreaterOrEqual: &yes},
+			{Type: ConditionValueTypePercent, Value: "33", GreaterOrEqual: &yes},
+			{Type: ConditionValueTypePercent, Value: "67", GreaterOrEqual: &yes},
+		},
+	}, newIconSet(IconSetType3Arrows))
+
+	require.Equal(t, []*ml.ConditionValue{
+		{Type: ConditionValueTypePercent, Value: "0", GreaterOrEqual: &yes},
+		{Type: ConditionValueTypePercent, Value: "20", GreaterOrEqual: &yes},
+		{Type: ConditionValueTypePercent, Value: "40", GreaterOrEqual: &yes},
+		{Type: ConditionValueTypePercent, Value: "60", GreaterOrEqual: &yes},
+		{Type: ConditionValueTypePercent, Value: "80", GreaterOrEqual: &yes},
+	}, newIconSet(IconSetType5Arrows).Values)
+
+	require.Equal(t, &ml.IconSet{
+		Type:      IconSetType4TrafficLights,
+		Reverse:   true,
+		ShowValue: &no,
+		Values: []*ml.ConditionValue{
+			{Type: ConditionValueTypeNum, Value: "0", GreaterOrEqual: &yes},
+			{Type: ConditionValueTypeNum, Value: "10", GreaterOrEqual: &no},
+			{Type: ConditionValueTypeFormula, Value: "$A$1", GreaterOrEqual: &yes},
+			{Type: ConditionValueTypePercentile, Value: "90", GreaterOrEqual: &yes},
+		},
+	}, newIconSet(IconSetType4TrafficLights,
+		IconSet.Reverse,
+		IconSet.IconOnly,
+		IconSet.Thresholds(
+			ConditionValue(ConditionValueTypeNum, "0", true),
+			ConditionValue(ConditionValueTypeNum, "10", false),
+			ConditionValue(ConditionValueTypeFormula, "$A$1", true),
+			ConditionValue(ConditionValueTypePercentile, "90", true),
+		),
+	))
+
+	encoded, err := xml.Marshal(newIconSet(IconSetType3Flags, IconSet.IconOnly, IconSet.Thresholds(
+		ConditionValue(ConditionValueTypePercent, "0", true),
+		ConditionValue(ConditionValueTypeNum, "5", false),
+		ConditionValue(ConditionValueTypeNum, "10", true),
+	)))
+	require.Nil(t, err)
+	require.Equal(t, `<IconSet iconSet="3Flags" showValue="false"><cfvo type="percent" val="0" gte="true"></cfvo><cfvo type="num" val="5" gte="false"></cfvo><cfvo type="num" val="10" gte="true"></cfvo></IconSet>`, string(encoded))
+}
+
+func TestConditionalRule_Icons(t *testing.T) {
+	rule := newConditionalRule(Condition.Priority(1), Condition.Icons(IconSetType5Rating, IconSet.Reverse))
+	require.Equal(t, ConditionTypeIconSet, rule.rule.Type)
+	require.Equal(t, newIconSet(IconSetType5Rating, IconSet.Reverse), rule.rule.IconSet)
+
+	require.Nil(t, NewConditions(
+		Conditions.Refs("A1:A10"),
+		Conditions.Rule(Condition.Priority(1), Condition.Icons(IconSetType4Arrows)),
+	).Validate())
+
+	require.NotNil(t, NewConditions(
+		Conditions.Refs("A1:A10"),
+		Conditions.Rule(Condition.Priority(1), Condition.Icons(IconSetType4Arrows, IconSet.Thresholds(
+			ConditionValue(ConditionValueTypePercent, "0", true),
+			ConditionValue(ConditionValueTypePercent, "50", true),
+		))),
+	).Validate())
+}
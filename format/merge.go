@@ -0,0 +1,54 @@
+package format
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+	"reflect"
+)
+
+//mergeFields sets fields of dst with non-empty fields of src, where dst and src are pointers to same type of struct
+func mergeFields(dst, src interface{}) {
+	d, s := reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem()
+	for i := 0; i < s.NumField(); i++ {
+		if field := s.Field(i); !reflect.DeepEqual(field.Interface(), reflect.Zero(field.Type()).Interface()) {
+			d.Field(i).Set(field)
+		}
+	}
+}
+
+//Merge returns a new StyleFormat with settings of base, where non-empty settings of overlay are layered on top, e.g.: Merge(existing, NewStyles(Font.Bold)) keeps settings of existing and makes font bold.
+//N.B.: colors, number format, gradient and named style are replaced as a whole, empty settings of overlay (e.g. not bold font) can't reset settings of base
+func Merge(base, overlay *StyleFormat) *StyleFormat {
+	s := toStyleFormat(fromStyleFormat(base))
+	style, delta := s.styleInfo, overlay.styleInfo
+
+	mergeFields(style.Font, delta.Font)
+	mergeFields(style.Alignment, delta.Alignment)
+	mergeFields(style.Protection, delta.Protection)
+	mergeFields(style.Fill.Pattern, delta.Fill.Pattern)
+
+	mergeFields(style.Border.Left, delta.Border.Left)
+	mergeFields(style.Border.Right, delta.Border.Right)
+	mergeFields(style.Border.Top, delta.Border.Top)
+	mergeFields(style.Border.Bottom, delta.Border.Bottom)
+	mergeFields(style.Border.Diagonal, delta.Border.Diagonal)
+	mergeFields(style.Border.Vertical, delta.Border.Vertical)
+	mergeFields(style.Border.Horizontal, delta.Border.Horizontal)
+	style.Border.DiagonalUp = style.Border.DiagonalUp || delta.Border.DiagonalUp
+	style.Border.DiagonalDown = style.Border.DiagonalDown || delta.Border.DiagonalDown
+	style.Border.Outline = style.Border.Outline || delta.Border.Outline
+
+	if !reflect.DeepEqual(delta.Fill.Gradient, &ml.GradientFill{}) {
+		*style.Fill.Gradient = *delta.Fill.Gradient
+		style.Fill.Gradient.Stop = append([]*ml.GradientStop(nil), delta.Fill.Gradient.Stop...)
+	}
+
+	if *delta.NumberFormat != (ml.NumberFormat{}) {
+		*style.NumberFormat = *delta.NumberFormat
+	}
+
+	if *overlay.namedInfo != (ml.NamedStyleInfo{}) {
+		*s.namedInfo = *overlay.namedInfo
+	}
+
+	return s
+}
@@ -0,0 +1,58 @@
+package format
+
+import (
+	"github.com/plandem/xlsx/internal/color"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	base := NewStyles(
+		Font.Name("Arial"),
+		Font.Size(10),
+		Font.Color("#FF0000"),
+		Fill.Type(PatternTypeSolid),
+		Fill.Color("#FFFF00"),
+		Border.Left.Type(BorderStyleThin),
+		Alignment.HAlign(HAlignCenter),
+		NumberFormat("0.00%"),
+	)
+
+	merged := Merge(base, NewStyles(
+		Font.Bold,
+		Font.Color("#0000FF"),
+		Border.Right.Type(BorderStyleThick),
+	))
+
+	font, fill, alignment, number, protection, border, namedInfo := fromStyleFormat(merged)
+	require.Equal(t, &ml.Font{Name: "Arial", Size: 10, Bold: true, Color: color.New("FF0000FF")}, font)
+	require.Equal(t, &ml.Fill{Pattern: &ml.PatternFill{Type: PatternTypeSolid, Color: color.New("FFFFFF00")}}, fill)
+	require.Equal(t, &ml.CellAlignment{Horizontal: HAlignCenter}, alignment)
+	require.Equal(t, "0.00%", number.Code)
+	require.Nil(t, protection)
+	require.Equal(t, BorderStyleThin, border.Left.Type)
+	require.Equal(t, BorderStyleThick, border.Right.Type)
+	require.Nil(t, namedInfo)
+
+	//base is not changed
+	font, _, _, _, _, border, _ = fromStyleFormat(base)
+	require.Equal(t, &ml.Font{Name: "Arial", Size: 10, Color: color.New("FFFF0000")}, font)
+	require.Nil(t, border.Right)
+
+	//empty overlay keeps everything as is
+	require.Equal(t, base, Merge(base, NewStyles()))
+}
+
+func TestToStyleFormat(t *testing.T) {
+	style := NewStyles(
+		Font.Bold,
+		Fill.Type(PatternTypeSolid),
+		Fill.Color("#FFFF00"),
+		Border.Top.Type(BorderStyleDouble),
+		Protection.Hidden,
+	)
+
+	require.Equal(t, style, toStyleFormat(fromStyleFormat(style)))
+	require.Equal(t, NewStyles(), toStyleFormat(nil, nil, nil, nil, nil, nil, nil))
+}
@@ -17,3 +17,18 @@ func NumberFormatID(id int) func(*StyleFormat) {
 		*s.styleInfo.NumberFormat = numberFormat.New(id, "")
 	}
 }
+
+//IsDateFormat returns true if number format code has a date part, e.g.: yyyy-mm-dd or locale specific [$-F800]
+func IsDateFormat(code string) bool {
+	return numberFormat.IsDate(code)
+}
+
+//IsTimeFormat returns true if number format code has a time part, e.g.: h:mm AM/PM or [h]:mm:ss
+func IsTimeFormat(code string) bool {
+	return numberFormat.IsTime(code)
+}
+
+//IsCurrencyFormat returns true if number format code has a currency symbol, e.g.: $#,##0.00 or [$€-407]#,##0.00
+func IsCurrencyFormat(code string) bool {
+	return numberFormat.IsCurrency(code)
+}
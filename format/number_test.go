@@ -30,3 +30,12 @@ func TestNumberFormat(t *testing.T) {
 		}
 	}), style)
 }
+
+func TestNumberFormatDetection(t *testing.T) {
+	require.Equal(t, true, IsDateFormat(`dd.mm.yyyy`))
+	require.Equal(t, false, IsDateFormat(`#,##0.00`))
+	require.Equal(t, true, IsTimeFormat(`h:mm AM/PM`))
+	require.Equal(t, false, IsTimeFormat(`m/d/yy`))
+	require.Equal(t, true, IsCurrencyFormat(`[$€-407]#,##0.00`))
+	require.Equal(t, false, IsCurrencyFormat(`0.00%`))
+}
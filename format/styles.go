@@ -181,3 +181,62 @@ func toRichFont(f *StyleFormat) *ml.RichFont {
 
 	return nil
 }
+
+//private method used by stylesheet manager to pack settings of existing style into StyleFormat
+func toStyleFormat(font *ml.Font, fill *ml.Fill, alignment *ml.CellAlignment, numFormat *ml.NumberFormat, protection *ml.CellProtection, border *ml.Border, namedInfo *ml.NamedStyleInfo) *StyleFormat {
+	s := NewStyles()
+	style := s.styleInfo
+
+	if font != nil {
+		*style.Font = *font
+	}
+
+	if alignment != nil {
+		*style.Alignment = *alignment
+	}
+
+	if numFormat != nil {
+		*style.NumberFormat = *numFormat
+	}
+
+	if protection != nil {
+		*style.Protection = *protection
+	}
+
+	if namedInfo != nil {
+		*s.namedInfo = *namedInfo
+	}
+
+	if fill != nil {
+		if fill.Pattern != nil {
+			*style.Fill.Pattern = *fill.Pattern
+		}
+
+		if fill.Gradient != nil {
+			*style.Fill.Gradient = *fill.Gradient
+			style.Fill.Gradient.Stop = append([]*ml.GradientStop(nil), fill.Gradient.Stop...)
+		}
+	}
+
+	if border != nil {
+		for _, segment := range []struct{ from, to *ml.BorderSegment }{
+			{border.Left, style.Border.Left},
+			{border.Right, style.Border.Right},
+			{border.Top, style.Border.Top},
+			{border.Bottom, style.Border.Bottom},
+			{border.Diagonal, style.Border.Diagonal},
+			{border.Vertical, style.Border.Vertical},
+			{border.Horizontal, style.Border.Horizontal},
+		} {
+			if segment.from != nil {
+				*segment.to = *segment.from
+			}
+		}
+
+		style.Border.DiagonalUp = border.DiagonalUp
+		style.Border.DiagonalDown = border.DiagonalDown
+		style.Border.Outline = border.Outline
+	}
+
+	return s
+}
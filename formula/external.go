@@ -0,0 +1,76 @@
+package formula
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var regExpExternal = regexp.MustCompile(`^\[([0-9]+)\]`)
+
+//isExternalBoundary returns true if reference to external workbook can start right after position, i.e. it's not a part of structured reference
+func isExternalBoundary(s string, pos int) bool {
+	if pos < 0 {
+		return true
+	}
+
+	return strings.IndexByte("=+-*/^&<>(,; %'{", s[pos]) != -1
+}
+
+//ReplaceExternalLinks returns formula where each 1-based index of external workbook is replaced with result of callback, e.g.: ReplaceExternalLinks("[1]Sheet1!A1+'[2]My Sheet'!B2", ...)
+func ReplaceExternalLinks(formula string, replace func(index int) int) string {
+	var result strings.Builder
+	for pos := 0; pos < len(formula); {
+		rest := formula[pos:]
+
+		//strings must be kept as is
+		if rest[0] == '"' {
+			end := 1
+			for end < len(rest) {
+				if rest[end] == '"' {
+					if end+1 < len(rest) && rest[end+1] == '"' {
+						end += 2
+						continue
+					}
+
+					end++
+					break
+				}
+
+				end++
+			}
+
+			result.WriteString(rest[:end])
+			pos += end
+			continue
+		}
+
+		if m := regExpExternal.FindStringSubmatch(rest); m != nil && isExternalBoundary(formula, pos-1) {
+			index, _ := strconv.Atoi(m[1])
+			result.WriteString("[" + strconv.Itoa(replace(index)) + "]")
+			pos += len(m[0])
+			continue
+		}
+
+		result.WriteByte(rest[0])
+		pos++
+	}
+
+	return result.String()
+}
+
+//ExternalLinks returns unique 1-based indexes of external workbooks that are used by formula, e.g.: ExternalLinks("[1]Sheet1!A1+[3]Sheet1!A1") => [1, 3]
+func ExternalLinks(formula string) []int {
+	var indexes []int
+	used := make(map[int]bool)
+	ReplaceExternalLinks(formula, func(index int) int {
+		if !used[index] {
+			used[index] = true
+			indexes = append(indexes, index)
+		}
+
+		return index
+	})
+
+	return indexes
+}
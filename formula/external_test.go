@@ -0,0 +1,22 @@
+package formula
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestReplaceExternalLinks(t *testing.T) {
+	for formula, result := range map[string]string{
+		"[1]Sheet1!A1+[2]Sheet1!B2":     "[2]Sheet1!A1+[3]Sheet1!B2",
+		"SUM('[3]My Sheet'!A1:A10)":     "SUM('[4]My Sheet'!A1:A10)",
+		`"[1]Sheet1!A1"&[1]Sheet1!A1`:   `"[1]Sheet1!A1"&[2]Sheet1!A1`,
+		"SUM(Table1[1])+Table1[[#All]]": "SUM(Table1[1])+Table1[[#All]]",
+		"[1]!TaxRate*A1":                "[2]!TaxRate*A1",
+		"A1+B2":                         "A1+B2",
+	} {
+		require.Equal(t, result, ReplaceExternalLinks(formula, func(index int) int { return index + 1 }), formula)
+	}
+
+	require.Equal(t, []int{1, 3}, ExternalLinks("[1]Sheet1!A1+[3]Sheet1!A1*[1]Sheet2!B1"))
+	require.Nil(t, ExternalLinks(`"[1]Sheet1!A1"&Table1[1]`))
+}
@@ -0,0 +1,154 @@
+package formula
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+//Error is a type of Excel error value, e.g.: #DIV/0!
+type Error string
+
+//List of all possible error values
+const (
+	ErrorNull   Error = "#NULL!"
+	ErrorDivide Error = "#DIV/0!"
+	ErrorValue  Error = "#VALUE!"
+	ErrorRef    Error = "#REF!"
+	ErrorName   Error = "#NAME?"
+	ErrorNum    Error = "#NUM!"
+	ErrorNA     Error = "#N/A"
+)
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+//Range is a value of multi-cell reference, rows of values
+type Range [][]interface{}
+
+//Function is a type of function that can be used in formulas. Arguments are already evaluated and can be one of: nil (empty cell), float64, string, bool, Error or Range
+type Function func(args ...interface{}) interface{}
+
+//Context is an interface that provides access to content of spreadsheet during evaluation
+type Context interface {
+	//Value returns value of cell with 0-based indexes at sheet with name (empty name for current sheet): nil (empty cell), float64, string, bool or Error
+	Value(sheet string, cIdx, rIdx int) interface{}
+	//Dimension returns total number of columns and rows of sheet with name (empty name for current sheet)
+	Dimension(sheet string) (cols int, rows int)
+	//Name returns formula of defined name and true, or false if there is no such name
+	Name(name string) (string, bool)
+}
+
+//Evaluator is an interface of formula evaluator
+type Evaluator interface {
+	//Evaluate evaluates formula and returns result: nil, float64, string, bool or Error. Error is returned only for invalid formulas
+	Evaluate(formula string, ctx Context) (interface{}, error)
+}
+
+//Engine is a built-in evaluator that supports arithmetic, comparison, concatenation and the most common functions
+type Engine struct {
+	functions map[string]Function
+	cache     map[string]node
+	mu        sync.RWMutex
+}
+
+var _ Evaluator = (*Engine)(nil)
+
+//maxDepth is maximum depth of nested defined names
+const maxDepth = 64
+
+//maxCachedFormulas is maximum number of parsed formulas that are cached by engine. Cache is dropped when limit is reached, so memory is not leaked by long-lived engines
+const maxCachedFormulas = 4096
+
+//New creates and returns a new Engine with all built-in functions
+func New() *Engine {
+	e := &Engine{
+		functions: make(map[string]Function, len(builtinFunctions)),
+		cache:     make(map[string]node),
+	}
+
+	for name, f := range builtinFunctions {
+		e.functions[name] = f
+	}
+
+	return e
+}
+
+//Register registers a new or replaces existing function with name
+func (e *Engine) Register(name string, f Function) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.functions[strings.ToUpper(name)] = f
+}
+
+//Evaluate evaluates formula and returns result: nil, float64, string, bool or Error. Error is returned only for invalid formulas
+func (e *Engine) Evaluate(formula string, ctx Context) (interface{}, error) {
+	if ctx == nil {
+		return nil, errors.New("no context for evaluation")
+	}
+
+	n, err := e.parse(formula)
+	if err != nil {
+		return nil, err
+	}
+
+	result := n.eval(&evaluation{engine: e, ctx: ctx})
+
+	//top level result of multi-cell reference is a value of the first cell
+	if r, ok := result.(Range); ok {
+		result = r.first()
+	}
+
+	return result, nil
+}
+
+//parse parses formula or returns already parsed version
+func (e *Engine) parse(formula string) (node, error) {
+	formula = strings.TrimPrefix(strings.TrimSpace(formula), "=")
+
+	e.mu.RLock()
+	n, ok := e.cache[formula]
+	e.mu.RUnlock()
+
+	if ok {
+		return n, nil
+	}
+
+	tokens, err := tokenize(formula)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if n, err = p.parse(); err != nil {
+		return nil, errors.New(fmt.Sprintf("invalid formula %q: %s", formula, err))
+	}
+
+	e.mu.Lock()
+	if len(e.cache) >= maxCachedFormulas {
+		e.cache = make(map[string]node)
+	}
+
+	e.cache[formula] = n
+	e.mu.Unlock()
+
+	return n, nil
+}
+
+//function returns function with name or nil if there is no such function
+func (e *Engine) function(name string) Function {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.functions[name]
+}
+
+//evaluation holds state of evaluation
+type evaluation struct {
+	engine *Engine
+	ctx    Context
+	depth  int
+}
@@ -0,0 +1,157 @@
+package formula
+
+import (
+	"fmt"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type testContext struct {
+	cells map[string]interface{}
+	names map[string]string
+}
+
+func (c *testContext) Value(sheet string, cIdx, rIdx int) interface{} {
+	if len(sheet) > 0 {
+		return c.cells[sheet+"!"+string(types.CellRefFromIndexes(cIdx, rIdx))]
+	}
+
+	return c.cells[string(types.CellRefFromIndexes(cIdx, rIdx))]
+}
+
+func (c *testContext) Dimension(sheet string) (int, int) {
+	return 3, 5
+}
+
+func (c *testContext) Name(name string) (string, bool) {
+	formula, ok := c.names[name]
+	return formula, ok
+}
+
+func newTestContext() *testContext {
+	return &testContext{
+		cells: map[string]interface{}{
+			"A1": "Region", "B1": "Amount", "C1": true,
+			"A2": "North", "B2": 100.0,
+			"A3": "South", "B3": 200.0,
+			"A4": "East", "B4": "n/a",
+			"A5": "West", "B5": 50.0, "C5": ErrorDivide,
+			"Other Sheet!A1": 7.0,
+		},
+		names: map[string]string{
+			"TaxRate": "0.2",
+			"Amounts": "$B$2:$B$5",
+			"Loop":    "Loop+1",
+		},
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	tokens, err := tokenize(`SUM('Other ''s'!$A$1:B2, Sheet1!C:C, 1:2) & "a""b" <> #N/A`)
+	require.Nil(t, err)
+	require.Equal(t, []token{
+		{kind: tokenFunction, value: "SUM"},
+		{kind: tokenOpen, value: "("},
+		{kind: tokenRef, value: "$A$1:B2", sheet: "Other 's"},
+		{kind: tokenComma, value: ","},
+		{kind: tokenRef, value: "C:C", sheet: "Sheet1"},
+		{kind: tokenComma, value: ","},
+		{kind: tokenRef, value: "1:2"},
+		{kind: tokenClose, value: ")"},
+		{kind: tokenOperator, value: "&"},
+		{kind: tokenString, value: `a"b`},
+		{kind: tokenOperator, value: "<>"},
+		{kind: tokenError, value: "#N/A"},
+	}, tokens)
+
+	//names and functions that look like references
+	tokens, err = tokenize(`LOG10(A1)+TaxRate+_xlfn.CONCAT(TRUE)`)
+	require.Nil(t, err)
+	require.Equal(t, tokenFunction, tokens[0].kind)
+	require.Equal(t, "LOG10", tokens[0].value)
+	require.Equal(t, token{kind: tokenName, value: "TaxRate"}, tokens[5])
+	require.Equal(t, token{kind: tokenFunction, value: "CONCAT"}, tokens[7])
+	require.Equal(t, token{kind: tokenBool, value: "TRUE"}, tokens[9])
+
+	_, err = tokenize(`"abc`)
+	require.NotNil(t, err)
+
+	_, err = tokenize(`#ABC`)
+	require.NotNil(t, err)
+}
+
+func TestEvaluate(t *testing.T) {
+	e := New()
+	ctx := newTestContext()
+
+	for formula, result := range map[string]interface{}{
+		"=1+2*3":             7.0,
+		"(1+2)*3":            9.0,
+		"-2^2":               4.0,
+		"2^3^2":              64.0,
+		"50%":                0.5,
+		"10/4":               2.5,
+		"1/0":                ErrorDivide,
+		"B2+B3":              300.0,
+		"B2+B4":              ErrorValue,
+		"B2+C1":              101.0,
+		"B2+D10":             100.0,
+		`A2&" "&B2`:          "North 100",
+		`0.1+0.2&""`:         "0.3",
+		"B2>B3":              false,
+		`A2="north"`:         true,
+		`"a"<1`:              false,
+		"TRUE>1":             true,
+		"D10=0":              true,
+		`D10=""`:             true,
+		"A2:B3":              "North",
+		"B2:B3+1":            ErrorValue,
+		"'Other Sheet'!A1*2": 14.0,
+		"B2*TaxRate":         20.0,
+		"SUM(Amounts)":       350.0,
+		"Unknown":            ErrorName,
+		"Loop":               ErrorRef,
+		"UNKNOWN(1)":         ErrorName,
+		"C5+1":               ErrorDivide,
+		"#N/A":               ErrorNA,
+	} {
+		value, err := e.Evaluate(formula, ctx)
+		require.Nil(t, err, formula)
+		require.Equal(t, result, value, formula)
+	}
+
+	//invalid formulas
+	for _, formula := range []string{"", "1+", "(1", "SUM(1", "SUM(1 2)", "1)", "A1 B1"} {
+		_, err := e.Evaluate(formula, ctx)
+		require.NotNil(t, err, formula)
+	}
+
+	_, err := e.Evaluate("1", nil)
+	require.NotNil(t, err)
+
+	//custom function
+	e.Register("double", func(args ...interface{}) interface{} {
+		f, err := number(args[0])
+		if err != "" {
+			return err
+		}
+
+		return f * 2
+	})
+
+	value, err := e.Evaluate("DOUBLE(B2)", ctx)
+	require.Nil(t, err)
+	require.Equal(t, 200.0, value)
+
+	//parsed formulas are cached
+	require.Equal(t, 1, len(e.cache["DOUBLE(B2)"].(*callNode).args))
+
+	//cache is limited
+	for i := 0; i < maxCachedFormulas+10; i++ {
+		_, err = e.Evaluate(fmt.Sprintf("B2+%d", i), ctx)
+		require.Nil(t, err)
+	}
+
+	require.True(t, len(e.cache) <= maxCachedFormulas)
+}
@@ -0,0 +1,1027 @@
+package formula
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+//builtinFunctions is a list of functions that are supported by Engine out of the box
+var builtinFunctions = map[string]Function{
+	//math and aggregation
+	"SUM":        fnSum,
+	"PRODUCT":    fnProduct,
+	"AVERAGE":    fnAverage,
+	"MIN":        fnMin,
+	"MAX":        fnMax,
+	"COUNT":      fnCount,
+	"COUNTA":     fnCountA,
+	"COUNTBLANK": fnCountBlank,
+	"STDEV":      fnStdDev,
+	"VAR":        fnVar,
+	"SUBTOTAL":   fnSubtotal,
+	"SUMIF":      fnSumIf,
+	"COUNTIF":    fnCountIf,
+	"ABS":        fnMath(math.Abs),
+	"INT":        fnMath(math.Floor),
+	"SQRT":       fnSqrt,
+	"MOD":        fnMod,
+	"POWER":      fnPower,
+	"ROUND":      fnRound(math.Round),
+	"ROUNDUP":    fnRound(func(f float64) float64 { return math.Copysign(math.Ceil(math.Abs(f)), f) }),
+	"ROUNDDOWN":  fnRound(math.Trunc),
+
+	//logical
+	"IF":      fnIf,
+	"IFERROR": fnIfError,
+	"AND":     fnAnd,
+	"OR":      fnOr,
+	"NOT":     fnNot,
+	"TRUE":    func(args ...interface{}) interface{} { return true },
+	"FALSE":   func(args ...interface{}) interface{} { return false },
+
+	//information
+	"ISBLANK":  fnIs(func(v interface{}) bool { return v == nil }),
+	"ISNUMBER": fnIs(func(v interface{}) bool { _, ok := v.(float64); return ok }),
+	"ISTEXT":   fnIs(func(v interface{}) bool { _, ok := v.(string); return ok }),
+	"ISERROR":  fnIs(func(v interface{}) bool { _, ok := v.(Error); return ok }),
+	"ISNA":     fnIs(func(v interface{}) bool { return v == ErrorNA }),
+
+	//text
+	"CONCATENATE": fnConcatenate,
+	"CONCAT":      fnConcatenate,
+	"LEN":         fnLen,
+	"LEFT":        fnLeft,
+	"RIGHT":       fnRight,
+	"MID":         fnMid,
+	"UPPER":       fnText(strings.ToUpper),
+	"LOWER":       fnText(strings.ToLower),
+	"TRIM":        fnText(func(s string) string { return strings.Join(strings.Fields(s), " ") }),
+	"SUBSTITUTE":  fnSubstitute,
+	"EXACT":       fnExact,
+	"VALUE":       fnValue,
+
+	//lookup
+	"VLOOKUP": fnVLookup,
+	"HLOOKUP": fnHLookup,
+	"MATCH":   fnMatch,
+	"INDEX":   fnIndex,
+}
+
+//checkArgs returns #VALUE! if number of arguments is not in range of min and max (-1 for unlimited)
+func checkArgs(args []interface{}, min, max int) Error {
+	if len(args) < min || (max >= 0 && len(args) > max) {
+		return ErrorValue
+	}
+
+	return ""
+}
+
+//number returns argument as number
+func number(arg interface{}) (float64, Error) {
+	return toNumber(scalar(arg))
+}
+
+//text returns argument as string
+func text(arg interface{}) (string, Error) {
+	v := scalar(arg)
+	if err, ok := v.(Error); ok {
+		return "", err
+	}
+
+	return toText(v), ""
+}
+
+//numbers collects numbers from arguments. Only numbers of references are used, other arguments are converted to number
+func numbers(args []interface{}) ([]float64, Error) {
+	var list []float64
+
+	for _, arg := range args {
+		if r, ok := arg.(Range); ok {
+			for _, row := range r {
+				for _, v := range row {
+					switch v := v.(type) {
+					case float64:
+						list = append(list, v)
+					case Error:
+						return nil, v
+					}
+				}
+			}
+
+			continue
+		}
+
+		f, err := toNumber(arg)
+		if err != "" {
+			return nil, err
+		}
+
+		list = append(list, f)
+	}
+
+	return list, ""
+}
+
+//aggregate returns function that applies fn to the numbers of arguments
+func aggregate(fn func(list []float64) interface{}) Function {
+	return func(args ...interface{}) interface{} {
+		list, err := numbers(args)
+		if err != "" {
+			return err
+		}
+
+		return fn(list)
+	}
+}
+
+var (
+	fnSum = aggregate(func(list []float64) interface{} {
+		sum := 0.0
+		for _, f := range list {
+			sum += f
+		}
+
+		return sum
+	})
+
+	fnProduct = aggregate(func(list []float64) interface{} {
+		if len(list) == 0 {
+			return 0.0
+		}
+
+		product := 1.0
+		for _, f := range list {
+			product *= f
+		}
+
+		return product
+	})
+
+	fnAverage = aggregate(func(list []float64) interface{} {
+		if len(list) == 0 {
+			return ErrorDivide
+		}
+
+		sum := 0.0
+		for _, f := range list {
+			sum += f
+		}
+
+		return sum / float64(len(list))
+	})
+
+	fnMin = aggregate(func(list []float64) interface{} {
+		if len(list) == 0 {
+			return 0.0
+		}
+
+		min := list[0]
+		for _, f := range list {
+			min = math.Min(min, f)
+		}
+
+		return min
+	})
+
+	fnMax = aggregate(func(list []float64) interface{} {
+		if len(list) == 0 {
+			return 0.0
+		}
+
+		max := list[0]
+		for _, f := range list {
+			max = math.Max(max, f)
+		}
+
+		return max
+	})
+
+	fnVar = aggregate(func(list []float64) interface{} {
+		return variance(list)
+	})
+
+	fnStdDev = aggregate(func(list []float64) interface{} {
+		v := variance(list)
+		if f, ok := v.(float64); ok {
+			return math.Sqrt(f)
+		}
+
+		return v
+	})
+)
+
+//variance returns sample variance for numbers
+func variance(list []float64) interface{} {
+	if len(list) < 2 {
+		return ErrorDivide
+	}
+
+	mean := 0.0
+	for _, f := range list {
+		mean += f
+	}
+
+	mean /= float64(len(list))
+	sum := 0.0
+	for _, f := range list {
+		sum += (f - mean) * (f - mean)
+	}
+
+	return sum / float64(len(list)-1)
+}
+
+//values calls fn for each value of arguments, including values of references
+func values(args []interface{}, fn func(v interface{}, isRef bool)) {
+	for _, arg := range args {
+		if r, ok := arg.(Range); ok {
+			for _, row := range r {
+				for _, v := range row {
+					fn(v, true)
+				}
+			}
+		} else {
+			fn(arg, false)
+		}
+	}
+}
+
+func fnCount(args ...interface{}) interface{} {
+	count := 0.0
+	values(args, func(v interface{}, isRef bool) {
+		if _, ok := v.(float64); ok {
+			count++
+		} else if _, err := toNumber(v); !isRef && v != nil && err == "" {
+			count++
+		}
+	})
+
+	return count
+}
+
+func fnCountA(args ...interface{}) interface{} {
+	count := 0.0
+	values(args, func(v interface{}, isRef bool) {
+		if v != nil {
+			count++
+		}
+	})
+
+	return count
+}
+
+func fnCountBlank(args ...interface{}) interface{} {
+	count := 0.0
+	values(args, func(v interface{}, isRef bool) {
+		if v == nil || v == "" {
+			count++
+		}
+	})
+
+	return count
+}
+
+//subtotalFunctions is a list of functions for SUBTOTAL, codes above 100 ignore hidden rows and same for evaluation
+var subtotalFunctions = map[int]Function{
+	1:  fnAverage,
+	2:  fnCount,
+	3:  fnCountA,
+	4:  fnMax,
+	5:  fnMin,
+	6:  fnProduct,
+	7:  fnStdDev,
+	9:  fnSum,
+	10: fnVar,
+}
+
+func fnSubtotal(args ...interface{}) interface{} {
+	if err := checkArgs(args, 2, -1); err != "" {
+		return err
+	}
+
+	code, err := number(args[0])
+	if err != "" {
+		return err
+	}
+
+	if f, ok := subtotalFunctions[int(code)%100]; ok {
+		return f(args[1:]...)
+	}
+
+	return ErrorValue
+}
+
+//criteria returns matcher for criteria of SUMIF/COUNTIF, e.g.: ">5", "North", "N*"
+func criteria(arg interface{}) func(v interface{}) bool {
+	value := scalar(arg)
+	s, ok := value.(string)
+	if !ok {
+		return func(v interface{}) bool { return v != nil && compare(v, value) == 0 }
+	}
+
+	operator := ""
+	for _, op := range []string{"<=", ">=", "<>", "<", ">", "="} {
+		if strings.HasPrefix(s, op) {
+			operator, s = op, s[len(op):]
+			break
+		}
+	}
+
+	//numbers are compared only with numbers
+	if f, err := toNumber(s); err == "" && len(strings.TrimSpace(s)) > 0 {
+		return func(v interface{}) bool {
+			n, ok := v.(float64)
+			if !ok {
+				if str, isStr := v.(string); isStr {
+					if n, err = toNumber(str); err != "" {
+						return operator == "<>"
+					}
+				} else {
+					return operator == "<>"
+				}
+			}
+
+			return matchOperator(operator, compareFloat(n, f))
+		}
+	}
+
+	if operator == "" || operator == "=" || operator == "<>" {
+		//empty criteria matches empty cells
+		if len(s) == 0 {
+			return func(v interface{}) bool {
+				return (v == nil || v == "") == (operator != "<>")
+			}
+		}
+
+		re := regexp.MustCompile("(?is)^" + strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(regexp.QuoteMeta(s)) + "$")
+		return func(v interface{}) bool {
+			str, ok := v.(string)
+			return (ok && re.MatchString(str)) == (operator != "<>")
+		}
+	}
+
+	return func(v interface{}) bool {
+		str, ok := v.(string)
+		return ok && matchOperator(operator, compare(str, s))
+	}
+}
+
+//matchOperator returns true if result of comparison satisfies operator
+func matchOperator(operator string, result int) bool {
+	switch operator {
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	case "<>":
+		return result != 0
+	}
+
+	return result == 0
+}
+
+func fnSumIf(args ...interface{}) interface{} {
+	if err := checkArgs(args, 2, 3); err != "" {
+		return err
+	}
+
+	r, sumRange := toRange(args[0]), toRange(args[0])
+	if len(args) == 3 {
+		sumRange = toRange(args[2])
+	}
+
+	match, sum := criteria(args[1]), 0.0
+	for rIdx, row := range r {
+		for cIdx, v := range row {
+			if match(v) && rIdx < len(sumRange) && cIdx < len(sumRange[rIdx]) {
+				if f, ok := sumRange[rIdx][cIdx].(float64); ok {
+					sum += f
+				}
+			}
+		}
+	}
+
+	return sum
+}
+
+func fnCountIf(args ...interface{}) interface{} {
+	if err := checkArgs(args, 2, 2); err != "" {
+		return err
+	}
+
+	match, count := criteria(args[1]), 0.0
+	values(args[:1], func(v interface{}, isRef bool) {
+		if match(v) {
+			count++
+		}
+	})
+
+	return count
+}
+
+//fnMath returns function for math function with one argument
+func fnMath(fn func(f float64) float64) Function {
+	return func(args ...interface{}) interface{} {
+		if err := checkArgs(args, 1, 1); err != "" {
+			return err
+		}
+
+		f, err := number(args[0])
+		if err != "" {
+			return err
+		}
+
+		return fn(f)
+	}
+}
+
+func fnSqrt(args ...interface{}) interface{} {
+	if err := checkArgs(args, 1, 1); err != "" {
+		return err
+	}
+
+	f, err := number(args[0])
+	if err != "" {
+		return err
+	}
+
+	if f < 0 {
+		return ErrorNum
+	}
+
+	return math.Sqrt(f)
+}
+
+func fnMod(args ...interface{}) interface{} {
+	if err := checkArgs(args, 2, 2); err != "" {
+		return err
+	}
+
+	a, err := number(args[0])
+	if err != "" {
+		return err
+	}
+
+	b, err := number(args[1])
+	if err != "" {
+		return err
+	}
+
+	if b == 0 {
+		return ErrorDivide
+	}
+
+	//N.B.: result has the same sign as divisor
+	return a - b*math.Floor(a/b)
+}
+
+func fnPower(args ...interface{}) interface{} {
+	if err := checkArgs(args, 2, 2); err != "" {
+		return err
+	}
+
+	a, err := number(args[0])
+	if err != "" {
+		return err
+	}
+
+	b, err := number(args[1])
+	if err != "" {
+		return err
+	}
+
+	return power(a, b)
+}
+
+//fnRound returns function to round number to digits with rounding function
+func fnRound(fn func(f float64) float64) Function {
+	return func(args ...interface{}) interface{} {
+		if err := checkArgs(args, 1, 2); err != "" {
+			return err
+		}
+
+		f, err := number(args[0])
+		if err != "" {
+			return err
+		}
+
+		digits := 0.0
+		if len(args) == 2 {
+			if digits, err = number(args[1]); err != "" {
+				return err
+			}
+		}
+
+		//N.B.: use 15 significant digits to prevent issues with binary representation, e.g. 2.675 => 2.67499999
+		p := math.Pow(10, math.Trunc(digits))
+		scaled, _ := toNumber(formatNumber(f * p))
+		return fn(scaled) / p
+	}
+}
+
+func fnIf(args ...interface{}) interface{} {
+	if err := checkArgs(args, 1, 3); err != "" {
+		return err
+	}
+
+	condition, err := toBool(scalar(args[0]))
+	if err != "" {
+		return err
+	}
+
+	if condition {
+		if len(args) > 1 {
+			return args[1]
+		}
+
+		return true
+	}
+
+	if len(args) > 2 {
+		return args[2]
+	}
+
+	return false
+}
+
+func fnIfError(args ...interface{}) interface{} {
+	if err := checkArgs(args, 2, 2); err != "" {
+		return err
+	}
+
+	if _, ok := scalar(args[0]).(Error); ok {
+		return args[1]
+	}
+
+	return args[0]
+}
+
+//logical returns function that combines boolean values of arguments
+func logical(and bool) Function {
+	return func(args ...interface{}) interface{} {
+		if err := checkArgs(args, 1, -1); err != "" {
+			return err
+		}
+
+		result, total, err := and, 0, Error("")
+		values(args, func(v interface{}, isRef bool) {
+			if err != "" {
+				return
+			}
+
+			if _, ok := v.(string); ok && isRef {
+				return
+			}
+
+			if v == nil && isRef {
+				return
+			}
+
+			b, e := toBool(v)
+			if e != "" {
+				err = e
+				return
+			}
+
+			total++
+			if and {
+				result = result && b
+			} else {
+				result = result || b
+			}
+		})
+
+		if err != "" {
+			return err
+		}
+
+		if total == 0 {
+			return ErrorValue
+		}
+
+		return result
+	}
+}
+
+var (
+	fnAnd = logical(true)
+	fnOr  = logical(false)
+)
+
+func fnNot(args ...interface{}) interface{} {
+	if err := checkArgs(args, 1, 1); err != "" {
+		return err
+	}
+
+	b, err := toBool(scalar(args[0]))
+	if err != "" {
+		return err
+	}
+
+	return !b
+}
+
+//fnIs returns function that checks type of value
+func fnIs(fn func(v interface{}) bool) Function {
+	return func(args ...interface{}) interface{} {
+		if err := checkArgs(args, 1, 1); err != "" {
+			return err
+		}
+
+		return fn(scalar(args[0]))
+	}
+}
+
+func fnConcatenate(args ...interface{}) interface{} {
+	var result strings.Builder
+	var err Error
+
+	values(args, func(v interface{}, isRef bool) {
+		if e, ok := v.(Error); ok && err == "" {
+			err = e
+		}
+
+		result.WriteString(toText(v))
+	})
+
+	if err != "" {
+		return err
+	}
+
+	return result.String()
+}
+
+func fnLen(args ...interface{}) interface{} {
+	if err := checkArgs(args, 1, 1); err != "" {
+		return err
+	}
+
+	s, err := text(args[0])
+	if err != "" {
+		return err
+	}
+
+	return float64(utf8.RuneCountInString(s))
+}
+
+//substring returns runes of string args[0] from start with length from args[lengthIdx] or default length
+func substring(args []interface{}, fn func(runes []rune, n int) string) interface{} {
+	if err := checkArgs(args, 1, 2); err != "" {
+		return err
+	}
+
+	s, err := text(args[0])
+	if err != "" {
+		return err
+	}
+
+	n := 1.0
+	if len(args) == 2 {
+		if n, err = number(args[1]); err != "" {
+			return err
+		}
+	}
+
+	if n < 0 {
+		return ErrorValue
+	}
+
+	runes := []rune(s)
+	if int(n) > len(runes) {
+		n = float64(len(runes))
+	}
+
+	return fn(runes, int(n))
+}
+
+func fnLeft(args ...interface{}) interface{} {
+	return substring(args, func(runes []rune, n int) string { return string(runes[:n]) })
+}
+
+func fnRight(args ...interface{}) interface{} {
+	return substring(args, func(runes []rune, n int) string { return string(runes[len(runes)-n:]) })
+}
+
+func fnMid(args ...interface{}) interface{} {
+	if err := checkArgs(args, 3, 3); err != "" {
+		return err
+	}
+
+	s, err := text(args[0])
+	if err != "" {
+		return err
+	}
+
+	start, err := number(args[1])
+	if err != "" {
+		return err
+	}
+
+	n, err := number(args[2])
+	if err != "" {
+		return err
+	}
+
+	if start < 1 || n < 0 {
+		return ErrorValue
+	}
+
+	runes := []rune(s)
+	from := int(start) - 1
+	if from >= len(runes) {
+		return ""
+	}
+
+	to := from + int(n)
+	if to > len(runes) {
+		to = len(runes)
+	}
+
+	return string(runes[from:to])
+}
+
+//fnText returns function that transforms text
+func fnText(fn func(s string) string) Function {
+	return func(args ...interface{}) interface{} {
+		if err := checkArgs(args, 1, 1); err != "" {
+			return err
+		}
+
+		s, err := text(args[0])
+		if err != "" {
+			return err
+		}
+
+		return fn(s)
+	}
+}
+
+func fnSubstitute(args ...interface{}) interface{} {
+	if err := checkArgs(args, 3, 4); err != "" {
+		return err
+	}
+
+	parts := make([]string, 3)
+	for i := range parts {
+		s, err := text(args[i])
+		if err != "" {
+			return err
+		}
+
+		parts[i] = s
+	}
+
+	if len(parts[1]) == 0 {
+		return parts[0]
+	}
+
+	if len(args) == 3 {
+		return strings.Replace(parts[0], parts[1], parts[2], -1)
+	}
+
+	instance, err := number(args[3])
+	if err != "" {
+		return err
+	}
+
+	if instance < 1 {
+		return ErrorValue
+	}
+
+	s, old := parts[0], parts[1]
+	for i, pos := 1, 0; ; i++ {
+		idx := strings.Index(s[pos:], old)
+		if idx == -1 {
+			return s
+		}
+
+		if i == int(instance) {
+			return s[:pos+idx] + parts[2] + s[pos+idx+len(old):]
+		}
+
+		pos += idx + len(old)
+	}
+}
+
+func fnExact(args ...interface{}) interface{} {
+	if err := checkArgs(args, 2, 2); err != "" {
+		return err
+	}
+
+	a, err := text(args[0])
+	if err != "" {
+		return err
+	}
+
+	b, err := text(args[1])
+	if err != "" {
+		return err
+	}
+
+	return a == b
+}
+
+func fnValue(args ...interface{}) interface{} {
+	if err := checkArgs(args, 1, 1); err != "" {
+		return err
+	}
+
+	f, err := number(args[0])
+	if err != "" {
+		return err
+	}
+
+	return f
+}
+
+//lookup returns index of value in list or -1 if there is no such value. For approximate lookup, list must be sorted in ascending order
+func lookup(value interface{}, list []interface{}, exact bool) int {
+	found := -1
+
+	for i, v := range list {
+		if exact {
+			if v != nil && compare(v, value) == 0 {
+				return i
+			}
+
+			continue
+		}
+
+		if v != nil && typeRank(v) == typeRank(value) {
+			if compare(v, value) > 0 {
+				break
+			}
+
+			found = i
+		}
+	}
+
+	return found
+}
+
+//rangeLookup implements VLOOKUP and HLOOKUP
+func rangeLookup(args []interface{}, vertical bool) interface{} {
+	if err := checkArgs(args, 3, 4); err != "" {
+		return err
+	}
+
+	value := scalar(args[0])
+	if err, ok := value.(Error); ok {
+		return err
+	}
+
+	r, ok := args[1].(Range)
+	if !ok {
+		return ErrorValue
+	}
+
+	index, err := number(args[2])
+	if err != "" {
+		return err
+	}
+
+	exact := false
+	if len(args) == 4 {
+		approximate, err := toBool(scalar(args[3]))
+		if err != "" {
+			return err
+		}
+
+		exact = !approximate
+	}
+
+	if index < 1 {
+		return ErrorValue
+	}
+
+	var list []interface{}
+	if vertical {
+		for _, row := range r {
+			list = append(list, row[0])
+		}
+	} else if len(r) > 0 {
+		list = r[0]
+	}
+
+	found := lookup(value, list, exact)
+	if found == -1 {
+		return ErrorNA
+	}
+
+	if vertical {
+		if int(index) > len(r[found]) {
+			return ErrorRef
+		}
+
+		return r[found][int(index)-1]
+	}
+
+	if int(index) > len(r) {
+		return ErrorRef
+	}
+
+	return r[int(index)-1][found]
+}
+
+func fnVLookup(args ...interface{}) interface{} {
+	return rangeLookup(args, true)
+}
+
+func fnHLookup(args ...interface{}) interface{} {
+	return rangeLookup(args, false)
+}
+
+func fnMatch(args ...interface{}) interface{} {
+	if err := checkArgs(args, 2, 3); err != "" {
+		return err
+	}
+
+	value := scalar(args[0])
+	if err, ok := value.(Error); ok {
+		return err
+	}
+
+	r := toRange(args[1])
+	var list []interface{}
+	if len(r) == 1 {
+		list = r[0]
+	} else {
+		for _, row := range r {
+			if len(row) != 1 {
+				return ErrorNA
+			}
+
+			list = append(list, row[0])
+		}
+	}
+
+	matchType := 1.0
+	if len(args) == 3 {
+		var err Error
+		if matchType, err = number(args[2]); err != "" {
+			return err
+		}
+	}
+
+	found := -1
+	switch {
+	case matchType == 0:
+		found = lookup(value, list, true)
+	case matchType > 0:
+		found = lookup(value, list, false)
+	default:
+		//list is sorted in descending order, so looking for the smallest value that is greater than or equal to lookup value
+		for i, v := range list {
+			if v != nil && typeRank(v) == typeRank(value) {
+				if compare(v, value) < 0 {
+					break
+				}
+
+				found = i
+			}
+		}
+	}
+
+	if found == -1 {
+		return ErrorNA
+	}
+
+	return float64(found + 1)
+}
+
+func fnIndex(args ...interface{}) interface{} {
+	if err := checkArgs(args, 2, 3); err != "" {
+		return err
+	}
+
+	r := toRange(args[0])
+	rIdx, err := number(args[1])
+	if err != "" {
+		return err
+	}
+
+	cIdx := 1.0
+	if len(args) == 3 {
+		if cIdx, err = number(args[2]); err != "" {
+			return err
+		}
+	} else if len(r) == 1 {
+		//for single row, the only index is index of column
+		rIdx, cIdx = 1, rIdx
+	}
+
+	if rIdx < 1 || cIdx < 1 {
+		return ErrorValue
+	}
+
+	if int(rIdx) > len(r) || int(cIdx) > len(r[int(rIdx)-1]) {
+		return ErrorRef
+	}
+
+	return r[int(rIdx)-1][int(cIdx)-1]
+}
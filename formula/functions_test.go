@@ -0,0 +1,117 @@
+package formula
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFunctions(t *testing.T) {
+	e := New()
+	ctx := newTestContext()
+
+	for formula, result := range map[string]interface{}{
+		//math and aggregation
+		"SUM(B1:B5)":                   350.0,
+		"SUM(B2,B3,1,TRUE)":            302.0,
+		`SUM("1",2)`:                   3.0,
+		`SUM("a")`:                     ErrorValue,
+		"SUM(B:B)":                     350.0,
+		"SUM(C1:C5)":                   ErrorDivide,
+		"PRODUCT(B2,2)":                200.0,
+		"AVERAGE(B2:B5)":               350.0 / 3,
+		"AVERAGE(A1:A5)":               ErrorDivide,
+		"MIN(B2:B5)":                   50.0,
+		"MAX(B2:B5,1000)":              1000.0,
+		"COUNT(B1:B5)":                 3.0,
+		`COUNT(1,"2","a")`:             2.0,
+		"COUNTA(A1:B5)":                10.0,
+		"COUNTBLANK(C1:C5)":            3.0,
+		"VAR(2,4,4,4,5,5,7,9)":         32.0 / 7,
+		"STDEV(1)":                     ErrorDivide,
+		"SUBTOTAL(109,B2:B5)":          350.0,
+		"SUBTOTAL(101,B2,B3)":          150.0,
+		"SUBTOTAL(8,B2)":               ErrorValue,
+		`SUMIF(B2:B5,">=100")`:         300.0,
+		`SUMIF(A2:A5,"*th",B2:B5)`:     300.0,
+		`SUMIF(A2:A5,"<>North",B2:B5)`: 250.0,
+		`COUNTIF(A1:A5,"?est")`:        1.0,
+		`COUNTIF(B1:B5,">60")`:         2.0,
+		`COUNTIF(C1:C5,"")`:            3.0,
+		`COUNTIF(A1:A5,"north")`:       1.0,
+		"COUNTIF(B2:B5,100)":           1.0,
+		"ABS(-1.5)":                    1.5,
+		"INT(-1.5)":                    -2.0,
+		"SQRT(16)":                     4.0,
+		"SQRT(-1)":                     ErrorNum,
+		"MOD(-3,2)":                    1.0,
+		"MOD(1,0)":                     ErrorDivide,
+		"POWER(2,10)":                  1024.0,
+		"ROUND(2.675,2)":               2.68,
+		"ROUND(-2.5,0)":                -3.0,
+		"ROUND(1234,-2)":               1200.0,
+		"ROUNDUP(1.21,1)":              1.3,
+		"ROUNDUP(-1.21,1)":             -1.3,
+		"ROUNDDOWN(1.29,1)":            1.2,
+
+		//logical
+		"IF(B2>50,\"big\",\"small\")": "big",
+		"IF(B5>50,\"big\",\"small\")": "small",
+		"IF(FALSE,1)":                 false,
+		"IF(A2,1,2)":                  ErrorValue,
+		"IFERROR(1/0,\"oops\")":       "oops",
+		"IFERROR(B2,\"oops\")":        100.0,
+		"AND(TRUE,B2>1)":              true,
+		"AND(C1:C4,0)":                false,
+		"OR(FALSE,0,C1)":              true,
+		"OR(A1:A5)":                   ErrorValue,
+		"NOT(B2)":                     false,
+		"TRUE()":                      true,
+
+		//information
+		"ISBLANK(D1)":  true,
+		"ISNUMBER(B2)": true,
+		"ISTEXT(B2)":   false,
+		"ISERROR(C5)":  true,
+		"ISNA(#N/A)":   true,
+
+		//text
+		`CONCATENATE(A2,"-",B2,"-",C1)`: "North-100-TRUE",
+		"CONCAT(A2:A3)":                 "NorthSouth",
+		"LEN(A2)":                       5.0,
+		"LEFT(A2,2)":                    "No",
+		"LEFT(A2)":                      "N",
+		"RIGHT(A2,10)":                  "North",
+		"MID(A2,2,3)":                   "ort",
+		"MID(A2,10,3)":                  "",
+		"MID(A2,0,3)":                   ErrorValue,
+		"UPPER(A2)":                     "NORTH",
+		"LOWER(A2)":                     "north",
+		`TRIM("  a   b ")`:              "a b",
+		`SUBSTITUTE("a-b-c","-","+")`:   "a+b+c",
+		`SUBSTITUTE("a-b-c","-","+",2)`: "a-b+c",
+		`EXACT("a","A")`:                false,
+		`VALUE("1.5")`:                  1.5,
+		`VALUE("abc")`:                  ErrorValue,
+
+		//lookup
+		`VLOOKUP("south",A2:B5,2,FALSE)`:       200.0,
+		`VLOOKUP("x",A2:B5,2,FALSE)`:           ErrorNA,
+		`VLOOKUP("North",A2:B5,3,FALSE)`:       ErrorRef,
+		`VLOOKUP("North",A2:B5,0,FALSE)`:       ErrorValue,
+		"VLOOKUP(150,B2:B3,1)":                 100.0,
+		"VLOOKUP(10,B2:B3,1)":                  ErrorNA,
+		`HLOOKUP("Amount",A1:C3,3,0)`:          200.0,
+		`MATCH("West",A1:A5,0)`:                5.0,
+		"MATCH(150,B2:B3)":                     1.0,
+		"MATCH(60,B3:B5,-1)":                   1.0,
+		"MATCH(1,A1:B2,0)":                     ErrorNA,
+		"INDEX(A1:B5,3,2)":                     200.0,
+		"INDEX(A1:C1,2)":                       "Amount",
+		"INDEX(A1:B5,6,1)":                     ErrorRef,
+		`INDEX(A1:B5,MATCH("East",A1:A5,0),1)`: "East",
+	} {
+		value, err := e.Evaluate(formula, ctx)
+		require.Nil(t, err, formula)
+		require.Equal(t, result, value, formula)
+	}
+}
@@ -0,0 +1,157 @@
+package formula
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type tokenType byte
+
+//List of all possible types of tokens
+const (
+	tokenNumber tokenType = iota + 1
+	tokenString
+	tokenBool
+	tokenError
+	tokenRef
+	tokenName
+	tokenFunction
+	tokenOperator
+	tokenOpen
+	tokenClose
+	tokenComma
+)
+
+type token struct {
+	kind  tokenType
+	value string
+	sheet string
+}
+
+var (
+	regExpSheetPrefix = `(?:'((?:[^']|'')+)'|([A-Za-z_][A-Za-z0-9_.]*(?::[A-Za-z_][A-Za-z0-9_.]*)?))!`
+	regExpRefCell     = regexp.MustCompile(`^(?:` + regExpSheetPrefix + `)?(\$?[A-Za-z]{1,3}\$?[0-9]+(?::\$?[A-Za-z]{1,3}\$?[0-9]+)?)`)
+	regExpRefCols     = regexp.MustCompile(`^(?:` + regExpSheetPrefix + `)?(\$?[A-Za-z]{1,3}:\$?[A-Za-z]{1,3})`)
+	regExpRefRows     = regexp.MustCompile(`^(?:` + regExpSheetPrefix + `)?(\$?[0-9]+:\$?[0-9]+)`)
+	regExpNumber      = regexp.MustCompile(`^[0-9]*\.?[0-9]+(?:[eE][+-]?[0-9]+)?`)
+	regExpIdentifier  = regexp.MustCompile(`^[A-Za-z_\\][A-Za-z0-9_.]*`)
+	regExpOperator    = regexp.MustCompile(`^(?:<>|<=|>=|[-+*/^&=<>%])`)
+	errorValues       = []Error{ErrorNull, ErrorDivide, ErrorValue, ErrorRef, ErrorName, ErrorNum, ErrorNA}
+)
+
+//isBoundary returns true if there is no identifier or function call right after position
+func isBoundary(s string, pos int) bool {
+	if pos >= len(s) {
+		return true
+	}
+
+	c := s[pos]
+	return !(c == '(' || c == '_' || c == '.' || c == '!' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'))
+}
+
+//matchRef tries to match reference at the beginning of formula
+func matchRef(s string) (t token, size int, ok bool) {
+	for _, re := range []*regexp.Regexp{regExpRefCell, regExpRefCols, regExpRefRows} {
+		if m := re.FindStringSubmatch(s); m != nil && isBoundary(s, len(m[0])) {
+			sheet := m[2]
+			if len(m[1]) > 0 {
+				sheet = strings.Replace(m[1], `''`, `'`, -1)
+			}
+
+			return token{kind: tokenRef, value: strings.ToUpper(m[3]), sheet: sheet}, len(m[0]), true
+		}
+	}
+
+	return
+}
+
+//tokenize splits formula into tokens
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+
+	for pos := 0; pos < len(s); {
+		rest := s[pos:]
+		c := rest[0]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			pos++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenOpen, value: "("})
+			pos++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenClose, value: ")"})
+			pos++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, value: ","})
+			pos++
+		case c == '"':
+			var value strings.Builder
+			closed := false
+
+			for pos++; pos < len(s); pos++ {
+				if s[pos] == '"' {
+					if pos+1 < len(s) && s[pos+1] == '"' {
+						value.WriteByte('"')
+						pos++
+						continue
+					}
+
+					closed = true
+					pos++
+					break
+				}
+
+				value.WriteByte(s[pos])
+			}
+
+			if !closed {
+				return nil, errors.New("unterminated string")
+			}
+
+			tokens = append(tokens, token{kind: tokenString, value: value.String()})
+		case c == '#':
+			matched := false
+			for _, e := range errorValues {
+				if strings.HasPrefix(strings.ToUpper(rest), string(e)) {
+					tokens = append(tokens, token{kind: tokenError, value: string(e)})
+					pos += len(e)
+					matched = true
+					break
+				}
+			}
+
+			if !matched {
+				return nil, errors.New(fmt.Sprintf("unknown error value at %d", pos))
+			}
+		default:
+			if t, size, ok := matchRef(rest); ok {
+				tokens = append(tokens, t)
+				pos += size
+			} else if m := regExpNumber.FindString(rest); len(m) > 0 {
+				tokens = append(tokens, token{kind: tokenNumber, value: m})
+				pos += len(m)
+			} else if m := regExpIdentifier.FindString(rest); len(m) > 0 {
+				pos += len(m)
+				name := strings.ToUpper(m)
+
+				if pos < len(s) && s[pos] == '(' {
+					tokens = append(tokens, token{kind: tokenFunction, value: strings.TrimPrefix(name, "_XLFN.")})
+				} else if name == "TRUE" || name == "FALSE" {
+					tokens = append(tokens, token{kind: tokenBool, value: name})
+				} else {
+					tokens = append(tokens, token{kind: tokenName, value: m})
+				}
+			} else if m := regExpOperator.FindString(rest); len(m) > 0 {
+				tokens = append(tokens, token{kind: tokenOperator, value: m})
+				pos += len(m)
+			} else {
+				return nil, errors.New(fmt.Sprintf("unexpected character %q at %d", c, pos))
+			}
+		}
+	}
+
+	return tokens, nil
+}
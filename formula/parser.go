@@ -0,0 +1,422 @@
+package formula
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/types"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type node interface {
+	eval(e *evaluation) interface{}
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+type refNode struct {
+	sheet                          string
+	fromCol, fromRow, toCol, toRow int
+}
+
+type nameNode struct {
+	name string
+}
+
+type unaryNode struct {
+	operand node
+}
+
+type percentNode struct {
+	operand node
+}
+
+type binaryNode struct {
+	operator    string
+	left, right node
+}
+
+type callNode struct {
+	name string
+	args []node
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+//comparison operators have the lowest precedence, then concatenation, additive and multiplicative
+var precedence = [][]string{
+	{"=", "<>", "<", "<=", ">", ">="},
+	{"&"},
+	{"+", "-"},
+	{"*", "/"},
+	{"^"},
+}
+
+func (p *parser) peek() *token {
+	if p.pos < len(p.tokens) {
+		return &p.tokens[p.pos]
+	}
+
+	return nil
+}
+
+func (p *parser) next() *token {
+	t := p.peek()
+	if t != nil {
+		p.pos++
+	}
+
+	return t
+}
+
+func (p *parser) isOperator(operators []string) (string, bool) {
+	if t := p.peek(); t != nil && t.kind == tokenOperator {
+		for _, op := range operators {
+			if t.value == op {
+				return op, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+//parse parses all tokens into the tree of nodes
+func (p *parser) parse() (node, error) {
+	if len(p.tokens) == 0 {
+		return nil, errors.New("empty formula")
+	}
+
+	n, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t != nil {
+		return nil, errors.New(fmt.Sprintf("unexpected %q", t.value))
+	}
+
+	return n, nil
+}
+
+//parseBinary parses binary operators with precedence at level or higher
+func (p *parser) parseBinary(level int) (node, error) {
+	if level >= len(precedence) {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op, ok := p.isOperator(precedence[level])
+		if !ok {
+			return left, nil
+		}
+
+		p.next()
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		left = &binaryNode{operator: op, left: left, right: right}
+	}
+}
+
+//parseUnary parses unary plus/minus and percent
+func (p *parser) parseUnary() (node, error) {
+	if op, ok := p.isOperator([]string{"-", "+"}); ok {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		if op == "+" {
+			return operand, nil
+		}
+
+		return &unaryNode{operand: operand}, nil
+	}
+
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, ok := p.isOperator([]string{"%"}); !ok {
+			return n, nil
+		}
+
+		p.next()
+		n = &percentNode{operand: n}
+	}
+}
+
+//parsePrimary parses literals, references, names, function calls and parentheses
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	if t == nil {
+		return nil, errors.New("unexpected end of formula")
+	}
+
+	switch t.kind {
+	case tokenNumber:
+		f, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		return &literalNode{value: f}, nil
+	case tokenString:
+		return &literalNode{value: t.value}, nil
+	case tokenBool:
+		return &literalNode{value: t.value == "TRUE"}, nil
+	case tokenError:
+		return &literalNode{value: Error(t.value)}, nil
+	case tokenRef:
+		return newRefNode(t.sheet, t.value), nil
+	case tokenName:
+		return &nameNode{name: t.value}, nil
+	case tokenOpen:
+		n, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+
+		if t := p.next(); t == nil || t.kind != tokenClose {
+			return nil, errors.New("missing closing parenthesis")
+		}
+
+		return n, nil
+	case tokenFunction:
+		return p.parseCall(t.value)
+	}
+
+	return nil, errors.New(fmt.Sprintf("unexpected %q", t.value))
+}
+
+//parseCall parses arguments of function call
+func (p *parser) parseCall(name string) (node, error) {
+	call := &callNode{name: name}
+
+	if t := p.next(); t == nil || t.kind != tokenOpen {
+		return nil, errors.New(fmt.Sprintf("missing arguments of %s", name))
+	}
+
+	if t := p.peek(); t != nil && t.kind == tokenClose {
+		p.next()
+		return call, nil
+	}
+
+	for {
+		//omitted arguments are allowed, e.g.: IF(A1,,1)
+		if t := p.peek(); t != nil && (t.kind == tokenComma || t.kind == tokenClose) {
+			call.args = append(call.args, &literalNode{})
+		} else {
+			arg, err := p.parseBinary(0)
+			if err != nil {
+				return nil, err
+			}
+
+			call.args = append(call.args, arg)
+		}
+
+		t := p.next()
+		if t == nil {
+			return nil, errors.New(fmt.Sprintf("missing closing parenthesis of %s", name))
+		}
+
+		if t.kind == tokenClose {
+			return call, nil
+		}
+
+		if t.kind != tokenComma {
+			return nil, errors.New(fmt.Sprintf("unexpected %q in arguments of %s", t.value, name))
+		}
+	}
+}
+
+//newRefNode creates node for reference, -1 is used for unbounded parts of whole columns or rows
+func newRefNode(sheet string, ref string) *refNode {
+	parts := strings.Split(strings.Replace(ref, "$", "", -1), ":")
+	if len(parts) == 1 {
+		parts = append(parts, parts[0])
+	}
+
+	n := &refNode{sheet: sheet}
+	n.fromCol, n.fromRow = types.CellRef(parts[0]).ToIndexes()
+	n.toCol, n.toRow = types.CellRef(parts[1]).ToIndexes()
+
+	if n.fromCol > n.toCol {
+		n.fromCol, n.toCol = n.toCol, n.fromCol
+	}
+
+	if n.fromRow > n.toRow {
+		n.fromRow, n.toRow = n.toRow, n.fromRow
+	}
+
+	return n
+}
+
+func (n *literalNode) eval(e *evaluation) interface{} {
+	return n.value
+}
+
+func (n *refNode) eval(e *evaluation) interface{} {
+	fromCol, fromRow, toCol, toRow := n.fromCol, n.fromRow, n.toCol, n.toRow
+
+	//whole columns or rows are limited with dimension of sheet
+	if fromCol < 0 || fromRow < 0 {
+		cols, rows := e.ctx.Dimension(n.sheet)
+		if fromCol < 0 {
+			fromCol, toCol = 0, cols-1
+		}
+
+		if fromRow < 0 {
+			fromRow, toRow = 0, rows-1
+		}
+	}
+
+	//N.B.: reference is always a range, even for single cell, so functions can ignore non-numeric values of references
+	r := make(Range, 0, toRow-fromRow+1)
+	for rIdx := fromRow; rIdx <= toRow; rIdx++ {
+		row := make([]interface{}, 0, toCol-fromCol+1)
+		for cIdx := fromCol; cIdx <= toCol; cIdx++ {
+			row = append(row, e.ctx.Value(n.sheet, cIdx, rIdx))
+		}
+
+		r = append(r, row)
+	}
+
+	return r
+}
+
+func (n *nameNode) eval(e *evaluation) interface{} {
+	formula, ok := e.ctx.Name(n.name)
+	if !ok {
+		return ErrorName
+	}
+
+	if e.depth >= maxDepth {
+		return ErrorRef
+	}
+
+	body, err := e.engine.parse(formula)
+	if err != nil {
+		return ErrorName
+	}
+
+	e.depth++
+	defer func() { e.depth-- }()
+	return body.eval(e)
+}
+
+func (n *unaryNode) eval(e *evaluation) interface{} {
+	f, err := toNumber(scalar(n.operand.eval(e)))
+	if err != "" {
+		return err
+	}
+
+	return -f
+}
+
+func (n *percentNode) eval(e *evaluation) interface{} {
+	f, err := toNumber(scalar(n.operand.eval(e)))
+	if err != "" {
+		return err
+	}
+
+	return f / 100
+}
+
+func (n *binaryNode) eval(e *evaluation) interface{} {
+	left, right := scalar(n.left.eval(e)), scalar(n.right.eval(e))
+	if err, ok := left.(Error); ok {
+		return err
+	}
+
+	if err, ok := right.(Error); ok {
+		return err
+	}
+
+	switch n.operator {
+	case "&":
+		return toText(left) + toText(right)
+	case "=":
+		return compare(left, right) == 0
+	case "<>":
+		return compare(left, right) != 0
+	case "<":
+		return compare(left, right) < 0
+	case "<=":
+		return compare(left, right) <= 0
+	case ">":
+		return compare(left, right) > 0
+	case ">=":
+		return compare(left, right) >= 0
+	}
+
+	a, err := toNumber(left)
+	if err != "" {
+		return err
+	}
+
+	b, err := toNumber(right)
+	if err != "" {
+		return err
+	}
+
+	switch n.operator {
+	case "+":
+		return a + b
+	case "-":
+		return a - b
+	case "*":
+		return a * b
+	case "/":
+		if b == 0 {
+			return ErrorDivide
+		}
+
+		return a / b
+	case "^":
+		return power(a, b)
+	}
+
+	return ErrorValue
+}
+
+//power returns a raised to power b or #NUM! if result is not a finite number
+func power(a, b float64) interface{} {
+	result := math.Pow(a, b)
+	if math.IsNaN(result) || math.IsInf(result, 0) {
+		return ErrorNum
+	}
+
+	return result
+}
+
+func (n *callNode) eval(e *evaluation) interface{} {
+	f := e.engine.function(n.name)
+	if f == nil {
+		return ErrorName
+	}
+
+	args := make([]interface{}, 0, len(n.args))
+	for _, arg := range n.args {
+		args = append(args, arg.eval(e))
+	}
+
+	return f(args...)
+}
@@ -0,0 +1,38 @@
+package formula
+
+import (
+	"github.com/plandem/xlsx/types"
+	"strings"
+)
+
+//SheetPrefix returns prefix with name of sheet for references, name is quoted if required, e.g.: SheetPrefix("My Sheet") => "'My Sheet'!"
+func SheetPrefix(sheet string) string {
+	return types.SheetPrefix(sheet)
+}
+
+//RenameSheet updates references to sheet with name from after renaming it to name to, e.g.: RenameSheet("SUM(Sheet1!A1:A10)", "Sheet1", "My Data") => "SUM('My Data'!A1:A10)". References without sheet are not affected, 3-D references are updated if sheet is the first or the last sheet of reference
+func RenameSheet(formula string, from string, to string) string {
+	if from == to {
+		return formula
+	}
+
+	return replaceRefTokens(formula, func(prefix, ref string) string {
+		r, err := types.ParseReference(prefix + ref)
+		if err != nil {
+			return prefix + ref
+		}
+
+		renamed := false
+		for _, name := range []*string{&r.Sheet, &r.ToSheet} {
+			if len(*name) > 0 && strings.EqualFold(*name, from) {
+				*name, renamed = to, true
+			}
+		}
+
+		if !renamed {
+			return prefix + ref
+		}
+
+		return types.SheetRangePrefix(r.Sheet, r.ToSheet) + ref
+	})
+}
@@ -0,0 +1,42 @@
+package formula
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSheetPrefix(t *testing.T) {
+	for sheet, prefix := range map[string]string{
+		"Data":       "Data!",
+		"Q1_2020":    "Q1_2020!",
+		"My Sheet":   "'My Sheet'!",
+		"John's":     "'John''s'!",
+		"A1":         "'A1'!",
+		"R1C1":       "'R1C1'!",
+		"2020":       "'2020'!",
+		"Sales-2020": "'Sales-2020'!",
+	} {
+		require.Equal(t, prefix, SheetPrefix(sheet), sheet)
+	}
+}
+
+func TestRenameSheet(t *testing.T) {
+	for formula, result := range map[string]string{
+		"SUM(Sheet1!A1:A10)+A1":        "SUM('My Data'!A1:A10)+A1",
+		"'Sheet1'!$B$2*sheet1!C:C":     "'My Data'!$B$2*'My Data'!C:C",
+		"Sheet2!A1+'Sheet 1'!A1":       "Sheet2!A1+'Sheet 1'!A1",
+		`"Sheet1!A1"&Sheet1!A1`:        `"Sheet1!A1"&'My Data'!A1`,
+		"VLOOKUP(A1,Sheet1!1:2,2)":     "VLOOKUP(A1,'My Data'!1:2,2)",
+		"Sheet1.Total+Sheet1!TaxRate1": "Sheet1.Total+Sheet1!TaxRate1",
+		"SUM(Sheet1:Sheet3!A1)":        "SUM('My Data:Sheet3'!A1)",
+		"SUM(Sheet0:Sheet1!A1:B2)":     "SUM('Sheet0:My Data'!A1:B2)",
+		"SUM('Sheet1:Sheet 3'!A1)":     "SUM('My Data:Sheet 3'!A1)",
+		"SUM(Sheet2:Sheet3!A1)":        "SUM(Sheet2:Sheet3!A1)",
+	} {
+		require.Equal(t, result, RenameSheet(formula, "Sheet1", "My Data"), formula)
+	}
+
+	require.Equal(t, "Data!A1", RenameSheet("'Old Name'!A1", "Old Name", "Data"))
+	require.Equal(t, "Data:Sheet3!A1", RenameSheet("'Old Name:Sheet3'!A1", "Old Name", "Data"))
+	require.Equal(t, "Sheet1!A1", RenameSheet("Sheet1!A1", "Sheet1", "Sheet1"))
+}
@@ -0,0 +1,202 @@
+package formula
+
+import (
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/types"
+	"strings"
+)
+
+//Shift shifts relative references of formula by cols and rows, e.g.: Shift("A1+$B$1", 1, 1) => "B2+$B$1". References that are shifted out of sheet become #REF!
+func Shift(formula string, cols, rows int) string {
+	if cols == 0 && rows == 0 {
+		return formula
+	}
+
+	return replaceRefs(formula, func(ref *types.Reference) bool {
+		return shiftRef(ref, cols, rows)
+	})
+}
+
+//ShiftRows updates references to sheet after inserting (n > 0) or deleting (n < 0) of n rows at 0-based index at. References without sheet are references to the owner sheet of formula, e.g.: ShiftRows("SUM(A1:A10)", "Sheet1", "Sheet1", 4, 2) => "SUM(A1:A12)". References to deleted cells become #REF!
+func ShiftRows(formula string, owner string, sheet string, at, n int) string {
+	return shiftSheetRefs(formula, owner, sheet, at, n, false)
+}
+
+//ShiftCols updates references to sheet after inserting (n > 0) or deleting (n < 0) of n cols at 0-based index at. References without sheet are references to the owner sheet of formula, e.g.: ShiftCols("SUM(A1:C1)", "Sheet1", "Sheet1", 1, -1) => "SUM(A1:B1)". References to deleted cells become #REF!
+func ShiftCols(formula string, owner string, sheet string, at, n int) string {
+	return shiftSheetRefs(formula, owner, sheet, at, n, true)
+}
+
+//shiftSheetRefs updates references to sheet after inserting or deleting of rows or cols
+func shiftSheetRefs(formula string, owner string, sheet string, at, n int, cols bool) string {
+	if n == 0 {
+		return formula
+	}
+
+	return replaceRefs(formula, func(ref *types.Reference) bool {
+		refSheet := ref.Sheet
+		if len(refSheet) == 0 {
+			refSheet = owner
+		}
+
+		//3-D references are not affected, because rows or cols are inserted or deleted at one sheet only
+		if len(ref.ToSheet) > 0 || !strings.EqualFold(refSheet, sheet) {
+			return true
+		}
+
+		return shiftSheetRef(ref, at, n, cols)
+	})
+}
+
+//replaceRefs returns formula where each reference is updated by callback, prefix with sheet is kept as is. Reference becomes #REF! if callback returns false, unchanged references are kept as is
+func replaceRefs(formula string, update func(ref *types.Reference) bool) string {
+	return replaceRefTokens(formula, func(prefix, ref string) string {
+		r, err := types.ParseReference(prefix + ref)
+		if err != nil {
+			return prefix + ref
+		}
+
+		original := *r
+		if !update(r) {
+			return prefix + string(ErrorRef)
+		}
+
+		if *r == original {
+			return prefix + ref
+		}
+
+		r.Sheet, r.ToSheet = "", ""
+		return prefix + r.String()
+	})
+}
+
+//replaceRefTokens returns formula where each reference, including prefix with sheet, is replaced with result of callback
+func replaceRefTokens(formula string, replace func(prefix, ref string) string) string {
+	var result strings.Builder
+	for pos := 0; pos < len(formula); {
+		rest := formula[pos:]
+
+		//strings must be kept as is
+		if rest[0] == '"' {
+			end := 1
+			for end < len(rest) {
+				if rest[end] == '"' {
+					if end+1 < len(rest) && rest[end+1] == '"' {
+						end += 2
+						continue
+					}
+
+					end++
+					break
+				}
+
+				end++
+			}
+
+			result.WriteString(rest[:end])
+			pos += end
+			continue
+		}
+
+		//structured references and indexes of external workbooks must be kept as is, references to external workbooks as well
+		if rest[0] == '[' {
+			end := skipBrackets(rest)
+			if regExpExternal.MatchString(rest) && isExternalBoundary(formula, pos-1) {
+				if _, size, ok := matchRef(rest[end:]); ok {
+					end += size
+				}
+			}
+
+			result.WriteString(rest[:end])
+			pos += end
+			continue
+		}
+
+		if _, size, ok := matchRef(rest); ok {
+			text := rest[:size]
+			idx := strings.LastIndex(text, "!")
+			result.WriteString(replace(text[:idx+1], text[idx+1:]))
+			pos += size
+			continue
+		}
+
+		//names, functions and numbers can look like a part of reference, so skip them entirely
+		size := len(regExpIdentifier.FindString(rest))
+		if size == 0 {
+			size = len(regExpNumber.FindString(rest))
+		}
+
+		if size == 0 {
+			size = 1
+		}
+
+		result.WriteString(rest[:size])
+		pos += size
+	}
+
+	return result.String()
+}
+
+//skipBrackets returns size of bracketed part at the beginning of formula with respect to nested brackets and escaped characters, e.g.: [[#Headers],[Price'[USD']]]
+func skipBrackets(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\'':
+			i++
+		case '[':
+			depth++
+		case ']':
+			if depth--; depth == 0 {
+				return i + 1
+			}
+		}
+	}
+
+	return len(s)
+}
+
+//shiftRef shifts relative parts of reference, e.g.: A1, $A1:B$2, A:B or 1:2. Returns false if reference is shifted out of sheet
+func shiftRef(ref *types.Reference, cols, rows int) bool {
+	b := &ref.Bounds
+	if !ref.Rows {
+		if !ref.AbsFromCol {
+			b.FromCol += cols
+		}
+
+		if !ref.AbsToCol {
+			b.ToCol += cols
+		}
+	}
+
+	if !ref.Cols {
+		if !ref.AbsFromRow {
+			b.FromRow += rows
+		}
+
+		if !ref.AbsToRow {
+			b.ToRow += rows
+		}
+	}
+
+	return b.FromCol >= 0 && b.ToCol >= 0 && b.FromRow >= 0 && b.ToRow >= 0 && b.FromCol < internal.ExcelColumnLimit && b.ToCol < internal.ExcelColumnLimit && b.FromRow < internal.ExcelRowLimit && b.ToRow < internal.ExcelRowLimit
+}
+
+//shiftSheetRef updates rows or cols of reference after inserting or deleting, e.g.: A1, $A1:B$2, A:B or 1:2. Returns false if cells of reference were deleted or shifted out of sheet
+func shiftSheetRef(ref *types.Reference, at, n int, cols bool) bool {
+	//whole rows are not affected by cols and vice versa
+	if cols && ref.Rows || !cols && ref.Cols {
+		return true
+	}
+
+	b := &ref.Bounds
+	if cols {
+		from, to, ok := internal.ShiftIndexes(b.FromCol, b.ToCol, at, n)
+		b.FromCol, b.ToCol = from, to
+		return ok && to < internal.ExcelColumnLimit
+	}
+
+	from, to, ok := internal.ShiftIndexes(b.FromRow, b.ToRow, at, n)
+	b.FromRow, b.ToRow = from, to
+	return ok && to < internal.ExcelRowLimit
+}
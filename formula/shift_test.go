@@ -0,0 +1,72 @@
+package formula
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestShift(t *testing.T) {
+	for formula, result := range map[string]string{
+		"A1+B2":                    "B3+C4",
+		"$A$1+$A1+A$1":             "$A$1+$A3+B$1",
+		"SUM(A1:B2)*LOG10(C3)":     "SUM(B3:C4)*LOG10(D5)",
+		`"A1"&A1`:                  `"A1"&B3`,
+		`"say ""A1"""&A1`:          `"say ""A1"""&B3`,
+		"'My Sheet'!A1+Sheet2!$B1": "'My Sheet'!B3+Sheet2!$B3",
+		"SUM(A:A,1:1,$C:$C,$2:$2)": "SUM(B:B,3:3,$C:$C,$2:$2)",
+		"TaxRate*A1+1.5E+3":        "TaxRate*B3+1.5E+3",
+		"_xlfn.CONCAT(a1)":         "_xlfn.CONCAT(B3)",
+	} {
+		require.Equal(t, result, Shift(formula, 1, 2), formula)
+	}
+
+	require.Equal(t, "A1", Shift("A1", 0, 0))
+	require.Equal(t, "#REF!+$A$1", Shift("A1+$A$1", -1, 0))
+	require.Equal(t, "Sheet1!#REF!", Shift("Sheet1!A1", 0, -1))
+}
+
+func TestShiftRows(t *testing.T) {
+	for formula, result := range map[string]string{
+		"A1+A5+$B$7":               "A1+A7+$B$9",
+		"SUM(A1:A10)":              "SUM(A1:A12)",
+		"SUM(A5:B6)+SUM(A:A)":      "SUM(A7:B8)+SUM(A:A)",
+		"SUM(5:$6)+$4:4":           "SUM(7:$8)+$4:4",
+		"Data!A5+'Other Sheet'!A5": "Data!A7+'Other Sheet'!A5",
+		`"A5"&data!A5`:             `"A5"&data!A7`,
+	} {
+		require.Equal(t, result, ShiftRows(formula, "Data", "Data", 4, 2), formula)
+	}
+
+	//references of other sheet
+	require.Equal(t, "A5+Data!A7", ShiftRows("A5+Data!A5", "Other", "Data", 4, 2))
+
+	//delete
+	require.Equal(t, "A1+#REF!+A4", ShiftRows("A1+A5+A7", "Data", "Data", 2, -3))
+	require.Equal(t, "SUM(A1:A7)", ShiftRows("SUM(A1:A10)", "Data", "Data", 2, -3))
+	require.Equal(t, "SUM(A3:A4)", ShiftRows("SUM(A4:A7)", "Data", "Data", 2, -3))
+	require.Equal(t, "SUM(#REF!)", ShiftRows("SUM(A3:A5)", "Data", "Data", 2, -3))
+
+	//out of sheet
+	require.Equal(t, "#REF!", ShiftRows("A1048576", "Data", "Data", 0, 1))
+}
+
+func TestShiftCols(t *testing.T) {
+	require.Equal(t, "A1+D1+$E$1+SUM(A1:E1)", ShiftCols("A1+B1+$C$1+SUM(A1:C1)", "Data", "Data", 1, 2))
+	require.Equal(t, "SUM(D:E)+SUM(1:1)", ShiftCols("SUM(B:C)+SUM(1:1)", "Data", "Data", 1, 2))
+	require.Equal(t, "#REF!+B1+SUM(A1:B1)", ShiftCols("B1+C1+SUM(A1:C1)", "Data", "Data", 1, -1))
+	require.Equal(t, "B1", ShiftCols("B1", "Data", "Other", 1, -1))
+}
+
+func TestShiftReferences(t *testing.T) {
+	//3-D references are not affected by inserting or deleting at one sheet
+	require.Equal(t, "SUM(Data:Other!A5)+Data!A7", ShiftRows("SUM(Data:Other!A5)+Data!A5", "Data", "Data", 4, 2))
+	require.Equal(t, "SUM('Data:My Sheet'!B2)", Shift("SUM('Data:My Sheet'!A1)", 1, 1))
+
+	//structured references and references to external workbooks are kept as is
+	require.Equal(t, "Sales[[#This Row],[A1]]+B3", Shift("Sales[[#This Row],[A1]]+A1", 1, 2))
+	require.Equal(t, "[1]Data!A5+Data!A7", ShiftRows("[1]Data!A5+Data!A5", "Data", "Data", 4, 2))
+	require.Equal(t, "[1]Data!A5+'My Data'!A1", RenameSheet("[1]Data!A5+Data!A1", "Data", "My Data"))
+
+	//lower case references are normalized only if changed
+	require.Equal(t, "a1+B7", ShiftRows("a1+b5", "Data", "Data", 4, 2))
+}
@@ -0,0 +1,190 @@
+package formula
+
+import (
+	"strconv"
+	"strings"
+)
+
+//first returns value of the first cell of range
+func (r Range) first() interface{} {
+	if len(r) > 0 && len(r[0]) > 0 {
+		return r[0][0]
+	}
+
+	return nil
+}
+
+//scalar returns value of single cell for reference or #VALUE! if there are more cells
+func scalar(v interface{}) interface{} {
+	if r, ok := v.(Range); ok {
+		if len(r) == 1 && len(r[0]) == 1 {
+			return r[0][0]
+		}
+
+		return ErrorValue
+	}
+
+	return v
+}
+
+//toRange returns value as range
+func toRange(v interface{}) Range {
+	if r, ok := v.(Range); ok {
+		return r
+	}
+
+	return Range{{v}}
+}
+
+//toNumber converts value to number
+func toNumber(v interface{}) (float64, Error) {
+	switch v := v.(type) {
+	case nil:
+		return 0, ""
+	case float64:
+		return v, ""
+	case bool:
+		if v {
+			return 1, ""
+		}
+
+		return 0, ""
+	case string:
+		if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			return f, ""
+		}
+	case Error:
+		return 0, v
+	}
+
+	return 0, ErrorValue
+}
+
+//toBool converts value to boolean
+func toBool(v interface{}) (bool, Error) {
+	switch v := v.(type) {
+	case nil:
+		return false, ""
+	case float64:
+		return v != 0, ""
+	case bool:
+		return v, ""
+	case string:
+		switch strings.ToUpper(v) {
+		case "TRUE":
+			return true, ""
+		case "FALSE":
+			return false, ""
+		}
+	case Error:
+		return false, v
+	}
+
+	return false, ErrorValue
+}
+
+//toText converts value to string
+func toText(v interface{}) string {
+	switch v := v.(type) {
+	case float64:
+		return formatNumber(v)
+	case bool:
+		if v {
+			return "TRUE"
+		}
+
+		return "FALSE"
+	case string:
+		return v
+	case Error:
+		return string(v)
+	}
+
+	return ""
+}
+
+//formatNumber returns number as string with up to 15 significant digits, same as Excel does
+func formatNumber(f float64) string {
+	f, _ = strconv.ParseFloat(strconv.FormatFloat(f, 'g', 15, 64), 64)
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+//typeRank returns rank of value type that is used for comparison: numbers < strings < booleans
+func typeRank(v interface{}) int {
+	switch v.(type) {
+	case float64:
+		return 1
+	case string:
+		return 2
+	case bool:
+		return 3
+	}
+
+	return 0
+}
+
+//compare compares values and returns -1, 0 or 1. Strings are compared case-insensitive, empty values are equal to zero value of another type
+func compare(a, b interface{}) int {
+	if a == nil && b == nil {
+		return 0
+	}
+
+	if a == nil {
+		a = zeroOf(b)
+	}
+
+	if b == nil {
+		b = zeroOf(a)
+	}
+
+	ra, rb := typeRank(a), typeRank(b)
+	if ra != rb {
+		if ra < rb {
+			return -1
+		}
+
+		return 1
+	}
+
+	switch a := a.(type) {
+	case float64:
+		return compareFloat(a, b.(float64))
+	case string:
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b.(string)))
+	case bool:
+		if a == b.(bool) {
+			return 0
+		}
+
+		if !a {
+			return -1
+		}
+
+		return 1
+	}
+
+	return 0
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+//zeroOf returns zero value of the same type as value
+func zeroOf(v interface{}) interface{} {
+	switch v.(type) {
+	case string:
+		return ""
+	case bool:
+		return false
+	}
+
+	return float64(0)
+}
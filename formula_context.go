@@ -0,0 +1,140 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/formula"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/internal/number_format/convert"
+	"github.com/plandem/xlsx/types"
+	"strconv"
+	"strings"
+)
+
+//formulaContext implements formula.Context to provide access to cells of spreadsheet during evaluation of formulas
+type formulaContext struct {
+	sheet   *sheetInfo
+	visited map[*ml.Cell]bool
+}
+
+var _ formula.Context = (*formulaContext)(nil)
+
+//newFormulaContext creates context for evaluation of formulas at sheet
+func newFormulaContext(sheet *sheetInfo) *formulaContext {
+	return &formulaContext{sheet: sheet, visited: make(map[*ml.Cell]bool)}
+}
+
+//resolveSheet returns sheet with name or current sheet for empty name
+func (ctx *formulaContext) resolveSheet(name string) *sheetInfo {
+	if len(name) == 0 {
+		return ctx.sheet
+	}
+
+	return ctx.sheet.workbook.doc.sheetByName(name)
+}
+
+//Value returns value of cell with 0-based indexes at sheet with name
+func (ctx *formulaContext) Value(name string, cIdx, rIdx int) interface{} {
+	sheet := ctx.resolveSheet(name)
+	if sheet == nil {
+		return formula.ErrorRef
+	}
+
+	//N.B.: we don't use sheet.Cell() to prevent expanding sheet for references out of dimension
+	data := sheet.storedCell(cIdx, rIdx)
+	if data == nil {
+		return nil
+	}
+
+	c := &Cell{ml: data, sheet: sheet}
+	if c.HasFormula() {
+		if value, err := c.evaluate(&formulaContext{sheet: sheet, visited: ctx.visited}); err == nil {
+			return value
+		}
+	}
+
+	return c.typedValue()
+}
+
+//Dimension returns total number of columns and rows of sheet with name
+func (ctx *formulaContext) Dimension(name string) (int, int) {
+	if sheet := ctx.resolveSheet(name); sheet != nil {
+		return sheet.Dimension()
+	}
+
+	return 0, 0
+}
+
+//Name returns formula of sheet-level or workbook-level defined name
+func (ctx *formulaContext) Name(name string) (string, bool) {
+	definedNames := ctx.sheet.workbook.definedNames
+	for _, sheetIndex := range []int{ctx.sheet.index, -1} {
+		if idx := definedNames.index(name, sheetIndex); idx != -1 {
+			return definedNames.Get(name, sheetIndex), true
+		}
+	}
+
+	return "", false
+}
+
+//evaluate evaluates formula of cell with evaluator of spreadsheet
+func (c *Cell) evaluate(ctx *formulaContext) (interface{}, error) {
+	//circular references are not allowed
+	if ctx.visited[c.ml] {
+		return formula.ErrorRef, nil
+	}
+
+	ctx.visited[c.ml] = true
+	defer delete(ctx.visited, c.ml)
+
+	return c.sheet.workbook.doc.evaluator.Evaluate(c.Formula(), ctx)
+}
+
+//typedValue returns cached value of cell as a value for formula: nil, float64, string, bool or formula.Error
+func (c *Cell) typedValue() interface{} {
+	value := c.rawValue()
+
+	switch c.ml.Type {
+	case types.CellTypeBool:
+		return value == "1" || strings.EqualFold(value, "true")
+	case types.CellTypeError:
+		return formula.Error(value)
+	case types.CellTypeDate:
+		//dates are serial numbers for formulas
+		if t, err := convert.ToDate(value); err == nil {
+			return c.sheet.workbook.toSerial(t)
+		}
+
+		return value
+	case types.CellTypeSharedString, types.CellTypeInlineString, types.CellTypeFormula:
+		return value
+	}
+
+	if len(value) == 0 {
+		return nil
+	}
+
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+
+	return value
+}
+
+//fromFormulaValue returns result of formula as raw value of cell
+func fromFormulaValue(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		if v {
+			return "1"
+		}
+
+		return "0"
+	case string:
+		return v
+	case formula.Error:
+		return string(v)
+	}
+
+	return ""
+}
@@ -0,0 +1,103 @@
+package xlsx
+
+import (
+	"bytes"
+	"github.com/plandem/xlsx/formula"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestFormulaContext(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Data")
+	sheet.CellByRef("A1").SetValue("North")
+	sheet.CellByRef("B1").SetValue(100)
+	sheet.CellByRef("A2").SetValue("South")
+	sheet.CellByRef("B2").SetValue(200)
+	sheet.CellByRef("C1").SetBool(true)
+	sheet.CellByRef("D1").SetInlineString("inline")
+	sheet.CellByRef("E1").SetDate(time.Date(2018, 1, 2, 12, 0, 0, 0, time.UTC))
+	sheet.CellByRef("F1").SetFormula("B1+B2")
+	sheet.CellByRef("F1").ml.Value = "42"
+
+	other := xl.AddSheet("Other Data")
+	other.CellByRef("A1").SetValue(5)
+	other.CellByRef("B1").SetFormula("'Other Data'!A1*Data!B1")
+	require.Nil(t, xl.DefineName("Rate", "0.5"))
+	require.Nil(t, other.DefineName("Rate", "2"))
+
+	//without evaluator cached value is used
+	require.Equal(t, "B1+B2", sheet.CellByRef("F1").Formula())
+	require.Equal(t, "42", sheet.CellByRef("F1").Value())
+
+	xl.SetEvaluator(formula.New())
+	ctx := newFormulaContext(sheet.info())
+	require.Equal(t, "North", ctx.Value("", 0, 0))
+	require.Equal(t, 100.0, ctx.Value("", 1, 0))
+	require.Equal(t, true, ctx.Value("", 2, 0))
+	require.Equal(t, "inline", ctx.Value("", 3, 0))
+	require.Equal(t, 43102.5, ctx.Value("", 4, 0))
+	require.Equal(t, 300.0, ctx.Value("", 5, 0))
+	require.Equal(t, 5.0, ctx.Value("other data", 0, 0))
+	require.Equal(t, formula.ErrorRef, ctx.Value("Unknown", 0, 0))
+	require.Nil(t, ctx.Value("", 100, 100))
+
+	//references out of dimension must not expand sheet
+	cols, rows := ctx.Dimension("")
+	require.Equal(t, 6, cols)
+	require.Equal(t, 2, rows)
+
+	value, ok := ctx.Name("Rate")
+	require.Equal(t, true, ok)
+	require.Equal(t, "0.5", value)
+	value, _ = newFormulaContext(other.info()).Name("Rate")
+	require.Equal(t, "2", value)
+	_, ok = ctx.Name("Unknown")
+	require.Equal(t, false, ok)
+
+	//computed values
+	require.Equal(t, "300", sheet.CellByRef("F1").Value())
+	require.Equal(t, "500", other.CellByRef("B1").Value())
+
+	sheet.CellByRef("G1").SetFormula("=F1*Rate")
+	require.Equal(t, "F1*Rate", sheet.CellByRef("G1").Formula())
+	require.Equal(t, "150", sheet.CellByRef("G1").Value())
+
+	sheet.CellByRef("H1").SetFormula(`VLOOKUP("south",A1:B2,2,FALSE)&"!"`)
+	require.Equal(t, "200!", sheet.CellByRef("H1").Value())
+
+	sheet.CellByRef("I1").SetFormula("B1>B2")
+	require.Equal(t, "0", sheet.CellByRef("I1").Value())
+
+	//circular references
+	sheet.CellByRef("J1").SetFormula("J2+1")
+	sheet.CellByRef("J2").SetFormula("J1+1")
+	require.Equal(t, "#REF!", sheet.CellByRef("J1").Value())
+
+	//invalid formula returns cached value
+	sheet.CellByRef("K1").SetFormula("SUM(")
+	require.Equal(t, "", sheet.CellByRef("K1").Value())
+
+	//formula can be removed
+	sheet.CellByRef("G1").SetFormula("")
+	require.Equal(t, false, sheet.CellByRef("G1").HasFormula())
+	require.Equal(t, "", sheet.CellByRef("G1").Formula())
+}
+
+func TestFormulaContextSaved(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	xl.SetEvaluator(formula.New())
+	xl.AddSheet("S").CellByRef("C5").SetValue(7)
+	target := xl.AddSheet("T").CellByRef("A1")
+	target.SetFormula("S!C5*2")
+	require.Equal(t, "14", target.Value())
+
+	//grid of sheet is shrunk after saving
+	require.Nil(t, xl.SaveAs(&bytes.Buffer{}))
+	require.Equal(t, "14", xl.Sheet(1).CellByRef("A1").Value())
+	require.Equal(t, nil, newFormulaContext(xl.Sheet(0).info()).Value("", 0, 0))
+	require.Equal(t, 7.0, newFormulaContext(xl.Sheet(0).info()).Value("", 2, 4))
+}
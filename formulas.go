@@ -0,0 +1,186 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/formula"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/plandem/xlsx/types"
+	"strings"
+)
+
+type formulas struct {
+	sheet  *sheetInfo
+	shared map[int]*ml.Cell
+}
+
+//newFormulas creates an object that implements shared and array formulas functionality
+func newFormulas(sheet *sheetInfo) *formulas {
+	return &formulas{sheet: sheet}
+}
+
+//isSharedMaster returns true if cell is a master cell of shared formula with index si
+func isSharedMaster(c *ml.Cell, si int) bool {
+	return c != nil && c.Formula != nil && c.Formula.T == primitives.CellFormulaTypeShared && c.Formula.Si != nil && *c.Formula.Si == si && len(c.Formula.Content) > 0
+}
+
+//index rebuilds index of master cells for shared formulas
+func (f *formulas) index() {
+	f.shared = make(map[int]*ml.Cell)
+
+	for _, row := range f.sheet.ml.SheetData {
+		for _, c := range row.Cells {
+			if c != nil && c.Formula != nil && c.Formula.Si != nil && isSharedMaster(c, *c.Formula.Si) {
+				f.shared[*c.Formula.Si] = c
+			}
+		}
+	}
+}
+
+//master returns master cell of shared formula with index si or nil if there is no such formula
+func (f *formulas) master(si int) *ml.Cell {
+	if c, ok := f.shared[si]; ok && isSharedMaster(c, si) {
+		return c
+	}
+
+	f.index()
+	return f.shared[si]
+}
+
+//nextSharedIndex returns a next unique index for shared formula
+func (f *formulas) nextSharedIndex() int {
+	si := 0
+	for _, row := range f.sheet.ml.SheetData {
+		for _, c := range row.Cells {
+			if c != nil && c.Formula != nil && c.Formula.Si != nil && *c.Formula.Si >= si {
+				si = *c.Formula.Si + 1
+			}
+		}
+	}
+
+	return si
+}
+
+//resolve returns formula of cell, for cells of shared formula it is a formula of master cell with shifted references
+func (f *formulas) resolve(c *ml.Cell) string {
+	if c.Formula == nil {
+		return ""
+	}
+
+	if c.Formula.T != primitives.CellFormulaTypeShared || len(c.Formula.Content) > 0 || c.Formula.Si == nil {
+		return c.Formula.Content
+	}
+
+	master := f.master(*c.Formula.Si)
+	if master == nil {
+		return ""
+	}
+
+	mCol, mRow := master.Ref.ToIndexes()
+	cCol, cRow := c.Ref.ToIndexes()
+	return formula.Shift(master.Formula.Content, cCol-mCol, cRow-mRow)
+}
+
+//release must be called before removing formula of cell to keep other cells of shared formula consistent
+func (f *formulas) release(c *ml.Cell) {
+	if c.Formula == nil || c.Formula.Si == nil || !isSharedMaster(c, *c.Formula.Si) {
+		return
+	}
+
+	if f.shared == nil {
+		f.index()
+	}
+
+	//the first cell that uses formula becomes a new master with bounds of all other cells that use formula
+	si, bounds := *c.Formula.Si, c.Formula.Bounds
+	var master *ml.Cell
+	var used types.Bounds
+	for rIdx := bounds.FromRow; rIdx <= bounds.ToRow && rIdx < len(f.sheet.ml.SheetData); rIdx++ {
+		cells := f.sheet.ml.SheetData[rIdx].Cells
+		for cIdx := bounds.FromCol; cIdx <= bounds.ToCol && cIdx < len(cells); cIdx++ {
+			if child := cells[cIdx]; child != nil && child != c && child.Formula != nil && child.Formula.T == primitives.CellFormulaTypeShared && child.Formula.Si != nil && *child.Formula.Si == si {
+				if master == nil {
+					master, used = child, types.BoundsFromIndexes(cIdx, rIdx, cIdx, rIdx)
+					continue
+				}
+
+				if cIdx < used.FromCol {
+					used.FromCol = cIdx
+				}
+
+				if cIdx > used.ToCol {
+					used.ToCol = cIdx
+				}
+
+				used.ToRow = rIdx
+			}
+		}
+	}
+
+	if master == nil {
+		delete(f.shared, si)
+		return
+	}
+
+	master.Formula.Content = f.resolve(master)
+	master.Formula.Bounds = used
+	f.shared[si] = master
+}
+
+//setArray sets array formula for bounds
+func (f *formulas) setArray(expression string, bounds types.Bounds) {
+	r := newRange(f.sheet.sheet, bounds.FromCol, bounds.ToCol, bounds.FromRow, bounds.ToRow)
+	r.Walk(func(idx, cIdx, rIdx int, c *Cell) {
+		c.SetFormula("")
+
+		if idx == 0 {
+			c.SetFormula(expression)
+			if c.ml.Formula != nil {
+				c.ml.Formula.T = primitives.CellFormulaTypeArray
+				c.ml.Formula.Bounds = bounds
+			}
+		}
+	})
+}
+
+//setShared sets shared formula for bounds, formula is relative to the top left cell
+func (f *formulas) setShared(expression string, bounds types.Bounds) {
+	expression = strings.TrimPrefix(expression, "=")
+	si := f.nextSharedIndex()
+
+	r := newRange(f.sheet.sheet, bounds.FromCol, bounds.ToCol, bounds.FromRow, bounds.ToRow)
+	r.Walk(func(idx, cIdx, rIdx int, c *Cell) {
+		c.SetFormula("")
+
+		if len(expression) > 0 {
+			index := si
+			c.ml.Formula = &ml.CellFormula{T: primitives.CellFormulaTypeShared, Si: &index}
+
+			//only master cell holds formula and bounds
+			if idx == 0 {
+				c.ml.Formula.Content = expression
+				c.ml.Formula.Bounds = bounds
+			}
+		}
+	})
+}
+
+//unshare converts cells of shared formula with index si into cells with normal formulas
+func (f *formulas) unshare(si int) {
+	var cells []*ml.Cell
+	var expressions []string
+
+	for _, row := range f.sheet.ml.SheetData {
+		for _, c := range row.Cells {
+			if c != nil && c.Formula != nil && c.Formula.T == primitives.CellFormulaTypeShared && c.Formula.Si != nil && *c.Formula.Si == si {
+				cells = append(cells, c)
+				expressions = append(expressions, f.resolve(c))
+			}
+		}
+	}
+
+	for i, c := range cells {
+		c.Formula = &ml.CellFormula{Content: expressions[i]}
+	}
+
+	delete(f.shared, si)
+}
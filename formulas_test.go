@@ -0,0 +1,91 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/formula"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFormulas(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Formulas")
+	for i := 0; i < 3; i++ {
+		sheet.Cell(0, i).SetValue(i + 1)
+		sheet.Cell(1, i).SetValue(10)
+	}
+
+	//shared formula
+	sheet.Range("C1:C3").SetSharedFormula("=A1*$B$1")
+	master := sheet.CellByRef("C1").ml.Formula
+	require.Equal(t, primitives.CellFormulaTypeShared, master.T)
+	require.Equal(t, types.BoundsFromIndexes(2, 0, 2, 2), master.Bounds)
+	require.Equal(t, 0, *master.Si)
+	require.Equal(t, "", sheet.CellByRef("C2").ml.Formula.Content)
+	require.Equal(t, "A1*$B$1", sheet.CellByRef("C1").Formula())
+	require.Equal(t, "A2*$B$1", sheet.CellByRef("C2").Formula())
+	require.Equal(t, "A3*$B$1", sheet.CellByRef("C3").Formula())
+
+	//next shared formula has a new index
+	sheet.Range("D1:D2").SetSharedFormula("C1+1")
+	require.Equal(t, 1, *sheet.CellByRef("D2").ml.Formula.Si)
+	require.Equal(t, "C2+1", sheet.CellByRef("D2").Formula())
+
+	//array formula
+	sheet.Range("E1:E3").SetArrayFormula("A1:A3*B1:B3")
+	require.Equal(t, primitives.CellFormulaTypeArray, sheet.CellByRef("E1").ml.Formula.T)
+	require.Equal(t, types.BoundsFromIndexes(4, 0, 4, 2), sheet.CellByRef("E1").ml.Formula.Bounds)
+	require.Equal(t, "A1:A3*B1:B3", sheet.CellByRef("E1").Formula())
+	require.Equal(t, false, sheet.CellByRef("E2").HasFormula())
+
+	//evaluation of cells with shared formula
+	xl.SetEvaluator(formula.New())
+	require.Equal(t, "30", sheet.CellByRef("C3").Value())
+	require.Equal(t, "21", sheet.CellByRef("D2").Value())
+	xl.SetEvaluator(nil)
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	require.Equal(t, "A2*$B$1", sheet.CellByRef("C2").Formula())
+	require.Equal(t, "A3*$B$1", sheet.CellByRef("C3").Formula())
+	require.Equal(t, "A1:A3*B1:B3", sheet.CellByRef("E1").Formula())
+	require.Equal(t, types.BoundsFromIndexes(4, 0, 4, 2), sheet.CellByRef("E1").ml.Formula.Bounds)
+
+	//overwriting of master cell must keep formulas of other cells
+	sheet.CellByRef("C1").SetValue(1)
+	require.Equal(t, false, sheet.CellByRef("C1").HasFormula())
+	require.Equal(t, "A2*$B$1", sheet.CellByRef("C2").ml.Formula.Content)
+	require.Equal(t, types.BoundsFromIndexes(2, 1, 2, 2), sheet.CellByRef("C2").ml.Formula.Bounds)
+	require.Equal(t, "A3*$B$1", sheet.CellByRef("C3").Formula())
+
+	sheet.CellByRef("C2").Reset()
+	require.Equal(t, "A3*$B$1", sheet.CellByRef("C3").ml.Formula.Content)
+
+	//overwriting of shared formula
+	sheet.Range("C1:C3").SetSharedFormula("")
+	require.Equal(t, false, sheet.CellByRef("C3").HasFormula())
+
+	//bounds of a new master cell cover only remaining cells of shared formula
+	sheet.Range("F1:H3").SetSharedFormula("A1+1")
+	sheet.CellByRef("H2").Reset()
+	sheet.CellByRef("H3").Reset()
+	sheet.CellByRef("F1").Reset()
+	require.Equal(t, "B1+1", sheet.CellByRef("G1").ml.Formula.Content)
+	require.Equal(t, types.BoundsFromIndexes(5, 0, 7, 2), sheet.CellByRef("G1").ml.Formula.Bounds)
+
+	sheet.CellByRef("F2").Reset()
+	sheet.CellByRef("F3").Reset()
+	sheet.CellByRef("G1").Reset()
+	require.Equal(t, "C1+1", sheet.CellByRef("H1").ml.Formula.Content)
+	require.Equal(t, types.BoundsFromIndexes(6, 0, 7, 2), sheet.CellByRef("H1").ml.Formula.Bounds)
+	require.Equal(t, "B3+1", sheet.CellByRef("G3").Formula())
+}
@@ -0,0 +1,253 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"github.com/plandem/ooxml"
+	sharedML "github.com/plandem/ooxml/ml"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/page"
+	"image"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	_ "unsafe"
+)
+
+//go:linkname fromHeaderFooter github.com/plandem/xlsx/page.fromHeaderFooter
+func fromHeaderFooter(h *page.HeaderFooter) (*ml.HeaderFooter, map[string]io.Reader, map[string]bool)
+
+//go:linkname toHeaderFooter github.com/plandem/xlsx/page.toHeaderFooter
+func toHeaderFooter(hf *ml.HeaderFooter) *page.HeaderFooter
+
+//go:linkname usedImages github.com/plandem/xlsx/page.usedImages
+func usedImages(h *page.HeaderFooter) map[string]bool
+
+var regExpVmlShape = regexp.MustCompile(`(?s)<v:shape\s[^>]*\bid="([^"]+)".*?</v:shape>`)
+var regExpVmlShapeID = regexp.MustCompile(`o:spid="[^"]*"`)
+var regExpVmlImageRID = regexp.MustCompile(`o:relid="([^"]+)"`)
+
+//N.B.: shapes of comments use blocks of ids for each sheet, so images use blocks after it
+const headerFooterShapeBlock = 1024
+
+type headerFooter struct {
+	sheet         *sheetInfo
+	vml           ml.VmlDrawing
+	file          *ooxml.PackageFile
+	relationships *ooxml.Relationships
+	shapes        map[string]string
+	isLoaded      bool
+}
+
+//newHeaderFooter creates an object that implements headers and footers functionality
+func newHeaderFooter(sheet *sheetInfo) *headerFooter {
+	return &headerFooter{sheet: sheet, shapes: make(map[string]string)}
+}
+
+//loadIfRequired lookups for existing legacy drawing with images of headers and footers and loads it
+func (h *headerFooter) loadIfRequired() {
+	if h.isLoaded {
+		return
+	}
+
+	h.isLoaded = true
+
+	//only existing sheets can have existing images
+	if h.sheet.file.IsNew() || h.sheet.ml.LegacyDrawingHF == nil {
+		return
+	}
+
+	doc := h.sheet.workbook.doc
+	h.sheet.attachRelationshipsIfRequired()
+	fileName := h.sheet.relationships.GetTargetById(string(h.sheet.ml.LegacyDrawingHF.RID))
+	if zf, ok := doc.pkg.File(fileName).(*zip.File); ok {
+		h.file = ooxml.NewPackageFile(doc.pkg, zf, &h.vml, nil)
+		h.file.LoadIfRequired(h.afterLoadVml)
+	}
+}
+
+//afterLoadVml resolves shapes with images of existing legacy drawing
+func (h *headerFooter) afterLoadVml() {
+	for _, match := range regExpVmlShape.FindAllStringSubmatch(h.vml.InnerXML, -1) {
+		h.shapes[match[1]] = match[0]
+	}
+}
+
+//initIfRequired creates a new legacy drawing file for images if required
+func (h *headerFooter) initIfRequired() {
+	h.loadIfRequired()
+
+	if h.file == nil {
+		doc := h.sheet.workbook.doc
+		fileName := doc.uniqueFileName("xl/drawings/vmlDrawing%d.vml")
+		h.file = ooxml.NewPackageFile(doc.pkg, fileName, &h.vml, nil)
+		doc.pkg.ContentTypes().RegisterType("vml", internal.ContentTypeVmlDrawing)
+		h.sheet.attachRelationshipsIfRequired()
+		_, rid := h.sheet.relationships.AddFile(internal.RelationTypeVmlDrawing, fileName)
+		h.sheet.ml.LegacyDrawingHF = &ml.LegacyDrawing{RID: rid}
+	}
+
+	if h.relationships == nil {
+		doc := h.sheet.workbook.doc
+		fileName := fmt.Sprintf("xl/drawings/_rels/%s.rels", filepath.Base(h.file.FileName()))
+
+		if file := doc.pkg.File(fileName); file != nil {
+			h.relationships = ooxml.NewRelationships(file, doc.pkg)
+		} else {
+			h.relationships = ooxml.NewRelationships(fileName, doc.pkg)
+		}
+	}
+}
+
+//Set sets headers and footers of sheet
+func (h *headerFooter) Set(info *page.HeaderFooter) error {
+	if err := info.Validate(); err != nil {
+		return err
+	}
+
+	hf, images, washout := fromHeaderFooter(info)
+	if *hf == (ml.HeaderFooter{}) {
+		hf = nil
+	}
+
+	//images must be valid before any changes
+	type headerImage struct {
+		content []byte
+		format  string
+		config  image.Config
+		washout bool
+	}
+
+	decoded := make(map[string]*headerImage, len(images))
+	for id, reader := range images {
+		//removed images are not used by sections anymore
+		if reader == nil {
+			continue
+		}
+
+		content, format, config, err := readImage(reader)
+		if err != nil {
+			return err
+		}
+
+		decoded[id] = &headerImage{content: content, format: format, config: config, washout: washout[id]}
+	}
+
+	h.sheet.ml.HeaderFooter = hf
+
+	//images that are not used by sections anymore must be removed
+	used := usedImages(info)
+	h.loadIfRequired()
+
+	var stale []string
+	for id := range h.shapes {
+		if !used[id] {
+			stale = append(stale, id)
+		}
+	}
+
+	if len(stale) == 0 && len(decoded) == 0 {
+		return nil
+	}
+
+	h.initIfRequired()
+	for _, id := range stale {
+		h.removeShape(id)
+	}
+
+	for id, img := range decoded {
+		h.removeShape(id)
+		_, rid := h.relationships.AddFile(internal.RelationTypeImage, h.sheet.drawings.addMedia(img.content, img.format))
+
+		//washout of image is same as Excel uses for semi-transparent images, e.g. watermarks
+		effects := ""
+		if img.washout {
+			effects = ` gain="19661f" blacklevel="22938f"`
+		}
+
+		//N.B.: size of shape is in points, but size of image is in pixels
+		h.shapes[id] = fmt.Sprintf(`<v:shape id="%s" o:spid="" type="#_x0000_t75" style="position:absolute;margin-left:0;margin-top:0;width:%gpt;height:%gpt;z-index:1"><v:imagedata o:relid="%s" o:title="%s"%s/><o:lock v:ext="edit" rotation="t"/></v:shape>`, id, float64(img.config.Width)*0.75, float64(img.config.Height)*0.75, rid, id, effects)
+	}
+
+	h.update()
+	return nil
+}
+
+//Get returns headers and footers of sheet
+func (h *headerFooter) Get() *page.HeaderFooter {
+	return toHeaderFooter(h.sheet.ml.HeaderFooter)
+}
+
+//removeShape removes shape with id, relationship with image of it and image itself if it is not used anymore
+func (h *headerFooter) removeShape(id string) {
+	if shape, ok := h.shapes[id]; ok {
+		if match := regExpVmlImageRID.FindStringSubmatch(shape); match != nil {
+			rid := sharedML.RID(match[1])
+			fileName := h.relationships.GetTargetById(string(rid))
+			h.relationships.Remove(rid)
+			if len(fileName) > 0 {
+				h.sheet.workbook.doc.removeMediaIfUnused(fileName)
+			}
+		}
+
+		delete(h.shapes, id)
+	}
+}
+
+//remove removes legacy drawing for images with relationships of it
+func (h *headerFooter) remove() {
+	doc := h.sheet.workbook.doc
+	fileName := h.file.FileName()
+	if h.sheet.ml.LegacyDrawingHF != nil {
+		h.sheet.attachRelationshipsIfRequired()
+		h.sheet.relationships.Remove(h.sheet.ml.LegacyDrawingHF.RID)
+		h.sheet.ml.LegacyDrawingHF = nil
+	}
+
+	doc.pkg.Remove(fileName)
+	doc.pkg.Remove(fmt.Sprintf("xl/drawings/_rels/%s.rels", filepath.Base(fileName)))
+	h.vml = ml.VmlDrawing{}
+	h.file = nil
+	h.relationships = nil
+	h.sheet.file.MarkAsUpdated()
+}
+
+//update refreshes legacy drawing with shapes of images, legacy drawing is removed if there are no images anymore
+func (h *headerFooter) update() {
+	if len(h.shapes) == 0 {
+		h.remove()
+		return
+	}
+
+	ids := make([]string, 0, len(h.shapes))
+	for id := range h.shapes {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	vml := &bytes.Buffer{}
+	shapeIdx := headerFooterShapeBlock + h.sheet.index + 1
+
+	vml.WriteString(fmt.Sprintf(`<o:shapelayout v:ext="edit"><o:idmap v:ext="edit" data="%d"/></o:shapelayout>`, shapeIdx))
+	vml.WriteString(`<v:shapetype id="_x0000_t75" coordsize="21600,21600" o:spt="75" o:preferrelative="t" path="m@4@5l@4@11@9@11@9@5xe" filled="f" stroked="f"><v:stroke joinstyle="miter"/><v:formulas><v:f eqn="if lineDrawn pixelLineWidth 0"/><v:f eqn="sum @0 1 0"/><v:f eqn="sum 0 0 @1"/><v:f eqn="prod @2 1 2"/><v:f eqn="prod @3 21600 pixelWidth"/><v:f eqn="prod @3 21600 pixelHeight"/><v:f eqn="sum @0 0 1"/><v:f eqn="prod @6 1 2"/><v:f eqn="prod @7 21600 pixelWidth"/><v:f eqn="sum @8 21600 0"/><v:f eqn="prod @7 21600 pixelHeight"/><v:f eqn="sum @10 21600 0"/></v:formulas><v:path o:extrusionok="f" gradientshapeok="t" o:connecttype="rect"/><o:lock v:ext="edit" aspectratio="t"/></v:shapetype>`)
+
+	for i, id := range ids {
+		//shape ids must be unique across sheet, so refresh it
+		shape := regExpVmlShapeID.ReplaceAllString(h.shapes[id], fmt.Sprintf(`o:spid="_x0000_s%d"`, shapeIdx*1024+i+1))
+		h.shapes[id] = shape
+		vml.WriteString(shape)
+	}
+
+	h.vml = ml.VmlDrawing{
+		XmlnsV:   "urn:schemas-microsoft-com:vml",
+		XmlnsO:   "urn:schemas-microsoft-com:office:office",
+		XmlnsX:   "urn:schemas-microsoft-com:office:excel",
+		InnerXML: vml.String(),
+	}
+
+	h.file.MarkAsUpdated()
+}
@@ -0,0 +1,103 @@
+package xlsx
+
+import (
+	"bytes"
+	"github.com/plandem/xlsx/page"
+	"github.com/stretchr/testify/require"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestHeaderFooter(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 100, 40))
+	buf := &bytes.Buffer{}
+	require.Nil(t, png.Encode(buf, img))
+
+	xl := New()
+	sheet := xl.AddSheet("Invoice")
+
+	//invalid settings must not be applied
+	require.NotNil(t, sheet.SetHeaderFooter(page.Header(strings.Repeat("a", 256), "", "")))
+	require.NotNil(t, sheet.SetHeaderFooter(page.Image(page.PartHeader, page.SectionLeft, strings.NewReader("not an image"))))
+	require.Nil(t, sheet.info().ml.LegacyDrawingHF)
+
+	require.Nil(t, sheet.SetHeaderFooter(
+		page.Header("", "Invoice", ""),
+		page.Footer("", "Page "+page.FieldPageNumber+" of "+page.FieldPagesCount, ""),
+		page.Image(page.PartHeader, page.SectionLeft, bytes.NewReader(buf.Bytes())),
+	))
+
+	require.Equal(t, "&L&G&CInvoice", sheet.info().ml.HeaderFooter.OddHeader)
+	require.NotNil(t, sheet.info().ml.LegacyDrawingHF)
+
+	headerFooter := sheet.info().headerFooter
+	require.Equal(t, 1, len(headerFooter.shapes))
+	require.Equal(t, 1, headerFooter.relationships.Total())
+	require.True(t, strings.Contains(headerFooter.vml.InnerXML, `<v:shape id="LH" o:spid="_x0000_s1049601" type="#_x0000_t75" style="position:absolute;margin-left:0;margin-top:0;width:75pt;height:30pt;z-index:1">`))
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	info := sheet.HeaderFooter()
+	left, center, right := info.Text(page.PartFooter)
+	require.Equal(t, []string{"", "Page &P of &N", ""}, []string{left, center, right})
+
+	//existing images must be kept
+	require.Nil(t, sheet.SetHeaderFooter(page.Image(page.PartFooter, page.SectionRight, bytes.NewReader(buf.Bytes()))))
+	headerFooter = sheet.info().headerFooter
+	require.Equal(t, 2, len(headerFooter.shapes))
+	require.Equal(t, 2, headerFooter.relationships.Total())
+	require.Equal(t, "&L&G&CInvoice", sheet.info().ml.HeaderFooter.OddHeader)
+	require.Equal(t, "&CPage &P of &N&R&G", sheet.info().ml.HeaderFooter.OddFooter)
+
+	//remove image
+	require.Nil(t, sheet.SetHeaderFooter(page.Image(page.PartHeader, page.SectionLeft, nil)))
+	require.Equal(t, 1, len(headerFooter.shapes))
+	require.Equal(t, 1, headerFooter.relationships.Total())
+	require.Equal(t, "&CInvoice", sheet.info().ml.HeaderFooter.OddHeader)
+
+	//watermark is a semi-transparent image of header
+	require.Nil(t, sheet.SetHeaderFooter(page.Watermark(bytes.NewReader(buf.Bytes()))))
+	require.Equal(t, "&CInvoice&G", sheet.info().ml.HeaderFooter.OddHeader)
+	require.Equal(t, 2, len(headerFooter.shapes))
+	require.True(t, strings.Contains(headerFooter.shapes["CH"], `gain="19661f" blacklevel="22938f"`))
+	require.False(t, strings.Contains(headerFooter.shapes["RF"], `gain=`))
+
+	//replaced and removed images are not kept
+	require.Nil(t, sheet.SetHeaderFooter(page.Watermark(bytes.NewReader(buf.Bytes()))))
+	require.Equal(t, 2, len(headerFooter.shapes))
+	require.Equal(t, 2, headerFooter.relationships.Total())
+	require.Equal(t, 2, len(mediaFiles(xl)))
+
+	require.Nil(t, sheet.SetHeaderFooter(page.Footer("", "Page "+page.FieldPageNumber, "")))
+	require.Equal(t, 1, len(headerFooter.shapes))
+	require.Equal(t, 1, len(mediaFiles(xl)))
+
+	vmlFileName := headerFooter.file.FileName()
+	require.Nil(t, sheet.SetHeaderFooter(page.Watermark(nil)))
+	require.Equal(t, 0, len(mediaFiles(xl)))
+	require.Nil(t, sheet.info().ml.LegacyDrawingHF)
+	require.Nil(t, xl.pkg.File(vmlFileName))
+	require.Equal(t, "&CInvoice", sheet.info().ml.HeaderFooter.OddHeader)
+}
+
+//mediaFiles returns names of media files of package
+func mediaFiles(xl *Spreadsheet) []string {
+	var files []string
+	for fileName := range xl.pkg.Files() {
+		if strings.HasPrefix(fileName, "xl/media/") {
+			files = append(files, fileName)
+		}
+	}
+
+	return files
+}
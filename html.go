@@ -0,0 +1,328 @@
+package xlsx
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/plandem/xlsx/internal/color"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/types"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//htmlBorderStyles is a list of CSS width and style for styles of border
+var htmlBorderStyles = map[string]string{
+	"hair":             "1px solid",
+	"thin":             "1px solid",
+	"dotted":           "1px dotted",
+	"dashed":           "1px dashed",
+	"dashDot":          "1px dashed",
+	"dashDotDot":       "1px dotted",
+	"medium":           "2px solid",
+	"mediumDashed":     "2px dashed",
+	"mediumDashDot":    "2px dashed",
+	"mediumDashDotDot": "2px dotted",
+	"slantDashDot":     "2px dashed",
+	"thick":            "3px solid",
+	"double":           "3px double",
+}
+
+//htmlWriter writes HTML lines with indentation
+type htmlWriter struct {
+	buf    bytes.Buffer
+	indent string
+}
+
+//line writes s at nested level
+func (w *htmlWriter) line(level int, s string) {
+	if len(w.indent) > 0 {
+		w.buf.WriteString(strings.Repeat(w.indent, level))
+	}
+
+	w.buf.WriteString(s)
+
+	if len(w.indent) > 0 {
+		w.buf.WriteByte('\n')
+	}
+}
+
+//htmlFont returns CSS for settings of font that differs from the default font
+func htmlFont(name string, size float64, rgb string, bold, italic, strike bool, underline string, def *ml.Font, palette []string) []string {
+	var css []string
+	if len(name) > 0 && (def == nil || name != string(def.Name)) {
+		css = append(css, fmt.Sprintf("font-family:'%s'", strings.Replace(name, "'", "", -1)))
+	}
+
+	if size > 0 && (def == nil || size != float64(def.Size)) {
+		css = append(css, fmt.Sprintf("font-size:%spt", strconv.FormatFloat(size, 'f', -1, 64)))
+	}
+
+	if len(rgb) > 0 && (def == nil || rgb != color.ToThemedRGB(def.Color, palette)) {
+		css = append(css, "color:"+rgb)
+	}
+
+	if bold {
+		css = append(css, "font-weight:bold")
+	}
+
+	if italic {
+		css = append(css, "font-style:italic")
+	}
+
+	var decorations []string
+	if len(underline) > 0 && underline != "none" {
+		decorations = append(decorations, "underline")
+	}
+
+	if strike {
+		decorations = append(decorations, "line-through")
+	}
+
+	if len(decorations) > 0 {
+		css = append(css, "text-decoration:"+strings.Join(decorations, " "))
+	}
+
+	return css
+}
+
+//htmlStyle returns inline CSS for style of cell
+func htmlStyle(c *Cell, def *ml.Font, palette []string) string {
+	font, fill, border, alignment := c.sheet.workbook.doc.styleSheet.resolveStyle(c.ml.Style)
+
+	var css []string
+	if font != nil {
+		css = append(css, htmlFont(string(font.Name), float64(font.Size), color.ToThemedRGB(font.Color, palette), bool(font.Bold), bool(font.Italic), bool(font.Strike), string(font.Underline), def, palette)...)
+	}
+
+	//N.B.: only pattern fills are supported, gradient fills are ignored
+	if rgb := fillColor(fill, palette); len(rgb) > 0 {
+		css = append(css, "background-color:"+rgb)
+	}
+
+	if border != nil {
+		for _, side := range []struct {
+			name    string
+			segment *ml.BorderSegment
+		}{{"top", border.Top}, {"right", border.Right}, {"bottom", border.Bottom}, {"left", border.Left}} {
+			if side.segment == nil {
+				continue
+			}
+
+			if style, ok := htmlBorderStyles[side.segment.Type.String()]; ok {
+				rgb := color.ToThemedRGB(side.segment.Color, palette)
+				if len(rgb) == 0 {
+					rgb = "#000000"
+				}
+
+				css = append(css, fmt.Sprintf("border-%s:%s %s", side.name, style, rgb))
+			}
+		}
+	}
+
+	var horizontal, vertical string
+	if alignment != nil {
+		horizontal, vertical = alignment.Horizontal.String(), alignment.Vertical.String()
+	}
+
+	switch horizontal {
+	case "left", "right", "center", "justify":
+		css = append(css, "text-align:"+horizontal)
+	case "centerContinuous", "distributed":
+		css = append(css, "text-align:center")
+	case "fill":
+		css = append(css, "text-align:left")
+	default:
+		//general alignment depends on type of value (Excel behavior)
+		switch {
+		case c.ml.Type == types.CellTypeBool || c.ml.Type == types.CellTypeError:
+			css = append(css, "text-align:center")
+		case c.isNumeric() || c.ml.Type == types.CellTypeDate:
+			css = append(css, "text-align:right")
+		}
+	}
+
+	switch vertical {
+	case "top":
+		css = append(css, "vertical-align:top")
+	case "center", "justify", "distributed":
+		css = append(css, "vertical-align:middle")
+	}
+
+	if alignment != nil {
+		if alignment.WrapText {
+			css = append(css, "white-space:pre-wrap")
+		}
+
+		if alignment.Indent > 0 {
+			css = append(css, fmt.Sprintf("padding-left:%dpx", alignment.Indent*9))
+		}
+	}
+
+	return strings.Join(css, ";")
+}
+
+//htmlText returns escaped text with line breaks
+func htmlText(text string) string {
+	return strings.Replace(html.EscapeString(text), "\n", "<br>", -1)
+}
+
+//htmlValue returns HTML content of cell. Rich texts are rendered as spans, other values are formatted according to number format
+func htmlValue(c *Cell, def *ml.Font, palette []string) string {
+	runs := c.RichText()
+	rich := false
+	for _, run := range runs {
+		if run.hasFont() {
+			rich = true
+			break
+		}
+	}
+
+	if !rich {
+		return htmlText(c.FormattedValue())
+	}
+
+	var buf strings.Builder
+	for _, run := range runs {
+		css := htmlFont(run.Font, run.Size, run.Color, run.Bold, run.Italic, run.Strike, string(run.Underline), def, palette)
+		if len(css) == 0 {
+			buf.WriteString(htmlText(run.Text))
+			continue
+		}
+
+		buf.WriteString(fmt.Sprintf(`<span style="%s">%s</span>`, html.EscapeString(strings.Join(css, ";")), htmlText(run.Text)))
+	}
+
+	return buf.String()
+}
+
+//ToHTML writes cells of sheet as HTML table with inline CSS of fonts, fills, borders and alignments of cells. Merged cells are rendered as spanned cells, values are always formatted according to number format. If options is nil, then default options are used
+func (s *sheetInfo) ToHTML(w io.Writer, o *options.ExportOptions) error {
+	if o == nil {
+		o = options.NewExportOptions()
+	}
+
+	bounds := o.Range
+	if bounds.IsEmpty() {
+		cols, rows := s.sheet.Dimension()
+		if cols == 0 || rows == 0 {
+			_, err := io.WriteString(w, "<table></table>\n")
+			return err
+		}
+
+		bounds = types.BoundsFromIndexes(0, 0, cols-1, rows-1)
+	}
+
+	//merged cells that overlap bounds are clipped, so the top left visible cell spans the rest
+	type htmlSpan struct{ cols, rows int }
+	spans := make(map[types.CellRef]htmlSpan)
+	covered := make(map[types.CellRef]bool)
+	for _, merged := range s.mergedCells.List() {
+		if !merged.Overlaps(bounds) {
+			continue
+		}
+
+		clipped := merged
+		if clipped.FromCol < bounds.FromCol {
+			clipped.FromCol = bounds.FromCol
+		}
+
+		if clipped.FromRow < bounds.FromRow {
+			clipped.FromRow = bounds.FromRow
+		}
+
+		if clipped.ToCol > bounds.ToCol {
+			clipped.ToCol = bounds.ToCol
+		}
+
+		if clipped.ToRow > bounds.ToRow {
+			clipped.ToRow = bounds.ToRow
+		}
+
+		for rIdx := clipped.FromRow; rIdx <= clipped.ToRow; rIdx++ {
+			for cIdx := clipped.FromCol; cIdx <= clipped.ToCol; cIdx++ {
+				covered[types.CellRefFromIndexes(cIdx, rIdx)] = true
+			}
+		}
+
+		ref := types.CellRefFromIndexes(clipped.FromCol, clipped.FromRow)
+		delete(covered, ref)
+		spans[ref] = htmlSpan{clipped.ToCol - clipped.FromCol + 1, clipped.ToRow - clipped.FromRow + 1}
+	}
+
+	palette := s.workbook.doc.ThemeColors()
+	def, _, _, _ := s.workbook.doc.styleSheet.resolveStyle(0)
+
+	//default font is set for whole table, so cells have only differences
+	tableCSS := []string{"border-collapse:collapse", "white-space:nowrap"}
+	if def != nil {
+		tableCSS = append(tableCSS, htmlFont(string(def.Name), float64(def.Size), color.ToThemedRGB(def.Color, palette), false, false, false, "", nil, palette)...)
+	}
+
+	hw := &htmlWriter{indent: o.Indent}
+	hw.line(0, fmt.Sprintf(`<table style="%s">`, html.EscapeString(strings.Join(tableCSS, ";"))))
+	hw.line(1, "<colgroup>")
+	for cIdx := bounds.FromCol; cIdx <= bounds.ToCol; cIdx++ {
+		hw.line(2, fmt.Sprintf(`<col style="width:%dpx">`, s.drawings.columnWidth(cIdx)))
+	}
+
+	hw.line(1, "</colgroup>")
+
+	//N.B.: vertical-align of cells is inherited from sections, bottom is default for Excel
+	section := "tbody"
+	for rIdx := bounds.FromRow; rIdx <= bounds.ToRow; rIdx++ {
+		tag := "td"
+		if rIdx == bounds.FromRow {
+			if o.HeaderRow {
+				section, tag = "thead", "th"
+			}
+
+			hw.line(1, fmt.Sprintf(`<%s style="vertical-align:bottom">`, section))
+		} else if o.HeaderRow && rIdx == bounds.FromRow+1 {
+			hw.line(1, "</thead>")
+			section = "tbody"
+			hw.line(1, `<tbody style="vertical-align:bottom">`)
+		}
+
+		hw.line(2, "<tr>")
+		for cIdx := bounds.FromCol; cIdx <= bounds.ToCol; cIdx++ {
+			ref := types.CellRefFromIndexes(cIdx, rIdx)
+			if covered[ref] {
+				continue
+			}
+
+			c := s.sheet.Cell(cIdx, rIdx)
+
+			var attrs string
+			if span, ok := spans[ref]; ok {
+				if span.cols > 1 {
+					attrs += fmt.Sprintf(` colspan="%d"`, span.cols)
+				}
+
+				if span.rows > 1 {
+					attrs += fmt.Sprintf(` rowspan="%d"`, span.rows)
+				}
+			}
+
+			if css := htmlStyle(c, def, palette); len(css) > 0 {
+				attrs += fmt.Sprintf(` style="%s"`, html.EscapeString(css))
+			}
+
+			hw.line(3, fmt.Sprintf("<%s%s>%s</%s>", tag, attrs, htmlValue(c, def, palette), tag))
+		}
+
+		hw.line(2, "</tr>")
+	}
+
+	hw.line(1, fmt.Sprintf("</%s>", section))
+	hw.line(0, "</table>")
+
+	if len(o.Indent) == 0 {
+		hw.buf.WriteByte('\n')
+	}
+
+	_, err := w.Write(hw.buf.Bytes())
+	return err
+}
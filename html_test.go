@@ -0,0 +1,53 @@
+package xlsx
+
+import (
+	"bytes"
+	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestToHTML(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Invoices")
+
+	sheet.CellByRef("A1").SetValue("Invoice")
+	sheet.CellByRef("B1").SetValue("Total")
+	sheet.CellByRef("A2").SetValue("<INV-1>")
+	sheet.CellByRef("B2").SetValueWithFormat(1234.5, "#,##0.00")
+	sheet.CellByRef("A3").SetValue("Summary")
+	sheet.CellByRef("C2").SetValue("line 1\nline 2")
+	require.Nil(t, sheet.Range("A3:C3").Merge())
+
+	sheet.CellByRef("A1").SetFormatting(xl.AddFormatting(format.NewStyles(
+		format.Font.Bold,
+		format.Font.Color("#FF0000"),
+		format.Fill.Type(format.PatternTypeSolid),
+		format.Fill.Color("#FFFF00"),
+		format.Border.Type(format.BorderStyleThin),
+		format.Border.Color("#0000FF"),
+		format.Alignment.HAlign(format.HAlignCenter),
+	)))
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, sheet.ToHTML(buf, options.NewExportOptions(options.Export.HeaderRow(true))))
+
+	result := buf.String()
+	require.True(t, strings.HasPrefix(result, `<table style="border-collapse:collapse;white-space:nowrap;`))
+	require.True(t, strings.HasSuffix(result, "</tbody></table>\n"))
+	require.Contains(t, result, `<thead style="vertical-align:bottom"><tr><th style="color:#FF0000;font-weight:bold;background-color:#FFFF00;border-top:1px solid #0000FF;border-right:1px solid #0000FF;border-bottom:1px solid #0000FF;border-left:1px solid #0000FF;text-align:center">Invoice</th><th>Total</th><th></th></tr></thead>`)
+	require.Contains(t, result, `<td>&lt;INV-1&gt;</td><td style="text-align:right">1,234.50</td><td>line 1<br>line 2</td>`)
+	require.Contains(t, result, `<tr><td colspan="3">Summary</td></tr>`)
+
+	buf.Reset()
+	require.Nil(t, sheet.ToHTML(buf, options.NewExportOptions(
+		options.Export.Range(types.BoundsFromIndexes(1, 2, 2, 2)),
+		options.Export.Indent(" "),
+	)))
+	require.Contains(t, buf.String(), " <colgroup>\n  <col style=\"width:64px\">\n  <col style=\"width:64px\">\n </colgroup>\n <tbody style=\"vertical-align:bottom\">\n  <tr>\n   <td colspan=\"2\">Summary</td>\n  </tr>\n </tbody>\n</table>\n")
+}
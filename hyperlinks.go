@@ -3,10 +3,12 @@ package xlsx
 import (
 	"errors"
 	"fmt"
+	sharedML "github.com/plandem/ooxml/ml"
 	"github.com/plandem/xlsx/format"
 	"github.com/plandem/xlsx/internal"
 	"github.com/plandem/xlsx/internal/ml"
 	"github.com/plandem/xlsx/types"
+	"sort"
 	_ "unsafe"
 )
 
@@ -19,15 +21,68 @@ func toHyperlinkInfo(hyperlink *ml.Hyperlink, targetInfo string, styleID format.
 type hyperlinks struct {
 	sheet          *sheetInfo
 	defaultStyleID format.DirectStyleID
+	released       map[sharedML.RID]bool
 }
 
 //newHyperlinks creates an object that implements hyperlinks functionality
 func newHyperlinks(sheet *sheetInfo) *hyperlinks {
-	return &hyperlinks{sheet: sheet, defaultStyleID: -1}
+	return &hyperlinks{sheet: sheet, defaultStyleID: -1, released: make(map[sharedML.RID]bool)}
 }
 
-//Add adds a new hyperlink info for provided bounds, where link can be string or HyperlinkInfo
-func (h *hyperlinks) Add(bounds types.Bounds, link interface{}) (format.DirectStyleID, error) {
+//release marks relationship of removed or replaced hyperlink as a candidate for removing during saving
+func (h *hyperlinks) release(link *ml.Hyperlink) {
+	if link != nil && len(link.RID) > 0 {
+		h.released[link.RID] = true
+	}
+}
+
+//pack removes relationships of released hyperlinks that are not used by any other hyperlink anymore
+func (h *hyperlinks) pack() {
+	if len(h.released) == 0 {
+		return
+	}
+
+	for _, link := range h.sheet.ml.Hyperlinks.Items {
+		delete(h.released, link.RID)
+	}
+
+	if len(h.released) > 0 {
+		h.sheet.attachRelationshipsIfRequired()
+		for rid := range h.released {
+			h.sheet.relationships.Remove(rid)
+		}
+
+		h.sheet.file.MarkAsUpdated()
+	}
+
+	h.released = make(map[sharedML.RID]bool)
+}
+
+//hyperlinkFont returns settings of font for default style of hyperlink, that are layered on top of existing style of cell
+func hyperlinkFont() *format.StyleFormat {
+	return format.NewStyles(
+		format.Font.Underline(format.UnderlineTypeSingle),
+		format.Font.Color("#0563C1"),
+	)
+}
+
+//applyStyle sets style of hyperlink for cell. Default style of hyperlink does not replace existing style of cell, but only adds settings of font
+func (h *hyperlinks) applyStyle(c *Cell, styleID format.DirectStyleID) {
+	if styleID == h.defaultStyleID && c.ml.Style != format.DefaultDirectStyle && c.ml.Style != styleID {
+		styleID = h.sheet.workbook.doc.styleSheet.mergeStyle(c.ml.Style, hyperlinkFont())
+	}
+
+	c.SetFormatting(styleID)
+}
+
+//SheetHyperlink is a hyperlink of sheet for bounds of cells
+type SheetHyperlink struct {
+	Bounds types.Bounds
+	Link   *types.HyperlinkInfo
+}
+
+//resolve returns markup and style for hyperlink, where link can be string or HyperlinkInfo. Relationship for external target is added if required
+func (h *hyperlinks) resolve(link interface{}) (*ml.Hyperlink, format.DirectStyleID, error) {
 	//check if hyperlink has style and if not, then add default
 	if h.defaultStyleID == -1 {
 		//we need to add default named style for hyperlink
@@ -45,50 +100,66 @@ func (h *hyperlinks) Add(bounds types.Bounds, link interface{}) (format.DirectSt
 	var object *types.HyperlinkInfo
 	if target, ok := link.(string); ok {
 		object = types.NewHyperlink(types.Hyperlink.ToTarget(target))
-	} else if pointer, ok := link.(*types.HyperlinkInfo); ok {
+	} else if pointer, ok := link.(*types.HyperlinkInfo); ok && pointer != nil {
 		object = pointer
 	} else if value, ok := link.(types.HyperlinkInfo); ok {
 		object = &value
 	} else {
-		return format.DefaultDirectStyle, errors.New("unsupported type of hyperlink, only string or types.HyperlinkInfo is allowed")
-	}
-
-	//let's check existing hyperlinks for overlapping bounds
-	hyperlinkIndex := -1
-	for linkIndex, link := range h.sheet.ml.Hyperlinks.Items {
-		if link.Bounds.Equals(bounds) {
-			hyperlinkIndex = linkIndex
-		} else if link.Bounds.Overlaps(bounds) {
-			return format.DefaultDirectStyle, errors.New(fmt.Sprintf("intersection of different hyperlinks is not allowed, %s intersects with %s", link.Bounds, bounds))
-		}
+		return nil, format.DefaultDirectStyle, errors.New("unsupported type of hyperlink, only string or types.HyperlinkInfo is allowed")
 	}
 
 	//prepare hyperlink info
-	hyperlink, styleID, err := fromHyperlinkInfo(object)
+	info, styleID, err := fromHyperlinkInfo(object)
 	if err != nil {
-		return format.DefaultDirectStyle, err
+		return nil, format.DefaultDirectStyle, err
 	}
 
-	//exceeded Excel limit for total hyperlinks
-	if len(h.sheet.ml.Hyperlinks.Items) >= internal.ExcelHyperlinkLimit {
-		return format.DefaultDirectStyle, errors.New(fmt.Sprintf("exceeds Excel limit (%d) for total number of hyperlinks per worksheet", internal.ExcelHyperlinkLimit))
-	}
+	//N.B.: same info can be used for few hyperlinks, so markup must be copied
+	hyperlink := *info
 
 	//if link has external target, then add relation for it
 	if len(hyperlink.RID) > 0 {
 		h.sheet.attachRelationshipsIfRequired()
 
-		//lookup for already existing targets to get RID
-		rid := h.sheet.relationships.GetIdByTarget(string(hyperlink.RID))
-
 		//looks like target is new, let's create it and use
-		if rid = h.sheet.relationships.GetIdByTarget(string(hyperlink.RID)); len(rid) == 0 {
+		rid := h.sheet.relationships.GetIdByTarget(string(hyperlink.RID))
+		if len(rid) == 0 {
 			_, rid = h.sheet.relationships.AddLink(internal.RelationTypeHyperlink, string(hyperlink.RID))
 		}
 
 		hyperlink.RID = rid
 	}
 
+	//if there are custom styles, then use it otherwise use default hyperlink styles
+	if styleID == format.DefaultDirectStyle {
+		styleID = h.defaultStyleID
+	}
+
+	return &hyperlink, styleID, nil
+}
+
+//Add adds a new hyperlink info for provided bounds, where link can be string or HyperlinkInfo
+func (h *hyperlinks) Add(bounds types.Bounds, link interface{}) (format.DirectStyleID, error) {
+	//let's check existing hyperlinks for overlapping bounds
+	hyperlinkIndex := -1
+	for linkIndex, link := range h.sheet.ml.Hyperlinks.Items {
+		if link.Bounds.Equals(bounds) {
+			hyperlinkIndex = linkIndex
+		} else if link.Bounds.Overlaps(bounds) {
+			return format.DefaultDirectStyle, errors.New(fmt.Sprintf("intersection of different hyperlinks is not allowed, %s intersects with %s", link.Bounds, bounds))
+		}
+	}
+
+	//exceeded Excel limit for total hyperlinks
+	if hyperlinkIndex == -1 && len(h.sheet.ml.Hyperlinks.Items) >= internal.ExcelHyperlinkLimit {
+		return format.DefaultDirectStyle, errors.New(fmt.Sprintf("exceeds Excel limit (%d) for total number of hyperlinks per worksheet", internal.ExcelHyperlinkLimit))
+	}
+
+	hyperlink, styleID, err := h.resolve(link)
+	if err != nil {
+		return format.DefaultDirectStyle, err
+	}
+
 	//add source Ref info
 	hyperlink.Bounds = bounds
 	if hyperlinkIndex == -1 {
@@ -96,15 +167,107 @@ func (h *hyperlinks) Add(bounds types.Bounds, link interface{}) (format.DirectSt
 		h.sheet.ml.Hyperlinks.Items = append(h.sheet.ml.Hyperlinks.Items, hyperlink)
 	} else {
 		//update existing hyperlink
+		h.release(h.sheet.ml.Hyperlinks.Items[hyperlinkIndex])
 		h.sheet.ml.Hyperlinks.Items[hyperlinkIndex] = hyperlink
 	}
 
-	//if there are custom styles, then use it otherwise use default hyperlink styles
-	if styleID == format.DefaultDirectStyle {
-		styleID = h.defaultStyleID
+	return styleID, nil
+}
+
+//AddList adds hyperlinks for few bounds at once and returns styles for each of them. Hyperlinks with same bounds as existing ones replace them. Overlapping of bounds is validated once for all hyperlinks
+func (h *hyperlinks) AddList(list []SheetHyperlink) ([]format.DirectStyleID, error) {
+	items := make([]*ml.Hyperlink, len(h.sheet.ml.Hyperlinks.Items), len(h.sheet.ml.Hyperlinks.Items)+len(list))
+	copy(items, h.sheet.ml.Hyperlinks.Items)
+
+	index := make(map[types.Bounds]int, cap(items))
+	for i, link := range items {
+		index[link.Bounds] = i
 	}
 
-	return styleID, nil
+	positions := make([]int, len(list))
+	for i, item := range list {
+		if linkIndex, ok := index[item.Bounds]; ok {
+			positions[i] = linkIndex
+			continue
+		}
+
+		positions[i] = len(items)
+		index[item.Bounds] = len(items)
+		items = append(items, &ml.Hyperlink{Bounds: item.Bounds})
+	}
+
+	//exceeded Excel limit for total hyperlinks
+	if len(items) > internal.ExcelHyperlinkLimit {
+		return nil, errors.New(fmt.Sprintf("exceeds Excel limit (%d) for total number of hyperlinks per worksheet", internal.ExcelHyperlinkLimit))
+	}
+
+	//sweep hyperlinks sorted by rows and check only hyperlinks with rows that are still active
+	sorted := make([]*ml.Hyperlink, len(items))
+	copy(sorted, items)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Bounds.FromRow < sorted[j].Bounds.FromRow
+	})
+
+	var active []*ml.Hyperlink
+	for _, link := range sorted {
+		n := 0
+		for _, a := range active {
+			if a.Bounds.ToRow >= link.Bounds.FromRow {
+				if a.Bounds.Overlaps(link.Bounds) {
+					return nil, errors.New(fmt.Sprintf("intersection of different hyperlinks is not allowed, %s intersects with %s", a.Bounds, link.Bounds))
+				}
+
+				active[n] = a
+				n++
+			}
+		}
+
+		active = append(active[:n], link)
+	}
+
+	styles := make([]format.DirectStyleID, len(list))
+	for i, item := range list {
+		hyperlink, styleID, err := h.resolve(item.Link)
+		if err != nil {
+			return nil, err
+		}
+
+		hyperlink.Bounds = item.Bounds
+		h.release(items[positions[i]])
+		items[positions[i]] = hyperlink
+		styles[i] = styleID
+	}
+
+	h.sheet.ml.Hyperlinks.Items = items
+	return styles, nil
+}
+
+//List returns all hyperlinks of sheet with resolved targets
+func (h *hyperlinks) List() []SheetHyperlink {
+	if len(h.sheet.ml.Hyperlinks.Items) == 0 {
+		return nil
+	}
+
+	h.sheet.attachRelationshipsIfRequired()
+	list := make([]SheetHyperlink, 0, len(h.sheet.ml.Hyperlinks.Items))
+	for _, link := range h.sheet.ml.Hyperlinks.Items {
+		styleID := h.sheet.sheet.Cell(link.Bounds.FromCol, link.Bounds.FromRow).ml.Style
+		list = append(list, SheetHyperlink{
+			Bounds: link.Bounds,
+			Link:   toHyperlinkInfo(link, h.sheet.relationships.GetTargetById(string(link.RID)), styleID),
+		})
+	}
+
+	return list
+}
+
+//RemoveAll removes all hyperlinks of sheet
+func (h *hyperlinks) RemoveAll() {
+	for _, link := range h.sheet.ml.Hyperlinks.Items {
+		h.release(link)
+	}
+
+	h.sheet.ml.Hyperlinks.Items = nil
 }
 
 //Get returns a resolved hyperlink info for provided ref or nil if there is no any hyperlink
@@ -116,6 +279,7 @@ func (h *hyperlinks) Get(ref types.CellRef) *types.HyperlinkInfo {
 			if link.Bounds.Contains(cIdx, rIdx) {
 				cell := h.sheet.sheet.CellByRef(ref)
 				styleID := cell.ml.Style
+				h.sheet.attachRelationshipsIfRequired()
 				return toHyperlinkInfo(link, h.sheet.relationships.GetTargetById(string(link.RID)), styleID)
 			}
 		}
@@ -133,6 +297,8 @@ func (h *hyperlinks) Remove(bounds types.Bounds) {
 			if !link.Bounds.Overlaps(bounds) {
 				//copy only non overlapping bounds
 				newLinks = append(newLinks, link)
+			} else {
+				h.release(link)
 			}
 		}
 
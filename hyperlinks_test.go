@@ -1 +1,134 @@
 package xlsx
+
+import (
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestHyperlinks(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Links")
+	require.Nil(t, sheet.CellByRef("A1").SetHyperlink(types.NewHyperlink(
+		types.Hyperlink.ToUrl("https://github.com/plandem/xlsx"),
+		types.Hyperlink.Tooltip("Sources"),
+		types.Hyperlink.Display("xlsx"),
+	)))
+
+	require.Nil(t, sheet.CellByRef("A2").SetHyperlink(types.NewHyperlink(
+		types.Hyperlink.ToMail("spam@spam.it", "topic"),
+	)))
+
+	require.Nil(t, sheet.CellByRef("A3").SetHyperlink(types.NewHyperlink(
+		types.Hyperlink.ToLocation("'Sheet 2'!A1"),
+	)))
+
+	//round trip
+	content, err := xl.Bytes()
+	require.Nil(t, err)
+
+	xl2, err := OpenBytes(content)
+	require.Nil(t, err)
+	defer xl2.Close()
+
+	sheet = xl2.Sheet(0)
+	link := sheet.CellByRef("A1").Hyperlink()
+	require.Equal(t, true, link.IsURL())
+	require.Equal(t, "https://github.com/plandem/xlsx", link.Target())
+	require.Equal(t, "Sources", link.Tooltip())
+	require.Equal(t, "xlsx", link.Display())
+
+	link = sheet.CellByRef("A2").Hyperlink()
+	address, subject := link.Email()
+	require.Equal(t, true, link.IsEmail())
+	require.Equal(t, "spam@spam.it", address)
+	require.Equal(t, "topic", subject)
+
+	link = sheet.CellByRef("A3").Hyperlink()
+	require.Equal(t, true, link.IsInternal())
+	require.Equal(t, "", link.Target())
+	require.Equal(t, "'Sheet 2'!A1", link.Location())
+}
+
+func TestHyperlinks_bulk(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Links")
+	require.Nil(t, sheet.CellByRef("A1").SetHyperlink("https://github.com/plandem/xlsx"))
+
+	link := types.NewHyperlink(types.Hyperlink.ToUrl("https://github.com/plandem/ooxml"))
+	links := []SheetHyperlink{
+		{Bounds: types.BoundsFromIndexes(0, 0, 0, 0), Link: link},
+		{Bounds: types.BoundsFromIndexes(0, 1, 1, 1), Link: link},
+		{Bounds: types.BoundsFromIndexes(0, 2, 0, 2), Link: types.NewHyperlink(types.Hyperlink.ToLocation("Links!A1"))},
+	}
+
+	require.Nil(t, sheet.AddHyperlinks(links...))
+	require.Equal(t, "https://github.com/plandem/ooxml", sheet.CellByRef("A1").Hyperlink().String())
+	require.Equal(t, "https://github.com/plandem/ooxml", sheet.CellByRef("B2").Hyperlink().String())
+	require.Equal(t, sheet.CellByRef("A2").Formatting(), sheet.CellByRef("B2").Formatting())
+
+	list := sheet.Hyperlinks()
+	require.Equal(t, 3, len(list))
+	require.Equal(t, types.BoundsFromIndexes(0, 0, 0, 0), list[0].Bounds)
+	require.Equal(t, "https://github.com/plandem/ooxml", list[0].Link.Target())
+	require.Equal(t, types.BoundsFromIndexes(0, 2, 0, 2), list[2].Bounds)
+	require.Equal(t, "Links!A1", list[2].Link.Location())
+
+	//overlapping
+	require.NotNil(t, sheet.AddHyperlinks(SheetHyperlink{Bounds: types.BoundsFromIndexes(1, 0, 1, 3), Link: link}))
+	require.NotNil(t, sheet.AddHyperlinks(
+		SheetHyperlink{Bounds: types.BoundsFromIndexes(5, 0, 5, 3), Link: link},
+		SheetHyperlink{Bounds: types.BoundsFromIndexes(4, 3, 6, 3), Link: link},
+	))
+	require.Equal(t, 3, len(sheet.Hyperlinks()))
+
+	sheet.DeleteHyperlinks()
+	require.Nil(t, sheet.Hyperlinks())
+	require.Nil(t, sheet.CellByRef("A1").Hyperlink())
+}
+
+func TestHyperlinks_pack(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Links")
+	require.Nil(t, sheet.CellByRef("A1").SetHyperlink("https://github.com/plandem/xlsx"))
+	require.Nil(t, sheet.CellByRef("A2").SetHyperlink("https://github.com/plandem/xlsx"))
+	require.Nil(t, sheet.CellByRef("A3").SetHyperlink("https://github.com/plandem/ooxml"))
+	require.Nil(t, sheet.CellByRef("A4").SetHyperlink("https://github.com/plandem/docx"))
+
+	relationships := sheet.info().relationships
+	xlsxID := relationships.GetIdByTarget("https://github.com/plandem/xlsx")
+	require.NotEqual(t, "", string(xlsxID))
+
+	//relationships that are still used by other hyperlinks are kept
+	sheet.CellByRef("A1").RemoveHyperlink()
+	require.Nil(t, sheet.CellByRef("A3").SetHyperlink("https://github.com/plandem/xlsx"))
+	sheet.DeleteRow(3)
+	require.Nil(t, xl.beforeSave())
+
+	require.Equal(t, xlsxID, relationships.GetIdByTarget("https://github.com/plandem/xlsx"))
+	require.Equal(t, "", string(relationships.GetIdByTarget("https://github.com/plandem/ooxml")))
+	require.Equal(t, "", string(relationships.GetIdByTarget("https://github.com/plandem/docx")))
+	require.Equal(t, 0, len(sheet.info().hyperlinks.released))
+
+	//round trip
+	content, err := xl.Bytes()
+	require.Nil(t, err)
+
+	xl2, err := OpenBytes(content)
+	require.Nil(t, err)
+	defer xl2.Close()
+
+	sheet = xl2.Sheet(0)
+	require.Equal(t, "https://github.com/plandem/xlsx", sheet.CellByRef("A2").Hyperlink().Target())
+	require.Equal(t, "https://github.com/plandem/xlsx", sheet.CellByRef("A3").Hyperlink().Target())
+
+	sheet.DeleteHyperlinks()
+	require.Nil(t, xl2.beforeSave())
+	require.Equal(t, "", string(sheet.info().relationships.GetIdByTarget("https://github.com/plandem/xlsx")))
+}
@@ -0,0 +1,101 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/errtype"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+	_ "unsafe"
+)
+
+//go:linkname fromErrorType github.com/plandem/xlsx/errtype.fromType
+func fromErrorType(t errtype.Type) *ml.IgnoredError
+
+//go:linkname toErrorType github.com/plandem/xlsx/errtype.toType
+func toErrorType(e *ml.IgnoredError) errtype.Type
+
+//setIgnoredErrors sets items of ignored errors, ignored errors are removed if there are no any items
+func (s *sheetInfo) setIgnoredErrors(items []*ml.IgnoredError) {
+	s.ml.IgnoredErrors.Items = items
+	if len(items) == 0 && s.ml.IgnoredErrors.ExtLst == nil {
+		s.ml.IgnoredErrors = nil
+	}
+}
+
+//IgnoreErrors suppresses warnings (green triangles) of type t for cells of bounds, e.g.: IgnoreErrors(bounds, errtype.NumberStoredAsText|errtype.FormulaRange). Previously ignored types for same bounds are replaced and zero type stops ignoring of errors
+func (s *sheetInfo) IgnoreErrors(bounds types.Bounds, t errtype.Type) {
+	if s.ml.IgnoredErrors == nil {
+		if t == 0 {
+			return
+		}
+
+		s.ml.IgnoredErrors = &ml.IgnoredErrors{}
+	}
+
+	//remove same bounds from existing items
+	items := make([]*ml.IgnoredError, 0, len(s.ml.IgnoredErrors.Items)+1)
+	for _, ignored := range s.ml.IgnoredErrors.Items {
+		list := make(types.BoundsList, 0, len(ignored.Bounds))
+		for _, b := range ignored.Bounds {
+			if !b.Equals(bounds) {
+				list = append(list, b)
+			}
+		}
+
+		if ignored.Bounds = list; len(list) > 0 {
+			items = append(items, ignored)
+		}
+	}
+
+	if t != 0 {
+		//N.B.: bounds with same types share item
+		added := false
+		for _, ignored := range items {
+			if toErrorType(ignored) == t {
+				ignored.Bounds = append(ignored.Bounds, bounds)
+				added = true
+				break
+			}
+		}
+
+		if !added {
+			ignored := fromErrorType(t)
+			ignored.Bounds = types.BoundsList{bounds}
+			items = append(items, ignored)
+		}
+	}
+
+	s.setIgnoredErrors(items)
+}
+
+//IgnoredErrors returns types of errors that are ignored for cell with ref
+func (s *sheetInfo) IgnoredErrors(cellRef types.CellRef) errtype.Type {
+	var t errtype.Type
+	if s.ml.IgnoredErrors != nil {
+		cIdx, rIdx := cellRef.ToIndexes()
+		for _, ignored := range s.ml.IgnoredErrors.Items {
+			for _, b := range ignored.Bounds {
+				if b.Contains(cIdx, rIdx) {
+					t |= toErrorType(ignored)
+				}
+			}
+		}
+	}
+
+	return t
+}
+
+//shiftIgnoredErrors updates bounds of ignored errors after inserting (n > 0) or deleting (n < 0) of n rows or cols at 0-based index at
+func (s *sheetInfo) shiftIgnoredErrors(at, n int, cols bool) {
+	if s.ml.IgnoredErrors == nil {
+		return
+	}
+
+	items := make([]*ml.IgnoredError, 0, len(s.ml.IgnoredErrors.Items))
+	for _, ignored := range s.ml.IgnoredErrors.Items {
+		if ignored.Bounds = shiftBoundsList(ignored.Bounds, at, n, cols); len(ignored.Bounds) > 0 {
+			items = append(items, ignored)
+		}
+	}
+
+	s.setIgnoredErrors(items)
+}
@@ -0,0 +1,56 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/errtype"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIgnoredErrors(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Data")
+	sheet.CellByRef("A1").SetString("123")
+
+	sheet.IgnoreErrors(types.BoundsFromIndexes(0, 0, 0, 9), 0)
+	require.Nil(t, sheet.info().ml.IgnoredErrors)
+
+	sheet.IgnoreErrors(types.BoundsFromIndexes(0, 0, 0, 9), errtype.NumberStoredAsText)
+	sheet.IgnoreErrors(types.BoundsFromIndexes(2, 0, 2, 9), errtype.NumberStoredAsText)
+	sheet.IgnoreErrors(types.BoundsFromIndexes(1, 0, 1, 9), errtype.FormulaRange|errtype.Formula)
+	require.Equal(t, 2, len(sheet.info().ml.IgnoredErrors.Items))
+	require.Equal(t, "A1:A10 C1:C10", sheet.info().ml.IgnoredErrors.Items[0].Bounds.String())
+
+	require.Equal(t, errtype.NumberStoredAsText, sheet.IgnoredErrors("A5"))
+	require.Equal(t, errtype.FormulaRange|errtype.Formula, sheet.IgnoredErrors("B1"))
+	require.Equal(t, errtype.Type(0), sheet.IgnoredErrors("D1"))
+
+	//types for same bounds are replaced
+	sheet.IgnoreErrors(types.BoundsFromIndexes(2, 0, 2, 9), errtype.EvalError)
+	require.Equal(t, errtype.EvalError, sheet.IgnoredErrors("C1"))
+	require.Equal(t, 3, len(sheet.info().ml.IgnoredErrors.Items))
+
+	//bounds are moved with rows and cols
+	sheet.InsertCols(0, 1)
+	require.Equal(t, errtype.NumberStoredAsText, sheet.IgnoredErrors("B5"))
+	require.Equal(t, errtype.Type(0), sheet.IgnoredErrors("A5"))
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	require.Equal(t, errtype.NumberStoredAsText, sheet.IgnoredErrors("B5"))
+	require.Equal(t, errtype.FormulaRange|errtype.Formula, sheet.IgnoredErrors("C1"))
+	require.Equal(t, errtype.EvalError, sheet.IgnoredErrors("D1"))
+
+	sheet.IgnoreErrors(types.BoundsFromIndexes(1, 0, 1, 9), 0)
+	sheet.IgnoreErrors(types.BoundsFromIndexes(2, 0, 2, 9), 0)
+	sheet.IgnoreErrors(types.BoundsFromIndexes(3, 0, 3, 9), 0)
+	require.Nil(t, sheet.info().ml.IgnoredErrors)
+}
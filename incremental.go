@@ -0,0 +1,144 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/plandem/ooxml"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+//marshalPart returns content of updated part with XML header if required
+func marshalPart(content interface{}) ([]byte, error) {
+	if data, ok := content.([]byte); ok {
+		return data, nil
+	}
+
+	target := content
+	if prep, ok := content.(ooxml.MarshalPreparation); ok {
+		target = prep.BeforeMarshalXML()
+	}
+
+	data, err := xml.Marshal(target)
+	if err != nil {
+		return nil, err
+	}
+
+	if fix, ok := content.(ooxml.MarshalFixation); ok {
+		data = fix.AfterMarshalXML(data)
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+//SaveAsIncremental saves document into file with name or io.Writer, where only updated parts are marshaled and compressed again.
+//Parts that were not updated since opening of document are copied from the source as is, without decompressing and compressing, so saving of big documents with few updated cells is much faster
+func (xl *Spreadsheet) SaveAsIncremental(f interface{}) error {
+	return xl.savePackage(context.Background(), f, true)
+}
+
+//savePackage saves document into file with name or io.Writer, where saving is canceled when ctx is done and progress is reported after writing of each part.
+//If incremental is true, then parts that were not updated are copied as is, otherwise these parts are decompressed and compressed again.
+//File with name is written via temporary file in same folder, so partially written file is removed if saving was canceled or failed and source of document can be overwritten
+func (xl *Spreadsheet) savePackage(ctx context.Context, f interface{}, incremental bool) (err error) {
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
+	var target io.Writer
+	switch t := f.(type) {
+	case string:
+		file, createErr := ioutil.TempFile(filepath.Dir(t), ".~"+filepath.Base(t))
+		if createErr != nil {
+			return createErr
+		}
+
+		defer func() {
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+
+			if err == nil {
+				err = os.Rename(file.Name(), t)
+			}
+
+			if err != nil {
+				os.Remove(file.Name())
+			}
+		}()
+
+		target = file
+	case io.Writer:
+		target = t
+	default:
+		return errors.New(fmt.Sprintf("unsupported type of target = %T", f))
+	}
+
+	if err = xl.beforeSave(); err != nil {
+		return err
+	}
+
+	files := xl.pkg.Files()
+	fileNames := make([]string, 0, len(files))
+	for fileName, content := range files {
+		if content != nil {
+			fileNames = append(fileNames, fileName)
+		}
+	}
+
+	//N.B.: [Content_Types].xml goes first, as recommended by OPC
+	sort.Strings(fileNames)
+
+	//rows are reported only for sheets that are marshaled again
+	rows := make(map[string]int)
+	for _, sheet := range xl.sheets {
+		if sheet != nil && sheet.sheet != nil {
+			rows[sheet.file.FileName()] = len(sheet.ml.SheetData)
+		}
+	}
+
+	progress := Progress{TotalParts: len(fileNames)}
+	zipper := newZipWriter(target)
+	for _, fileName := range fileNames {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		switch content := files[fileName].(type) {
+		case *zip.File:
+			if incremental {
+				//raw copy of compressed data of untouched part
+				err = zipper.Copy(content)
+			} else {
+				err = zipper.CreateFrom(content)
+			}
+		default:
+			data, marshalErr := marshalPart(content)
+			if marshalErr == nil {
+				marshalErr = errorOfPart(content)
+			}
+
+			if marshalErr != nil {
+				return marshalErr
+			}
+
+			err = zipper.Create(fileName, data)
+			progress.Rows += rows[fileName]
+		}
+
+		if err != nil {
+			return err
+		}
+
+		progress.Parts++
+		xl.reportProgress(progress)
+	}
+
+	return zipper.Close()
+}
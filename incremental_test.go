@@ -0,0 +1,63 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"testing"
+)
+
+func TestSaveAsIncremental(t *testing.T) {
+	source, err := ioutil.ReadFile("./test_files/example_simple.xlsx")
+	require.Nil(t, err)
+
+	xl, err := OpenBytes(source)
+	require.Nil(t, err)
+	defer xl.Close()
+
+	xl.Sheet(0).CellByRef("A1").SetValue("updated")
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, xl.SaveAsIncremental(buf))
+	require.NotNil(t, xl.SaveAsIncremental(10))
+
+	saved, err := OpenBytes(buf.Bytes())
+	require.Nil(t, err)
+	defer saved.Close()
+	require.Equal(t, "updated", saved.Sheet(0).CellByRef("A1").Value())
+
+	//untouched parts are copied as is
+	sourceZip, err := zip.NewReader(bytes.NewReader(source), int64(len(source)))
+	require.Nil(t, err)
+	savedZip, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.Nil(t, err)
+
+	savedFiles := make(map[string]*zip.File)
+	for _, f := range savedZip.File {
+		savedFiles[f.Name] = f
+	}
+
+	copiedParts := 0
+	for _, f := range sourceZip.File {
+		if f.Name == "xl/theme/theme1.xml" {
+			require.NotNil(t, savedFiles[f.Name])
+			require.Equal(t, f.CRC32, savedFiles[f.Name].CRC32)
+			require.Equal(t, f.CompressedSize64, savedFiles[f.Name].CompressedSize64)
+		}
+
+		//content of copied parts is same after reopening
+		if _, copied := xl.pkg.Files()[f.Name].(*zip.File); copied {
+			require.NotNil(t, savedFiles[f.Name], f.Name)
+			content := readZipFile(f)
+			require.NotNil(t, content, f.Name)
+			require.True(t, bytes.Equal(content, readZipFile(savedFiles[f.Name])), f.Name)
+			copiedParts++
+		}
+	}
+
+	require.NotEqual(t, 0, copiedParts)
+
+	require.Equal(t, xl.Sheet(0).CellByRef("A2").Value(), saved.Sheet(0).CellByRef("A2").Value())
+	require.Equal(t, xl.Sheet(0).CellByRef("H13").Value(), saved.Sheet(0).CellByRef("H13").Value())
+}
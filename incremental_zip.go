@@ -0,0 +1,224 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"math"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	zipLocalHeaderSignature   = 0x04034b50
+	zipCentralHeaderSignature = 0x02014b50
+	zipEndSignature           = 0x06054b50
+	zipVersion                = 20
+	zipFlagDataDescriptor     = 0x8
+	zipFlagUTF8               = 0x800
+)
+
+//zipEntry is information about written part that is required for central directory of zip
+type zipEntry struct {
+	header zip.FileHeader
+	offset uint64
+}
+
+//zipWriter is a minimal writer of zip that can copy compressed data of parts as is. N.B.: zip64 is not supported, so parts and zip must not exceed 4GB
+type zipWriter struct {
+	target   io.Writer
+	offset   uint64
+	entries  []*zipEntry
+	modified time.Time
+}
+
+func newZipWriter(target io.Writer) *zipWriter {
+	return &zipWriter{target: target, modified: time.Now()}
+}
+
+//dosTime returns date and time in MS-DOS format that is used by headers of zip. Dates before 1980 are not supported by format, so 1 Jan 1980 is used for such dates
+func dosTime(t time.Time) (date uint16, clock uint16) {
+	if t.Year() < 1980 {
+		t = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	date = uint16(t.Day() + int(t.Month())<<5 + (t.Year()-1980)<<9)
+	clock = uint16(t.Second()/2 + t.Minute()<<5 + t.Hour()<<11)
+	return
+}
+
+//Copy copies compressed data of part from other zip without decompressing and compressing
+func (z *zipWriter) Copy(f *zip.File) error {
+	//zip.File can't provide compressed data directly (zip.File.OpenRaw requires Go 1.17), but for store method the content is returned as is.
+	//So a copy of file pretends to be stored and uncompressed size is replaced with compressed size, CRC32 is zeroed and data descriptor is dropped,
+	//because reader verifies checksum of returned data only if CRC32 is not zero and it would be a checksum of uncompressed data.
+	//Original header with real method, sizes and checksum is written into the target
+	raw := *f
+	raw.Method = zip.Store
+	raw.Flags &^= zipFlagDataDescriptor
+	raw.CRC32 = 0
+	raw.UncompressedSize64 = f.CompressedSize64
+
+	reader, err := raw.Open()
+	if err != nil {
+		return err
+	}
+
+	defer reader.Close()
+
+	header := f.FileHeader
+	header.Flags &^= zipFlagDataDescriptor
+	return z.write(&header, reader)
+}
+
+//Create compresses data and adds it as part with name
+func (z *zipWriter) Create(fileName string, data []byte) error {
+	compressed := &bytes.Buffer{}
+	compressor, err := flate.NewWriter(compressed, flate.DefaultCompression)
+	if err != nil {
+		return err
+	}
+
+	if _, err = compressor.Write(data); err != nil {
+		return err
+	}
+
+	if err = compressor.Close(); err != nil {
+		return err
+	}
+
+	header := &zip.FileHeader{
+		Name:               fileName,
+		Method:             zip.Deflate,
+		CRC32:              crc32.ChecksumIEEE(data),
+		CompressedSize64:   uint64(compressed.Len()),
+		UncompressedSize64: uint64(len(data)),
+	}
+
+	header.ModifiedDate, header.ModifiedTime = dosTime(z.modified)
+
+	//names with non ASCII characters must be marked as UTF-8
+	for i := 0; i < len(fileName); i++ {
+		if fileName[i] >= utf8.RuneSelf {
+			header.Flags |= zipFlagUTF8
+			break
+		}
+	}
+
+	return z.write(header, compressed)
+}
+
+//CreateFrom decompresses part from other zip and adds it as part with same name that is compressed again
+func (z *zipWriter) CreateFrom(f *zip.File) error {
+	reader, err := f.Open()
+	if err != nil {
+		return err
+	}
+
+	defer reader.Close()
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	return z.Create(f.Name, data)
+}
+
+//write writes local header of part with compressed data
+func (z *zipWriter) write(header *zip.FileHeader, data io.Reader) error {
+	if header.CompressedSize64 >= math.MaxUint32 || header.UncompressedSize64 >= math.MaxUint32 || z.offset >= math.MaxUint32 {
+		return errors.New(fmt.Sprintf("part %s exceeds allowed size of zip", header.Name))
+	}
+
+	buf := &bytes.Buffer{}
+	for _, v := range []interface{}{
+		uint32(zipLocalHeaderSignature),
+		uint16(zipVersion),
+		header.Flags,
+		header.Method,
+		header.ModifiedTime,
+		header.ModifiedDate,
+		header.CRC32,
+		uint32(header.CompressedSize64),
+		uint32(header.UncompressedSize64),
+		uint16(len(header.Name)),
+		uint16(0),
+	} {
+		_ = binary.Write(buf, binary.LittleEndian, v)
+	}
+
+	buf.WriteString(header.Name)
+	if _, err := z.target.Write(buf.Bytes()); err != nil {
+		return err
+	}
+
+	written, err := io.Copy(z.target, data)
+	if err != nil {
+		return err
+	}
+
+	if uint64(written) != header.CompressedSize64 {
+		return errors.New(fmt.Sprintf("can't write content of part %s", header.Name))
+	}
+
+	z.entries = append(z.entries, &zipEntry{header: *header, offset: z.offset})
+	z.offset += uint64(buf.Len()) + header.CompressedSize64
+	return nil
+}
+
+//Close writes central directory of zip. Target is not closed
+func (z *zipWriter) Close() error {
+	if len(z.entries) >= math.MaxUint16 || z.offset >= math.MaxUint32 {
+		return errors.New("number of parts or size of zip exceeds allowed limit")
+	}
+
+	buf := &bytes.Buffer{}
+	for _, entry := range z.entries {
+		for _, v := range []interface{}{
+			uint32(zipCentralHeaderSignature),
+			uint16(zipVersion),
+			uint16(zipVersion),
+			entry.header.Flags,
+			entry.header.Method,
+			entry.header.ModifiedTime,
+			entry.header.ModifiedDate,
+			entry.header.CRC32,
+			uint32(entry.header.CompressedSize64),
+			uint32(entry.header.UncompressedSize64),
+			uint16(len(entry.header.Name)),
+			uint16(0),
+			uint16(0),
+			uint16(0),
+			uint16(0),
+			uint32(0),
+			uint32(entry.offset),
+		} {
+			_ = binary.Write(buf, binary.LittleEndian, v)
+		}
+
+		buf.WriteString(entry.header.Name)
+	}
+
+	size := buf.Len()
+	for _, v := range []interface{}{
+		uint32(zipEndSignature),
+		uint16(0),
+		uint16(0),
+		uint16(len(z.entries)),
+		uint16(len(z.entries)),
+		uint32(size),
+		uint32(z.offset),
+		uint16(0),
+	} {
+		_ = binary.Write(buf, binary.LittleEndian, v)
+	}
+
+	_, err := z.target.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,59 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"testing"
+)
+
+func TestZipWriter(t *testing.T) {
+	//zip.Writer writes data descriptor after content of each part
+	source := &bytes.Buffer{}
+	zipper := zip.NewWriter(source)
+	writer, err := zipper.Create("xl/copied.xml")
+	require.Nil(t, err)
+	_, err = writer.Write(bytes.Repeat([]byte("<copied/>"), 100))
+	require.Nil(t, err)
+	require.Nil(t, zipper.Close())
+
+	sourceZip, err := zip.NewReader(bytes.NewReader(source.Bytes()), int64(source.Len()))
+	require.Nil(t, err)
+	require.Equal(t, uint16(zipFlagDataDescriptor), sourceZip.File[0].Flags&zipFlagDataDescriptor)
+
+	target := &bytes.Buffer{}
+	z := newZipWriter(target)
+	require.Nil(t, z.Copy(sourceZip.File[0]))
+	require.Nil(t, z.Create("xl/created.xml", []byte("<created/>")))
+	require.Nil(t, z.Create("xl/media/ñame.xml", []byte("<name/>")))
+	require.Nil(t, z.Close())
+
+	targetZip, err := zip.NewReader(bytes.NewReader(target.Bytes()), int64(target.Len()))
+	require.Nil(t, err)
+	require.Equal(t, 3, len(targetZip.File))
+
+	contents := make(map[string]string)
+	for _, f := range targetZip.File {
+		reader, err := f.Open()
+		require.Nil(t, err)
+		content, err := ioutil.ReadAll(reader)
+		require.Nil(t, err)
+		require.Nil(t, reader.Close())
+		contents[f.Name] = string(content)
+	}
+
+	require.Equal(t, string(bytes.Repeat([]byte("<copied/>"), 100)), contents["xl/copied.xml"])
+	require.Equal(t, sourceZip.File[0].CRC32, targetZip.File[0].CRC32)
+	require.Equal(t, uint16(0), targetZip.File[0].Flags&zipFlagDataDescriptor)
+	require.Equal(t, "<created/>", contents["xl/created.xml"])
+	require.Equal(t, uint16(0), targetZip.File[1].Flags&zipFlagUTF8)
+	require.Equal(t, "<name/>", contents["xl/media/ñame.xml"])
+	require.Equal(t, uint16(zipFlagUTF8), targetZip.File[2].Flags&zipFlagUTF8)
+
+	//created parts have time of saving
+	date, clock := dosTime(z.modified)
+	require.Equal(t, date, targetZip.File[1].ModifiedDate)
+	require.Equal(t, clock, targetZip.File[1].ModifiedTime)
+	require.Equal(t, z.modified.Year(), targetZip.File[1].Modified.Year())
+}
@@ -0,0 +1,459 @@
+package cfb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+//List of special values for sector numbers and directory entries
+const (
+	sectorFree       uint32 = 0xFFFFFFFF
+	sectorEndOfChain uint32 = 0xFFFFFFFE
+	sectorFAT        uint32 = 0xFFFFFFFD
+	sectorDIFAT      uint32 = 0xFFFFFFFC
+	noStream         uint32 = 0xFFFFFFFF
+)
+
+//List of types of directory entries
+const (
+	entryStorage byte = 1
+	entryStream  byte = 2
+	entryRoot    byte = 5
+)
+
+const (
+	headerSize       = 512
+	sectorSize       = 512
+	miniSectorSize   = 64
+	miniStreamCutoff = 4096
+	directorySize    = 128
+	headerDIFATSize  = 109
+)
+
+var signature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+//Stream is a named stream of compound file
+type Stream struct {
+	Name string
+	Data []byte
+}
+
+type entry struct {
+	name  string
+	kind  byte
+	left  uint32
+	right uint32
+	child uint32
+	start uint32
+	size  uint64
+}
+
+//IsCompoundFile returns true if data has signature of compound file
+func IsCompoundFile(data []byte) bool {
+	return len(data) >= len(signature) && bytes.Equal(data[:len(signature)], signature)
+}
+
+//Read returns streams of root storage of compound file as described in MS-CFB
+func Read(data []byte) (map[string][]byte, error) {
+	if len(data) < headerSize || !IsCompoundFile(data) {
+		return nil, errors.New("invalid compound file signature")
+	}
+
+	le := binary.LittleEndian
+	size := 1 << le.Uint16(data[30:])
+	if size != 512 && size != 4096 {
+		return nil, errors.New(fmt.Sprintf("unsupported size of sector = %d", size))
+	}
+
+	total := uint32((len(data) - size) / size)
+	sector := func(id uint32) []byte {
+		if id >= total {
+			return nil
+		}
+
+		offset := int(id+1) * size
+		return data[offset : offset+size]
+	}
+
+	//locate sectors of FAT
+	var fatSectors []uint32
+	for i := 0; i < headerDIFATSize; i++ {
+		fatSectors = append(fatSectors, le.Uint32(data[76+i*4:]))
+	}
+
+	for id, n := le.Uint32(data[68:]), uint32(0); id != sectorEndOfChain && id != sectorFree && n < total; n++ {
+		s := sector(id)
+		if s == nil {
+			return nil, errors.New("invalid DIFAT sector")
+		}
+
+		for i := 0; i < size/4-1; i++ {
+			fatSectors = append(fatSectors, le.Uint32(s[i*4:]))
+		}
+
+		id = le.Uint32(s[size-4:])
+	}
+
+	count := int(le.Uint32(data[44:]))
+	if count > len(fatSectors) {
+		return nil, errors.New("invalid number of FAT sectors")
+	}
+
+	var fat []uint32
+	for _, id := range fatSectors[:count] {
+		s := sector(id)
+		if s == nil {
+			return nil, errors.New("invalid FAT sector")
+		}
+
+		for i := 0; i < size/4; i++ {
+			fat = append(fat, le.Uint32(s[i*4:]))
+		}
+	}
+
+	chain := func(start uint32, table []uint32, read func(id uint32) []byte) ([]byte, error) {
+		var b []byte
+		for id, n := start, 0; id != sectorEndOfChain; n++ {
+			s := read(id)
+			if s == nil || int(id) >= len(table) || n > len(table) {
+				return nil, errors.New("invalid chain of sectors")
+			}
+
+			b = append(b, s...)
+			id = table[id]
+		}
+
+		return b, nil
+	}
+
+	//read directory
+	dir, err := chain(le.Uint32(data[48:]), fat, sector)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*entry
+	for offset := 0; offset+directorySize <= len(dir); offset += directorySize {
+		e := dir[offset : offset+directorySize]
+		name := make([]uint16, 0, 32)
+		for i := 0; i < int(le.Uint16(e[64:]))/2-1 && i < 32; i++ {
+			name = append(name, le.Uint16(e[i*2:]))
+		}
+
+		streamSize := le.Uint64(e[120:])
+		if size == 512 {
+			streamSize &= 0xFFFFFFFF
+		}
+
+		entries = append(entries, &entry{
+			name:  string(utf16.Decode(name)),
+			kind:  e[66],
+			left:  le.Uint32(e[68:]),
+			right: le.Uint32(e[72:]),
+			child: le.Uint32(e[76:]),
+			start: le.Uint32(e[116:]),
+			size:  streamSize,
+		})
+	}
+
+	if len(entries) == 0 || entries[0].kind != entryRoot {
+		return nil, errors.New("there is no root entry")
+	}
+
+	//read mini stream
+	miniFATData, err := chain(le.Uint32(data[60:]), fat, sector)
+	if err != nil && le.Uint32(data[64:]) > 0 {
+		return nil, err
+	}
+
+	miniFAT := make([]uint32, len(miniFATData)/4)
+	for i := range miniFAT {
+		miniFAT[i] = le.Uint32(miniFATData[i*4:])
+	}
+
+	var miniStream []byte
+	if entries[0].start != sectorEndOfChain {
+		if miniStream, err = chain(entries[0].start, fat, sector); err != nil {
+			return nil, err
+		}
+	}
+
+	miniSector := func(id uint32) []byte {
+		offset := int(id) * miniSectorSize
+		if offset+miniSectorSize > len(miniStream) {
+			return nil
+		}
+
+		return miniStream[offset : offset+miniSectorSize]
+	}
+
+	//collect streams of root storage
+	streams := make(map[string][]byte)
+	visited := make(map[uint32]bool)
+	stack := []uint32{entries[0].child}
+	cutoff := uint64(le.Uint32(data[56:]))
+
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if id == noStream || int(id) >= len(entries) || visited[id] {
+			continue
+		}
+
+		visited[id] = true
+		e := entries[id]
+		stack = append(stack, e.left, e.right)
+
+		if e.kind != entryStream {
+			continue
+		}
+
+		var b []byte
+		if e.size > 0 {
+			if e.size < cutoff {
+				b, err = chain(e.start, miniFAT, miniSector)
+			} else {
+				b, err = chain(e.start, fat, sector)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			if uint64(len(b)) < e.size {
+				return nil, errors.New(fmt.Sprintf("stream '%s' is truncated", e.name))
+			}
+		}
+
+		streams[e.name] = b[:e.size]
+	}
+
+	return streams, nil
+}
+
+//Write writes streams into root storage of compound file as described in MS-CFB
+func Write(w io.Writer, streams ...*Stream) error {
+	le := binary.LittleEndian
+
+	var body, mini bytes.Buffer
+	var fat, miniFAT []uint32
+
+	//allocate appends data to sectors and returns first sector of chain
+	allocate := func(b *bytes.Buffer, table *[]uint32, data []byte, size int) uint32 {
+		count := (len(data) + size - 1) / size
+		if count == 0 {
+			return sectorEndOfChain
+		}
+
+		start := uint32(len(*table))
+		for i := 1; i < count; i++ {
+			*table = append(*table, start+uint32(i))
+		}
+
+		*table = append(*table, sectorEndOfChain)
+		b.Write(data)
+		b.Write(make([]byte, count*size-len(data)))
+		return start
+	}
+
+	entries := []*entry{{name: "Root Entry", kind: entryRoot, left: noStream, right: noStream, child: noStream}}
+	for _, s := range streams {
+		if len(s.Name) == 0 || len(utf16.Encode([]rune(s.Name))) > 31 {
+			return errors.New(fmt.Sprintf("invalid name of stream '%s'", s.Name))
+		}
+
+		entries = append(entries, &entry{name: s.Name, kind: entryStream, left: noStream, right: noStream, child: noStream, size: uint64(len(s.Data))})
+	}
+
+	for i, s := range streams {
+		if len(s.Data) < miniStreamCutoff {
+			entries[i+1].start = allocate(&mini, &miniFAT, s.Data, miniSectorSize)
+		}
+	}
+
+	entries[0].start = allocate(&body, &fat, mini.Bytes(), sectorSize)
+	entries[0].size = uint64(mini.Len())
+
+	for i, s := range streams {
+		if len(s.Data) >= miniStreamCutoff {
+			entries[i+1].start = allocate(&body, &fat, s.Data, sectorSize)
+		}
+	}
+
+	miniFATData := make([]byte, len(miniFAT)*4)
+	for i, id := range miniFAT {
+		le.PutUint32(miniFATData[i*4:], id)
+	}
+
+	miniFATSectors := (len(miniFATData) + sectorSize - 1) / sectorSize
+	miniFATStart := allocate(&body, &fat, miniFATData, sectorSize)
+
+	//sort streams in order that is required by red-black tree of directory and build balanced tree
+	children := make([]uint32, len(streams))
+	for i := range children {
+		children[i] = uint32(i + 1)
+	}
+
+	sort.Slice(children, func(i, j int) bool {
+		return compareNames(entries[children[i]].name, entries[children[j]].name) < 0
+	})
+
+	var tree func(ids []uint32) uint32
+	tree = func(ids []uint32) uint32 {
+		if len(ids) == 0 {
+			return noStream
+		}
+
+		mid := len(ids) / 2
+		entries[ids[mid]].left = tree(ids[:mid])
+		entries[ids[mid]].right = tree(ids[mid+1:])
+		return ids[mid]
+	}
+
+	entries[0].child = tree(children)
+
+	perSector := sectorSize / directorySize
+	dir := make([]byte, ((len(entries)+perSector-1)/perSector)*sectorSize)
+	for i := range dir[len(entries)*directorySize:] {
+		if (i%directorySize) >= 68 && (i%directorySize) < 80 {
+			dir[len(entries)*directorySize+i] = 0xFF
+		}
+	}
+
+	for i, e := range entries {
+		b := dir[i*directorySize:]
+		name := utf16.Encode([]rune(e.name))
+		for j, c := range name {
+			le.PutUint16(b[j*2:], c)
+		}
+
+		le.PutUint16(b[64:], uint16((len(name)+1)*2))
+		b[66] = e.kind
+		b[67] = 1
+		le.PutUint32(b[68:], e.left)
+		le.PutUint32(b[72:], e.right)
+		le.PutUint32(b[76:], e.child)
+		le.PutUint32(b[116:], e.start)
+		le.PutUint64(b[120:], e.size)
+	}
+
+	dirStart := allocate(&body, &fat, dir, sectorSize)
+
+	//reserve sectors for FAT and DIFAT
+	used := uint32(len(fat))
+	fatSectors, difatSectors := uint32(0), uint32(0)
+	for {
+		perFAT := uint32(sectorSize / 4)
+		f := (used + fatSectors + difatSectors + perFAT - 1) / perFAT
+		d := uint32(0)
+		if f > headerDIFATSize {
+			d = (f - headerDIFATSize + perFAT - 2) / (perFAT - 1)
+		}
+
+		if f == fatSectors && d == difatSectors {
+			break
+		}
+
+		fatSectors, difatSectors = f, d
+	}
+
+	for i := uint32(0); i < fatSectors; i++ {
+		fat = append(fat, sectorFAT)
+	}
+
+	for i := uint32(0); i < difatSectors; i++ {
+		fat = append(fat, sectorDIFAT)
+	}
+
+	for len(fat) < int(fatSectors)*sectorSize/4 {
+		fat = append(fat, sectorFree)
+	}
+
+	for _, id := range fat {
+		var b [4]byte
+		le.PutUint32(b[:], id)
+		body.Write(b[:])
+	}
+
+	var difat []uint32
+	for i := uint32(0); i < fatSectors; i++ {
+		difat = append(difat, used+i)
+	}
+
+	header := make([]byte, headerSize)
+	copy(header, signature)
+	le.PutUint16(header[24:], 0x003E)
+	le.PutUint16(header[26:], 0x0003)
+	le.PutUint16(header[28:], 0xFFFE)
+	le.PutUint16(header[30:], 9)
+	le.PutUint16(header[32:], 6)
+	le.PutUint32(header[44:], fatSectors)
+	le.PutUint32(header[48:], dirStart)
+	le.PutUint32(header[56:], miniStreamCutoff)
+	le.PutUint32(header[60:], miniFATStart)
+	le.PutUint32(header[64:], uint32(miniFATSectors))
+	le.PutUint32(header[68:], sectorEndOfChain)
+	le.PutUint32(header[72:], difatSectors)
+
+	for i := 0; i < headerDIFATSize; i++ {
+		id := sectorFree
+		if i < len(difat) {
+			id = difat[i]
+		}
+
+		le.PutUint32(header[76+i*4:], id)
+	}
+
+	//DIFAT sectors hold the rest of FAT sectors, the last entry of each sector is a link to the next one
+	if difatSectors > 0 {
+		le.PutUint32(header[68:], used+fatSectors)
+		rest := difat[headerDIFATSize:]
+		for i := uint32(0); i < difatSectors; i++ {
+			s := make([]byte, sectorSize)
+			for j := 0; j < sectorSize/4-1; j++ {
+				id := sectorFree
+				if len(rest) > 0 {
+					id, rest = rest[0], rest[1:]
+				}
+
+				le.PutUint32(s[j*4:], id)
+			}
+
+			next := sectorEndOfChain
+			if i+1 < difatSectors {
+				next = used + fatSectors + i + 1
+			}
+
+			le.PutUint32(s[sectorSize-4:], next)
+			body.Write(s)
+		}
+	}
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	_, err := w.Write(body.Bytes())
+	return err
+}
+
+//compareNames compares names of directory entries: shorter names are less, names with same length are compared in upper case
+func compareNames(a, b string) int {
+	la, lb := len(utf16.Encode([]rune(a))), len(utf16.Encode([]rune(b)))
+	if la != lb {
+		if la < lb {
+			return -1
+		}
+
+		return 1
+	}
+
+	return strings.Compare(strings.ToUpper(a), strings.ToUpper(b))
+}
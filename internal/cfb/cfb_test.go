@@ -0,0 +1,39 @@
+package cfb_test
+
+import (
+	"bytes"
+	"github.com/plandem/xlsx/internal/cfb"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCompoundFile(t *testing.T) {
+	small := []byte("small stream that resides in mini stream")
+	large := bytes.Repeat([]byte{1, 2, 3, 4, 5, 6, 7}, 2000)
+	huge := bytes.Repeat([]byte{9, 8, 7}, 3000000)
+
+	for _, streams := range [][]*cfb.Stream{
+		{{Name: "EncryptionInfo", Data: small}, {Name: "EncryptedPackage", Data: large}},
+		{{Name: "Empty", Data: nil}, {Name: "A", Data: small}, {Name: "B", Data: small}, {Name: "Huge", Data: huge}},
+	} {
+		var buf bytes.Buffer
+		require.Nil(t, cfb.Write(&buf, streams...))
+		require.Equal(t, true, cfb.IsCompoundFile(buf.Bytes()))
+		require.Equal(t, 0, buf.Len()%512)
+
+		result, err := cfb.Read(buf.Bytes())
+		require.Nil(t, err)
+		require.Equal(t, len(streams), len(result))
+
+		for _, s := range streams {
+			require.Equal(t, len(s.Data), len(result[s.Name]))
+			require.Equal(t, true, bytes.Equal(s.Data, result[s.Name]))
+		}
+	}
+
+	require.NotNil(t, cfb.Write(&bytes.Buffer{}, &cfb.Stream{Name: "name of stream that is too long for entry"}))
+
+	_, err := cfb.Read([]byte("not a compound file"))
+	require.NotNil(t, err)
+	require.Equal(t, false, cfb.IsCompoundFile([]byte("PK")))
+}
@@ -34,3 +34,25 @@ func Normalize(color string) string {
 
 	return strings.ToUpper(color)
 }
+
+//ToRGB returns #RGB representation of ml.Color, respecting built-in indexed colors. Theme and auto colors are not supported and return empty string
+func ToRGB(c *ml.Color) string {
+	if c == nil {
+		return ""
+	}
+
+	argb := c.RGB
+	if c.Indexed != nil {
+		if *c.Indexed < 0 || *c.Indexed >= len(indexed) {
+			return ""
+		}
+
+		argb = indexed[*c.Indexed]
+	}
+
+	if len(argb) == 8 {
+		return "#" + argb[2:]
+	}
+
+	return ""
+}
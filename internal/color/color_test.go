@@ -12,4 +12,12 @@ func TestColor(t *testing.T) {
 	indexedColor := 6
 	require.Equal(t, &ml.Color{Indexed: sharedML.OptionalIndex(&indexedColor)}, color.New("#FF00FF"))
 	require.Equal(t, &ml.Color{RGB: "FF112233"}, color.New("#112233"))
+
+	require.Equal(t, "#FF00FF", color.ToRGB(color.New("#FF00FF")))
+	require.Equal(t, "#112233", color.ToRGB(color.New("#112233")))
+	require.Equal(t, "", color.ToRGB(&ml.Color{Theme: sharedML.OptionalIndex(&indexedColor)}))
+	require.Equal(t, "", color.ToRGB(nil))
+
+	require.Equal(t, 64, len(color.Indexed()))
+	require.Equal(t, "#FF00FF", color.Indexed()[6])
 }
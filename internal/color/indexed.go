@@ -73,3 +73,13 @@ func init() {
 		Normalize("#333333"),
 	}
 }
+
+//Indexed returns #RGB colors of built-in indexed palette
+func Indexed() []string {
+	colors := make([]string, len(indexed))
+	for i, c := range indexed {
+		colors[i] = "#" + c[2:]
+	}
+
+	return colors
+}
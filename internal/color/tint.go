@@ -0,0 +1,120 @@
+package color
+
+import (
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml"
+	"math"
+	"strconv"
+)
+
+//ToThemedRGB returns #RGB representation of ml.Color, respecting built-in indexed colors, theme colors of palette and tint. Auto colors are not supported and return empty string
+func ToThemedRGB(c *ml.Color, palette []string) string {
+	if c == nil {
+		return ""
+	}
+
+	var rgb string
+	if c.Theme != nil {
+		if *c.Theme >= 0 && *c.Theme < len(palette) {
+			rgb = palette[*c.Theme]
+		}
+	} else {
+		rgb = ToRGB(c)
+	}
+
+	if len(rgb) > 0 && c.Tint != 0 {
+		rgb = Tint(rgb, c.Tint)
+	}
+
+	return rgb
+}
+
+//Tint returns #RGB of color with applied tint in range from -1.0 (darken) to 1.0 (lighten) as Excel does
+func Tint(rgb string, tint float64) string {
+	argb := Normalize(rgb)
+	if len(argb) != 8 {
+		return ""
+	}
+
+	value, err := strconv.ParseUint(argb[2:], 16, 32)
+	if err != nil {
+		return ""
+	}
+
+	r, g, b := float64(value>>16&0xff)/255, float64(value>>8&0xff)/255, float64(value&0xff)/255
+	h, s, l := rgbToHsl(r, g, b)
+
+	if tint < 0 {
+		l = l * (1 + tint)
+	} else {
+		l = l*(1-tint) + tint
+	}
+
+	r, g, b = hslToRgb(h, s, math.Max(0, math.Min(1, l)))
+	return fmt.Sprintf("#%02X%02X%02X", int(math.Round(r*255)), int(math.Round(g*255)), int(math.Round(b*255)))
+}
+
+func rgbToHsl(r, g, b float64) (h, s, l float64) {
+	max, min := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	l = (max + min) / 2
+
+	if max == min {
+		return 0, 0, l
+	}
+
+	d := max - min
+	if l > 0.5 {
+		s = d / (2 - max - min)
+	} else {
+		s = d / (max + min)
+	}
+
+	switch max {
+	case r:
+		h = (g - b) / d
+		if g < b {
+			h += 6
+		}
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+
+	return h / 6, s, l
+}
+
+func hslToRgb(h, s, l float64) (r, g, b float64) {
+	if s == 0 {
+		return l, l, l
+	}
+
+	q := l * (1 + s)
+	if l >= 0.5 {
+		q = l + s - l*s
+	}
+
+	p := 2*l - q
+	return hueToRgb(p, q, h+1.0/3), hueToRgb(p, q, h), hueToRgb(p, q, h-1.0/3)
+}
+
+func hueToRgb(p, q, t float64) float64 {
+	if t < 0 {
+		t++
+	}
+
+	if t > 1 {
+		t--
+	}
+
+	switch {
+	case t < 1.0/6:
+		return p + (q-p)*6*t
+	case t < 1.0/2:
+		return q
+	case t < 2.0/3:
+		return p + (q-p)*(2.0/3-t)*6
+	}
+
+	return p
+}
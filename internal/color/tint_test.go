@@ -0,0 +1,24 @@
+package color_test
+
+import (
+	sharedML "github.com/plandem/ooxml/ml"
+	"github.com/plandem/xlsx/internal/color"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTint(t *testing.T) {
+	require.Equal(t, "#D9D9D9", color.Tint("#FFFFFF", -0.1499984740745262))
+	require.Equal(t, "#8FAADC", color.Tint("#4472C4", 0.3999755851924192))
+	require.Equal(t, "#2F5597", color.Tint("#4472C4", -0.249977111117893))
+	require.Equal(t, "#808080", color.Tint("#000000", 0.5))
+	require.Equal(t, "", color.Tint("wrong", 0.5))
+
+	accent1 := 4
+	palette := []string{"#FFFFFF", "#000000", "#E7E6E6", "#44546A", "#4472C4"}
+	require.Equal(t, "#4472C4", color.ToThemedRGB(&ml.Color{Theme: sharedML.OptionalIndex(&accent1)}, palette))
+	require.Equal(t, "#8FAADC", color.ToThemedRGB(&ml.Color{Theme: sharedML.OptionalIndex(&accent1), Tint: 0.3999755851924192}, palette))
+	require.Equal(t, "", color.ToThemedRGB(&ml.Color{Theme: sharedML.OptionalIndex(&accent1)}, nil))
+	require.Equal(t, "#112233", color.ToThemedRGB(color.New("#112233"), nil))
+}
@@ -0,0 +1,496 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml"
+	"hash"
+)
+
+//List of streams of compound file that hold encrypted package
+const (
+	StreamEncryptionInfo   = "EncryptionInfo"
+	StreamEncryptedPackage = "EncryptedPackage"
+)
+
+const (
+	keyEncryptorPassword = "http://schemas.microsoft.com/office/2006/keyEncryptor/password"
+	segmentSize          = 4096
+	agileKeyBits         = 256
+	agileHashSize        = 64
+	agileHashAlgorithm   = "SHA512"
+	standardSpinCount    = 50000
+)
+
+//block keys that are used to derive encryption keys for agile encryption
+var (
+	blockKeyVerifierHashInput = []byte{0xfe, 0xa7, 0xd2, 0x76, 0x3b, 0x4b, 0x9e, 0x79}
+	blockKeyVerifierHashValue = []byte{0xd7, 0xaa, 0x0f, 0x6d, 0x30, 0x61, 0x34, 0x4e}
+	blockKeyEncryptedKeyValue = []byte{0x14, 0x6e, 0x0b, 0xe7, 0xab, 0xac, 0xd0, 0xd6}
+	blockKeyHmacKey           = []byte{0x5f, 0xb2, 0xad, 0x01, 0x0c, 0xb9, 0xe1, 0xf6}
+	blockKeyHmacValue         = []byte{0xa0, 0x67, 0x7f, 0x02, 0xb2, 0x2c, 0x84, 0x33}
+)
+
+//List of errors that are returned when package can't be decrypted
+var (
+	ErrorInvalidPassword  = errors.New("invalid password")
+	ErrorIntegrityFailure = errors.New("integrity check of encrypted package failed")
+)
+
+//EncryptPackage encrypts package with password using agile encryption (AES-256, SHA-512) as described in MS-OFFCRYPTO and returns content of EncryptionInfo and EncryptedPackage streams
+func EncryptPackage(pkg []byte, password string) (info []byte, encrypted []byte, err error) {
+	keyData := ml.KeyData{
+		SaltSize:        aes.BlockSize,
+		BlockSize:       aes.BlockSize,
+		KeyBits:         agileKeyBits,
+		HashSize:        agileHashSize,
+		CipherAlgorithm: "AES",
+		CipherChaining:  "ChainingModeCBC",
+		HashAlgorithm:   agileHashAlgorithm,
+	}
+
+	var keyDataSalt, secretKey, hmacKey, passwordSalt, verifier []byte
+	for _, b := range []*[]byte{&keyDataSalt, &passwordSalt, &verifier} {
+		if *b, err = randomBytes(aes.BlockSize); err != nil {
+			return
+		}
+	}
+
+	if secretKey, err = randomBytes(agileKeyBits / 8); err != nil {
+		return
+	}
+
+	if hmacKey, err = randomBytes(agileHashSize); err != nil {
+		return
+	}
+
+	//encrypt package
+	if encrypted, err = cryptSegments(pkg, secretKey, keyDataSalt, sha512.New, true); err != nil {
+		return
+	}
+
+	//sign encrypted package
+	mac := hmac.New(sha512.New, hmacKey)
+	mac.Write(encrypted)
+
+	encryptedHmacKey, err := encryptCBC(secretKey, agileIV(sha512.New(), keyDataSalt, blockKeyHmacKey), hmacKey)
+	if err != nil {
+		return
+	}
+
+	encryptedHmacValue, err := encryptCBC(secretKey, agileIV(sha512.New(), keyDataSalt, blockKeyHmacValue), mac.Sum(nil))
+	if err != nil {
+		return
+	}
+
+	//encrypt secret key with password
+	passwordHash := iterateHash(sha512.New(), password, passwordSalt, SpinCount, true)
+	verifierHash := sha512.Sum512(verifier)
+
+	encryptedKey := &ml.EncryptedKey{KeyData: keyData, SpinCount: SpinCount}
+	encryptedKey.SaltValue = base64.StdEncoding.EncodeToString(passwordSalt)
+
+	for _, v := range []struct {
+		target   *string
+		blockKey []byte
+		data     []byte
+	}{
+		{&encryptedKey.EncryptedVerifierHashInput, blockKeyVerifierHashInput, verifier},
+		{&encryptedKey.EncryptedVerifierHashValue, blockKeyVerifierHashValue, verifierHash[:]},
+		{&encryptedKey.EncryptedKeyValue, blockKeyEncryptedKeyValue, secretKey},
+	} {
+		var b []byte
+		if b, err = encryptCBC(agileKey(sha512.New(), passwordHash, v.blockKey, agileKeyBits/8), passwordSalt, v.data); err != nil {
+			return
+		}
+
+		*v.target = base64.StdEncoding.EncodeToString(b)
+	}
+
+	keyData.SaltValue = base64.StdEncoding.EncodeToString(keyDataSalt)
+	descriptor, err := xml.Marshal(&ml.Encryption{
+		KeyData: keyData,
+		DataIntegrity: &ml.DataIntegrity{
+			EncryptedHmacKey:   base64.StdEncoding.EncodeToString(encryptedHmacKey),
+			EncryptedHmacValue: base64.StdEncoding.EncodeToString(encryptedHmacValue),
+		},
+		KeyEncryptors: []*ml.KeyEncryptor{{URI: keyEncryptorPassword, EncryptedKey: encryptedKey}},
+	})
+
+	if err != nil {
+		return
+	}
+
+	//version 4.4 with reserved flags is used for agile encryption
+	info = append([]byte{0x04, 0x00, 0x04, 0x00, 0x40, 0x00, 0x00, 0x00}, []byte(xml.Header)...)
+	info = append(info, descriptor...)
+	return
+}
+
+//DecryptPackage decrypts package with password using content of EncryptionInfo and EncryptedPackage streams. Agile and standard encryption are supported
+func DecryptPackage(info []byte, encrypted []byte, password string) ([]byte, error) {
+	if len(info) < 8 || len(encrypted) < 8 {
+		return nil, errors.New("invalid encryption info")
+	}
+
+	major, minor := binary.LittleEndian.Uint16(info[0:]), binary.LittleEndian.Uint16(info[2:])
+	switch {
+	case major == 4 && minor == 4:
+		return decryptAgile(info[8:], encrypted, password)
+	case (major == 2 || major == 3 || major == 4) && minor == 2:
+		return decryptStandard(info[8:], encrypted, password)
+	}
+
+	return nil, errors.New(fmt.Sprintf("unsupported version of encryption = %d.%d", major, minor))
+}
+
+//decryptAgile decrypts package that was encrypted with agile encryption
+func decryptAgile(descriptor []byte, encrypted []byte, password string) ([]byte, error) {
+	var encryption ml.Encryption
+	if err := xml.Unmarshal(descriptor, &encryption); err != nil {
+		return nil, err
+	}
+
+	var key *ml.EncryptedKey
+	for _, encryptor := range encryption.KeyEncryptors {
+		if encryptor.EncryptedKey != nil {
+			key = encryptor.EncryptedKey
+			break
+		}
+	}
+
+	if key == nil {
+		return nil, errors.New("there is no password key encryptor")
+	}
+
+	for _, kd := range []*ml.KeyData{&encryption.KeyData, &key.KeyData} {
+		if kd.CipherAlgorithm != "AES" || kd.CipherChaining != "ChainingModeCBC" {
+			return nil, errors.New(fmt.Sprintf("unsupported cipher = %s, %s", kd.CipherAlgorithm, kd.CipherChaining))
+		}
+
+		if kd.KeyBits != 128 && kd.KeyBits != 192 && kd.KeyBits != 256 {
+			return nil, errors.New(fmt.Sprintf("unsupported size of key = %d", kd.KeyBits))
+		}
+	}
+
+	if key.SpinCount < 0 || key.SpinCount > MaxSpinCount {
+		return nil, errors.New(fmt.Sprintf("spin count exceeds limit = %d", MaxSpinCount))
+	}
+
+	newHash, err := hashFunction(key.HashAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]string{
+		"salt":      key.SaltValue,
+		"input":     key.EncryptedVerifierHashInput,
+		"value":     key.EncryptedVerifierHashValue,
+		"key":       key.EncryptedKeyValue,
+		"data salt": encryption.KeyData.SaltValue,
+	}
+
+	if encryption.DataIntegrity != nil {
+		fields["hmac key"] = encryption.DataIntegrity.EncryptedHmacKey
+		fields["hmac value"] = encryption.DataIntegrity.EncryptedHmacValue
+	}
+
+	values := make(map[string][]byte)
+	for name, v := range fields {
+		if values[name], err = base64.StdEncoding.DecodeString(v); err != nil {
+			return nil, err
+		}
+	}
+
+	passwordHash := iterateHash(newHash(), password, values["salt"], key.SpinCount, true)
+	iv := fitSize(values["salt"], aes.BlockSize, 0x36)
+	keySize := key.KeyBits / 8
+
+	//check password with verifier
+	input, err := decryptCBC(agileKey(newHash(), passwordHash, blockKeyVerifierHashInput, keySize), iv, values["input"])
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := decryptCBC(agileKey(newHash(), passwordHash, blockKeyVerifierHashValue, keySize), iv, values["value"])
+	if err != nil {
+		return nil, err
+	}
+
+	if key.SaltSize <= 0 || key.SaltSize > len(input) {
+		return nil, errors.New("invalid size of salt")
+	}
+
+	h := newHash()
+	h.Write(input[:key.SaltSize])
+	if len(value) < h.Size() || !hmac.Equal(h.Sum(nil), value[:h.Size()]) {
+		return nil, ErrorInvalidPassword
+	}
+
+	secretKey, err := decryptCBC(agileKey(newHash(), passwordHash, blockKeyEncryptedKeyValue, keySize), iv, values["key"])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(secretKey) < encryption.KeyData.KeyBits/8 {
+		return nil, errors.New("invalid size of secret key")
+	}
+
+	if newHash, err = hashFunction(encryption.KeyData.HashAlgorithm); err != nil {
+		return nil, err
+	}
+
+	secretKey = secretKey[:encryption.KeyData.KeyBits/8]
+
+	//N.B.: data integrity is optional for MS-OFFCRYPTO, but if it exists, then encrypted package must match it
+	if encryption.DataIntegrity != nil {
+		if err = verifyIntegrity(encrypted, secretKey, values["data salt"], values["hmac key"], values["hmac value"], newHash); err != nil {
+			return nil, err
+		}
+	}
+
+	return cryptSegments(encrypted, secretKey, values["data salt"], newHash, false)
+}
+
+//verifyIntegrity checks HMAC of encrypted package (including size of original package) against HMAC of data integrity
+func verifyIntegrity(encrypted []byte, secretKey []byte, salt []byte, encryptedHmacKey []byte, encryptedHmacValue []byte, newHash func() hash.Hash) error {
+	hmacKey, err := decryptCBC(secretKey, agileIV(newHash(), salt, blockKeyHmacKey), encryptedHmacKey)
+	if err != nil {
+		return err
+	}
+
+	hmacValue, err := decryptCBC(secretKey, agileIV(newHash(), salt, blockKeyHmacValue), encryptedHmacValue)
+	if err != nil {
+		return err
+	}
+
+	size := newHash().Size()
+	if len(hmacKey) < size || len(hmacValue) < size {
+		return ErrorIntegrityFailure
+	}
+
+	mac := hmac.New(newHash, hmacKey[:size])
+	mac.Write(encrypted)
+	if !hmac.Equal(mac.Sum(nil), hmacValue[:size]) {
+		return ErrorIntegrityFailure
+	}
+
+	return nil
+}
+
+//decryptStandard decrypts package that was encrypted with standard encryption
+func decryptStandard(info []byte, encrypted []byte, password string) ([]byte, error) {
+	le := binary.LittleEndian
+	if len(info) < 4 {
+		return nil, errors.New("invalid encryption info")
+	}
+
+	size := int(le.Uint32(info))
+	if size < 32 || len(info) < 4+size+4+16+16+4+32 {
+		return nil, errors.New("invalid encryption info")
+	}
+
+	header, verifier := info[4:4+size], info[4+size:]
+	keySize := int(le.Uint32(header[16:])) / 8
+
+	switch le.Uint32(header[8:]) {
+	case 0x660E, 0x660F, 0x6610:
+	default:
+		return nil, errors.New("unsupported cipher for standard encryption")
+	}
+
+	if keySize == 0 {
+		keySize = 16
+	}
+
+	if le.Uint32(verifier) != 16 || keySize > 2*sha1.Size {
+		return nil, errors.New("invalid encryption verifier")
+	}
+
+	//derive key
+	h := iterateHash(sha1.New(), password, verifier[4:20], standardSpinCount, true)
+	final := sha1.Sum(append(h, 0, 0, 0, 0))
+
+	derived := make([]byte, 0, 2*sha1.Size)
+	for _, pad := range []byte{0x36, 0x5c} {
+		b := bytes.Repeat([]byte{pad}, 64)
+		for i := range final {
+			b[i] ^= final[i]
+		}
+
+		sum := sha1.Sum(b)
+		derived = append(derived, sum[:]...)
+	}
+
+	block, err := aes.NewCipher(derived[:keySize])
+	if err != nil {
+		return nil, err
+	}
+
+	//check password with verifier
+	decryptedVerifier := decryptECB(block, verifier[20:36])
+	verifierHash := decryptECB(block, verifier[40:72])
+	sum := sha1.Sum(decryptedVerifier)
+	if !hmac.Equal(sum[:], verifierHash[:sha1.Size]) {
+		return nil, ErrorInvalidPassword
+	}
+
+	total := le.Uint64(encrypted)
+	data := encrypted[8:]
+	data = data[:len(data)-len(data)%aes.BlockSize]
+	if uint64(len(data)) < total {
+		return nil, errors.New("encrypted package is truncated")
+	}
+
+	return decryptECB(block, data)[:total], nil
+}
+
+//cryptSegments encrypts or decrypts package with secret key by segments of 4096 bytes, encrypted package is prefixed by size of original package
+func cryptSegments(data []byte, secretKey []byte, salt []byte, newHash func() hash.Hash, encrypt bool) ([]byte, error) {
+	var result, source []byte
+	var total uint64
+
+	if encrypt {
+		total = uint64(len(data))
+		result = make([]byte, 8, 8+len(data)+aes.BlockSize)
+		binary.LittleEndian.PutUint64(result, total)
+		source = data
+	} else {
+		total = binary.LittleEndian.Uint64(data)
+		source = data[8:]
+	}
+
+	segment := make([]byte, 4)
+	for i := 0; i*segmentSize < len(source); i++ {
+		chunk := source[i*segmentSize:]
+		if len(chunk) > segmentSize {
+			chunk = chunk[:segmentSize]
+		}
+
+		binary.LittleEndian.PutUint32(segment, uint32(i))
+		iv := agileIV(newHash(), salt, segment)
+
+		var b []byte
+		var err error
+		if encrypt {
+			b, err = encryptCBC(secretKey, iv, chunk)
+		} else {
+			b, err = decryptCBC(secretKey, iv, chunk[:len(chunk)-len(chunk)%aes.BlockSize])
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, b...)
+	}
+
+	if !encrypt {
+		if uint64(len(result)) < total {
+			return nil, errors.New("encrypted package is truncated")
+		}
+
+		result = result[:total]
+	}
+
+	return result, nil
+}
+
+//agileKey derives key from hash of password and block key
+func agileKey(h hash.Hash, passwordHash []byte, blockKey []byte, size int) []byte {
+	h.Write(passwordHash)
+	h.Write(blockKey)
+	return fitSize(h.Sum(nil), size, 0x36)
+}
+
+//agileIV derives initialization vector from salt and block key
+func agileIV(h hash.Hash, salt []byte, blockKey []byte) []byte {
+	h.Write(salt)
+	h.Write(blockKey)
+	return fitSize(h.Sum(nil), aes.BlockSize, 0x36)
+}
+
+//fitSize truncates or pads data to required size
+func fitSize(b []byte, size int, pad byte) []byte {
+	if len(b) >= size {
+		return b[:size]
+	}
+
+	return append(append([]byte{}, b...), bytes.Repeat([]byte{pad}, size-len(b))...)
+}
+
+//hashFunction returns constructor of hash for name of hash algorithm
+func hashFunction(name string) (func() hash.Hash, error) {
+	switch name {
+	case "SHA1":
+		return sha1.New, nil
+	case "SHA256":
+		return sha256.New, nil
+	case "SHA384":
+		return sha512.New384, nil
+	case "SHA512":
+		return sha512.New, nil
+	}
+
+	return nil, errors.New(fmt.Sprintf("unsupported hash algorithm = %s", name))
+}
+
+//encryptCBC encrypts data padded with zeros to size of block with AES in CBC mode
+func encryptCBC(key, iv, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, (len(data)+aes.BlockSize-1)/aes.BlockSize*aes.BlockSize)
+	copy(b, data)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(b, b)
+	return b, nil
+}
+
+//decryptCBC decrypts data with AES in CBC mode
+func decryptCBC(key, iv, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data)%aes.BlockSize != 0 {
+		return nil, errors.New("encrypted data is not aligned to size of block")
+	}
+
+	b := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(b, data)
+	return b, nil
+}
+
+//decryptECB decrypts data with AES in ECB mode
+func decryptECB(block cipher.Block, data []byte) []byte {
+	b := make([]byte, len(data))
+	for i := 0; i+aes.BlockSize <= len(data); i += aes.BlockSize {
+		block.Decrypt(b[i:i+aes.BlockSize], data[i:i+aes.BlockSize])
+	}
+
+	return b
+}
+
+//randomBytes returns slice of random bytes with size
+func randomBytes(size int) ([]byte, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
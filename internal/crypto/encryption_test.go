@@ -0,0 +1,157 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/xml"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAgileEncryption(t *testing.T) {
+	for _, pkg := range [][]byte{
+		[]byte("PK small package"),
+		bytes.Repeat([]byte("PK package with few segments "), 1000),
+		bytes.Repeat([]byte{1}, segmentSize),
+	} {
+		info, encrypted, err := EncryptPackage(pkg, "secret")
+		require.Nil(t, err)
+		require.Equal(t, []byte{0x04, 0x00, 0x04, 0x00, 0x40, 0x00, 0x00, 0x00}, info[:8])
+		require.Equal(t, uint64(len(pkg)), binary.LittleEndian.Uint64(encrypted))
+		require.Equal(t, 0, (len(encrypted)-8)%aes.BlockSize)
+		require.Equal(t, false, bytes.Contains(encrypted, pkg))
+
+		var encryption ml.Encryption
+		require.Nil(t, xml.Unmarshal(info[8:], &encryption))
+		require.Equal(t, "SHA512", encryption.KeyData.HashAlgorithm)
+		require.Equal(t, 256, encryption.KeyData.KeyBits)
+		require.NotNil(t, encryption.DataIntegrity)
+		require.Equal(t, 1, len(encryption.KeyEncryptors))
+		require.Equal(t, SpinCount, encryption.KeyEncryptors[0].EncryptedKey.SpinCount)
+
+		decrypted, err := DecryptPackage(info, encrypted, "secret")
+		require.Nil(t, err)
+		require.Equal(t, pkg, decrypted)
+
+		_, err = DecryptPackage(info, encrypted, "wrong")
+		require.Equal(t, ErrorInvalidPassword, err)
+	}
+
+	_, err := DecryptPackage([]byte{0x01, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00}, make([]byte, 16), "secret")
+	require.NotNil(t, err)
+}
+
+func TestAgileEncryption_malformed(t *testing.T) {
+	pkg := bytes.Repeat([]byte("PK package "), 1000)
+	info, encrypted, err := EncryptPackage(pkg, "secret")
+	require.Nil(t, err)
+
+	//tampered encrypted package
+	tampered := append([]byte{}, encrypted...)
+	tampered[len(tampered)-1] ^= 0xff
+	_, err = DecryptPackage(info, tampered, "secret")
+	require.Equal(t, ErrorIntegrityFailure, err)
+
+	//truncated encrypted package
+	_, err = DecryptPackage(info, encrypted[:len(encrypted)-aes.BlockSize], "secret")
+	require.Equal(t, ErrorIntegrityFailure, err)
+
+	_, err = DecryptPackage(info, encrypted[:4], "secret")
+	require.NotNil(t, err)
+
+	//malformed encryption info
+	var encryption ml.Encryption
+	require.Nil(t, xml.Unmarshal(info[8:], &encryption))
+
+	for _, update := range []func(e *ml.Encryption){
+		func(e *ml.Encryption) { e.KeyEncryptors[0].EncryptedKey.SaltSize = -1 },
+		func(e *ml.Encryption) { e.KeyEncryptors[0].EncryptedKey.SpinCount = MaxSpinCount + 1 },
+		func(e *ml.Encryption) { e.KeyEncryptors[0].EncryptedKey.KeyBits = -8 },
+		func(e *ml.Encryption) { e.KeyData.KeyBits = 0 },
+		func(e *ml.Encryption) { e.KeyData.HashAlgorithm = "MD5" },
+		func(e *ml.Encryption) { e.DataIntegrity.EncryptedHmacValue = "AAAA" },
+		func(e *ml.Encryption) { e.DataIntegrity.EncryptedHmacKey = "?" },
+	} {
+		malformed := encryption
+		encryptedKey := *encryption.KeyEncryptors[0].EncryptedKey
+		dataIntegrity := *encryption.DataIntegrity
+		malformed.KeyEncryptors = []*ml.KeyEncryptor{{URI: encryption.KeyEncryptors[0].URI, EncryptedKey: &encryptedKey}}
+		malformed.DataIntegrity = &dataIntegrity
+		update(&malformed)
+
+		descriptor, err := xml.Marshal(&malformed)
+		require.Nil(t, err)
+
+		require.NotPanics(t, func() {
+			_, err = DecryptPackage(append(append([]byte{}, info[:8]...), descriptor...), encrypted, "secret")
+		})
+		require.NotNil(t, err)
+	}
+
+	//data integrity is optional
+	encryption.DataIntegrity = nil
+	descriptor, err := xml.Marshal(&encryption)
+	require.Nil(t, err)
+
+	decrypted, err := DecryptPackage(append(append([]byte{}, info[:8]...), descriptor...), encrypted, "secret")
+	require.Nil(t, err)
+	require.Equal(t, pkg, decrypted)
+}
+
+func TestStandardEncryption(t *testing.T) {
+	//build encryption info of standard encryption with AES-128 and encrypt package manually
+	pkg := []byte("PK package encrypted with standard encryption")
+	salt := bytes.Repeat([]byte{7}, 16)
+	verifier := bytes.Repeat([]byte{3}, 16)
+
+	h := iterateHash(sha1.New(), "secret", salt, standardSpinCount, true)
+	final := sha1.Sum(append(h, 0, 0, 0, 0))
+	b := bytes.Repeat([]byte{0x36}, 64)
+	for i := range final {
+		b[i] ^= final[i]
+	}
+
+	key := sha1.Sum(b)
+	block, err := aes.NewCipher(key[:16])
+	require.Nil(t, err)
+
+	encryptECB := func(data []byte) []byte {
+		result := make([]byte, (len(data)+aes.BlockSize-1)/aes.BlockSize*aes.BlockSize)
+		copy(result, data)
+		for i := 0; i < len(result); i += aes.BlockSize {
+			block.Encrypt(result[i:i+aes.BlockSize], result[i:i+aes.BlockSize])
+		}
+
+		return result
+	}
+
+	header := make([]byte, 32)
+	binary.LittleEndian.PutUint32(header[0:], 0x24)
+	binary.LittleEndian.PutUint32(header[8:], 0x660E)
+	binary.LittleEndian.PutUint32(header[12:], 0x8004)
+	binary.LittleEndian.PutUint32(header[16:], 128)
+	binary.LittleEndian.PutUint32(header[20:], 0x18)
+
+	verifierHash := sha1.Sum(verifier)
+	info := []byte{0x03, 0x00, 0x02, 0x00, 0x24, 0x00, 0x00, 0x00, 32, 0, 0, 0}
+	info = append(info, header...)
+	info = append(info, 16, 0, 0, 0)
+	info = append(info, salt...)
+	info = append(info, encryptECB(verifier)...)
+	info = append(info, 20, 0, 0, 0)
+	info = append(info, encryptECB(verifierHash[:])...)
+
+	encrypted := make([]byte, 8)
+	binary.LittleEndian.PutUint64(encrypted, uint64(len(pkg)))
+	encrypted = append(encrypted, encryptECB(pkg)...)
+
+	decrypted, err := DecryptPackage(info, encrypted, "secret")
+	require.Nil(t, err)
+	require.Equal(t, pkg, decrypted)
+
+	_, err = DecryptPackage(info, encrypted, "wrong")
+	require.Equal(t, ErrorInvalidPassword, err)
+}
@@ -0,0 +1,144 @@
+package crypto
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"strings"
+	"unicode/utf16"
+)
+
+//List of settings that are used to hash passwords
+const (
+	AlgorithmSHA512 = "SHA-512"
+	SpinCount       = 100000
+	MaxSpinCount    = 10000000 //maximal number of iterations that is allowed by MS-OFFCRYPTO
+	SaltSize        = 16
+)
+
+//PasswordHash is a hashed password with settings that are required to verify it
+type PasswordHash struct {
+	AlgorithmName string
+	HashValue     string
+	SaltValue     string
+	SpinCount     int
+}
+
+//NewPasswordHash hashes password with SHA-512 algorithm and random salt
+func NewPasswordHash(password string) (*PasswordHash, error) {
+	salt := make([]byte, SaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	return &PasswordHash{
+		AlgorithmName: AlgorithmSHA512,
+		HashValue:     HashPassword(password, salt, SpinCount),
+		SaltValue:     base64.StdEncoding.EncodeToString(salt),
+		SpinCount:     SpinCount,
+	}, nil
+}
+
+//HashPassword returns base64 encoded SHA-512 hash of password as described in ECMA-376, Part 1, 18.2.28
+func HashPassword(password string, salt []byte, spinCount int) string {
+	return base64.StdEncoding.EncodeToString(iterateHash(sha512.New(), password, salt, spinCount, false))
+}
+
+//LegacyHashPassword returns hex encoded 16-bit XOR hash of password that is used by legacy password attributes as described in ECMA-376, Part 4, 14.7.1
+func LegacyHashPassword(password string) string {
+	verifier := uint16(0)
+	rotate := func(v uint16) uint16 {
+		return (v>>14)&0x01 | (v<<1)&0x7fff
+	}
+
+	runes := []rune(password)
+	for i := len(runes) - 1; i >= 0; i-- {
+		verifier = rotate(verifier) ^ uint16(byte(runes[i]))
+	}
+
+	verifier = rotate(verifier) ^ uint16(len(runes)) ^ 0xCE4B
+	return fmt.Sprintf("%04X", verifier)
+}
+
+//newHash returns hash for name of algorithm or nil if algorithm is not supported
+func newHash(algorithmName string) hash.Hash {
+	switch strings.ToUpper(algorithmName) {
+	case "SHA-512":
+		return sha512.New()
+	case "SHA-384":
+		return sha512.New384()
+	case "SHA-256":
+		return sha256.New()
+	case "SHA-1":
+		return sha1.New()
+	case "MD5":
+		return md5.New()
+	}
+
+	return nil
+}
+
+//VerifyPassword returns true if password matches hash. Legacy hash is used if there is no name of algorithm
+func VerifyPassword(password string, h *PasswordHash, legacyHash string) bool {
+	if h == nil || len(h.AlgorithmName) == 0 {
+		if len(legacyHash) == 0 {
+			return len(password) == 0
+		}
+
+		return len(password) > 0 && strings.EqualFold(LegacyHashPassword(password), legacyHash)
+	}
+
+	algorithm := newHash(h.AlgorithmName)
+	if algorithm == nil {
+		return false
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(h.SaltValue)
+	if err != nil || h.SpinCount > MaxSpinCount {
+		return false
+	}
+
+	return base64.StdEncoding.EncodeToString(iterateHash(algorithm, password, salt, h.SpinCount, false)) == h.HashValue
+}
+
+//iterateHash returns hash of salted password that was rehashed spinCount times. Each iteration hashes previous hash with 32-bit iterator, that goes before or after hash
+func iterateHash(h hash.Hash, password string, salt []byte, spinCount int, iteratorFirst bool) []byte {
+	h.Write(salt)
+	h.Write(utf16le(password))
+	result := h.Sum(nil)
+
+	iterator := make([]byte, 4)
+	for i := 0; i < spinCount; i++ {
+		binary.LittleEndian.PutUint32(iterator, uint32(i))
+
+		h.Reset()
+		if iteratorFirst {
+			h.Write(iterator)
+			h.Write(result)
+		} else {
+			h.Write(result)
+			h.Write(iterator)
+		}
+
+		result = h.Sum(result[:0])
+	}
+
+	return result
+}
+
+//utf16le returns UTF-16LE encoded bytes of string
+func utf16le(s string) []byte {
+	encoded := utf16.Encode([]rune(s))
+	b := make([]byte, len(encoded)*2)
+	for i, c := range encoded {
+		binary.LittleEndian.PutUint16(b[i*2:], c)
+	}
+
+	return b
+}
@@ -0,0 +1,55 @@
+package crypto_test
+
+import (
+	"encoding/base64"
+	"github.com/plandem/xlsx/internal/crypto"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestHashPassword(t *testing.T) {
+	salt := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	require.Equal(t, "M5SOVnbQG4SHyBnRVAYzAx8mPtxyyzMuWxcMv7tkyFO3MBXX9OJjklwPglNHdoHVkKPm4MPfUblqHmAsXfF5HA==", crypto.HashPassword("secret", salt, crypto.SpinCount))
+
+	hash, err := crypto.NewPasswordHash("secret")
+	require.Nil(t, err)
+	require.Equal(t, crypto.AlgorithmSHA512, hash.AlgorithmName)
+	require.Equal(t, crypto.SpinCount, hash.SpinCount)
+
+	salt, err = base64.StdEncoding.DecodeString(hash.SaltValue)
+	require.Nil(t, err)
+	require.Len(t, salt, crypto.SaltSize)
+	require.Equal(t, crypto.HashPassword("secret", salt, crypto.SpinCount), hash.HashValue)
+}
+
+func TestVerifyPassword(t *testing.T) {
+	require.Equal(t, "CBEB", crypto.LegacyHashPassword("test"))
+	require.Equal(t, true, crypto.VerifyPassword("test", nil, "cbeb"))
+	require.Equal(t, false, crypto.VerifyPassword("", nil, "CBEB"))
+	require.Equal(t, true, crypto.VerifyPassword("", nil, ""))
+	require.Equal(t, false, crypto.VerifyPassword("test", nil, ""))
+
+	hash, err := crypto.NewPasswordHash("secret")
+	require.Nil(t, err)
+	require.Equal(t, true, crypto.VerifyPassword("secret", hash, ""))
+	require.Equal(t, false, crypto.VerifyPassword("secrets", hash, ""))
+
+	hash.AlgorithmName = "RIPEMD-128"
+	require.Equal(t, false, crypto.VerifyPassword("secret", hash, ""))
+
+	salt := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	require.Equal(t, true, crypto.VerifyPassword("secret", &crypto.PasswordHash{
+		AlgorithmName: crypto.AlgorithmSHA512,
+		HashValue:     "M5SOVnbQG4SHyBnRVAYzAx8mPtxyyzMuWxcMv7tkyFO3MBXX9OJjklwPglNHdoHVkKPm4MPfUblqHmAsXfF5HA==",
+		SaltValue:     base64.StdEncoding.EncodeToString(salt),
+		SpinCount:     crypto.SpinCount,
+	}, ""))
+
+	//spin count is limited
+	require.Equal(t, false, crypto.VerifyPassword("secret", &crypto.PasswordHash{
+		AlgorithmName: crypto.AlgorithmSHA512,
+		HashValue:     "M5SOVnbQG4SHyBnRVAYzAx8mPtxyyzMuWxcMv7tkyFO3MBXX9OJjklwPglNHdoHVkKPm4MPfUblqHmAsXfF5HA==",
+		SaltValue:     base64.StdEncoding.EncodeToString(salt),
+		SpinCount:     crypto.MaxSpinCount + 1,
+	}, ""))
+}
@@ -6,14 +6,63 @@ import (
 
 //List of all supported RelationType and ContentType
 const (
-	RelationTypeWorkbook      ml.RelationType = ml.NamespaceRelationships + "/officeDocument"
-	RelationTypeSharedStrings ml.RelationType = ml.NamespaceRelationships + "/sharedStrings"
-	RelationTypeWorksheet     ml.RelationType = ml.NamespaceRelationships + "/worksheet"
-	RelationTypeStyles        ml.RelationType = ml.NamespaceRelationships + "/styles"
-	RelationTypeHyperlink     ml.RelationType = ml.NamespaceRelationships + "/hyperlink"
+	RelationTypeWorkbook         ml.RelationType = ml.NamespaceRelationships + "/officeDocument"
+	RelationTypeSharedStrings    ml.RelationType = ml.NamespaceRelationships + "/sharedStrings"
+	RelationTypeWorksheet        ml.RelationType = ml.NamespaceRelationships + "/worksheet"
+	RelationTypeStyles           ml.RelationType = ml.NamespaceRelationships + "/styles"
+	RelationTypeHyperlink        ml.RelationType = ml.NamespaceRelationships + "/hyperlink"
+	RelationTypeComments         ml.RelationType = ml.NamespaceRelationships + "/comments"
+	RelationTypeVmlDrawing       ml.RelationType = ml.NamespaceRelationships + "/vmlDrawing"
+	RelationTypeDrawing          ml.RelationType = ml.NamespaceRelationships + "/drawing"
+	RelationTypeImage            ml.RelationType = ml.NamespaceRelationships + "/image"
+	RelationTypeChart            ml.RelationType = ml.NamespaceRelationships + "/chart"
+	RelationTypeTable            ml.RelationType = ml.NamespaceRelationships + "/table"
+	RelationTypePivotTable       ml.RelationType = ml.NamespaceRelationships + "/pivotTable"
+	RelationTypePivotCache       ml.RelationType = ml.NamespaceRelationships + "/pivotCacheDefinition"
+	RelationTypeTheme            ml.RelationType = ml.NamespaceRelationships + "/theme"
+	RelationTypeCoreProps        ml.RelationType = "http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties"
+	RelationTypeExtendedProps    ml.RelationType = ml.NamespaceRelationships + "/extended-properties"
+	RelationTypeCustomProps      ml.RelationType = ml.NamespaceRelationships + "/custom-properties"
+	RelationTypeVBAProject       ml.RelationType = "http://schemas.microsoft.com/office/2006/relationships/vbaProject"
+	RelationTypeExternalLink     ml.RelationType = ml.NamespaceRelationships + "/externalLink"
+	RelationTypeExternalPath     ml.RelationType = ml.NamespaceRelationships + "/externalLinkPath"
+	RelationTypeCustomXML        ml.RelationType = ml.NamespaceRelationships + "/customXml"
+	RelationTypeCustomXMLProp    ml.RelationType = ml.NamespaceRelationships + "/customXmlProps"
+	RelationTypeControlProp      ml.RelationType = ml.NamespaceRelationships + "/ctrlProp"
+	RelationTypeSlicer           ml.RelationType = "http://schemas.microsoft.com/office/2007/relationships/slicer"
+	RelationTypeSlicerCache      ml.RelationType = "http://schemas.microsoft.com/office/2007/relationships/slicerCache"
+	RelationTypeOleObject        ml.RelationType = ml.NamespaceRelationships + "/oleObject"
+	RelationTypePackage          ml.RelationType = ml.NamespaceRelationships + "/package"
+	RelationTypeThreadedComments ml.RelationType = "http://schemas.microsoft.com/office/2017/10/relationships/threadedComment"
+	RelationTypePerson           ml.RelationType = "http://schemas.microsoft.com/office/2017/10/relationships/person"
 
-	ContentTypeWorkbook      ml.ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"
-	ContentTypeSharedStrings ml.ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"
-	ContentTypeWorksheet     ml.ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"
-	ContentTypeStyles        ml.ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"
+	ContentTypeWorkbook         ml.ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"
+	ContentTypeWorkbookMacro    ml.ContentType = "application/vnd.ms-excel.sheet.macroEnabled.main+xml"
+	ContentTypeSharedStrings    ml.ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"
+	ContentTypeWorksheet        ml.ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"
+	ContentTypeStyles           ml.ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"
+	ContentTypeComments         ml.ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.comments+xml"
+	ContentTypeVmlDrawing       ml.ContentType = "application/vnd.openxmlformats-officedocument.vmlDrawing"
+	ContentTypeDrawing          ml.ContentType = "application/vnd.openxmlformats-officedocument.drawing+xml"
+	ContentTypeChart            ml.ContentType = "application/vnd.openxmlformats-officedocument.drawingml.chart+xml"
+	ContentTypeTable            ml.ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.table+xml"
+	ContentTypePivotTable       ml.ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotTable+xml"
+	ContentTypePivotCache       ml.ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotCacheDefinition+xml"
+	ContentTypeTheme            ml.ContentType = "application/vnd.openxmlformats-officedocument.theme+xml"
+	ContentTypeCoreProps        ml.ContentType = "application/vnd.openxmlformats-package.core-properties+xml"
+	ContentTypeExtendedProps    ml.ContentType = "application/vnd.openxmlformats-officedocument.extended-properties+xml"
+	ContentTypeCustomProps      ml.ContentType = "application/vnd.openxmlformats-officedocument.custom-properties+xml"
+	ContentTypeVBAProject       ml.ContentType = "application/vnd.ms-office.vbaProject"
+	ContentTypeExternalLink     ml.ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.externalLink+xml"
+	ContentTypeCustomXML        ml.ContentType = "application/xml"
+	ContentTypeCustomXMLProp    ml.ContentType = "application/vnd.openxmlformats-officedocument.customXmlProperties+xml"
+	ContentTypeControlProp      ml.ContentType = "application/vnd.ms-excel.controlproperties+xml"
+	ContentTypeSlicer           ml.ContentType = "application/vnd.ms-excel.slicer+xml"
+	ContentTypeSlicerCache      ml.ContentType = "application/vnd.ms-excel.slicerCache+xml"
+	ContentTypeOleObject        ml.ContentType = "application/vnd.openxmlformats-officedocument.oleObject"
+	ContentTypeThreadedComments ml.ContentType = "application/vnd.ms-excel.threadedcomments+xml"
+	ContentTypePerson           ml.ContentType = "application/vnd.ms-excel.person+xml"
+	ContentTypePng              ml.ContentType = "image/png"
+	ContentTypeJpeg             ml.ContentType = "image/jpeg"
+	ContentTypeGif              ml.ContentType = "image/gif"
 )
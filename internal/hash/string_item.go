@@ -2,6 +2,7 @@ package hash
 
 import (
 	"github.com/plandem/xlsx/internal/ml"
+	"strconv"
 	"strings"
 )
 
@@ -13,12 +14,15 @@ func StringItem(si *ml.StringItem) Key {
 
 	result := []string{
 		string(si.Text),
-		string(Reserved(si.PhoneticPr)),
+	}
+
+	if si.PhoneticPr != nil {
+		result = append(result, strconv.Itoa(si.PhoneticPr.FontID), si.PhoneticPr.Type, si.PhoneticPr.Alignment)
 	}
 
 	if si.RPh != nil {
 		for _, r := range *si.RPh {
-			result = append(result, string(Reserved(r)))
+			result = append(result, string(r.Text), strconv.Itoa(r.Start), strconv.Itoa(r.End))
 		}
 	}
 
@@ -24,12 +24,30 @@ const ExcelHeaderFooterLimit = 255
 //Total number of hyperlinks in a worksheet
 const ExcelHyperlinkLimit = 66530
 
+//Total number of characters that a list of values for data validation can contain
+const ExcelValidationListLimit = 255
+
+//Total number of characters that a title of input or error message for data validation can contain
+const ExcelValidationTitleLimit = 32
+
+//Total number of characters that an input or error message for data validation can contain
+const ExcelValidationMessageLimit = 255
+
 //Total number of characters that a sheet name can contain
 const ExcelSheetNameLimit = 31
 
+//Total number of characters that a defined name can contain
+const ExcelDefinedNameLimit = 255
+
 //Total number of characters that a cell formula can contain
 const ExcelFormulaLimit = 255
 
+//Total number of unique cell formats/cell styles in a workbook
+const ExcelStyleLimit = 64000
+
+//Total number of custom number formats in a workbook, depending on the language version
+const ExcelNumberFormatLimit = 250
+
 //Total number of characters that an url can contain
 // https://stackoverflow.com/questions/417142/what-is-the-maximum-length-of-a-url-in-different-browsers
 const UrlLimit = 2000
@@ -0,0 +1,185 @@
+package ml
+
+import (
+	"github.com/plandem/ooxml/ml"
+)
+
+//ChartSpace is a direct mapping of XSD CT_ChartSpace
+type ChartSpace struct {
+	XMLName         ml.Name      `xml:"http://schemas.openxmlformats.org/drawingml/2006/chart chartSpace"`
+	RIDName         ml.RIDName   `xml:",attr"`
+	Date1904        *ChartBool   `xml:"date1904,omitempty"`
+	Lang            *ChartString `xml:"lang,omitempty"`
+	RoundedCorners  *ChartBool   `xml:"roundedCorners,omitempty"`
+	Style           *ChartInt    `xml:"style,omitempty"`
+	Chart           Chart        `xml:"chart"`
+	ShapeProperties *ml.Reserved `xml:"spPr,omitempty"`
+	TextProperties  *ml.Reserved `xml:"txPr,omitempty"`
+	ExternalData    *ml.Reserved `xml:"externalData,omitempty"`
+	PrintSettings   *ml.Reserved `xml:"printSettings,omitempty"`
+	UserShapes      *ml.Reserved `xml:"userShapes,omitempty"`
+	ExtLst          *ml.Reserved `xml:"extLst,omitempty"`
+}
+
+//Chart is a direct mapping of XSD CT_Chart
+type Chart struct {
+	Title            *ChartTitle  `xml:"title,omitempty"`
+	AutoTitleDeleted *ChartBool   `xml:"autoTitleDeleted,omitempty"`
+	PlotArea         PlotArea     `xml:"plotArea"`
+	Legend           *ChartLegend `xml:"legend,omitempty"`
+	PlotVisOnly      *ChartBool   `xml:"plotVisOnly,omitempty"`
+	DisplayBlanksAs  *ChartString `xml:"dispBlanksAs,omitempty"`
+	ExtLst           *ml.Reserved `xml:"extLst,omitempty"`
+}
+
+//ChartTitle is a direct mapping of XSD CT_Title
+type ChartTitle struct {
+	Text    *ChartText   `xml:"tx,omitempty"`
+	Layout  *ml.Reserved `xml:"layout,omitempty"`
+	Overlay *ChartBool   `xml:"overlay,omitempty"`
+}
+
+//ChartText is a direct mapping of XSD CT_Tx
+type ChartText struct {
+	Rich *ChartRichText `xml:"rich,omitempty"`
+}
+
+//ChartRichText is a direct mapping of XSD CT_TextBody
+type ChartRichText struct {
+	BodyProperties ml.Reserved      `xml:"http://schemas.openxmlformats.org/drawingml/2006/main bodyPr"`
+	Paragraphs     []*TextParagraph `xml:"http://schemas.openxmlformats.org/drawingml/2006/main p"`
+}
+
+//TextParagraph is a direct mapping of XSD CT_TextParagraph
+type TextParagraph struct {
+	Runs []*TextRun `xml:"http://schemas.openxmlformats.org/drawingml/2006/main r"`
+}
+
+//TextRun is a direct mapping of XSD CT_RegularTextRun
+type TextRun struct {
+	Text string `xml:"http://schemas.openxmlformats.org/drawingml/2006/main t"`
+}
+
+//PlotArea is a direct mapping of XSD CT_PlotArea
+type PlotArea struct {
+	Layout        *ml.Reserved    `xml:"layout,omitempty"`
+	BarCharts     []*BarChart     `xml:"barChart,omitempty"`
+	LineCharts    []*LineChart    `xml:"lineChart,omitempty"`
+	PieCharts     []*PieChart     `xml:"pieChart,omitempty"`
+	ScatterCharts []*ScatterChart `xml:"scatterChart,omitempty"`
+	CategoryAxes  []*ChartAxis    `xml:"catAx,omitempty"`
+	ValueAxes     []*ChartAxis    `xml:"valAx,omitempty"`
+	ExtLst        *ml.Reserved    `xml:"extLst,omitempty"`
+}
+
+//BarChart is a direct mapping of XSD CT_BarChart
+type BarChart struct {
+	BarDirection ChartString    `xml:"barDir"`
+	Grouping     *ChartString   `xml:"grouping,omitempty"`
+	VaryColors   *ChartBool     `xml:"varyColors,omitempty"`
+	Series       []*ChartSeries `xml:"ser,omitempty"`
+	GapWidth     *ChartInt      `xml:"gapWidth,omitempty"`
+	Overlap      *ChartInt      `xml:"overlap,omitempty"`
+	AxisIDs      []*ChartInt    `xml:"axId"`
+}
+
+//LineChart is a direct mapping of XSD CT_LineChart
+type LineChart struct {
+	Grouping   ChartString    `xml:"grouping"`
+	VaryColors *ChartBool     `xml:"varyColors,omitempty"`
+	Series     []*ChartSeries `xml:"ser,omitempty"`
+	Marker     *ChartBool     `xml:"marker,omitempty"`
+	AxisIDs    []*ChartInt    `xml:"axId"`
+}
+
+//PieChart is a direct mapping of XSD CT_PieChart
+type PieChart struct {
+	VaryColors      *ChartBool     `xml:"varyColors,omitempty"`
+	Series          []*ChartSeries `xml:"ser,omitempty"`
+	FirstSliceAngle *ChartInt      `xml:"firstSliceAng,omitempty"`
+}
+
+//ScatterChart is a direct mapping of XSD CT_ScatterChart
+type ScatterChart struct {
+	ScatterStyle ChartString    `xml:"scatterStyle"`
+	VaryColors   *ChartBool     `xml:"varyColors,omitempty"`
+	Series       []*ChartSeries `xml:"ser,omitempty"`
+	AxisIDs      []*ChartInt    `xml:"axId"`
+}
+
+//ChartSeries is a mapping of XSD CT_BarSer, CT_LineSer, CT_PieSer and CT_ScatterSer that covers all required elements of these types
+type ChartSeries struct {
+	Index      ChartInt           `xml:"idx"`
+	Order      ChartInt           `xml:"order"`
+	Text       *ChartSeriesText   `xml:"tx,omitempty"`
+	Marker     *ml.Reserved       `xml:"marker,omitempty"`
+	Categories *ChartDataSource   `xml:"cat,omitempty"`
+	Values     *ChartNumberSource `xml:"val,omitempty"`
+	XValues    *ChartDataSource   `xml:"xVal,omitempty"`
+	YValues    *ChartNumberSource `xml:"yVal,omitempty"`
+	Smooth     *ChartBool         `xml:"smooth,omitempty"`
+}
+
+//ChartSeriesText is a direct mapping of XSD CT_SerTx
+type ChartSeriesText struct {
+	StringRef *ChartFormula `xml:"strRef,omitempty"`
+	Value     string        `xml:"v,omitempty"`
+}
+
+//ChartDataSource is a direct mapping of XSD CT_AxDataSource
+type ChartDataSource struct {
+	NumberRef *ChartFormula `xml:"numRef,omitempty"`
+	StringRef *ChartFormula `xml:"strRef,omitempty"`
+}
+
+//ChartNumberSource is a direct mapping of XSD CT_NumDataSource
+type ChartNumberSource struct {
+	NumberRef *ChartFormula `xml:"numRef,omitempty"`
+}
+
+//ChartFormula is a mapping of XSD CT_NumRef and CT_StrRef without cached values
+type ChartFormula struct {
+	Formula string `xml:"f"`
+}
+
+//ChartAxis is a mapping of XSD CT_CatAx and CT_ValAx that covers all required elements of these types
+type ChartAxis struct {
+	AxisID         ChartInt     `xml:"axId"`
+	Scaling        ChartScaling `xml:"scaling"`
+	Delete         *ChartBool   `xml:"delete,omitempty"`
+	AxisPosition   ChartString  `xml:"axPos"`
+	MajorGridlines *ml.Reserved `xml:"majorGridlines,omitempty"`
+	TickLabelPos   *ChartString `xml:"tickLblPos,omitempty"`
+	CrossAxisID    ChartInt     `xml:"crossAx"`
+	Crosses        *ChartString `xml:"crosses,omitempty"`
+	Auto           *ChartBool   `xml:"auto,omitempty"`
+	LabelAlign     *ChartString `xml:"lblAlgn,omitempty"`
+	LabelOffset    *ChartInt    `xml:"lblOffset,omitempty"`
+	CrossBetween   *ChartString `xml:"crossBetween,omitempty"`
+}
+
+//ChartScaling is a direct mapping of XSD CT_Scaling
+type ChartScaling struct {
+	Orientation *ChartString `xml:"orientation,omitempty"`
+}
+
+//ChartLegend is a direct mapping of XSD CT_Legend
+type ChartLegend struct {
+	Position *ChartString `xml:"legendPos,omitempty"`
+	Overlay  *ChartBool   `xml:"overlay,omitempty"`
+}
+
+//ChartBool is a direct mapping of XSD CT_Boolean
+type ChartBool struct {
+	Val bool `xml:"val,attr"`
+}
+
+//ChartInt is a direct mapping of XSD CT_UnsignedInt
+type ChartInt struct {
+	Val int `xml:"val,attr"`
+}
+
+//ChartString is a mapping of XSD simple types with 'val' attribute, e.g. CT_BarDir, CT_LegendPos and etc
+type ChartString struct {
+	Val string `xml:"val,attr"`
+}
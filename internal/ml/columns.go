@@ -10,10 +10,11 @@ type ColList struct {
 	Items []*Col `xml:"col,omitempty"`
 }
 
-func (cols *ColList) pack() {
+//Pack normalizes columns into sorted and non-intersected ranges of columns with same settings. Non-grouped columns have priority over grouped columns with same indexes
+func (cols *ColList) Pack() {
 	//moving grouped column ahead
 	packed := cols.Items
-	sort.Slice(packed, func(i, j int) bool { return packed[i].Min != packed[i].Max })
+	sort.SliceStable(packed, func(i, j int) bool { return packed[i].Min != packed[i].Max && packed[j].Min == packed[j].Max })
 
 	//unpack columns
 	unpacked := make(map[int]*Col, len(packed))
@@ -80,7 +81,7 @@ func (cols *ColList) pack() {
 
 func (cols *ColList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	//after using columns manager ColList can contain mix of grouped/non-grouped columns with intersection of indexes
-	cols.pack()
+	cols.Pack()
 
 	if len(cols.Items) > 0 {
 		return e.EncodeElement(*cols, start)
@@ -0,0 +1,69 @@
+package ml
+
+import (
+	"github.com/plandem/ooxml/ml"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+//Comments is a direct mapping of XSD CT_Comments
+type Comments struct {
+	XMLName     ml.Name      `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main comments"`
+	Authors     []string     `xml:"authors>author"`
+	CommentList []*Comment   `xml:"commentList>comment"`
+	ExtLst      *ml.Reserved `xml:"extLst,omitempty"`
+}
+
+//Comment is a direct mapping of XSD CT_Comment
+type Comment struct {
+	Text      *StringItem        `xml:"text"`
+	CommentPr *ml.Reserved       `xml:"commentPr,omitempty"`
+	Ref       primitives.CellRef `xml:"ref,attr"`
+	AuthorID  int                `xml:"authorId,attr"`
+	Guid      string             `xml:"guid,attr,omitempty"`
+	ShapeID   int                `xml:"shapeId,attr,omitempty"`
+}
+
+//VmlDrawing is a legacy drawing that is used for shapes of comments. VML is not part of OOXML, so content is kept as is
+type VmlDrawing struct {
+	XMLName  ml.Name `xml:"xml"`
+	XmlnsV   string  `xml:"xmlns:v,attr"`
+	XmlnsO   string  `xml:"xmlns:o,attr"`
+	XmlnsX   string  `xml:"xmlns:x,attr"`
+	InnerXML string  `xml:",innerxml"`
+}
+
+//ThreadedComments is a direct mapping of XSD CT_ThreadedComments
+type ThreadedComments struct {
+	XMLName ml.Name            `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments ThreadedComments"`
+	Items   []*ThreadedComment `xml:"threadedComment"`
+	ExtLst  *ml.Reserved       `xml:"extLst,omitempty"`
+}
+
+//ThreadedComment is a direct mapping of XSD CT_ThreadedComment
+type ThreadedComment struct {
+	Text     string             `xml:"text"`
+	Mentions *ml.Reserved       `xml:"mentions,omitempty"`
+	ExtLst   *ml.Reserved       `xml:"extLst,omitempty"`
+	Ref      primitives.CellRef `xml:"ref,attr,omitempty"`
+	DT       string             `xml:"dT,attr,omitempty"`
+	PersonID string             `xml:"personId,attr"`
+	ID       string             `xml:"id,attr"`
+	ParentID string             `xml:"parentId,attr,omitempty"`
+	Done     bool               `xml:"done,attr,omitempty"`
+}
+
+//PersonList is a direct mapping of XSD CT_PersonList
+type PersonList struct {
+	XMLName ml.Name      `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments personList"`
+	Items   []*Person    `xml:"person"`
+	ExtLst  *ml.Reserved `xml:"extLst,omitempty"`
+}
+
+//Person is a direct mapping of XSD CT_Person
+type Person struct {
+	DisplayName string       `xml:"displayName,attr"`
+	ID          string       `xml:"id,attr"`
+	UserID      string       `xml:"userId,attr,omitempty"`
+	ProviderID  string       `xml:"providerId,attr,omitempty"`
+	ExtLst      *ml.Reserved `xml:"extLst,omitempty"`
+}
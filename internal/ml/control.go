@@ -0,0 +1,23 @@
+package ml
+
+import (
+	"github.com/plandem/ooxml/ml"
+)
+
+//FormControlPr is a direct mapping of XSD x14:CT_FormControlPr
+type FormControlPr struct {
+	XMLName     ml.Name      `xml:"http://schemas.microsoft.com/office/spreadsheetml/2009/9/main formControlPr"`
+	ItemLst     *ml.Reserved `xml:"itemLst,omitempty"`
+	ExtLst      *ml.Reserved `xml:"extLst,omitempty"`
+	ObjectType  string       `xml:"objectType,attr"`          //ST_ObjectType
+	Checked     string       `xml:"checked,attr,omitempty"`   //ST_Checked
+	DropStyle   string       `xml:"dropStyle,attr,omitempty"` //ST_DropStyle
+	DropLines   uint         `xml:"dropLines,attr,omitempty"`
+	FirstButton bool         `xml:"firstButton,attr,omitempty"`
+	FmlaLink    string       `xml:"fmlaLink,attr,omitempty"`
+	FmlaRange   string       `xml:"fmlaRange,attr,omitempty"`
+	FmlaMacro   string       `xml:"fmlaMacro,attr,omitempty"`
+	LockText    bool         `xml:"lockText,attr,omitempty"`
+	NoThreeD    bool         `xml:"noThreeD,attr,omitempty"`
+	Sel         uint         `xml:"sel,attr,omitempty"`
+}
@@ -0,0 +1,12 @@
+package ml
+
+import (
+	"github.com/plandem/ooxml/ml"
+)
+
+//DatastoreItem is a direct mapping of XSD CT_DatastoreItem
+type DatastoreItem struct {
+	XMLName    ml.Name      `xml:"http://schemas.openxmlformats.org/officeDocument/2006/customXml datastoreItem"`
+	ItemID     string       `xml:"itemID,attr"`
+	SchemaRefs *ml.Reserved `xml:"schemaRefs,omitempty"`
+}
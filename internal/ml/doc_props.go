@@ -0,0 +1,161 @@
+package ml
+
+import (
+	"encoding/xml"
+	"github.com/plandem/ooxml/ml"
+)
+
+//List of namespaces that are used by document properties
+const (
+	NamespaceCoreProperties     = "http://schemas.openxmlformats.org/package/2006/metadata/core-properties"
+	NamespaceDublinCore         = "http://purl.org/dc/elements/1.1/"
+	NamespaceDublinCoreTerms    = "http://purl.org/dc/terms/"
+	NamespaceDublinCoreType     = "http://purl.org/dc/dcmitype/"
+	NamespaceXMLSchemaInstance  = "http://www.w3.org/2001/XMLSchema-instance"
+	NamespaceExtendedProperties = "http://schemas.openxmlformats.org/officeDocument/2006/extended-properties"
+	NamespaceCustomProperties   = "http://schemas.openxmlformats.org/officeDocument/2006/custom-properties"
+	NamespaceDocPropsVTypes     = "http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes"
+)
+
+//CustomPropertiesFmtID is a format ID of custom properties that is used by Office
+const CustomPropertiesFmtID = "{D5CDD505-2E9C-101B-9397-08002B2CF9AE}"
+
+//CoreProperties is a direct mapping of OPC CT_CoreProperties
+type CoreProperties struct {
+	Category       string `xml:"http://schemas.openxmlformats.org/package/2006/metadata/core-properties category,omitempty"`
+	ContentStatus  string `xml:"http://schemas.openxmlformats.org/package/2006/metadata/core-properties contentStatus,omitempty"`
+	Created        string `xml:"http://purl.org/dc/terms/ created,omitempty"`
+	Creator        string `xml:"http://purl.org/dc/elements/1.1/ creator,omitempty"`
+	Description    string `xml:"http://purl.org/dc/elements/1.1/ description,omitempty"`
+	Identifier     string `xml:"http://purl.org/dc/elements/1.1/ identifier,omitempty"`
+	Keywords       string `xml:"http://schemas.openxmlformats.org/package/2006/metadata/core-properties keywords,omitempty"`
+	Language       string `xml:"http://purl.org/dc/elements/1.1/ language,omitempty"`
+	LastModifiedBy string `xml:"http://schemas.openxmlformats.org/package/2006/metadata/core-properties lastModifiedBy,omitempty"`
+	LastPrinted    string `xml:"http://schemas.openxmlformats.org/package/2006/metadata/core-properties lastPrinted,omitempty"`
+	Modified       string `xml:"http://purl.org/dc/terms/ modified,omitempty"`
+	Revision       string `xml:"http://schemas.openxmlformats.org/package/2006/metadata/core-properties revision,omitempty"`
+	Subject        string `xml:"http://purl.org/dc/elements/1.1/ subject,omitempty"`
+	Title          string `xml:"http://purl.org/dc/elements/1.1/ title,omitempty"`
+	Version        string `xml:"http://schemas.openxmlformats.org/package/2006/metadata/core-properties version,omitempty"`
+}
+
+//w3cDate is a dcterms:W3CDTF date of core properties
+type w3cDate struct {
+	Type  string `xml:"xsi:type,attr"`
+	Value string `xml:",chardata"`
+}
+
+//newW3CDate returns a date for non empty value or nil
+func newW3CDate(value string) *w3cDate {
+	if len(value) == 0 {
+		return nil
+	}
+
+	return &w3cDate{Type: "dcterms:W3CDTF", Value: value}
+}
+
+//MarshalXML marshal CoreProperties. N.B.: dcterms:W3CDTF type of dates requires prefixes, so prefixes are written as is
+func (p *CoreProperties) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	v := struct {
+		XMLName           ml.Name  `xml:"cp:coreProperties"`
+		NamespaceCP       string   `xml:"xmlns:cp,attr"`
+		NamespaceDC       string   `xml:"xmlns:dc,attr"`
+		NamespaceDCTerms  string   `xml:"xmlns:dcterms,attr"`
+		NamespaceDCMIType string   `xml:"xmlns:dcmitype,attr"`
+		NamespaceXSI      string   `xml:"xmlns:xsi,attr"`
+		Title             string   `xml:"dc:title,omitempty"`
+		Subject           string   `xml:"dc:subject,omitempty"`
+		Creator           string   `xml:"dc:creator,omitempty"`
+		Keywords          string   `xml:"cp:keywords,omitempty"`
+		Description       string   `xml:"dc:description,omitempty"`
+		Identifier        string   `xml:"dc:identifier,omitempty"`
+		Language          string   `xml:"dc:language,omitempty"`
+		LastModifiedBy    string   `xml:"cp:lastModifiedBy,omitempty"`
+		LastPrinted       string   `xml:"cp:lastPrinted,omitempty"`
+		Revision          string   `xml:"cp:revision,omitempty"`
+		Version           string   `xml:"cp:version,omitempty"`
+		Created           *w3cDate `xml:"dcterms:created,omitempty"`
+		Modified          *w3cDate `xml:"dcterms:modified,omitempty"`
+		Category          string   `xml:"cp:category,omitempty"`
+		ContentStatus     string   `xml:"cp:contentStatus,omitempty"`
+	}{
+		NamespaceCP:       NamespaceCoreProperties,
+		NamespaceDC:       NamespaceDublinCore,
+		NamespaceDCTerms:  NamespaceDublinCoreTerms,
+		NamespaceDCMIType: NamespaceDublinCoreType,
+		NamespaceXSI:      NamespaceXMLSchemaInstance,
+		Title:             p.Title,
+		Subject:           p.Subject,
+		Creator:           p.Creator,
+		Keywords:          p.Keywords,
+		Description:       p.Description,
+		Identifier:        p.Identifier,
+		Language:          p.Language,
+		LastModifiedBy:    p.LastModifiedBy,
+		LastPrinted:       p.LastPrinted,
+		Revision:          p.Revision,
+		Version:           p.Version,
+		Created:           newW3CDate(p.Created),
+		Modified:          newW3CDate(p.Modified),
+		Category:          p.Category,
+		ContentStatus:     p.ContentStatus,
+	}
+
+	return e.Encode(&v)
+}
+
+//ExtendedProperties is a direct mapping of XSD CT_Properties of extended properties
+type ExtendedProperties struct {
+	XMLName              ml.Name      `xml:"http://schemas.openxmlformats.org/officeDocument/2006/extended-properties Properties"`
+	NamespaceVT          string       `xml:"xmlns:vt,attr"`
+	Template             string       `xml:"Template,omitempty"`
+	Manager              string       `xml:"Manager,omitempty"`
+	Company              string       `xml:"Company,omitempty"`
+	TotalTime            int          `xml:"TotalTime,omitempty"`
+	Application          string       `xml:"Application,omitempty"`
+	DocSecurity          int          `xml:"DocSecurity,omitempty"`
+	ScaleCrop            bool         `xml:"ScaleCrop,omitempty"`
+	HeadingPairs         *ml.Reserved `xml:"HeadingPairs,omitempty"`
+	TitlesOfParts        *ml.Reserved `xml:"TitlesOfParts,omitempty"`
+	LinksUpToDate        bool         `xml:"LinksUpToDate,omitempty"`
+	SharedDoc            bool         `xml:"SharedDoc,omitempty"`
+	HyperlinkBase        string       `xml:"HyperlinkBase,omitempty"`
+	HLinks               *ml.Reserved `xml:"HLinks,omitempty"`
+	HyperlinksChanged    bool         `xml:"HyperlinksChanged,omitempty"`
+	DigSig               *ml.Reserved `xml:"DigSig,omitempty"`
+	AppVersion           string       `xml:"AppVersion,omitempty"`
+	CharactersWithSpaces int          `xml:"CharactersWithSpaces,omitempty"`
+}
+
+//CustomProperties is a direct mapping of XSD CT_Properties of custom properties
+type CustomProperties struct {
+	XMLName     ml.Name           `xml:"http://schemas.openxmlformats.org/officeDocument/2006/custom-properties Properties"`
+	NamespaceVT string            `xml:"xmlns:vt,attr"`
+	Items       []*CustomProperty `xml:"property"`
+}
+
+//CustomProperty is a direct mapping of XSD CT_Property of custom properties. Only simple types of values are supported
+type CustomProperty struct {
+	FmtID      string  `xml:"fmtid,attr"`
+	PID        int     `xml:"pid,attr"`
+	Name       string  `xml:"name,attr,omitempty"`
+	LinkTarget string  `xml:"linkTarget,attr,omitempty"`
+	Value      Variant `xml:",any"`
+}
+
+//Variant is a value of custom property with type, e.g.: lpwstr, i4, r8, bool or filetime
+type Variant struct {
+	Type  string
+	Value string
+}
+
+//MarshalXML marshal Variant
+func (v *Variant) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(v.Value, xml.StartElement{Name: xml.Name{Local: "vt:" + v.Type}})
+}
+
+//UnmarshalXML unmarshal Variant
+func (v *Variant) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	v.Type = start.Name.Local
+	return d.DecodeElement(&v.Value, &start)
+}
@@ -0,0 +1,189 @@
+package ml
+
+import (
+	"github.com/plandem/ooxml/ml"
+)
+
+//SpreadsheetDrawing is a direct mapping of XSD CT_Drawing from spreadsheetDrawing namespace
+type SpreadsheetDrawing struct {
+	XMLName         ml.Name          `xml:"http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing wsDr"`
+	RIDName         ml.RIDName       `xml:",attr"`
+	TwoCellAnchors  []*TwoCellAnchor `xml:"twoCellAnchor,omitempty"`
+	OneCellAnchors  []*OneCellAnchor `xml:"oneCellAnchor,omitempty"`
+	AbsoluteAnchors []*ml.Reserved   `xml:"absoluteAnchor,omitempty"`
+}
+
+//TwoCellAnchor is a direct mapping of XSD CT_TwoCellAnchor
+type TwoCellAnchor struct {
+	From            *DrawingMarker    `xml:"from"`
+	To              *DrawingMarker    `xml:"to"`
+	Shape           *ml.Reserved      `xml:"sp,omitempty"`
+	GroupShape      *ml.Reserved      `xml:"grpSp,omitempty"`
+	GraphicFrame    *GraphicFrame     `xml:"graphicFrame,omitempty"`
+	ConnectionShape *ml.Reserved      `xml:"cxnSp,omitempty"`
+	Picture         *Picture          `xml:"pic,omitempty"`
+	ContentPart     *ml.Reserved      `xml:"contentPart,omitempty"`
+	ClientData      DrawingClientData `xml:"clientData"`
+	EditAs          string            `xml:"editAs,attr,omitempty"` //ST_EditAs
+}
+
+//OneCellAnchor is a direct mapping of XSD CT_OneCellAnchor
+type OneCellAnchor struct {
+	From            *DrawingMarker    `xml:"from"`
+	Ext             *PositiveSize2D   `xml:"ext"`
+	Shape           *ml.Reserved      `xml:"sp,omitempty"`
+	GroupShape      *ml.Reserved      `xml:"grpSp,omitempty"`
+	GraphicFrame    *GraphicFrame     `xml:"graphicFrame,omitempty"`
+	ConnectionShape *ml.Reserved      `xml:"cxnSp,omitempty"`
+	Picture         *Picture          `xml:"pic,omitempty"`
+	ContentPart     *ml.Reserved      `xml:"contentPart,omitempty"`
+	ClientData      DrawingClientData `xml:"clientData"`
+}
+
+//DrawingMarker is a direct mapping of XSD CT_Marker
+type DrawingMarker struct {
+	Col       int `xml:"col"`
+	ColOffset int `xml:"colOff"`
+	Row       int `xml:"row"`
+	RowOffset int `xml:"rowOff"`
+}
+
+//DrawingClientData is a direct mapping of XSD CT_AnchorClientData
+type DrawingClientData struct {
+	LocksWithSheet *bool `xml:"fLocksWithSheet,attr,omitempty"`
+	PrintWithSheet *bool `xml:"fPrintsWithSheet,attr,omitempty"`
+}
+
+//PositiveSize2D is a direct mapping of XSD CT_PositiveSize2D
+type PositiveSize2D struct {
+	Width  int `xml:"cx,attr"`
+	Height int `xml:"cy,attr"`
+}
+
+//Point2D is a direct mapping of XSD CT_Point2D
+type Point2D struct {
+	X int `xml:"x,attr"`
+	Y int `xml:"y,attr"`
+}
+
+//Picture is a direct mapping of XSD CT_Picture
+type Picture struct {
+	NonVisual       PictureNonVisual `xml:"nvPicPr"`
+	BlipFill        BlipFill         `xml:"blipFill"`
+	ShapeProperties ShapeProperties  `xml:"spPr"`
+	Style           *ml.Reserved     `xml:"style,omitempty"`
+	Macro           string           `xml:"macro,attr,omitempty"`
+	Published       bool             `xml:"fPublished,attr,omitempty"`
+}
+
+//GraphicFrame is a direct mapping of XSD CT_GraphicalObjectFrame
+type GraphicFrame struct {
+	NonVisual GraphicFrameNonVisual `xml:"nvGraphicFramePr"`
+	Transform Transform2D           `xml:"xfrm"`
+	Graphic   Graphic               `xml:"http://schemas.openxmlformats.org/drawingml/2006/main graphic"`
+	Macro     string                `xml:"macro,attr"`
+	Published bool                  `xml:"fPublished,attr,omitempty"`
+}
+
+//GraphicFrameNonVisual is a direct mapping of XSD CT_GraphicalObjectFrameNonVisual
+type GraphicFrameNonVisual struct {
+	DrawingProperties      NonVisualDrawingProperties `xml:"cNvPr"`
+	GraphicFrameProperties *ml.Reserved               `xml:"cNvGraphicFramePr"`
+}
+
+//Graphic is a direct mapping of XSD CT_GraphicalObject
+type Graphic struct {
+	Data GraphicData `xml:"http://schemas.openxmlformats.org/drawingml/2006/main graphicData"`
+}
+
+//GraphicData is a direct mapping of XSD CT_GraphicalObjectData
+type GraphicData struct {
+	Chart  *GraphicChart  `xml:"http://schemas.openxmlformats.org/drawingml/2006/chart chart,omitempty"`
+	Slicer *GraphicSlicer `xml:"http://schemas.microsoft.com/office/drawing/2010/slicer slicer,omitempty"`
+	Items  []*ml.Reserved `xml:",any"`
+	URI    string         `xml:"uri,attr"`
+}
+
+//GraphicChart is a direct mapping of XSD CT_RelId from chart namespace
+type GraphicChart struct {
+	RID ml.RID `xml:"id,attr"`
+}
+
+//GraphicSlicer is a direct mapping of XSD CT_Slicer from slicer namespace of drawing
+type GraphicSlicer struct {
+	Name string `xml:"name,attr"`
+}
+
+//PictureNonVisual is a direct mapping of XSD CT_PictureNonVisual
+type PictureNonVisual struct {
+	DrawingProperties NonVisualDrawingProperties `xml:"cNvPr"`
+	PictureProperties NonVisualPictureProperties `xml:"cNvPicPr"`
+}
+
+//NonVisualDrawingProperties is a direct mapping of XSD CT_NonVisualDrawingProps
+type NonVisualDrawingProperties struct {
+	HyperlinkClick *ml.Reserved `xml:"http://schemas.openxmlformats.org/drawingml/2006/main hlinkClick,omitempty"`
+	HyperlinkHover *ml.Reserved `xml:"http://schemas.openxmlformats.org/drawingml/2006/main hlinkHover,omitempty"`
+	ExtLst         *ml.Reserved `xml:"http://schemas.openxmlformats.org/drawingml/2006/main extLst,omitempty"`
+	ID             int          `xml:"id,attr"`
+	Name           string       `xml:"name,attr"`
+	Description    string       `xml:"descr,attr,omitempty"`
+	Hidden         bool         `xml:"hidden,attr,omitempty"`
+	Title          string       `xml:"title,attr,omitempty"`
+}
+
+//NonVisualPictureProperties is a direct mapping of XSD CT_NonVisualPictureProperties
+type NonVisualPictureProperties struct {
+	PictureLocks         *PictureLocks `xml:"http://schemas.openxmlformats.org/drawingml/2006/main picLocks,omitempty"`
+	ExtLst               *ml.Reserved  `xml:"http://schemas.openxmlformats.org/drawingml/2006/main extLst,omitempty"`
+	PreferRelativeResize *bool         `xml:"preferRelativeResize,attr,omitempty"`
+}
+
+//PictureLocks is a direct mapping of XSD CT_PictureLocking
+type PictureLocks struct {
+	NoChangeAspect bool `xml:"noChangeAspect,attr,omitempty"`
+}
+
+//BlipFill is a direct mapping of XSD CT_BlipFillProperties
+type BlipFill struct {
+	Blip    *Blip        `xml:"http://schemas.openxmlformats.org/drawingml/2006/main blip,omitempty"`
+	SrcRect *ml.Reserved `xml:"http://schemas.openxmlformats.org/drawingml/2006/main srcRect,omitempty"`
+	Tile    *ml.Reserved `xml:"http://schemas.openxmlformats.org/drawingml/2006/main tile,omitempty"`
+	Stretch *Stretch     `xml:"http://schemas.openxmlformats.org/drawingml/2006/main stretch,omitempty"`
+}
+
+//Blip is a direct mapping of XSD CT_Blip
+type Blip struct {
+	Embed ml.RID `xml:"embed,attr,omitempty"`
+	Link  ml.RID `xml:"link,attr,omitempty"`
+}
+
+//Stretch is a direct mapping of XSD CT_StretchInfoProperties
+type Stretch struct {
+	FillRect *ml.Reserved `xml:"fillRect"`
+}
+
+//ShapeProperties is a direct mapping of XSD CT_ShapeProperties
+type ShapeProperties struct {
+	Transform      *Transform2D    `xml:"http://schemas.openxmlformats.org/drawingml/2006/main xfrm,omitempty"`
+	PresetGeometry *PresetGeometry `xml:"http://schemas.openxmlformats.org/drawingml/2006/main prstGeom,omitempty"`
+	NoFill         *ml.Reserved    `xml:"http://schemas.openxmlformats.org/drawingml/2006/main noFill,omitempty"`
+	SolidFill      *ml.Reserved    `xml:"http://schemas.openxmlformats.org/drawingml/2006/main solidFill,omitempty"`
+	Line           *ml.Reserved    `xml:"http://schemas.openxmlformats.org/drawingml/2006/main ln,omitempty"`
+	ExtLst         *ml.Reserved    `xml:"http://schemas.openxmlformats.org/drawingml/2006/main extLst,omitempty"`
+}
+
+//Transform2D is a direct mapping of XSD CT_Transform2D
+type Transform2D struct {
+	Offset *Point2D        `xml:"http://schemas.openxmlformats.org/drawingml/2006/main off,omitempty"`
+	Ext    *PositiveSize2D `xml:"http://schemas.openxmlformats.org/drawingml/2006/main ext,omitempty"`
+	Rotate int             `xml:"rot,attr,omitempty"`
+	FlipH  bool            `xml:"flipH,attr,omitempty"`
+	FlipV  bool            `xml:"flipV,attr,omitempty"`
+}
+
+//PresetGeometry is a direct mapping of XSD CT_PresetGeometry2D
+type PresetGeometry struct {
+	AdjustValues *ml.Reserved `xml:"avLst"`
+	Preset       string       `xml:"prst,attr"` //ST_ShapeType
+}
@@ -0,0 +1,46 @@
+package ml
+
+import (
+	"github.com/plandem/ooxml/ml"
+)
+
+//Encryption is a direct mapping of XSD CT_Encryption for agile encryption
+type Encryption struct {
+	XMLName       ml.Name         `xml:"http://schemas.microsoft.com/office/2006/encryption encryption"`
+	KeyData       KeyData         `xml:"keyData"`
+	DataIntegrity *DataIntegrity  `xml:"dataIntegrity,omitempty"`
+	KeyEncryptors []*KeyEncryptor `xml:"keyEncryptors>keyEncryptor"`
+}
+
+//KeyData is a direct mapping of XSD CT_KeyData
+type KeyData struct {
+	SaltSize        int    `xml:"saltSize,attr"`
+	BlockSize       int    `xml:"blockSize,attr"`
+	KeyBits         int    `xml:"keyBits,attr"`
+	HashSize        int    `xml:"hashSize,attr"`
+	CipherAlgorithm string `xml:"cipherAlgorithm,attr"`
+	CipherChaining  string `xml:"cipherChaining,attr"`
+	HashAlgorithm   string `xml:"hashAlgorithm,attr"`
+	SaltValue       string `xml:"saltValue,attr"`
+}
+
+//DataIntegrity is a direct mapping of XSD CT_DataIntegrity
+type DataIntegrity struct {
+	EncryptedHmacKey   string `xml:"encryptedHmacKey,attr"`
+	EncryptedHmacValue string `xml:"encryptedHmacValue,attr"`
+}
+
+//KeyEncryptor is a direct mapping of XSD CT_KeyEncryptor
+type KeyEncryptor struct {
+	URI          string        `xml:"uri,attr"`
+	EncryptedKey *EncryptedKey `xml:"http://schemas.microsoft.com/office/2006/keyEncryptor/password encryptedKey,omitempty"`
+}
+
+//EncryptedKey is a direct mapping of XSD CT_PasswordKeyEncryptor
+type EncryptedKey struct {
+	KeyData
+	SpinCount                  int    `xml:"spinCount,attr"`
+	EncryptedVerifierHashInput string `xml:"encryptedVerifierHashInput,attr"`
+	EncryptedVerifierHashValue string `xml:"encryptedVerifierHashValue,attr"`
+	EncryptedKeyValue          string `xml:"encryptedKeyValue,attr"`
+}
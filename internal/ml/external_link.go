@@ -0,0 +1,33 @@
+package ml
+
+import (
+	"github.com/plandem/ooxml/ml"
+)
+
+//ExternalLink is a direct mapping of XSD CT_ExternalLink
+type ExternalLink struct {
+	XMLName      ml.Name       `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main externalLink"`
+	RIDName      ml.RIDName    `xml:",attr"`
+	ExternalBook *ExternalBook `xml:"externalBook,omitempty"`
+	DdeLink      *ml.Reserved  `xml:"ddeLink,omitempty"`
+	OleLink      *ml.Reserved  `xml:"oleLink,omitempty"`
+	ExtLst       *ml.Reserved  `xml:"extLst,omitempty"`
+}
+
+//ExternalBook is a direct mapping of XSD CT_ExternalBook
+type ExternalBook struct {
+	RID          ml.RID              `xml:"id,attr"`
+	SheetNames   *ExternalSheetNames `xml:"sheetNames,omitempty"`
+	DefinedNames *ml.Reserved        `xml:"definedNames,omitempty"`
+	SheetDataSet *ml.Reserved        `xml:"sheetDataSet,omitempty"`
+}
+
+//ExternalSheetNames is a direct mapping of XSD CT_ExternalSheetNames
+type ExternalSheetNames struct {
+	Items []*ExternalSheetName `xml:"sheetName"`
+}
+
+//ExternalSheetName is a direct mapping of XSD CT_ExternalSheetName
+type ExternalSheetName struct {
+	Val string `xml:"val,attr,omitempty"`
+}
@@ -64,6 +64,15 @@ type MergedCellList struct {
 	Items []*MergeCell `xml:"mergeCell,omitempty"`
 }
 
+//DataValidationList is a direct mapping of XSD CT_DataValidations
+type DataValidationList struct {
+	Count          int               `xml:"count,attr"`
+	DisablePrompts bool              `xml:"disablePrompts,attr,omitempty"`
+	XWindow        uint              `xml:"xWindow,attr,omitempty"`
+	YWindow        uint              `xml:"yWindow,attr,omitempty"`
+	Items          []*DataValidation `xml:"dataValidation,omitempty"`
+}
+
 //SheetViewList is a direct mapping of XSD CT_SheetViews
 type SheetViewList struct {
 	Items  []*SheetView `xml:"sheetView,omitempty"`
@@ -75,9 +84,67 @@ type BookViewList struct {
 	Items []*BookView `xml:"workbookView,omitempty"`
 }
 
+//TableColumnList is a direct mapping of XSD CT_TableColumns
+type TableColumnList struct {
+	Count int            `xml:"count,attr"`
+	Items []*TableColumn `xml:"tableColumn"`
+}
+
+//TablePartList is a direct mapping of XSD CT_TableParts
+type TablePartList struct {
+	Count int          `xml:"count,attr"`
+	Items []*TablePart `xml:"tablePart,omitempty"`
+}
+
+//CacheFieldList is a direct mapping of XSD CT_CacheFields
+type CacheFieldList struct {
+	Count int           `xml:"count,attr"`
+	Items []*CacheField `xml:"cacheField"`
+}
+
+//PivotFieldList is a direct mapping of XSD CT_PivotFields
+type PivotFieldList struct {
+	Count int           `xml:"count,attr"`
+	Items []*PivotField `xml:"pivotField"`
+}
+
+//PivotItemList is a direct mapping of XSD CT_Items
+type PivotItemList struct {
+	Count int          `xml:"count,attr"`
+	Items []*PivotItem `xml:"item,omitempty"`
+}
+
+//PivotAxisFieldList is a direct mapping of XSD CT_RowFields and CT_ColFields
+type PivotAxisFieldList struct {
+	Count int               `xml:"count,attr"`
+	Items []*PivotAxisField `xml:"field,omitempty"`
+}
+
+//PivotPageFieldList is a direct mapping of XSD CT_PageFields
+type PivotPageFieldList struct {
+	Count int               `xml:"count,attr"`
+	Items []*PivotPageField `xml:"pageField,omitempty"`
+}
+
+//PivotDataFieldList is a direct mapping of XSD CT_DataFields
+type PivotDataFieldList struct {
+	Count int               `xml:"count,attr"`
+	Items []*PivotDataField `xml:"dataField,omitempty"`
+}
+
+//PivotCacheList is a direct mapping of XSD CT_PivotCaches
+type PivotCacheList struct {
+	Items []*PivotCache `xml:"pivotCache,omitempty"`
+}
+
+//DefinedNameList is a direct mapping of XSD CT_DefinedNames
+type DefinedNameList struct {
+	Items []*DefinedName `xml:"definedName,omitempty"`
+}
+
 //ExternalReferenceList is a direct mapping of XSD CT_ExternalReferences
 type ExternalReferenceList struct {
-	Items []*ExternalReference `xml:"workbookView,omitempty"`
+	Items []*ExternalReference `xml:"externalReference,omitempty"`
 }
 
 func (r *DiffStyleList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
@@ -160,6 +227,14 @@ func (r *MergedCellList) MarshalXML(e *xml.Encoder, start xml.StartElement) erro
 	return nil
 }
 
+func (r *DataValidationList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if r.Count = len(r.Items); r.Count > 0 {
+		return e.EncodeElement(*r, start)
+	}
+
+	return nil
+}
+
 func (r *SheetViewList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	if len(r.Items) > 0 {
 		return e.EncodeElement(*r, start)
@@ -183,3 +258,80 @@ func (r *ExternalReferenceList) MarshalXML(e *xml.Encoder, start xml.StartElemen
 
 	return nil
 }
+
+func (r *DefinedNameList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if len(r.Items) > 0 {
+		return e.EncodeElement(*r, start)
+	}
+
+	return nil
+}
+
+func (r *TableColumnList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	r.Count = len(r.Items)
+	return e.EncodeElement(*r, start)
+}
+
+func (r *TablePartList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if r.Count = len(r.Items); r.Count > 0 {
+		return e.EncodeElement(*r, start)
+	}
+
+	return nil
+}
+
+func (r *CacheFieldList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if r.Count = len(r.Items); r.Count > 0 {
+		return e.EncodeElement(*r, start)
+	}
+
+	return nil
+}
+
+func (r *PivotFieldList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if r.Count = len(r.Items); r.Count > 0 {
+		return e.EncodeElement(*r, start)
+	}
+
+	return nil
+}
+
+func (r *PivotItemList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if r.Count = len(r.Items); r.Count > 0 {
+		return e.EncodeElement(*r, start)
+	}
+
+	return nil
+}
+
+func (r *PivotAxisFieldList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if r.Count = len(r.Items); r.Count > 0 {
+		return e.EncodeElement(*r, start)
+	}
+
+	return nil
+}
+
+func (r *PivotPageFieldList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if r.Count = len(r.Items); r.Count > 0 {
+		return e.EncodeElement(*r, start)
+	}
+
+	return nil
+}
+
+func (r *PivotDataFieldList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if r.Count = len(r.Items); r.Count > 0 {
+		return e.EncodeElement(*r, start)
+	}
+
+	return nil
+}
+
+func (r *PivotCacheList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if len(r.Items) > 0 {
+		return e.EncodeElement(*r, start)
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package ml
+
+import (
+	"github.com/plandem/ooxml/ml"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+//PivotCacheDefinition is a direct mapping of XSD CT_PivotCacheDefinition
+type PivotCacheDefinition struct {
+	XMLName               ml.Name        `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main pivotCacheDefinition"`
+	RIDName               ml.RIDName     `xml:",attr"`
+	RID                   ml.RID         `xml:"id,attr,omitempty"`
+	Invalid               bool           `xml:"invalid,attr,omitempty"`
+	SaveData              *bool          `xml:"saveData,attr,omitempty"` //default true
+	RefreshOnLoad         bool           `xml:"refreshOnLoad,attr,omitempty"`
+	RefreshedBy           string         `xml:"refreshedBy,attr,omitempty"`
+	RefreshedVersion      int            `xml:"refreshedVersion,attr,omitempty"`
+	CreatedVersion        int            `xml:"createdVersion,attr,omitempty"`
+	MinRefreshableVersion int            `xml:"minRefreshableVersion,attr,omitempty"`
+	RecordCount           int            `xml:"recordCount,attr,omitempty"`
+	CacheSource           CacheSource    `xml:"cacheSource"`
+	CacheFields           CacheFieldList `xml:"cacheFields"`
+	CacheHierarchies      *ml.Reserved   `xml:"cacheHierarchies,omitempty"`
+	Kpis                  *ml.Reserved   `xml:"kpis,omitempty"`
+	TupleCache            *ml.Reserved   `xml:"tupleCache,omitempty"`
+	CalculatedItems       *ml.Reserved   `xml:"calculatedItems,omitempty"`
+	CalculatedMembers     *ml.Reserved   `xml:"calculatedMembers,omitempty"`
+	Dimensions            *ml.Reserved   `xml:"dimensions,omitempty"`
+	MeasureGroups         *ml.Reserved   `xml:"measureGroups,omitempty"`
+	Maps                  *ml.Reserved   `xml:"maps,omitempty"`
+	ExtLst                *ml.Reserved   `xml:"extLst,omitempty"`
+}
+
+//CacheSource is a direct mapping of XSD CT_CacheSource
+type CacheSource struct {
+	Type            string           `xml:"type,attr"`
+	WorksheetSource *WorksheetSource `xml:"worksheetSource,omitempty"`
+	Consolidation   *ml.Reserved     `xml:"consolidation,omitempty"`
+	ExtLst          *ml.Reserved     `xml:"extLst,omitempty"`
+}
+
+//WorksheetSource is a direct mapping of XSD CT_WorksheetSource
+type WorksheetSource struct {
+	Bounds primitives.Bounds `xml:"ref,attr,omitempty"`
+	Name   string            `xml:"name,attr,omitempty"`
+	Sheet  string            `xml:"sheet,attr,omitempty"`
+}
+
+//CacheField is a direct mapping of XSD CT_CacheField
+type CacheField struct {
+	Name        string       `xml:"name,attr"`
+	NumFmtID    int          `xml:"numFmtId,attr"`
+	SharedItems *SharedItems `xml:"sharedItems,omitempty"`
+	FieldGroup  *ml.Reserved `xml:"fieldGroup,omitempty"`
+	MpMap       *ml.Reserved `xml:"mpMap,omitempty"`
+	ExtLst      *ml.Reserved `xml:"extLst,omitempty"`
+}
+
+//SharedItems is a direct mapping of XSD CT_SharedItems
+type SharedItems struct {
+	ContainsBlank bool           `xml:"containsBlank,attr,omitempty"`
+	Count         int            `xml:"count,attr,omitempty"`
+	Missing       []*ml.Reserved `xml:"m,omitempty"`
+}
+
+//PivotTableDefinition is a direct mapping of XSD CT_pivotTableDefinition
+type PivotTableDefinition struct {
+	XMLName               ml.Name              `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main pivotTableDefinition"`
+	Name                  string               `xml:"name,attr"`
+	CacheID               int                  `xml:"cacheId,attr"`
+	DataOnRows            bool                 `xml:"dataOnRows,attr,omitempty"`
+	DataCaption           string               `xml:"dataCaption,attr"`
+	UpdatedVersion        int                  `xml:"updatedVersion,attr,omitempty"`
+	MinRefreshableVersion int                  `xml:"minRefreshableVersion,attr,omitempty"`
+	UseAutoFormatting     bool                 `xml:"useAutoFormatting,attr,omitempty"`
+	ItemPrintTitles       bool                 `xml:"itemPrintTitles,attr,omitempty"`
+	CreatedVersion        int                  `xml:"createdVersion,attr,omitempty"`
+	Outline               bool                 `xml:"outline,attr,omitempty"`
+	OutlineData           bool                 `xml:"outlineData,attr,omitempty"`
+	RowGrandTotals        *bool                `xml:"rowGrandTotals,attr,omitempty"` //default true
+	ColGrandTotals        *bool                `xml:"colGrandTotals,attr,omitempty"` //default true
+	Location              PivotLocation        `xml:"location"`
+	PivotFields           PivotFieldList       `xml:"pivotFields"`
+	RowFields             PivotAxisFieldList   `xml:"rowFields"`
+	RowItems              *ml.Reserved         `xml:"rowItems,omitempty"`
+	ColFields             PivotAxisFieldList   `xml:"colFields"`
+	ColItems              *ml.Reserved         `xml:"colItems,omitempty"`
+	PageFields            PivotPageFieldList   `xml:"pageFields"`
+	DataFields            PivotDataFieldList   `xml:"dataFields"`
+	Formats               *ml.Reserved         `xml:"formats,omitempty"`
+	ConditionalFormats    *ml.Reserved         `xml:"conditionalFormats,omitempty"`
+	ChartFormats          *ml.Reserved         `xml:"chartFormats,omitempty"`
+	PivotHierarchies      *ml.Reserved         `xml:"pivotHierarchies,omitempty"`
+	StyleInfo             *PivotTableStyleInfo `xml:"pivotTableStyleInfo,omitempty"`
+	Filters               *ml.Reserved         `xml:"filters,omitempty"`
+	RowHierarchiesUsage   *ml.Reserved         `xml:"rowHierarchiesUsage,omitempty"`
+	ColHierarchiesUsage   *ml.Reserved         `xml:"colHierarchiesUsage,omitempty"`
+	ExtLst                *ml.Reserved         `xml:"extLst,omitempty"`
+}
+
+//PivotLocation is a direct mapping of XSD CT_Location
+type PivotLocation struct {
+	Bounds         primitives.Bounds `xml:"ref,attr"`
+	FirstHeaderRow int               `xml:"firstHeaderRow,attr"`
+	FirstDataRow   int               `xml:"firstDataRow,attr"`
+	FirstDataCol   int               `xml:"firstDataCol,attr"`
+	RowPageCount   int               `xml:"rowPageCount,attr,omitempty"`
+	ColPageCount   int               `xml:"colPageCount,attr,omitempty"`
+}
+
+//PivotField is a direct mapping of XSD CT_PivotField
+type PivotField struct {
+	Axis          string        `xml:"axis,attr,omitempty"`
+	DataField     bool          `xml:"dataField,attr,omitempty"`
+	ShowAll       *bool         `xml:"showAll,attr,omitempty"` //default true
+	Items         PivotItemList `xml:"items"`
+	AutoSortScope *ml.Reserved  `xml:"autoSortScope,omitempty"`
+	ExtLst        *ml.Reserved  `xml:"extLst,omitempty"`
+}
+
+//PivotItem is a direct mapping of XSD CT_Item
+type PivotItem struct {
+	Type  string           `xml:"t,attr,omitempty"`
+	Index ml.OptionalIndex `xml:"x,attr,omitempty"`
+}
+
+//PivotAxisField is a direct mapping of XSD CT_Field
+type PivotAxisField struct {
+	Index int `xml:"x,attr"`
+}
+
+//PivotPageField is a direct mapping of XSD CT_PageField
+type PivotPageField struct {
+	Field     int              `xml:"fld,attr"`
+	Item      ml.OptionalIndex `xml:"item,attr,omitempty"`
+	Hierarchy int              `xml:"hier,attr"`
+}
+
+//PivotDataField is a direct mapping of XSD CT_DataField
+type PivotDataField struct {
+	Name      string                                 `xml:"name,attr,omitempty"`
+	Field     int                                    `xml:"fld,attr"`
+	Subtotal  primitives.DataConsolidateFunctionType `xml:"subtotal,attr,omitempty"`
+	BaseField int                                    `xml:"baseField,attr"`
+	BaseItem  int                                    `xml:"baseItem,attr"`
+	NumFmtID  int                                    `xml:"numFmtId,attr,omitempty"`
+}
+
+//PivotTableStyleInfo is a direct mapping of XSD CT_PivotTableStyle
+type PivotTableStyleInfo struct {
+	Name           string `xml:"name,attr,omitempty"`
+	ShowRowHeaders bool   `xml:"showRowHeaders,attr"`
+	ShowColHeaders bool   `xml:"showColHeaders,attr"`
+	ShowRowStripes bool   `xml:"showRowStripes,attr"`
+	ShowColStripes bool   `xml:"showColStripes,attr"`
+	ShowLastColumn bool   `xml:"showLastColumn,attr"`
+}
+
+//PivotCache is a direct mapping of XSD CT_PivotCache
+type PivotCache struct {
+	CacheID int    `xml:"cacheId,attr"`
+	RID     ml.RID `xml:"id,attr"`
+}
@@ -29,6 +29,13 @@ func (cr CellRef) ToIndexes() (int, int) {
 	return colIndex, rowIndex
 }
 
+//ToAbsolute returns absolute version of reference, e.g.: A1 => $A$1
+func (cr CellRef) ToAbsolute() string {
+	colPart := strings.Map(ooxml.GetLettersFn, string(cr))
+	rowPart := strings.Map(ooxml.GetNumbersFn, string(cr))
+	return fmt.Sprintf("$%s$%s", colPart, rowPart)
+}
+
 //CellRefFromIndexes returns a CellRef for 0-based indexes
 func CellRefFromIndexes(colIndex, rowIndex int) CellRef {
 	if colIndex < 0 || rowIndex < 0 {
@@ -18,4 +18,5 @@ func TestCelRef(t *testing.T) {
 	col, row := ref.ToIndexes()
 	require.Equal(t, 100, col)
 	require.Equal(t, 100, row)
+	require.Equal(t, "$CW$101", ref.ToAbsolute())
 }
@@ -22,6 +22,8 @@ func TestConditionValueType(t *testing.T) {
 		"min":        format.ConditionValueTypeMin,
 		"formula":    format.ConditionValueTypeFormula,
 		"percentile": format.ConditionValueTypePercentile,
+		"autoMin":    format.ConditionValueTypeAutoMin,
+		"autoMax":    format.ConditionValueTypeAutoMax,
 	}
 
 	for s, v := range list {
@@ -0,0 +1,38 @@
+package primitives
+
+import "encoding/xml"
+
+//DataBarAxisPosition is a direct mapping of XSD ST_DataBarAxisPosition
+type DataBarAxisPosition byte
+
+//DataBarAxisPosition maps for marshal/unmarshal process
+var (
+	ToDataBarAxisPosition   map[string]DataBarAxisPosition
+	FromDataBarAxisPosition map[DataBarAxisPosition]string
+)
+
+func (t DataBarAxisPosition) String() string {
+	return FromDataBarAxisPosition[t]
+}
+
+//MarshalXMLAttr marshal DataBarAxisPosition
+func (t *DataBarAxisPosition) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	attr := xml.Attr{Name: name}
+
+	if v, ok := FromDataBarAxisPosition[*t]; ok {
+		attr.Value = v
+	} else {
+		attr = xml.Attr{}
+	}
+
+	return attr, nil
+}
+
+//UnmarshalXMLAttr unmarshal DataBarAxisPosition
+func (t *DataBarAxisPosition) UnmarshalXMLAttr(attr xml.Attr) error {
+	if v, ok := ToDataBarAxisPosition[attr.Value]; ok {
+		*t = v
+	}
+
+	return nil
+}
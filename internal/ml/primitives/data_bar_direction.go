@@ -0,0 +1,38 @@
+package primitives
+
+import "encoding/xml"
+
+//DataBarDirection is a direct mapping of XSD ST_DataBarDirection
+type DataBarDirection byte
+
+//DataBarDirection maps for marshal/unmarshal process
+var (
+	ToDataBarDirection   map[string]DataBarDirection
+	FromDataBarDirection map[DataBarDirection]string
+)
+
+func (t DataBarDirection) String() string {
+	return FromDataBarDirection[t]
+}
+
+//MarshalXMLAttr marshal DataBarDirection
+func (t *DataBarDirection) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	attr := xml.Attr{Name: name}
+
+	if v, ok := FromDataBarDirection[*t]; ok {
+		attr.Value = v
+	} else {
+		attr = xml.Attr{}
+	}
+
+	return attr, nil
+}
+
+//UnmarshalXMLAttr unmarshal DataBarDirection
+func (t *DataBarDirection) UnmarshalXMLAttr(attr xml.Attr) error {
+	if v, ok := ToDataBarDirection[attr.Value]; ok {
+		*t = v
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package primitives
+
+import (
+	"encoding/xml"
+)
+
+//DataConsolidateFunctionType is a direct mapping of XSD ST_DataConsolidateFunction
+type DataConsolidateFunctionType byte
+
+//DataConsolidateFunctionType maps for marshal/unmarshal process
+var (
+	ToDataConsolidateFunctionType   map[string]DataConsolidateFunctionType
+	FromDataConsolidateFunctionType map[DataConsolidateFunctionType]string
+)
+
+func (t DataConsolidateFunctionType) String() string {
+	return FromDataConsolidateFunctionType[t]
+}
+
+//MarshalXMLAttr marshal DataConsolidateFunctionType
+func (t *DataConsolidateFunctionType) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	attr := xml.Attr{Name: name}
+
+	if v, ok := FromDataConsolidateFunctionType[*t]; ok {
+		attr.Value = v
+	} else {
+		attr = xml.Attr{}
+	}
+
+	return attr, nil
+}
+
+//UnmarshalXMLAttr unmarshal DataConsolidateFunctionType
+func (t *DataConsolidateFunctionType) UnmarshalXMLAttr(attr xml.Attr) error {
+	if v, ok := ToDataConsolidateFunctionType[attr.Value]; ok {
+		*t = v
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package primitives_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/plandem/xlsx/pivot"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDataConsolidateFunction(t *testing.T) {
+	type Entity struct {
+		Attribute primitives.DataConsolidateFunctionType `xml:"attribute,attr"`
+	}
+
+	list := map[string]primitives.DataConsolidateFunctionType{
+		"":          primitives.DataConsolidateFunctionType(0),
+		"sum":       pivot.Sum,
+		"count":     pivot.Count,
+		"average":   pivot.Average,
+		"max":       pivot.Max,
+		"min":       pivot.Min,
+		"product":   pivot.Product,
+		"countNums": pivot.CountNums,
+		"stdDev":    pivot.StdDev,
+		"stdDevp":   pivot.StdDevp,
+		"var":       pivot.Var,
+		"varp":      pivot.Varp,
+	}
+
+	for s, v := range list {
+		t.Run(s, func(tt *testing.T) {
+			entity := Entity{Attribute: v}
+			encoded, err := xml.Marshal(&entity)
+
+			require.Empty(tt, err)
+			if s == "" {
+				require.Equal(tt, `<Entity></Entity>`, string(encoded))
+			} else {
+				require.Equal(tt, fmt.Sprintf(`<Entity attribute="%s"></Entity>`, s), string(encoded))
+			}
+
+			var decoded Entity
+			err = xml.Unmarshal(encoded, &decoded)
+			require.Empty(tt, err)
+
+			require.Equal(tt, entity, decoded)
+			require.Equal(tt, s, decoded.Attribute.String())
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package primitives
+
+import (
+	"encoding/xml"
+)
+
+//DataValidationErrorStyle is a direct mapping of XSD ST_DataValidationErrorStyle
+type DataValidationErrorStyle byte
+
+//DataValidationErrorStyle maps for marshal/unmarshal process
+var (
+	ToDataValidationErrorStyle   map[string]DataValidationErrorStyle
+	FromDataValidationErrorStyle map[DataValidationErrorStyle]string
+)
+
+func (t DataValidationErrorStyle) String() string {
+	return FromDataValidationErrorStyle[t]
+}
+
+//MarshalXMLAttr marshal DataValidationErrorStyle
+func (t *DataValidationErrorStyle) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	attr := xml.Attr{Name: name}
+
+	if v, ok := FromDataValidationErrorStyle[*t]; ok {
+		attr.Value = v
+	} else {
+		attr = xml.Attr{}
+	}
+
+	return attr, nil
+}
+
+//UnmarshalXMLAttr unmarshal DataValidationErrorStyle
+func (t *DataValidationErrorStyle) UnmarshalXMLAttr(attr xml.Attr) error {
+	if v, ok := ToDataValidationErrorStyle[attr.Value]; ok {
+		*t = v
+	}
+
+	return nil
+}
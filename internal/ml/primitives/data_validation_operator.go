@@ -0,0 +1,40 @@
+package primitives
+
+import (
+	"encoding/xml"
+)
+
+//DataValidationOperatorType is a direct mapping of XSD ST_DataValidationOperator
+type DataValidationOperatorType byte
+
+//DataValidationOperatorType maps for marshal/unmarshal process
+var (
+	ToDataValidationOperatorType   map[string]DataValidationOperatorType
+	FromDataValidationOperatorType map[DataValidationOperatorType]string
+)
+
+func (t DataValidationOperatorType) String() string {
+	return FromDataValidationOperatorType[t]
+}
+
+//MarshalXMLAttr marshal DataValidationOperatorType
+func (t *DataValidationOperatorType) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	attr := xml.Attr{Name: name}
+
+	if v, ok := FromDataValidationOperatorType[*t]; ok {
+		attr.Value = v
+	} else {
+		attr = xml.Attr{}
+	}
+
+	return attr, nil
+}
+
+//UnmarshalXMLAttr unmarshal DataValidationOperatorType
+func (t *DataValidationOperatorType) UnmarshalXMLAttr(attr xml.Attr) error {
+	if v, ok := ToDataValidationOperatorType[attr.Value]; ok {
+		*t = v
+	}
+
+	return nil
+}
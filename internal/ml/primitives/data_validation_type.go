@@ -0,0 +1,40 @@
+package primitives
+
+import (
+	"encoding/xml"
+)
+
+//DataValidationType is a direct mapping of XSD ST_DataValidationType
+type DataValidationType byte
+
+//DataValidationType maps for marshal/unmarshal process
+var (
+	ToDataValidationType   map[string]DataValidationType
+	FromDataValidationType map[DataValidationType]string
+)
+
+func (t DataValidationType) String() string {
+	return FromDataValidationType[t]
+}
+
+//MarshalXMLAttr marshal DataValidationType
+func (t *DataValidationType) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	attr := xml.Attr{Name: name}
+
+	if v, ok := FromDataValidationType[*t]; ok {
+		attr.Value = v
+	} else {
+		attr = xml.Attr{}
+	}
+
+	return attr, nil
+}
+
+//UnmarshalXMLAttr unmarshal DataValidationType
+func (t *DataValidationType) UnmarshalXMLAttr(attr xml.Attr) error {
+	if v, ok := ToDataValidationType[attr.Value]; ok {
+		*t = v
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package primitives_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDataValidationType(t *testing.T) {
+	type Entity struct {
+		Attribute primitives.DataValidationType `xml:"attribute,attr"`
+	}
+
+	list := map[string]primitives.DataValidationType{
+		"":           primitives.DataValidationType(0),
+		"none":       types.ValidationTypeNone,
+		"whole":      types.ValidationTypeWhole,
+		"decimal":    types.ValidationTypeDecimal,
+		"list":       types.ValidationTypeList,
+		"date":       types.ValidationTypeDate,
+		"time":       types.ValidationTypeTime,
+		"textLength": types.ValidationTypeTextLength,
+		"custom":     types.ValidationTypeCustom,
+	}
+
+	for s, v := range list {
+		t.Run(s, func(tt *testing.T) {
+			entity := Entity{Attribute: v}
+			encoded, err := xml.Marshal(&entity)
+
+			require.Empty(tt, err)
+			if s == "" {
+				require.Equal(tt, `<Entity></Entity>`, string(encoded))
+			} else {
+				require.Equal(tt, fmt.Sprintf(`<Entity attribute="%s"></Entity>`, s), string(encoded))
+			}
+
+			var decoded Entity
+			err = xml.Unmarshal(encoded, &decoded)
+			require.Empty(tt, err)
+
+			require.Equal(tt, entity, decoded)
+			require.Equal(tt, s, decoded.Attribute.String())
+		})
+	}
+}
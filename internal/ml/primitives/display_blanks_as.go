@@ -0,0 +1,38 @@
+package primitives
+
+import "encoding/xml"
+
+//DisplayBlanksAs is a direct mapping of XSD ST_DispBlanksAs
+type DisplayBlanksAs byte
+
+//DisplayBlanksAs maps for marshal/unmarshal process
+var (
+	ToDisplayBlanksAs   map[string]DisplayBlanksAs
+	FromDisplayBlanksAs map[DisplayBlanksAs]string
+)
+
+func (t DisplayBlanksAs) String() string {
+	return FromDisplayBlanksAs[t]
+}
+
+//MarshalXMLAttr marshal DisplayBlanksAs
+func (t *DisplayBlanksAs) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	attr := xml.Attr{Name: name}
+
+	if v, ok := FromDisplayBlanksAs[*t]; ok {
+		attr.Value = v
+	} else {
+		attr = xml.Attr{}
+	}
+
+	return attr, nil
+}
+
+//UnmarshalXMLAttr unmarshal DisplayBlanksAs
+func (t *DisplayBlanksAs) UnmarshalXMLAttr(attr xml.Attr) error {
+	if v, ok := ToDisplayBlanksAs[attr.Value]; ok {
+		*t = v
+	}
+
+	return nil
+}
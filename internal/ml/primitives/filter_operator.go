@@ -0,0 +1,40 @@
+package primitives
+
+import (
+	"encoding/xml"
+)
+
+//FilterOperatorType is a direct mapping of XSD ST_FilterOperator
+type FilterOperatorType byte
+
+//FilterOperatorType maps for marshal/unmarshal process
+var (
+	ToFilterOperatorType   map[string]FilterOperatorType
+	FromFilterOperatorType map[FilterOperatorType]string
+)
+
+func (t FilterOperatorType) String() string {
+	return FromFilterOperatorType[t]
+}
+
+//MarshalXMLAttr marshal FilterOperatorType
+func (t *FilterOperatorType) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	attr := xml.Attr{Name: name}
+
+	if v, ok := FromFilterOperatorType[*t]; ok {
+		attr.Value = v
+	} else {
+		attr = xml.Attr{}
+	}
+
+	return attr, nil
+}
+
+//UnmarshalXMLAttr unmarshal FilterOperatorType
+func (t *FilterOperatorType) UnmarshalXMLAttr(attr xml.Attr) error {
+	if v, ok := ToFilterOperatorType[attr.Value]; ok {
+		*t = v
+	}
+
+	return nil
+}
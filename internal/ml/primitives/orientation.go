@@ -0,0 +1,38 @@
+package primitives
+
+import "encoding/xml"
+
+//OrientationType is a direct mapping of XSD ST_Orientation
+type OrientationType byte
+
+//OrientationType maps for marshal/unmarshal process
+var (
+	ToOrientationType   map[string]OrientationType
+	FromOrientationType map[OrientationType]string
+)
+
+func (t OrientationType) String() string {
+	return FromOrientationType[t]
+}
+
+//MarshalXMLAttr marshal OrientationType
+func (t *OrientationType) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	attr := xml.Attr{Name: name}
+
+	if v, ok := FromOrientationType[*t]; ok {
+		attr.Value = v
+	} else {
+		attr = xml.Attr{}
+	}
+
+	return attr, nil
+}
+
+//UnmarshalXMLAttr unmarshal OrientationType
+func (t *OrientationType) UnmarshalXMLAttr(attr xml.Attr) error {
+	if v, ok := ToOrientationType[attr.Value]; ok {
+		*t = v
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package primitives
+
+import "encoding/xml"
+
+//PageOrderType is a direct mapping of XSD ST_PageOrder
+type PageOrderType byte
+
+//PageOrderType maps for marshal/unmarshal process
+var (
+	ToPageOrderType   map[string]PageOrderType
+	FromPageOrderType map[PageOrderType]string
+)
+
+func (t PageOrderType) String() string {
+	return FromPageOrderType[t]
+}
+
+//MarshalXMLAttr marshal PageOrderType
+func (t *PageOrderType) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	attr := xml.Attr{Name: name}
+
+	if v, ok := FromPageOrderType[*t]; ok {
+		attr.Value = v
+	} else {
+		attr = xml.Attr{}
+	}
+
+	return attr, nil
+}
+
+//UnmarshalXMLAttr unmarshal PageOrderType
+func (t *PageOrderType) UnmarshalXMLAttr(attr xml.Attr) error {
+	if v, ok := ToPageOrderType[attr.Value]; ok {
+		*t = v
+	}
+
+	return nil
+}
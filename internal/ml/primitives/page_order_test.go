@@ -0,0 +1,43 @@
+package primitives_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/plandem/xlsx/page"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPageOrderType(t *testing.T) {
+	type Entity struct {
+		Attribute primitives.PageOrderType `xml:"attribute,attr"`
+	}
+
+	list := map[string]primitives.PageOrderType{
+		"":             primitives.PageOrderType(0),
+		"downThenOver": page.OrderDownThenOver,
+		"overThenDown": page.OrderOverThenDown,
+	}
+
+	for s, v := range list {
+		t.Run(s, func(tt *testing.T) {
+			entity := Entity{Attribute: v}
+			encoded, err := xml.Marshal(&entity)
+
+			require.Empty(tt, err)
+			if s == "" {
+				require.Equal(tt, `<Entity></Entity>`, string(encoded))
+			} else {
+				require.Equal(tt, fmt.Sprintf(`<Entity attribute="%s"></Entity>`, s), string(encoded))
+			}
+
+			var decoded Entity
+			err = xml.Unmarshal(encoded, &decoded)
+			require.Empty(tt, err)
+
+			require.Equal(tt, entity, decoded)
+			require.Equal(tt, s, decoded.Attribute.String())
+		})
+	}
+}
@@ -22,6 +22,16 @@ func (r Ref) ToCellRefs() (from CellRef, to CellRef) {
 	return
 }
 
+//ToAbsolute returns absolute version of reference, e.g.: A1:B2 => $A$1:$B$2
+func (r Ref) ToAbsolute() string {
+	from, to := r.ToCellRefs()
+	if from == to {
+		return from.ToAbsolute()
+	}
+
+	return from.ToAbsolute() + ":" + to.ToAbsolute()
+}
+
 //ToBounds returns related bounds of Ref
 func (r Ref) ToBounds() Bounds {
 	from, to := r.ToCellRefs()
@@ -29,4 +29,8 @@ func TestRef(t *testing.T) {
 	//0x9 => A10
 	ref = primitives.RefFromIndexes(0, 9)
 	require.Equal(t, primitives.Ref("A10"), ref)
+
+	//A10 => $A$10, A10:B20 => $A$10:$B$20
+	require.Equal(t, "$A$10", ref.ToAbsolute())
+	require.Equal(t, "$A$10:$B$20", primitives.Ref("A10:B20").ToAbsolute())
 }
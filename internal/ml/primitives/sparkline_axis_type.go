@@ -0,0 +1,38 @@
+package primitives
+
+import "encoding/xml"
+
+//SparklineAxisType is a direct mapping of XSD ST_SparklineAxisMinMax
+type SparklineAxisType byte
+
+//SparklineAxisType maps for marshal/unmarshal process
+var (
+	ToSparklineAxisType   map[string]SparklineAxisType
+	FromSparklineAxisType map[SparklineAxisType]string
+)
+
+func (t SparklineAxisType) String() string {
+	return FromSparklineAxisType[t]
+}
+
+//MarshalXMLAttr marshal SparklineAxisType
+func (t *SparklineAxisType) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	attr := xml.Attr{Name: name}
+
+	if v, ok := FromSparklineAxisType[*t]; ok {
+		attr.Value = v
+	} else {
+		attr = xml.Attr{}
+	}
+
+	return attr, nil
+}
+
+//UnmarshalXMLAttr unmarshal SparklineAxisType
+func (t *SparklineAxisType) UnmarshalXMLAttr(attr xml.Attr) error {
+	if v, ok := ToSparklineAxisType[attr.Value]; ok {
+		*t = v
+	}
+
+	return nil
+}
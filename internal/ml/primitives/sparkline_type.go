@@ -0,0 +1,38 @@
+package primitives
+
+import "encoding/xml"
+
+//SparklineType is a direct mapping of XSD ST_SparklineType
+type SparklineType byte
+
+//SparklineType maps for marshal/unmarshal process
+var (
+	ToSparklineType   map[string]SparklineType
+	FromSparklineType map[SparklineType]string
+)
+
+func (t SparklineType) String() string {
+	return FromSparklineType[t]
+}
+
+//MarshalXMLAttr marshal SparklineType
+func (t *SparklineType) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	attr := xml.Attr{Name: name}
+
+	if v, ok := FromSparklineType[*t]; ok {
+		attr.Value = v
+	} else {
+		attr = xml.Attr{}
+	}
+
+	return attr, nil
+}
+
+//UnmarshalXMLAttr unmarshal SparklineType
+func (t *SparklineType) UnmarshalXMLAttr(attr xml.Attr) error {
+	if v, ok := ToSparklineType[attr.Value]; ok {
+		*t = v
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package primitives
+
+import (
+	"encoding/xml"
+)
+
+//TotalsRowFunctionType is a direct mapping of XSD ST_TotalsRowFunction
+type TotalsRowFunctionType byte
+
+//TotalsRowFunctionType maps for marshal/unmarshal process
+var (
+	ToTotalsRowFunctionType   map[string]TotalsRowFunctionType
+	FromTotalsRowFunctionType map[TotalsRowFunctionType]string
+)
+
+func (t TotalsRowFunctionType) String() string {
+	return FromTotalsRowFunctionType[t]
+}
+
+//MarshalXMLAttr marshal TotalsRowFunctionType
+func (t *TotalsRowFunctionType) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	attr := xml.Attr{Name: name}
+
+	if v, ok := FromTotalsRowFunctionType[*t]; ok {
+		attr.Value = v
+	} else {
+		attr = xml.Attr{}
+	}
+
+	return attr, nil
+}
+
+//UnmarshalXMLAttr unmarshal TotalsRowFunctionType
+func (t *TotalsRowFunctionType) UnmarshalXMLAttr(attr xml.Attr) error {
+	if v, ok := ToTotalsRowFunctionType[attr.Value]; ok {
+		*t = v
+	}
+
+	return nil
+}
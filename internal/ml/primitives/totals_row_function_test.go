@@ -0,0 +1,51 @@
+package primitives_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/plandem/xlsx/table"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTotalsRowFunction(t *testing.T) {
+	type Entity struct {
+		Attribute primitives.TotalsRowFunctionType `xml:"attribute,attr"`
+	}
+
+	list := map[string]primitives.TotalsRowFunctionType{
+		"":          primitives.TotalsRowFunctionType(0),
+		"none":      table.TotalsNone,
+		"sum":       table.TotalsSum,
+		"min":       table.TotalsMin,
+		"max":       table.TotalsMax,
+		"average":   table.TotalsAverage,
+		"count":     table.TotalsCount,
+		"countNums": table.TotalsCountNums,
+		"stdDev":    table.TotalsStdDev,
+		"var":       table.TotalsVar,
+		"custom":    table.TotalsCustom,
+	}
+
+	for s, v := range list {
+		t.Run(s, func(tt *testing.T) {
+			entity := Entity{Attribute: v}
+			encoded, err := xml.Marshal(&entity)
+
+			require.Empty(tt, err)
+			if s == "" {
+				require.Equal(tt, `<Entity></Entity>`, string(encoded))
+			} else {
+				require.Equal(tt, fmt.Sprintf(`<Entity attribute="%s"></Entity>`, s), string(encoded))
+			}
+
+			var decoded Entity
+			err = xml.Unmarshal(encoded, &decoded)
+			require.Empty(tt, err)
+
+			require.Equal(tt, entity, decoded)
+			require.Equal(tt, s, decoded.Attribute.String())
+		})
+	}
+}
@@ -16,10 +16,24 @@ type SharedStrings struct {
 
 //StringItem is a direct mapping of XSD CT_Rst
 type StringItem struct {
-	Text       primitives.Text `xml:"t,omitempty"` //optional
-	RichText   *[]*RichText    `xml:"r,omitempty"` //optional
-	RPh        *[]*ml.Reserved `xml:"rPh,omitempty"`
-	PhoneticPr *ml.Reserved    `xml:"phoneticPr,omitempty"`
+	Text       primitives.Text     `xml:"t,omitempty"` //optional
+	RichText   *[]*RichText        `xml:"r,omitempty"` //optional
+	RPh        *[]*PhoneticRun     `xml:"rPh,omitempty"`
+	PhoneticPr *PhoneticProperties `xml:"phoneticPr,omitempty"`
+}
+
+//PhoneticRun is a direct mapping of XSD CT_PhoneticRun
+type PhoneticRun struct {
+	Text  primitives.Text `xml:"t"` //required
+	Start int             `xml:"sb,attr"`
+	End   int             `xml:"eb,attr"`
+}
+
+//PhoneticProperties is a direct mapping of XSD CT_PhoneticPr
+type PhoneticProperties struct {
+	FontID    int    `xml:"fontId,attr"`
+	Type      string `xml:"type,attr,omitempty"`      //ST_PhoneticType
+	Alignment string `xml:"alignment,attr,omitempty"` //ST_PhoneticAlignment
 }
 
 //RichText is a direct mapping of XSD CT_RElt
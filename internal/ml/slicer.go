@@ -0,0 +1,70 @@
+package ml
+
+import (
+	"github.com/plandem/ooxml/ml"
+)
+
+//SlicerCacheDefinition is a direct mapping of XSD x14:CT_SlicerCacheDefinition
+type SlicerCacheDefinition struct {
+	XMLName     ml.Name                 `xml:"http://schemas.microsoft.com/office/spreadsheetml/2009/9/main slicerCacheDefinition"`
+	PivotTables *SlicerCachePivotTables `xml:"pivotTables,omitempty"`
+	Data        *SlicerCacheData        `xml:"data,omitempty"`
+	ExtLst      *ml.Reserved            `xml:"extLst,omitempty"`
+	Name        string                  `xml:"name,attr"`
+	SourceName  string                  `xml:"sourceName,attr"`
+}
+
+//SlicerCachePivotTables is a direct mapping of XSD x14:CT_SlicerCachePivotTables
+type SlicerCachePivotTables struct {
+	Items []*SlicerCachePivotTable `xml:"pivotTable"`
+}
+
+//SlicerCachePivotTable is a direct mapping of XSD x14:CT_SlicerCachePivotTable
+type SlicerCachePivotTable struct {
+	TabID uint   `xml:"tabId,attr"`
+	Name  string `xml:"name,attr"`
+}
+
+//SlicerCacheData is a direct mapping of XSD x14:CT_SlicerCacheData
+type SlicerCacheData struct {
+	OLAP    *ml.Reserved        `xml:"olap,omitempty"`
+	Tabular *TabularSlicerCache `xml:"tabular,omitempty"`
+}
+
+//TabularSlicerCache is a direct mapping of XSD x14:CT_TabularSlicerCache
+type TabularSlicerCache struct {
+	Items          TabularSlicerCacheItems `xml:"items"`
+	ExtLst         *ml.Reserved            `xml:"extLst,omitempty"`
+	PivotCacheID   uint                    `xml:"pivotCacheId,attr"`
+	SortOrder      string                  `xml:"sortOrder,attr,omitempty"`      //ST_TabularSlicerCacheSortOrder
+	CustomListSort *bool                   `xml:"customListSort,attr,omitempty"` //default true
+	ShowMissing    *bool                   `xml:"showMissing,attr,omitempty"`    //default true
+	CrossFilter    string                  `xml:"crossFilter,attr,omitempty"`    //ST_SlicerCacheCrossFilter
+}
+
+//TabularSlicerCacheItems is a direct mapping of XSD x14:CT_TabularSlicerCacheItems
+type TabularSlicerCacheItems struct {
+	Items []*ml.Reserved `xml:"i,omitempty"`
+	Count uint           `xml:"count,attr"`
+}
+
+//Slicers is a direct mapping of XSD x14:CT_Slicers
+type Slicers struct {
+	XMLName ml.Name   `xml:"http://schemas.microsoft.com/office/spreadsheetml/2009/9/main slicers"`
+	Items   []*Slicer `xml:"slicer"`
+}
+
+//Slicer is a direct mapping of XSD x14:CT_Slicer
+type Slicer struct {
+	ExtLst         *ml.Reserved `xml:"extLst,omitempty"`
+	Name           string       `xml:"name,attr"`
+	Cache          string       `xml:"cache,attr"`
+	Caption        string       `xml:"caption,attr,omitempty"`
+	StartItem      uint         `xml:"startItem,attr,omitempty"`
+	ColumnCount    uint         `xml:"columnCount,attr,omitempty"`
+	ShowCaption    *bool        `xml:"showCaption,attr,omitempty"` //default true
+	Level          uint         `xml:"level,attr,omitempty"`
+	Style          string       `xml:"style,attr,omitempty"`
+	LockedPosition bool         `xml:"lockedPosition,attr,omitempty"`
+	RowHeight      uint         `xml:"rowHeight,attr"`
+}
@@ -26,7 +26,7 @@ type StyleSheet struct {
 	CellStyles    NamedStyleInfoList `xml:"cellStyles"`
 	Dxfs          DiffStyleList      `xml:"dxfs"`
 	TableStyles   *ml.Reserved       `xml:"tableStyles,omitempty"`
-	Colors        *ml.Reserved       `xml:"colors,omitempty"`
+	Colors        *Colors            `xml:"colors,omitempty"`
 	ExtLst        *ml.Reserved       `xml:"extLst,omitempty"`
 }
 
@@ -63,6 +63,22 @@ type Color struct {
 	Theme   ml.OptionalIndex `xml:"theme,attr,omitempty"`
 }
 
+//Colors is a direct mapping of XSD CT_Colors
+type Colors struct {
+	Indexed *IndexedColorList `xml:"indexedColors,omitempty"`
+	MRU     *ml.Reserved      `xml:"mruColors,omitempty"`
+}
+
+//IndexedColorList is a direct mapping of XSD CT_IndexedColors
+type IndexedColorList struct {
+	Items []*RgbColor `xml:"rgbColor,omitempty"`
+}
+
+//RgbColor is a direct mapping of XSD CT_RgbColor
+type RgbColor struct {
+	RGB string `xml:"rgb,attr,omitempty"`
+}
+
 //Fill is a direct mapping of XSD CT_Fill
 type Fill struct {
 	Pattern  *PatternFill  `xml:"patternFill,omitempty"`
@@ -0,0 +1,72 @@
+package ml
+
+import (
+	"github.com/plandem/ooxml/ml"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+//Table is a direct mapping of XSD CT_Table
+type Table struct {
+	XMLName              ml.Name           `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main table"`
+	AutoFilter           *AutoFilter       `xml:"autoFilter,omitempty"`
+	SortState            *ml.Reserved      `xml:"sortState,omitempty"`
+	Columns              TableColumnList   `xml:"tableColumns"`
+	StyleInfo            *TableStyleInfo   `xml:"tableStyleInfo,omitempty"`
+	ExtLst               *ml.Reserved      `xml:"extLst,omitempty"`
+	ID                   int               `xml:"id,attr"`
+	Name                 string            `xml:"name,attr,omitempty"`
+	DisplayName          string            `xml:"displayName,attr"`
+	Comment              string            `xml:"comment,attr,omitempty"`
+	Bounds               primitives.Bounds `xml:"ref,attr"`
+	TableType            string            `xml:"tableType,attr,omitempty"`
+	HeaderRowCount       *int              `xml:"headerRowCount,attr,omitempty"`
+	InsertRow            bool              `xml:"insertRow,attr,omitempty"`
+	InsertRowShift       bool              `xml:"insertRowShift,attr,omitempty"`
+	TotalsRowCount       int               `xml:"totalsRowCount,attr,omitempty"`
+	TotalsRowShown       *bool             `xml:"totalsRowShown,attr,omitempty"`
+	Published            bool              `xml:"published,attr,omitempty"`
+	HeaderRowDxfID       ml.OptionalIndex  `xml:"headerRowDxfId,attr,omitempty"`
+	DataDxfID            ml.OptionalIndex  `xml:"dataDxfId,attr,omitempty"`
+	TotalsRowDxfID       ml.OptionalIndex  `xml:"totalsRowDxfId,attr,omitempty"`
+	HeaderRowBorderDxfID ml.OptionalIndex  `xml:"headerRowBorderDxfId,attr,omitempty"`
+	TableBorderDxfID     ml.OptionalIndex  `xml:"tableBorderDxfId,attr,omitempty"`
+	TotalsRowBorderDxfID ml.OptionalIndex  `xml:"totalsRowBorderDxfId,attr,omitempty"`
+	HeaderRowCellStyle   string            `xml:"headerRowCellStyle,attr,omitempty"`
+	DataCellStyle        string            `xml:"dataCellStyle,attr,omitempty"`
+	TotalsRowCellStyle   string            `xml:"totalsRowCellStyle,attr,omitempty"`
+	ConnectionID         uint              `xml:"connectionId,attr,omitempty"`
+}
+
+//TableColumn is a direct mapping of XSD CT_TableColumn
+type TableColumn struct {
+	CalculatedColumnFormula *ml.Reserved                     `xml:"calculatedColumnFormula,omitempty"`
+	TotalsRowFormula        *ml.Reserved                     `xml:"totalsRowFormula,omitempty"`
+	XmlColumnPr             *ml.Reserved                     `xml:"xmlColumnPr,omitempty"`
+	ExtLst                  *ml.Reserved                     `xml:"extLst,omitempty"`
+	ID                      int                              `xml:"id,attr"`
+	UniqueName              string                           `xml:"uniqueName,attr,omitempty"`
+	Name                    string                           `xml:"name,attr"`
+	TotalsRowFunction       primitives.TotalsRowFunctionType `xml:"totalsRowFunction,attr,omitempty"`
+	TotalsRowLabel          string                           `xml:"totalsRowLabel,attr,omitempty"`
+	QueryTableFieldID       uint                             `xml:"queryTableFieldId,attr,omitempty"`
+	HeaderRowDxfID          ml.OptionalIndex                 `xml:"headerRowDxfId,attr,omitempty"`
+	DataDxfID               ml.OptionalIndex                 `xml:"dataDxfId,attr,omitempty"`
+	TotalsRowDxfID          ml.OptionalIndex                 `xml:"totalsRowDxfId,attr,omitempty"`
+	HeaderRowCellStyle      string                           `xml:"headerRowCellStyle,attr,omitempty"`
+	DataCellStyle           string                           `xml:"dataCellStyle,attr,omitempty"`
+	TotalsRowCellStyle      string                           `xml:"totalsRowCellStyle,attr,omitempty"`
+}
+
+//TableStyleInfo is a direct mapping of XSD CT_TableStyleInfo
+type TableStyleInfo struct {
+	Name              string `xml:"name,attr,omitempty"`
+	ShowFirstColumn   bool   `xml:"showFirstColumn,attr"`
+	ShowLastColumn    bool   `xml:"showLastColumn,attr"`
+	ShowRowStripes    bool   `xml:"showRowStripes,attr"`
+	ShowColumnStripes bool   `xml:"showColumnStripes,attr"`
+}
+
+//TablePart is a direct mapping of XSD CT_TablePart
+type TablePart struct {
+	RID ml.RID `xml:"id,attr"`
+}
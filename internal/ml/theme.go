@@ -0,0 +1,96 @@
+package ml
+
+import (
+	"encoding/xml"
+)
+
+//NamespaceDrawingML is a namespace of DrawingML that is used by theme
+const NamespaceDrawingML = "http://schemas.openxmlformats.org/drawingml/2006/main"
+
+//Theme is a direct mapping of XSD CT_OfficeStyleSheet. DrawingML is not fully supported, so content is kept as is
+type Theme struct {
+	Prefix   string
+	Attrs    []xml.Attr
+	InnerXML string
+}
+
+//ThemeColorScheme is a direct mapping of XSD CT_ColorScheme
+type ThemeColorScheme struct {
+	Name              string     `xml:"name,attr"`
+	Dark1             ThemeColor `xml:"dk1"`
+	Light1            ThemeColor `xml:"lt1"`
+	Dark2             ThemeColor `xml:"dk2"`
+	Light2            ThemeColor `xml:"lt2"`
+	Accent1           ThemeColor `xml:"accent1"`
+	Accent2           ThemeColor `xml:"accent2"`
+	Accent3           ThemeColor `xml:"accent3"`
+	Accent4           ThemeColor `xml:"accent4"`
+	Accent5           ThemeColor `xml:"accent5"`
+	Accent6           ThemeColor `xml:"accent6"`
+	Hyperlink         ThemeColor `xml:"hlink"`
+	FollowedHyperlink ThemeColor `xml:"folHlink"`
+}
+
+//ThemeColor is a direct mapping of XSD CT_Color from DrawingML. Only RGB and system colors are supported
+type ThemeColor struct {
+	RGB    *ThemeRGBColor    `xml:"srgbClr,omitempty"`
+	System *ThemeSystemColor `xml:"sysClr,omitempty"`
+}
+
+//ThemeRGBColor is a direct mapping of XSD CT_SRgbColor
+type ThemeRGBColor struct {
+	Val string `xml:"val,attr"`
+}
+
+//ThemeSystemColor is a direct mapping of XSD CT_SystemColor
+type ThemeSystemColor struct {
+	Val       string `xml:"val,attr"`
+	LastColor string `xml:"lastClr,attr,omitempty"`
+}
+
+//UnmarshalXML unmarshal Theme
+func (t *Theme) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	//decoder resolves prefixes into namespaces, so we need to restore prefixes to keep content as is
+	prefixes := make(map[string]string)
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" {
+			prefixes[attr.Value] = attr.Name.Local
+		}
+	}
+
+	t.Prefix = prefixes[start.Name.Space]
+	t.Attrs = nil
+	for _, attr := range start.Attr {
+		switch {
+		case attr.Name.Space == "xmlns":
+			attr.Name = xml.Name{Local: "xmlns:" + attr.Name.Local}
+		case attr.Name.Space != "":
+			attr.Name = xml.Name{Local: prefixes[attr.Name.Space] + ":" + attr.Name.Local}
+		}
+
+		t.Attrs = append(t.Attrs, attr)
+	}
+
+	var inner struct {
+		XML string `xml:",innerxml"`
+	}
+
+	if err := d.DecodeElement(&inner, &start); err != nil {
+		return err
+	}
+
+	t.InnerXML = inner.XML
+	return nil
+}
+
+//MarshalXML marshal Theme
+func (t *Theme) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start = xml.StartElement{Name: xml.Name{Local: "theme"}, Attr: t.Attrs}
+	if len(t.Prefix) > 0 {
+		start.Name.Local = t.Prefix + ":theme"
+	}
+
+	return e.EncodeElement(struct {
+		XML string `xml:",innerxml"`
+	}{t.InnerXML}, start)
+}
@@ -12,16 +12,16 @@ type Workbook struct {
 	FileVersion         *FileVersion          `xml:"fileVersion,omitempty"`
 	FileSharing         *ml.Reserved          `xml:"fileSharing,omitempty"`
 	WorkbookPr          *WorkbookPr           `xml:"workbookPr,omitempty"`
-	WorkbookProtection  *ml.Reserved          `xml:"workbookProtection,omitempty"`
+	WorkbookProtection  *WorkbookProtection   `xml:"workbookProtection,omitempty"`
 	BookViews           BookViewList          `xml:"bookViews"`
 	Sheets              []*Sheet              `xml:"sheets>sheet"`
 	FunctionGroups      *ml.Reserved          `xml:"functionGroups,omitempty"`
 	ExternalReferences  ExternalReferenceList `xml:"externalReferences"`
-	DefinedNames        *ml.Reserved          `xml:"definedNames,omitempty"`
-	CalcPr              *ml.Reserved          `xml:"calcPr,omitempty"`
+	DefinedNames        DefinedNameList       `xml:"definedNames"`
+	CalcPr              *CalcPr               `xml:"calcPr,omitempty"`
 	OleSize             *ml.Reserved          `xml:"oleSize,omitempty"`
 	CustomWorkbookViews *ml.Reserved          `xml:"customWorkbookViews,omitempty"`
-	PivotCaches         *ml.Reserved          `xml:"pivotCaches,omitempty"`
+	PivotCaches         PivotCacheList        `xml:"pivotCaches"`
 	SmartTagPr          *ml.Reserved          `xml:"smartTagPr,omitempty"`
 	SmartTagTypes       *ml.Reserved          `xml:"smartTagTypes,omitempty"`
 	WebPublishing       *ml.Reserved          `xml:"webPublishing,omitempty"`
@@ -31,6 +31,40 @@ type Workbook struct {
 	Conformance         string                `xml:"conformance,attr,omitempty"`
 }
 
+//WorkbookProtection is a direct mapping of XSD CT_WorkbookProtection
+type WorkbookProtection struct {
+	WorkbookPassword       string `xml:"workbookPassword,attr,omitempty"`
+	RevisionsPassword      string `xml:"revisionsPassword,attr,omitempty"`
+	LockStructure          bool   `xml:"lockStructure,attr,omitempty"`
+	LockWindows            bool   `xml:"lockWindows,attr,omitempty"`
+	LockRevision           bool   `xml:"lockRevision,attr,omitempty"`
+	RevisionsAlgorithmName string `xml:"revisionsAlgorithmName,attr,omitempty"`
+	RevisionsHashValue     string `xml:"revisionsHashValue,attr,omitempty"`
+	RevisionsSaltValue     string `xml:"revisionsSaltValue,attr,omitempty"`
+	RevisionsSpinCount     int    `xml:"revisionsSpinCount,attr,omitempty"`
+	WorkbookAlgorithmName  string `xml:"workbookAlgorithmName,attr,omitempty"`
+	WorkbookHashValue      string `xml:"workbookHashValue,attr,omitempty"`
+	WorkbookSaltValue      string `xml:"workbookSaltValue,attr,omitempty"`
+	WorkbookSpinCount      int    `xml:"workbookSpinCount,attr,omitempty"`
+}
+
+//CalcPr is a direct mapping of XSD CT_CalcPr
+type CalcPr struct {
+	CalcID                uint    `xml:"calcId,attr,omitempty"`
+	CalcMode              string  `xml:"calcMode,attr,omitempty"` //ST_CalcMode
+	FullCalcOnLoad        bool    `xml:"fullCalcOnLoad,attr,omitempty"`
+	RefMode               string  `xml:"refMode,attr,omitempty"` //ST_RefMode
+	Iterate               bool    `xml:"iterate,attr,omitempty"`
+	IterateCount          uint    `xml:"iterateCount,attr,omitempty"`   //default 100
+	IterateDelta          float64 `xml:"iterateDelta,attr,omitempty"`   //default 0.001
+	FullPrecision         *bool   `xml:"fullPrecision,attr,omitempty"`  //default true
+	CalcCompleted         *bool   `xml:"calcCompleted,attr,omitempty"`  //default true
+	CalcOnSave            *bool   `xml:"calcOnSave,attr,omitempty"`     //default true
+	ConcurrentCalc        *bool   `xml:"concurrentCalc,attr,omitempty"` //default true
+	ConcurrentManualCount uint    `xml:"concurrentManualCount,attr,omitempty"`
+	ForceFullCalc         bool    `xml:"forceFullCalc,attr,omitempty"`
+}
+
 //FileVersion is a direct mapping of XSD CT_FileVersion
 type FileVersion struct {
 	AppName      string `xml:"appName,attr,omitempty"`
@@ -92,3 +126,23 @@ type Sheet struct {
 type ExternalReference struct {
 	RID ml.RID `xml:"id,attr,omitempty"`
 }
+
+//DefinedName is a direct mapping of XSD CT_DefinedName
+type DefinedName struct {
+	Formula           string           `xml:",chardata"`
+	Name              string           `xml:"name,attr"`
+	Comment           string           `xml:"comment,attr,omitempty"`
+	CustomMenu        string           `xml:"customMenu,attr,omitempty"`
+	Description       string           `xml:"description,attr,omitempty"`
+	Help              string           `xml:"help,attr,omitempty"`
+	StatusBar         string           `xml:"statusBar,attr,omitempty"`
+	LocalSheetID      ml.OptionalIndex `xml:"localSheetId,attr,omitempty"`
+	Hidden            bool             `xml:"hidden,attr,omitempty"`
+	Function          bool             `xml:"function,attr,omitempty"`
+	VbProcedure       bool             `xml:"vbProcedure,attr,omitempty"`
+	Xlm               bool             `xml:"xlm,attr,omitempty"`
+	FunctionGroupID   uint             `xml:"functionGroupId,attr,omitempty"`
+	ShortcutKey       string           `xml:"shortcutKey,attr,omitempty"`
+	PublishToServer   bool             `xml:"publishToServer,attr,omitempty"`
+	WorkbookParameter bool             `xml:"workbookParameter,attr,omitempty"`
+}
@@ -9,45 +9,207 @@ import (
 type Worksheet struct {
 	XMLName               ml.Name                   `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main worksheet"`
 	RIDName               ml.RIDName                `xml:",attr"`
-	SheetPr               *ml.Reserved              `xml:"sheetPr,omitempty"`
+	SheetPr               *SheetPr                  `xml:"sheetPr,omitempty"`
 	Dimension             *SheetDimension           `xml:"dimension,omitempty"`
 	SheetViews            SheetViewList             `xml:"sheetViews"`
-	SheetFormatPr         *ml.Reserved              `xml:"sheetFormatPr,omitempty"`
+	SheetFormatPr         *SheetFormatPr            `xml:"sheetFormatPr,omitempty"`
 	Cols                  ColList                   `xml:"cols"`
 	SheetData             []*Row                    `xml:"sheetData>row"`
 	SheetCalcPr           *ml.Reserved              `xml:"sheetCalcPr,omitempty"`
-	SheetProtection       *ml.Reserved              `xml:"sheetProtection,omitempty"`
+	SheetProtection       *SheetProtection          `xml:"sheetProtection,omitempty"`
 	ProtectedRanges       *ml.Reserved              `xml:"protectedRanges,omitempty"`
 	Scenarios             *ml.Reserved              `xml:"scenarios,omitempty"`
-	AutoFilter            *ml.Reserved              `xml:"autoFilter,omitempty"`
+	AutoFilter            *AutoFilter               `xml:"autoFilter,omitempty"`
 	SortState             *ml.Reserved              `xml:"sortState,omitempty"`
 	DataConsolidate       *ml.Reserved              `xml:"dataConsolidate,omitempty"`
 	CustomSheetViews      *ml.Reserved              `xml:"customSheetViews,omitempty"`
 	MergeCells            MergedCellList            `xml:"mergeCells"`
 	PhoneticPr            *ml.Reserved              `xml:"phoneticPr,omitempty"`
 	ConditionalFormatting *[]*ConditionalFormatting `xml:"conditionalFormatting,omitempty"`
-	DataValidations       *ml.Reserved              `xml:"dataValidations,omitempty"`
+	DataValidations       DataValidationList        `xml:"dataValidations"`
 	Hyperlinks            HyperlinkList             `xml:"hyperlinks"`
-	PrintOptions          *ml.Reserved              `xml:"printOptions,omitempty"`
-	PageMargins           *ml.Reserved              `xml:"pageMargins,omitempty"`
-	PageSetup             *ml.Reserved              `xml:"pageSetup,omitempty"`
-	HeaderFooter          *ml.Reserved              `xml:"headerFooter,omitempty"`
-	RowBreaks             *ml.Reserved              `xml:"rowBreaks,omitempty"`
-	ColBreaks             *ml.Reserved              `xml:"colBreaks,omitempty"`
+	PrintOptions          *PrintOptions             `xml:"printOptions,omitempty"`
+	PageMargins           *PageMargins              `xml:"pageMargins,omitempty"`
+	PageSetup             *PageSetup                `xml:"pageSetup,omitempty"`
+	HeaderFooter          *HeaderFooter             `xml:"headerFooter,omitempty"`
+	RowBreaks             *PageBreaks               `xml:"rowBreaks,omitempty"`
+	ColBreaks             *PageBreaks               `xml:"colBreaks,omitempty"`
 	CustomProperties      *ml.Reserved              `xml:"customProperties,omitempty"`
 	CellWatches           *ml.Reserved              `xml:"cellWatches,omitempty"`
-	IgnoredErrors         *ml.Reserved              `xml:"ignoredErrors,omitempty"`
+	IgnoredErrors         *IgnoredErrors            `xml:"ignoredErrors,omitempty"`
 	SmartTags             *ml.Reserved              `xml:"smartTags,omitempty"`
-	Drawing               *ml.Reserved              `xml:"drawing,omitempty"`
+	Drawing               *Drawing                  `xml:"drawing,omitempty"`
+	LegacyDrawing         *LegacyDrawing            `xml:"legacyDrawing,omitempty"`
+	LegacyDrawingHF       *LegacyDrawing            `xml:"legacyDrawingHF,omitempty"`
 	DrawingHF             *ml.Reserved              `xml:"drawingHF,omitempty"`
-	Picture               *ml.Reserved              `xml:"picture,omitempty"`
+	Picture               *SheetBackgroundPicture   `xml:"picture,omitempty"`
 	OleObjects            *ml.Reserved              `xml:"oleObjects,omitempty"`
 	Controls              *ml.Reserved              `xml:"controls,omitempty"`
 	WebPublishItems       *ml.Reserved              `xml:"webPublishItems,omitempty"`
-	TableParts            *ml.Reserved              `xml:"tableParts,omitempty"`
+	TableParts            TablePartList             `xml:"tableParts"`
 	ExtLst                *ml.Reserved              `xml:"extLst,omitempty"`
 }
 
+//IgnoredErrors is a direct mapping of XSD CT_IgnoredErrors
+type IgnoredErrors struct {
+	Items  []*IgnoredError `xml:"ignoredError"`
+	ExtLst *ml.Reserved    `xml:"extLst,omitempty"`
+}
+
+//IgnoredError is a direct mapping of XSD CT_IgnoredError
+type IgnoredError struct {
+	Bounds             primitives.BoundsList `xml:"sqref,attr"`
+	EvalError          bool                  `xml:"evalError,attr,omitempty"`
+	TwoDigitTextYear   bool                  `xml:"twoDigitTextYear,attr,omitempty"`
+	NumberStoredAsText bool                  `xml:"numberStoredAsText,attr,omitempty"`
+	Formula            bool                  `xml:"formula,attr,omitempty"`
+	FormulaRange       bool                  `xml:"formulaRange,attr,omitempty"`
+	UnlockedFormula    bool                  `xml:"unlockedFormula,attr,omitempty"`
+	EmptyCellReference bool                  `xml:"emptyCellReference,attr,omitempty"`
+	ListDataValidation bool                  `xml:"listDataValidation,attr,omitempty"`
+	CalculatedColumn   bool                  `xml:"calculatedColumn,attr,omitempty"`
+}
+
+//PageBreaks is a direct mapping of XSD CT_PageBreak
+type PageBreaks struct {
+	Count            int      `xml:"count,attr,omitempty"`
+	ManualBreakCount int      `xml:"manualBreakCount,attr,omitempty"`
+	Items            []*Break `xml:"brk"`
+}
+
+//Break is a direct mapping of XSD CT_Break
+type Break struct {
+	ID     int  `xml:"id,attr,omitempty"`
+	Min    int  `xml:"min,attr,omitempty"`
+	Max    int  `xml:"max,attr,omitempty"`
+	Manual bool `xml:"man,attr,omitempty"`
+	Pivot  bool `xml:"pt,attr,omitempty"`
+}
+
+//SheetProtection is a direct mapping of XSD CT_SheetProtection
+type SheetProtection struct {
+	Password            string `xml:"password,attr,omitempty"`
+	AlgorithmName       string `xml:"algorithmName,attr,omitempty"`
+	HashValue           string `xml:"hashValue,attr,omitempty"`
+	SaltValue           string `xml:"saltValue,attr,omitempty"`
+	SpinCount           int    `xml:"spinCount,attr,omitempty"`
+	Sheet               bool   `xml:"sheet,attr,omitempty"`
+	Objects             bool   `xml:"objects,attr,omitempty"`
+	Scenarios           bool   `xml:"scenarios,attr,omitempty"`
+	FormatCells         *bool  `xml:"formatCells,attr,omitempty"`      //default true
+	FormatColumns       *bool  `xml:"formatColumns,attr,omitempty"`    //default true
+	FormatRows          *bool  `xml:"formatRows,attr,omitempty"`       //default true
+	InsertColumns       *bool  `xml:"insertColumns,attr,omitempty"`    //default true
+	InsertRows          *bool  `xml:"insertRows,attr,omitempty"`       //default true
+	InsertHyperlinks    *bool  `xml:"insertHyperlinks,attr,omitempty"` //default true
+	DeleteColumns       *bool  `xml:"deleteColumns,attr,omitempty"`    //default true
+	DeleteRows          *bool  `xml:"deleteRows,attr,omitempty"`       //default true
+	SelectLockedCells   bool   `xml:"selectLockedCells,attr,omitempty"`
+	Sort                *bool  `xml:"sort,attr,omitempty"`        //default true
+	AutoFilter          *bool  `xml:"autoFilter,attr,omitempty"`  //default true
+	PivotTables         *bool  `xml:"pivotTables,attr,omitempty"` //default true
+	SelectUnlockedCells bool   `xml:"selectUnlockedCells,attr,omitempty"`
+}
+
+//SheetPr is a direct mapping of XSD CT_SheetPr
+type SheetPr struct {
+	TabColor                          *Color       `xml:"tabColor,omitempty"`
+	OutlinePr                         *OutlinePr   `xml:"outlinePr,omitempty"`
+	PageSetUpPr                       *PageSetUpPr `xml:"pageSetUpPr,omitempty"`
+	SyncHorizontal                    bool         `xml:"syncHorizontal,attr,omitempty"`
+	SyncVertical                      bool         `xml:"syncVertical,attr,omitempty"`
+	SyncRef                           string       `xml:"syncRef,attr,omitempty"`
+	TransitionEvaluation              bool         `xml:"transitionEvaluation,attr,omitempty"`
+	TransitionEntry                   bool         `xml:"transitionEntry,attr,omitempty"`
+	Published                         *bool        `xml:"published,attr,omitempty"` //default true
+	CodeName                          string       `xml:"codeName,attr,omitempty"`
+	FilterMode                        bool         `xml:"filterMode,attr,omitempty"`
+	EnableFormatConditionsCalculation *bool        `xml:"enableFormatConditionsCalculation,attr,omitempty"` //default true
+}
+
+//OutlinePr is a direct mapping of XSD CT_OutlinePr
+type OutlinePr struct {
+	ApplyStyles        bool  `xml:"applyStyles,attr,omitempty"`
+	SummaryBelow       *bool `xml:"summaryBelow,attr,omitempty"`       //default true
+	SummaryRight       *bool `xml:"summaryRight,attr,omitempty"`       //default true
+	ShowOutlineSymbols *bool `xml:"showOutlineSymbols,attr,omitempty"` //default true
+}
+
+//PageSetUpPr is a direct mapping of XSD CT_PageSetUpPr
+type PageSetUpPr struct {
+	AutoPageBreaks *bool `xml:"autoPageBreaks,attr,omitempty"` //default true
+	FitToPage      bool  `xml:"fitToPage,attr,omitempty"`
+}
+
+//SheetFormatPr is a direct mapping of XSD CT_SheetFormatPr
+type SheetFormatPr struct {
+	BaseColWidth     int     `xml:"baseColWidth,attr,omitempty"` //default 8
+	DefaultColWidth  float64 `xml:"defaultColWidth,attr,omitempty"`
+	DefaultRowHeight float64 `xml:"defaultRowHeight,attr"`
+	CustomHeight     bool    `xml:"customHeight,attr,omitempty"`
+	ZeroHeight       bool    `xml:"zeroHeight,attr,omitempty"`
+	ThickTop         bool    `xml:"thickTop,attr,omitempty"`
+	ThickBottom      bool    `xml:"thickBottom,attr,omitempty"`
+	OutlineLevelRow  uint8   `xml:"outlineLevelRow,attr,omitempty"`
+	OutlineLevelCol  uint8   `xml:"outlineLevelCol,attr,omitempty"`
+}
+
+//PrintOptions is a direct mapping of XSD CT_PrintOptions
+type PrintOptions struct {
+	HorizontalCentered bool  `xml:"horizontalCentered,attr,omitempty"`
+	VerticalCentered   bool  `xml:"verticalCentered,attr,omitempty"`
+	Headings           bool  `xml:"headings,attr,omitempty"`
+	GridLines          bool  `xml:"gridLines,attr,omitempty"`
+	GridLinesSet       *bool `xml:"gridLinesSet,attr,omitempty"` //default true
+}
+
+//PageMargins is a direct mapping of XSD CT_PageMargins
+type PageMargins struct {
+	Left   float64 `xml:"left,attr"`
+	Right  float64 `xml:"right,attr"`
+	Top    float64 `xml:"top,attr"`
+	Bottom float64 `xml:"bottom,attr"`
+	Header float64 `xml:"header,attr"`
+	Footer float64 `xml:"footer,attr"`
+}
+
+//PageSetup is a direct mapping of XSD CT_PageSetup
+type PageSetup struct {
+	PaperSize          int                        `xml:"paperSize,attr,omitempty"` //default 1
+	PaperHeight        string                     `xml:"paperHeight,attr,omitempty"`
+	PaperWidth         string                     `xml:"paperWidth,attr,omitempty"`
+	Scale              int                        `xml:"scale,attr,omitempty"`           //default 100
+	FirstPageNumber    int                        `xml:"firstPageNumber,attr,omitempty"` //default 1
+	FitToWidth         *int                       `xml:"fitToWidth,attr,omitempty"`      //default 1
+	FitToHeight        *int                       `xml:"fitToHeight,attr,omitempty"`     //default 1
+	PageOrder          primitives.PageOrderType   `xml:"pageOrder,attr,omitempty"`
+	Orientation        primitives.OrientationType `xml:"orientation,attr,omitempty"`
+	UsePrinterDefaults *bool                      `xml:"usePrinterDefaults,attr,omitempty"` //default true
+	BlackAndWhite      bool                       `xml:"blackAndWhite,attr,omitempty"`
+	Draft              bool                       `xml:"draft,attr,omitempty"`
+	CellComments       string                     `xml:"cellComments,attr,omitempty"`
+	UseFirstPageNumber bool                       `xml:"useFirstPageNumber,attr,omitempty"`
+	Errors             string                     `xml:"errors,attr,omitempty"`
+	HorizontalDpi      int                        `xml:"horizontalDpi,attr,omitempty"` //default 600
+	VerticalDpi        int                        `xml:"verticalDpi,attr,omitempty"`   //default 600
+	Copies             int                        `xml:"copies,attr,omitempty"`        //default 1
+	RID                ml.RID                     `xml:"id,attr,omitempty"`
+}
+
+//HeaderFooter is a direct mapping of XSD CT_HeaderFooter
+type HeaderFooter struct {
+	OddHeader        string `xml:"oddHeader,omitempty"`
+	OddFooter        string `xml:"oddFooter,omitempty"`
+	EvenHeader       string `xml:"evenHeader,omitempty"`
+	EvenFooter       string `xml:"evenFooter,omitempty"`
+	FirstHeader      string `xml:"firstHeader,omitempty"`
+	FirstFooter      string `xml:"firstFooter,omitempty"`
+	DifferentOddEven bool   `xml:"differentOddEven,attr,omitempty"`
+	DifferentFirst   bool   `xml:"differentFirst,attr,omitempty"`
+	ScaleWithDoc     *bool  `xml:"scaleWithDoc,attr,omitempty"`     //default true
+	AlignWithMargins *bool  `xml:"alignWithMargins,attr,omitempty"` //default true
+}
+
 //SheetDimension is a direct mapping of XSD CT_SheetDimension
 type SheetDimension struct {
 	Bounds primitives.Bounds `xml:"ref,attr"`
@@ -123,14 +285,14 @@ type MergeCell struct {
 
 //SheetView is a direct mapping of XSD CT_SheetView
 type SheetView struct {
-	Pane                     *ml.Reserved       `xml:"pane,omitempty"`
-	Selection                *ml.Reserved       `xml:"selection,omitempty"`
+	Pane                     *Pane              `xml:"pane,omitempty"`
+	Selection                []*Selection       `xml:"selection,omitempty"`
 	PivotSelection           *ml.Reserved       `xml:"pivotSelection,omitempty"`
 	ExtLst                   *ml.Reserved       `xml:"extLst,omitempty"`
 	WindowProtection         bool               `xml:"windowProtection,attr,omitempty"`
 	ShowFormulas             bool               `xml:"showFormulas,attr,omitempty"`
-	ShowGridLines            bool               `xml:"showGridLines,attr,omitempty"`
-	ShowRowColHeaders        bool               `xml:"showRowColHeaders,attr,omitempty"`
+	ShowGridLines            *bool              `xml:"showGridLines,attr,omitempty"`     //default true
+	ShowRowColHeaders        *bool              `xml:"showRowColHeaders,attr,omitempty"` //default true
 	ShowZeros                bool               `xml:"showZeros,attr,omitempty"`
 	RightToLeft              bool               `xml:"rightToLeft,attr,omitempty"`
 	TabSelected              bool               `xml:"tabSelected,attr,omitempty"`
@@ -148,6 +310,23 @@ type SheetView struct {
 	WorkbookViewId           uint               `xml:"workbookViewId,attr"`
 }
 
+//Pane is a direct mapping of XSD CT_Pane
+type Pane struct {
+	XSplit      float64            `xml:"xSplit,attr,omitempty"`
+	YSplit      float64            `xml:"ySplit,attr,omitempty"`
+	TopLeftCell primitives.CellRef `xml:"topLeftCell,attr,omitempty"`
+	ActivePane  string             `xml:"activePane,attr,omitempty"` //ST_Pane
+	State       string             `xml:"state,attr,omitempty"`      //ST_PaneState
+}
+
+//Selection is a direct mapping of XSD CT_Selection
+type Selection struct {
+	Pane         string                `xml:"pane,attr,omitempty"` //ST_Pane
+	ActiveCell   primitives.CellRef    `xml:"activeCell,attr,omitempty"`
+	ActiveCellID uint                  `xml:"activeCellId,attr,omitempty"`
+	Bounds       primitives.BoundsList `xml:"sqref,attr,omitempty"`
+}
+
 //Hyperlink is a direct mapping of XSD CT_Hyperlink
 type Hyperlink struct {
 	Bounds   primitives.Bounds `xml:"ref,attr"`
@@ -157,6 +336,95 @@ type Hyperlink struct {
 	RID      ml.RID            `xml:"id,attr,omitempty"`
 }
 
+//AutoFilter is a direct mapping of XSD CT_AutoFilter
+type AutoFilter struct {
+	FilterColumns []*FilterColumn   `xml:"filterColumn,omitempty"`
+	SortState     *ml.Reserved      `xml:"sortState,omitempty"`
+	ExtLst        *ml.Reserved      `xml:"extLst,omitempty"`
+	Bounds        primitives.Bounds `xml:"ref,attr,omitempty"`
+}
+
+//FilterColumn is a direct mapping of XSD CT_FilterColumn
+type FilterColumn struct {
+	Filters       *Filters       `xml:"filters,omitempty"`
+	Top10         *Top10Filter   `xml:"top10,omitempty"`
+	CustomFilters *CustomFilters `xml:"customFilters,omitempty"`
+	DynamicFilter *ml.Reserved   `xml:"dynamicFilter,omitempty"`
+	ColorFilter   *ml.Reserved   `xml:"colorFilter,omitempty"`
+	IconFilter    *ml.Reserved   `xml:"iconFilter,omitempty"`
+	ExtLst        *ml.Reserved   `xml:"extLst,omitempty"`
+	ColID         int            `xml:"colId,attr"`
+	HiddenButton  bool           `xml:"hiddenButton,attr,omitempty"`
+	ShowButton    *bool          `xml:"showButton,attr,omitempty"`
+}
+
+//Filters is a direct mapping of XSD CT_Filters
+type Filters struct {
+	Items          []*Filter      `xml:"filter,omitempty"`
+	DateGroupItems []*ml.Reserved `xml:"dateGroupItem,omitempty"`
+	Blank          bool           `xml:"blank,attr,omitempty"`
+	CalendarType   string         `xml:"calendarType,attr,omitempty"`
+}
+
+//Filter is a direct mapping of XSD CT_Filter
+type Filter struct {
+	Val string `xml:"val,attr"`
+}
+
+//Top10Filter is a direct mapping of XSD CT_Top10
+type Top10Filter struct {
+	Top       *bool   `xml:"top,attr,omitempty"`
+	Percent   bool    `xml:"percent,attr,omitempty"`
+	Val       float64 `xml:"val,attr"`
+	FilterVal float64 `xml:"filterVal,attr,omitempty"`
+}
+
+//CustomFilters is a direct mapping of XSD CT_CustomFilters
+type CustomFilters struct {
+	Items []*CustomFilter `xml:"customFilter"`
+	And   bool            `xml:"and,attr,omitempty"`
+}
+
+//CustomFilter is a direct mapping of XSD CT_CustomFilter
+type CustomFilter struct {
+	Operator primitives.FilterOperatorType `xml:"operator,attr,omitempty"`
+	Val      string                        `xml:"val,attr"`
+}
+
+//Drawing is a direct mapping of XSD CT_Drawing
+type Drawing struct {
+	RID ml.RID `xml:"id,attr"`
+}
+
+//LegacyDrawing is a direct mapping of XSD CT_LegacyDrawing
+type LegacyDrawing struct {
+	RID ml.RID `xml:"id,attr"`
+}
+
+//SheetBackgroundPicture is a direct mapping of XSD CT_SheetBackgroundPicture
+type SheetBackgroundPicture struct {
+	RID ml.RID `xml:"id,attr"`
+}
+
+//DataValidation is a direct mapping of XSD CT_DataValidation
+type DataValidation struct {
+	Formula1         primitives.Formula                    `xml:"formula1,omitempty"`
+	Formula2         primitives.Formula                    `xml:"formula2,omitempty"`
+	Type             primitives.DataValidationType         `xml:"type,attr,omitempty"`
+	ErrorStyle       primitives.DataValidationErrorStyle   `xml:"errorStyle,attr,omitempty"`
+	ImeMode          string                                `xml:"imeMode,attr,omitempty"` //ST_DataValidationImeMode
+	Operator         primitives.DataValidationOperatorType `xml:"operator,attr,omitempty"`
+	AllowBlank       bool                                  `xml:"allowBlank,attr,omitempty"`
+	ShowDropDown     bool                                  `xml:"showDropDown,attr,omitempty"` //N.B.: true means hide dropdown
+	ShowInputMessage bool                                  `xml:"showInputMessage,attr,omitempty"`
+	ShowErrorMessage bool                                  `xml:"showErrorMessage,attr,omitempty"`
+	ErrorTitle       string                                `xml:"errorTitle,attr,omitempty"`
+	Error            string                                `xml:"error,attr,omitempty"`
+	PromptTitle      string                                `xml:"promptTitle,attr,omitempty"`
+	Prompt           string                                `xml:"prompt,attr,omitempty"`
+	Bounds           primitives.BoundsList                 `xml:"sqref,attr"`
+}
+
 type ConditionalFormatting struct {
 	Pivot  bool                  `xml:"pivot,attr,omitempty"`
 	Bounds primitives.BoundsList `xml:"sqref,attr"`
@@ -166,7 +434,7 @@ type ConditionalFormatting struct {
 
 //ConditionalRule is a direct mapping of XSD CT_CfRule
 type ConditionalRule struct {
-	Formula      primitives.Formula               `xml:"formula,omitempty"`
+	Formula      []primitives.Formula             `xml:"formula,omitempty"`
 	ColorScale   *ColorScale                      `xml:"colorScale,omitempty"`
 	DataBar      *DataBar                         `xml:"dataBar,omitempty"`
 	IconSet      *IconSet                         `xml:"iconSet,omitempty"`
@@ -189,9 +457,9 @@ type ConditionalRule struct {
 //ConditionValue is a direct mapping of XSD CT_Cfvo
 type ConditionValue struct {
 	ExtLst         *ml.Reserved                  `xml:"extLst,omitempty"`
-	Type           primitives.ConditionValueType `xml:"ST_CfvoType,attr"`
+	Type           primitives.ConditionValueType `xml:"type,attr"`
 	Value          string                        `xml:"val,attr,omitempty"`
-	GreaterOrEqual bool                          `xml:"gte,attr,omitempty"`
+	GreaterOrEqual *bool                         `xml:"gte,attr,omitempty"`
 }
 
 //ColorScale is a direct mapping of XSD CT_ColorScale
@@ -213,7 +481,7 @@ type DataBar struct {
 type IconSet struct {
 	Values    []*ConditionValue      `xml:"cfvo"` //minimum 2 values
 	Type      primitives.IconSetType `xml:"iconSet,attr,omitempty"`
-	ShowValue bool                   `xml:"showValue,attr,omitempty"`
-	Percent   bool                   `xml:"percent,attr,omitempty"`
+	ShowValue *bool                  `xml:"showValue,attr,omitempty"`
+	Percent   *bool                  `xml:"percent,attr,omitempty"`
 	Reverse   bool                   `xml:"reverse,attr,omitempty"`
 }
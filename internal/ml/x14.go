@@ -0,0 +1,178 @@
+package ml
+
+import (
+	"encoding/xml"
+	"github.com/plandem/ooxml/ml"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"strings"
+)
+
+//N.B.: x14 objects are stored inside of extLst that is not unpacked, so these types use prefixed names that Excel expects and must be decoded with UnmarshalExtensions
+
+//List of namespaces and URIs of extensions that are used by x14 objects
+const (
+	NamespaceMain                 = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	NamespaceX14                  = "http://schemas.microsoft.com/office/spreadsheetml/2009/9/main"
+	NamespaceXM                   = "http://schemas.microsoft.com/office/excel/2006/main"
+	ExtURIConditionalFormattings  = "{78C0D931-6437-407d-A8EE-F0AAD7539E65}"
+	ExtURIConditionalFormattingID = "{B025F937-C7B1-47D3-B67F-A62EFF666E3E}"
+	ExtURISparklineGroups         = "{05C60535-1F16-4fd2-B633-F4F36F0B64E0}"
+	NamespaceX15                  = "http://schemas.microsoft.com/office/spreadsheetml/2010/11/main"
+	ExtURIPivotCacheDefinition    = "{725AE2AE-9491-48be-B2B4-4EB974FC3084}"
+	ExtURISlicerList              = "{A8765BA9-456A-4dab-B4F3-ACF838C121DE}"
+	ExtURISlicerCaches            = "{BBE1A952-AA13-448e-AADC-164F8A28A991}"
+	ExtURITableSlicerList         = "{3A4CF648-6AED-40f4-86FF-DC5316D8AED3}"
+	ExtURITableSlicerCaches       = "{46BE6895-7355-4a93-B00E-2C351335B9C9}"
+	ExtURITableSlicerCache        = "{2F2917AC-EB37-4324-AD4E-5DD8C200BD13}"
+)
+
+//ExtensionList is a direct mapping of XSD CT_ExtensionList with known x14 extensions
+type ExtensionList struct {
+	Extensions []*Extension `xml:"ext"`
+}
+
+//Extension is a direct mapping of XSD CT_Extension with known x14 extensions
+type Extension struct {
+	URI             string              `xml:"uri,attr"`
+	SparklineGroups *X14SparklineGroups `xml:"x14:sparklineGroups,omitempty"`
+}
+
+//X14ConditionalFormatting is a direct mapping of XSD x14:CT_ConditionalFormatting
+type X14ConditionalFormatting struct {
+	XMLName     ml.Name               `xml:"x14:conditionalFormatting"`
+	NamespaceXM string                `xml:"xmlns:xm,attr"`
+	Rules       []*X14ConditionalRule `xml:"x14:cfRule"`
+	Bounds      string                `xml:"xm:sqref"`
+}
+
+//X14ConditionalRule is a direct mapping of XSD x14:CT_CfRule
+type X14ConditionalRule struct {
+	DataBar *X14DataBar              `xml:"x14:dataBar,omitempty"`
+	Type    primitives.ConditionType `xml:"type,attr"`
+	ID      string                   `xml:"id,attr"`
+}
+
+//X14DataBar is a direct mapping of XSD x14:CT_DataBar
+type X14DataBar struct {
+	Values                               []*X14ConditionValue           `xml:"x14:cfvo"` //2 values only
+	FillColor                            *Color                         `xml:"x14:fillColor,omitempty"`
+	BorderColor                          *Color                         `xml:"x14:borderColor,omitempty"`
+	NegativeFillColor                    *Color                         `xml:"x14:negativeFillColor,omitempty"`
+	NegativeBorderColor                  *Color                         `xml:"x14:negativeBorderColor,omitempty"`
+	AxisColor                            *Color                         `xml:"x14:axisColor,omitempty"`
+	MinLength                            uint                           `xml:"minLength,attr"`
+	MaxLength                            uint                           `xml:"maxLength,attr"`
+	Border                               bool                           `xml:"border,attr,omitempty"`
+	Gradient                             *bool                          `xml:"gradient,attr,omitempty"`
+	Direction                            primitives.DataBarDirection    `xml:"direction,attr,omitempty"`
+	NegativeBarColorSameAsPositive       bool                           `xml:"negativeBarColorSameAsPositive,attr,omitempty"`
+	NegativeBarBorderColorSameAsPositive *bool                          `xml:"negativeBarBorderColorSameAsPositive,attr,omitempty"`
+	AxisPosition                         primitives.DataBarAxisPosition `xml:"axisPosition,attr,omitempty"`
+}
+
+//X14ConditionValue is a direct mapping of XSD x14:CT_Cfvo
+type X14ConditionValue struct {
+	Formula        string                        `xml:"xm:f,omitempty"`
+	Type           primitives.ConditionValueType `xml:"type,attr"`
+	GreaterOrEqual *bool                         `xml:"gte,attr,omitempty"`
+}
+
+//X14ConditionalRuleID is a direct mapping of XSD CT_Extension that links conditional rule with x14 rule
+type X14ConditionalRuleID struct {
+	XMLName      ml.Name `xml:"ext"`
+	URI          string  `xml:"uri,attr"`
+	NamespaceX14 string  `xml:"xmlns:x14,attr"`
+	ID           string  `xml:"x14:id"`
+}
+
+//X14SparklineGroups is a direct mapping of XSD x14:CT_SparklineGroups
+type X14SparklineGroups struct {
+	XMLName     ml.Name              `xml:"x14:sparklineGroups"`
+	NamespaceXM string               `xml:"xmlns:xm,attr"`
+	Groups      []*X14SparklineGroup `xml:"x14:sparklineGroup"`
+}
+
+//X14SparklineGroup is a direct mapping of XSD x14:CT_SparklineGroup
+type X14SparklineGroup struct {
+	ColorSeries         *Color                       `xml:"x14:colorSeries,omitempty"`
+	ColorNegative       *Color                       `xml:"x14:colorNegative,omitempty"`
+	ColorAxis           *Color                       `xml:"x14:colorAxis,omitempty"`
+	ColorMarkers        *Color                       `xml:"x14:colorMarkers,omitempty"`
+	ColorFirst          *Color                       `xml:"x14:colorFirst,omitempty"`
+	ColorLast           *Color                       `xml:"x14:colorLast,omitempty"`
+	ColorHigh           *Color                       `xml:"x14:colorHigh,omitempty"`
+	ColorLow            *Color                       `xml:"x14:colorLow,omitempty"`
+	DateAxisFormula     string                       `xml:"xm:f,omitempty"`
+	Sparklines          []*X14Sparkline              `xml:"x14:sparklines>x14:sparkline"`
+	ManualMax           *float64                     `xml:"manualMax,attr,omitempty"`
+	ManualMin           *float64                     `xml:"manualMin,attr,omitempty"`
+	LineWeight          float64                      `xml:"lineWeight,attr,omitempty"`
+	Type                primitives.SparklineType     `xml:"type,attr,omitempty"`
+	DateAxis            bool                         `xml:"dateAxis,attr,omitempty"`
+	DisplayEmptyCellsAs primitives.DisplayBlanksAs   `xml:"displayEmptyCellsAs,attr,omitempty"`
+	Markers             bool                         `xml:"markers,attr,omitempty"`
+	High                bool                         `xml:"high,attr,omitempty"`
+	Low                 bool                         `xml:"low,attr,omitempty"`
+	First               bool                         `xml:"first,attr,omitempty"`
+	Last                bool                         `xml:"last,attr,omitempty"`
+	Negative            bool                         `xml:"negative,attr,omitempty"`
+	DisplayXAxis        bool                         `xml:"displayXAxis,attr,omitempty"`
+	DisplayHidden       bool                         `xml:"displayHidden,attr,omitempty"`
+	MinAxisType         primitives.SparklineAxisType `xml:"minAxisType,attr,omitempty"`
+	MaxAxisType         primitives.SparklineAxisType `xml:"maxAxisType,attr,omitempty"`
+	RightToLeft         bool                         `xml:"rightToLeft,attr,omitempty"`
+}
+
+//X14Sparkline is a direct mapping of XSD x14:CT_Sparkline
+type X14Sparkline struct {
+	Formula string `xml:"xm:f"`
+	Bounds  string `xml:"xm:sqref"`
+}
+
+//prefixedTokenReader keeps prefixes of names as is, so prefixed names of x14 objects can be decoded without resolving of namespaces
+type prefixedTokenReader struct {
+	decoder *xml.Decoder
+}
+
+func (r *prefixedTokenReader) Token() (xml.Token, error) {
+	t, err := r.decoder.RawToken()
+	if err != nil {
+		return nil, err
+	}
+
+	prefixed := func(name xml.Name) xml.Name {
+		if len(name.Space) > 0 {
+			return xml.Name{Local: name.Space + ":" + name.Local}
+		}
+
+		return name
+	}
+
+	switch v := t.(type) {
+	case xml.StartElement:
+		v.Name = prefixed(v.Name)
+		attrs := make([]xml.Attr, len(v.Attr))
+		for i, a := range v.Attr {
+			attrs[i] = xml.Attr{Name: prefixed(a.Name), Value: a.Value}
+		}
+
+		v.Attr = attrs
+		return v, nil
+	case xml.EndElement:
+		v.Name = prefixed(v.Name)
+		return v, nil
+	}
+
+	return xml.CopyToken(t), nil
+}
+
+//UnmarshalExtensions decodes known x14 extensions from content of extLst
+func UnmarshalExtensions(content string) (*ExtensionList, error) {
+	list := &ExtensionList{}
+	decoder := xml.NewTokenDecoder(&prefixedTokenReader{decoder: xml.NewDecoder(strings.NewReader("<extLst>" + content + "</extLst>"))})
+	if err := decoder.Decode(list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
@@ -1,6 +1,7 @@
 package convert
 
 import (
+	"math"
 	"strconv"
 	"time"
 )
@@ -8,8 +9,14 @@ import (
 const (
 	//ISO8601 is format for ISO8601 dates. Like RFC3339, but without timezone
 	ISO8601 = "2006-01-02T15:04:05"
+
+	//Date1904Offset is a difference in days between serial dates of 1900 and 1904 date systems
+	Date1904Offset = 1462
 )
 
+//excelEpoch is a base date for serial dates of 1900 date system
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
 //ToBool tries to convert string into bool type
 func ToBool(value string) (bool, error) {
 	return strconv.ParseBool(value)
@@ -36,3 +43,19 @@ func ToDate(value string) (time.Time, error) {
 
 	return time.Parse(ISO8601, value)
 }
+
+//FromSerial converts serial date into time.Time type, rounding to milliseconds
+func FromSerial(serial float64) time.Time {
+	return excelEpoch.Add(ToSerialDuration(serial))
+}
+
+//ToSerialDuration converts serial value of days into time.Duration type, rounding to milliseconds
+func ToSerialDuration(serial float64) time.Duration {
+	return time.Duration(math.Round(serial*86400*1000)) * time.Millisecond
+}
+
+//ToSerial converts time.Time into serial date of 1900 date system. Serial dates have no time zone, so wall clock of date is used regardless of location
+func ToSerial(date time.Time) float64 {
+	date = time.Date(date.Year(), date.Month(), date.Day(), date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), time.UTC)
+	return float64(date.Sub(excelEpoch)) / float64(24*time.Hour)
+}
@@ -3,6 +3,7 @@ package convert
 import (
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestToBool(t *testing.T) {
@@ -71,3 +72,10 @@ func TestToDate(t *testing.T) {
 	_, err = ToDate("dsdsds")
 	require.NotNil(t, err)
 }
+
+func TestFromSerial(t *testing.T) {
+	require.Equal(t, "2018-07-27 16:54:47 +0000 UTC", FromSerial(43308.7047106481).String())
+	require.Equal(t, 43308.5, ToSerial(time.Date(2018, 7, 27, 12, 0, 0, 0, time.UTC)))
+	require.Equal(t, 43308.5, ToSerial(time.Date(2018, 7, 27, 12, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60))))
+	require.Equal(t, 36*time.Hour, ToSerialDuration(1.5))
+}
@@ -0,0 +1,1022 @@
+package numberFormat
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/plandem/xlsx/internal/number_format/convert"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type tokenType byte
+
+//List of all possible types of tokens of number format
+const (
+	tokenLiteral tokenType = iota
+	tokenGeneral
+	tokenText
+	tokenDigitZero
+	tokenDigitHash
+	tokenDigitSpace
+	tokenDecimalPoint
+	tokenThousands
+	tokenPercent
+	tokenExponent
+	tokenFraction
+	tokenYear
+	tokenMonth
+	tokenDay
+	tokenHour
+	tokenMinute
+	tokenSecond
+	tokenSubSecond
+	tokenElapsedHour
+	tokenElapsedMinute
+	tokenElapsedSecond
+	tokenAmPm
+	tokenCondition
+)
+
+type token struct {
+	kind tokenType
+	text string
+}
+
+//section is a one of sections of number format code separated by semicolon
+type section struct {
+	tokens    []token
+	condition func(float64) bool
+	isDate    bool
+	isTime    bool
+}
+
+//splitSections splits code into sections, respecting quoted text and escaped characters
+func splitSections(code string) []string {
+	var sections []string
+	var quoted bool
+
+	last := 0
+	for i := 0; i < len(code); i++ {
+		switch c := code[i]; {
+		case c == '"':
+			quoted = !quoted
+		case c == '\\' && !quoted:
+			i++
+		case c == ';' && !quoted:
+			sections = append(sections, code[last:i])
+			last = i + 1
+		}
+	}
+
+	return append(sections, code[last:])
+}
+
+//parseCondition parses condition of section, e.g.: [>=100]
+func parseCondition(s string) func(float64) bool {
+	op := strings.TrimRightFunc(s, func(r rune) bool { return unicode.IsDigit(r) || r == '.' || r == '-' })
+	value, err := strconv.ParseFloat(s[len(op):], 64)
+	if err != nil {
+		return nil
+	}
+
+	switch op {
+	case "<":
+		return func(v float64) bool { return v < value }
+	case "<=":
+		return func(v float64) bool { return v <= value }
+	case ">":
+		return func(v float64) bool { return v > value }
+	case ">=":
+		return func(v float64) bool { return v >= value }
+	case "=":
+		return func(v float64) bool { return v == value }
+	case "<>":
+		return func(v float64) bool { return v != value }
+	}
+
+	return nil
+}
+
+//parseSection parses a section of number format code into tokens
+func parseSection(code string) *section {
+	s := &section{}
+	runes := []rune(code)
+	lower := []rune(strings.ToLower(code))
+
+	count := func(i int, c rune) int {
+		n := 0
+		for i+n < len(lower) && lower[i+n] == c {
+			n++
+		}
+
+		return n
+	}
+
+	add := func(kind tokenType, text string) {
+		//merge literals to simplify formatting
+		if kind == tokenLiteral && len(s.tokens) > 0 && s.tokens[len(s.tokens)-1].kind == tokenLiteral {
+			s.tokens[len(s.tokens)-1].text += text
+			return
+		}
+
+		s.tokens = append(s.tokens, token{kind, text})
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := lower[i]
+
+		switch {
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+
+			add(tokenLiteral, string(runes[i+1:min(end, len(runes))]))
+			i = end
+		case r == '\\':
+			if i+1 < len(runes) {
+				add(tokenLiteral, string(runes[i+1]))
+				i++
+			}
+		case r == '_':
+			//space with width of next character
+			add(tokenLiteral, " ")
+			i++
+		case r == '*':
+			//repeat next character to fill width of cell
+			i++
+		case r == '[':
+			end := i + 1
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+
+			inner := string(lower[i+1 : min(end, len(runes))])
+			i = end
+
+			switch {
+			case len(inner) > 0 && strings.Trim(inner, "h") == "":
+				add(tokenElapsedHour, inner)
+				s.isTime = true
+			case len(inner) > 0 && strings.Trim(inner, "m") == "":
+				add(tokenElapsedMinute, inner)
+				s.isTime = true
+			case len(inner) > 0 && strings.Trim(inner, "s") == "":
+				add(tokenElapsedSecond, inner)
+				s.isTime = true
+			case strings.HasPrefix(inner, "$"):
+				//locale and currency, e.g.: [$€-407] or [$-F800]
+				parts := strings.SplitN(string(runes[i-len([]rune(inner)) : i])[1:], "-", 2)
+				if len(parts[0]) > 0 {
+					add(tokenLiteral, parts[0])
+				}
+
+				if len(parts) > 1 {
+					switch strings.ToUpper(parts[1]) {
+					case "F800", "X-SYSDATE":
+						//system long date
+						s.isDate = true
+						s.tokens = append(s.tokens, token{tokenDay, "dddd"}, token{tokenLiteral, ", "}, token{tokenMonth, "mmmm"}, token{tokenLiteral, " "}, token{tokenDay, "dd"}, token{tokenLiteral, ", "}, token{tokenYear, "yyyy"})
+					case "F400", "X-SYSTIME":
+						//system time
+						s.isTime = true
+						s.tokens = append(s.tokens, token{tokenHour, "h"}, token{tokenLiteral, ":"}, token{tokenMinute, "mm"}, token{tokenLiteral, ":"}, token{tokenSecond, "ss"}, token{tokenLiteral, " "}, token{tokenAmPm, "am/pm"})
+					}
+				}
+			case len(inner) > 0 && strings.ContainsAny(inner[:1], "<>="):
+				s.condition = parseCondition(inner)
+				add(tokenCondition, inner)
+			}
+			//N.B.: other brackets are colors, e.g.: [Red] or [Color10], that are not affecting text
+		case strings.HasPrefix(string(lower[i:]), "general"):
+			add(tokenGeneral, "")
+			i += len("general") - 1
+		case strings.HasPrefix(string(lower[i:]), "am/pm"):
+			add(tokenAmPm, "am/pm")
+			s.isTime = true
+			i += len("am/pm") - 1
+		case strings.HasPrefix(string(lower[i:]), "a/p"):
+			add(tokenAmPm, string(runes[i:i+3]))
+			s.isTime = true
+			i += len("a/p") - 1
+		case r == 'e' && i+1 < len(runes) && (runes[i+1] == '+' || runes[i+1] == '-'):
+			add(tokenExponent, string(runes[i+1]))
+			i++
+		case r == 'y' || r == 'e':
+			n := count(i, r)
+			add(tokenYear, strings.Repeat("y", n))
+			s.isDate = true
+			i += n - 1
+		case r == 'm':
+			n := count(i, r)
+			add(tokenMonth, strings.Repeat("m", n))
+			s.isDate = true
+			i += n - 1
+		case r == 'd':
+			n := count(i, r)
+			add(tokenDay, strings.Repeat("d", n))
+			s.isDate = true
+			i += n - 1
+		case r == 'h':
+			n := count(i, r)
+			add(tokenHour, strings.Repeat("h", n))
+			s.isTime = true
+			i += n - 1
+		case r == 's':
+			n := count(i, r)
+			add(tokenSecond, strings.Repeat("s", n))
+			s.isTime = true
+			i += n - 1
+
+			//fractions of second
+			if i+2 < len(runes) && runes[i+1] == '.' && runes[i+2] == '0' {
+				n = count(i+2, '0')
+				add(tokenSubSecond, strings.Repeat("0", n))
+				i += n + 1
+			}
+		case r == '0':
+			add(tokenDigitZero, "0")
+		case r == '#':
+			add(tokenDigitHash, "#")
+		case r == '?':
+			add(tokenDigitSpace, "?")
+		case r == '.':
+			add(tokenDecimalPoint, ".")
+		case r == ',':
+			add(tokenThousands, ",")
+		case r == '%':
+			add(tokenPercent, "%")
+		case r == '/':
+			add(tokenFraction, "/")
+		case r == '@':
+			add(tokenText, "@")
+		case r == 'b' || r == 'g':
+			//buddhist year and japanese era are not supported
+		default:
+			add(tokenLiteral, string(runes[i]))
+		}
+	}
+
+	//minutes and months have same code, so resolve it through context: minutes go after hours or before seconds
+	prev := tokenLiteral
+	for i, t := range s.tokens {
+		if t.kind == tokenMonth && len(t.text) <= 2 {
+			next := tokenLiteral
+			for _, n := range s.tokens[i+1:] {
+				if n.kind != tokenLiteral {
+					next = n.kind
+					break
+				}
+			}
+
+			if prev == tokenHour || prev == tokenElapsedHour || next == tokenSecond || next == tokenElapsedSecond {
+				s.tokens[i].kind = tokenMinute
+				s.isTime = true
+			}
+		}
+
+		if t.kind != tokenLiteral {
+			prev = s.tokens[i].kind
+		}
+	}
+
+	//date flag can be set by minutes only
+	s.isDate = false
+	for _, t := range s.tokens {
+		if t.kind == tokenYear || t.kind == tokenMonth || t.kind == tokenDay {
+			s.isDate = true
+		}
+	}
+
+	//'e' has a meaning of year in date formats only, so restore exponent for numbers otherwise
+	if !s.isDate && !s.isTime {
+		s.tokens = parseNumberSection(code)
+	}
+
+	return s
+}
+
+//parseNumberSection parses section of numeric format code
+func parseNumberSection(code string) []token {
+	var tokens []token
+	runes := []rune(code)
+
+	for i := 0; i < len(runes); i++ {
+		var t token
+
+		switch r := runes[i]; {
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+
+			t = token{tokenLiteral, string(runes[i+1 : min(end, len(runes))])}
+			i = end
+		case r == '\\' && i+1 < len(runes):
+			t = token{tokenLiteral, string(runes[i+1])}
+			i++
+		case r == '_':
+			t = token{tokenLiteral, " "}
+			i++
+		case r == '*':
+			i++
+			continue
+		case r == '[':
+			end := i + 1
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+
+			inner := string(runes[i+1 : min(end, len(runes))])
+			i = end
+			if strings.HasPrefix(inner, "$") {
+				if symbol := strings.SplitN(inner[1:], "-", 2)[0]; len(symbol) > 0 {
+					t = token{tokenLiteral, symbol}
+				}
+			}
+
+			if len(t.text) == 0 {
+				continue
+			}
+		case (r == 'E' || r == 'e') && i+1 < len(runes) && (runes[i+1] == '+' || runes[i+1] == '-'):
+			t = token{tokenExponent, string(runes[i+1])}
+			i++
+		case strings.HasPrefix(strings.ToLower(string(runes[i:])), "general"):
+			t = token{tokenGeneral, ""}
+			i += len("general") - 1
+		case r == '0':
+			t = token{tokenDigitZero, "0"}
+		case r == '#':
+			t = token{tokenDigitHash, "#"}
+		case r == '?':
+			t = token{tokenDigitSpace, "?"}
+		case r == '.':
+			t = token{tokenDecimalPoint, "."}
+		case r == ',':
+			t = token{tokenThousands, ","}
+		case r == '%':
+			t = token{tokenPercent, "%"}
+		case r == '/':
+			t = token{tokenFraction, "/"}
+		case r == '@':
+			t = token{tokenText, "@"}
+		default:
+			t = token{tokenLiteral, string(r)}
+		}
+
+		if t.kind == tokenLiteral && len(tokens) > 0 && tokens[len(tokens)-1].kind == tokenLiteral {
+			tokens[len(tokens)-1].text += t.text
+		} else {
+			tokens = append(tokens, t)
+		}
+	}
+
+	return tokens
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+//parse parses code into sections
+func parse(code string) []*section {
+	var result []*section
+	for _, s := range splitSections(code) {
+		result = append(result, parseSection(s))
+	}
+
+	return result
+}
+
+//IsDate returns true if number format code has date part, e.g.: yyyy-mm-dd
+func IsDate(code string) bool {
+	return parse(code)[0].isDate
+}
+
+//IsTime returns true if number format code has time part, e.g.: hh:mm:ss
+func IsTime(code string) bool {
+	return parse(code)[0].isTime
+}
+
+//IsCurrency returns true if number format code has currency symbol, e.g.: $#,##0.00
+func IsCurrency(code string) bool {
+	s := parse(code)[0]
+	if s.isDate || s.isTime {
+		return false
+	}
+
+	for _, t := range s.tokens {
+		if t.kind == tokenLiteral {
+			for _, r := range t.text {
+				if unicode.Is(unicode.Sc, r) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+//IsDateID returns true if id is a built-in number format for date or time, including locale specific built-in formats
+func IsDateID(id int) bool {
+	if f, ok := builtIn[id]; ok {
+		return f.Type == Date || f.Type == Time || f.Type == DateTime || f.Type == DeltaTime
+	}
+
+	//N.B.: locale specific built-in formats are dates, e.g. for East Asian locales
+	return (id >= 27 && id <= 36) || (id >= 50 && id <= 58)
+}
+
+//Format tries to format value into required format code
+func Format(value, code string, t primitives.CellType) string {
+	switch t.String() {
+	case "e":
+		return value
+	case "b":
+		if value == "1" || strings.EqualFold(value, "true") {
+			return "TRUE"
+		}
+
+		return "FALSE"
+	case "s", "str", "inlineStr":
+		return formatText(value, code)
+	case "d":
+		date, err := time.Parse("2006-01-02T15:04:05", value)
+		if err != nil {
+			return value
+		}
+
+		sections := parse(code)
+		if !sections[0].isDate && !sections[0].isTime {
+			return value
+		}
+
+		return formatDate(convert.ToSerial(date), sections[0])
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return formatText(value, code)
+	}
+
+	return FormatNumber(number, code)
+}
+
+//formatText formats text according to the text section of code
+func formatText(value, code string) string {
+	sections := parse(code)
+
+	var text *section
+	if len(sections) >= 4 {
+		text = sections[3]
+	} else {
+		for _, t := range sections[0].tokens {
+			if t.kind == tokenText {
+				text = sections[0]
+			}
+		}
+	}
+
+	if text == nil {
+		return value
+	}
+
+	var result strings.Builder
+	for _, t := range text.tokens {
+		switch t.kind {
+		case tokenText:
+			result.WriteString(value)
+		case tokenLiteral:
+			result.WriteString(t.text)
+		}
+	}
+
+	return result.String()
+}
+
+//FormatNumber formats number according to the code
+func FormatNumber(value float64, code string) string {
+	sections := parse(code)
+	s := sections[0]
+	sign := value < 0
+
+	switch {
+	case sections[0].condition != nil:
+		//sections with conditions
+		s = nil
+		for _, section := range sections {
+			if section.condition == nil || section.condition(value) {
+				s = section
+				break
+			}
+		}
+
+		if s == nil {
+			return strings.Repeat("#", 10)
+		}
+
+		if s != sections[0] && s.condition == nil && len(sections) > 2 {
+			value = math.Abs(value)
+			sign = false
+		}
+	case value < 0 && len(sections) > 1:
+		s, value, sign = sections[1], -value, false
+	case value == 0 && len(sections) > 2:
+		s = sections[2]
+	}
+
+	if s.isDate || s.isTime {
+		if value < 0 {
+			return strings.Repeat("#", 10)
+		}
+
+		return formatDate(value, s)
+	}
+
+	result := formatNumber(math.Abs(value), s)
+	if sign && strings.ContainsAny(result, "123456789") {
+		result = "-" + result
+	}
+
+	return result
+}
+
+//formatGeneral formats number as Excel does for General format
+func formatGeneral(value float64) string {
+	abs := math.Abs(value)
+	if abs != 0 && (abs >= 1e11 || abs < 1e-9) {
+		s := strconv.FormatFloat(value, 'E', 5, 64)
+		parts := strings.SplitN(s, "E", 2)
+		mantissa := strings.TrimRight(strings.TrimRight(parts[0], "0"), ".")
+		exp := parts[1]
+		if len(exp) == 2 {
+			exp = exp[:1] + "0" + exp[1:]
+		}
+
+		return mantissa + "E" + exp
+	}
+
+	intDigits := len(strconv.FormatFloat(math.Trunc(abs), 'f', 0, 64))
+	decimals := 10 - intDigits
+	if decimals < 0 {
+		decimals = 0
+	}
+
+	s := strconv.FormatFloat(value, 'f', decimals, 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+
+	if s == "-0" {
+		s = "0"
+	}
+
+	return s
+}
+
+//formatNumber formats non-negative number according to the section
+func formatNumber(value float64, s *section) string {
+	tokens := s.tokens
+
+	hasDigits := false
+	for _, t := range tokens {
+		switch t.kind {
+		case tokenGeneral, tokenText:
+			return formatLiterals(tokens, formatGeneral(value))
+		case tokenDigitZero, tokenDigitHash, tokenDigitSpace:
+			hasDigits = true
+		}
+	}
+
+	if !hasDigits {
+		return formatLiterals(tokens, "")
+	}
+
+	//percents and scaling by thousands
+	for i, t := range tokens {
+		switch t.kind {
+		case tokenPercent:
+			value *= 100
+		case tokenThousands:
+			//thousands separator after last digit placeholder scales number
+			scaling := true
+			for _, n := range tokens[i+1:] {
+				if n.kind == tokenDigitZero || n.kind == tokenDigitHash || n.kind == tokenDigitSpace {
+					scaling = false
+					break
+				}
+
+				if n.kind == tokenDecimalPoint {
+					break
+				}
+			}
+
+			if scaling {
+				value /= 1000
+				tokens[i].kind = tokenLiteral
+				tokens[i].text = ""
+			}
+		}
+	}
+
+	//split tokens into integer, decimal, exponent and fraction parts
+	var intPart, decPart, expPart []int
+	fraction := -1
+	exponent := -1
+	decimal := -1
+	for i, t := range tokens {
+		switch t.kind {
+		case tokenDecimalPoint:
+			if decimal == -1 && exponent == -1 && fraction == -1 {
+				decimal = i
+			}
+		case tokenExponent:
+			exponent = i
+		case tokenFraction:
+			fraction = i
+		case tokenDigitZero, tokenDigitHash, tokenDigitSpace:
+			switch {
+			case exponent != -1:
+				expPart = append(expPart, i)
+			case decimal != -1:
+				decPart = append(decPart, i)
+			default:
+				intPart = append(intPart, i)
+			}
+		}
+	}
+
+	if fraction != -1 {
+		return formatFraction(value, tokens, fraction)
+	}
+
+	grouping := false
+	for _, t := range tokens {
+		if t.kind == tokenThousands {
+			grouping = true
+		}
+	}
+
+	exp := 0
+	if exponent != -1 && value != 0 {
+		step := 1
+		if len(intPart) > 1 && tokens[intPart[0]].kind == tokenDigitHash {
+			//engineering notation, e.g.: ##0.0E+0
+			step = len(intPart)
+		}
+
+		exp = int(math.Floor(math.Log10(value)))
+		exp = int(math.Floor(float64(exp)/float64(step))) * step
+		value /= math.Pow(10, float64(exp))
+	}
+
+	digits := strconv.FormatFloat(value, 'f', len(decPart), 64)
+	intDigits, decDigits := digits, ""
+	if len(decPart) > 0 {
+		intDigits, decDigits = digits[:len(digits)-len(decPart)-1], digits[len(digits)-len(decPart):]
+	}
+
+	if intDigits == "0" {
+		intDigits = ""
+	}
+
+	result := make([]string, len(tokens))
+	for i, t := range tokens {
+		switch t.kind {
+		case tokenLiteral:
+			result[i] = t.text
+		case tokenDecimalPoint:
+			if i == decimal {
+				result[i] = "."
+			}
+		case tokenPercent:
+			result[i] = "%"
+		case tokenExponent:
+			sign := ""
+			if exp < 0 {
+				sign = "-"
+			} else if t.text == "+" {
+				sign = "+"
+			}
+
+			result[i] = "E" + sign
+		}
+	}
+
+	//integer digits are filled from right to left, extra digits go to the first placeholder
+	fill := func(positions []int, digits string, grouping bool) {
+		if grouping {
+			//N.B.: grouping is applied to placeholders as a whole
+			minDigits := 0
+			for _, p := range positions {
+				if tokens[p].kind == tokenDigitZero {
+					minDigits = len(positions) - indexOf(positions, p)
+					break
+				}
+			}
+
+			for len(digits) < minDigits {
+				digits = "0" + digits
+			}
+
+			var grouped strings.Builder
+			for i, d := range digits {
+				if i > 0 && (len(digits)-i)%3 == 0 {
+					grouped.WriteByte(',')
+				}
+
+				grouped.WriteRune(d)
+			}
+
+			result[positions[0]] = grouped.String()
+			return
+		}
+
+		for j := len(positions) - 1; j >= 0; j-- {
+			p := positions[j]
+			switch {
+			case len(digits) > 0 && j == 0:
+				result[p] = digits
+				digits = ""
+			case len(digits) > 0:
+				result[p] = digits[len(digits)-1:]
+				digits = digits[:len(digits)-1]
+			case tokens[p].kind == tokenDigitZero:
+				result[p] = "0"
+			case tokens[p].kind == tokenDigitSpace:
+				result[p] = " "
+			}
+		}
+	}
+
+	if len(intPart) > 0 {
+		fill(intPart, intDigits, grouping)
+	} else if len(intDigits) > 0 {
+		//number has integer part, but there is no placeholder for it
+		if decimal != -1 {
+			result[decimal] = intDigits + "."
+		}
+	}
+
+	//decimal digits are filled from left to right, trailing zeros are removed for optional placeholders
+	for j := len(decPart) - 1; j >= 0; j-- {
+		p, d := decPart[j], decDigits[j:j+1]
+		if d == "0" && tokens[p].kind != tokenDigitZero && strings.TrimRight(decDigits[j:], "0") == "" {
+			if tokens[p].kind == tokenDigitSpace {
+				result[p] = " "
+			}
+
+			continue
+		}
+
+		result[p] = d
+	}
+
+	if exponent != -1 {
+		fill(expPart, strconv.Itoa(int(math.Abs(float64(exp)))), false)
+	}
+
+	return strings.Join(result, "")
+}
+
+func indexOf(list []int, value int) int {
+	for i, v := range list {
+		if v == value {
+			return i
+		}
+	}
+
+	return -1
+}
+
+//formatFraction formats number as fraction, e.g.: # ?/? or # ??/16
+func formatFraction(value float64, tokens []token, fraction int) string {
+	//placeholders of numerator are digits right before fraction, integer part is placeholders separated by literal
+	numStart := fraction
+	for numStart > 0 && isDigitToken(tokens[numStart-1]) {
+		numStart--
+	}
+
+	intEnd := numStart
+	for intEnd > 0 && !isDigitToken(tokens[intEnd-1]) {
+		intEnd--
+	}
+
+	hasInteger := false
+	for i := 0; i < intEnd; i++ {
+		if isDigitToken(tokens[i]) {
+			hasInteger = true
+		}
+	}
+
+	denEnd := fraction + 1
+	fixedDenominator := ""
+	for denEnd < len(tokens) && (isDigitToken(tokens[denEnd]) || (tokens[denEnd].kind == tokenLiteral && strings.Trim(tokens[denEnd].text, "0123456789") == "" && len(tokens[denEnd].text) > 0)) {
+		if tokens[denEnd].kind == tokenLiteral {
+			fixedDenominator += tokens[denEnd].text
+		}
+
+		denEnd++
+	}
+
+	integer := 0.0
+	if hasInteger {
+		integer = math.Floor(value)
+		value -= integer
+	}
+
+	num, den := 0, 1
+	if d, err := strconv.Atoi(fixedDenominator); err == nil && d > 0 {
+		den = d
+		num = int(math.Round(value * float64(d)))
+	} else {
+		maxDen := int(math.Pow(10, float64(denEnd-fraction-1))) - 1
+		if maxDen < 1 {
+			maxDen = 9
+		}
+
+		bestErr := math.Inf(1)
+		for d := 1; d <= maxDen; d++ {
+			n := int(math.Round(value * float64(d)))
+			if e := math.Abs(value - float64(n)/float64(d)); e < bestErr-1e-12 {
+				bestErr, num, den = e, n, d
+			}
+		}
+	}
+
+	if hasInteger && num == den {
+		integer++
+		num = 0
+	}
+
+	var result strings.Builder
+	if hasInteger {
+		if integer > 0 || num == 0 {
+			result.WriteString(strconv.FormatFloat(integer, 'f', 0, 64))
+		}
+
+		for i := intEnd; i < numStart; i++ {
+			if integer > 0 && num != 0 {
+				result.WriteString(tokens[i].text)
+			}
+		}
+	}
+
+	if num != 0 || !hasInteger {
+		result.WriteString(strconv.Itoa(num) + "/" + strconv.Itoa(den))
+	}
+
+	for _, t := range tokens[denEnd:] {
+		if t.kind == tokenLiteral {
+			result.WriteString(t.text)
+		}
+	}
+
+	return result.String()
+}
+
+func isDigitToken(t token) bool {
+	return t.kind == tokenDigitZero || t.kind == tokenDigitHash || t.kind == tokenDigitSpace
+}
+
+//formatLiterals formats tokens of section that has no digits
+func formatLiterals(tokens []token, general string) string {
+	var result strings.Builder
+	for _, t := range tokens {
+		switch t.kind {
+		case tokenLiteral:
+			result.WriteString(t.text)
+		case tokenGeneral, tokenText:
+			result.WriteString(general)
+		case tokenPercent:
+			result.WriteString("%")
+		}
+	}
+
+	return result.String()
+}
+
+//formatDate formats serial date according to the section
+func formatDate(serial float64, s *section) string {
+	subSecond := 0
+	for _, t := range s.tokens {
+		if t.kind == tokenSubSecond {
+			subSecond = len(t.text)
+		}
+	}
+
+	//round to the precision of format
+	precision := math.Pow(10, float64(subSecond))
+	totalSeconds := math.Round(serial*86400*precision) / precision
+	date := convert.FromSerial(totalSeconds / 86400)
+
+	//N.B.: Excel treats 1900 as leap year, so dates before 1 Mar 1900 are shifted by a day and serial 60 is 29 Feb 1900. Days of week are same as Excel has for such dates
+	weekday := date.Weekday()
+	year, month, day := date.Date()
+	if days := totalSeconds / 86400; days >= 60 && days < 61 {
+		year, month, day = 1900, time.February, 29
+	} else if days >= 1 && days < 60 {
+		year, month, day = date.AddDate(0, 0, 1).Date()
+	}
+
+	hasAmPm := false
+	for _, t := range s.tokens {
+		if t.kind == tokenAmPm {
+			hasAmPm = true
+		}
+	}
+
+	var result strings.Builder
+	for _, t := range s.tokens {
+		switch t.kind {
+		case tokenLiteral, tokenDecimalPoint, tokenThousands, tokenFraction, tokenPercent, tokenDigitZero, tokenDigitHash:
+			//N.B.: separators of dates are same as for numbers, e.g.: dd.mm.yyyy or m/d/yy
+			result.WriteString(t.text)
+		case tokenYear:
+			if len(t.text) <= 2 {
+				result.WriteString(pad(year%100, 2))
+			} else {
+				result.WriteString(pad(year, 4))
+			}
+		case tokenMonth:
+			switch len(t.text) {
+			case 1:
+				result.WriteString(strconv.Itoa(int(month)))
+			case 2:
+				result.WriteString(pad(int(month), 2))
+			case 3:
+				result.WriteString(month.String()[:3])
+			case 5:
+				result.WriteString(month.String()[:1])
+			default:
+				result.WriteString(month.String())
+			}
+		case tokenDay:
+			switch len(t.text) {
+			case 1:
+				result.WriteString(strconv.Itoa(day))
+			case 2:
+				result.WriteString(pad(day, 2))
+			case 3:
+				result.WriteString(weekday.String()[:3])
+			default:
+				result.WriteString(weekday.String())
+			}
+		case tokenHour:
+			hour := date.Hour()
+			if hasAmPm {
+				hour = hour % 12
+				if hour == 0 {
+					hour = 12
+				}
+			}
+
+			result.WriteString(pad(hour, len(t.text)))
+		case tokenMinute:
+			result.WriteString(pad(date.Minute(), len(t.text)))
+		case tokenSecond:
+			result.WriteString(pad(date.Second(), len(t.text)))
+		case tokenSubSecond:
+			fraction := float64(date.Nanosecond()) / float64(time.Second)
+			result.WriteString("." + strconv.FormatFloat(fraction, 'f', subSecond, 64)[2:])
+		case tokenElapsedHour:
+			result.WriteString(pad(int(math.Floor(totalSeconds/3600)), len(t.text)))
+		case tokenElapsedMinute:
+			result.WriteString(pad(int(math.Floor(totalSeconds/60)), len(t.text)))
+		case tokenElapsedSecond:
+			result.WriteString(pad(int(math.Floor(totalSeconds)), len(t.text)))
+		case tokenAmPm:
+			am, pm := "AM", "PM"
+			if t.text != "am/pm" {
+				am, pm = t.text[:1], t.text[2:]
+			}
+
+			if date.Hour() < 12 {
+				result.WriteString(am)
+			} else {
+				result.WriteString(pm)
+			}
+		}
+	}
+
+	return result.String()
+}
+
+//pad returns value with leading zeros up to width
+func pad(value int, width int) string {
+	s := strconv.Itoa(value)
+	for len(s) < width {
+		s = "0" + s
+	}
+
+	return s
+}
@@ -0,0 +1,82 @@
+package numberFormat
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	for _, f := range []struct {
+		value    string
+		code     string
+		expected string
+	}{
+		{"1234.5678", "@", "1234.5678"},
+		{"1234.5678", "General", "1234.5678"},
+		{"0.1", "General", "0.1"},
+		{"123456789012", "General", "1.23457E+11"},
+		{"1234.5678", "0", "1235"},
+		{"1234.5678", "0.00", "1234.57"},
+		{"1234.5678", "#,##0.00", "1,234.57"},
+		{"0.5", "#,##0.00", "0.50"},
+		{"0.5", "#.##", ".5"},
+		{"5", "000", "005"},
+		{"-1234.5", "#,##0.00;(#,##0.00)", "(1,234.50)"},
+		{"-1234.5", "#,##0.00", "-1,234.50"},
+		{"0", "0.00;-0.00;\"zero\"", "zero"},
+		{"0.256", "0.0%", "25.6%"},
+		{"1234567", "#,##0,", "1,235"},
+		{"1234567", `0.0,,"M"`, "1.2M"},
+		{"12345.678", "0.00E+00", "1.23E+04"},
+		{"0.00012", "0.00E+00", "1.20E-04"},
+		{"1.5", "# ?/?", "1 1/2"},
+		{"0.75", "# ?/4", "3/4"},
+		{"1234.5", `$#,##0.00`, "$1,234.50"},
+		{"1234.5", `[$€-407]#,##0.00`, "€1,234.50"},
+		{"1234.5", `[Red]0.0`, "1234.5"},
+		{"150", `[>=100]"big";"small"`, "big"},
+		{"50", `[>=100]"big";"small"`, "small"},
+		{"43831", "yyyy-mm-dd", "2020-01-01"},
+		{"43831", "m/d/yy", "1/1/20"},
+		{"43831", "d-mmm-yyyy", "1-Jan-2020"},
+		{"43831", "dddd, mmmm d", "Wednesday, January 1"},
+		{"43831.75", "h:mm AM/PM", "6:00 PM"},
+		{"43831.5", "yyyy-mm-dd hh:mm:ss", "2020-01-01 12:00:00"},
+		{"1.5", "[h]:mm", "36:00"},
+		{"0.000011574", "mm:ss.00", "00:01.00"},
+		{"43831", "[$-F800]", "Wednesday, January 01, 2020"},
+		{"1", "yyyy-mm-dd dddd", "1900-01-01 Sunday"},
+		{"59", "yyyy-mm-dd dddd", "1900-02-28 Tuesday"},
+		{"60", "yyyy-mm-dd dddd", "1900-02-29 Wednesday"},
+		{"60.5", "d-mmm-yy hh:mm", "29-Feb-00 12:00"},
+		{"61", "yyyy-mm-dd dddd", "1900-03-01 Thursday"},
+	} {
+		require.Equal(t, f.expected, Format(f.value, f.code, 0), f.code)
+	}
+
+	require.Equal(t, "text", formatText("text", "0.00"))
+	require.Equal(t, "Name: text", formatText("text", `0.00;0.00;0.00;"Name: "@`))
+}
+
+func TestDetection(t *testing.T) {
+	require.Equal(t, true, IsDate("yyyy-mm-dd"))
+	require.Equal(t, true, IsDate("[$-409]d-mmm-yy;@"))
+	require.Equal(t, true, IsDate("[$-F800]"))
+	require.Equal(t, false, IsDate("h:mm:ss"))
+	require.Equal(t, false, IsDate(`0.00E+00`))
+	require.Equal(t, false, IsDate(`"day" 0`))
+
+	require.Equal(t, true, IsTime("h:mm:ss"))
+	require.Equal(t, true, IsTime("[h]:mm"))
+	require.Equal(t, true, IsTime("[$-F400]"))
+	require.Equal(t, false, IsTime("yyyy-mm"))
+
+	require.Equal(t, true, IsCurrency(`$#,##0.00`))
+	require.Equal(t, true, IsCurrency(`[$€-407]#,##0.00`))
+	require.Equal(t, true, IsCurrency(`#,##0.00\ "₽"`))
+	require.Equal(t, false, IsCurrency(`#,##0.00`))
+
+	require.Equal(t, true, IsDateID(14))
+	require.Equal(t, true, IsDateID(27))
+	require.Equal(t, false, IsDateID(2))
+}
@@ -2,7 +2,6 @@ package numberFormat
 
 import (
 	"github.com/plandem/xlsx/internal/ml"
-	"github.com/plandem/xlsx/internal/ml/primitives"
 )
 
 //Type of underlying value of built-in number format
@@ -87,9 +86,3 @@ func Default(t Type) (int, string) {
 	number := builtIn[typeDefault[General]]
 	return number.NumberFormat.ID, number.NumberFormat.Code
 }
-
-//Format tries to format value into required format code
-func Format(value, code string, t primitives.CellType) string {
-	//TODO: implement formatting based on code and type
-	return value
-}
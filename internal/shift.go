@@ -0,0 +1,36 @@
+package internal
+
+//ShiftIndexes returns 0-based indexes of range from-to after inserting (n > 0) or deleting (n < 0) of n indexes at 0-based index at. If range was deleted entirely, then false is returned
+func ShiftIndexes(from, to, at, n int) (int, int, bool) {
+	if n >= 0 {
+		if from >= at {
+			from += n
+		}
+
+		if to >= at {
+			to += n
+		}
+
+		return from, to, true
+	}
+
+	//deleted indexes are [at, end)
+	end := at - n
+	if from >= at && to < end {
+		return from, to, false
+	}
+
+	if from >= end {
+		from += n
+	} else if from > at {
+		from = at
+	}
+
+	if to >= end {
+		to += n
+	} else if to >= at {
+		to = at - 1
+	}
+
+	return from, to, true
+}
@@ -0,0 +1,155 @@
+package xlsx
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/plandem/xlsx/internal/number_format/convert"
+	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/types"
+	"io"
+	"math"
+	"strings"
+)
+
+//jsonField is a pair of key and value of object
+type jsonField struct {
+	key   string
+	value interface{}
+}
+
+//jsonObject is an object that keeps order of keys during encoding
+type jsonObject []jsonField
+
+//jsonMarshal encodes value without escaping of HTML
+func jsonMarshal(v interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+//MarshalJSON encodes object with keys in same order as columns
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := jsonMarshal(f.key)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := jsonMarshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+//jsonValue returns value of cell according to the type and number format of cell
+func jsonValue(c *Cell, formatted bool) interface{} {
+	if len(c.Value()) == 0 {
+		return nil
+	}
+
+	if formatted || c.ml.Type == types.CellTypeError {
+		return c.FormattedValue()
+	}
+
+	if b, err := c.Bool(); err == nil && c.ml.Type == types.CellTypeBool {
+		return b
+	}
+
+	//dates are exported as ISO8601 and times as displayed
+	if date, t := c.hasDateFormat(); date || c.ml.Type == types.CellTypeDate {
+		if d, err := c.Time(); err == nil {
+			return d.Format(convert.ISO8601)
+		}
+	} else if t {
+		return c.FormattedValue()
+	}
+
+	if f, err := c.Float64(); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+			return int64(f)
+		}
+
+		return f
+	}
+
+	return c.Value()
+}
+
+//ToJSON writes cells of sheet as JSON array of arrays or array of objects with keys from header row. If options is nil, then default options are used
+func (s *sheetInfo) ToJSON(w io.Writer, o *options.ExportOptions) error {
+	if o == nil {
+		o = options.NewExportOptions()
+	}
+
+	bounds := o.Range
+	if bounds.IsEmpty() {
+		cols, rows := s.sheet.Dimension()
+		if cols == 0 || rows == 0 {
+			_, err := io.WriteString(w, "[]\n")
+			return err
+		}
+
+		bounds = types.BoundsFromIndexes(0, 0, cols-1, rows-1)
+	}
+
+	var keys []string
+	result := make([]interface{}, 0, bounds.ToRow-bounds.FromRow+1)
+	for rIdx := bounds.FromRow; rIdx <= bounds.ToRow; rIdx++ {
+		//header row has names of keys, columns without names use ref of column, e.g.: C
+		if o.HeaderRow && rIdx == bounds.FromRow {
+			used := make(map[string]bool)
+			for cIdx := bounds.FromCol; cIdx <= bounds.ToCol; cIdx++ {
+				key := s.sheet.Cell(cIdx, rIdx).Value()
+				if len(key) == 0 || used[key] {
+					key = strings.TrimSuffix(string(types.CellRefFromIndexes(cIdx, 0)), "1")
+				}
+
+				used[key] = true
+				keys = append(keys, key)
+			}
+
+			continue
+		}
+
+		if o.HeaderRow {
+			object := make(jsonObject, 0, len(keys))
+			for cIdx := bounds.FromCol; cIdx <= bounds.ToCol; cIdx++ {
+				object = append(object, jsonField{keys[cIdx-bounds.FromCol], jsonValue(s.sheet.Cell(cIdx, rIdx), o.FormattedValues)})
+			}
+
+			result = append(result, object)
+			continue
+		}
+
+		values := make([]interface{}, 0, bounds.ToCol-bounds.FromCol+1)
+		for cIdx := bounds.FromCol; cIdx <= bounds.ToCol; cIdx++ {
+			values = append(values, jsonValue(s.sheet.Cell(cIdx, rIdx), o.FormattedValues))
+		}
+
+		result = append(result, values)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", o.Indent)
+	return encoder.Encode(result)
+}
@@ -0,0 +1,50 @@
+package xlsx
+
+import (
+	"bytes"
+	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestToJSON(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Invoices")
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, sheet.ToJSON(buf, nil))
+	require.Equal(t, "[[null]]\n", buf.String())
+
+	sheet.CellByRef("A1").SetValue("Invoice")
+	sheet.CellByRef("B1").SetValue("Total")
+	sheet.CellByRef("C1").SetValue("Paid")
+	sheet.CellByRef("E1").SetValue("Total")
+	sheet.CellByRef("A2").SetValue("<INV-1>")
+	sheet.CellByRef("B2").SetValueWithFormat(1234.5, "#,##0.00")
+	sheet.CellByRef("C2").SetBool(true)
+	sheet.CellByRef("D2").SetDate(time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC))
+	sheet.CellByRef("E2").SetInt(10)
+
+	buf.Reset()
+	require.Nil(t, sheet.ToJSON(buf, &options.ExportOptions{HeaderRow: true}))
+	require.Equal(t, `[{"Invoice":"<INV-1>","Total":1234.5,"Paid":true,"D":"2020-01-31T00:00:00","E":10}]`+"\n", buf.String())
+
+	buf.Reset()
+	require.Nil(t, sheet.ToJSON(buf, options.NewExportOptions(
+		options.Export.FormattedValues(true),
+		options.Export.Range(types.BoundsFromIndexes(1, 1, 3, 2)),
+	)))
+	require.Equal(t, `[["1,234.50","TRUE","1-31-20"],[null,null,null]]`+"\n", buf.String())
+
+	buf.Reset()
+	require.Nil(t, sheet.ToJSON(buf, options.NewExportOptions(
+		options.Export.Range(types.BoundsFromIndexes(1, 0, 2, 1)),
+		options.Export.HeaderRow(true),
+		options.Export.Indent(" "),
+	)))
+	require.Equal(t, "[\n {\n  \"Total\": 1234.5,\n  \"Paid\": true\n }\n]\n", buf.String())
+}
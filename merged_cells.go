@@ -7,6 +7,16 @@ import (
 	"github.com/plandem/xlsx/types"
 )
 
+//MergeOption is a type of option to control values of cells during merging
+type MergeOption byte
+
+//List of all possible options to control values of cells during merging
+const (
+	MergeMoveValue   MergeOption = iota //moves the first value into the top left cell and clears other cells (Excel behavior)
+	MergeKeepValues                     //keeps values and formatting of all cells as is
+	MergeClearValues                    //clears all cells except formatting of the top left cell
+)
+
 type mergedCells struct {
 	sheet *sheetInfo
 }
@@ -30,8 +40,29 @@ func (m *mergedCells) Resolve(cIdx, rIdx int) (int, int, bool) {
 	return cIdx, rIdx, merged
 }
 
-//Merge adds a merged cells info for bounds
-func (m *mergedCells) Add(bounds types.Bounds) error {
+//Get returns bounds of merged cells that contain cell with cIdx and rIdx
+func (m *mergedCells) Get(cIdx, rIdx int) (types.Bounds, bool) {
+	for _, mc := range m.sheet.ml.MergeCells.Items {
+		if mc.Bounds.Contains(cIdx, rIdx) {
+			return mc.Bounds, true
+		}
+	}
+
+	return types.Bounds{}, false
+}
+
+//List returns bounds of all merged cells
+func (m *mergedCells) List() []types.Bounds {
+	list := make([]types.Bounds, 0, len(m.sheet.ml.MergeCells.Items))
+	for _, mc := range m.sheet.ml.MergeCells.Items {
+		list = append(list, mc.Bounds)
+	}
+
+	return list
+}
+
+//validate checks if bounds can be merged
+func (m *mergedCells) validate(bounds types.Bounds) error {
 	//let's check existing merged cells for overlapping
 	for _, mc := range m.sheet.ml.MergeCells.Items {
 		if mc.Bounds.Overlaps(bounds) {
@@ -39,6 +70,23 @@ func (m *mergedCells) Add(bounds types.Bounds) error {
 		}
 	}
 
+	//cells of tables can't be merged
+	m.sheet.tables.loadIfRequired()
+	for _, item := range m.sheet.tables.items {
+		if item.ml.Bounds.Overlaps(bounds) {
+			return errors.New(fmt.Sprintf("merging of cells in table is not allowed, %s intersects with table %s", bounds, item.ml.DisplayName))
+		}
+	}
+
+	return nil
+}
+
+//Add adds a merged cells info for bounds
+func (m *mergedCells) Add(bounds types.Bounds) error {
+	if err := m.validate(bounds); err != nil {
+		return err
+	}
+
 	//looks like there are no any merged cells in that area, so let's add it
 	m.sheet.ml.MergeCells.Items = append(m.sheet.ml.MergeCells.Items, &ml.MergeCell{
 		Bounds: bounds,
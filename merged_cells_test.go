@@ -1 +1,65 @@
 package xlsx
+
+import (
+	"github.com/plandem/xlsx/table"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMergedCells(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Data")
+
+	fill := func(ref types.Ref) {
+		sheet.Range(ref).Walk(func(idx, cIdx, rIdx int, c *Cell) {
+			if idx > 0 {
+				c.SetValue(idx)
+			}
+		})
+	}
+
+	//default: first value is moved into the top left cell
+	fill("A1:B2")
+	require.Nil(t, sheet.Range("A1:B2").Merge())
+	require.Equal(t, "1", sheet.CellByRef("A1").Value())
+
+	//keep values
+	fill("D1:E2")
+	require.Nil(t, sheet.Range("D1:E2").Merge(MergeKeepValues))
+	require.Equal(t, "", sheet.CellByRef("D1").Value())
+
+	//clear values
+	fill("G1:H2")
+	sheet.CellByRef("G1").SetValue("top left")
+	require.Nil(t, sheet.Range("G1:H2").Merge(MergeClearValues))
+	require.Equal(t, "", sheet.CellByRef("G1").Value())
+
+	//overlapping of merged cells is not allowed
+	require.NotNil(t, sheet.Range("B2:C3").Merge())
+
+	//merging of cells in table is not allowed
+	require.Nil(t, sheet.AddTable(types.BoundsFromIndexes(0, 9, 2, 12), table.Name("Sales")))
+	require.NotNil(t, sheet.Range("B11:C11").Merge())
+
+	require.Equal(t, []types.Bounds{
+		types.BoundsFromIndexes(0, 0, 1, 1),
+		types.BoundsFromIndexes(3, 0, 4, 1),
+		types.BoundsFromIndexes(6, 0, 7, 1),
+	}, sheet.MergedCells())
+
+	bounds, ok := sheet.MergedRange("E2")
+	require.Equal(t, types.BoundsFromIndexes(3, 0, 4, 1), bounds)
+	require.Equal(t, true, ok)
+
+	bounds, ok = sheet.MergedRange("C1")
+	require.Equal(t, types.Bounds{}, bounds)
+	require.Equal(t, false, ok)
+
+	//values of cells are accessible after splitting
+	sheet.Range("A1:H2").Split()
+	require.Equal(t, 0, len(sheet.MergedCells()))
+	require.Equal(t, []string{"1", "", "", ""}, []string{sheet.CellByRef("A1").Value(), sheet.CellByRef("B1").Value(), sheet.CellByRef("A2").Value(), sheet.CellByRef("B2").Value()})
+	require.Equal(t, []string{"", "1", "2", "3"}, []string{sheet.CellByRef("D1").Value(), sheet.CellByRef("E1").Value(), sheet.CellByRef("D2").Value(), sheet.CellByRef("E2").Value()})
+	require.Equal(t, []string{"", "", "", ""}, []string{sheet.CellByRef("G1").Value(), sheet.CellByRef("H1").Value(), sheet.CellByRef("G2").Value(), sheet.CellByRef("H2").Value()})
+}
@@ -0,0 +1,30 @@
+package locale
+
+//Locale is information about regional conventions that are used to build number format codes
+type Locale struct {
+	ID             uint16 //Windows locale identifier (LCID), e.g.: 0x0407 for German (Germany)
+	Date           string //short date, e.g.: dd.mm.yyyy
+	LongDate       string //date with names of day and month, e.g.: dddd, d. mmmm yyyy
+	Time           string //short time, e.g.: hh:mm
+	CurrencySuffix bool   //currency symbol is placed after number, e.g.: 1.234,56 €
+	PercentSpace   bool   //percent sign is separated from number with space, e.g.: 15 %
+}
+
+//List of predefined locales
+var (
+	US = Locale{ID: 0x0409, Date: `m/d/yyyy`, LongDate: `dddd, mmmm d, yyyy`, Time: `h:mm AM/PM`}
+	GB = Locale{ID: 0x0809, Date: `dd/mm/yyyy`, LongDate: `dddd, d mmmm yyyy`, Time: `hh:mm`}
+	DE = Locale{ID: 0x0407, Date: `dd.mm.yyyy`, LongDate: `dddd, d. mmmm yyyy`, Time: `hh:mm`, CurrencySuffix: true}
+	CH = Locale{ID: 0x0807, Date: `dd.mm.yyyy`, LongDate: `dddd, d. mmmm yyyy`, Time: `hh:mm`}
+	FR = Locale{ID: 0x040c, Date: `dd/mm/yyyy`, LongDate: `dddd d mmmm yyyy`, Time: `hh:mm`, CurrencySuffix: true, PercentSpace: true}
+	ES = Locale{ID: 0x0c0a, Date: `dd/mm/yyyy`, LongDate: `dddd, d "de" mmmm "de" yyyy`, Time: `h:mm`, CurrencySuffix: true}
+	IT = Locale{ID: 0x0410, Date: `dd/mm/yyyy`, LongDate: `dddd d mmmm yyyy`, Time: `hh:mm`, CurrencySuffix: true}
+	NL = Locale{ID: 0x0413, Date: `d-m-yyyy`, LongDate: `dddd d mmmm yyyy`, Time: `hh:mm`}
+	PL = Locale{ID: 0x0415, Date: `dd.mm.yyyy`, LongDate: `dddd, d mmmm yyyy`, Time: `hh:mm`, CurrencySuffix: true}
+	BR = Locale{ID: 0x0416, Date: `dd/mm/yyyy`, LongDate: `dddd, d "de" mmmm "de" yyyy`, Time: `hh:mm`}
+	RU = Locale{ID: 0x0419, Date: `dd.mm.yyyy`, LongDate: `d mmmm yyyy "г."`, Time: `h:mm`, CurrencySuffix: true, PercentSpace: true}
+	JP = Locale{ID: 0x0411, Date: `yyyy/m/d`, LongDate: `yyyy"年"m"月"d"日"`, Time: `h:mm`}
+	CN = Locale{ID: 0x0804, Date: `yyyy/m/d`, LongDate: `yyyy"年"m"月"d"日"`, Time: `h:mm`}
+	KR = Locale{ID: 0x0412, Date: `yyyy-mm-dd`, LongDate: `yyyy"년" m"월" d"일" dddd`, Time: `h:mm`}
+	IN = Locale{ID: 0x4009, Date: `dd-mm-yyyy`, LongDate: `dddd, d mmmm, yyyy`, Time: `hh:mm`}
+)
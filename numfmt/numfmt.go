@@ -0,0 +1,101 @@
+package numfmt
+
+import (
+	"fmt"
+	"github.com/plandem/xlsx/numfmt/locale"
+	"strings"
+)
+
+//currencyInfo is information about currency that is used to build format code
+type currencyInfo struct {
+	symbol   string
+	decimals int
+}
+
+//currencies is a list of known currencies by ISO 4217 code. Unknown currencies use ISO code as symbol and 2 decimals
+var currencies = map[string]currencyInfo{
+	"USD": {"$", 2},
+	"EUR": {"€", 2},
+	"GBP": {"£", 2},
+	"CHF": {"CHF", 2},
+	"JPY": {"¥", 0},
+	"CNY": {"¥", 2},
+	"KRW": {"₩", 0},
+	"INR": {"₹", 2},
+	"RUB": {"₽", 2},
+	"PLN": {"zł", 2},
+	"BRL": {"R$", 2},
+}
+
+//tag returns locale tag for format code, e.g.: [$€-407] for symbol or [$-407] without symbol
+func tag(symbol string, l locale.Locale) string {
+	return fmt.Sprintf("[$%s-%X]", symbol, l.ID)
+}
+
+//number returns format code of number with grouping of thousands and decimals
+func number(decimals int) string {
+	if decimals <= 0 {
+		return `#,##0`
+	}
+
+	return `#,##0.` + strings.Repeat("0", decimals)
+}
+
+//Currency returns format code for currency with ISO 4217 code and regional conventions of locale, e.g.: Currency("EUR", locale.DE) returns #,##0.00 [$€-407];-#,##0.00 [$€-407]
+func Currency(code string, l locale.Locale) string {
+	code = strings.ToUpper(code)
+	info, ok := currencies[code]
+	if !ok {
+		info = currencyInfo{code, 2}
+	}
+
+	return CurrencyWithDecimals(info.symbol, info.decimals, l)
+}
+
+//CurrencyWithDecimals returns format code for currency with symbol, number of decimals and regional conventions of locale
+func CurrencyWithDecimals(symbol string, decimals int, l locale.Locale) string {
+	value := number(decimals)
+	symbol = tag(symbol, l)
+
+	if l.CurrencySuffix {
+		value = value + " " + symbol
+	} else {
+		value = symbol + value
+	}
+
+	return value + ";-" + value
+}
+
+//Percent returns format code for percentage with number of decimals and regional conventions of locale, e.g.: Percent(1, locale.FR) returns 0.0 %
+func Percent(decimals int, l locale.Locale) string {
+	value := `0`
+	if decimals > 0 {
+		value += "." + strings.Repeat("0", decimals)
+	}
+
+	if l.PercentSpace {
+		return value + " %"
+	}
+
+	return value + "%"
+}
+
+//Date returns format code for short date of locale, e.g.: Date(locale.DE) returns [$-407]dd.mm.yyyy
+func Date(l locale.Locale) string {
+	return tag("", l) + l.Date
+}
+
+//LongDate returns format code for date with names of day and month in language of locale, e.g.: LongDate(locale.DE) returns [$-407]dddd, d. mmmm yyyy
+func LongDate(l locale.Locale) string {
+	return tag("", l) + l.LongDate
+}
+
+//Time returns format code for short time of locale, e.g.: Time(locale.US) returns [$-409]h:mm AM/PM
+func Time(l locale.Locale) string {
+	return tag("", l) + l.Time
+}
+
+//DateTime returns format code for short date and time of locale, e.g.: DateTime(locale.GB) returns [$-809]dd/mm/yyyy hh:mm
+func DateTime(l locale.Locale) string {
+	return tag("", l) + l.Date + " " + l.Time
+}
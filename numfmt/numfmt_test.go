@@ -0,0 +1,30 @@
+package numfmt
+
+import (
+	"github.com/plandem/xlsx/numfmt/locale"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCurrency(t *testing.T) {
+	require.Equal(t, `#,##0.00 [$€-407];-#,##0.00 [$€-407]`, Currency("EUR", locale.DE))
+	require.Equal(t, `[$€-1809]#,##0.00;-[$€-1809]#,##0.00`, Currency("eur", locale.Locale{ID: 0x1809}))
+	require.Equal(t, `[$$-409]#,##0.00;-[$$-409]#,##0.00`, Currency("USD", locale.US))
+	require.Equal(t, `[$¥-411]#,##0;-[$¥-411]#,##0`, Currency("JPY", locale.JP))
+	require.Equal(t, `#,##0.00 [$SEK-407];-#,##0.00 [$SEK-407]`, Currency("SEK", locale.DE))
+	require.Equal(t, `#,##0.000 [$BD-407];-#,##0.000 [$BD-407]`, CurrencyWithDecimals("BD", 3, locale.DE))
+}
+
+func TestPercent(t *testing.T) {
+	require.Equal(t, `0%`, Percent(0, locale.US))
+	require.Equal(t, `0.00%`, Percent(2, locale.DE))
+	require.Equal(t, `0.0 %`, Percent(1, locale.FR))
+}
+
+func TestDate(t *testing.T) {
+	require.Equal(t, `[$-407]dd.mm.yyyy`, Date(locale.DE))
+	require.Equal(t, `[$-409]m/d/yyyy`, Date(locale.US))
+	require.Equal(t, `[$-407]dddd, d. mmmm yyyy`, LongDate(locale.DE))
+	require.Equal(t, `[$-409]h:mm AM/PM`, Time(locale.US))
+	require.Equal(t, `[$-809]dd/mm/yyyy hh:mm`, DateTime(locale.GB))
+}
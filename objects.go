@@ -0,0 +1,458 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/plandem/ooxml"
+	sharedML "github.com/plandem/ooxml/ml"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/cfb"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/types"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//Names of streams of compound file with embedded OLE object
+const (
+	streamOle10Native = "\x01Ole10Native"
+	streamCompObj     = "\x01CompObj"
+	streamPackage     = "Package"
+	streamContents    = "CONTENTS"
+)
+
+//clsidPackage is a CLSID of OLE Packager, {0003000C-0000-0000-C000-000000000046}
+var clsidPackage = []byte{0x0C, 0x00, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}
+
+//EmbeddedObject is an embedded object of sheet, e.g. attached file
+type EmbeddedObject struct {
+	Name   string       //name of embedded file or part
+	ProgID string       //programmatic identifier of application that handles object, e.g.: Package
+	Bounds types.Bounds //cells that are covered by icon of object, empty for objects without anchor
+	Data   []byte       //content of embedded file, as is for unknown kinds of objects
+}
+
+type objectItem struct {
+	bounds  types.Bounds
+	rid     sharedML.RID
+	iconRID sharedML.RID
+	vmlRID  sharedML.RID
+}
+
+type objects struct {
+	sheet            *sheetInfo
+	items            []*objectItem
+	vmlRelationships *ooxml.Relationships
+	existing         string
+	isLoaded         bool
+}
+
+//newObjects creates an object that implements embedded objects functionality
+func newObjects(sheet *sheetInfo) *objects {
+	return &objects{sheet: sheet}
+}
+
+//packOle10Native returns native data of OLE package for file with name, content and label that is shown under icon
+func packOle10Native(label, name string, data []byte) []byte {
+	le := binary.LittleEndian
+	b := &bytes.Buffer{}
+	writeString := func(s string) {
+		b.WriteString(s)
+		b.WriteByte(0)
+	}
+
+	_ = binary.Write(b, le, uint16(2))
+	writeString(label)
+	writeString(name) //source path
+	_ = binary.Write(b, le, uint32(0x00030000))
+	_ = binary.Write(b, le, uint32(len(name)+1))
+	writeString(name) //temp path
+	_ = binary.Write(b, le, uint32(len(data)))
+	b.Write(data)
+
+	native := make([]byte, 4, 4+b.Len())
+	le.PutUint32(native, uint32(b.Len()))
+	return append(native, b.Bytes()...)
+}
+
+//unpackOle10Native returns name and content of file from native data of OLE package
+func unpackOle10Native(native []byte) (string, []byte, error) {
+	errInvalid := errors.New("invalid native data of OLE package")
+	le := binary.LittleEndian
+	pos := 6
+	readString := func() (string, bool) {
+		if pos > len(native) {
+			return "", false
+		}
+
+		idx := bytes.IndexByte(native[pos:], 0)
+		if idx < 0 {
+			return "", false
+		}
+
+		s := string(native[pos : pos+idx])
+		pos += idx + 1
+		return s, true
+	}
+
+	//skip label and use name of source path
+	if _, ok := readString(); !ok {
+		return "", nil, errInvalid
+	}
+
+	name, ok := readString()
+	if !ok {
+		return "", nil, errInvalid
+	}
+
+	//skip type and temp path
+	if pos += 8; pos > len(native) {
+		return "", nil, errInvalid
+	}
+
+	if _, ok = readString(); !ok || pos+4 > len(native) {
+		return "", nil, errInvalid
+	}
+
+	size := int(le.Uint32(native[pos:]))
+	if pos += 4; pos+size > len(native) {
+		return "", nil, errInvalid
+	}
+
+	if idx := strings.LastIndexAny(name, `\/`); idx >= 0 {
+		name = name[idx+1:]
+	}
+
+	return name, native[pos : pos+size], nil
+}
+
+//packageCompObj returns content of CompObj stream for OLE package
+func packageCompObj() []byte {
+	le := binary.LittleEndian
+	b := &bytes.Buffer{}
+	writeString := func(s string) {
+		_ = binary.Write(b, le, uint32(len(s)+1))
+		b.WriteString(s)
+		b.WriteByte(0)
+	}
+
+	_ = binary.Write(b, le, []uint32{0xFFFE0001, 0x00000A03, 0xFFFFFFFF})
+	b.Write(clsidPackage)
+	writeString("OLE Package")
+	_ = binary.Write(b, le, uint32(0))
+	writeString("Package")
+	_ = binary.Write(b, le, []uint32{0x71B239F4, 0, 0, 0})
+	return b.Bytes()
+}
+
+//defaultObjectIcon returns PNG image of blank page that is used as icon of embedded object by default
+func defaultObjectIcon() []byte {
+	const width, height, fold = 32, 40, 10
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	border, fill := color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xFF}, color.RGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			switch {
+			case x+fold-y > width-1:
+				//transparent corner
+			case x == 0 || y == 0 || x == width-1 || y == height-1 || x+fold-y == width-1 || (x == width-fold-1 && y <= fold) || (y == fold && x >= width-fold-1):
+				img.Set(x, y, border)
+			default:
+				img.Set(x, y, fill)
+			}
+		}
+	}
+
+	b := &bytes.Buffer{}
+	_ = png.Encode(b, img)
+	return b.Bytes()
+}
+
+//readPackageFile returns content of file with name from package
+func readPackageFile(doc *Spreadsheet, fileName string) ([]byte, error) {
+	switch file := doc.pkg.File(fileName).(type) {
+	case *zip.File:
+		reader, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+
+		defer reader.Close()
+		return ioutil.ReadAll(reader)
+	case []byte:
+		return file, nil
+	}
+
+	return nil, errors.New(fmt.Sprintf("there is no file %s", fileName))
+}
+
+//loadIfRequired keeps existing list of embedded objects of sheet, so new embedded objects are appended to it. Shapes of existing embedded objects are kept by legacy drawing as is
+func (o *objects) loadIfRequired() {
+	if o.isLoaded {
+		return
+	}
+
+	o.isLoaded = true
+	if o.sheet.ml.OleObjects != nil && o.sheet.ml.OleObjects.InnerXML != nil {
+		o.existing = o.sheet.ml.OleObjects.InnerXML.XML
+	}
+}
+
+//attachVmlRelationshipsIfRequired attaches relationships of legacy drawing
+func (o *objects) attachVmlRelationshipsIfRequired() {
+	if o.vmlRelationships == nil {
+		doc := o.sheet.workbook.doc
+		fileName := fmt.Sprintf("xl/drawings/_rels/%s.rels", filepath.Base(o.sheet.comments.vmlFile.FileName()))
+
+		if file := doc.pkg.File(fileName); file != nil {
+			o.vmlRelationships = ooxml.NewRelationships(file, doc.pkg)
+		} else {
+			o.vmlRelationships = ooxml.NewRelationships(fileName, doc.pkg)
+		}
+	}
+}
+
+//Add embeds file with name and content as OLE package with icon that fits bounds
+func (o *objects) Add(bounds types.Bounds, name string, reader io.Reader, settings *options.ObjectOptions) error {
+	if reader == nil {
+		return errors.New("no content of embedded object")
+	}
+
+	if bounds.IsEmpty() {
+		return errors.New("no bounds for embedded object")
+	}
+
+	if len(name) == 0 {
+		return errors.New("no name of embedded object")
+	}
+
+	if settings == nil {
+		settings = options.NewObjectOptions()
+	}
+
+	o.loadIfRequired()
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	icon := settings.Icon
+	if len(icon) == 0 {
+		icon = defaultObjectIcon()
+	}
+
+	_, format, err := image.DecodeConfig(bytes.NewReader(icon))
+	if err != nil {
+		return errors.New(fmt.Sprintf("unsupported format of icon: %s", err))
+	}
+
+	if _, ok := imageContentTypes[format]; !ok {
+		return errors.New(fmt.Sprintf("unsupported format of icon: %s", format))
+	}
+
+	label := settings.Label
+	if len(label) == 0 {
+		label = filepath.Base(name)
+	}
+
+	content := &bytes.Buffer{}
+	if err = cfb.Write(content,
+		&cfb.Stream{Name: streamCompObj, Data: packageCompObj()},
+		&cfb.Stream{Name: streamOle10Native, Data: packOle10Native(label, filepath.Base(name), data)},
+	); err != nil {
+		return err
+	}
+
+	doc := o.sheet.workbook.doc
+	fileName := doc.uniqueFileName("xl/embeddings/oleObject%d.bin")
+	doc.pkg.Add(fileName, content.Bytes())
+	doc.pkg.ContentTypes().RegisterContent(fileName, internal.ContentTypeOleObject)
+
+	o.sheet.attachRelationshipsIfRequired()
+	_, rid := o.sheet.relationships.AddFile(internal.RelationTypeOleObject, fileName)
+
+	//N.B.: icon is referenced by sheet for Excel 2010 and later, and by legacy drawing for older versions
+	iconFileName := o.sheet.drawings.addMedia(icon, format)
+	_, iconRID := o.sheet.relationships.AddFile(internal.RelationTypeImage, iconFileName)
+
+	o.sheet.comments.initVmlIfRequired()
+	o.attachVmlRelationshipsIfRequired()
+	_, vmlRID := o.vmlRelationships.AddFile(internal.RelationTypeImage, iconFileName)
+
+	o.items = append(o.items, &objectItem{
+		bounds:  bounds,
+		rid:     rid,
+		iconRID: iconRID,
+		vmlRID:  vmlRID,
+	})
+
+	o.sheet.comments.markAsUpdated()
+	return nil
+}
+
+//update adds shapes of new embedded objects into legacy drawing with ids that start from shapeID and appends them to list of embedded objects of sheet
+func (o *objects) update(vml *bytes.Buffer, shapeID int) {
+	if len(o.items) == 0 {
+		return
+	}
+
+	o.sheet.comments.writeShapeType(vml, "_x0000_t75", `<v:shapetype id="_x0000_t75" coordsize="21600,21600" o:spt="75" o:preferrelative="t" path="m@4@5l@4@11@9@11@9@5xe" filled="f" stroked="f"><v:stroke joinstyle="miter"/>`+
+		`<v:formulas><v:f eqn="if lineDrawn pixelLineWidth 0"/><v:f eqn="sum @0 1 0"/><v:f eqn="sum 0 0 @1"/><v:f eqn="prod @2 1 2"/><v:f eqn="prod @3 21600 pixelWidth"/><v:f eqn="prod @3 21600 pixelHeight"/><v:f eqn="sum @0 0 1"/><v:f eqn="prod @6 1 2"/><v:f eqn="prod @7 21600 pixelWidth"/><v:f eqn="sum @8 21600 0"/><v:f eqn="prod @7 21600 pixelHeight"/><v:f eqn="sum @10 21600 0"/></v:formulas>`+
+		`<v:path o:extrusionok="f" gradientshapeok="t" o:connecttype="rect"/><o:lock v:ext="edit" aspectratio="t"/></v:shapetype>`)
+
+	list := bytes.NewBufferString(o.existing)
+	for i, item := range o.items {
+		id, b := shapeID+i, item.bounds
+		vml.WriteString(fmt.Sprintf(`<v:shape id="_x0000_s%d" type="#_x0000_t75" style="position:absolute;margin-left:0;margin-top:0;width:48pt;height:48pt;z-index:%d">`, id, id))
+		vml.WriteString(fmt.Sprintf(`<v:imagedata o:relid="%s" o:title=""/>`, item.vmlRID))
+		vml.WriteString(fmt.Sprintf(`<x:ClientData ObjectType="Pict"><x:SizeWithCells/><x:Anchor>%d, 0, %d, 0, %d, 0, %d, 0</x:Anchor><x:CF>Pict</x:CF><x:AutoPict/></x:ClientData></v:shape>`, b.FromCol, b.FromRow, b.ToCol+1, b.ToRow+1))
+
+		object := fmt.Sprintf(`<oleObject progId="Package" dvAspect="DVASPECT_ICON" shapeId="%d" r:id="%s"`, id, item.rid)
+		list.WriteString(fmt.Sprintf(`<mc:AlternateContent xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" xmlns:x14="%s"><mc:Choice Requires="x14">`, ml.NamespaceX14))
+		list.WriteString(fmt.Sprintf(`%s><objectPr defaultSize="0" autoPict="0" r:id="%s">`, object, item.iconRID))
+		list.WriteString(`<anchor moveWithCells="1" xmlns:xdr="http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing">`)
+		list.WriteString(fmt.Sprintf(`<from><xdr:col>%d</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>%d</xdr:row><xdr:rowOff>0</xdr:rowOff></from>`, b.FromCol, b.FromRow))
+		list.WriteString(fmt.Sprintf(`<to><xdr:col>%d</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>%d</xdr:row><xdr:rowOff>0</xdr:rowOff></to>`, b.ToCol+1, b.ToRow+1))
+		list.WriteString(fmt.Sprintf(`</anchor></objectPr></oleObject></mc:Choice><mc:Fallback>%s/></mc:Fallback></mc:AlternateContent>`, object))
+	}
+
+	o.sheet.ml.OleObjects = &sharedML.Reserved{InnerXML: &sharedML.InnerXML{XML: list.String()}}
+}
+
+//extract returns name and content of embedded file for part with fileName
+func (o *objects) extract(fileName string) (string, []byte, error) {
+	data, err := readPackageFile(o.sheet.workbook.doc, fileName)
+	if err != nil {
+		return "", nil, err
+	}
+
+	name := filepath.Base(fileName)
+	if !cfb.IsCompoundFile(data) {
+		//N.B.: embedded packages, e.g. documents of Word, are stored as is
+		return name, data, nil
+	}
+
+	streams, err := cfb.Read(data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if native, ok := streams[streamOle10Native]; ok {
+		return unpackOle10Native(native)
+	}
+
+	for _, stream := range []string{streamPackage, streamContents} {
+		if content, ok := streams[stream]; ok {
+			return name, content, nil
+		}
+	}
+
+	return name, data, nil
+}
+
+//List returns all embedded objects of sheet
+func (o *objects) List() ([]EmbeddedObject, error) {
+	o.sheet.comments.updateIfRequired()
+	if o.sheet.ml.OleObjects == nil || o.sheet.ml.OleObjects.InnerXML == nil {
+		return nil, nil
+	}
+
+	o.sheet.attachRelationshipsIfRequired()
+
+	var list []EmbeddedObject
+	var object *EmbeddedObject
+	var marker string
+	var element string
+	var fallback int
+	anchor := make(map[string]int)
+
+	decoder := xml.NewDecoder(strings.NewReader(o.sheet.ml.OleObjects.InnerXML.XML))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			element = t.Name.Local
+			switch {
+			case element == "Fallback":
+				//N.B.: fallback has same objects as choice
+				fallback++
+			case fallback > 0:
+			case element == "oleObject":
+				object = &EmbeddedObject{}
+				anchor = make(map[string]int)
+				var rid string
+				for _, attr := range t.Attr {
+					switch attr.Name.Local {
+					case "progId":
+						object.ProgID = attr.Value
+					case "id":
+						rid = attr.Value
+					}
+				}
+
+				if fileName := o.sheet.relationships.GetTargetById(rid); len(fileName) > 0 {
+					if object.Name, object.Data, err = o.extract(fileName); err != nil {
+						return nil, err
+					}
+				}
+			case element == "from" || element == "to":
+				marker = element
+			}
+		case xml.CharData:
+			if object != nil && fallback == 0 && len(marker) > 0 {
+				if value, err := strconv.Atoi(strings.TrimSpace(string(t))); err == nil {
+					anchor[marker+element] = value
+				}
+			}
+		case xml.EndElement:
+			element = ""
+			switch {
+			case t.Name.Local == "Fallback":
+				fallback--
+			case fallback > 0:
+			case t.Name.Local == "from" || t.Name.Local == "to":
+				marker = ""
+			case t.Name.Local == "oleObject" && object != nil:
+				if _, ok := anchor["tocol"]; ok {
+					//N.B.: 'to' marker points to the cell after object if there is no offset
+					toCol, toRow := anchor["tocol"], anchor["torow"]
+					if anchor["tocolOff"] == 0 && toCol > anchor["fromcol"] {
+						toCol--
+					}
+
+					if anchor["torowOff"] == 0 && toRow > anchor["fromrow"] {
+						toRow--
+					}
+
+					object.Bounds = types.BoundsFromIndexes(anchor["fromcol"], anchor["fromrow"], toCol, toRow)
+				}
+
+				list = append(list, *object)
+				object = nil
+			}
+		}
+	}
+
+	return list, nil
+}
@@ -0,0 +1,106 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestOle10Native(t *testing.T) {
+	native := packOle10Native("Contract", "contract.pdf", []byte("%PDF-1.4"))
+	name, data, err := unpackOle10Native(native)
+	require.Nil(t, err)
+	require.Equal(t, "contract.pdf", name)
+	require.Equal(t, []byte("%PDF-1.4"), data)
+
+	name, _, err = unpackOle10Native(packOle10Native("Contract", `C:\Documents\contract.pdf`, []byte("%PDF-1.4")))
+	require.Nil(t, err)
+	require.Equal(t, "contract.pdf", name)
+
+	_, _, err = unpackOle10Native(native[:10])
+	require.NotNil(t, err)
+}
+
+func TestObjects(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Sheet1")
+
+	//invalid embedded objects
+	require.NotNil(t, sheet.AddObject(types.BoundsFromIndexes(1, 1, 2, 3), "contract.pdf", nil, nil))
+	require.NotNil(t, sheet.AddObject(types.Bounds{}, "contract.pdf", strings.NewReader("%PDF-1.4"), nil))
+	require.NotNil(t, sheet.AddObject(types.BoundsFromIndexes(1, 1, 2, 3), "", strings.NewReader("%PDF-1.4"), nil))
+	require.NotNil(t, sheet.AddObject(types.BoundsFromIndexes(1, 1, 2, 3), "contract.pdf", strings.NewReader("%PDF-1.4"), options.NewObjectOptions(options.Object.Icon([]byte("icon")))))
+	require.Nil(t, sheet.info().ml.OleObjects)
+
+	list, err := sheet.Objects()
+	require.Nil(t, err)
+	require.Equal(t, 0, len(list))
+
+	//embedded objects with comment
+	require.Nil(t, sheet.CellByRef("A1").SetComment("Attachments"))
+	require.Nil(t, sheet.AddObject(types.BoundsFromIndexes(1, 1, 2, 3), "contract.pdf", strings.NewReader("%PDF-1.4"), options.NewObjectOptions(options.Object.Label("Contract"))))
+	require.Nil(t, sheet.AddObject(types.BoundsFromIndexes(4, 1, 4, 1), "notes.txt", strings.NewReader("notes"), nil))
+	require.Equal(t, 2, len(sheet.info().objects.items))
+	require.Equal(t, internal.RelationTypeOleObject, sheet.info().relationships.GetTypeById(string(sheet.info().relationships.GetIdByTarget("xl/embeddings/oleObject1.bin"))))
+
+	//legacy drawing and list of embedded objects are refreshed once before saving
+	sheet.info().comments.updateIfRequired()
+	vml := sheet.info().comments.vml.InnerXML
+	require.True(t, strings.Contains(vml, `<v:shapetype id="_x0000_t75"`))
+	require.True(t, strings.Contains(vml, `<v:shape id="_x0000_s1026" type="#_x0000_t75"`))
+	require.True(t, strings.Contains(vml, `<x:ClientData ObjectType="Pict"><x:SizeWithCells/><x:Anchor>1, 0, 1, 0, 3, 0, 4, 0</x:Anchor>`))
+	require.True(t, strings.Contains(sheet.info().ml.OleObjects.InnerXML.XML, `<oleObject progId="Package" dvAspect="DVASPECT_ICON" shapeId="1027" r:id="`))
+
+	list, err = sheet.Objects()
+	require.Nil(t, err)
+	require.Equal(t, []EmbeddedObject{
+		{Name: "contract.pdf", ProgID: "Package", Bounds: types.BoundsFromIndexes(1, 1, 2, 3), Data: []byte("%PDF-1.4")},
+		{Name: "notes.txt", ProgID: "Package", Bounds: types.BoundsFromIndexes(4, 1, 4, 1), Data: []byte("notes")},
+	}, list)
+
+	//save and reopen
+	err = xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	require.NotNil(t, sheet.info().ml.OleObjects)
+	require.NotNil(t, sheet.info().ml.LegacyDrawing)
+	require.Equal(t, "Attachments", sheet.CellByRef("A1").Comment().String())
+
+	list, err = sheet.Objects()
+	require.Nil(t, err)
+	require.Equal(t, 2, len(list))
+	require.Equal(t, "contract.pdf", list[0].Name)
+	require.Equal(t, []byte("%PDF-1.4"), list[0].Data)
+	require.Equal(t, types.BoundsFromIndexes(1, 1, 2, 3), list[0].Bounds)
+
+	//existing embedded objects and their shapes are kept
+	require.Nil(t, sheet.AddObject(types.BoundsFromIndexes(6, 1, 6, 1), "readme.txt", strings.NewReader("readme"), nil))
+	sheet.info().comments.updateIfRequired()
+	require.Equal(t, 3, strings.Count(sheet.info().comments.vml.InnerXML, `type="#_x0000_t75"`))
+	require.Equal(t, 1, strings.Count(sheet.info().comments.vml.InnerXML, `<v:shapetype id="_x0000_t75"`))
+
+	//round trip
+	content, err := xl.Bytes()
+	require.Nil(t, err)
+
+	xl2, err := OpenBytes(content)
+	require.Nil(t, err)
+	defer xl2.Close()
+
+	list, err = xl2.Sheet(0).Objects()
+	require.Nil(t, err)
+	require.Equal(t, 3, len(list))
+	require.Equal(t, "contract.pdf", list[0].Name)
+	require.Equal(t, "notes.txt", list[1].Name)
+	require.Equal(t, EmbeddedObject{Name: "readme.txt", ProgID: "Package", Bounds: types.BoundsFromIndexes(6, 1, 6, 1), Data: []byte("readme")}, list[2])
+	require.Equal(t, "Attachments", xl2.Sheet(0).CellByRef("A1").Comment().String())
+}
@@ -0,0 +1,99 @@
+package options
+
+//CalcMode is a mode of calculation of formulas
+type CalcMode string
+
+//List of all possible values for CalcMode
+const (
+	CalcModeAuto        CalcMode = "auto"
+	CalcModeAutoNoTable CalcMode = "autoNoTable"
+	CalcModeManual      CalcMode = "manual"
+)
+
+type calcOption func(co *CalcOptions)
+
+//CalcOptions is a helper type to simplify process of settings options for calculation of workbook
+type CalcOptions struct {
+	Mode                   CalcMode
+	Iterate                bool
+	IterateCount           uint
+	IterateDelta           float64
+	FullPrecision          bool
+	CalcOnSave             bool
+	ForceFullRecalculation bool
+}
+
+//Calc is a 'namespace' for all possible options for calculation of workbook
+//
+// Possible options are:
+// Mode
+// Iterate
+// FullPrecision
+// CalcOnSave
+// ForceFullRecalculation
+var Calc calcOption
+
+//NewCalcOptions create and returns option set for calculation of workbook with defaults of Excel
+func NewCalcOptions(options ...calcOption) *CalcOptions {
+	s := &CalcOptions{
+		Mode:          CalcModeAuto,
+		IterateCount:  100,
+		IterateDelta:  0.001,
+		FullPrecision: true,
+		CalcOnSave:    true,
+	}
+	s.Set(options...)
+	return s
+}
+
+//Set sets new options for option set
+func (co *CalcOptions) Set(options ...calcOption) {
+	for _, o := range options {
+		o(co)
+	}
+}
+
+//Mode sets mode of calculation, e.g.: automatic or manual.
+func (o *calcOption) Mode(mode CalcMode) calcOption {
+	return func(co *CalcOptions) {
+		switch mode {
+		case CalcModeAuto, CalcModeAutoNoTable, CalcModeManual:
+			co.Mode = mode
+		}
+	}
+}
+
+//Iterate turns on iterative calculation of circular references with maximum number of iterations and maximum change between iterations.
+func (o *calcOption) Iterate(count uint, delta float64) calcOption {
+	return func(co *CalcOptions) {
+		co.Iterate = true
+		if count > 0 {
+			co.IterateCount = count
+		}
+
+		if delta > 0 {
+			co.IterateDelta = delta
+		}
+	}
+}
+
+//FullPrecision sets flag indicating if the calculation uses full precision rather than precision as displayed.
+func (o *calcOption) FullPrecision(full bool) calcOption {
+	return func(co *CalcOptions) {
+		co.FullPrecision = full
+	}
+}
+
+//CalcOnSave sets flag indicating if the formulas should be recalculated before saving in manual mode.
+func (o *calcOption) CalcOnSave(calc bool) calcOption {
+	return func(co *CalcOptions) {
+		co.CalcOnSave = calc
+	}
+}
+
+//ForceFullRecalculation sets flag indicating if all formulas should be recalculated when the workbook is opened, so stale cached values are replaced.
+func (o *calcOption) ForceFullRecalculation(force bool) calcOption {
+	return func(co *CalcOptions) {
+		co.ForceFullRecalculation = force
+	}
+}
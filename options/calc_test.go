@@ -0,0 +1,42 @@
+package options
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCalcOptions(t *testing.T) {
+	o := NewCalcOptions()
+	require.Equal(t, &CalcOptions{
+		Mode:          CalcModeAuto,
+		IterateCount:  100,
+		IterateDelta:  0.001,
+		FullPrecision: true,
+		CalcOnSave:    true,
+	}, o)
+
+	o = NewCalcOptions(
+		Calc.Mode(CalcModeManual),
+		Calc.Iterate(50, 0.01),
+		Calc.FullPrecision(false),
+		Calc.CalcOnSave(false),
+		Calc.ForceFullRecalculation(true),
+	)
+
+	require.IsType(t, &CalcOptions{}, o)
+	require.Equal(t, &CalcOptions{
+		Mode:                   CalcModeManual,
+		Iterate:                true,
+		IterateCount:           50,
+		IterateDelta:           0.01,
+		FullPrecision:          false,
+		CalcOnSave:             false,
+		ForceFullRecalculation: true,
+	}, o)
+
+	//invalid values are ignored
+	o.Set(Calc.Mode("unknown"), Calc.Iterate(0, 0))
+	require.Equal(t, CalcModeManual, o.Mode)
+	require.Equal(t, uint(50), o.IterateCount)
+	require.Equal(t, 0.01, o.IterateDelta)
+}
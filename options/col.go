@@ -1,5 +1,9 @@
 package options
 
+import (
+	"github.com/plandem/xlsx/format"
+)
+
 type columnOption func(co *ColumnOptions)
 
 //ColumnOptions is a helper type to simplify process of settings options for column
@@ -8,7 +12,9 @@ type ColumnOptions struct {
 	Collapsed    bool
 	Phonetic     bool
 	Hidden       bool
+	BestFit      bool
 	Width        float32
+	Style        format.DirectStyleID
 }
 
 //Column is a 'namespace' for all possible options for column
@@ -18,7 +24,9 @@ type ColumnOptions struct {
 // Collapsed
 // Phonetic
 // Hidden
+// BestFit
 // Width
+// Style
 var Column columnOption
 
 //NewColumnOptions create and returns option set for column
@@ -71,3 +79,17 @@ func (o *columnOption) Width(width float32) columnOption {
 		co.Width = width
 	}
 }
+
+//BestFit sets flag indicating if the affected column width was set to 'best fit'. Excel recalculates width of these columns by content during editing.
+func (o *columnOption) BestFit(bestFit bool) columnOption {
+	return func(co *ColumnOptions) {
+		co.BestFit = bestFit
+	}
+}
+
+//Style sets default style for the affected column. Style applies to new cells of column.
+func (o *columnOption) Style(styleID format.DirectStyleID) columnOption {
+	return func(co *ColumnOptions) {
+		co.Style = styleID
+	}
+}
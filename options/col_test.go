@@ -1,6 +1,7 @@
 package options
 
 import (
+	"github.com/plandem/xlsx/format"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -12,6 +13,8 @@ func TestColumnOptions(t *testing.T) {
 		Column.Phonetic(true),
 		Column.Width(45.5),
 		Column.Collapsed(true),
+		Column.BestFit(true),
+		Column.Style(format.DirectStyleID(2)),
 	)
 
 	require.IsType(t, &ColumnOptions{}, o)
@@ -21,6 +24,8 @@ func TestColumnOptions(t *testing.T) {
 		Phonetic:     true,
 		Width:        45.5,
 		Collapsed:    true,
+		BestFit:      true,
+		Style:        format.DirectStyleID(2),
 	}, o)
 
 	o = NewColumnOptions(
@@ -0,0 +1,53 @@
+package options
+
+type compareOption func(co *CompareOptions)
+
+//CompareOptions is a helper type to simplify process of settings options for comparing of workbooks
+type CompareOptions struct {
+	IgnoreFormulas bool
+	IgnoreStyles   bool
+	Sheets         []string
+}
+
+//Compare is a 'namespace' for all possible options for comparing of workbooks
+//
+// Possible options are:
+// IgnoreFormulas
+// IgnoreStyles
+// Sheets
+var Compare compareOption
+
+//NewCompareOptions create and returns option set for comparing of workbooks
+func NewCompareOptions(options ...compareOption) *CompareOptions {
+	s := &CompareOptions{}
+	s.Set(options...)
+	return s
+}
+
+//Set sets new options for option set
+func (co *CompareOptions) Set(options ...compareOption) {
+	for _, o := range options {
+		o(co)
+	}
+}
+
+//IgnoreFormulas sets flag indicating if formulas of cells should not be compared.
+func (o *compareOption) IgnoreFormulas(ignore bool) compareOption {
+	return func(co *CompareOptions) {
+		co.IgnoreFormulas = ignore
+	}
+}
+
+//IgnoreStyles sets flag indicating if styles of cells should not be compared.
+func (o *compareOption) IgnoreStyles(ignore bool) compareOption {
+	return func(co *CompareOptions) {
+		co.IgnoreStyles = ignore
+	}
+}
+
+//Sheets sets names of sheets to compare. By default, all sheets are compared.
+func (o *compareOption) Sheets(names ...string) compareOption {
+	return func(co *CompareOptions) {
+		co.Sheets = names
+	}
+}
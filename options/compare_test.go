@@ -0,0 +1,23 @@
+package options
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCompareOptions(t *testing.T) {
+	o := NewCompareOptions()
+	require.IsType(t, &CompareOptions{}, o)
+	require.Equal(t, &CompareOptions{}, o)
+
+	o = NewCompareOptions(
+		Compare.IgnoreFormulas(true),
+		Compare.IgnoreStyles(true),
+		Compare.Sheets("Sheet1", "Sheet2"),
+	)
+	require.Equal(t, &CompareOptions{
+		IgnoreFormulas: true,
+		IgnoreStyles:   true,
+		Sheets:         []string{"Sheet1", "Sheet2"},
+	}, o)
+}
@@ -0,0 +1,100 @@
+package options
+
+type csvOption func(co *CSVOptions)
+
+//CSVOptions is a helper type to simplify process of settings options for import and export of CSV/TSV
+type CSVOptions struct {
+	Delimiter       rune
+	Comment         rune
+	LazyQuotes      bool
+	QuoteAll        bool
+	UseCRLF         bool
+	InferTypes      bool
+	FormattedValues bool
+	DateFormat      string
+}
+
+//CSV is a 'namespace' for all possible options for CSV/TSV
+//
+// Possible options are:
+// Delimiter
+// Comment
+// LazyQuotes
+// QuoteAll
+// UseCRLF
+// InferTypes
+// FormattedValues
+// DateFormat
+var CSV csvOption
+
+//NewCSVOptions create and returns option set for CSV/TSV
+func NewCSVOptions(options ...csvOption) *CSVOptions {
+	s := &CSVOptions{Delimiter: ',', FormattedValues: true}
+	s.Set(options...)
+	return s
+}
+
+//Set sets new options for option set
+func (co *CSVOptions) Set(options ...csvOption) {
+	for _, o := range options {
+		o(co)
+	}
+}
+
+//Delimiter sets delimiter of fields, e.g. '\t' for TSV. Delimiter can't be a quote, carriage return or line feed.
+func (o *csvOption) Delimiter(delimiter rune) csvOption {
+	return func(co *CSVOptions) {
+		if delimiter != '"' && delimiter != '\r' && delimiter != '\n' && delimiter != 0 {
+			co.Delimiter = delimiter
+		}
+	}
+}
+
+//Comment sets character that starts comment lines during import. Zero value disables comments.
+func (o *csvOption) Comment(comment rune) csvOption {
+	return func(co *CSVOptions) {
+		co.Comment = comment
+	}
+}
+
+//LazyQuotes sets flag indicating if quotes can appear in unquoted field and non-doubled quotes can appear in quoted field during import.
+func (o *csvOption) LazyQuotes(lazy bool) csvOption {
+	return func(co *CSVOptions) {
+		co.LazyQuotes = lazy
+	}
+}
+
+//QuoteAll sets flag indicating if all fields should be quoted during export, rather than fields that require it only.
+func (o *csvOption) QuoteAll(quote bool) csvOption {
+	return func(co *CSVOptions) {
+		co.QuoteAll = quote
+	}
+}
+
+//UseCRLF sets flag indicating if \r\n should be used as line terminator during export.
+func (o *csvOption) UseCRLF(crlf bool) csvOption {
+	return func(co *CSVOptions) {
+		co.UseCRLF = crlf
+	}
+}
+
+//InferTypes sets flag indicating if numbers, booleans and dates should be detected during import, rather than importing all values as strings.
+func (o *csvOption) InferTypes(infer bool) csvOption {
+	return func(co *CSVOptions) {
+		co.InferTypes = infer
+	}
+}
+
+//FormattedValues sets flag indicating if values should be exported as they are displayed by number format of cell, rather than raw values.
+func (o *csvOption) FormattedValues(formatted bool) csvOption {
+	return func(co *CSVOptions) {
+		co.FormattedValues = formatted
+	}
+}
+
+//DateFormat sets layout of dates in terms of time.Format, that is used to parse dates during import and to format dates during export.
+func (o *csvOption) DateFormat(layout string) csvOption {
+	return func(co *CSVOptions) {
+		co.DateFormat = layout
+	}
+}
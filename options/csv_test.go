@@ -0,0 +1,40 @@
+package options
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCSVOptions(t *testing.T) {
+	o := NewCSVOptions()
+	require.IsType(t, &CSVOptions{}, o)
+	require.Equal(t, &CSVOptions{
+		Delimiter:       ',',
+		FormattedValues: true,
+	}, o)
+
+	o = NewCSVOptions(
+		CSV.Delimiter('\t'),
+		CSV.Comment('#'),
+		CSV.LazyQuotes(true),
+		CSV.QuoteAll(true),
+		CSV.UseCRLF(true),
+		CSV.InferTypes(true),
+		CSV.FormattedValues(false),
+		CSV.DateFormat("02.01.2006"),
+	)
+	require.Equal(t, &CSVOptions{
+		Delimiter:  '\t',
+		Comment:    '#',
+		LazyQuotes: true,
+		QuoteAll:   true,
+		UseCRLF:    true,
+		InferTypes: true,
+		DateFormat: "02.01.2006",
+	}, o)
+
+	o = NewCSVOptions(
+		CSV.Delimiter('"'),
+	)
+	require.Equal(t, ',', o.Delimiter)
+}
@@ -0,0 +1,66 @@
+package options
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+type exportOption func(eo *ExportOptions)
+
+//ExportOptions is a helper type to simplify process of settings options for export of sheet
+type ExportOptions struct {
+	HeaderRow       bool
+	FormattedValues bool
+	Range           primitives.Bounds
+	Indent          string
+}
+
+//Export is a 'namespace' for all possible options for export
+//
+// Possible options are:
+// HeaderRow
+// FormattedValues
+// Range
+// Indent
+var Export exportOption
+
+//NewExportOptions create and returns option set for export
+func NewExportOptions(options ...exportOption) *ExportOptions {
+	s := &ExportOptions{}
+	s.Set(options...)
+	return s
+}
+
+//Set sets new options for option set
+func (eo *ExportOptions) Set(options ...exportOption) {
+	for _, o := range options {
+		o(eo)
+	}
+}
+
+//HeaderRow sets flag indicating if first row of range is a header, so rows will be exported as objects with keys from header rather than arrays.
+func (o *exportOption) HeaderRow(header bool) exportOption {
+	return func(eo *ExportOptions) {
+		eo.HeaderRow = header
+	}
+}
+
+//FormattedValues sets flag indicating if values should be exported as strings that are displayed by number format of cell, rather than typed values.
+func (o *exportOption) FormattedValues(formatted bool) exportOption {
+	return func(eo *ExportOptions) {
+		eo.FormattedValues = formatted
+	}
+}
+
+//Range sets bounds of cells to export. By default, all cells of sheet are exported.
+func (o *exportOption) Range(bounds primitives.Bounds) exportOption {
+	return func(eo *ExportOptions) {
+		eo.Range = bounds
+	}
+}
+
+//Indent sets indentation of output, e.g. "  " for pretty printed output.
+func (o *exportOption) Indent(indent string) exportOption {
+	return func(eo *ExportOptions) {
+		eo.Indent = indent
+	}
+}
@@ -0,0 +1,26 @@
+package options
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestExportOptions(t *testing.T) {
+	o := NewExportOptions()
+	require.IsType(t, &ExportOptions{}, o)
+	require.Equal(t, &ExportOptions{}, o)
+
+	o = NewExportOptions(
+		Export.HeaderRow(true),
+		Export.FormattedValues(true),
+		Export.Range(primitives.BoundsFromIndexes(0, 0, 2, 10)),
+		Export.Indent("  "),
+	)
+	require.Equal(t, &ExportOptions{
+		HeaderRow:       true,
+		FormattedValues: true,
+		Range:           primitives.BoundsFromIndexes(0, 0, 2, 10),
+		Indent:          "  ",
+	}, o)
+}
@@ -0,0 +1,66 @@
+package options
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+type findOption func(fo *FindOptions)
+
+//FindOptions is a helper type to simplify process of settings options for search and replace of cells
+type FindOptions struct {
+	MatchCase  bool
+	WholeCell  bool
+	InFormulas bool
+	Range      primitives.Bounds
+}
+
+//Find is a 'namespace' for all possible options for search and replace
+//
+// Possible options are:
+// MatchCase
+// WholeCell
+// InFormulas
+// Range
+var Find findOption
+
+//NewFindOptions create and returns option set for search and replace
+func NewFindOptions(options ...findOption) *FindOptions {
+	s := &FindOptions{}
+	s.Set(options...)
+	return s
+}
+
+//Set sets new options for option set
+func (fo *FindOptions) Set(options ...findOption) {
+	for _, o := range options {
+		o(fo)
+	}
+}
+
+//MatchCase sets flag indicating if search is case sensitive.
+func (o *findOption) MatchCase(match bool) findOption {
+	return func(fo *FindOptions) {
+		fo.MatchCase = match
+	}
+}
+
+//WholeCell sets flag indicating if entire content of cell must match, rather than part of it.
+func (o *findOption) WholeCell(whole bool) findOption {
+	return func(fo *FindOptions) {
+		fo.WholeCell = whole
+	}
+}
+
+//InFormulas sets flag indicating if formulas of cells should be searched, rather than values of cells.
+func (o *findOption) InFormulas(formulas bool) findOption {
+	return func(fo *FindOptions) {
+		fo.InFormulas = formulas
+	}
+}
+
+//Range sets bounds of cells to search. By default, all cells of sheet are searched.
+func (o *findOption) Range(bounds primitives.Bounds) findOption {
+	return func(fo *FindOptions) {
+		fo.Range = bounds
+	}
+}
@@ -0,0 +1,26 @@
+package options
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFindOptions(t *testing.T) {
+	o := NewFindOptions()
+	require.IsType(t, &FindOptions{}, o)
+	require.Equal(t, &FindOptions{}, o)
+
+	o = NewFindOptions(
+		Find.MatchCase(true),
+		Find.WholeCell(true),
+		Find.InFormulas(true),
+		Find.Range(primitives.BoundsFromIndexes(0, 0, 2, 10)),
+	)
+	require.Equal(t, &FindOptions{
+		MatchCase:  true,
+		WholeCell:  true,
+		InFormulas: true,
+		Range:      primitives.BoundsFromIndexes(0, 0, 2, 10),
+	}, o)
+}
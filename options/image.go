@@ -0,0 +1,89 @@
+package options
+
+//ImageAnchorType is a type to define how image should be anchored to cells
+type ImageAnchorType byte
+
+//List of all possible values for ImageAnchorType
+const (
+	ImageAnchorOneCell ImageAnchorType = iota //image moves with cells, but will not be resized
+	ImageAnchorTwoCell                        //image moves and resizes with cells
+)
+
+type imageOption func(co *ImageOptions)
+
+//ImageOptions is a helper type to simplify process of settings options for image
+type ImageOptions struct {
+	Anchor      ImageAnchorType
+	ScaleX      float64
+	ScaleY      float64
+	OffsetX     int
+	OffsetY     int
+	Name        string
+	Description string
+}
+
+//Image is a 'namespace' for all possible options for image
+//
+// Possible options are:
+// Anchor
+// Scale
+// Offset
+// Name
+// Description
+var Image imageOption
+
+//NewImageOptions create and returns option set for image
+func NewImageOptions(options ...imageOption) *ImageOptions {
+	s := &ImageOptions{ScaleX: 1, ScaleY: 1}
+	s.Set(options...)
+	return s
+}
+
+//Set sets new options for option set
+func (io *ImageOptions) Set(options ...imageOption) {
+	for _, o := range options {
+		o(io)
+	}
+}
+
+//Anchor sets type of anchor for image.
+func (o *imageOption) Anchor(anchor ImageAnchorType) imageOption {
+	return func(io *ImageOptions) {
+		io.Anchor = anchor
+	}
+}
+
+//Scale sets horizontal and vertical scale factors of image. E.g. 0.5 is a half of original size.
+func (o *imageOption) Scale(x, y float64) imageOption {
+	return func(io *ImageOptions) {
+		if x > 0 {
+			io.ScaleX = x
+		}
+
+		if y > 0 {
+			io.ScaleY = y
+		}
+	}
+}
+
+//Offset sets horizontal and vertical offsets of image in pixels, relative to top left corner of cell.
+func (o *imageOption) Offset(x, y int) imageOption {
+	return func(io *ImageOptions) {
+		io.OffsetX = x
+		io.OffsetY = y
+	}
+}
+
+//Name sets name of image.
+func (o *imageOption) Name(name string) imageOption {
+	return func(io *ImageOptions) {
+		io.Name = name
+	}
+}
+
+//Description sets alternative text of image.
+func (o *imageOption) Description(description string) imageOption {
+	return func(io *ImageOptions) {
+		io.Description = description
+	}
+}
@@ -0,0 +1,40 @@
+package options
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestImageOptions(t *testing.T) {
+	o := NewImageOptions()
+	require.IsType(t, &ImageOptions{}, o)
+	require.Equal(t, &ImageOptions{
+		ScaleX: 1,
+		ScaleY: 1,
+	}, o)
+
+	o = NewImageOptions(
+		Image.Anchor(ImageAnchorTwoCell),
+		Image.Scale(0.5, 2),
+		Image.Offset(10, 20),
+		Image.Name("Logo"),
+		Image.Description("Company logo"),
+	)
+	require.Equal(t, &ImageOptions{
+		Anchor:      ImageAnchorTwoCell,
+		ScaleX:      0.5,
+		ScaleY:      2,
+		OffsetX:     10,
+		OffsetY:     20,
+		Name:        "Logo",
+		Description: "Company logo",
+	}, o)
+
+	o = NewImageOptions(
+		Image.Scale(-1, 0),
+	)
+	require.Equal(t, &ImageOptions{
+		ScaleX: 1,
+		ScaleY: 1,
+	}, o)
+}
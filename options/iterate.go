@@ -0,0 +1,57 @@
+package options
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+type iterateOption func(o *IterateOptions)
+
+//IterateOptions is a helper type to simplify process of settings options for iterating cells of sheet
+type IterateOptions struct {
+	SkipEmpty bool
+	ByColumn  bool
+	Range     primitives.Bounds
+}
+
+//Iterate is a 'namespace' for all possible options for iterating cells of sheet
+//
+// Possible options are:
+// SkipEmpty
+// ByColumn
+// Range
+var Iterate iterateOption
+
+//NewIterateOptions create and returns option set for iterating cells of sheet
+func NewIterateOptions(options ...iterateOption) *IterateOptions {
+	s := &IterateOptions{}
+	s.Set(options...)
+	return s
+}
+
+//Set sets new options for option set
+func (it *IterateOptions) Set(options ...iterateOption) {
+	for _, o := range options {
+		o(it)
+	}
+}
+
+//SkipEmpty sets flag indicating if only non empty cells (with value, formula or style) should be iterated, rather than all cells of range.
+func (o *iterateOption) SkipEmpty(skip bool) iterateOption {
+	return func(it *IterateOptions) {
+		it.SkipEmpty = skip
+	}
+}
+
+//ByColumn sets flag indicating if cells should be iterated column by column, rather than row by row.
+func (o *iterateOption) ByColumn(byColumn bool) iterateOption {
+	return func(it *IterateOptions) {
+		it.ByColumn = byColumn
+	}
+}
+
+//Range sets bounds of cells to iterate. By default, cells of used range of sheet are iterated.
+func (o *iterateOption) Range(bounds primitives.Bounds) iterateOption {
+	return func(it *IterateOptions) {
+		it.Range = bounds
+	}
+}
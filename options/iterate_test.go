@@ -0,0 +1,24 @@
+package options
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIterateOptions(t *testing.T) {
+	o := NewIterateOptions()
+	require.IsType(t, &IterateOptions{}, o)
+	require.Equal(t, &IterateOptions{}, o)
+
+	o = NewIterateOptions(
+		Iterate.SkipEmpty(true),
+		Iterate.ByColumn(true),
+		Iterate.Range(primitives.BoundsFromIndexes(0, 0, 2, 10)),
+	)
+	require.Equal(t, &IterateOptions{
+		SkipEmpty: true,
+		ByColumn:  true,
+		Range:     primitives.BoundsFromIndexes(0, 0, 2, 10),
+	}, o)
+}
@@ -0,0 +1,44 @@
+package options
+
+type objectOption func(co *ObjectOptions)
+
+//ObjectOptions is a helper type to simplify process of settings options for embedded object
+type ObjectOptions struct {
+	Label string
+	Icon  []byte
+}
+
+//Object is a 'namespace' for all possible options for embedded object
+//
+// Possible options are:
+// Label
+// Icon
+var Object objectOption
+
+//NewObjectOptions create and returns option set for embedded object
+func NewObjectOptions(options ...objectOption) *ObjectOptions {
+	s := &ObjectOptions{}
+	s.Set(options...)
+	return s
+}
+
+//Set sets new options for option set
+func (oo *ObjectOptions) Set(options ...objectOption) {
+	for _, o := range options {
+		o(oo)
+	}
+}
+
+//Label sets label of embedded object that is shown under icon. By default, it is a name of embedded file.
+func (o *objectOption) Label(label string) objectOption {
+	return func(oo *ObjectOptions) {
+		oo.Label = label
+	}
+}
+
+//Icon sets content of image in PNG, JPEG or GIF format that is displayed for embedded object instead of default icon.
+func (o *objectOption) Icon(icon []byte) objectOption {
+	return func(oo *ObjectOptions) {
+		oo.Icon = icon
+	}
+}
@@ -0,0 +1,21 @@
+package options
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestObjectOptions(t *testing.T) {
+	o := NewObjectOptions()
+	require.IsType(t, &ObjectOptions{}, o)
+	require.Equal(t, &ObjectOptions{}, o)
+
+	o = NewObjectOptions(
+		Object.Label("Contract"),
+		Object.Icon([]byte{1, 2, 3}),
+	)
+	require.Equal(t, &ObjectOptions{
+		Label: "Contract",
+		Icon:  []byte{1, 2, 3},
+	}, o)
+}
@@ -1,5 +1,9 @@
 package options
 
+import (
+	"github.com/plandem/xlsx/format"
+)
+
 type rowOption func(co *RowOptions)
 
 //RowOptions is a helper type to simplify process of settings options for row
@@ -9,6 +13,7 @@ type RowOptions struct {
 	Phonetic     bool
 	Hidden       bool
 	Height       float32
+	Style        format.DirectStyleID
 }
 
 //Row is a 'namespace' for all possible options for row
@@ -19,6 +24,7 @@ type RowOptions struct {
 // Phonetic
 // Hidden
 // Height
+// Style
 var Row rowOption
 
 //NewRowOptions create and returns option set for row
@@ -71,3 +77,10 @@ func (o *rowOption) Height(height float32) rowOption {
 		ro.Height = height
 	}
 }
+
+//Style sets default style for the affected row. Style applies to new cells of row.
+func (o *rowOption) Style(styleID format.DirectStyleID) rowOption {
+	return func(ro *RowOptions) {
+		ro.Style = styleID
+	}
+}
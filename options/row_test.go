@@ -1,6 +1,7 @@
 package options
 
 import (
+	"github.com/plandem/xlsx/format"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -12,6 +13,7 @@ func TestRowOptions(t *testing.T) {
 		Row.Phonetic(true),
 		Row.Height(45.5),
 		Row.Collapsed(true),
+		Row.Style(format.DirectStyleID(2)),
 	)
 
 	require.IsType(t, &RowOptions{}, o)
@@ -21,6 +23,7 @@ func TestRowOptions(t *testing.T) {
 		Phonetic:     true,
 		Height:       45.5,
 		Collapsed:    true,
+		Style:        format.DirectStyleID(2),
 	}, o)
 
 	o = NewRowOptions(
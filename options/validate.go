@@ -0,0 +1,44 @@
+package options
+
+type validateOption func(vo *ValidateOptions)
+
+//ValidateOptions is a helper type to simplify process of settings options for validation of workbook
+type ValidateOptions struct {
+	WellFormed bool
+	Schema     bool
+}
+
+//Validate is a 'namespace' for all possible options for validation of workbook
+//
+// Possible options are:
+// WellFormed
+// Schema
+var Validate validateOption
+
+//NewValidateOptions create and returns option set for validation of workbook
+func NewValidateOptions(options ...validateOption) *ValidateOptions {
+	s := &ValidateOptions{}
+	s.Set(options...)
+	return s
+}
+
+//Set sets new options for option set
+func (vo *ValidateOptions) Set(options ...validateOption) {
+	for _, o := range options {
+		o(vo)
+	}
+}
+
+//WellFormed sets flag indicating if marshalled parts of package should be checked for well-formed XML too. Parts are marshalled the same way as for saving, but document is not saved
+func (o *validateOption) WellFormed(validate bool) validateOption {
+	return func(vo *ValidateOptions) {
+		vo.WellFormed = validate
+	}
+}
+
+//Schema sets flag indicating if marshalled parts of package should be validated against bundled schemas of ECMA-376 too, i.e. allowed child elements, order of child elements, required child elements and required attributes of main parts are checked
+func (o *validateOption) Schema(validate bool) validateOption {
+	return func(vo *ValidateOptions) {
+		vo.Schema = validate
+	}
+}
@@ -0,0 +1,21 @@
+package options
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestValidateOptions(t *testing.T) {
+	o := NewValidateOptions()
+	require.IsType(t, &ValidateOptions{}, o)
+	require.Equal(t, &ValidateOptions{}, o)
+
+	o = NewValidateOptions(
+		Validate.WellFormed(true),
+		Validate.Schema(true),
+	)
+	require.Equal(t, &ValidateOptions{
+		WellFormed: true,
+		Schema:     true,
+	}, o)
+}
@@ -0,0 +1,100 @@
+package options
+
+//ViewType is a type of view of sheet
+type ViewType string
+
+//List of all possible values for ViewType
+const (
+	ViewTypeNormal           ViewType = "normal"
+	ViewTypePageLayout       ViewType = "pageLayout"
+	ViewTypePageBreakPreview ViewType = "pageBreakPreview"
+)
+
+type viewOption func(vo *ViewOptions)
+
+//ViewOptions is a helper type to simplify process of settings options for view of sheet
+type ViewOptions struct {
+	ZoomScale         uint
+	ShowGridLines     bool
+	ShowRowColHeaders bool
+	RightToLeft       bool
+	Type              ViewType
+	TabColor          string
+}
+
+//View is a 'namespace' for all possible options for view of sheet
+//
+// Possible options are:
+// ZoomScale
+// ShowGridLines
+// ShowRowColHeaders
+// RightToLeft
+// Type
+// TabColor
+var View viewOption
+
+//NewViewOptions create and returns option set for view of sheet with defaults of Excel
+func NewViewOptions(options ...viewOption) *ViewOptions {
+	s := &ViewOptions{
+		ZoomScale:         100,
+		ShowGridLines:     true,
+		ShowRowColHeaders: true,
+		Type:              ViewTypeNormal,
+	}
+	s.Set(options...)
+	return s
+}
+
+//Set sets new options for option set
+func (vo *ViewOptions) Set(options ...viewOption) {
+	for _, o := range options {
+		o(vo)
+	}
+}
+
+//ZoomScale sets zoom of view in percents, allowed values are between 10 and 400.
+func (o *viewOption) ZoomScale(scale uint) viewOption {
+	return func(vo *ViewOptions) {
+		if scale >= 10 && scale <= 400 {
+			vo.ZoomScale = scale
+		}
+	}
+}
+
+//ShowGridLines sets flag indicating if the grid lines should be displayed.
+func (o *viewOption) ShowGridLines(show bool) viewOption {
+	return func(vo *ViewOptions) {
+		vo.ShowGridLines = show
+	}
+}
+
+//ShowRowColHeaders sets flag indicating if the headers of rows and columns should be displayed.
+func (o *viewOption) ShowRowColHeaders(show bool) viewOption {
+	return func(vo *ViewOptions) {
+		vo.ShowRowColHeaders = show
+	}
+}
+
+//RightToLeft sets flag indicating if the sheet is in 'right to left' display mode.
+func (o *viewOption) RightToLeft(rtl bool) viewOption {
+	return func(vo *ViewOptions) {
+		vo.RightToLeft = rtl
+	}
+}
+
+//Type sets type of view, e.g.: normal, page layout or page break preview.
+func (o *viewOption) Type(t ViewType) viewOption {
+	return func(vo *ViewOptions) {
+		switch t {
+		case ViewTypeNormal, ViewTypePageLayout, ViewTypePageBreakPreview:
+			vo.Type = t
+		}
+	}
+}
+
+//TabColor sets color of sheet tab in #RRGGBB format. Empty string removes color of tab.
+func (o *viewOption) TabColor(color string) viewOption {
+	return func(vo *ViewOptions) {
+		vo.TabColor = color
+	}
+}
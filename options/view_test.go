@@ -0,0 +1,40 @@
+package options
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestViewOptions(t *testing.T) {
+	o := NewViewOptions()
+	require.Equal(t, &ViewOptions{
+		ZoomScale:         100,
+		ShowGridLines:     true,
+		ShowRowColHeaders: true,
+		Type:              ViewTypeNormal,
+	}, o)
+
+	o = NewViewOptions(
+		View.ZoomScale(150),
+		View.ShowGridLines(false),
+		View.ShowRowColHeaders(false),
+		View.RightToLeft(true),
+		View.Type(ViewTypePageLayout),
+		View.TabColor("#FF0000"),
+	)
+
+	require.IsType(t, &ViewOptions{}, o)
+	require.Equal(t, &ViewOptions{
+		ZoomScale:         150,
+		ShowGridLines:     false,
+		ShowRowColHeaders: false,
+		RightToLeft:       true,
+		Type:              ViewTypePageLayout,
+		TabColor:          "#FF0000",
+	}, o)
+
+	//invalid values are ignored
+	o.Set(View.ZoomScale(1000), View.Type("unknown"))
+	require.Equal(t, uint(150), o.ZoomScale)
+	require.Equal(t, ViewTypePageLayout, o.Type)
+}
@@ -0,0 +1,143 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+)
+
+//N.B.: Excel supports up to 7 levels of outline
+const outlineLevelLimit = 7
+
+//defaultRowHeight is a default height of row in points that is used by Excel for a new sheet
+const defaultRowHeight = 15
+
+//sheetFormatPr returns format properties of sheet, properties will be added if required
+func (s *sheetInfo) sheetFormatPr() *ml.SheetFormatPr {
+	if s.ml.SheetFormatPr == nil {
+		s.ml.SheetFormatPr = &ml.SheetFormatPr{DefaultRowHeight: defaultRowHeight}
+	}
+
+	return s.ml.SheetFormatPr
+}
+
+//validateOutline checks if indexes and level are valid for grouping
+func validateOutline(fromIndex, toIndex int, level uint8, limit int) error {
+	if fromIndex < 0 || fromIndex > toIndex || toIndex >= limit {
+		return errors.New(fmt.Sprintf("invalid range for grouping: %d-%d", fromIndex, toIndex))
+	}
+
+	if level > outlineLevelLimit {
+		return errors.New(fmt.Sprintf("outline level must be between 0 and %d, but %d was used", outlineLevelLimit, level))
+	}
+
+	return nil
+}
+
+//OutlineSummary returns true for below if summary rows are below of details and true for right if summary columns are at right of details
+func (s *sheetInfo) OutlineSummary() (below bool, right bool) {
+	below, right = true, true
+	if s.ml.SheetPr != nil && s.ml.SheetPr.OutlinePr != nil {
+		if pr := s.ml.SheetPr.OutlinePr; pr.SummaryBelow != nil {
+			below = *pr.SummaryBelow
+		}
+
+		if pr := s.ml.SheetPr.OutlinePr; pr.SummaryRight != nil {
+			right = *pr.SummaryRight
+		}
+	}
+
+	return
+}
+
+//SetOutlineSummary sets position of summary rows and columns. By default, summary rows are below of details and summary columns are at right of details
+func (s *sheetInfo) SetOutlineSummary(below bool, right bool) {
+	if s.ml.SheetPr == nil {
+		s.ml.SheetPr = &ml.SheetPr{}
+	}
+
+	if s.ml.SheetPr.OutlinePr == nil {
+		s.ml.SheetPr.OutlinePr = &ml.OutlinePr{}
+	}
+
+	//true is a default value
+	pr := s.ml.SheetPr.OutlinePr
+	pr.SummaryBelow, pr.SummaryRight = nil, nil
+
+	if !below {
+		pr.SummaryBelow = &below
+	}
+
+	if !right {
+		pr.SummaryRight = &right
+	}
+}
+
+//GroupRows groups rows between fromIndex and toIndex with outline level, collapsed group hides rows. Zero level ungroups rows
+func (s *sheetInfo) GroupRows(fromIndex, toIndex int, level uint8, collapsed bool) error {
+	if err := validateOutline(fromIndex, toIndex, level, internal.ExcelRowLimit); err != nil {
+		return err
+	}
+
+	collapsed = collapsed && level > 0
+	for rIdx := fromIndex; rIdx <= toIndex; rIdx++ {
+		row := s.sheet.Row(rIdx)
+		row.ml.OutlineLevel = level
+		row.ml.Hidden = collapsed
+	}
+
+	//state of group is stored at summary row
+	summary := toIndex + 1
+	if below, _ := s.OutlineSummary(); !below {
+		summary = fromIndex - 1
+	}
+
+	if summary >= 0 && summary < internal.ExcelRowLimit {
+		s.sheet.Row(summary).ml.Collapsed = collapsed
+	}
+
+	maxLevel := uint8(0)
+	for _, row := range s.ml.SheetData {
+		if row != nil && row.OutlineLevel > maxLevel {
+			maxLevel = row.OutlineLevel
+		}
+	}
+
+	s.sheetFormatPr().OutlineLevelRow = maxLevel
+	return nil
+}
+
+//GroupCols groups columns between fromIndex and toIndex with outline level, collapsed group hides columns. Zero level ungroups columns
+func (s *sheetInfo) GroupCols(fromIndex, toIndex int, level uint8, collapsed bool) error {
+	if err := validateOutline(fromIndex, toIndex, level, internal.ExcelColumnLimit); err != nil {
+		return err
+	}
+
+	collapsed = collapsed && level > 0
+	for cIdx := fromIndex; cIdx <= toIndex; cIdx++ {
+		col := s.columns.Resolve(cIdx)
+		col.OutlineLevel = level
+		col.Hidden = collapsed
+	}
+
+	//state of group is stored at summary column
+	summary := toIndex + 1
+	if _, right := s.OutlineSummary(); !right {
+		summary = fromIndex - 1
+	}
+
+	if summary >= 0 && summary < internal.ExcelColumnLimit {
+		s.columns.Resolve(summary).Collapsed = collapsed
+	}
+
+	maxLevel := uint8(0)
+	for _, col := range s.ml.Cols.Items {
+		if col.OutlineLevel > maxLevel {
+			maxLevel = col.OutlineLevel
+		}
+	}
+
+	s.sheetFormatPr().OutlineLevelCol = maxLevel
+	return nil
+}
@@ -0,0 +1,68 @@
+package xlsx
+
+import (
+	"encoding/xml"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestOutline(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Report")
+
+	//invalid groups
+	require.NotNil(t, sheet.GroupRows(3, 1, 1, false))
+	require.NotNil(t, sheet.GroupRows(-1, 1, 1, false))
+	require.NotNil(t, sheet.GroupCols(1, 3, 8, false))
+	require.Nil(t, sheet.info().ml.SheetFormatPr)
+
+	below, right := sheet.OutlineSummary()
+	require.Equal(t, true, below)
+	require.Equal(t, true, right)
+
+	//nested groups of rows, summary rows are below of details
+	require.Nil(t, sheet.GroupRows(1, 6, 1, false))
+	require.Nil(t, sheet.GroupRows(2, 3, 2, true))
+	require.Equal(t, uint8(1), sheet.Row(1).OutlineLevel())
+	require.Equal(t, uint8(2), sheet.Row(2).OutlineLevel())
+	require.Equal(t, true, sheet.info().ml.SheetData[3].Hidden)
+	require.Equal(t, true, sheet.Row(4).IsCollapsed())
+	require.Equal(t, false, sheet.Row(7).IsCollapsed())
+	require.Equal(t, uint8(2), sheet.info().ml.SheetFormatPr.OutlineLevelRow)
+
+	//summary cols are at left of details
+	sheet.SetOutlineSummary(true, false)
+	require.Nil(t, sheet.GroupCols(2, 4, 1, true))
+	require.Equal(t, uint8(1), sheet.Col(3).OutlineLevel())
+	require.Equal(t, true, sheet.Col(1).IsCollapsed())
+	require.Equal(t, false, sheet.Col(5).IsCollapsed())
+	require.Equal(t, uint8(1), sheet.info().ml.SheetFormatPr.OutlineLevelCol)
+
+	encoded, err := xml.Marshal(sheet.info().ml.SheetPr)
+	require.Nil(t, err)
+	require.Equal(t, `<SheetPr><outlinePr summaryRight="false"></outlinePr></SheetPr>`, string(encoded))
+
+	//save and reopen
+	err = xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	below, right = sheet.OutlineSummary()
+	require.Equal(t, true, below)
+	require.Equal(t, false, right)
+	require.Equal(t, uint8(2), sheet.Row(3).OutlineLevel())
+	require.Equal(t, true, sheet.Row(4).IsCollapsed())
+	require.Equal(t, uint8(1), sheet.Col(2).OutlineLevel())
+
+	//ungroup
+	require.Nil(t, sheet.GroupRows(2, 3, 0, true))
+	require.Equal(t, uint8(0), sheet.Row(2).OutlineLevel())
+	require.Equal(t, false, sheet.info().ml.SheetData[3].Hidden)
+	require.Equal(t, false, sheet.Row(4).IsCollapsed())
+	require.Equal(t, uint8(1), sheet.info().ml.SheetFormatPr.OutlineLevelRow)
+}
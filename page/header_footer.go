@@ -0,0 +1,317 @@
+package page
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"io"
+	"strings"
+)
+
+//Part is a type of header or footer
+type Part byte
+
+//List of all possible parts of headers and footers
+const (
+	PartHeader Part = iota
+	PartFooter
+	PartFirstHeader
+	PartFirstFooter
+	PartEvenHeader
+	PartEvenFooter
+)
+
+//Section is a type of section of header or footer
+type Section byte
+
+//List of all possible sections of headers and footers
+const (
+	SectionLeft Section = iota
+	SectionCenter
+	SectionRight
+)
+
+//List of the most used field codes for text of headers and footers, e.g.: "Page " + FieldPageNumber + " of " + FieldPagesCount
+const (
+	FieldPageNumber = "&P"
+	FieldPagesCount = "&N"
+	FieldDate       = "&D"
+	FieldTime       = "&T"
+	FieldFileName   = "&F"
+	FieldFilePath   = "&Z"
+	FieldSheetName  = "&A"
+	FieldImage      = "&G"
+)
+
+//HeaderFooter is objects that holds headers and footers of sheet
+type HeaderFooter struct {
+	hf      *ml.HeaderFooter
+	images  map[string]io.Reader
+	washout map[string]bool
+}
+
+//HeaderFooterOption is a type of option for headers and footers
+type HeaderFooterOption func(h *HeaderFooter)
+
+//NewHeaderFooter creates and returns a new HeaderFooter object that holds headers and footers of sheet
+func NewHeaderFooter(options ...HeaderFooterOption) *HeaderFooter {
+	h := &HeaderFooter{
+		hf:      &ml.HeaderFooter{},
+		images:  make(map[string]io.Reader),
+		washout: make(map[string]bool),
+	}
+
+	h.Set(options...)
+	return h
+}
+
+//Set sets new options for headers and footers
+func (h *HeaderFooter) Set(options ...HeaderFooterOption) {
+	for _, o := range options {
+		o(h)
+	}
+}
+
+//text returns text of part
+func (h *HeaderFooter) text(part Part) *string {
+	switch part {
+	case PartFooter:
+		return &h.hf.OddFooter
+	case PartFirstHeader:
+		return &h.hf.FirstHeader
+	case PartFirstFooter:
+		return &h.hf.FirstFooter
+	case PartEvenHeader:
+		return &h.hf.EvenHeader
+	case PartEvenFooter:
+		return &h.hf.EvenFooter
+	}
+
+	return &h.hf.OddHeader
+}
+
+//Text returns left, center and right sections of part
+func (h *HeaderFooter) Text(part Part) (left, center, right string) {
+	sections := splitSections(*h.text(part))
+	return sections[SectionLeft], sections[SectionCenter], sections[SectionRight]
+}
+
+//DifferentFirst returns true if the first page has own header and footer
+func (h *HeaderFooter) DifferentFirst() bool {
+	return h.hf.DifferentFirst
+}
+
+//DifferentOddEven returns true if even pages have own header and footer
+func (h *HeaderFooter) DifferentOddEven() bool {
+	return h.hf.DifferentOddEven
+}
+
+//Validate validates headers and footers
+func (h *HeaderFooter) Validate() error {
+	for _, text := range []string{h.hf.OddHeader, h.hf.OddFooter, h.hf.EvenHeader, h.hf.EvenFooter, h.hf.FirstHeader, h.hf.FirstFooter} {
+		if len(text) > internal.ExcelHeaderFooterLimit {
+			return errors.New(fmt.Sprintf("text of header or footer exceeded maximum allowed length (%d chars)", internal.ExcelHeaderFooterLimit))
+		}
+	}
+
+	return nil
+}
+
+//splitSections splits text of header or footer into left, center and right sections. Text without section is a center section
+func splitSections(text string) [3]string {
+	var sections [3]string
+	section := SectionCenter
+
+	for i := 0; i < len(text); i++ {
+		if text[i] == '&' && i+1 < len(text) {
+			switch text[i+1] {
+			case 'L':
+				section, i = SectionLeft, i+1
+				continue
+			case 'C':
+				section, i = SectionCenter, i+1
+				continue
+			case 'R':
+				section, i = SectionRight, i+1
+				continue
+			default:
+				//keep other codes as is, e.g.: &&, &P or &B
+				sections[section] += text[i : i+2]
+				i++
+				continue
+			}
+		}
+
+		sections[section] += text[i : i+1]
+	}
+
+	return sections
+}
+
+//joinSections joins left, center and right sections into text of header or footer
+func joinSections(left, center, right string) string {
+	var text string
+	for i, section := range []string{left, center, right} {
+		if len(section) > 0 {
+			text += []string{"&L", "&C", "&R"}[i] + section
+		}
+	}
+
+	return text
+}
+
+//setText sets left, center and right sections of part
+func setText(part Part, left, center, right string) HeaderFooterOption {
+	return func(h *HeaderFooter) {
+		*h.text(part) = joinSections(left, center, right)
+
+		switch part {
+		case PartFirstHeader, PartFirstFooter:
+			h.hf.DifferentFirst = true
+		case PartEvenHeader, PartEvenFooter:
+			h.hf.DifferentOddEven = true
+		}
+	}
+}
+
+//Header sets left, center and right sections of header, e.g.: Header("", "Invoice", "Page "+FieldPageNumber). Empty sections are omitted
+func Header(left, center, right string) HeaderFooterOption {
+	return setText(PartHeader, left, center, right)
+}
+
+//Footer sets left, center and right sections of footer
+func Footer(left, center, right string) HeaderFooterOption {
+	return setText(PartFooter, left, center, right)
+}
+
+//FirstHeader sets left, center and right sections of header of the first page
+func FirstHeader(left, center, right string) HeaderFooterOption {
+	return setText(PartFirstHeader, left, center, right)
+}
+
+//FirstFooter sets left, center and right sections of footer of the first page
+func FirstFooter(left, center, right string) HeaderFooterOption {
+	return setText(PartFirstFooter, left, center, right)
+}
+
+//EvenHeader sets left, center and right sections of header of even pages
+func EvenHeader(left, center, right string) HeaderFooterOption {
+	return setText(PartEvenHeader, left, center, right)
+}
+
+//EvenFooter sets left, center and right sections of footer of even pages
+func EvenFooter(left, center, right string) HeaderFooterOption {
+	return setText(PartEvenFooter, left, center, right)
+}
+
+//ScaleWithDocument sets flag indicating if headers and footers should be scaled with document
+func ScaleWithDocument(scale bool) HeaderFooterOption {
+	return func(h *HeaderFooter) {
+		h.hf.ScaleWithDoc = nil
+		if !scale {
+			h.hf.ScaleWithDoc = &scale
+		}
+	}
+}
+
+//AlignWithMargins sets flag indicating if headers and footers should be aligned with margins of page
+func AlignWithMargins(align bool) HeaderFooterOption {
+	return func(h *HeaderFooter) {
+		h.hf.AlignWithMargins = nil
+		if !align {
+			h.hf.AlignWithMargins = &align
+		}
+	}
+}
+
+//Image sets image for section of part, FieldImage will be added to text of section if required. Nil image removes image
+func Image(part Part, section Section, image io.Reader) HeaderFooterOption {
+	return func(h *HeaderFooter) {
+		sections := splitSections(*h.text(part))
+		if image != nil {
+			if !strings.Contains(sections[section], FieldImage) {
+				sections[section] += FieldImage
+			}
+		} else {
+			sections[section] = strings.Replace(sections[section], FieldImage, "", -1)
+		}
+
+		setText(part, sections[SectionLeft], sections[SectionCenter], sections[SectionRight])(h)
+		h.images[imageID(part, section)] = image
+		delete(h.washout, imageID(part, section))
+	}
+}
+
+//Watermark sets semi-transparent image for center section of header, e.g. to stamp pages with DRAFT or CONFIDENTIAL. Image of header is printed behind content of sheet, so image should be big enough to cover page. Nil image removes watermark
+func Watermark(image io.Reader) HeaderFooterOption {
+	return func(h *HeaderFooter) {
+		Image(PartHeader, SectionCenter, image)(h)
+		if image != nil {
+			h.washout[imageID(PartHeader, SectionCenter)] = true
+		}
+	}
+}
+
+//imageID returns id of shape with image for section of part, e.g.: LH for left section of header
+func imageID(part Part, section Section) string {
+	id := []string{"L", "C", "R"}[section]
+
+	switch part {
+	case PartHeader, PartFirstHeader, PartEvenHeader:
+		id += "H"
+	default:
+		id += "F"
+	}
+
+	switch part {
+	case PartFirstHeader, PartFirstFooter:
+		id += "FP"
+	case PartEvenHeader, PartEvenFooter:
+		id += "EP"
+	}
+
+	return id
+}
+
+//private method used by headers and footers manager to unpack HeaderFooter
+func fromHeaderFooter(h *HeaderFooter) (*ml.HeaderFooter, map[string]io.Reader, map[string]bool) {
+	//copy info to prevent side effects of reusing HeaderFooter for different sheets
+	hf := *h.hf
+	images := make(map[string]io.Reader, len(h.images))
+	for id, image := range h.images {
+		images[id] = image
+	}
+
+	washout := make(map[string]bool, len(h.washout))
+	for id := range h.washout {
+		washout[id] = true
+	}
+
+	return &hf, images, washout
+}
+
+//private method used by headers and footers manager to get ids of images that are used by sections of parts
+func usedImages(h *HeaderFooter) map[string]bool {
+	used := make(map[string]bool)
+	for _, part := range []Part{PartHeader, PartFooter, PartFirstHeader, PartFirstFooter, PartEvenHeader, PartEvenFooter} {
+		for section, text := range splitSections(*h.text(part)) {
+			if strings.Contains(text, FieldImage) {
+				used[imageID(part, Section(section))] = true
+			}
+		}
+	}
+
+	return used
+}
+
+//private method used by headers and footers manager to pack HeaderFooter
+func toHeaderFooter(hf *ml.HeaderFooter) *HeaderFooter {
+	h := NewHeaderFooter()
+	if hf != nil {
+		c := *hf
+		h.hf = &c
+	}
+
+	return h
+}
@@ -0,0 +1,83 @@
+package page
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestHeaderFooter(t *testing.T) {
+	h := NewHeaderFooter(
+		Header("Invoice", "", "&BPage "+FieldPageNumber+" of "+FieldPagesCount),
+		Footer("", FieldDate, ""),
+		FirstHeader("", "Cover", ""),
+		EvenFooter(FieldSheetName, "", FieldFileName),
+		ScaleWithDocument(false),
+		AlignWithMargins(true),
+	)
+
+	require.Nil(t, h.Validate())
+	require.Equal(t, true, h.DifferentFirst())
+	require.Equal(t, true, h.DifferentOddEven())
+
+	left, center, right := h.Text(PartHeader)
+	require.Equal(t, []string{"Invoice", "", "&BPage &P of &N"}, []string{left, center, right})
+
+	left, center, right = h.Text(PartEvenFooter)
+	require.Equal(t, []string{"&A", "", "&F"}, []string{left, center, right})
+
+	no := false
+	hf, images, washout := fromHeaderFooter(h)
+	require.Equal(t, &ml.HeaderFooter{
+		OddHeader:        "&LInvoice&R&BPage &P of &N",
+		OddFooter:        "&C&D",
+		EvenFooter:       "&L&A&R&F",
+		FirstHeader:      "&CCover",
+		DifferentOddEven: true,
+		DifferentFirst:   true,
+		ScaleWithDoc:     &no,
+	}, hf)
+	require.Equal(t, map[string]io.Reader{}, images)
+	require.Equal(t, map[string]bool{}, washout)
+
+	//text without sections is a center section, escaped ampersand is not a section
+	h = toHeaderFooter(&ml.HeaderFooter{OddHeader: "Tom && Jerry&R&P"})
+	left, center, right = h.Text(PartHeader)
+	require.Equal(t, []string{"", "Tom && Jerry", "&P"}, []string{left, center, right})
+
+	//images
+	logo := strings.NewReader("logo")
+	h.Set(Image(PartHeader, SectionLeft, logo), Image(PartFirstFooter, SectionRight, logo))
+	hf, images, _ = fromHeaderFooter(h)
+	require.Equal(t, "&L&G&CTom && Jerry&R&P", hf.OddHeader)
+	require.Equal(t, "&R&G", hf.FirstFooter)
+	require.Equal(t, true, hf.DifferentFirst)
+	require.Equal(t, map[string]io.Reader{"LH": logo, "RFFP": logo}, images)
+	require.Equal(t, map[string]bool{"LH": true, "RFFP": true}, usedImages(h))
+
+	h.Set(Image(PartHeader, SectionLeft, nil))
+	hf, images, _ = fromHeaderFooter(h)
+	require.Equal(t, "&CTom && Jerry&R&P", hf.OddHeader)
+	require.Nil(t, images["LH"])
+	require.Equal(t, map[string]bool{"RFFP": true}, usedImages(h))
+
+	//watermark
+	h.Set(Watermark(logo))
+	hf, images, washout = fromHeaderFooter(h)
+	require.Equal(t, "&CTom && Jerry&G&R&P", hf.OddHeader)
+	require.Equal(t, logo, images["CH"])
+	require.Equal(t, map[string]bool{"CH": true}, washout)
+
+	h.Set(Image(PartHeader, SectionCenter, logo))
+	_, _, washout = fromHeaderFooter(h)
+	require.Equal(t, map[string]bool{}, washout)
+
+	h.Set(Watermark(nil))
+	hf, _, washout = fromHeaderFooter(h)
+	require.Equal(t, "&CTom && Jerry&R&P", hf.OddHeader)
+	require.Equal(t, map[string]bool{}, washout)
+
+	require.NotNil(t, NewHeaderFooter(Header(strings.Repeat("a", 256), "", "")).Validate())
+}
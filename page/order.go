@@ -0,0 +1,29 @@
+package page
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+//Order is alias of original primitives.PageOrderType type to:
+// 1) make it public
+// 2) forbid usage of integers directly
+type Order = primitives.PageOrderType
+
+//List of all possible values for Order
+const (
+	_ Order = iota
+	OrderDownThenOver
+	OrderOverThenDown
+)
+
+func init() {
+	primitives.FromPageOrderType = map[primitives.PageOrderType]string{
+		OrderDownThenOver: "downThenOver",
+		OrderOverThenDown: "overThenDown",
+	}
+
+	primitives.ToPageOrderType = make(map[string]primitives.PageOrderType, len(primitives.FromPageOrderType))
+	for k, v := range primitives.FromPageOrderType {
+		primitives.ToPageOrderType[v] = k
+	}
+}
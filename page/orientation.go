@@ -0,0 +1,31 @@
+package page
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+//Orientation is alias of original primitives.OrientationType type to:
+// 1) make it public
+// 2) forbid usage of integers directly
+type Orientation = primitives.OrientationType
+
+//List of all possible values for Orientation
+const (
+	_ Orientation = iota
+	OrientationDefault
+	OrientationPortrait
+	OrientationLandscape
+)
+
+func init() {
+	primitives.FromOrientationType = map[primitives.OrientationType]string{
+		OrientationDefault:   "default",
+		OrientationPortrait:  "portrait",
+		OrientationLandscape: "landscape",
+	}
+
+	primitives.ToOrientationType = make(map[string]primitives.OrientationType, len(primitives.FromOrientationType))
+	for k, v := range primitives.FromOrientationType {
+		primitives.ToOrientationType[v] = k
+	}
+}
@@ -0,0 +1,345 @@
+package page
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+)
+
+//Info is objects that holds page setup and print settings of sheet
+type Info struct {
+	setup        *ml.PageSetup
+	margins      *ml.PageMargins
+	printOptions *ml.PrintOptions
+	fitToPage    bool
+	printArea    types.Ref
+	repeatRows   *span
+	repeatCols   *span
+}
+
+//span is a range of rows or columns to repeat at each printed page
+type span struct {
+	from int
+	to   int
+}
+
+//Option is a type of option for page setup
+type Option func(i *Info)
+
+//New creates and returns a new Info object that holds page setup and print settings of sheet
+func New(options ...Option) *Info {
+	i := &Info{
+		setup:        &ml.PageSetup{},
+		printOptions: &ml.PrintOptions{},
+	}
+
+	i.Set(options...)
+	return i
+}
+
+//Set sets new options for page setup
+func (i *Info) Set(options ...Option) {
+	for _, o := range options {
+		o(i)
+	}
+}
+
+//Orientation returns orientation of page
+func (i *Info) Orientation() Orientation {
+	if i.setup.Orientation == 0 {
+		return OrientationDefault
+	}
+
+	return i.setup.Orientation
+}
+
+//Paper returns size of paper
+func (i *Info) Paper() PaperSize {
+	if i.setup.PaperSize == 0 {
+		return PaperLetter
+	}
+
+	return PaperSize(i.setup.PaperSize)
+}
+
+//Scale returns scale of printing in percents
+func (i *Info) Scale() int {
+	if i.setup.Scale == 0 {
+		return 100
+	}
+
+	return i.setup.Scale
+}
+
+//FitToPage returns number of pages to fit sheet in width and height. Zero values mean that number of pages is not limited. If sheet is not fit to pages then false is returned
+func (i *Info) FitToPage() (width int, height int, ok bool) {
+	if !i.fitToPage {
+		return
+	}
+
+	width, height = 1, 1
+	if i.setup.FitToWidth != nil {
+		width = *i.setup.FitToWidth
+	}
+
+	if i.setup.FitToHeight != nil {
+		height = *i.setup.FitToHeight
+	}
+
+	return width, height, true
+}
+
+//Margins returns margins of page in inches
+func (i *Info) Margins() (left, right, top, bottom float64) {
+	m := i.pageMargins()
+	return m.Left, m.Right, m.Top, m.Bottom
+}
+
+//HeaderFooterMargins returns margins of header and footer in inches
+func (i *Info) HeaderFooterMargins() (header, footer float64) {
+	m := i.pageMargins()
+	return m.Header, m.Footer
+}
+
+//PrintArea returns printable area of sheet or empty ref if whole sheet will be printed
+func (i *Info) PrintArea() types.Ref {
+	return i.printArea
+}
+
+//RepeatRows returns 0-based indexes of rows to repeat at top of each printed page. If there are no such rows then false is returned
+func (i *Info) RepeatRows() (from int, to int, ok bool) {
+	if i.repeatRows == nil {
+		return
+	}
+
+	return i.repeatRows.from, i.repeatRows.to, true
+}
+
+//RepeatColumns returns 0-based indexes of columns to repeat at left of each printed page. If there are no such columns then false is returned
+func (i *Info) RepeatColumns() (from int, to int, ok bool) {
+	if i.repeatCols == nil {
+		return
+	}
+
+	return i.repeatCols.from, i.repeatCols.to, true
+}
+
+//GridLines returns true if grid lines will be printed
+func (i *Info) GridLines() bool {
+	return i.printOptions.GridLines
+}
+
+//Validate validates page setup settings
+func (i *Info) Validate() error {
+	if i.setup.Scale != 0 && (i.setup.Scale < 10 || i.setup.Scale > 400) {
+		return errors.New(fmt.Sprintf("scale must be between 10 and 400 percents, but %d was used", i.setup.Scale))
+	}
+
+	if (i.setup.FitToWidth != nil && *i.setup.FitToWidth < 0) || (i.setup.FitToHeight != nil && *i.setup.FitToHeight < 0) {
+		return errors.New("number of pages to fit can't be negative")
+	}
+
+	if i.setup.PaperSize < 0 || i.setup.Copies < 0 || i.setup.FirstPageNumber < 0 {
+		return errors.New("paper size, number of copies and first page number can't be negative")
+	}
+
+	if m := i.margins; m != nil && (m.Left < 0 || m.Right < 0 || m.Top < 0 || m.Bottom < 0 || m.Header < 0 || m.Footer < 0) {
+		return errors.New("margins can't be negative")
+	}
+
+	if bounds := i.printArea.ToBounds(); len(i.printArea) > 0 && (bounds.FromCol < 0 || bounds.FromRow < 0 || bounds.ToCol < 0 || bounds.ToRow < 0) {
+		return errors.New(fmt.Sprintf("invalid print area: %s", i.printArea))
+	}
+
+	for _, s := range []*span{i.repeatRows, i.repeatCols} {
+		if s != nil && (s.from < 0 || s.from > s.to) {
+			return errors.New(fmt.Sprintf("invalid range of rows or columns to repeat: %d-%d", s.from, s.to))
+		}
+	}
+
+	return nil
+}
+
+//pageMargins returns margins of page or Excel's normal margins if there are no margins
+func (i *Info) pageMargins() ml.PageMargins {
+	if i.margins == nil {
+		return ml.PageMargins{Left: 0.7, Right: 0.7, Top: 0.75, Bottom: 0.75, Header: 0.3, Footer: 0.3}
+	}
+
+	return *i.margins
+}
+
+//Portrait sets portrait orientation of page
+func Portrait(i *Info) {
+	i.setup.Orientation = OrientationPortrait
+}
+
+//Landscape sets landscape orientation of page
+func Landscape(i *Info) {
+	i.setup.Orientation = OrientationLandscape
+}
+
+//Paper sets size of paper, e.g.: Paper(PaperA4)
+func Paper(size PaperSize) Option {
+	return func(i *Info) {
+		i.setup.PaperSize = int(size)
+	}
+}
+
+//Scale sets scale of printing in percents. Range is 10 to 400. Scale is ignored if sheet is fit to pages
+func Scale(percent int) Option {
+	return func(i *Info) {
+		i.setup.Scale = percent
+		i.fitToPage = false
+	}
+}
+
+//FitToPage fits sheet to number of pages in width and height, e.g.: FitToPage(1, 0) fits all columns to one page. Zero value means that number of pages is not limited
+func FitToPage(width, height int) Option {
+	return func(i *Info) {
+		i.fitToPage = true
+		i.setup.FitToWidth, i.setup.FitToHeight = nil, nil
+
+		//1 is a default value
+		if width != 1 {
+			i.setup.FitToWidth = &width
+		}
+
+		if height != 1 {
+			i.setup.FitToHeight = &height
+		}
+	}
+}
+
+//Margins sets margins of page in inches
+func Margins(left, right, top, bottom float64) Option {
+	return func(i *Info) {
+		m := i.pageMargins()
+		m.Left, m.Right, m.Top, m.Bottom = left, right, top, bottom
+		i.margins = &m
+	}
+}
+
+//HeaderFooterMargins sets margins of header and footer in inches
+func HeaderFooterMargins(header, footer float64) Option {
+	return func(i *Info) {
+		m := i.pageMargins()
+		m.Header, m.Footer = header, footer
+		i.margins = &m
+	}
+}
+
+//PrintArea sets printable area of sheet, e.g.: PrintArea("A1:F40"). Empty ref removes print area
+func PrintArea(ref types.Ref) Option {
+	return func(i *Info) {
+		i.printArea = ref
+	}
+}
+
+//RepeatRows sets 0-based indexes of rows to repeat at top of each printed page, e.g.: RepeatRows(0, 0) repeats a header row. Negative indexes remove repeating of rows
+func RepeatRows(from, to int) Option {
+	return func(i *Info) {
+		i.repeatRows = nil
+		if from >= 0 || to >= 0 {
+			i.repeatRows = &span{from: from, to: to}
+		}
+	}
+}
+
+//RepeatColumns sets 0-based indexes of columns to repeat at left of each printed page. Negative indexes remove repeating of columns
+func RepeatColumns(from, to int) Option {
+	return func(i *Info) {
+		i.repeatCols = nil
+		if from >= 0 || to >= 0 {
+			i.repeatCols = &span{from: from, to: to}
+		}
+	}
+}
+
+//GridLines prints grid lines
+func GridLines(i *Info) {
+	i.printOptions.GridLines = true
+}
+
+//Headings prints headings of rows and columns
+func Headings(i *Info) {
+	i.printOptions.Headings = true
+}
+
+//CenterHorizontally centers data on page horizontally
+func CenterHorizontally(i *Info) {
+	i.printOptions.HorizontalCentered = true
+}
+
+//CenterVertically centers data on page vertically
+func CenterVertically(i *Info) {
+	i.printOptions.VerticalCentered = true
+}
+
+//OverThenDown prints pages from left to right and then down, instead of default down and then from left to right
+func OverThenDown(i *Info) {
+	i.setup.PageOrder = OrderOverThenDown
+}
+
+//BlackAndWhite prints in black and white
+func BlackAndWhite(i *Info) {
+	i.setup.BlackAndWhite = true
+}
+
+//Draft prints in draft quality
+func Draft(i *Info) {
+	i.setup.Draft = true
+}
+
+//FirstPageNumber sets number of the first printed page
+func FirstPageNumber(number int) Option {
+	return func(i *Info) {
+		i.setup.FirstPageNumber = number
+		i.setup.UseFirstPageNumber = true
+	}
+}
+
+//Copies sets number of copies to print
+func Copies(copies int) Option {
+	return func(i *Info) {
+		i.setup.Copies = copies
+	}
+}
+
+//private method used by page setup manager to unpack Info
+func fromPageInfo(info *Info) (*ml.PageSetup, *ml.PageMargins, *ml.PrintOptions, bool) {
+	//copy info to prevent side effects of reusing Info for different sheets
+	setup, printOptions := *info.setup, *info.printOptions
+
+	var margins *ml.PageMargins
+	if info.margins != nil {
+		m := *info.margins
+		margins = &m
+	}
+
+	return &setup, margins, &printOptions, info.fitToPage
+}
+
+//private method used by page setup manager to pack Info
+func toPageInfo(setup *ml.PageSetup, margins *ml.PageMargins, printOptions *ml.PrintOptions, fitToPage bool) *Info {
+	info := New()
+	info.fitToPage = fitToPage
+
+	if setup != nil {
+		s := *setup
+		info.setup = &s
+	}
+
+	if margins != nil {
+		m := *margins
+		info.margins = &m
+	}
+
+	if printOptions != nil {
+		p := *printOptions
+		info.printOptions = &p
+	}
+
+	return info
+}
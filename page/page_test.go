@@ -0,0 +1,110 @@
+package page
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPage(t *testing.T) {
+	info := New()
+	require.Equal(t, OrientationDefault, info.Orientation())
+	require.Equal(t, PaperLetter, info.Paper())
+	require.Equal(t, 100, info.Scale())
+	require.Equal(t, false, info.GridLines())
+	require.Nil(t, info.Validate())
+
+	left, right, top, bottom := info.Margins()
+	require.Equal(t, []float64{0.7, 0.7, 0.75, 0.75}, []float64{left, right, top, bottom})
+	_, _, ok := info.FitToPage()
+	require.Equal(t, false, ok)
+	_, _, ok = info.RepeatRows()
+	require.Equal(t, false, ok)
+
+	setup, margins, printOptions, fitToPage := fromPageInfo(info)
+	require.Equal(t, &ml.PageSetup{}, setup)
+	require.Nil(t, margins)
+	require.Equal(t, &ml.PrintOptions{}, printOptions)
+	require.Equal(t, false, fitToPage)
+
+	info = New(
+		Landscape,
+		Paper(PaperA4),
+		FitToPage(1, 0),
+		Margins(0.5, 0.5, 1, 1),
+		HeaderFooterMargins(0.2, 0.25),
+		PrintArea("A1:F40"),
+		RepeatRows(0, 1),
+		RepeatColumns(0, 0),
+		GridLines,
+		Headings,
+		CenterHorizontally,
+		CenterVertically,
+		OverThenDown,
+		BlackAndWhite,
+		Draft,
+		FirstPageNumber(3),
+		Copies(2),
+	)
+
+	require.Nil(t, info.Validate())
+	require.Equal(t, OrientationLandscape, info.Orientation())
+	require.Equal(t, PaperA4, info.Paper())
+	require.Equal(t, true, info.GridLines())
+
+	width, height, ok := info.FitToPage()
+	require.Equal(t, []int{1, 0}, []int{width, height})
+	require.Equal(t, true, ok)
+
+	from, to, ok := info.RepeatRows()
+	require.Equal(t, []int{0, 1}, []int{from, to})
+	require.Equal(t, true, ok)
+
+	from, to, ok = info.RepeatColumns()
+	require.Equal(t, []int{0, 0}, []int{from, to})
+	require.Equal(t, true, ok)
+
+	header, footer := info.HeaderFooterMargins()
+	require.Equal(t, []float64{0.2, 0.25}, []float64{header, footer})
+
+	zero := 0
+	setup, margins, printOptions, fitToPage = fromPageInfo(info)
+	require.Equal(t, &ml.PageSetup{
+		PaperSize:          9,
+		FirstPageNumber:    3,
+		FitToHeight:        &zero,
+		PageOrder:          OrderOverThenDown,
+		Orientation:        OrientationLandscape,
+		BlackAndWhite:      true,
+		Draft:              true,
+		UseFirstPageNumber: true,
+		Copies:             2,
+	}, setup)
+	require.Equal(t, &ml.PageMargins{Left: 0.5, Right: 0.5, Top: 1, Bottom: 1, Header: 0.2, Footer: 0.25}, margins)
+	require.Equal(t, &ml.PrintOptions{HorizontalCentered: true, VerticalCentered: true, Headings: true, GridLines: true}, printOptions)
+	require.Equal(t, true, fitToPage)
+
+	//scale disables fitting to pages
+	info.Set(Scale(80), RepeatRows(-1, -1))
+	_, _, ok = info.FitToPage()
+	require.Equal(t, false, ok)
+	require.Equal(t, 80, info.Scale())
+	_, _, ok = info.RepeatRows()
+	require.Equal(t, false, ok)
+
+	//packed info must be independent from source
+	info = toPageInfo(setup, margins, printOptions, fitToPage)
+	info.Set(Portrait, Margins(0, 0, 0, 0))
+	require.Equal(t, OrientationLandscape, setup.Orientation)
+	require.Equal(t, 0.5, margins.Left)
+	width, height, ok = info.FitToPage()
+	require.Equal(t, []int{1, 0}, []int{width, height})
+	require.Equal(t, true, ok)
+
+	require.NotNil(t, New(Scale(5)).Validate())
+	require.NotNil(t, New(FitToPage(-1, 1)).Validate())
+	require.NotNil(t, New(Margins(-1, 0, 0, 0)).Validate())
+	require.NotNil(t, New(PrintArea("wrong")).Validate())
+	require.NotNil(t, New(RepeatRows(2, 1)).Validate())
+	require.NotNil(t, New(RepeatColumns(-1, 1)).Validate())
+}
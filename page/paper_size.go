@@ -0,0 +1,25 @@
+package page
+
+//PaperSize is a type of paper to print on
+type PaperSize int
+
+//List of the most used paper sizes. For other sizes: https://docs.microsoft.com/en-us/dotnet/api/documentformat.openxml.spreadsheet.pagesetup
+const (
+	PaperLetter          PaperSize = 1
+	PaperTabloid         PaperSize = 3
+	PaperLedger          PaperSize = 4
+	PaperLegal           PaperSize = 5
+	PaperStatement       PaperSize = 6
+	PaperExecutive       PaperSize = 7
+	PaperA3              PaperSize = 8
+	PaperA4              PaperSize = 9
+	PaperA5              PaperSize = 11
+	PaperB4              PaperSize = 12
+	PaperB5              PaperSize = 13
+	PaperFolio           PaperSize = 14
+	PaperEnvelope10      PaperSize = 20
+	PaperEnvelopeDL      PaperSize = 27
+	PaperEnvelopeC5      PaperSize = 28
+	PaperEnvelopeB5      PaperSize = 34
+	PaperEnvelopeMonarch PaperSize = 37
+)
@@ -0,0 +1,145 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"sort"
+)
+
+//PageBreakType is a type of manual page break
+type PageBreakType byte
+
+//List of all possible values for PageBreakType
+const (
+	PageBreakRow    PageBreakType = iota //page break before row
+	PageBreakColumn                      //page break before column
+)
+
+//PageBreak is a manual page break before row or column with 0-based index, i.e. row or column with index starts a new page
+type PageBreak struct {
+	Type  PageBreakType
+	Index int
+}
+
+//pageBreaks returns page breaks of type t and limit for index, breaks will be added if required
+func (s *sheetInfo) pageBreaks(t PageBreakType, create bool) (**ml.PageBreaks, int) {
+	breaks, limit := &s.ml.RowBreaks, internal.ExcelRowLimit
+	if t == PageBreakColumn {
+		breaks, limit = &s.ml.ColBreaks, internal.ExcelColumnLimit
+	}
+
+	if create && *breaks == nil {
+		*breaks = &ml.PageBreaks{}
+	}
+
+	return breaks, limit
+}
+
+//updatePageBreaks sorts breaks, updates counters and removes breaks if there are no any items
+func updatePageBreaks(breaks **ml.PageBreaks) {
+	if *breaks == nil {
+		return
+	}
+
+	items := (*breaks).Items
+	if len(items) == 0 {
+		*breaks = nil
+		return
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+
+	manual := 0
+	for _, b := range items {
+		if b.Manual {
+			manual++
+		}
+	}
+
+	(*breaks).Count, (*breaks).ManualBreakCount = len(items), manual
+}
+
+//InsertPageBreak inserts a manual page break of type t before row or column with 0-based index, e.g.: InsertPageBreak(xlsx.PageBreakRow, 20) starts a new page from row 21
+func (s *sheetInfo) InsertPageBreak(t PageBreakType, index int) error {
+	breaks, limit := s.pageBreaks(t, false)
+	if index < 1 || index >= limit {
+		return errors.New(fmt.Sprintf("page break can't be inserted before index %d, index must be in range [1, %d]", index, limit-1))
+	}
+
+	breaks, _ = s.pageBreaks(t, true)
+	for _, b := range (*breaks).Items {
+		if b.ID == index {
+			b.Manual = true
+			updatePageBreaks(breaks)
+			return nil
+		}
+	}
+
+	//N.B.: row breaks span all columns and column breaks span all rows
+	span := internal.ExcelColumnLimit - 1
+	if t == PageBreakColumn {
+		span = internal.ExcelRowLimit - 1
+	}
+
+	(*breaks).Items = append((*breaks).Items, &ml.Break{ID: index, Max: span, Manual: true})
+	updatePageBreaks(breaks)
+	return nil
+}
+
+//RemovePageBreak removes a page break of type t before row or column with 0-based index
+func (s *sheetInfo) RemovePageBreak(t PageBreakType, index int) {
+	breaks, _ := s.pageBreaks(t, false)
+	if *breaks == nil {
+		return
+	}
+
+	items := make([]*ml.Break, 0, len((*breaks).Items))
+	for _, b := range (*breaks).Items {
+		if b.ID != index {
+			items = append(items, b)
+		}
+	}
+
+	(*breaks).Items = items
+	updatePageBreaks(breaks)
+}
+
+//PageBreaks returns all page breaks of sheet, row breaks first
+func (s *sheetInfo) PageBreaks() []PageBreak {
+	var list []PageBreak
+	for _, t := range []PageBreakType{PageBreakRow, PageBreakColumn} {
+		if breaks, _ := s.pageBreaks(t, false); *breaks != nil {
+			for _, b := range (*breaks).Items {
+				list = append(list, PageBreak{Type: t, Index: b.ID})
+			}
+		}
+	}
+
+	return list
+}
+
+//shiftPageBreaks updates page breaks after inserting (n > 0) or deleting (n < 0) of n rows or cols at 0-based index at, breaks before deleted rows or cols are removed
+func (s *sheetInfo) shiftPageBreaks(at, n int, cols bool) {
+	t := PageBreakRow
+	if cols {
+		t = PageBreakColumn
+	}
+
+	breaks, limit := s.pageBreaks(t, false)
+	if *breaks == nil {
+		return
+	}
+
+	items := make([]*ml.Break, 0, len((*breaks).Items))
+	for _, b := range (*breaks).Items {
+		if index, _, ok := internal.ShiftIndexes(b.ID, b.ID, at, n); ok && index > 0 && index < limit {
+			b.ID = index
+			items = append(items, b)
+		}
+	}
+
+	(*breaks).Items = items
+	updatePageBreaks(breaks)
+}
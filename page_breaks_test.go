@@ -0,0 +1,62 @@
+package xlsx
+
+import (
+	"encoding/xml"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPageBreaks(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Report")
+	require.Nil(t, sheet.PageBreaks())
+	sheet.Cell(0, 49).SetInt(1)
+
+	require.NotNil(t, sheet.InsertPageBreak(PageBreakRow, 0))
+	require.NotNil(t, sheet.InsertPageBreak(PageBreakColumn, 16384))
+	require.Nil(t, sheet.info().ml.RowBreaks)
+	require.Nil(t, sheet.info().ml.ColBreaks)
+
+	require.Nil(t, sheet.InsertPageBreak(PageBreakRow, 40))
+	require.Nil(t, sheet.InsertPageBreak(PageBreakRow, 20))
+	require.Nil(t, sheet.InsertPageBreak(PageBreakRow, 20))
+	require.Nil(t, sheet.InsertPageBreak(PageBreakColumn, 5))
+
+	encoded, err := xml.Marshal(sheet.info().ml.RowBreaks)
+	require.Nil(t, err)
+	require.Equal(t, `<PageBreaks count="2" manualBreakCount="2"><brk id="20" max="16383" man="true"></brk><brk id="40" max="16383" man="true"></brk></PageBreaks>`, string(encoded))
+
+	require.Equal(t, []PageBreak{
+		{Type: PageBreakRow, Index: 20},
+		{Type: PageBreakRow, Index: 40},
+		{Type: PageBreakColumn, Index: 5},
+	}, sheet.PageBreaks())
+
+	//breaks are moved with rows
+	sheet.InsertRows(10, 2)
+	sheet.DeleteRows(40, 5)
+	require.Equal(t, []PageBreak{
+		{Type: PageBreakRow, Index: 22},
+		{Type: PageBreakColumn, Index: 5},
+	}, sheet.PageBreaks())
+
+	//save and reopen
+	err = xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	require.Equal(t, []PageBreak{
+		{Type: PageBreakRow, Index: 22},
+		{Type: PageBreakColumn, Index: 5},
+	}, sheet.PageBreaks())
+
+	sheet.RemovePageBreak(PageBreakColumn, 5)
+	sheet.RemovePageBreak(PageBreakRow, 100)
+	require.Nil(t, sheet.info().ml.ColBreaks)
+	require.Equal(t, []PageBreak{{Type: PageBreakRow, Index: 22}}, sheet.PageBreaks())
+}
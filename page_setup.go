@@ -0,0 +1,154 @@
+package xlsx
+
+import (
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/page"
+	"github.com/plandem/xlsx/types"
+	"strconv"
+	"strings"
+	_ "unsafe"
+)
+
+//go:linkname fromPageInfo github.com/plandem/xlsx/page.fromPageInfo
+func fromPageInfo(info *page.Info) (*ml.PageSetup, *ml.PageMargins, *ml.PrintOptions, bool)
+
+//go:linkname toPageInfo github.com/plandem/xlsx/page.toPageInfo
+func toPageInfo(setup *ml.PageSetup, margins *ml.PageMargins, printOptions *ml.PrintOptions, fitToPage bool) *page.Info
+
+//N.B.: Excel uses built-in defined names to store print area and print titles
+const (
+	definedNamePrintArea   = "_xlnm.Print_Area"
+	definedNamePrintTitles = "_xlnm.Print_Titles"
+)
+
+type pageSetup struct {
+	sheet *sheetInfo
+}
+
+//newPageSetup creates an object that implements page setup functionality
+func newPageSetup(sheet *sheetInfo) *pageSetup {
+	return &pageSetup{sheet: sheet}
+}
+
+//prefix returns name of sheet to use in formulas of defined names
+func (p *pageSetup) prefix() string {
+	return fmt.Sprintf("'%s'!", strings.Replace(p.sheet.Name(), `'`, `''`, -1))
+}
+
+//Set sets page setup and print settings of sheet
+func (p *pageSetup) Set(info *page.Info) error {
+	if err := info.Validate(); err != nil {
+		return err
+	}
+
+	setup, margins, printOptions, fitToPage := fromPageInfo(info)
+	if *setup == (ml.PageSetup{}) {
+		setup = nil
+	}
+
+	if *printOptions == (ml.PrintOptions{}) {
+		printOptions = nil
+	}
+
+	if fitToPage {
+		if p.sheet.ml.SheetPr == nil {
+			p.sheet.ml.SheetPr = &ml.SheetPr{}
+		}
+
+		if p.sheet.ml.SheetPr.PageSetUpPr == nil {
+			p.sheet.ml.SheetPr.PageSetUpPr = &ml.PageSetUpPr{}
+		}
+
+		p.sheet.ml.SheetPr.PageSetUpPr.FitToPage = true
+	} else if p.sheet.ml.SheetPr != nil && p.sheet.ml.SheetPr.PageSetUpPr != nil {
+		p.sheet.ml.SheetPr.PageSetUpPr.FitToPage = false
+	}
+
+	p.sheet.ml.PageSetup, p.sheet.ml.PageMargins, p.sheet.ml.PrintOptions = setup, margins, printOptions
+
+	definedNames := p.sheet.workbook.definedNames
+	if area := info.PrintArea(); len(area) > 0 {
+		bounds := area.ToBounds()
+		if err := definedNames.Add(definedNamePrintArea, p.prefix()+string(bounds.ToRef().ToAbsolute()), p.sheet.index); err != nil {
+			return err
+		}
+	} else {
+		definedNames.Remove(definedNamePrintArea, p.sheet.index)
+	}
+
+	//N.B.: Excel stores columns before rows
+	var titles []string
+	if from, to, ok := info.RepeatColumns(); ok {
+		titles = append(titles, fmt.Sprintf("%s$%s:$%s", p.prefix(), columnName(from), columnName(to)))
+	}
+
+	if from, to, ok := info.RepeatRows(); ok {
+		titles = append(titles, fmt.Sprintf("%s$%d:$%d", p.prefix(), from+1, to+1))
+	}
+
+	if len(titles) > 0 {
+		return definedNames.Add(definedNamePrintTitles, strings.Join(titles, ","), p.sheet.index)
+	}
+
+	definedNames.Remove(definedNamePrintTitles, p.sheet.index)
+	return nil
+}
+
+//Get returns page setup and print settings of sheet
+func (p *pageSetup) Get() *page.Info {
+	fitToPage := p.sheet.ml.SheetPr != nil && p.sheet.ml.SheetPr.PageSetUpPr != nil && p.sheet.ml.SheetPr.PageSetUpPr.FitToPage
+	info := toPageInfo(p.sheet.ml.PageSetup, p.sheet.ml.PageMargins, p.sheet.ml.PrintOptions, fitToPage)
+
+	definedNames := p.sheet.workbook.definedNames
+	if formula := definedNames.Get(definedNamePrintArea, p.sheet.index); len(formula) > 0 {
+		//only the first area is supported
+		area := splitRefs(formula)[0]
+		info.Set(page.PrintArea(types.Ref(strings.Replace(area[strings.LastIndex(area, "!")+1:], "$", "", -1))))
+	}
+
+	if formula := definedNames.Get(definedNamePrintTitles, p.sheet.index); len(formula) > 0 {
+		for _, title := range splitRefs(formula) {
+			parts := strings.Split(strings.Replace(title[strings.LastIndex(title, "!")+1:], "$", "", -1), ":")
+			if len(parts) != 2 {
+				continue
+			}
+
+			if from, err := strconv.Atoi(parts[0]); err == nil {
+				if to, err := strconv.Atoi(parts[1]); err == nil {
+					info.Set(page.RepeatRows(from-1, to-1))
+				}
+			} else {
+				from, _ := types.CellRef(parts[0] + "1").ToIndexes()
+				to, _ := types.CellRef(parts[1] + "1").ToIndexes()
+				info.Set(page.RepeatColumns(from, to))
+			}
+		}
+	}
+
+	return info
+}
+
+//splitRefs splits list of references into references, respecting quoted names of sheets, e.g.: 'Q1, 2020'!$A:$A,'Q1, 2020'!$1:$1
+func splitRefs(formula string) []string {
+	var refs []string
+	var quoted bool
+
+	last := 0
+	for i := 0; i < len(formula); i++ {
+		switch c := formula[i]; {
+		case c == '\'':
+			quoted = !quoted
+		case c == ',' && !quoted:
+			refs = append(refs, formula[last:i])
+			last = i + 1
+		}
+	}
+
+	return append(refs, formula[last:])
+}
+
+//columnName returns name of column with 0-based index, e.g.: A for 0
+func columnName(index int) string {
+	return strings.TrimSuffix(string(types.CellRefFromIndexes(index, 0)), "1")
+}
@@ -0,0 +1,102 @@
+package xlsx
+
+import (
+	"encoding/xml"
+	"github.com/plandem/xlsx/page"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPageSetup(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Invoice")
+
+	//invalid settings must not be applied
+	require.NotNil(t, sheet.SetPageSetup(page.Scale(1000)))
+	require.Nil(t, sheet.info().ml.PageSetup)
+
+	require.Nil(t, sheet.SetPageSetup(
+		page.Landscape,
+		page.Paper(page.PaperA4),
+		page.FitToPage(1, 0),
+		page.Margins(0.5, 0.5, 0.75, 0.75),
+		page.PrintArea("A1:F40"),
+		page.RepeatRows(0, 1),
+		page.GridLines,
+		page.CenterHorizontally,
+	))
+
+	encode := func(v interface{}) string {
+		encoded, err := xml.Marshal(v)
+		require.Nil(t, err)
+		return string(encoded)
+	}
+
+	require.Equal(t, `<PageSetup paperSize="9" fitToHeight="0" orientation="landscape"></PageSetup>`, encode(sheet.info().ml.PageSetup))
+	require.Equal(t, `<PageMargins left="0.5" right="0.5" top="0.75" bottom="0.75" header="0.3" footer="0.3"></PageMargins>`, encode(sheet.info().ml.PageMargins))
+	require.Equal(t, `<PrintOptions horizontalCentered="true" gridLines="true"></PrintOptions>`, encode(sheet.info().ml.PrintOptions))
+	require.Equal(t, `<SheetPr><pageSetUpPr fitToPage="true"></pageSetUpPr></SheetPr>`, encode(sheet.info().ml.SheetPr))
+	require.Equal(t, "'Invoice'!$A$1:$F$40", sheet.DefinedName("_xlnm.Print_Area"))
+	require.Equal(t, "'Invoice'!$1:$2", sheet.DefinedName("_xlnm.Print_Titles"))
+
+	//settings that are not affected by options must be kept
+	require.Nil(t, sheet.SetPageSetup(page.RepeatColumns(0, 1)))
+	require.Equal(t, "'Invoice'!$A:$B,'Invoice'!$1:$2", sheet.DefinedName("_xlnm.Print_Titles"))
+	require.Equal(t, page.OrientationLandscape, sheet.PageSetup().Orientation())
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	info := sheet.PageSetup()
+	require.Equal(t, page.OrientationLandscape, info.Orientation())
+	require.Equal(t, page.PaperA4, info.Paper())
+	require.Equal(t, types.Ref("A1:F40"), info.PrintArea())
+	require.Equal(t, true, info.GridLines())
+
+	width, height, ok := info.FitToPage()
+	require.Equal(t, []int{1, 0}, []int{width, height})
+	require.Equal(t, true, ok)
+
+	from, to, ok := info.RepeatRows()
+	require.Equal(t, []int{0, 1}, []int{from, to})
+	require.Equal(t, true, ok)
+
+	from, to, ok = info.RepeatColumns()
+	require.Equal(t, []int{0, 1}, []int{from, to})
+	require.Equal(t, true, ok)
+
+	left, _, top, _ := info.Margins()
+	require.Equal(t, []float64{0.5, 0.75}, []float64{left, top})
+
+	//remove print area, titles and fitting to pages
+	require.Nil(t, sheet.SetPageSetup(page.PrintArea(""), page.RepeatRows(-1, -1), page.RepeatColumns(-1, -1), page.Scale(90)))
+	require.Equal(t, "", sheet.DefinedName("_xlnm.Print_Area"))
+	require.Equal(t, "", sheet.DefinedName("_xlnm.Print_Titles"))
+	require.Equal(t, false, sheet.info().ml.SheetPr.PageSetUpPr.FitToPage)
+	require.Equal(t, 90, sheet.PageSetup().Scale())
+
+	//names of sheets with commas
+	sheet.SetName("Q1, 2020")
+	require.Nil(t, sheet.SetPageSetup(page.PrintArea("B2:C3"), page.RepeatRows(0, 0), page.RepeatColumns(0, 0)))
+	require.Equal(t, "'Q1, 2020'!$A:$A,'Q1, 2020'!$1:$1", sheet.DefinedName("_xlnm.Print_Titles"))
+
+	info = sheet.PageSetup()
+	require.Equal(t, types.Ref("B2:C3"), info.PrintArea())
+
+	from, to, ok = info.RepeatRows()
+	require.Equal(t, []int{0, 0}, []int{from, to})
+	require.Equal(t, true, ok)
+
+	from, to, ok = info.RepeatColumns()
+	require.Equal(t, []int{0, 0}, []int{from, to})
+	require.Equal(t, true, ok)
+	require.Equal(t, []string{"'It''s, Q1'!$A:$A", "B"}, splitRefs("'It''s, Q1'!$A:$A,B"))
+}
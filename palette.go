@@ -0,0 +1,157 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal/color"
+	"github.com/plandem/xlsx/internal/ml"
+)
+
+//indexedColors returns #RGB colors of indexed palette, if palette was not customized, then built-in palette is returned
+func (ss *StyleSheet) indexedColors() []string {
+	ss.doc.lock()
+	defer ss.doc.unlock()
+
+	ss.file.LoadIfRequired(ss.buildIndexes)
+
+	if colors := ss.customIndexedColors(); colors != nil {
+		return colors
+	}
+
+	return color.Indexed()
+}
+
+//customIndexedColors returns #RGB colors of customized indexed palette or nil if palette was not customized. Missing colors are taken from built-in palette
+//N.B.: caller must hold lock of document
+func (ss *StyleSheet) customIndexedColors() []string {
+	if ss.ml.Colors == nil || ss.ml.Colors.Indexed == nil || len(ss.ml.Colors.Indexed.Items) == 0 {
+		return nil
+	}
+
+	colors := color.Indexed()
+	for i, c := range ss.ml.Colors.Indexed.Items {
+		if i >= len(colors) {
+			break
+		}
+
+		if rgb := color.ToRGB(&ml.Color{RGB: c.RGB}); len(rgb) > 0 {
+			colors[i] = rgb
+		}
+	}
+
+	return colors
+}
+
+//setIndexedColors overrides #RGB colors of indexed palette in order of indexes, empty color keeps existing color as is. Without colors built-in palette is restored
+func (ss *StyleSheet) setIndexedColors(colors ...string) error {
+	ss.doc.lock()
+	defer ss.doc.unlock()
+
+	ss.file.LoadIfRequired(ss.buildIndexes)
+
+	builtIn := color.Indexed()
+	if len(colors) > len(builtIn) {
+		return errors.New(fmt.Sprintf("indexed palette has only %d colors, but %d colors were used", len(builtIn), len(colors)))
+	}
+
+	for _, rgb := range colors {
+		if len(rgb) > 0 && !regExpThemeColor.MatchString(rgb) {
+			return errors.New(fmt.Sprintf("color '%s' is not in #RGB format", rgb))
+		}
+	}
+
+	if len(colors) == 0 {
+		if ss.ml.Colors != nil {
+			ss.ml.Colors.Indexed = nil
+			if ss.ml.Colors.MRU == nil {
+				ss.ml.Colors = nil
+			}
+		}
+
+		ss.file.MarkAsUpdated()
+		return nil
+	}
+
+	palette := ss.customIndexedColors()
+	if palette == nil {
+		palette = builtIn
+	}
+
+	for i, rgb := range colors {
+		if len(rgb) > 0 {
+			if rgb[0] != '#' {
+				rgb = "#" + rgb
+			}
+
+			palette[i] = rgb
+		}
+	}
+
+	list := &ml.IndexedColorList{}
+	for _, rgb := range palette {
+		list.Items = append(list.Items, &ml.RgbColor{RGB: color.Normalize(rgb)})
+	}
+
+	if ss.ml.Colors == nil {
+		ss.ml.Colors = &ml.Colors{}
+	}
+
+	ss.ml.Colors.Indexed = list
+	ss.file.MarkAsUpdated()
+	return nil
+}
+
+//toPaletteColor returns RGB color of palette for indexed color or color as is for other types of color
+func toPaletteColor(c *ml.Color, palette []string) *ml.Color {
+	if c == nil || c.Indexed == nil || *c.Indexed < 0 || *c.Indexed >= len(palette) {
+		return c
+	}
+
+	return &ml.Color{RGB: color.Normalize(palette[*c.Indexed]), Tint: c.Tint}
+}
+
+//applyPalette returns copies of font, fill and border where indexed colors are replaced with RGB colors of palette
+func applyPalette(font *ml.Font, fill *ml.Fill, border *ml.Border, palette []string) (*ml.Font, *ml.Fill, *ml.Border) {
+	if font != nil {
+		f := *font
+		f.Color = toPaletteColor(f.Color, palette)
+		font = &f
+	}
+
+	if fill != nil {
+		f := ml.Fill{}
+		if fill.Pattern != nil {
+			pattern := *fill.Pattern
+			pattern.Color = toPaletteColor(pattern.Color, palette)
+			pattern.Background = toPaletteColor(pattern.Background, palette)
+			f.Pattern = &pattern
+		}
+
+		if fill.Gradient != nil {
+			gradient := *fill.Gradient
+			gradient.Stop = make([]*ml.GradientStop, 0, len(fill.Gradient.Stop))
+			for _, stop := range fill.Gradient.Stop {
+				gradient.Stop = append(gradient.Stop, &ml.GradientStop{Position: stop.Position, Color: toPaletteColor(stop.Color, palette)})
+			}
+
+			f.Gradient = &gradient
+		}
+
+		fill = &f
+	}
+
+	if border != nil {
+		b := *border
+		for _, segment := range []**ml.BorderSegment{&b.Left, &b.Right, &b.Top, &b.Bottom, &b.Diagonal, &b.Vertical, &b.Horizontal} {
+			if *segment != nil {
+				s := **segment
+				s.Color = toPaletteColor(s.Color, palette)
+				*segment = &s
+			}
+		}
+
+		border = &b
+	}
+
+	return font, fill, border
+}
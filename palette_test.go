@@ -0,0 +1,64 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/format"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIndexedColors(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Sheet1")
+
+	//built-in palette
+	colors := xl.IndexedColors()
+	require.Equal(t, 64, len(colors))
+	require.Equal(t, []string{"#000000", "#FFFFFF", "#FF0000"}, colors[:3])
+	require.Nil(t, xl.styleSheet.ml.Colors)
+
+	//style with built-in indexed color
+	builtIn := sheet.CellByRef("A1").AddStyles(format.NewStyles(format.Fill.Solid("#FF0000")))
+	require.NotNil(t, xl.styleSheet.ml.Fills.Items[xl.styleSheet.ml.CellXfs.Items[builtIn].FillId].Pattern.Color.Indexed)
+
+	//custom palette
+	require.NotNil(t, xl.SetIndexedColors("red"))
+	require.NotNil(t, xl.SetIndexedColors(make([]string, 65)...))
+	require.Nil(t, xl.SetIndexedColors("", "", "#FF6600"))
+	require.Equal(t, "#FF6600", xl.IndexedColors()[2])
+	require.Equal(t, "#FFFFFF", xl.IndexedColors()[1])
+	require.Equal(t, 64, len(xl.styleSheet.ml.Colors.Indexed.Items))
+	require.Equal(t, "FFFF6600", xl.styleSheet.ml.Colors.Indexed.Items[2].RGB)
+
+	//existing indexed colors are resolved with custom palette
+	require.Equal(t, "#FF6600", sheet.CellByRef("A1").Styles().Fill)
+
+	//new styles keep colors as is
+	styleID := sheet.CellByRef("A2").AddStyles(format.NewStyles(
+		format.Font.Color("#FF0000"),
+		format.Fill.LinearGradient(90, "#FFFFFF", "#FF0000"),
+		format.Border.Left.Color("#FF0000"),
+	))
+
+	fill := xl.styleSheet.ml.Fills.Items[xl.styleSheet.ml.CellXfs.Items[styleID].FillId]
+	require.Nil(t, fill.Gradient.Stop[1].Color.Indexed)
+	require.Equal(t, "FFFF0000", fill.Gradient.Stop[1].Color.RGB)
+	require.Equal(t, "#FF0000", sheet.CellByRef("A2").Styles().Color)
+	require.Equal(t, "#FF0000", sheet.CellByRef("A2").Styles().Left.Color)
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	require.Equal(t, "#FF6600", xl.IndexedColors()[2])
+	require.Equal(t, "#FF6600", xl.Sheet(0).CellByRef("A1").Styles().Fill)
+
+	//built-in palette is restored
+	require.Nil(t, xl.SetIndexedColors())
+	require.Nil(t, xl.styleSheet.ml.Colors)
+	require.Equal(t, "#FF0000", xl.IndexedColors()[2])
+}
@@ -0,0 +1,124 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/plandem/xlsx/types"
+)
+
+//list of panes of sheet view
+const (
+	paneTopRight    = "topRight"
+	paneBottomLeft  = "bottomLeft"
+	paneBottomRight = "bottomRight"
+	paneStateFrozen = "frozen"
+)
+
+//sheetView returns the first view of sheet, view will be added if required
+func (s *sheetInfo) sheetView() *ml.SheetView {
+	if len(s.ml.SheetViews.Items) == 0 {
+		s.ml.SheetViews.Items = append(s.ml.SheetViews.Items, &ml.SheetView{})
+	}
+
+	return s.ml.SheetViews.Items[0]
+}
+
+//frozenPanes returns number of frozen columns and rows
+func (s *sheetInfo) frozenPanes() (cols int, rows int) {
+	if len(s.ml.SheetViews.Items) > 0 {
+		if pane := s.ml.SheetViews.Items[0].Pane; pane != nil && pane.State == paneStateFrozen {
+			cols, rows = int(pane.XSplit), int(pane.YSplit)
+		}
+	}
+
+	return
+}
+
+//freezePanes freezes cols and rows, zero values unfreeze panes
+func (s *sheetInfo) freezePanes(cols, rows int) {
+	if cols < 0 {
+		cols = 0
+	}
+
+	if rows < 0 {
+		rows = 0
+	}
+
+	view := s.sheetView()
+	view.Pane, view.Selection = nil, nil
+
+	if cols == 0 && rows == 0 {
+		return
+	}
+
+	topLeft := types.CellRefFromIndexes(cols, rows)
+	view.Pane = &ml.Pane{
+		XSplit:      float64(cols),
+		YSplit:      float64(rows),
+		TopLeftCell: topLeft,
+		State:       paneStateFrozen,
+	}
+
+	selection := func(pane string, cIdx, rIdx int) *ml.Selection {
+		cell := types.CellRefFromIndexes(cIdx, rIdx)
+		return &ml.Selection{Pane: pane, ActiveCell: cell, Bounds: primitives.BoundsListFromRefs(primitives.Ref(cell))}
+	}
+
+	switch {
+	case cols > 0 && rows > 0:
+		view.Pane.ActivePane = paneBottomRight
+		view.Selection = []*ml.Selection{
+			selection(paneTopRight, cols, 0),
+			selection(paneBottomLeft, 0, rows),
+			selection(paneBottomRight, cols, rows),
+		}
+	case rows > 0:
+		view.Pane.ActivePane = paneBottomLeft
+		view.Selection = []*ml.Selection{selection(paneBottomLeft, 0, rows)}
+	default:
+		view.Pane.ActivePane = paneTopRight
+		view.Selection = []*ml.Selection{selection(paneTopRight, cols, 0)}
+	}
+}
+
+//FreezeRows freezes top n rows, frozen columns are kept as is. Zero value unfreezes rows
+func (s *sheetInfo) FreezeRows(n int) {
+	cols, _ := s.frozenPanes()
+	s.freezePanes(cols, n)
+}
+
+//FreezeColumns freezes left n columns, frozen rows are kept as is. Zero value unfreezes columns
+func (s *sheetInfo) FreezeColumns(n int) {
+	_, rows := s.frozenPanes()
+	s.freezePanes(n, rows)
+}
+
+//SplitPanes splits sheet view into panes at horizontal position x and vertical position y in 1/20th of a point. Zero values remove split
+func (s *sheetInfo) SplitPanes(x, y float64) {
+	if x < 0 {
+		x = 0
+	}
+
+	if y < 0 {
+		y = 0
+	}
+
+	view := s.sheetView()
+	view.Pane, view.Selection = nil, nil
+
+	if x == 0 && y == 0 {
+		return
+	}
+
+	view.Pane = &ml.Pane{XSplit: x, YSplit: y}
+	switch {
+	case x > 0 && y > 0:
+		view.Pane.ActivePane = paneBottomRight
+	case y > 0:
+		view.Pane.ActivePane = paneBottomLeft
+	default:
+		view.Pane.ActivePane = paneTopRight
+	}
+
+	view.Selection = []*ml.Selection{{Pane: view.Pane.ActivePane}}
+}
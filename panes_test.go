@@ -0,0 +1,56 @@
+package xlsx
+
+import (
+	"encoding/xml"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPanes(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Report")
+
+	encode := func() string {
+		encoded, err := xml.Marshal(sheet.info().ml.SheetViews.Items[0])
+		require.Nil(t, err)
+		return string(encoded)
+	}
+
+	sheet.FreezeRows(1)
+	require.Equal(t, `<SheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"></pane><selection pane="bottomLeft" activeCell="A2" sqref="A2"></selection></SheetView>`, encode())
+
+	sheet.FreezeColumns(2)
+	require.Equal(t, `<SheetView workbookViewId="0"><pane xSplit="2" ySplit="1" topLeftCell="C2" activePane="bottomRight" state="frozen"></pane><selection pane="topRight" activeCell="C1" sqref="C1"></selection><selection pane="bottomLeft" activeCell="A2" sqref="A2"></selection><selection pane="bottomRight" activeCell="C2" sqref="C2"></selection></SheetView>`, encode())
+
+	sheet.FreezeRows(0)
+	require.Equal(t, `<SheetView workbookViewId="0"><pane xSplit="2" topLeftCell="C1" activePane="topRight" state="frozen"></pane><selection pane="topRight" activeCell="C1" sqref="C1"></selection></SheetView>`, encode())
+
+	sheet.FreezeColumns(0)
+	require.Equal(t, `<SheetView workbookViewId="0"></SheetView>`, encode())
+
+	sheet.SplitPanes(2400, 1800)
+	require.Equal(t, `<SheetView workbookViewId="0"><pane xSplit="2400" ySplit="1800" activePane="bottomRight"></pane><selection pane="bottomRight"></selection></SheetView>`, encode())
+
+	//split is not a frozen pane
+	sheet.FreezeRows(3)
+	require.Equal(t, &ml.Pane{YSplit: 3, TopLeftCell: "A4", ActivePane: "bottomLeft", State: "frozen"}, sheet.info().ml.SheetViews.Items[0].Pane)
+
+	sheet.SplitPanes(0, -1)
+	require.Nil(t, sheet.info().ml.SheetViews.Items[0].Pane)
+
+	sheet.FreezeRows(1)
+	sheet.FreezeColumns(1)
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	require.Equal(t, &ml.Pane{XSplit: 1, YSplit: 1, TopLeftCell: "B2", ActivePane: "bottomRight", State: "frozen"}, xl.Sheet(0).info().ml.SheetViews.Items[0].Pane)
+	require.Equal(t, 3, len(xl.Sheet(0).info().ml.SheetViews.Items[0].Selection))
+}
@@ -0,0 +1,75 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"github.com/plandem/ooxml"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"regexp"
+)
+
+var regExpPersons = regexp.MustCompile(`^xl/persons/person\d*\.xml$`)
+
+type persons struct {
+	doc      *Spreadsheet
+	ml       ml.PersonList
+	file     *ooxml.PackageFile
+	isLoaded bool
+}
+
+//newPersons creates an object that implements list of persons that are authors of threaded comments
+func newPersons(doc *Spreadsheet) *persons {
+	return &persons{doc: doc}
+}
+
+//loadIfRequired lookups for existing list of persons of workbook and loads it
+func (p *persons) loadIfRequired() {
+	if p.isLoaded {
+		return
+	}
+
+	p.isLoaded = true
+	for _, f := range p.doc.pkg.Files() {
+		if zf, ok := f.(*zip.File); ok && regExpPersons.MatchString(zf.Name) && len(p.doc.relationships.GetIdByTarget(zf.Name)) > 0 {
+			p.file = ooxml.NewPackageFile(p.doc.pkg, zf, &p.ml, nil)
+			p.file.LoadIfRequired(nil)
+			break
+		}
+	}
+}
+
+//ID returns id of person with name and adds a new person if required
+func (p *persons) ID(name string) string {
+	p.loadIfRequired()
+
+	for _, person := range p.ml.Items {
+		if person.DisplayName == name {
+			return person.ID
+		}
+	}
+
+	if p.file == nil {
+		fileName := "xl/persons/person.xml"
+		p.file = ooxml.NewPackageFile(p.doc.pkg, fileName, &p.ml, nil)
+		p.doc.pkg.ContentTypes().RegisterContent(fileName, internal.ContentTypePerson)
+		p.doc.relationships.AddFile(internal.RelationTypePerson, fileName)
+	}
+
+	id := newGUID()
+	p.ml.Items = append(p.ml.Items, &ml.Person{DisplayName: name, ID: id, UserID: name, ProviderID: "None"})
+	p.file.MarkAsUpdated()
+	return id
+}
+
+//Name returns name of person with id or empty string if there is no such person
+func (p *persons) Name(id string) string {
+	p.loadIfRequired()
+
+	for _, person := range p.ml.Items {
+		if person.ID == id {
+			return person.DisplayName
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,95 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/plandem/xlsx/types"
+	"strconv"
+)
+
+//PhoneticRun is a phonetic text, e.g. furigana, for characters of base text from Start till End, where Start and End are 0-based indexes of characters and End is not included
+type PhoneticRun struct {
+	Text  string
+	Start int
+	End   int
+}
+
+//stringItem returns string item of shared or inline string and nil for other types of cell
+func (c *Cell) stringItem() *ml.StringItem {
+	switch c.ml.Type {
+	case types.CellTypeInlineString:
+		return c.ml.InlineStr
+	case types.CellTypeSharedString:
+		var sid int
+
+		if len(c.ml.Value) > 0 {
+			sid, _ = strconv.Atoi(c.ml.Value)
+		}
+
+		return c.sheet.workbook.doc.sharedStrings.get(sid)
+	}
+
+	return nil
+}
+
+//Phonetic returns phonetic runs of shared or inline string and nil for other types of cell or strings without phonetic runs
+func (c *Cell) Phonetic() []PhoneticRun {
+	si := c.stringItem()
+	if si == nil || si.RPh == nil {
+		return nil
+	}
+
+	runs := make([]PhoneticRun, 0, len(*si.RPh))
+	for _, r := range *si.RPh {
+		runs = append(runs, PhoneticRun{Text: string(r.Text), Start: r.Start, End: r.End})
+	}
+
+	return runs
+}
+
+//SetPhonetic sets phonetic runs for string of cell and shows it, e.g. SetPhonetic(xlsx.PhoneticRun{Text: "とうきょう", Start: 0, End: 2}) for 東京. Phonetic runs are removed if there are no runs
+func (c *Cell) SetPhonetic(runs ...PhoneticRun) error {
+	si := c.stringItem()
+	if si == nil {
+		return errors.New(fmt.Sprintf("phonetic runs can be set only for string, but cell %s has type %s", c.ml.Ref, c.ml.Type))
+	}
+
+	//we can update sharedStrings only when sheet is in write mode, to prevent pollution of sharedStrings with fake values
+	if c.ml.Type == types.CellTypeSharedString && (c.sheet.mode()&sheetModeWrite) == 0 {
+		panic(errorNotSupportedWrite)
+	}
+
+	text := *si
+	text.RPh = nil
+	text.PhoneticPr = nil
+
+	if len(runs) > 0 {
+		length := len([]rune(c.Value()))
+		rph := make([]*ml.PhoneticRun, 0, len(runs))
+		for _, r := range runs {
+			if r.Start < 0 || r.End <= r.Start || r.End > length {
+				return errors.New(fmt.Sprintf("phonetic run %q is out of characters of cell %s", r.Text, c.ml.Ref))
+			}
+
+			rph = append(rph, &ml.PhoneticRun{Text: primitives.Text(r.Text), Start: r.Start, End: r.End})
+		}
+
+		text.RPh = &rph
+		text.PhoneticPr = si.PhoneticPr
+		if text.PhoneticPr == nil {
+			text.PhoneticPr = &ml.PhoneticProperties{}
+		}
+	}
+
+	c.ml.Ph = len(runs) > 0
+	if c.ml.Type == types.CellTypeInlineString {
+		c.ml.InlineStr = &text
+	} else {
+		c.ml.Value = strconv.Itoa(c.sheet.workbook.doc.sharedStrings.addText(&text))
+	}
+
+	c.sheet.changes.add(c)
+	return nil
+}
@@ -0,0 +1,50 @@
+package xlsx
+
+import (
+	"bytes"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCell_Phonetic(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Sheet1")
+	sheet.CellByRef("A1").SetValue("東京都")
+	sheet.CellByRef("A2").SetValue("東京都")
+	sheet.CellByRef("A3").SetInlineString("大阪")
+	sheet.CellByRef("A4").SetInt(1)
+
+	require.Nil(t, sheet.CellByRef("A1").Phonetic())
+	require.Nil(t, sheet.CellByRef("A1").SetPhonetic(PhoneticRun{"とうきょう", 0, 2}, PhoneticRun{"と", 2, 3}))
+	require.Nil(t, sheet.CellByRef("A3").SetPhonetic(PhoneticRun{"おおさか", 0, 2}))
+	require.NotNil(t, sheet.CellByRef("A2").SetPhonetic(PhoneticRun{"とうきょう", 0, 4}))
+	require.NotNil(t, sheet.CellByRef("A2").SetPhonetic(PhoneticRun{"とうきょう", 1, 1}))
+	require.NotNil(t, sheet.CellByRef("A4").SetPhonetic(PhoneticRun{"いち", 0, 1}))
+
+	//same text without phonetic runs is another string
+	require.Equal(t, "東京都", sheet.CellByRef("A1").Value())
+	require.Nil(t, sheet.CellByRef("A2").Phonetic())
+	require.NotEqual(t, sheet.CellByRef("A1").ml.Value, sheet.CellByRef("A2").ml.Value)
+	require.Equal(t, true, sheet.CellByRef("A1").ml.Ph)
+	require.Equal(t, false, sheet.CellByRef("A2").ml.Ph)
+	require.Equal(t, []PhoneticRun{{"おおさか", 0, 2}}, sheet.CellByRef("A3").Phonetic())
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, xl.SaveAs(buf))
+
+	saved, err := OpenBytes(buf.Bytes())
+	require.Nil(t, err)
+	defer saved.Close()
+
+	savedSheet := saved.Sheet(0)
+	require.Equal(t, []PhoneticRun{{"とうきょう", 0, 2}, {"と", 2, 3}}, savedSheet.CellByRef("A1").Phonetic())
+	require.Equal(t, []PhoneticRun{{"おおさか", 0, 2}}, savedSheet.CellByRef("A3").Phonetic())
+	require.Equal(t, &ml.PhoneticProperties{}, savedSheet.CellByRef("A1").stringItem().PhoneticPr)
+
+	require.Nil(t, savedSheet.CellByRef("A1").SetPhonetic())
+	require.Nil(t, savedSheet.CellByRef("A1").Phonetic())
+	require.Equal(t, false, savedSheet.CellByRef("A1").ml.Ph)
+}
@@ -0,0 +1,47 @@
+package pivot
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+//Function is alias of original primitives.DataConsolidateFunctionType type to:
+// 1) make it public
+// 2) forbid usage of integers directly
+type Function = primitives.DataConsolidateFunctionType
+
+//List of all possible values for Function
+const (
+	_ Function = iota
+	Sum
+	Count
+	Average
+	Max
+	Min
+	Product
+	CountNums
+	StdDev
+	StdDevp
+	Var
+	Varp
+)
+
+func init() {
+	primitives.FromDataConsolidateFunctionType = map[Function]string{
+		Sum:       "sum",
+		Count:     "count",
+		Average:   "average",
+		Max:       "max",
+		Min:       "min",
+		Product:   "product",
+		CountNums: "countNums",
+		StdDev:    "stdDev",
+		StdDevp:   "stdDevp",
+		Var:       "var",
+		Varp:      "varp",
+	}
+
+	primitives.ToDataConsolidateFunctionType = make(map[string]Function, len(primitives.FromDataConsolidateFunctionType))
+	for k, v := range primitives.FromDataConsolidateFunctionType {
+		primitives.ToDataConsolidateFunctionType[v] = k
+	}
+}
@@ -0,0 +1,255 @@
+package pivot
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+	"strings"
+)
+
+//Info is objects that holds information about pivot table
+type Info struct {
+	name    string
+	style   string
+	sheet   string
+	rows    []string
+	columns []string
+	filters []string
+	values  []*value
+}
+
+type value struct {
+	field    string
+	function Function
+}
+
+//Option is a type of option for pivot table
+type Option func(i *Info)
+
+//list of captions for aggregation functions that are used by names of data fields
+var functionCaptions = map[Function]string{
+	Sum:       "Sum",
+	Count:     "Count",
+	Average:   "Average",
+	Max:       "Max",
+	Min:       "Min",
+	Product:   "Product",
+	CountNums: "Count",
+	StdDev:    "StdDev",
+	StdDevp:   "StdDevp",
+	Var:       "Var",
+	Varp:      "Varp",
+}
+
+//New creates and returns a new Info object that holds settings for pivot table
+func New(options ...Option) *Info {
+	i := &Info{style: "PivotStyleLight16"}
+	i.Set(options...)
+	return i
+}
+
+//Set sets new options for pivot table
+func (i *Info) Set(options ...Option) {
+	for _, o := range options {
+		o(i)
+	}
+}
+
+//Name returns name of pivot table
+func (i *Info) Name() string {
+	return i.name
+}
+
+//SourceSheet returns name of sheet with source data or empty string if source data resides at the same sheet as pivot table
+func (i *Info) SourceSheet() string {
+	return i.sheet
+}
+
+//Name sets name of pivot table. Name must be unique across all pivot tables of sheet
+func Name(name string) Option {
+	return func(i *Info) {
+		i.name = name
+	}
+}
+
+//Style sets name of built-in style for pivot table, e.g.: PivotStyleMedium9
+func Style(name string) Option {
+	return func(i *Info) {
+		i.style = name
+	}
+}
+
+//SourceSheet sets name of sheet with source data. By default, source data resides at the same sheet as pivot table
+func SourceSheet(name string) Option {
+	return func(i *Info) {
+		i.sheet = name
+	}
+}
+
+//Rows adds fields of source data to use as row labels
+func Rows(fields ...string) Option {
+	return func(i *Info) {
+		i.rows = append(i.rows, fields...)
+	}
+}
+
+//Columns adds fields of source data to use as column labels
+func Columns(fields ...string) Option {
+	return func(i *Info) {
+		i.columns = append(i.columns, fields...)
+	}
+}
+
+//Filters adds fields of source data to use as report filters
+func Filters(fields ...string) Option {
+	return func(i *Info) {
+		i.filters = append(i.filters, fields...)
+	}
+}
+
+//Value adds field of source data to summarize with aggregation function
+func Value(field string, function Function) Option {
+	return func(i *Info) {
+		i.values = append(i.values, &value{field: field, function: function})
+	}
+}
+
+//Validate validates settings of pivot table
+func (i *Info) Validate() error {
+	if len(i.values) == 0 {
+		return errors.New("pivot table must have at least one value field")
+	}
+
+	used := make(map[string]bool)
+	for _, fields := range [][]string{i.rows, i.columns, i.filters} {
+		for _, field := range fields {
+			if used[strings.ToLower(field)] {
+				return errors.New(fmt.Sprintf("field '%s' can be used only once as row, column or filter", field))
+			}
+
+			used[strings.ToLower(field)] = true
+		}
+	}
+
+	for _, v := range i.values {
+		if _, ok := functionCaptions[v.function]; !ok {
+			return errors.New(fmt.Sprintf("unknown aggregation function for value field '%s'", v.field))
+		}
+	}
+
+	return nil
+}
+
+//private method used by pivot tables manager to unpack Info for source data with fields
+func fromPivotInfo(info *Info, fields []string) (pivot *ml.PivotTableDefinition, err error) {
+	if err = info.Validate(); err != nil {
+		return
+	}
+
+	index := make(map[string]int, len(fields))
+	for i, field := range fields {
+		index[strings.ToLower(field)] = i
+	}
+
+	resolve := func(field string) (int, error) {
+		if idx, ok := index[strings.ToLower(field)]; ok {
+			return idx, nil
+		}
+
+		return -1, errors.New(fmt.Sprintf("there is no field '%s' at source data", field))
+	}
+
+	showAll := false
+	pivot = &ml.PivotTableDefinition{
+		Name:                  info.name,
+		DataCaption:           "Values",
+		UpdatedVersion:        6,
+		MinRefreshableVersion: 3,
+		CreatedVersion:        6,
+		UseAutoFormatting:     true,
+		ItemPrintTitles:       true,
+		Outline:               true,
+		OutlineData:           true,
+		StyleInfo: &ml.PivotTableStyleInfo{
+			Name:           info.style,
+			ShowRowHeaders: true,
+			ShowColHeaders: true,
+			ShowLastColumn: true,
+		},
+	}
+
+	for range fields {
+		pivot.PivotFields.Items = append(pivot.PivotFields.Items, &ml.PivotField{ShowAll: &showAll})
+	}
+
+	//fields of axis use the only item that will be populated by Excel during refresh
+	for axis, list := range map[string][]string{"axisRow": info.rows, "axisCol": info.columns, "axisPage": info.filters} {
+		for _, field := range list {
+			idx, err := resolve(field)
+			if err != nil {
+				return nil, err
+			}
+
+			item := 0
+			pivot.PivotFields.Items[idx].Axis = axis
+			pivot.PivotFields.Items[idx].Items.Items = []*ml.PivotItem{{Index: &item}, {Type: "default"}}
+		}
+	}
+
+	for _, field := range info.rows {
+		idx, _ := resolve(field)
+		pivot.RowFields.Items = append(pivot.RowFields.Items, &ml.PivotAxisField{Index: idx})
+	}
+
+	for _, field := range info.columns {
+		idx, _ := resolve(field)
+		pivot.ColFields.Items = append(pivot.ColFields.Items, &ml.PivotAxisField{Index: idx})
+	}
+
+	for _, field := range info.filters {
+		idx, _ := resolve(field)
+		pivot.PageFields.Items = append(pivot.PageFields.Items, &ml.PivotPageField{Field: idx, Hierarchy: -1})
+	}
+
+	for _, v := range info.values {
+		idx, err := resolve(v.field)
+		if err != nil {
+			return nil, err
+		}
+
+		pivot.PivotFields.Items[idx].DataField = true
+		pivot.DataFields.Items = append(pivot.DataFields.Items, &ml.PivotDataField{
+			Name:     fmt.Sprintf("%s of %s", functionCaptions[v.function], fields[idx]),
+			Field:    idx,
+			Subtotal: v.function,
+		})
+	}
+
+	//few value fields are shown as columns with special field
+	if len(info.values) > 1 {
+		pivot.ColFields.Items = append(pivot.ColFields.Items, &ml.PivotAxisField{Index: -2})
+	}
+
+	//initial location, Excel will update it during refresh
+	width, height := len(info.values), 2
+	pivot.Location.FirstHeaderRow = 1
+	pivot.Location.FirstDataRow = 1
+	if len(info.rows) > 0 {
+		width++
+		pivot.Location.FirstDataCol = 1
+	}
+
+	if len(pivot.ColFields.Items) > 0 {
+		height++
+		pivot.Location.FirstDataRow++
+	}
+
+	if len(info.filters) > 0 {
+		pivot.Location.RowPageCount = len(info.filters)
+		pivot.Location.ColPageCount = 1
+	}
+
+	pivot.Location.Bounds = types.BoundsFromIndexes(0, 0, width-1, height-1)
+	return
+}
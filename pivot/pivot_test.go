@@ -0,0 +1,58 @@
+package pivot
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPivot(t *testing.T) {
+	fields := []string{"Region", "Product", "Year", "Amount"}
+
+	//invalid settings
+	_, err := fromPivotInfo(New(Rows("Region")), fields)
+	require.NotNil(t, err)
+
+	_, err = fromPivotInfo(New(Rows("Region"), Columns("Region"), Value("Amount", Sum)), fields)
+	require.NotNil(t, err)
+
+	_, err = fromPivotInfo(New(Rows("Unknown"), Value("Amount", Sum)), fields)
+	require.NotNil(t, err)
+
+	_, err = fromPivotInfo(New(Value("Amount", Function(100))), fields)
+	require.NotNil(t, err)
+
+	//rows and single value
+	info := New(Name("Sales"), Rows("region"), Value("Amount", Sum))
+	require.Equal(t, "Sales", info.Name())
+
+	pivot, err := fromPivotInfo(info, fields)
+	require.Nil(t, err)
+	require.Equal(t, "", info.SourceSheet())
+	require.Equal(t, "Sales", pivot.Name)
+	require.Equal(t, "PivotStyleLight16", pivot.StyleInfo.Name)
+	require.Equal(t, 4, len(pivot.PivotFields.Items))
+	require.Equal(t, "axisRow", pivot.PivotFields.Items[0].Axis)
+	require.Equal(t, 2, len(pivot.PivotFields.Items[0].Items.Items))
+	require.Equal(t, "default", pivot.PivotFields.Items[0].Items.Items[1].Type)
+	require.Equal(t, true, pivot.PivotFields.Items[3].DataField)
+	require.Equal(t, []*ml.PivotAxisField{{Index: 0}}, pivot.RowFields.Items)
+	require.Nil(t, pivot.ColFields.Items)
+	require.Equal(t, []*ml.PivotDataField{{Name: "Sum of Amount", Field: 3, Subtotal: Sum}}, pivot.DataFields.Items)
+	require.Equal(t, ml.PivotLocation{Bounds: types.BoundsFromIndexes(0, 0, 1, 1), FirstHeaderRow: 1, FirstDataRow: 1, FirstDataCol: 1}, pivot.Location)
+
+	//rows, columns, filters and few values
+	info = New(SourceSheet("Data"), Style("PivotStyleMedium9"), Rows("Region"), Columns("Year"), Filters("Product"), Value("Amount", Sum), Value("Amount", Average))
+	require.Equal(t, "Data", info.SourceSheet())
+
+	pivot, err = fromPivotInfo(info, fields)
+	require.Nil(t, err)
+	require.Equal(t, "PivotStyleMedium9", pivot.StyleInfo.Name)
+	require.Equal(t, "axisPage", pivot.PivotFields.Items[1].Axis)
+	require.Equal(t, "axisCol", pivot.PivotFields.Items[2].Axis)
+	require.Equal(t, []*ml.PivotAxisField{{Index: 2}, {Index: -2}}, pivot.ColFields.Items)
+	require.Equal(t, []*ml.PivotPageField{{Field: 1, Hierarchy: -1}}, pivot.PageFields.Items)
+	require.Equal(t, "Average of Amount", pivot.DataFields.Items[1].Name)
+	require.Equal(t, ml.PivotLocation{Bounds: types.BoundsFromIndexes(0, 0, 2, 2), FirstHeaderRow: 1, FirstDataRow: 2, FirstDataCol: 1, RowPageCount: 1, ColPageCount: 1}, pivot.Location)
+}
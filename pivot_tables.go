@@ -0,0 +1,261 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/plandem/ooxml"
+	sharedML "github.com/plandem/ooxml/ml"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/pivot"
+	"github.com/plandem/xlsx/types"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	_ "unsafe"
+)
+
+//go:linkname fromPivotInfo github.com/plandem/xlsx/pivot.fromPivotInfo
+func fromPivotInfo(info *pivot.Info, fields []string) (*ml.PivotTableDefinition, error)
+
+var regExpPivotTable = regexp.MustCompile(`^xl/pivotTables/pivotTable\d+\.xml$`)
+
+var regExpPivotCacheID = regexp.MustCompile(`pivotCacheId="(\d+)"`)
+
+//pivotCache is a pivot cache of pivot table. Definition of cache is loaded only if required
+type pivotCache struct {
+	id         int
+	definition *ml.PivotCacheDefinition
+	file       *ooxml.PackageFile
+}
+
+type pivotTables struct {
+	sheet    *sheetInfo
+	names    []string
+	caches   map[string]*pivotCache
+	isLoaded bool
+}
+
+//newPivotTables creates an object that implements pivot tables functionality
+func newPivotTables(sheet *sheetInfo) *pivotTables {
+	return &pivotTables{sheet: sheet, caches: make(map[string]*pivotCache)}
+}
+
+//loadIfRequired lookups for names of existing pivot tables of sheet
+func (p *pivotTables) loadIfRequired() {
+	if p.isLoaded {
+		return
+	}
+
+	p.isLoaded = true
+
+	//only existing sheets can have existing pivot tables
+	if p.sheet.file.IsNew() {
+		return
+	}
+
+	p.sheet.attachRelationshipsIfRequired()
+	for _, f := range p.sheet.workbook.doc.pkg.Files() {
+		if zf, ok := f.(*zip.File); ok && regExpPivotTable.MatchString(zf.Name) {
+			if rid := p.sheet.relationships.GetIdByTarget(zf.Name); rid == "" || p.sheet.relationships.GetTypeById(string(rid)) != internal.RelationTypePivotTable {
+				continue
+			}
+
+			if reader, err := zf.Open(); err == nil {
+				definition := ml.PivotTableDefinition{}
+				if err := xml.NewDecoder(reader).Decode(&definition); err == nil {
+					p.names = append(p.names, definition.Name)
+					p.caches[strings.ToLower(definition.Name)] = &pivotCache{id: definition.CacheID}
+				}
+
+				_ = reader.Close()
+			}
+		}
+	}
+}
+
+//nextCacheID returns a next unique id for pivot cache of workbook
+func (p *pivotTables) nextCacheID() int {
+	id := 1
+	for _, cache := range p.sheet.workbook.ml.PivotCaches.Items {
+		if cache.CacheID >= id {
+			id = cache.CacheID + 1
+		}
+	}
+
+	return id
+}
+
+//isUnique returns true if there is no pivot table with name at sheet
+func (p *pivotTables) isUnique(name string) bool {
+	for _, n := range p.names {
+		if strings.EqualFold(n, name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+//Add adds a new pivot table with top left corner at cellRef for source data with bounds. The first row of source data is a header with names of fields
+func (p *pivotTables) Add(cellRef types.CellRef, bounds types.Bounds, info *pivot.Info) error {
+	p.loadIfRequired()
+
+	if info == nil {
+		return errors.New("pivot table requires settings")
+	}
+
+	//resolve sheet with source data
+	source := p.sheet
+	if name := info.SourceSheet(); len(name) > 0 {
+		if source = p.sheet.workbook.doc.sheetByName(name); source == nil {
+			return errors.New(fmt.Sprintf("there is no sheet '%s' with source data", name))
+		}
+	}
+
+	if bounds.ToRow <= bounds.FromRow {
+		return errors.New("source data must have a header and at least one row of data")
+	}
+
+	cIdx, rIdx := cellRef.ToIndexes()
+	if source == p.sheet && bounds.Contains(cIdx, rIdx) {
+		return errors.New(fmt.Sprintf("pivot table can't be placed inside of source data %s", bounds))
+	}
+
+	//names of fields
+	fields := make([]string, 0, bounds.ToCol-bounds.FromCol+1)
+	unique := make(map[string]bool)
+	for c := bounds.FromCol; c <= bounds.ToCol; c++ {
+		name := strings.TrimSpace(source.sheet.Cell(c, bounds.FromRow).Value())
+		if len(name) == 0 || unique[strings.ToLower(name)] {
+			return errors.New(fmt.Sprintf("header of source data must have unique names of fields, got '%s'", name))
+		}
+
+		unique[strings.ToLower(name)] = true
+		fields = append(fields, name)
+	}
+
+	definition, err := fromPivotInfo(info, fields)
+	if err != nil {
+		return err
+	}
+
+	//report filters are placed above pivot table with an empty row
+	if pages := len(definition.PageFields.Items); pages > 0 && rIdx < pages+1 {
+		return errors.New(fmt.Sprintf("pivot table with %d filters requires at least %d rows above", pages, pages+1))
+	}
+
+	if len(definition.Name) == 0 {
+		for i := len(p.names) + 1; len(definition.Name) == 0 || !p.isUnique(definition.Name); i++ {
+			definition.Name = fmt.Sprintf("PivotTable%d", i)
+		}
+	} else if !p.isUnique(definition.Name) {
+		return errors.New(fmt.Sprintf("pivot table with name '%s' already exists", definition.Name))
+	}
+
+	location := definition.Location.Bounds
+	definition.Location.Bounds = types.BoundsFromIndexes(cIdx, rIdx, cIdx+location.ToCol, rIdx+location.ToRow)
+	definition.CacheID = p.nextCacheID()
+
+	//cache stores no records and will be refreshed by Excel during opening
+	saveData := false
+	cache := &ml.PivotCacheDefinition{
+		SaveData:              &saveData,
+		RefreshOnLoad:         true,
+		RefreshedVersion:      6,
+		CreatedVersion:        6,
+		MinRefreshableVersion: 3,
+		CacheSource: ml.CacheSource{
+			Type:            "worksheet",
+			WorksheetSource: &ml.WorksheetSource{Bounds: bounds, Sheet: source.Name()},
+		},
+	}
+
+	for i, name := range fields {
+		items := &ml.SharedItems{}
+		if len(definition.PivotFields.Items[i].Axis) > 0 {
+			items = &ml.SharedItems{ContainsBlank: true, Count: 1, Missing: []*sharedML.Reserved{{}}}
+		}
+
+		cache.CacheFields.Items = append(cache.CacheFields.Items, &ml.CacheField{Name: name, SharedItems: items})
+	}
+
+	doc := p.sheet.workbook.doc
+	cacheFileName := doc.uniqueFileName("xl/pivotCache/pivotCacheDefinition%d.xml")
+	cacheFile := ooxml.NewPackageFile(doc.pkg, cacheFileName, cache, nil)
+	cacheFile.MarkAsUpdated()
+	doc.pkg.ContentTypes().RegisterContent(cacheFileName, internal.ContentTypePivotCache)
+	_, rid := doc.relationships.AddFile(internal.RelationTypePivotCache, cacheFileName)
+	p.sheet.workbook.ml.PivotCaches.Items = append(p.sheet.workbook.ml.PivotCaches.Items, &ml.PivotCache{CacheID: definition.CacheID, RID: rid})
+	p.sheet.workbook.file.MarkAsUpdated()
+
+	fileName := doc.uniqueFileName("xl/pivotTables/pivotTable%d.xml")
+	ooxml.NewPackageFile(doc.pkg, fileName, definition, nil).MarkAsUpdated()
+	doc.pkg.ContentTypes().RegisterContent(fileName, internal.ContentTypePivotTable)
+	ooxml.NewRelationships(fmt.Sprintf("xl/pivotTables/_rels/%s.rels", filepath.Base(fileName)), doc.pkg).AddFile(internal.RelationTypePivotCache, cacheFileName)
+	p.sheet.attachRelationshipsIfRequired()
+	p.sheet.relationships.AddFile(internal.RelationTypePivotTable, fileName)
+	p.names = append(p.names, definition.Name)
+	p.caches[strings.ToLower(definition.Name)] = &pivotCache{id: definition.CacheID, definition: cache, file: cacheFile}
+	return nil
+}
+
+//cache returns pivot cache of pivot table with name or nil if there is no such pivot table at sheet
+func (p *pivotTables) cache(name string) *pivotCache {
+	p.loadIfRequired()
+
+	c, ok := p.caches[strings.ToLower(name)]
+	if !ok {
+		return nil
+	}
+
+	if c.definition == nil {
+		doc := p.sheet.workbook.doc
+		for _, item := range p.sheet.workbook.ml.PivotCaches.Items {
+			if item.CacheID != c.id {
+				continue
+			}
+
+			if zf, ok := doc.pkg.File(doc.relationships.GetTargetById(string(item.RID))).(*zip.File); ok {
+				c.definition = &ml.PivotCacheDefinition{}
+				c.file = ooxml.NewPackageFile(doc.pkg, zf, c.definition, nil)
+				c.file.LoadIfRequired(nil)
+			}
+
+			break
+		}
+	}
+
+	if c.definition == nil {
+		return nil
+	}
+
+	return c
+}
+
+//fields returns names of fields of pivot cache
+func (c *pivotCache) fields() []string {
+	fields := make([]string, 0, len(c.definition.CacheFields.Items))
+	for _, field := range c.definition.CacheFields.Items {
+		fields = append(fields, field.Name)
+	}
+
+	return fields
+}
+
+//extensionID returns id of pivot cache that is used by x14 objects, e.g.: slicers. Id is added to definition of cache if required
+func (c *pivotCache) extensionID() int {
+	if c.definition.ExtLst != nil && c.definition.ExtLst.InnerXML != nil {
+		if match := regExpPivotCacheID.FindStringSubmatch(c.definition.ExtLst.InnerXML.XML); match != nil {
+			id, _ := strconv.Atoi(match[1])
+			return id
+		}
+	}
+
+	addExtension(&c.definition.ExtLst, ml.ExtURIPivotCacheDefinition, "x14:pivotCacheDefinition", fmt.Sprintf(` pivotCacheId="%d"`, c.id), "")
+	c.file.MarkAsUpdated()
+	return c.id
+}
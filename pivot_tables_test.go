@@ -0,0 +1,69 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/pivot"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPivotTables(t *testing.T) {
+	xl := New()
+	data := xl.AddSheet("Data")
+	data.CellByRef("A1").SetValue("Region")
+	data.CellByRef("B1").SetValue("Product")
+	data.CellByRef("C1").SetValue("Amount")
+	data.CellByRef("A2").SetValue("North")
+	data.CellByRef("B2").SetValue("Apple")
+	data.CellByRef("C2").SetValue(100)
+	data.CellByRef("A3").SetValue("South")
+	data.CellByRef("B3").SetValue("Pear")
+	data.CellByRef("C3").SetValue(200)
+
+	report := xl.AddSheet("Report")
+	source := types.BoundsFromIndexes(0, 0, 2, 2)
+
+	//invalid pivot tables
+	require.NotNil(t, data.AddPivotTable("E1", source))
+	require.NotNil(t, data.AddPivotTable("B2", source, pivot.Rows("Region"), pivot.Value("Amount", pivot.Sum)))
+	require.NotNil(t, data.AddPivotTable("E1", types.BoundsFromIndexes(0, 0, 2, 0), pivot.Value("Amount", pivot.Sum)))
+	require.NotNil(t, data.AddPivotTable("E1", types.BoundsFromIndexes(0, 0, 3, 2), pivot.Value("Amount", pivot.Sum)))
+	require.NotNil(t, data.AddPivotTable("E1", source, pivot.Rows("Unknown"), pivot.Value("Amount", pivot.Sum)))
+	require.NotNil(t, report.AddPivotTable("A1", source, pivot.SourceSheet("Unknown"), pivot.Value("Amount", pivot.Sum)))
+	require.NotNil(t, report.AddPivotTable("A2", source, pivot.SourceSheet("Data"), pivot.Filters("Product"), pivot.Value("Amount", pivot.Sum)))
+	require.Equal(t, 0, len(xl.workbook.ml.PivotCaches.Items))
+
+	//pivot table at the same sheet
+	require.Nil(t, data.AddPivotTable("E1", source, pivot.Rows("Region"), pivot.Value("Amount", pivot.Sum)))
+
+	//pivot table at another sheet
+	require.Nil(t, report.AddPivotTable("A3", source, pivot.Name("Sales"), pivot.SourceSheet("data"), pivot.Rows("Region"), pivot.Filters("Product"), pivot.Value("Amount", pivot.Sum), pivot.Value("Amount", pivot.Count)))
+	require.NotNil(t, report.AddPivotTable("H3", source, pivot.Name("sales"), pivot.SourceSheet("Data"), pivot.Value("Amount", pivot.Sum)))
+
+	require.Equal(t, []*ml.PivotCache{{CacheID: 1, RID: xl.workbook.ml.PivotCaches.Items[0].RID}, {CacheID: 2, RID: xl.workbook.ml.PivotCaches.Items[1].RID}}, xl.workbook.ml.PivotCaches.Items)
+	require.Equal(t, []string{"PivotTable1"}, data.info().pivotTables.names)
+	require.Equal(t, []string{"Sales"}, report.info().pivotTables.names)
+	require.Equal(t, "xl/pivotCache/pivotCacheDefinition2.xml", xl.relationships.GetTargetById(string(xl.workbook.ml.PivotCaches.Items[1].RID)))
+	require.NotEqual(t, "", report.info().relationships.GetIdByTarget("xl/pivotTables/pivotTable2.xml"))
+	require.Equal(t, internal.RelationTypePivotTable, report.info().relationships.GetTypeById(string(report.info().relationships.GetIdByTarget("xl/pivotTables/pivotTable2.xml"))))
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	require.Equal(t, 2, len(xl.workbook.ml.PivotCaches.Items))
+
+	//names of existing pivot tables must be unique
+	report = xl.Sheet(1)
+	require.NotNil(t, report.AddPivotTable("H3", source, pivot.Name("Sales"), pivot.SourceSheet("Data"), pivot.Value("Amount", pivot.Sum)))
+	require.Nil(t, report.AddPivotTable("H3", source, pivot.SourceSheet("Data"), pivot.Value("Amount", pivot.Sum)))
+	require.Equal(t, []string{"Sales", "PivotTable2"}, report.info().pivotTables.names)
+	require.Equal(t, 3, xl.workbook.ml.PivotCaches.Items[2].CacheID)
+}
@@ -0,0 +1,141 @@
+package xlsx
+
+import (
+	"errors"
+	"github.com/plandem/xlsx/internal/crypto"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/protection"
+	_ "unsafe"
+)
+
+//go:linkname fromProtectionInfo github.com/plandem/xlsx/protection.fromProtectionInfo
+func fromProtectionInfo(info *protection.Info) *ml.SheetProtection
+
+//Protect protects sheet with password and allowed actions, e.g.: Protect("secret", protection.AllowSort, protection.AllowFilter). Empty password protects sheet without password
+func (s *sheetInfo) Protect(password string, options ...protection.Option) error {
+	p := fromProtectionInfo(protection.New(options...))
+
+	if len(password) > 0 {
+		hash, err := crypto.NewPasswordHash(password)
+		if err != nil {
+			return err
+		}
+
+		p.AlgorithmName, p.HashValue, p.SaltValue, p.SpinCount = hash.AlgorithmName, hash.HashValue, hash.SaltValue, hash.SpinCount
+	}
+
+	s.ml.SheetProtection = p
+	return nil
+}
+
+//Unprotect removes protection of sheet
+func (s *sheetInfo) Unprotect() {
+	s.ml.SheetProtection = nil
+}
+
+//IsProtected returns true if sheet is protected
+func (s *sheetInfo) IsProtected() bool {
+	return s.ml.SheetProtection != nil && s.ml.SheetProtection.Sheet
+}
+
+//VerifyPassword returns true if sheet is protected and password matches password of protection. Legacy hashes of passwords are supported too. Empty password matches protection without password
+func (s *sheetInfo) VerifyPassword(password string) bool {
+	if !s.IsProtected() {
+		return false
+	}
+
+	p := s.ml.SheetProtection
+	return crypto.VerifyPassword(password, &crypto.PasswordHash{
+		AlgorithmName: p.AlgorithmName,
+		HashValue:     p.HashValue,
+		SaltValue:     p.SaltValue,
+		SpinCount:     p.SpinCount,
+	}, p.Password)
+}
+
+//ChangePassword changes password of protected sheet and keeps allowed actions as is. Empty password removes password, but keeps protection
+func (s *sheetInfo) ChangePassword(password string) error {
+	if !s.IsProtected() {
+		return errors.New("sheet is not protected")
+	}
+
+	p := s.ml.SheetProtection
+	p.Password, p.AlgorithmName, p.HashValue, p.SaltValue, p.SpinCount = "", "", "", "", 0
+	if len(password) > 0 {
+		hash, err := crypto.NewPasswordHash(password)
+		if err != nil {
+			return err
+		}
+
+		p.AlgorithmName, p.HashValue, p.SaltValue, p.SpinCount = hash.AlgorithmName, hash.HashValue, hash.SaltValue, hash.SpinCount
+	}
+
+	return nil
+}
+
+//ProtectStructure protects structure of workbook with password, so sheets can't be added, deleted, renamed or moved. Empty password protects structure without password
+func (xl *Spreadsheet) ProtectStructure(password string) error {
+	p := &ml.WorkbookProtection{LockStructure: true}
+
+	if len(password) > 0 {
+		hash, err := crypto.NewPasswordHash(password)
+		if err != nil {
+			return err
+		}
+
+		p.WorkbookAlgorithmName, p.WorkbookHashValue, p.WorkbookSaltValue, p.WorkbookSpinCount = hash.AlgorithmName, hash.HashValue, hash.SaltValue, hash.SpinCount
+	}
+
+	xl.workbook.ml.WorkbookProtection = p
+	xl.workbook.file.MarkAsUpdated()
+	return nil
+}
+
+//UnprotectStructure removes protection of workbook structure
+func (xl *Spreadsheet) UnprotectStructure() {
+	if xl.workbook.ml.WorkbookProtection != nil {
+		xl.workbook.ml.WorkbookProtection = nil
+		xl.workbook.file.MarkAsUpdated()
+	}
+}
+
+//IsStructureProtected returns true if structure of workbook is protected
+func (xl *Spreadsheet) IsStructureProtected() bool {
+	return xl.workbook.ml.WorkbookProtection != nil && xl.workbook.ml.WorkbookProtection.LockStructure
+}
+
+//VerifyStructurePassword returns true if structure of workbook is protected and password matches password of protection. Legacy hashes of passwords are supported too. Empty password matches protection without password
+func (xl *Spreadsheet) VerifyStructurePassword(password string) bool {
+	if !xl.IsStructureProtected() {
+		return false
+	}
+
+	p := xl.workbook.ml.WorkbookProtection
+	return crypto.VerifyPassword(password, &crypto.PasswordHash{
+		AlgorithmName: p.WorkbookAlgorithmName,
+		HashValue:     p.WorkbookHashValue,
+		SaltValue:     p.WorkbookSaltValue,
+		SpinCount:     p.WorkbookSpinCount,
+	}, p.WorkbookPassword)
+}
+
+//ChangeStructurePassword changes password of protected workbook structure. Empty password removes password, but keeps protection
+func (xl *Spreadsheet) ChangeStructurePassword(password string) error {
+	if !xl.IsStructureProtected() {
+		return errors.New("structure of workbook is not protected")
+	}
+
+	p := xl.workbook.ml.WorkbookProtection
+	p.WorkbookPassword, p.WorkbookAlgorithmName, p.WorkbookHashValue, p.WorkbookSaltValue, p.WorkbookSpinCount = "", "", "", "", 0
+	if len(password) > 0 {
+		hash, err := crypto.NewPasswordHash(password)
+		if err != nil {
+			return err
+		}
+
+		p.WorkbookAlgorithmName, p.WorkbookHashValue, p.WorkbookSaltValue, p.WorkbookSpinCount = hash.AlgorithmName, hash.HashValue, hash.SaltValue, hash.SpinCount
+	}
+
+	xl.workbook.file.MarkAsUpdated()
+	return nil
+}
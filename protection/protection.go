@@ -0,0 +1,121 @@
+package protection
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+)
+
+//Info is objects that holds settings of sheet protection
+type Info struct {
+	protection *ml.SheetProtection
+}
+
+//Option is a type of option for sheet protection
+type Option func(i *Info)
+
+//New creates and returns a new Info object that holds settings of sheet protection. By default everything except selecting of cells is prohibited
+func New(options ...Option) *Info {
+	i := &Info{
+		protection: &ml.SheetProtection{
+			Sheet:     true,
+			Objects:   true,
+			Scenarios: true,
+		},
+	}
+	i.Set(options...)
+	return i
+}
+
+//Set sets new options for sheet protection
+func (i *Info) Set(options ...Option) {
+	for _, o := range options {
+		o(i)
+	}
+}
+
+//allowed returns value for attributes that prohibit action by default
+func allowed() *bool {
+	value := false
+	return &value
+}
+
+//AllowFormatCells allows formatting of cells
+func AllowFormatCells(i *Info) {
+	i.protection.FormatCells = allowed()
+}
+
+//AllowFormatColumns allows formatting of columns
+func AllowFormatColumns(i *Info) {
+	i.protection.FormatColumns = allowed()
+}
+
+//AllowFormatRows allows formatting of rows
+func AllowFormatRows(i *Info) {
+	i.protection.FormatRows = allowed()
+}
+
+//AllowInsertColumns allows inserting of columns
+func AllowInsertColumns(i *Info) {
+	i.protection.InsertColumns = allowed()
+}
+
+//AllowInsertRows allows inserting of rows
+func AllowInsertRows(i *Info) {
+	i.protection.InsertRows = allowed()
+}
+
+//AllowInsertHyperlinks allows inserting of hyperlinks
+func AllowInsertHyperlinks(i *Info) {
+	i.protection.InsertHyperlinks = allowed()
+}
+
+//AllowDeleteColumns allows deleting of columns
+func AllowDeleteColumns(i *Info) {
+	i.protection.DeleteColumns = allowed()
+}
+
+//AllowDeleteRows allows deleting of rows
+func AllowDeleteRows(i *Info) {
+	i.protection.DeleteRows = allowed()
+}
+
+//AllowSort allows sorting
+func AllowSort(i *Info) {
+	i.protection.Sort = allowed()
+}
+
+//AllowFilter allows using of auto filter
+func AllowFilter(i *Info) {
+	i.protection.AutoFilter = allowed()
+}
+
+//AllowPivotTables allows using of pivot tables
+func AllowPivotTables(i *Info) {
+	i.protection.PivotTables = allowed()
+}
+
+//AllowEditObjects allows editing of objects, e.g. charts or comments
+func AllowEditObjects(i *Info) {
+	i.protection.Objects = false
+}
+
+//AllowEditScenarios allows editing of scenarios
+func AllowEditScenarios(i *Info) {
+	i.protection.Scenarios = false
+}
+
+//DenySelectLockedCells prohibits selecting of locked cells
+func DenySelectLockedCells(i *Info) {
+	i.protection.SelectLockedCells = true
+}
+
+//DenySelectUnlockedCells prohibits selecting of unlocked cells
+func DenySelectUnlockedCells(i *Info) {
+	i.protection.SelectUnlockedCells = true
+}
+
+//private method used by sheet to unpack Info
+func fromProtectionInfo(info *Info) *ml.SheetProtection {
+	//copy info to prevent side effects of reusing Info for different sheets
+	p := *info.protection
+	return &p
+}
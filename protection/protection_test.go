@@ -0,0 +1,57 @@
+package protection
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestProtection(t *testing.T) {
+	allow := false
+
+	require.Equal(t, &ml.SheetProtection{
+		Sheet:     true,
+		Objects:   true,
+		Scenarios: true,
+	}, fromProtectionInfo(New()))
+
+	info := New(
+		AllowFormatCells,
+		AllowFormatColumns,
+		AllowFormatRows,
+		AllowInsertColumns,
+		AllowInsertRows,
+		AllowInsertHyperlinks,
+		AllowDeleteColumns,
+		AllowDeleteRows,
+		AllowSort,
+		AllowFilter,
+		AllowPivotTables,
+		AllowEditObjects,
+		AllowEditScenarios,
+		DenySelectLockedCells,
+		DenySelectUnlockedCells,
+	)
+
+	p := fromProtectionInfo(info)
+	require.Equal(t, &ml.SheetProtection{
+		Sheet:               true,
+		FormatCells:         &allow,
+		FormatColumns:       &allow,
+		FormatRows:          &allow,
+		InsertColumns:       &allow,
+		InsertRows:          &allow,
+		InsertHyperlinks:    &allow,
+		DeleteColumns:       &allow,
+		DeleteRows:          &allow,
+		SelectLockedCells:   true,
+		Sort:                &allow,
+		AutoFilter:          &allow,
+		PivotTables:         &allow,
+		SelectUnlockedCells: true,
+	}, p)
+
+	//unpacked settings must not affect info
+	p.Sheet = false
+	require.Equal(t, true, fromProtectionInfo(info).Sheet)
+}
@@ -0,0 +1,102 @@
+package xlsx
+
+import (
+	"encoding/base64"
+	"github.com/plandem/xlsx/internal/crypto"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/protection"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestProtection(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Data")
+	other := xl.AddSheet("Other")
+
+	require.Equal(t, false, sheet.IsProtected())
+	require.Nil(t, sheet.Protect("secret", protection.AllowSort, protection.AllowFilter))
+	require.Equal(t, true, sheet.IsProtected())
+
+	require.Nil(t, other.Protect(""))
+	require.Equal(t, true, other.IsProtected())
+	require.Equal(t, &ml.SheetProtection{Sheet: true, Objects: true, Scenarios: true}, other.info().ml.SheetProtection)
+	other.Unprotect()
+	require.Equal(t, false, other.IsProtected())
+	require.Nil(t, other.info().ml.SheetProtection)
+
+	require.Equal(t, false, xl.IsStructureProtected())
+	require.Nil(t, xl.ProtectStructure("secret"))
+	require.Equal(t, true, xl.IsStructureProtected())
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	require.Equal(t, true, sheet.IsProtected())
+	require.Equal(t, false, xl.Sheet(1).IsProtected())
+
+	allow := false
+	p := sheet.info().ml.SheetProtection
+	require.Equal(t, crypto.AlgorithmSHA512, p.AlgorithmName)
+	require.Equal(t, crypto.SpinCount, p.SpinCount)
+	require.Equal(t, &allow, p.Sort)
+	require.Equal(t, &allow, p.AutoFilter)
+	require.Nil(t, p.FormatCells)
+
+	salt, err := base64.StdEncoding.DecodeString(p.SaltValue)
+	require.Nil(t, err)
+	require.Equal(t, crypto.HashPassword("secret", salt, p.SpinCount), p.HashValue)
+
+	require.Equal(t, true, xl.IsStructureProtected())
+	wp := xl.workbook.ml.WorkbookProtection
+	require.Equal(t, crypto.AlgorithmSHA512, wp.WorkbookAlgorithmName)
+	salt, err = base64.StdEncoding.DecodeString(wp.WorkbookSaltValue)
+	require.Nil(t, err)
+	require.Equal(t, crypto.HashPassword("secret", salt, wp.WorkbookSpinCount), wp.WorkbookHashValue)
+
+	xl.UnprotectStructure()
+	require.Equal(t, false, xl.IsStructureProtected())
+}
+
+func TestProtection_passwords(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Data")
+	require.Equal(t, false, sheet.VerifyPassword(""))
+	require.NotNil(t, sheet.ChangePassword("secret"))
+
+	require.Nil(t, sheet.Protect("secret", protection.AllowSort))
+	require.Equal(t, true, sheet.VerifyPassword("secret"))
+	require.Equal(t, false, sheet.VerifyPassword("Secret"))
+
+	require.Nil(t, sheet.ChangePassword("rotated"))
+	require.Equal(t, false, sheet.VerifyPassword("secret"))
+	require.Equal(t, true, sheet.VerifyPassword("rotated"))
+
+	allow := false
+	require.Equal(t, &allow, sheet.info().ml.SheetProtection.Sort)
+
+	//legacy hash of password
+	sheet.info().ml.SheetProtection = &ml.SheetProtection{Sheet: true, Password: "CBEB"}
+	require.Equal(t, true, sheet.VerifyPassword("test"))
+	require.Equal(t, false, sheet.VerifyPassword("secret"))
+	require.Nil(t, sheet.ChangePassword(""))
+	require.Equal(t, true, sheet.VerifyPassword(""))
+	require.Equal(t, &ml.SheetProtection{Sheet: true}, sheet.info().ml.SheetProtection)
+
+	require.Equal(t, false, xl.VerifyStructurePassword(""))
+	require.NotNil(t, xl.ChangeStructurePassword("secret"))
+	require.Nil(t, xl.ProtectStructure("secret"))
+	require.Equal(t, true, xl.VerifyStructurePassword("secret"))
+	require.Nil(t, xl.ChangeStructurePassword("rotated"))
+	require.Equal(t, false, xl.VerifyStructurePassword("secret"))
+	require.Equal(t, true, xl.VerifyStructurePassword("rotated"))
+}
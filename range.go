@@ -2,7 +2,9 @@ package xlsx
 
 import (
 	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/internal/ml"
 	"github.com/plandem/xlsx/types"
+	"math"
 )
 
 //Range is a object that provides some functionality for cells inside of range. E.g.: A1:D12
@@ -68,6 +70,48 @@ func (r *Range) Walk(cb func(idx, cIdx, rIdx int, c *Cell)) {
 	}
 }
 
+//SetValues sets values of cells in range row by row starting with the top left cell, e.g.: SetValues([][]interface{}{{"Name", "Price"}, {"Apple", 1.5}}). Values out of range and nil values are ignored
+func (r *Range) SetValues(values [][]interface{}) {
+	//expand grid to required size only once
+	r.sheet.Cell(r.bounds.ToCol, r.bounds.ToRow)
+
+	for rOffset, row := range values {
+		rIdx := r.bounds.FromRow + rOffset
+		if rIdx > r.bounds.ToRow {
+			break
+		}
+
+		for cOffset, value := range row {
+			cIdx := r.bounds.FromCol + cOffset
+			if cIdx > r.bounds.ToCol {
+				break
+			}
+
+			if value != nil {
+				r.sheet.Cell(cIdx, rIdx).SetValue(value)
+			}
+		}
+	}
+}
+
+//Fill sets same value to all cells in range
+func (r *Range) Fill(value interface{}) {
+	r.Walk(func(idx, cIdx, rIdx int, c *Cell) {
+		c.SetValue(value)
+	})
+}
+
+//FillSeries fills cells in range with linear series of numbers starting with start and incremented by step, row by row. E.g.: sheet.Range("A1:A10").FillSeries(1, 1) fills column with numbers from 1 to 10
+func (r *Range) FillSeries(start, step float64) {
+	r.Walk(func(idx, cIdx, rIdx int, c *Cell) {
+		if value := start + float64(idx)*step; value == math.Trunc(value) && math.Abs(value) < math.MaxInt32 {
+			c.SetInt(int(value))
+		} else {
+			c.SetFloat(value)
+		}
+	})
+}
+
 //SetFormatting sets style format to all cells in range
 func (r *Range) SetFormatting(styleID format.DirectStyleID) {
 	r.Walk(func(idx, cIdx, rIdx int, c *Cell) {
@@ -75,9 +119,40 @@ func (r *Range) SetFormatting(styleID format.DirectStyleID) {
 	})
 }
 
+//SetStyles adds style format to document and sets it to all cells in range, returns ID of added style
+func (r *Range) SetStyles(style *format.StyleFormat) format.DirectStyleID {
+	styleID := r.sheet.info().workbook.doc.AddFormatting(style)
+	r.SetFormatting(styleID)
+	return styleID
+}
+
+//AddStyles adds style format to document, where settings of style are layered on top of current style of each cell in range instead of replacing it
+func (r *Range) AddStyles(style *format.StyleFormat) {
+	merged := make(map[format.DirectStyleID]format.DirectStyleID)
+	r.Walk(func(idx, cIdx, rIdx int, c *Cell) {
+		current := c.ml.Style
+		if styleID, ok := merged[current]; ok {
+			c.SetFormatting(styleID)
+		} else {
+			merged[current] = c.AddStyles(style)
+		}
+	})
+}
+
+//SetNamedStyle sets style format that is based on named style with name to all cells in range or returns error if there is no such named style
+func (r *Range) SetNamedStyle(name string) error {
+	styleID, err := r.sheet.info().workbook.doc.NamedStyle(name)
+	if err != nil {
+		return err
+	}
+
+	r.SetFormatting(styleID)
+	return nil
+}
+
 func (r *Range) ensureNotStream() {
-	//result is unpredictable in stream mode
-	if mode := r.sheet.mode(); (mode & SheetModeStream) != 0 {
+	//result is unpredictable in stream mode or for rows at disk cache
+	if mode := r.sheet.mode(); (mode & (SheetModeStream | SheetModeDiskCache)) != 0 {
 		panic(errorNotSupportedStream)
 	}
 }
@@ -112,40 +187,73 @@ func (r *Range) CopyTo(cIdx, rIdx int) {
 
 					//refresh ref
 					target.ml.Ref = types.CellRefFromIndexes(cIdxTarget, rIdxTarget)
+					r.sheet.info().changes.add(target)
 				}
 			}
 		})
 	}
 }
 
-//Merge merges range
-func (r *Range) Merge() error {
+//SetArrayFormula sets array formula for range, e.g.: sheet.Range("C1:C3").SetArrayFormula("A1:A3*B1:B3")
+func (r *Range) SetArrayFormula(formula string) {
+	r.sheet.info().formulas.setArray(formula, r.bounds)
+}
+
+//SetSharedFormula sets formula for the top left cell of range and shares it with other cells using shifted references, e.g.: sheet.Range("C1:C3").SetSharedFormula("A1*B1")
+func (r *Range) SetSharedFormula(formula string) {
+	r.sheet.info().formulas.setShared(formula, r.bounds)
+}
+
+//Merge merges range. By default, the first value is moved into the top left cell and other cells are cleared (Excel behavior), e.g.: Merge(MergeKeepValues) keeps values of all cells
+func (r *Range) Merge(options ...MergeOption) error {
 	//stream is not supported for copying cell's info
 	r.ensureNotStream()
 
-	if err := r.sheet.info().mergedCells.Add(r.bounds); err != nil {
+	mergedCells := r.sheet.info().mergedCells
+	if err := mergedCells.validate(r.bounds); err != nil {
 		return err
 	}
 
-	//we should reset cells and copy first cell with value into the first cell of that range (Excel behavior)
-	copied := false
-	r.Walk(func(idx, cIdx, rIdx int, c *Cell) {
-		//if there is a value and it was not copied yet, then do it
-		if !copied && len(c.ml.Value) > 0 {
+	option := MergeMoveValue
+	for _, o := range options {
+		option = o
+	}
+
+	//N.B.: cells must be processed before merging, because cells of merged range are resolved into the top left cell
+	switch option {
+	case MergeClearValues:
+		r.Walk(func(idx, cIdx, rIdx int, c *Cell) {
 			if idx > 0 {
-				target := r.sheet.Cell(r.bounds.FromCol, r.bounds.FromRow)
-				*target.ml = *c.ml
-				target.ml.Ref = types.CellRefFromIndexes(r.bounds.FromCol, r.bounds.FromRow)
+				c.Reset()
+			} else {
+				c.resetFormula()
+				*c.ml = ml.Cell{Ref: c.ml.Ref, Style: c.ml.Style}
+				r.sheet.info().changes.add(c)
 			}
+		})
+	case MergeMoveValue:
+		//we should reset cells and copy first cell with value into the first cell of that range (Excel behavior)
+		copied := false
+		r.Walk(func(idx, cIdx, rIdx int, c *Cell) {
+			//if there is a value and it was not copied yet, then do it
+			if !copied && len(c.ml.Value) > 0 {
+				if idx > 0 {
+					target := r.sheet.Cell(r.bounds.FromCol, r.bounds.FromRow)
+					*target.ml = *c.ml
+					target.ml.Ref = types.CellRefFromIndexes(r.bounds.FromCol, r.bounds.FromRow)
+					r.sheet.info().changes.add(target)
+					c.Reset()
+				}
 
-			copied = true
-		} else {
-			//cleanup rest info
-			c.Reset()
-		}
-	})
+				copied = true
+			} else {
+				//cleanup rest info
+				c.Reset()
+			}
+		})
+	}
 
-	return nil
+	return mergedCells.Add(r.bounds)
 }
 
 //Split splits cells in range
@@ -159,7 +267,7 @@ func (r *Range) SetHyperlink(link interface{}) error {
 		return err
 	} else {
 		r.Walk(func(idx, cIdx, rIdx int, c *Cell) {
-			c.SetFormatting(styleID)
+			r.sheet.info().hyperlinks.applyStyle(c, styleID)
 		})
 	}
 
@@ -0,0 +1,194 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/formula"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/plandem/xlsx/types"
+)
+
+//CopyOption is a type of option to control copying and moving of cells
+type CopyOption byte
+
+//List of all possible options to control copying and moving of cells
+const (
+	CopyTranspose CopyOption = 1 << iota //transposes rows and cols of range
+)
+
+//copiedCell is a snapshot of source cell
+type copiedCell struct {
+	cIdx, rIdx int
+	ml         *ml.Cell
+	formula    string
+}
+
+//rangeCopy holds information required to copy or move range of cells
+type rangeCopy struct {
+	sheet       *sheetInfo
+	source      types.Bounds
+	cIdx, rIdx  int
+	transpose   bool
+	cells       []copiedCell
+	mergedCells []types.Bounds
+	hyperlinks  []ml.Hyperlink
+}
+
+//newRangeCopy creates an object that copies cells of source bounds to cells starting with cIdx and rIdx
+func newRangeCopy(sheet *sheetInfo, source types.Bounds, target types.CellRef, options []CopyOption) (*rangeCopy, error) {
+	c := &rangeCopy{sheet: sheet, source: source}
+	c.cIdx, c.rIdx = target.ToIndexes()
+
+	for _, o := range options {
+		c.transpose = c.transpose || (o&CopyTranspose) != 0
+	}
+
+	if bounds := c.target(); bounds.ToCol >= internal.ExcelColumnLimit || bounds.ToRow >= internal.ExcelRowLimit {
+		return nil, errors.New(fmt.Sprintf("range %s does not fit into the sheet at %s", source, target))
+	}
+
+	return c, nil
+}
+
+//mapIndexes returns indexes of target cell for indexes of source cell
+func (c *rangeCopy) mapIndexes(cIdx, rIdx int) (int, int) {
+	cOffset, rOffset := cIdx-c.source.FromCol, rIdx-c.source.FromRow
+	if c.transpose {
+		cOffset, rOffset = rOffset, cOffset
+	}
+
+	return c.cIdx + cOffset, c.rIdx + rOffset
+}
+
+//mapBounds returns bounds of target for bounds of source
+func (c *rangeCopy) mapBounds(bounds types.Bounds) types.Bounds {
+	fromCol, fromRow := c.mapIndexes(bounds.FromCol, bounds.FromRow)
+	toCol, toRow := c.mapIndexes(bounds.ToCol, bounds.ToRow)
+	return types.BoundsFromIndexes(fromCol, fromRow, toCol, toRow)
+}
+
+//target returns bounds of target
+func (c *rangeCopy) target() types.Bounds {
+	return c.mapBounds(c.source)
+}
+
+//contains returns true if source contains bounds entirely
+func (c *rangeCopy) contains(bounds types.Bounds) bool {
+	return c.source.Contains(bounds.FromCol, bounds.FromRow) && c.source.Contains(bounds.ToCol, bounds.ToRow)
+}
+
+//snapshot saves information of source cells, merged cells and hyperlinks, so source and target can overlap
+func (c *rangeCopy) snapshot() {
+	//expand grid to required size
+	c.sheet.sheet.Cell(c.source.ToCol, c.source.ToRow)
+
+	for rIdx := c.source.FromRow; rIdx <= c.source.ToRow; rIdx++ {
+		for cIdx := c.source.FromCol; cIdx <= c.source.ToCol; cIdx++ {
+			copied := copiedCell{cIdx: cIdx, rIdx: rIdx}
+
+			//N.B.: grid is used directly, because cells of merged range are resolved into the top left cell
+			if cell := c.sheet.ml.SheetData[rIdx].Cells[cIdx]; !isCellEmpty(cell) {
+				data := *cell
+				copied.ml = &data
+				copied.formula = c.sheet.formulas.resolve(cell)
+			}
+
+			c.cells = append(c.cells, copied)
+		}
+	}
+
+	for _, mc := range c.sheet.ml.MergeCells.Items {
+		if c.contains(mc.Bounds) {
+			c.mergedCells = append(c.mergedCells, mc.Bounds)
+		}
+	}
+
+	for _, link := range c.sheet.ml.Hyperlinks.Items {
+		if c.contains(link.Bounds) {
+			c.hyperlinks = append(c.hyperlinks, *link)
+		}
+	}
+}
+
+//clear removes cells, merged cells and hyperlinks of bounds
+func (c *rangeCopy) clear(bounds types.Bounds) {
+	c.sheet.mergedCells.Remove(bounds)
+	c.sheet.hyperlinks.Remove(bounds)
+
+	for rIdx := bounds.FromRow; rIdx <= bounds.ToRow; rIdx++ {
+		for cIdx := bounds.FromCol; cIdx <= bounds.ToCol; cIdx++ {
+			c.sheet.sheet.Cell(cIdx, rIdx).Reset()
+		}
+	}
+}
+
+//paste writes saved information into the target. If shift is true, then relative references of formulas are shifted
+func (c *rangeCopy) paste(shift bool) error {
+	c.clear(c.target())
+
+	for _, copied := range c.cells {
+		if copied.ml == nil {
+			continue
+		}
+
+		cIdx, rIdx := c.mapIndexes(copied.cIdx, copied.rIdx)
+		target := c.sheet.sheet.Cell(cIdx, rIdx)
+		*target.ml = *copied.ml
+		target.ml.Ref = types.CellRefFromIndexes(cIdx, rIdx)
+
+		if copied.ml.Formula != nil {
+			expression := copied.formula
+			if shift {
+				expression = formula.Shift(expression, cIdx-copied.cIdx, rIdx-copied.rIdx)
+			}
+
+			//N.B.: cells of shared formula become cells with normal formula
+			target.ml.Formula = &ml.CellFormula{Content: expression}
+			if copied.ml.Formula.T == primitives.CellFormulaTypeArray {
+				target.ml.Formula.T = primitives.CellFormulaTypeArray
+				target.ml.Formula.Bounds = c.mapBounds(copied.ml.Formula.Bounds)
+			}
+		}
+
+		c.sheet.changes.add(target)
+	}
+
+	for _, bounds := range c.mergedCells {
+		if err := c.sheet.mergedCells.Add(c.mapBounds(bounds)); err != nil {
+			return err
+		}
+	}
+
+	for _, link := range c.hyperlinks {
+		link := link
+		link.Bounds = c.mapBounds(link.Bounds)
+		c.sheet.ml.Hyperlinks.Items = append(c.sheet.ml.Hyperlinks.Items, &link)
+	}
+
+	return nil
+}
+
+//CopyRange copies cells with styles, merged cells and hyperlinks of source range into the target starting with cell ref. Relative references of formulas are shifted, e.g.: CopyRange("A1:B2", "D1", xlsx.CopyTranspose)
+func (s *sheetInfo) CopyRange(source types.Ref, target types.CellRef, options ...CopyOption) error {
+	c, err := newRangeCopy(s, source.ToBounds(), target, options)
+	if err != nil {
+		return err
+	}
+
+	c.snapshot()
+	return c.paste(true)
+}
+
+//MoveRange moves cells with styles, merged cells and hyperlinks of source range into the target starting with cell ref. Formulas of moved cells are kept as is (Excel behavior)
+func (s *sheetInfo) MoveRange(source types.Ref, target types.CellRef, options ...CopyOption) error {
+	c, err := newRangeCopy(s, source.ToBounds(), target, options)
+	if err != nil {
+		return err
+	}
+
+	c.snapshot()
+	c.clear(c.source)
+	return c.paste(false)
+}
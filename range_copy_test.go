@@ -0,0 +1,66 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCopyRange(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Copy")
+	bold := xl.AddFormatting(format.NewStyles(format.Font.Bold))
+
+	sheet.CellByRef("A1").SetValue(1)
+	sheet.CellByRef("A1").SetFormatting(bold)
+	sheet.CellByRef("B1").SetValue(2)
+	sheet.CellByRef("A2").SetFormula("A1+$B$1")
+	sheet.Range("B2:B3").SetSharedFormula("A1*2")
+	require.Nil(t, sheet.Range("A3").SetHyperlink("https://github.com/plandem/xlsx"))
+	require.Nil(t, sheet.Range("A4:B4").Merge())
+
+	//copy
+	require.Nil(t, sheet.CopyRange("A1:B4", "D2"))
+	require.Equal(t, "1", sheet.CellByRef("D2").Value())
+	require.Equal(t, bold, sheet.CellByRef("D2").Formatting())
+	require.Equal(t, "2", sheet.CellByRef("E2").Value())
+	require.Equal(t, "D2+$B$1", sheet.CellByRef("D3").Formula())
+	require.Equal(t, "D2*2", sheet.CellByRef("E3").Formula())
+	require.Equal(t, "D3*2", sheet.CellByRef("E4").Formula())
+	require.Equal(t, "https://github.com/plandem/xlsx", sheet.CellByRef("D4").Hyperlink().String())
+	require.Equal(t, []types.Bounds{types.BoundsFromIndexes(0, 3, 1, 3), types.BoundsFromIndexes(3, 4, 4, 4)}, sheet.MergedCells())
+
+	//source is not changed
+	require.Equal(t, "A1*2", sheet.CellByRef("B2").Formula())
+	require.Equal(t, "A2*2", sheet.CellByRef("B3").Formula())
+
+	//references out of sheet
+	require.Nil(t, sheet.CopyRange("A2", "A1"))
+	require.Equal(t, "#REF!+$B$1", sheet.CellByRef("A1").Formula())
+
+	//transpose
+	require.Nil(t, sheet.CopyRange("D2:E3", "H5", CopyTranspose))
+	require.Equal(t, "1", sheet.CellByRef("H5").Value())
+	require.Equal(t, "2", sheet.CellByRef("H6").Value())
+	require.Equal(t, "I4+$B$1", sheet.CellByRef("I5").Formula())
+	require.Equal(t, "H5*2", sheet.CellByRef("I6").Formula())
+
+	//move
+	require.Nil(t, sheet.MoveRange("D2:E4", "D10"))
+	require.Equal(t, "", sheet.CellByRef("D2").Value())
+	require.Equal(t, format.DefaultDirectStyle, sheet.CellByRef("D2").Formatting())
+	require.Nil(t, sheet.CellByRef("D4").Hyperlink())
+	require.Equal(t, "1", sheet.CellByRef("D10").Value())
+	require.Equal(t, "D2+$B$1", sheet.CellByRef("D11").Formula())
+	require.Equal(t, "https://github.com/plandem/xlsx", sheet.CellByRef("D12").Hyperlink().String())
+
+	//overlapping move
+	require.Nil(t, sheet.MoveRange("D10:E11", "E10"))
+	require.Equal(t, "1", sheet.CellByRef("E10").Value())
+	require.Equal(t, "2", sheet.CellByRef("F10").Value())
+	require.Equal(t, "", sheet.CellByRef("D10").Value())
+
+	//out of sheet
+	require.NotNil(t, sheet.CopyRange("A1:B2", "XFD1"))
+}
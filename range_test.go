@@ -41,3 +41,38 @@ func TestRange(t *testing.T) {
 	require.Equal(t, format.DirectStyleID(0), sheet.CellByRef("D10").ml.Style)
 	require.Equal(t, format.DirectStyleID(0), sheet.CellByRef("E10").ml.Style)
 }
+
+func TestRange_bulk(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Bulk")
+	r := sheet.Range("B2:C3")
+	r.SetValues([][]interface{}{
+		{"Name", "Price"},
+		{"Apple", 1.5, "ignored"},
+		{"ignored"},
+	})
+	require.Equal(t, []string{"Name", "Price", "Apple", "1.5"}, r.Values())
+	require.Equal(t, "", sheet.CellByRef("D3").Value())
+	require.Equal(t, "", sheet.CellByRef("B4").Value())
+
+	r.SetValues([][]interface{}{{nil, "Cost"}})
+	require.Equal(t, []string{"Name", "Cost", "Apple", "1.5"}, r.Values())
+
+	styleID := r.SetStyles(format.NewStyles(format.Font.Bold))
+	require.Equal(t, styleID, sheet.CellByRef("B2").Formatting())
+	require.Equal(t, styleID, sheet.CellByRef("C3").Formatting())
+
+	r.Fill("x")
+	require.Equal(t, []string{"x", "x", "x", "x"}, r.Values())
+
+	sheet.Range("A1:A4").FillSeries(1, 2)
+	require.Equal(t, []string{"1", "3", "5", "7"}, sheet.Range("A1:A4").Values())
+
+	sheet.Range("A1:B2").FillSeries(0, 0.5)
+	require.Equal(t, []string{"0", "0.5", "1", "1.5"}, sheet.Range("A1:B2").Values())
+
+	r.Clear()
+	require.Equal(t, []string{"", "", "", ""}, r.Values())
+}
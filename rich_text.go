@@ -3,8 +3,10 @@ package xlsx
 import (
 	"errors"
 	"fmt"
+	sharedML "github.com/plandem/ooxml/ml"
 	"github.com/plandem/xlsx/format"
 	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/color"
 	"github.com/plandem/xlsx/internal/ml"
 	"github.com/plandem/xlsx/internal/ml/primitives"
 	_ "unsafe"
@@ -13,6 +15,100 @@ import (
 //go:linkname toRichFont github.com/plandem/xlsx/format.toRichFont
 func toRichFont(f *format.StyleFormat) *ml.RichFont
 
+//RichTextRun is a fragment of rich text with own font settings. Empty settings mean default font of cell
+type RichTextRun struct {
+	Text      string
+	Font      string
+	Size      float64
+	Color     string
+	Bold      bool
+	Italic    bool
+	Strike    bool
+	Underline primitives.UnderlineType
+	VAlign    primitives.FontVAlignType
+}
+
+//hasFont returns true if run has any font settings
+func (r *RichTextRun) hasFont() bool {
+	return len(r.Font) > 0 || r.Size > 0 || len(r.Color) > 0 || r.Bold || r.Italic || r.Strike || len(r.Underline) > 0 || len(r.VAlign) > 0
+}
+
+//toRichFont returns font settings of run as ml.RichFont or nil if there are no settings
+func (r *RichTextRun) toRichFont() *ml.RichFont {
+	if !r.hasFont() {
+		return nil
+	}
+
+	font := &ml.RichFont{
+		Name:      sharedML.Property(r.Font),
+		Size:      sharedML.PropertyDouble(r.Size),
+		Bold:      sharedML.PropertyBool(r.Bold),
+		Italic:    sharedML.PropertyBool(r.Italic),
+		Strike:    sharedML.PropertyBool(r.Strike),
+		Underline: r.Underline,
+		VAlign:    r.VAlign,
+	}
+
+	if len(r.Color) > 0 {
+		font.Color = color.New(r.Color)
+	}
+
+	return font
+}
+
+//toRichTextRuns converts runs into ml.StringItem
+func toRichTextRuns(runs ...RichTextRun) (*ml.StringItem, error) {
+	richText := make([]*ml.RichText, 0, len(runs))
+	length := 0
+
+	for i := range runs {
+		length += len(runs[i].Text)
+		richText = append(richText, &ml.RichText{
+			Text: primitives.Text(runs[i].Text),
+			Font: runs[i].toRichFont(),
+		})
+	}
+
+	if length > internal.ExcelCellLimit {
+		return nil, errors.New(fmt.Sprintf("text exceeds allowed length for cell value = %d", internal.ExcelCellLimit))
+	}
+
+	return &ml.StringItem{RichText: &richText}, nil
+}
+
+//fromRichTextRuns converts ml.StringItem into runs, theme colors are resolved via palette
+func fromRichTextRuns(text *ml.StringItem, palette ...string) []RichTextRun {
+	if text == nil {
+		return nil
+	}
+
+	var runs []RichTextRun
+	if len(text.Text) > 0 {
+		runs = append(runs, RichTextRun{Text: string(text.Text)})
+	}
+
+	if text.RichText != nil {
+		for _, part := range *text.RichText {
+			run := RichTextRun{Text: string(part.Text)}
+
+			if font := part.Font; font != nil {
+				run.Font = string(font.Name)
+				run.Size = float64(font.Size)
+				run.Color = color.ToThemedRGB(font.Color, palette)
+				run.Bold = bool(font.Bold)
+				run.Italic = bool(font.Italic)
+				run.Strike = bool(font.Strike)
+				run.Underline = font.Underline
+				run.VAlign = font.VAlign
+			}
+
+			runs = append(runs, run)
+		}
+	}
+
+	return runs
+}
+
 func toRichText(parts ...interface{}) (*ml.StringItem, error) {
 	si := &ml.StringItem{}
 	length := 0
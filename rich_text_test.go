@@ -96,3 +96,67 @@ func TestFromRichText(t *testing.T) {
 
 	require.Equal(t, "", fromRichText(nil))
 }
+
+func TestRichTextRuns(t *testing.T) {
+	text, err := toRichTextRuns(
+		RichTextRun{Text: "plain "},
+		RichTextRun{Text: "bold", Bold: true, Color: "#FF1122"},
+		RichTextRun{Text: " small", Size: 8, Font: "Arial", Underline: format.UnderlineTypeSingle},
+	)
+
+	require.Nil(t, err)
+	require.Equal(t, &ml.StringItem{
+		RichText: &[]*ml.RichText{
+			{
+				Text: "plain ",
+			},
+			{
+				Text: "bold",
+				Font: &ml.RichFont{
+					Bold:  true,
+					Color: &ml.Color{RGB: "FFFF1122"},
+				},
+			},
+			{
+				Text: " small",
+				Font: &ml.RichFont{
+					Name:      "Arial",
+					Size:      8,
+					Underline: format.UnderlineTypeSingle,
+				},
+			},
+		},
+	}, text)
+
+	require.Equal(t, []RichTextRun{
+		{Text: "plain "},
+		{Text: "bold", Bold: true, Color: "#FF1122"},
+		{Text: " small", Size: 8, Font: "Arial", Underline: format.UnderlineTypeSingle},
+	}, fromRichTextRuns(text))
+
+	require.Equal(t, []RichTextRun{{Text: "plain"}}, fromRichTextRuns(&ml.StringItem{Text: "plain"}))
+	require.Nil(t, fromRichTextRuns(nil))
+}
+
+func TestCell_RichText(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("rich text")
+
+	runs := []RichTextRun{
+		{Text: "red", Color: "#FF0000", Bold: true},
+		{Text: " and plain"},
+	}
+
+	c := sheet.CellByRef("A1")
+	require.Nil(t, c.SetRichText(runs...))
+	require.Equal(t, "red and plain", c.Value())
+	require.Equal(t, runs, c.RichText())
+
+	c = sheet.CellByRef("A2")
+	require.Nil(t, c.SetInlineText(format.NewStyles(format.Font.Italic), "italic", "plain"))
+	require.Equal(t, []RichTextRun{{Text: "italic", Italic: true}, {Text: "plain"}}, c.RichText())
+
+	c = sheet.CellByRef("A3")
+	c.SetInt(1)
+	require.Nil(t, c.RichText())
+}
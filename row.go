@@ -10,6 +10,7 @@ import (
 type Row struct {
 	ml *ml.Row
 	*Range
+	styles *resolvedStyles
 }
 
 //Cell returns cell of row at col with colIndex
@@ -17,17 +18,54 @@ func (r *Row) Cell(colIndex int) *Cell {
 	return r.sheet.Cell(colIndex, r.bounds.FromRow)
 }
 
+//StyledCells returns iterator for non empty cells of row with resolved styles and detected kinds of values. Cells covered by merged cells are skipped
+func (r *Row) StyledCells() StyledCellIterator {
+	return newStyledCellIterator(r.Range, r.styles)
+}
+
 //Set sets options for row
 func (r *Row) Set(o *options.RowOptions) {
+	setRowOptions(r.ml, o)
+}
+
+//Options returns options of row
+func (r *Row) Options() *options.RowOptions {
+	return &options.RowOptions{
+		OutlineLevel: r.ml.OutlineLevel,
+		Collapsed:    r.ml.Collapsed,
+		Phonetic:     r.ml.Phonetic,
+		Hidden:       r.ml.Hidden,
+		Height:       r.ml.Height,
+		Style:        r.ml.Style,
+	}
+}
+
+//setRowOptions applies options to the row's settings
+func setRowOptions(data *ml.Row, o *options.RowOptions) {
 	if o.Height > 0 {
-		r.ml.Height = o.Height
-		r.ml.CustomHeight = true
+		data.Height = o.Height
+		data.CustomHeight = true
 	}
 
-	r.ml.OutlineLevel = o.OutlineLevel
-	r.ml.Hidden = o.Hidden
-	r.ml.Collapsed = o.Collapsed
-	r.ml.Phonetic = o.Phonetic
+	if o.Style > 0 {
+		data.Style = o.Style
+		data.CustomFormat = true
+	}
+
+	data.OutlineLevel = o.OutlineLevel
+	data.Hidden = o.Hidden
+	data.Collapsed = o.Collapsed
+	data.Phonetic = o.Phonetic
+}
+
+//OutlineLevel returns outline level of row
+func (r *Row) OutlineLevel() uint8 {
+	return r.ml.OutlineLevel
+}
+
+//IsCollapsed returns true if outlining of group that is summarized by row is in the collapsed state
+func (r *Row) IsCollapsed() bool {
+	return r.ml.Collapsed
 }
 
 //Formatting returns DirectStyleID of default format for row
@@ -0,0 +1,53 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/options"
+)
+
+//validateBulkRange checks if indexes are valid for bulk update of rows or columns
+func validateBulkRange(fromIndex, toIndex int, limit int) error {
+	if fromIndex < 0 || fromIndex > toIndex || toIndex >= limit {
+		return errors.New(fmt.Sprintf("invalid range of indexes: %d-%d", fromIndex, toIndex))
+	}
+
+	return nil
+}
+
+//SetRowsOptions sets options for rows between fromIndex and toIndex
+func (s *sheetInfo) SetRowsOptions(fromIndex, toIndex int, o *options.RowOptions) error {
+	if err := validateBulkRange(fromIndex, toIndex, internal.ExcelRowLimit); err != nil {
+		return err
+	}
+
+	for rIdx := fromIndex; rIdx <= toIndex; rIdx++ {
+		setRowOptions(s.sheet.Row(rIdx).ml, o)
+	}
+
+	return nil
+}
+
+//SetColsOptions sets options for cols between fromIndex and toIndex. Cols with same settings are stored as a single range, rather than settings per each col
+func (s *sheetInfo) SetColsOptions(fromIndex, toIndex int, o *options.ColumnOptions) error {
+	if err := validateBulkRange(fromIndex, toIndex, internal.ExcelColumnLimit); err != nil {
+		return err
+	}
+
+	s.columns.Update(fromIndex, toIndex, func(c *ml.Col) {
+		setColOptions(c, o)
+	})
+
+	return nil
+}
+
+//ColOptions returns options of col with index. Unlike Col(index).Options(), settings of cols are not affected
+func (s *sheetInfo) ColOptions(index int) *options.ColumnOptions {
+	if c := s.columns.Get(index); c != nil {
+		return colOptions(c)
+	}
+
+	return &options.ColumnOptions{}
+}
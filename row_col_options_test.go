@@ -0,0 +1,58 @@
+package xlsx
+
+import (
+	"encoding/xml"
+	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/options"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRowColOptions(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Report")
+
+	//invalid ranges
+	require.NotNil(t, sheet.SetColsOptions(3, 1, options.NewColumnOptions(options.Column.Hidden(true))))
+	require.NotNil(t, sheet.SetRowsOptions(-1, 1, options.NewRowOptions(options.Row.Hidden(true))))
+
+	//cols are stored as ranges
+	sheet.Col(2).Set(options.NewColumnOptions(options.Column.Width(20)))
+	require.Nil(t, sheet.SetColsOptions(0, 9999, options.NewColumnOptions(options.Column.Hidden(true))))
+	require.Nil(t, sheet.SetColsOptions(5, 6, options.NewColumnOptions(options.Column.Width(30), options.Column.BestFit(true), options.Column.Style(format.DirectStyleID(1)))))
+
+	encoded, err := xml.Marshal(&sheet.info().ml.Cols)
+	require.Nil(t, err)
+	require.Equal(t, `<ColList><col min="1" max="2" hidden="true"></col><col min="3" max="3" width="20" hidden="true" customWidth="true"></col><col min="4" max="5" hidden="true"></col><col min="6" max="7" width="30" style="1" bestFit="true" customWidth="true"></col><col min="8" max="10000" hidden="true"></col></ColList>`, string(encoded))
+
+	require.Equal(t, &options.ColumnOptions{Hidden: true}, sheet.ColOptions(100))
+	require.Equal(t, &options.ColumnOptions{Width: 30, BestFit: true, Style: format.DirectStyleID(1)}, sheet.ColOptions(6))
+	require.Equal(t, &options.ColumnOptions{}, sheet.ColOptions(10000))
+	require.Equal(t, 5, len(sheet.info().ml.Cols.Items))
+
+	//rows
+	require.Nil(t, sheet.SetRowsOptions(1, 3, options.NewRowOptions(options.Row.Height(30), options.Row.Hidden(true))))
+	require.Equal(t, &options.RowOptions{Height: 30, Hidden: true}, sheet.Row(2).Options())
+	require.Equal(t, &options.RowOptions{}, sheet.Row(0).Options())
+
+	//save and reopen
+	err = xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	require.Equal(t, 5, len(sheet.info().ml.Cols.Items))
+	require.Equal(t, &options.ColumnOptions{Width: 20, Hidden: true}, sheet.ColOptions(2))
+	require.Equal(t, &options.ColumnOptions{Hidden: true}, sheet.Col(5000).Options())
+	require.Equal(t, &options.RowOptions{Height: 30, Hidden: true}, sheet.Row(3).Options())
+
+	//unhide cols, cols without settings are removed
+	require.Nil(t, sheet.SetColsOptions(0, 9999, options.NewColumnOptions()))
+	encoded, err = xml.Marshal(&sheet.info().ml.Cols)
+	require.Nil(t, err)
+	require.Equal(t, `<ColList><col min="3" max="3" width="20" customWidth="true"></col><col min="6" max="7" width="30" style="1" customWidth="true"></col></ColList>`, string(encoded))
+}
@@ -11,9 +11,10 @@ type RowIterator interface {
 
 //rowIterator is object that holds required information for common row's iterator
 type rowIterator struct {
-	idx   int
-	max   int
-	sheet Sheet
+	idx    int
+	max    int
+	sheet  Sheet
+	styles *resolvedStyles
 }
 
 var _ RowIterator = (*rowIterator)(nil)
@@ -21,16 +22,21 @@ var _ RowIterator = (*rowIterator)(nil)
 func newRowIterator(sheet Sheet) RowIterator {
 	_, rows := sheet.Dimension()
 	return &rowIterator{
-		idx:   -1,
-		max:   rows - 1,
-		sheet: sheet,
+		idx:    -1,
+		max:    rows - 1,
+		sheet:  sheet,
+		styles: &resolvedStyles{},
 	}
 }
 
 //Next returns next Row in sheet and corresponding index
 func (i *rowIterator) Next() (int, *Row) {
 	i.idx++
-	return i.idx, i.sheet.Row(i.idx)
+
+	//resolved styles are shared between rows of sheet
+	row := i.sheet.Row(i.idx)
+	row.styles = i.styles
+	return i.idx, row
 }
 
 //HasNext returns true if there are rows to iterate or false in other case
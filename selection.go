@@ -0,0 +1,83 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/plandem/xlsx/types"
+)
+
+//activeSelection returns selection of active pane of view or nil if there is no any selection
+func activeSelection(view *ml.SheetView) *ml.Selection {
+	pane := ""
+	if view.Pane != nil {
+		pane = view.Pane.ActivePane
+	}
+
+	for _, selection := range view.Selection {
+		//N.B.: topLeft is a default pane
+		if selection.Pane == pane || (pane == "" && selection.Pane == "topLeft") {
+			return selection
+		}
+	}
+
+	return nil
+}
+
+//SetSelection sets active cell and selected ranges of sheet, e.g.: SetSelection("B2", "A1:C3", "E5"). If there is no any ref, then only active cell is selected. For frozen or split panes, selection of active pane is updated
+func (s *sheetInfo) SetSelection(active types.CellRef, refs ...types.Ref) error {
+	cIdx, rIdx := active.ToIndexes()
+	if cIdx < 0 || rIdx < 0 {
+		return errors.New(fmt.Sprintf("invalid active cell %s", active))
+	}
+
+	if len(refs) == 0 {
+		refs = []types.Ref{types.Ref(active)}
+	}
+
+	bounds := primitives.BoundsListFromRefs(refs...)
+	activeID := -1
+	for i, b := range bounds {
+		if b.Contains(cIdx, rIdx) {
+			activeID = i
+			break
+		}
+	}
+
+	if activeID < 0 {
+		return errors.New(fmt.Sprintf("active cell %s must be inside of selected ranges %s", active, bounds))
+	}
+
+	view := s.sheetView()
+	selection := activeSelection(view)
+	if selection == nil {
+		selection = &ml.Selection{}
+		if view.Pane != nil {
+			selection.Pane = view.Pane.ActivePane
+		}
+
+		view.Selection = append(view.Selection, selection)
+	}
+
+	selection.ActiveCell = active
+	selection.ActiveCellID = uint(activeID)
+	selection.Bounds = bounds
+	return nil
+}
+
+//Selection returns active cell and selected ranges of sheet. By default, A1 is active and selected
+func (s *sheetInfo) Selection() (types.CellRef, []types.Ref) {
+	if len(s.ml.SheetViews.Items) > 0 {
+		if selection := activeSelection(s.ml.SheetViews.Items[0]); selection != nil && len(selection.ActiveCell) > 0 {
+			refs := make([]types.Ref, 0, len(selection.Bounds))
+			for _, b := range selection.Bounds {
+				refs = append(refs, b.ToRef())
+			}
+
+			return selection.ActiveCell, refs
+		}
+	}
+
+	return "A1", []types.Ref{"A1"}
+}
@@ -8,6 +8,15 @@ import (
 	"github.com/plandem/xlsx/internal/ml/primitives"
 )
 
+//StringsMode is a type of mode to store strings of cells
+type StringsMode byte
+
+//List of all possible modes to store strings of cells
+const (
+	StringsShared StringsMode = iota //strings are deduplicated and stored in shared strings table
+	StringsInline                    //strings are stored inline in cells, faster writes and streaming-friendly
+)
+
 //SharedStrings is a higher level object that wraps ml.SharedStrings with functionality
 type SharedStrings struct {
 	ml    ml.SharedStrings
@@ -41,6 +50,9 @@ func (ss *SharedStrings) afterLoad() {
 
 //get returns string item stored at index
 func (ss *SharedStrings) get(index int) *ml.StringItem {
+	ss.doc.lock()
+	defer ss.doc.unlock()
+
 	ss.file.LoadIfRequired(ss.afterLoad)
 
 	if index < len(ss.ml.StringItem) {
@@ -55,8 +67,21 @@ func (ss *SharedStrings) addString(value string) int {
 	return ss.addText(&ml.StringItem{Text: primitives.Text(value)})
 }
 
+//inline returns a copy of string item stored at index, that can be used as inline string
+func (ss *SharedStrings) inline(index int) *ml.StringItem {
+	if si := ss.get(index); si != nil {
+		text := *si
+		return &text
+	}
+
+	return &ml.StringItem{}
+}
+
 //addText adds a new StringItem and return index for it
 func (ss *SharedStrings) addText(si *ml.StringItem) int {
+	ss.doc.lock()
+	defer ss.doc.unlock()
+
 	ss.file.LoadIfRequired(ss.afterLoad)
 
 	key := hash.StringItem(si).Hash()
@@ -4,6 +4,7 @@ import (
 	"github.com/plandem/ooxml"
 	"github.com/plandem/xlsx/internal/hash"
 	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -70,3 +71,39 @@ func TestSharedStrings(t *testing.T) {
 	require.Equal(t, "another value", fromRichText(ss.get(1)))
 	require.Equal(t, "part1part2", fromRichText(ss.get(2)))
 }
+
+func TestStringsMode(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Strings")
+	sheet.CellByRef("A1").SetValue("shared")
+	require.Equal(t, types.CellTypeSharedString, sheet.CellByRef("A1").Type())
+
+	xl.SetStringsMode(StringsInline)
+	sheet.CellByRef("A2").SetValue("inline")
+	require.Nil(t, sheet.CellByRef("A3").SetText("rich", "text"))
+	require.Equal(t, types.CellTypeInlineString, sheet.CellByRef("A2").Type())
+	require.Equal(t, types.CellTypeInlineString, sheet.CellByRef("A3").Type())
+	require.Equal(t, "inline", sheet.CellByRef("A2").Value())
+	require.Equal(t, "richtext", sheet.CellByRef("A3").Value())
+	require.Equal(t, 1, len(xl.sharedStrings.ml.StringItem))
+
+	//shared strings are converted into inline strings during saving
+	require.Nil(t, xl.SaveAs("./test_files/tmp.xlsx"))
+	xl.Close()
+
+	xl, err := Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	require.Equal(t, types.CellTypeInlineString, sheet.CellByRef("A1").Type())
+	require.Equal(t, "shared", sheet.CellByRef("A1").Value())
+	require.Equal(t, "inline", sheet.CellByRef("A2").Value())
+	require.Equal(t, "richtext", sheet.CellByRef("A3").Value())
+
+	//switching back to shared strings
+	xl.SetStringsMode(StringsShared)
+	sheet.CellByRef("A2").SetValue("inline")
+	require.Equal(t, types.CellTypeSharedString, sheet.CellByRef("A2").Type())
+	require.Equal(t, "inline", sheet.CellByRef("A2").Value())
+}
@@ -1,9 +1,20 @@
 package xlsx
 
 import (
+	"github.com/plandem/xlsx/chart"
+	"github.com/plandem/xlsx/control"
+	"github.com/plandem/xlsx/errtype"
 	"github.com/plandem/xlsx/format"
 	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/page"
+	"github.com/plandem/xlsx/pivot"
+	"github.com/plandem/xlsx/protection"
+	"github.com/plandem/xlsx/slicer"
+	"github.com/plandem/xlsx/sorting"
+	"github.com/plandem/xlsx/sparkline"
+	"github.com/plandem/xlsx/table"
 	"github.com/plandem/xlsx/types"
+	"io"
 )
 
 const errorNotSupported = "not supported"
@@ -20,6 +31,7 @@ const (
 	SheetModeStream          //In stream mode only forward reading/writing is allowed
 	SheetModeMultiPhase      //Sheet will be iterated two times: first one to load meta information (e.g. merged cells) and another one for sheet data. Only for SheetModeStream mode.
 	SheetModeIgnoreDimension //Ignore dimension information during reading or skip it during writing
+	SheetModeDiskCache       //Rows are kept at temporary file and loaded into memory on demand only, so huge sheets can be updated with small memory footprint. Operations that require all rows in memory are not supported
 )
 
 //Sheet is interface for a higher level object that wraps ml.Worksheet with functionality
@@ -34,6 +46,10 @@ type Sheet interface {
 	Row(index int) *Row
 	//Cols returns iterator for all cols of sheet
 	Cols() ColIterator
+	//Cells returns iterator for cells of used range of sheet or bounds of options limited by used range. Empty cells can be skipped and cells can be iterated column by column. Grid of sheet is not expanded, so cells that are not stored are not added to the sheet
+	Cells(o *options.IterateOptions) RangeIterator
+	//UsedRange returns bounds of non empty cells of sheet, regardless of dimension of sheet
+	UsedRange() types.Bounds
 	//Col returns a col for 0-based index
 	Col(index int) *Col
 	//Range returns a range for ref
@@ -50,10 +66,58 @@ type Sheet interface {
 	InsertCol(index int) *Col
 	//DeleteCol deletes a col at 0-based index
 	DeleteCol(index int)
-	//MergeRows merges rows between fromIndex and toIndex
-	MergeRows(fromIndex, toIndex int) error
-	//MergeCols merges cols between fromIndex and toIndex
-	MergeCols(fromIndex, toIndex int) error
+	//InsertRows inserts n rows at 0-based index and updates formulas, merged cells, hyperlinks, conditional formatting, data validations and defined names that refer to rows after it
+	InsertRows(index, n int)
+	//DeleteRows deletes n rows at 0-based index and updates formulas, merged cells, hyperlinks, conditional formatting, data validations and defined names that refer to deleted rows or rows after it
+	DeleteRows(index, n int)
+	//InsertCols inserts n cols at 0-based index and updates formulas, merged cells, hyperlinks, conditional formatting, data validations and defined names that refer to cols after it
+	InsertCols(index, n int)
+	//DeleteCols deletes n cols at 0-based index and updates formulas, merged cells, hyperlinks, conditional formatting, data validations and defined names that refer to deleted cols or cols after it
+	DeleteCols(index, n int)
+	//MergeRows merges rows between fromIndex and toIndex with optional control of values, e.g.: MergeRows(0, 1, MergeKeepValues)
+	MergeRows(fromIndex, toIndex int, options ...MergeOption) error
+	//MergeCols merges cols between fromIndex and toIndex with optional control of values
+	MergeCols(fromIndex, toIndex int, options ...MergeOption) error
+	//GroupRows groups rows between fromIndex and toIndex with outline level (1-7), collapsed group hides rows. Zero level ungroups rows
+	GroupRows(fromIndex, toIndex int, level uint8, collapsed bool) error
+	//GroupCols groups cols between fromIndex and toIndex with outline level (1-7), collapsed group hides cols. Zero level ungroups cols
+	GroupCols(fromIndex, toIndex int, level uint8, collapsed bool) error
+	//SetRowsOptions sets options for rows between fromIndex and toIndex, e.g.: SetRowsOptions(0, 9, options.NewRowOptions(options.Row.Height(30)))
+	SetRowsOptions(fromIndex, toIndex int, o *options.RowOptions) error
+	//SetColsOptions sets options for cols between fromIndex and toIndex, cols with same settings are stored as a single range
+	SetColsOptions(fromIndex, toIndex int, o *options.ColumnOptions) error
+	//ColOptions returns options of col with index without affecting settings of cols
+	ColOptions(index int) *options.ColumnOptions
+	//AutoFitColumns sets approximate best-fit widths of cols to fit content of cells in bounds. Optional metrics can be used instead of DefaultFontMetrics
+	AutoFitColumns(bounds types.Bounds, metrics ...*FontMetrics) error
+	//WriteStructs writes slice of structs into the sheet - first row is a header with names of fields and each struct is a row after it. Fields can be annotated with `xlsx:"header,format:0.00,width:12"` tag
+	WriteStructs(data interface{}) error
+	//ReadStructs reads rows of sheet into the slice of structs that out points to. First row is a header and fields are mapped to the cols by names of header or by `xlsx:",col:C"` tag
+	ReadStructs(out interface{}) error
+	//ToCSV writes all rows of sheet as records of CSV/TSV. If options is nil, then default options are used
+	ToCSV(w io.Writer, o *options.CSVOptions) error
+	//ToJSON writes cells of sheet as JSON array of arrays or array of objects with keys from header row. If options is nil, then default options are used
+	ToJSON(w io.Writer, o *options.ExportOptions) error
+	//ToHTML writes cells of sheet as HTML table with inline CSS derived from styles of cells. If options is nil, then default options are used
+	ToHTML(w io.Writer, o *options.ExportOptions) error
+	//CopyRange copies cells with styles, merged cells and hyperlinks of source range into the target starting with cell ref. Relative references of formulas are shifted
+	CopyRange(source types.Ref, target types.CellRef, options ...CopyOption) error
+	//MoveRange moves cells with styles, merged cells and hyperlinks of source range into the target starting with cell ref. Formulas of moved cells are kept as is
+	MoveRange(source types.Ref, target types.CellRef, options ...CopyOption) error
+	//Find returns refs of cells with values that match value, where value can be string, *regexp.Regexp or any other value. If options is nil, then default options are used
+	Find(value interface{}, o *options.FindOptions) ([]types.CellRef, error)
+	//Replace replaces matches of old in cells with replacement and returns number of updated cells. If options is nil, then default options are used
+	Replace(old interface{}, replacement string, o *options.FindOptions) (int, error)
+	//Sort sorts rows of bounds by values of columns with keys, e.g.: Sort(bounds, sorting.ByColumn(2, sorting.Desc), sorting.ByColumn(0, sorting.Asc)). Styles, hyperlinks and merged cells of rows are moved together with values
+	Sort(bounds types.Bounds, keys ...*sorting.Key) error
+	//SetOutlineSummary sets position of summary rows and cols. By default, summary rows are below of details and summary cols are at right of details
+	SetOutlineSummary(below bool, right bool)
+	//OutlineSummary returns true for below if summary rows are below of details and true for right if summary cols are at right of details
+	OutlineSummary() (below bool, right bool)
+	//MergedCells returns bounds of all merged cells
+	MergedCells() []types.Bounds
+	//MergedRange returns bounds of merged cells that contain cell with ref. If cell is not merged then false is returned
+	MergedRange(ref types.CellRef) (types.Bounds, bool)
 	//SplitRows splits rows between fromIndex and toIndex
 	SplitRows(fromIndex, toIndex int)
 	//SplitCols splits cols between fromIndex and toIndex
@@ -62,14 +126,122 @@ type Sheet interface {
 	AddConditional(conditional *format.ConditionalFormat, refs ...types.Ref) error
 	//DeleteConditional deletes conditional formatting for refs
 	DeleteConditional(refs ...types.Ref)
+	//ConditionalFormats returns all conditional formatting of sheet with bounds, rules and resolved differential styles
+	ConditionalFormats() []*ConditionalInfo
+	//AddValidation adds data validation for bounds
+	AddValidation(bounds types.Bounds, validation *types.ValidationInfo) error
+	//DeleteValidation deletes data validation for bounds
+	DeleteValidation(bounds types.Bounds)
+	//Validation returns data validation for cell ref or nil if there is no any validation
+	Validation(cellRef types.CellRef) *types.ValidationInfo
+	//AddHyperlinks adds hyperlinks for few bounds at once and sets styles of hyperlinks for cells of bounds. Overlapping of bounds is validated once for all hyperlinks
+	AddHyperlinks(links ...SheetHyperlink) error
+	//Hyperlinks returns all hyperlinks of sheet with resolved targets
+	Hyperlinks() []SheetHyperlink
+	//DeleteHyperlinks deletes all hyperlinks of sheet
+	DeleteHyperlinks()
+	//AddImage adds image with top left corner at cell ref
+	AddImage(cellRef types.CellRef, image io.Reader, o *options.ImageOptions) error
+	//SetBackground sets image as background of sheet, that is tiled across sheet on screen, but is not printed. Nil image removes background
+	SetBackground(image io.Reader) error
+	//AddControl adds form control of type that fits bounds, e.g.: AddControl(bounds, control.CheckBox, control.Text("Done"), control.LinkedCell("C2"))
+	AddControl(bounds types.Bounds, t control.Type, options ...control.Option) error
+	//AddObject embeds file with name and content that is displayed as icon that fits bounds, e.g.: AddObject(bounds, "contract.pdf", file, options.NewObjectOptions(options.Object.Label("Contract")))
+	AddObject(bounds types.Bounds, name string, content io.Reader, o *options.ObjectOptions) error
+	//Objects returns all embedded objects of sheet with extracted content of files
+	Objects() ([]EmbeddedObject, error)
+	//AddChart adds chart that fits bounds
+	AddChart(bounds types.Bounds, info *chart.Info) error
+	//SetAutoFilter sets auto filter for bounds with optional criteria for columns. N.B.: rows are not hidden by criteria until filter will be reapplied in Excel
+	SetAutoFilter(bounds types.Bounds, filters ...*types.FilterInfo) error
+	//AutoFilter returns bounds and criteria of auto filter
+	AutoFilter() (types.Bounds, []*types.FilterInfo)
+	//DeleteAutoFilter deletes auto filter
+	DeleteAutoFilter()
+	//AddTable adds a new table for bounds with the first row as a header. Totals row, if any, will be added right after bounds
+	AddTable(bounds types.Bounds, options ...table.Option) error
+	//Table returns information about table with name or nil if there is no such table
+	Table(name string) *table.Info
+	//Tables returns information about all tables of sheet
+	Tables() []*table.Info
+	//DeleteTable deletes table with name, content of cells will be kept as is
+	DeleteTable(name string)
+	//AddPivotTable adds a new pivot table with top left corner at cellRef for source data with bounds. The first row of source data is a header with names of fields. Pivot table will be populated by Excel during opening
+	AddPivotTable(cellRef types.CellRef, source types.Bounds, options ...pivot.Option) error
+	//AddSlicer adds a new slicer that fits bounds for field of table or pivot table with name at same sheet, e.g.: AddSlicer(bounds, "Sales", "Region", slicer.Style("SlicerStyleDark2"))
+	AddSlicer(bounds types.Bounds, source string, field string, options ...slicer.Option) error
+	//FreezeRows freezes top n rows, e.g.: FreezeRows(1) freezes a header row. Zero value unfreezes rows
+	FreezeRows(n int)
+	//FreezeColumns freezes left n columns. Zero value unfreezes columns
+	FreezeColumns(n int)
+	//SplitPanes splits view of sheet into panes at horizontal position x and vertical position y in 1/20th of a point. Zero values remove split
+	SplitPanes(x, y float64)
+	//AddSparkline adds a group of sparklines of type at location for data, e.g.: AddSparkline("F1:F10", "A1:E10", sparkline.Line, sparkline.Markers) adds a sparkline for each row of data. Data can be at another sheet, e.g.: Data!A1:E10
+	AddSparkline(location types.Ref, data types.Ref, t sparkline.Type, options ...sparkline.Option) error
+	//Sparklines returns information about all groups of sparklines of sheet
+	Sparklines() []*sparkline.Info
+	//SetView sets presentation settings of sheet, e.g.: SetView(options.NewViewOptions(options.View.ZoomScale(150), options.View.ShowGridLines(false))). If options is nil, then default options are used
+	SetView(o *options.ViewOptions)
+	//View returns presentation settings of sheet
+	View() *options.ViewOptions
+	//SetSelection sets active cell and selected ranges of sheet, e.g.: SetSelection("B2", "A1:C3", "E5"). If there is no any ref, then only active cell is selected
+	SetSelection(active types.CellRef, refs ...types.Ref) error
+	//Selection returns active cell and selected ranges of sheet
+	Selection() (types.CellRef, []types.Ref)
+	//SetPageSetup sets page setup and print settings of sheet, e.g.: SetPageSetup(page.Landscape, page.Paper(page.PaperA4), page.FitToPage(1, 0), page.RepeatRows(0, 0)). Settings that are not affected by options are kept as is
+	SetPageSetup(options ...page.Option) error
+	//PageSetup returns page setup and print settings of sheet
+	PageSetup() *page.Info
+	//SetHeaderFooter sets headers and footers of sheet, e.g.: SetHeaderFooter(page.Header("", "Invoice", ""), page.Footer("", "Page "+page.FieldPageNumber+" of "+page.FieldPagesCount, "")). Settings that are not affected by options are kept as is
+	SetHeaderFooter(options ...page.HeaderFooterOption) error
+	//HeaderFooter returns headers and footers of sheet
+	HeaderFooter() *page.HeaderFooter
+	//InsertPageBreak inserts a manual page break of type t before row or column with 0-based index, e.g.: InsertPageBreak(xlsx.PageBreakRow, 20) starts a new page from row 21
+	InsertPageBreak(t PageBreakType, index int) error
+	//RemovePageBreak removes a page break of type t before row or column with 0-based index
+	RemovePageBreak(t PageBreakType, index int)
+	//PageBreaks returns all page breaks of sheet, row breaks first
+	PageBreaks() []PageBreak
+	//IgnoreErrors suppresses warnings (green triangles) of type t for cells of bounds, e.g.: IgnoreErrors(bounds, errtype.NumberStoredAsText|errtype.FormulaRange). Zero type stops ignoring of errors for bounds
+	IgnoreErrors(bounds types.Bounds, t errtype.Type)
+	//IgnoredErrors returns types of errors that are ignored for cell with ref
+	IgnoredErrors(cellRef types.CellRef) errtype.Type
+	//DirtyBounds returns bounds of cells with values or formulas that were changed since opening of sheet or last call of ResetDirtyBounds. Bounds are empty if there are no changes
+	DirtyBounds() types.Bounds
+	//ResetDirtyBounds forgets about changed cells, e.g. after syncing of changes with downstream storage
+	ResetDirtyBounds()
+	//OnChange sets callback that is called after changing of value or formula of any cell of sheet, e.g.: OnChange(func(col, row int, c *xlsx.Cell) { ... }). Use nil to remove callback
+	OnChange(callback ChangeCallback)
+	//Protect protects sheet with password and allowed actions, e.g.: Protect("secret", protection.AllowSort, protection.AllowFilter). Empty password protects sheet without password
+	Protect(password string, options ...protection.Option) error
+	//Unprotect removes protection of sheet
+	Unprotect()
+	//IsProtected returns true if sheet is protected
+	IsProtected() bool
+	//VerifyPassword returns true if sheet is protected and password matches password of protection. Empty password matches protection without password
+	VerifyPassword(password string) bool
+	//ChangePassword changes password of protected sheet and keeps allowed actions as is. Empty password removes password, but keeps protection
+	ChangePassword(password string) error
+	//DefineName adds a new or updates existing sheet-level defined name with formula
+	DefineName(name string, formula string) error
+	//DefinedName returns formula of sheet-level defined name or empty string if there is no such name
+	DefinedName(name string) string
+	//DeleteName deletes sheet-level defined name
+	DeleteName(name string)
+	//DefinedNames returns names of all sheet-level defined names
+	DefinedNames() []string
+	//RegisterExtension registers custom marshaler of extension for extLst of sheet, e.g. vendor-specific data that is not supported by library. Existing extension with same uri is unmarshaled right away and markup of extension is updated during saving
+	RegisterExtension(marshaler ExtensionMarshaler) error
 	//Name returns name of sheet
 	Name() string
-	//SetName sets a name for sheet
+	//SetName sets a name for sheet and updates formulas, defined names and hyperlinks that refer to sheet
 	SetName(name string)
-	//Set sets options for sheet
+	//Set sets options for sheet, e.g.: Set(options.NewSheetOptions(options.Sheet.Visibility(options.VisibilityTypeVeryHidden))). Hidden sheet can't be active, so the next visible sheet becomes active if required
 	Set(o *options.SheetOptions)
-	//SetActive sets the sheet as active
-	SetActive()
+	//Options returns options of sheet, e.g. visibility
+	Options() *options.SheetOptions
+	//SetActive sets the sheet as active. Hidden sheet can't be active
+	SetActive() error
 	//Close frees allocated by sheet resources
 	Close()
 
@@ -0,0 +1,151 @@
+package xlsx
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+	"strconv"
+)
+
+//CopySheet adds a new sheet with name that is a copy of sheet with 0-based index i. Cells, styles, merged cells, hyperlinks, validations, conditional formatting and images are copied.
+//N.B.: tables, comments, charts, pivot tables, slicers, auto filter and sheet-level defined names are not copied
+func (xl *Spreadsheet) CopySheet(i int, name string) (Sheet, error) {
+	return xl.copySheet(xl, i, name)
+}
+
+//CopySheetFrom adds a new sheet that is a copy of sheet with name from other spreadsheet. Styles and shared strings are remapped to this spreadsheet.
+//N.B.: tables, comments, charts, pivot tables, slicers, auto filter and sheet-level defined names are not copied
+func (xl *Spreadsheet) CopySheetFrom(other *Spreadsheet, sheetName string) (Sheet, error) {
+	if other == nil {
+		return nil, errors.New("no spreadsheet to copy from")
+	}
+
+	src := other.sheetByName(sheetName)
+	if src == nil {
+		return nil, errors.New(fmt.Sprintf("there is no sheet with name '%s'", sheetName))
+	}
+
+	return xl.copySheet(other, src.index, src.Name())
+}
+
+//copySheet adds a new sheet with name that is a copy of sheet with 0-based index i from spreadsheet
+func (xl *Spreadsheet) copySheet(from *Spreadsheet, i int, name string) (Sheet, error) {
+	if i < 0 || i >= len(from.sheets) {
+		return nil, errors.New(fmt.Sprintf("there is no sheet with index %d", i))
+	}
+
+	if xl.sheetByName(name) != nil {
+		return nil, errors.New(fmt.Sprintf("sheet with name '%s' already exists", name))
+	}
+
+	src := from.Sheet(i).info()
+
+	//make a deep copy of markup
+	content, err := xml.Marshal(&src.ml)
+	if err != nil {
+		return nil, err
+	}
+
+	var w ml.Worksheet
+	if err := xml.Unmarshal(content, &w); err != nil {
+		return nil, err
+	}
+
+	//drop parts that are related to files of source sheet
+	w.Drawing = nil
+	w.LegacyDrawing = nil
+	w.LegacyDrawingHF = nil
+	w.DrawingHF = nil
+	w.Picture = nil
+	w.OleObjects = nil
+	w.Controls = nil
+	w.AutoFilter = nil
+	w.TableParts = ml.TablePartList{}
+	w.Hyperlinks = ml.HyperlinkList{}
+	removeExtension(w.ExtLst, ml.ExtURISlicerList)
+	removeExtension(w.ExtLst, ml.ExtURITableSlicerList)
+
+	for _, view := range w.SheetViews.Items {
+		view.TabSelected = false
+	}
+
+	if from != xl {
+		xl.remapSheet(from, &w)
+	}
+
+	sheet := xl.AddSheet(name)
+	dst := sheet.info()
+	dst.ml = w
+	dst.isInitialized = false
+	sheet.(*sheetReadWrite).expandOnInit()
+
+	//hyperlinks with external targets require own relations
+	src.attachRelationshipsIfRequired()
+	for _, link := range src.ml.Hyperlinks.Items {
+		hyperlink := *link
+		if len(hyperlink.RID) > 0 {
+			dst.attachRelationshipsIfRequired()
+			_, hyperlink.RID = dst.relationships.AddLink(internal.RelationTypeHyperlink, src.relationships.GetTargetById(string(link.RID)))
+		}
+
+		dst.ml.Hyperlinks.Items = append(dst.ml.Hyperlinks.Items, &hyperlink)
+	}
+
+	if err := dst.drawings.copyImages(src.drawings); err != nil {
+		return nil, err
+	}
+
+	return sheet, nil
+}
+
+//remapSheet remaps styles and shared strings of markup from other spreadsheet to this spreadsheet
+func (xl *Spreadsheet) remapSheet(from *Spreadsheet, w *ml.Worksheet) {
+	styles := make(map[ml.DirectStyleID]ml.DirectStyleID)
+	remap := func(id ml.DirectStyleID) ml.DirectStyleID {
+		if id == 0 {
+			return 0
+		}
+
+		if styleID, ok := styles[id]; ok {
+			return styleID
+		}
+
+		styles[id] = xl.styleSheet.copyDirectStyle(from.styleSheet, id)
+		return styles[id]
+	}
+
+	for _, col := range w.Cols.Items {
+		col.Style = remap(col.Style)
+	}
+
+	for _, row := range w.SheetData {
+		row.Style = remap(row.Style)
+
+		for _, cell := range row.Cells {
+			cell.Style = remap(cell.Style)
+
+			if cell.Type == types.CellTypeSharedString {
+				if sid, err := strconv.Atoi(cell.Value); err == nil {
+					if si := from.sharedStrings.get(sid); si != nil {
+						item := *si
+						cell.Value = strconv.Itoa(xl.sharedStrings.addText(&item))
+					}
+				}
+			}
+		}
+	}
+
+	if w.ConditionalFormatting != nil {
+		for _, conditional := range *w.ConditionalFormatting {
+			for _, rule := range conditional.Rules {
+				if rule.Style != nil {
+					styleID := xl.styleSheet.copyDiffStyle(from.styleSheet, *rule.Style)
+					rule.Style = &styleID
+				}
+			}
+		}
+	}
+}
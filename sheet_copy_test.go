@@ -0,0 +1,80 @@
+package xlsx
+
+import (
+	"bytes"
+	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"image"
+	"image/png"
+	"testing"
+)
+
+func TestCopySheet(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	buf := &bytes.Buffer{}
+	require.Nil(t, png.Encode(buf, img))
+
+	xl := New()
+	sheet := xl.AddSheet("Source")
+	bold := xl.AddFormatting(format.NewStyles(format.Font.Bold, format.Font.Color("#FF0000")))
+	sheet.Cell(0, 0).SetValue("shared")
+	sheet.Cell(0, 0).SetFormatting(bold)
+	sheet.Cell(1, 1).SetValue(123)
+	require.Nil(t, sheet.Range("A3:B3").Merge())
+	require.Nil(t, sheet.Cell(2, 0).SetHyperlink("https://github.com/plandem/xlsx"))
+	require.Nil(t, sheet.AddValidation(types.BoundsFromIndexes(3, 0, 3, 9), types.NewValidation(types.Validation.List("A", "B"))))
+	require.Nil(t, sheet.AddImage("E5", bytes.NewReader(buf.Bytes()), nil))
+
+	//errors
+	_, err := xl.CopySheet(0, "source")
+	require.NotNil(t, err)
+	_, err = xl.CopySheet(10, "Copy")
+	require.NotNil(t, err)
+
+	//copy within same spreadsheet
+	sheetCopy, err := xl.CopySheet(0, "Copy")
+	require.Nil(t, err)
+	require.Equal(t, []string{"Source", "Copy"}, xl.GetSheetNames())
+	require.Equal(t, "shared", sheetCopy.Cell(0, 0).Value())
+	require.Equal(t, bold, sheetCopy.Cell(0, 0).Formatting())
+	require.Equal(t, "123", sheetCopy.Cell(1, 1).Value())
+	require.Equal(t, []types.Bounds{types.BoundsFromIndexes(0, 2, 1, 2)}, sheetCopy.MergedCells())
+	require.Equal(t, "https://github.com/plandem/xlsx", sheetCopy.Cell(2, 0).Hyperlink().String())
+	require.NotNil(t, sheetCopy.Validation("D5"))
+	require.Equal(t, 1, len(sheetCopy.info().drawings.ml.OneCellAnchors))
+
+	//changes of copy do not affect source
+	sheetCopy.Cell(1, 1).SetValue(456)
+	require.Equal(t, "123", sheet.Cell(1, 1).Value())
+
+	//copy from other spreadsheet
+	other := New()
+	other.AddSheet("Other").Cell(0, 0).SetValue("other")
+	sheetCopy, err = other.CopySheetFrom(xl, "Source")
+	require.Nil(t, err)
+	require.Equal(t, []string{"Other", "Source"}, other.GetSheetNames())
+	require.Equal(t, "shared", sheetCopy.Cell(0, 0).Value())
+	require.Equal(t, "other", other.Sheet(0).Cell(0, 0).Value())
+	require.Equal(t, xl.styleSheet.resolveFont(bold), other.styleSheet.resolveFont(sheetCopy.Cell(0, 0).Formatting()))
+
+	_, err = other.CopySheetFrom(xl, "Unknown")
+	require.NotNil(t, err)
+
+	//save and reopen
+	require.Nil(t, other.SaveAs("./test_files/tmp.xlsx"))
+	other.Close()
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(1)
+	require.Equal(t, "shared", sheet.Cell(0, 0).Value())
+	require.Equal(t, "123", sheet.Cell(1, 1).Value())
+	require.Equal(t, "https://github.com/plandem/xlsx", sheet.Cell(2, 0).Hyperlink().String())
+	sheet.info().drawings.loadIfRequired()
+	require.Equal(t, 1, len(sheet.info().drawings.ml.OneCellAnchors))
+	require.Equal(t, true, bool(xl.styleSheet.resolveFont(sheet.Cell(0, 0).Formatting()).Bold))
+}
@@ -0,0 +1,636 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"github.com/plandem/ooxml"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/sorting"
+	"github.com/plandem/xlsx/types"
+	"io"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"sort"
+	"sync/atomic"
+)
+
+const (
+	//diskCachePlaceholder is a ref of fake row that is replaced with rows of cache during marshaling
+	diskCachePlaceholder = -1
+
+	//diskCacheRowsLimit is a number of rows that are kept in memory, rows that were loaded before are evicted if there are no cells or rows that refer these rows
+	diskCacheRowsLimit = 1024
+)
+
+//diskCacheRow is a location of row's XML at the cache
+type diskCacheRow struct {
+	offset int64
+	size   int64
+}
+
+//diskCacheSkeleton is a target for file of sheet that skips content, because information of sheet was already loaded during spilling into the cache
+type diskCacheSkeleton struct{}
+
+//UnmarshalXML skips content of sheet
+func (d *diskCacheSkeleton) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	return decoder.Skip()
+}
+
+//sheetDiskCache is a sheet that keeps rows at temporary file and loads rows into memory on demand only. Only limited number of loaded rows is kept in memory,
+//but rows are pinned while there are cells or rows that refer these rows, so loaded rows are not evicted till such objects are collected by garbage collector
+type sheetDiskCache struct {
+	*sheetInfo
+	cache    *os.File
+	size     int64
+	index    map[int]diskCacheRow
+	rows     map[int]*ml.Row
+	pins     map[int]*int32
+	loaded   []int
+	skeleton []byte
+	err      error
+}
+
+var _ Sheet = (*sheetDiskCache)(nil)
+
+//Cell returns a cell for 0-based indexes
+func (s *sheetDiskCache) Cell(colIndex, rowIndex int) *Cell {
+	s.expandIfRequired(colIndex, rowIndex)
+
+	colIndex, rowIndex, _ = s.mergedCells.Resolve(colIndex, rowIndex)
+	row := s.row(rowIndex)
+	data := row.Cells[colIndex]
+
+	//if there is no any data for this cell, then create it
+	if data == nil {
+		data = &ml.Cell{
+			Ref: types.CellRefFromIndexes(colIndex, rowIndex),
+		}
+
+		row.Cells[colIndex] = data
+	}
+
+	cell := &Cell{ml: data, sheet: s.sheetInfo}
+	s.pin(rowIndex, cell)
+	return cell
+}
+
+//CellByRef returns a cell for ref
+func (s *sheetDiskCache) CellByRef(cellRef types.CellRef) *Cell {
+	cid, rid := cellRef.ToIndexes()
+	return s.Cell(cid, rid)
+}
+
+//Row returns a row for 0-based index
+func (s *sheetDiskCache) Row(index int) *Row {
+	s.expandIfRequired(0, index)
+
+	data := s.row(index)
+	row := &Row{
+		data,
+		newRange(s, 0, len(data.Cells)-1, index, index),
+		nil,
+	}
+
+	s.pin(index, row)
+	return row
+}
+
+//Rows returns iterator for all rows of sheet
+func (s *sheetDiskCache) Rows() RowIterator {
+	return newRowIterator(s)
+}
+
+//row returns row with 0-based index, loading it from the cache if required. Loaded rows are kept in memory, because cells of rows can be changed
+func (s *sheetDiskCache) row(index int) *ml.Row {
+	if row, ok := s.rows[index]; ok {
+		return row
+	}
+
+	loaded, err := s.loadRow(index)
+	if err != nil {
+		s.setError(err)
+	}
+
+	row := s.expandRow(loaded)
+	s.rows[index] = row
+	s.pins[index] = new(int32)
+	s.loaded = append(s.loaded, index)
+	s.evictIfRequired()
+	return row
+}
+
+//pin prevents eviction of row with 0-based index while object that refers this row is reachable
+func (s *sheetDiskCache) pin(index int, object interface{}) {
+	//N.B.: finalizers are called at own goroutine, so only counter is changed there
+	counter := s.pins[index]
+	atomic.AddInt32(counter, 1)
+	runtime.SetFinalizer(object, func(interface{}) {
+		atomic.AddInt32(counter, -1)
+	})
+}
+
+//evictIfRequired stores and removes from memory rows that were loaded first and are not pinned, till number of loaded rows fits limit. Last loaded row is never evicted, because it is going to be used
+func (s *sheetDiskCache) evictIfRequired() {
+	for i := 0; i < len(s.loaded)-1 && len(s.loaded) > diskCacheRowsLimit; {
+		evicted := s.loaded[i]
+		if atomic.LoadInt32(s.pins[evicted]) > 0 {
+			i++
+			continue
+		}
+
+		//row is kept in memory if it can't be stored, so changes are not lost
+		if err := s.store(evicted); err != nil {
+			s.setError(err)
+			return
+		}
+
+		s.loaded = append(s.loaded[:i], s.loaded[i+1:]...)
+		delete(s.rows, evicted)
+		delete(s.pins, evicted)
+	}
+}
+
+//setError keeps first error of reading or writing of the cache, so saving of document fails with that error
+func (s *sheetDiskCache) setError(err error) {
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+//expandRow places stored cells of row at positions of columns, so row holds cells for width of sheet
+func (s *sheetDiskCache) expandRow(row *ml.Row) *ml.Row {
+	width, _ := s.Dimension()
+	cells := make([]*ml.Cell, width)
+	for _, c := range row.Cells {
+		if !isCellEmpty(c) {
+			cIdx, _ := c.Ref.ToIndexes()
+			cells[cIdx] = c
+		}
+	}
+
+	row.Cells = cells
+	return row
+}
+
+//compactRow returns copy of loaded row with 0-based index that holds non empty cells only
+func compactRow(row *ml.Row, index int) *ml.Row {
+	next := &ml.Row{}
+	*next = *row
+	next.Cells = make([]*ml.Cell, 0, len(row.Cells))
+	for cIdx, cell := range row.Cells {
+		if !isCellEmpty(cell) {
+			cell.Ref = types.CellRefFromIndexes(cIdx, index)
+			next.Cells = append(next.Cells, cell)
+		}
+	}
+
+	return next
+}
+
+//encodeRow returns XML of row
+func encodeRow(row *ml.Row) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	encoder := xml.NewEncoder(buf)
+	if err := encoder.EncodeElement(row, xml.StartElement{Name: xml.Name{Local: "row"}}); err != nil {
+		return nil, err
+	}
+
+	err := encoder.Flush()
+	return buf.Bytes(), err
+}
+
+//isRowChanged returns true if loaded row with 0-based index differs from row of the cache
+func (s *sheetDiskCache) isRowChanged(index int) (bool, error) {
+	next, err := encodeRow(compactRow(s.rows[index], index))
+	if err != nil {
+		return false, err
+	}
+
+	stored, err := s.loadRow(index)
+	if err != nil {
+		return false, err
+	}
+
+	prev, err := encodeRow(compactRow(s.expandRow(stored), index))
+	if err != nil {
+		return false, err
+	}
+
+	return !bytes.Equal(prev, next), nil
+}
+
+//store writes loaded row with 0-based index into the cache if row was changed, rows that were only read are not written
+func (s *sheetDiskCache) store(index int) error {
+	changed, err := s.isRowChanged(index)
+	if err != nil || !changed {
+		return err
+	}
+
+	s.file.MarkAsUpdated()
+
+	row := compactRow(s.rows[index], index)
+	if isRowEmpty(row) {
+		delete(s.index, index)
+		return nil
+	}
+
+	content, err := encodeRow(row)
+	if err != nil {
+		return err
+	}
+
+	if _, err = s.cache.WriteAt(content, s.size); err != nil {
+		return err
+	}
+
+	s.index[index] = diskCacheRow{offset: s.size, size: int64(len(content))}
+	s.size += int64(len(content))
+	return nil
+}
+
+//marshalSkeleton returns XML of sheet's information without rows and dimension, that is used to find changes of such information
+func (s *sheetDiskCache) marshalSkeleton() ([]byte, error) {
+	dimension, rows := s.ml.Dimension, s.ml.SheetData
+	s.ml.Dimension, s.ml.SheetData = nil, nil
+	defer func() {
+		s.ml.Dimension, s.ml.SheetData = dimension, rows
+	}()
+
+	return xml.Marshal(&s.ml)
+}
+
+//flush marks sheet as updated if loaded rows or other information of sheet were changed. Loaded rows are kept in memory, because cells of rows still can be changed
+func (s *sheetDiskCache) flush() error {
+	if s.err != nil {
+		return s.err
+	}
+
+	for index := range s.rows {
+		changed, err := s.isRowChanged(index)
+		if err != nil {
+			return err
+		}
+
+		if changed {
+			s.file.MarkAsUpdated()
+			return nil
+		}
+	}
+
+	skeleton, err := s.marshalSkeleton()
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(skeleton, s.skeleton) {
+		s.file.MarkAsUpdated()
+	}
+
+	return nil
+}
+
+//loadRow reads row with 0-based index from the cache as is or returns an empty row if there is no such row or row can't be read
+func (s *sheetDiskCache) loadRow(index int) (*ml.Row, error) {
+	row := &ml.Row{Ref: index + 1}
+	if location, ok := s.index[index]; ok {
+		data := make([]byte, location.size)
+		if _, err := s.cache.ReadAt(data, location.offset); err != nil {
+			return row, err
+		}
+
+		if err := xml.Unmarshal(data, row); err != nil {
+			return &ml.Row{Ref: index + 1}, err
+		}
+	}
+
+	row.Ref = index + 1
+	return row, nil
+}
+
+//expandIfRequired expands dimension and loaded rows to required size
+func (s *sheetDiskCache) expandIfRequired(colIndex, rowIndex int) {
+	cols, rows := s.Dimension()
+	if colIndex < cols && rowIndex < rows {
+		return
+	}
+
+	if colIndex >= cols {
+		for _, row := range s.rows {
+			row.Cells = append(row.Cells, make([]*ml.Cell, colIndex+1-cols)...)
+		}
+
+		cols = colIndex + 1
+	}
+
+	if rowIndex >= rows {
+		rows = rowIndex + 1
+	}
+
+	s.ml.Dimension = &ml.SheetDimension{Bounds: types.BoundsFromIndexes(0, 0, cols-1, rows-1)}
+}
+
+//spill copies XML of sheet into the cache, indexes rows and loads other information of sheet
+func (s *sheetDiskCache) spill() error {
+	var reader io.Reader
+	doc := s.workbook.doc
+	if zf, ok := doc.pkg.File(s.file.FileName()).(*zip.File); ok {
+		zr, err := zf.Open()
+		if err != nil {
+			return err
+		}
+
+		defer zr.Close()
+		reader = zr
+
+		//information of sheet is loaded during spilling, so content of file must be skipped if file will be loaded to mark it as updated
+		s.file = ooxml.NewPackageFile(doc.pkg, zf, &diskCacheSkeleton{}, s.sheetInfo)
+	} else {
+		//a new sheet has no stored content, so current information of sheet is used
+		content, err := xml.Marshal(&s.ml)
+		if err != nil {
+			return err
+		}
+
+		reader = bytes.NewReader(content)
+	}
+
+	var err error
+	if s.cache, err = ioutil.TempFile("", "xlsx-sheet-"); err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(s.cache, reader); err != nil {
+		return err
+	}
+
+	size, err := s.cache.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	//index rows and find boundaries of sheet data
+	dataFrom, dataTo := size, size
+	rIdx, width, height := -1, 0, 0
+	decoder := xml.NewDecoder(io.NewSectionReader(s.cache, 0, size))
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "sheetData":
+				dataFrom = decoder.InputOffset()
+			case "row":
+				row := &ml.Row{}
+				if err = decoder.DecodeElement(row, &t); err != nil {
+					return err
+				}
+
+				if row.Ref > 0 {
+					rIdx = row.Ref - 1
+				} else {
+					rIdx++
+				}
+
+				if isRowEmpty(row) {
+					continue
+				}
+
+				s.index[rIdx] = diskCacheRow{offset: offset, size: decoder.InputOffset() - offset}
+				for _, c := range row.Cells {
+					if cIdx, _ := c.Ref.ToIndexes(); cIdx >= width {
+						width = cIdx + 1
+					}
+				}
+
+				height = rIdx + 1
+			}
+		case xml.EndElement:
+			if t.Name.Local == "sheetData" {
+				dataTo = offset
+			}
+		}
+	}
+
+	//load other information of sheet without rows
+	skeleton := make([]byte, dataFrom+size-dataTo)
+	if _, err = s.cache.ReadAt(skeleton[:dataFrom], 0); err != nil {
+		return err
+	}
+
+	if _, err = s.cache.ReadAt(skeleton[dataFrom:], dataTo); err != nil && err != io.EOF {
+		return err
+	}
+
+	s.size = size
+	s.ml = ml.Worksheet{}
+	if err = xml.Unmarshal(skeleton, &s.ml); err != nil {
+		return err
+	}
+
+	if (s.sheetMode&SheetModeIgnoreDimension) != 0 || s.ml.Dimension == nil || s.ml.Dimension.Bounds.IsEmpty() {
+		s.ml.Dimension = nil
+		if width > 0 && height > 0 {
+			s.ml.Dimension = &ml.SheetDimension{Bounds: types.BoundsFromIndexes(0, 0, width-1, height-1)}
+		}
+	} else {
+		//dimension can hold only last part of ref, e.g.: C10 instead of A1:C10
+		s.ml.Dimension.Bounds.FromCol = 0
+		s.ml.Dimension.Bounds.FromRow = 0
+
+		//rows must fit dimension
+		s.expandIfRequired(width-1, height-1)
+	}
+
+	s.skeleton, err = s.marshalSkeleton()
+	return err
+}
+
+//afterOpen copies rows of sheet into the cache. If rows can't be copied, then sheet has no rows and saving of document fails with that error
+func (s *sheetDiskCache) afterOpen() {
+	//adds a styles for types
+	s.workbook.doc.styleSheet.addTypedStylesIfRequired()
+
+	s.index = make(map[int]diskCacheRow)
+	s.rows = make(map[int]*ml.Row)
+	s.pins = make(map[int]*int32)
+	s.loaded = nil
+	if err := s.spill(); err != nil {
+		s.Close()
+		s.index = make(map[int]diskCacheRow)
+		s.setError(err)
+	}
+
+	s.applyPatches()
+}
+
+//BeforeMarshalXML returns related ML information for marshaling with a placeholder for rows
+func (s *sheetDiskCache) BeforeMarshalXML() interface{} {
+	s.conditionals.pack()
+	s.ml.SheetData = []*ml.Row{{Ref: diskCachePlaceholder}}
+	return &s.ml
+}
+
+//AfterMarshalXML replaces placeholder with rows of sheet, where loaded rows are taken from memory and other rows from the cache.
+//Content can't be fixed if rows can't be read from the cache, so such error is kept and reported by saving
+func (s *sheetDiskCache) AfterMarshalXML(content []byte) []byte {
+	s.ml.SheetData = nil
+
+	placeholder := []byte(fmt.Sprintf(`<row r="%d"></row>`, diskCachePlaceholder))
+	pos := bytes.Index(content, placeholder)
+	if pos < 0 {
+		return content
+	}
+
+	indexes := make([]int, 0, len(s.index)+len(s.rows))
+	for rIdx := range s.index {
+		indexes = append(indexes, rIdx)
+	}
+
+	for rIdx := range s.rows {
+		if _, ok := s.index[rIdx]; !ok {
+			indexes = append(indexes, rIdx)
+		}
+	}
+
+	sort.Ints(indexes)
+
+	buf := &bytes.Buffer{}
+	buf.Write(content[:pos])
+
+	//N.B.: rows of cache are re-encoded too, because unknown attributes can use prefixes of namespaces that are not declared anymore
+	encoder := xml.NewEncoder(buf)
+	for _, rIdx := range indexes {
+		var next *ml.Row
+		if row, ok := s.rows[rIdx]; ok {
+			//loaded rows hold all cells of row, so only non empty cells are kept
+			if next = compactRow(row, rIdx); isRowEmpty(next) {
+				continue
+			}
+		} else {
+			var err error
+			if next, err = s.loadRow(rIdx); err != nil {
+				s.setError(err)
+				return content
+			}
+		}
+
+		if err := encoder.EncodeElement(next, xml.StartElement{Name: xml.Name{Local: "row"}}); err != nil {
+			s.setError(err)
+			return content
+		}
+	}
+
+	if err := encoder.Flush(); err != nil {
+		s.setError(err)
+		return content
+	}
+
+	buf.Write(content[pos+len(placeholder):])
+	return buf.Bytes()
+}
+
+//errorOfPart returns error that happened during marshaling of part, e.g.: rows of sheet in disk cache mode can't be read from the cache
+func errorOfPart(content interface{}) error {
+	if si, ok := content.(*sheetInfo); ok {
+		if cache, ok := si.sheet.(*sheetDiskCache); ok {
+			return cache.err
+		}
+	}
+
+	return nil
+}
+
+//Close frees allocated by sheet resources and removes the cache. Sheet can't be used after closing, so document must be saved before
+func (s *sheetDiskCache) Close() {
+	if s.cache != nil {
+		_ = s.cache.Close()
+		_ = os.Remove(s.cache.Name())
+		s.cache = nil
+	}
+}
+
+//not allowed methods for disk cache mode, because these methods require all rows in memory
+func (s *sheetDiskCache) Col(index int) *Col {
+	panic(errorNotSupported)
+}
+
+func (s *sheetDiskCache) Cols() ColIterator {
+	panic(errorNotSupported)
+}
+
+func (s *sheetDiskCache) InsertCol(index int) *Col {
+	panic(errorNotSupported)
+}
+
+func (s *sheetDiskCache) InsertRow(index int) *Row {
+	panic(errorNotSupported)
+}
+
+func (s *sheetDiskCache) DeleteRow(index int) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetDiskCache) DeleteCol(index int) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetDiskCache) InsertRows(index, n int) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetDiskCache) DeleteRows(index, n int) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetDiskCache) InsertCols(index, n int) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetDiskCache) DeleteCols(index, n int) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetDiskCache) SetDimension(cols, rows int) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetDiskCache) GroupRows(fromIndex, toIndex int, level uint8, collapsed bool) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetDiskCache) AutoFitColumns(bounds types.Bounds, metrics ...*FontMetrics) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetDiskCache) CopyRange(source types.Ref, target types.CellRef, options ...CopyOption) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetDiskCache) MoveRange(source types.Ref, target types.CellRef, options ...CopyOption) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetDiskCache) Sort(bounds types.Bounds, keys ...*sorting.Key) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetDiskCache) Cells(o *options.IterateOptions) RangeIterator {
+	panic(errorNotSupported)
+}
+
+func (s *sheetDiskCache) UsedRange() types.Bounds {
+	panic(errorNotSupported)
+}
@@ -0,0 +1,66 @@
+package xlsx_test
+
+import (
+	"bytes"
+	"github.com/plandem/xlsx"
+	"github.com/plandem/xlsx/sorting"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSheetDiskCache(t *testing.T) {
+	xl, err := xlsx.Open("./test_files/example_simple.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet := xl.Sheet(0, xlsx.SheetModeDiskCache)
+	defer sheet.Close()
+
+	testSheetReadFull(t, sheet)
+	require.Equal(t, sheet, xl.Sheet(0))
+	require.Panics(t, func() { xl.Sheet(0, xlsx.SheetModeStream) })
+
+	sheet.CellByRef("A1").SetValue("updated")
+	sheet.CellByRef("P30").SetInt(10)
+
+	cols, rows := sheet.Dimension()
+	require.Equal(t, 16, cols)
+	require.Equal(t, 30, rows)
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, xl.SaveAs(buf))
+
+	saved, err := xlsx.OpenBytes(buf.Bytes())
+	require.Nil(t, err)
+	defer saved.Close()
+
+	savedSheet := saved.Sheet(0)
+	require.Equal(t, "updated", savedSheet.CellByRef("A1").Value())
+	require.Equal(t, "10", savedSheet.CellByRef("P30").Value())
+	require.Equal(t, "    with leading space", savedSheet.CellByRef("A2").Value())
+	require.Equal(t, "20", savedSheet.CellByRef("H13").Value())
+	require.Equal(t, "last cell", savedSheet.CellByRef("N28").Value())
+	require.Equal(t, []types.Bounds{types.BoundsFromIndexes(2, 1, 2, 4), types.BoundsFromIndexes(4, 1, 6, 1), types.BoundsFromIndexes(4, 3, 6, 4)}, savedSheet.MergedCells())
+
+	//operations that require all rows in memory
+	require.Panics(t, func() { sheet.Col(0) })
+	require.Panics(t, func() { sheet.Cols() })
+	require.Panics(t, func() { sheet.InsertCol(0) })
+	require.Panics(t, func() { sheet.InsertRow(0) })
+	require.Panics(t, func() { sheet.DeleteRow(0) })
+	require.Panics(t, func() { sheet.DeleteCol(0) })
+	require.Panics(t, func() { sheet.InsertRows(0, 1) })
+	require.Panics(t, func() { sheet.DeleteRows(0, 1) })
+	require.Panics(t, func() { sheet.InsertCols(0, 1) })
+	require.Panics(t, func() { sheet.DeleteCols(0, 1) })
+	require.Panics(t, func() { sheet.SetDimension(1, 1) })
+	require.Panics(t, func() { _ = sheet.GroupRows(0, 1, 1, false) })
+	require.Panics(t, func() { _ = sheet.AutoFitColumns(types.BoundsFromIndexes(0, 0, 1, 1)) })
+	require.Panics(t, func() { _ = sheet.CopyRange("A1:B2", "C3") })
+	require.Panics(t, func() { _ = sheet.MoveRange("A1:B2", "C3") })
+	require.Panics(t, func() { _ = sheet.Sort(types.BoundsFromIndexes(0, 0, 1, 1), sorting.ByColumn(0, sorting.Asc)) })
+	require.Panics(t, func() { sheet.Range("A1:B2").CopyToRef("C3") })
+	require.Panics(t, func() { sheet.Cells(nil) })
+	require.Panics(t, func() { sheet.UsedRange() })
+}
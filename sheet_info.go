@@ -5,11 +5,19 @@ import (
 	"fmt"
 	"github.com/plandem/ooxml"
 	sharedML "github.com/plandem/ooxml/ml"
+	"github.com/plandem/xlsx/chart"
+	"github.com/plandem/xlsx/control"
 	"github.com/plandem/xlsx/format"
 	"github.com/plandem/xlsx/internal"
 	"github.com/plandem/xlsx/internal/ml"
 	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/page"
+	"github.com/plandem/xlsx/pivot"
+	"github.com/plandem/xlsx/slicer"
+	"github.com/plandem/xlsx/sparkline"
+	"github.com/plandem/xlsx/table"
 	"github.com/plandem/xlsx/types"
+	"io"
 	"math"
 	"path/filepath"
 	"reflect"
@@ -25,9 +33,26 @@ type sheetInfo struct {
 	mergedCells   *mergedCells
 	hyperlinks    *hyperlinks
 	conditionals  *conditionals
+	validations   *validations
+	comments      *comments
+	controls      *controls
+	objects       *objects
+	drawings      *drawings
+	autoFilter    *autoFilter
+	tables        *tables
+	formulas      *formulas
+	pivotTables   *pivotTables
+	sparklines    *sparklines
+	pageSetup     *pageSetup
+	headerFooter  *headerFooter
+	changes       *changes
 	relationships *ooxml.Relationships
 	sheet         Sheet
 	sheetMode     sheetMode
+	isStreamed    bool
+	patches       []func(sheet *sheetInfo)
+
+	extensionMarshalers []ExtensionMarshaler
 }
 
 //isCellEmpty checks if cell is empty - has no value and any formatting
@@ -108,6 +133,19 @@ func newSheetInfo(f interface{}, doc *Spreadsheet) *sheetInfo {
 		sheet.mergedCells = newMergedCells(sheet)
 		sheet.hyperlinks = newHyperlinks(sheet)
 		sheet.conditionals = newConditionals(sheet)
+		sheet.validations = newValidations(sheet)
+		sheet.comments = newComments(sheet)
+		sheet.controls = newControls(sheet)
+		sheet.objects = newObjects(sheet)
+		sheet.drawings = newDrawings(sheet)
+		sheet.autoFilter = newAutoFilter(sheet)
+		sheet.tables = newTables(sheet)
+		sheet.formulas = newFormulas(sheet)
+		sheet.pivotTables = newPivotTables(sheet)
+		sheet.sparklines = newSparklines(sheet)
+		sheet.pageSetup = newPageSetup(sheet)
+		sheet.headerFooter = newHeaderFooter(sheet)
+		sheet.changes = newChanges(sheet)
 	}
 
 	return sheet
@@ -127,37 +165,68 @@ func (s *sheetInfo) Name() string {
 	return s.workbook.ml.Sheets[s.index].Name
 }
 
-//SetName sets a name for sheet
+//SetName sets a name for sheet and updates formulas, defined names and hyperlinks that refer to sheet
 func (s *sheetInfo) SetName(name string) {
-	s.workbook.ml.Sheets[s.index].Name = ooxml.UniqueName(name, s.workbook.doc.GetSheetNames(), internal.ExcelSheetNameLimit)
+	prev := s.Name()
+	s.setName(name)
+
+	if next := s.Name(); next != prev {
+		s.afterRename(prev, next)
+	}
+}
+
+//setName sets a unique name for sheet without updating of references
+func (s *sheetInfo) setName(name string) {
+	//name of this sheet must not affect uniqueness of a new name
+	names := s.workbook.doc.GetSheetNames()
+	names[s.index] = ""
+
+	s.workbook.ml.Sheets[s.index].Name = ooxml.UniqueName(name, names, internal.ExcelSheetNameLimit)
 	s.workbook.file.MarkAsUpdated()
 }
 
-//Set sets options for sheet
+//Options returns options of sheet, e.g. visibility
+func (s *sheetInfo) Options() *options.SheetOptions {
+	visibility := s.workbook.ml.Sheets[s.index].State
+	if visibility == 0 {
+		visibility = options.VisibilityTypeVisible
+	}
+
+	return options.NewSheetOptions(options.Sheet.Visibility(visibility))
+}
+
+//Set sets options for sheet. Hidden sheet can't be active, so the next visible sheet becomes active if required
 func (s *sheetInfo) Set(o *options.SheetOptions) {
 	if o.Visibility >= options.VisibilityTypeVisible && o.Visibility <= options.VisibilityTypeVeryHidden {
 		s.workbook.ml.Sheets[s.index].State = o.Visibility
 		s.workbook.file.MarkAsUpdated()
+
+		doc := s.workbook.doc
+		if o.Visibility != options.VisibilityTypeVisible && doc.ActiveSheet() == s.index {
+			for i := 1; i < len(doc.sheets); i++ {
+				if doc.SetActiveSheet((s.index+i)%len(doc.sheets)) == nil {
+					break
+				}
+			}
+		}
 	}
 }
 
-//SetActive sets the sheet as active
-func (s *sheetInfo) SetActive() {
-	//set activate from workbook side
-	if len(s.workbook.ml.BookViews.Items) == 0 {
-		s.workbook.ml.BookViews.Items = append(s.workbook.ml.BookViews.Items, &ml.BookView{
-			ActiveTab: s.index,
-		})
-	} else {
-		s.workbook.ml.BookViews.Items[0].ActiveTab = s.index
-	}
+//SetActive sets the sheet as active. Hidden sheet can't be active
+func (s *sheetInfo) SetActive() error {
+	return s.workbook.doc.SetActiveSheet(s.index)
+}
 
-	//set active from worksheet side
-	if len(s.ml.SheetViews.Items) > 0 {
-		s.ml.SheetViews.Items[0].TabSelected = true
+//selectTab selects tab of sheet if sheet is active or unselects it in other case
+func (s *sheetInfo) selectTab() {
+	if s.index == s.workbook.doc.ActiveSheet() {
+		s.sheetView().TabSelected = true
+		return
 	}
 
-	s.workbook.file.MarkAsUpdated()
+	for _, v := range s.ml.SheetViews.Items {
+		v.TabSelected = false
+	}
 }
 
 //Dimension returns total number of cols and rows in sheet
@@ -177,19 +246,30 @@ func (s *sheetInfo) Range(ref types.Ref) *Range {
 }
 
 //MergeRows merges rows between fromIndex and toIndex
-func (s *sheetInfo) MergeRows(fromIndex, toIndex int) error {
+func (s *sheetInfo) MergeRows(fromIndex, toIndex int, options ...MergeOption) error {
 	return s.Range(types.RefFromCellRefs(
 		types.CellRefFromIndexes(0, fromIndex),
 		types.CellRefFromIndexes(internal.ExcelColumnLimit, toIndex),
-	)).Merge()
+	)).Merge(options...)
 }
 
 //MergeCols merges cols between fromIndex and toIndex
-func (s *sheetInfo) MergeCols(fromIndex, toIndex int) error {
+func (s *sheetInfo) MergeCols(fromIndex, toIndex int, options ...MergeOption) error {
 	return s.Range(types.RefFromCellRefs(
 		types.CellRefFromIndexes(fromIndex, 0),
 		types.CellRefFromIndexes(toIndex, internal.ExcelRowLimit),
-	)).Merge()
+	)).Merge(options...)
+}
+
+//MergedCells returns bounds of all merged cells
+func (s *sheetInfo) MergedCells() []types.Bounds {
+	return s.mergedCells.List()
+}
+
+//MergedRange returns bounds of merged cells that contain cell with ref. If cell is not merged then false is returned
+func (s *sheetInfo) MergedRange(ref types.CellRef) (types.Bounds, bool) {
+	cIdx, rIdx := ref.ToIndexes()
+	return s.mergedCells.Get(cIdx, rIdx)
 }
 
 //SplitRows splits rows between fromIndex and toIndex
@@ -218,6 +298,177 @@ func (s *sheetInfo) DeleteConditional(refs ...types.Ref) {
 	s.conditionals.Remove(refs)
 }
 
+//ConditionalFormats returns all conditional formatting of sheet with bounds, rules and resolved differential styles
+func (s *sheetInfo) ConditionalFormats() []*ConditionalInfo {
+	return s.conditionals.List()
+}
+
+//AddValidation adds a new data validation for bounds
+func (s *sheetInfo) AddValidation(bounds types.Bounds, validation *types.ValidationInfo) error {
+	return s.validations.Add(bounds, validation)
+}
+
+//DeleteValidation deletes a data validation for bounds
+func (s *sheetInfo) DeleteValidation(bounds types.Bounds) {
+	s.validations.Remove(bounds)
+}
+
+//Validation returns a data validation for cell ref or nil if there is no any validation
+func (s *sheetInfo) Validation(cellRef types.CellRef) *types.ValidationInfo {
+	return s.validations.Get(cellRef)
+}
+
+//AddHyperlinks adds hyperlinks for few bounds at once and sets styles of hyperlinks for cells of bounds
+func (s *sheetInfo) AddHyperlinks(links ...SheetHyperlink) error {
+	styles, err := s.hyperlinks.AddList(links)
+	if err != nil {
+		return err
+	}
+
+	for i, link := range links {
+		styleID := styles[i]
+		s.Range(link.Bounds.ToRef()).Walk(func(idx, cIdx, rIdx int, c *Cell) {
+			s.hyperlinks.applyStyle(c, styleID)
+		})
+	}
+
+	return nil
+}
+
+//Hyperlinks returns all hyperlinks of sheet with resolved targets
+func (s *sheetInfo) Hyperlinks() []SheetHyperlink {
+	return s.hyperlinks.List()
+}
+
+//DeleteHyperlinks deletes all hyperlinks of sheet
+func (s *sheetInfo) DeleteHyperlinks() {
+	s.hyperlinks.RemoveAll()
+}
+
+//AddImage adds image with top left corner at cell ref
+func (s *sheetInfo) AddImage(cellRef types.CellRef, image io.Reader, o *options.ImageOptions) error {
+	return s.drawings.AddImage(cellRef, image, o)
+}
+
+//AddControl adds form control of type that fits bounds
+func (s *sheetInfo) AddControl(bounds types.Bounds, t control.Type, options ...control.Option) error {
+	return s.controls.Add(bounds, control.New(t, options...))
+}
+
+//AddObject embeds file with name and content that is displayed as icon that fits bounds
+func (s *sheetInfo) AddObject(bounds types.Bounds, name string, content io.Reader, o *options.ObjectOptions) error {
+	return s.objects.Add(bounds, name, content, o)
+}
+
+//Objects returns all embedded objects of sheet with extracted content of files
+func (s *sheetInfo) Objects() ([]EmbeddedObject, error) {
+	return s.objects.List()
+}
+
+//AddChart adds chart that fits bounds
+func (s *sheetInfo) AddChart(bounds types.Bounds, info *chart.Info) error {
+	return s.drawings.AddChart(bounds, info)
+}
+
+//SetAutoFilter sets auto filter for bounds with optional criteria for columns
+func (s *sheetInfo) SetAutoFilter(bounds types.Bounds, filters ...*types.FilterInfo) error {
+	return s.autoFilter.Set(bounds, filters)
+}
+
+//AutoFilter returns bounds and criteria of auto filter
+func (s *sheetInfo) AutoFilter() (types.Bounds, []*types.FilterInfo) {
+	return s.autoFilter.Get()
+}
+
+//DeleteAutoFilter deletes auto filter
+func (s *sheetInfo) DeleteAutoFilter() {
+	s.autoFilter.Remove()
+}
+
+//AddTable adds a new table for bounds with the first row as a header
+func (s *sheetInfo) AddTable(bounds types.Bounds, options ...table.Option) error {
+	return s.tables.Add(bounds, table.New(options...))
+}
+
+//Table returns information about table with name or nil if there is no such table
+func (s *sheetInfo) Table(name string) *table.Info {
+	return s.tables.Get(name)
+}
+
+//Tables returns information about all tables of sheet
+func (s *sheetInfo) Tables() []*table.Info {
+	return s.tables.List()
+}
+
+//DeleteTable deletes table with name
+func (s *sheetInfo) DeleteTable(name string) {
+	s.tables.Remove(name)
+}
+
+//AddPivotTable adds a new pivot table with top left corner at cellRef for source data with bounds
+func (s *sheetInfo) AddPivotTable(cellRef types.CellRef, source types.Bounds, options ...pivot.Option) error {
+	return s.pivotTables.Add(cellRef, source, pivot.New(options...))
+}
+
+//AddSlicer adds a new slicer that fits bounds for field of table or pivot table with name at same sheet
+func (s *sheetInfo) AddSlicer(bounds types.Bounds, source string, field string, options ...slicer.Option) error {
+	return s.workbook.doc.slicers.Add(s, bounds, source, field, slicer.New(options...))
+}
+
+//AddSparkline adds a group of sparklines of type at location for data
+func (s *sheetInfo) AddSparkline(location types.Ref, data types.Ref, t sparkline.Type, options ...sparkline.Option) error {
+	return s.sparklines.Add(location, data, sparkline.New(t, options...))
+}
+
+//Sparklines returns information about all groups of sparklines of sheet
+func (s *sheetInfo) Sparklines() []*sparkline.Info {
+	return s.sparklines.List()
+}
+
+//SetPageSetup sets page setup and print settings of sheet, settings that are not affected by options are kept as is
+func (s *sheetInfo) SetPageSetup(options ...page.Option) error {
+	info := s.pageSetup.Get()
+	info.Set(options...)
+	return s.pageSetup.Set(info)
+}
+
+//PageSetup returns page setup and print settings of sheet
+func (s *sheetInfo) PageSetup() *page.Info {
+	return s.pageSetup.Get()
+}
+
+//SetHeaderFooter sets headers and footers of sheet, settings that are not affected by options are kept as is
+func (s *sheetInfo) SetHeaderFooter(options ...page.HeaderFooterOption) error {
+	info := s.headerFooter.Get()
+	info.Set(options...)
+	return s.headerFooter.Set(info)
+}
+
+//HeaderFooter returns headers and footers of sheet
+func (s *sheetInfo) HeaderFooter() *page.HeaderFooter {
+	return s.headerFooter.Get()
+}
+
+//DefineName adds a new or updates existing sheet-level defined name with formula
+func (s *sheetInfo) DefineName(name string, formula string) error {
+	return s.workbook.definedNames.Add(name, formula, s.index)
+}
+
+//DefinedName returns formula of sheet-level defined name or empty string if there is no such name
+func (s *sheetInfo) DefinedName(name string) string {
+	return s.workbook.definedNames.Get(name, s.index)
+}
+
+//DeleteName deletes sheet-level defined name
+func (s *sheetInfo) DeleteName(name string) {
+	s.workbook.definedNames.Remove(name, s.index)
+}
+
+//DefinedNames returns names of all sheet-level defined names
+func (s *sheetInfo) DefinedNames() []string {
+	return s.workbook.definedNames.List(s.index)
+}
+
 //Close frees allocated by sheet resources
 func (s *sheetInfo) Close() {
 
@@ -243,7 +494,7 @@ func (s *sheetInfo) attachRelationshipsIfRequired() {
 //afterCreate is callback that will be called right after creating a new sheet. By default, it registers sheet at spreadsheet
 func (s *sheetInfo) afterCreate(name string) {
 	if len(name) > 0 {
-		s.SetName(name)
+		s.setName(name)
 	}
 
 	s.file.MarkAsUpdated()
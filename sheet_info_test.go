@@ -1,9 +1,11 @@
 package xlsx
 
 import (
+	"github.com/plandem/xlsx/chart"
 	"github.com/plandem/xlsx/internal/ml"
 	"github.com/plandem/xlsx/internal/ml/primitives"
 	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/types"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -41,6 +43,85 @@ func TestSheetInfo(t *testing.T) {
 	//test set active
 	require.Equal(t, 0, xl.workbook.ml.BookViews.Items[0].ActiveTab)
 	sheet = xl.AddSheet("test")
-	sheet.SetActive()
+	require.Nil(t, sheet.SetActive())
 	require.Equal(t, 1, xl.workbook.ml.BookViews.Items[0].ActiveTab)
 }
+
+func TestSheetInfo_SetName(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	data := xl.AddSheet("Data")
+	report := xl.AddSheet("Report")
+	data.CellByRef("A1").SetInt(10)
+	data.CellByRef("B1").SetFormula("A1*2")
+	report.CellByRef("A1").SetFormula("SUM(Data!A1:A10)+'Data'!B1")
+	require.Nil(t, report.CellByRef("A2").SetHyperlink(types.NewHyperlink(types.Hyperlink.ToLocation("Data!A1"))))
+	require.Nil(t, xl.DefineName("Total", "Data!$A$1"))
+	require.Equal(t, "Data!A1", report.info().ml.Hyperlinks.Items[0].Location)
+
+	//renaming to the same name keeps name
+	data.SetName("Data")
+	require.Equal(t, "Data", data.Name())
+
+	data.SetName("Sales 2020")
+	require.Equal(t, "Sales 2020", data.Name())
+	require.Equal(t, "A1*2", data.CellByRef("B1").Formula())
+	require.Equal(t, "SUM('Sales 2020'!A1:A10)+'Sales 2020'!B1", report.CellByRef("A1").Formula())
+	require.Equal(t, "'Sales 2020'!A1", report.info().ml.Hyperlinks.Items[0].Location)
+	require.Equal(t, "'Sales 2020'!$A$1", xl.DefinedName("Total"))
+}
+
+func TestSheetInfo_SetNameLazy(t *testing.T) {
+	xl := New()
+	data := xl.AddSheet("Data")
+	report := xl.AddSheet("Report")
+	data.CellByRef("A1").SetInt(10)
+	report.CellByRef("A1").SetFormula("SUM(Data:Report!A1)+Data!A1")
+	require.Nil(t, data.AddChart(types.BoundsFromIndexes(2, 1, 7, 15), chart.New(chart.Line,
+		chart.Series("Total", "A1:A5", "A1:A5"),
+	)))
+
+	//new charts are updated right away
+	data.SetName("Old")
+	require.Equal(t, "Old!$A$1:$A$5", chartFormulas(data.info().drawings.charts[0])[0].Formula)
+
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+
+	//sheets that were not opened are updated right before saving only
+	xl.Sheet(0).SetName("Sales 2020")
+	require.Nil(t, xl.sheets[1].sheet)
+	require.Equal(t, 1, len(xl.sheets[1].patches))
+	require.Contains(t, string(xl.pkg.File("xl/charts/chart1.xml").([]byte)), `'Sales 2020'!$A$1:$A$5`)
+
+	err = xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	require.Equal(t, "SUM('Sales 2020:Report'!A1)+'Sales 2020'!A1", xl.Sheet(1).CellByRef("A1").Formula())
+}
+
+func TestSheetInfo_Options(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	first := xl.AddSheet("First")
+	second := xl.AddSheet("Second")
+	require.Equal(t, options.VisibilityTypeVisible, first.Options().Visibility)
+
+	//hidden sheet can't be active
+	require.Nil(t, xl.SetActiveSheet(0))
+	first.Set(options.NewSheetOptions(options.Sheet.Visibility(options.VisibilityTypeVeryHidden)))
+	require.Equal(t, options.VisibilityTypeVeryHidden, first.Options().Visibility)
+	require.Equal(t, 1, xl.ActiveSheet())
+	require.Equal(t, options.VisibilityTypeVisible, second.Options().Visibility)
+}
@@ -3,10 +3,21 @@ package xlsx
 import (
 	"encoding/xml"
 	"github.com/plandem/ooxml"
+	"github.com/plandem/xlsx/chart"
+	"github.com/plandem/xlsx/control"
+	"github.com/plandem/xlsx/errtype"
 	"github.com/plandem/xlsx/format"
 	"github.com/plandem/xlsx/internal/ml"
 	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/page"
+	"github.com/plandem/xlsx/pivot"
+	"github.com/plandem/xlsx/protection"
+	"github.com/plandem/xlsx/slicer"
+	"github.com/plandem/xlsx/sorting"
+	"github.com/plandem/xlsx/sparkline"
+	"github.com/plandem/xlsx/table"
 	"github.com/plandem/xlsx/types"
+	"io"
 )
 
 type sheetReadStream struct {
@@ -79,6 +90,7 @@ func (s *sheetReadStream) Row(index int) *Row {
 	return &Row{
 		data,
 		newRange(s, 0, len(data.Cells)-1, index, index),
+		nil,
 	}
 }
 
@@ -242,11 +254,27 @@ func (s *sheetReadStream) DeleteCol(index int) {
 	panic(errorNotSupported)
 }
 
+func (s *sheetReadStream) InsertRows(index, n int) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) DeleteRows(index, n int) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) InsertCols(index, n int) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) DeleteCols(index, n int) {
+	panic(errorNotSupported)
+}
+
 func (s *sheetReadStream) SetDimension(cols, rows int) {
 	panic(errorNotSupported)
 }
 
-func (s *sheetReadStream) SetActive() {
+func (s *sheetReadStream) SetActive() error {
 	panic(errorNotSupported)
 }
 
@@ -258,11 +286,11 @@ func (s *sheetReadStream) SetName(name string) {
 	panic(errorNotSupported)
 }
 
-func (s *sheetReadStream) MergeRows(fromIndex, toIndex int) error {
+func (s *sheetReadStream) MergeRows(fromIndex, toIndex int, options ...MergeOption) error {
 	panic(errorNotSupported)
 }
 
-func (s *sheetReadStream) MergeCols(fromIndex, toIndex int) error {
+func (s *sheetReadStream) MergeCols(fromIndex, toIndex int, options ...MergeOption) error {
 	panic(errorNotSupported)
 }
 
@@ -281,3 +309,183 @@ func (s *sheetReadStream) AddConditional(conditional *format.ConditionalFormat,
 func (s *sheetReadStream) DeleteConditional(refs ...types.Ref) {
 	panic(errorNotSupported)
 }
+
+func (s *sheetReadStream) AddValidation(bounds types.Bounds, validation *types.ValidationInfo) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) DeleteValidation(bounds types.Bounds) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) Validation(cellRef types.CellRef) *types.ValidationInfo {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) AddHyperlinks(links ...SheetHyperlink) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) Hyperlinks() []SheetHyperlink {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) DeleteHyperlinks() {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) AddImage(cellRef types.CellRef, image io.Reader, o *options.ImageOptions) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) SetBackground(image io.Reader) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) SetAutoFilter(bounds types.Bounds, filters ...*types.FilterInfo) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) DeleteAutoFilter() {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) AddControl(bounds types.Bounds, t control.Type, options ...control.Option) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) AddObject(bounds types.Bounds, name string, content io.Reader, o *options.ObjectOptions) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) AddChart(bounds types.Bounds, info *chart.Info) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) AddTable(bounds types.Bounds, options ...table.Option) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) DeleteTable(name string) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) AddPivotTable(cellRef types.CellRef, source types.Bounds, options ...pivot.Option) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) AddSlicer(bounds types.Bounds, source string, field string, options ...slicer.Option) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) FreezeRows(n int) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) FreezeColumns(n int) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) SplitPanes(x, y float64) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) AddSparkline(location types.Ref, data types.Ref, t sparkline.Type, options ...sparkline.Option) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) GroupRows(fromIndex, toIndex int, level uint8, collapsed bool) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) GroupCols(fromIndex, toIndex int, level uint8, collapsed bool) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) SetRowsOptions(fromIndex, toIndex int, o *options.RowOptions) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) SetColsOptions(fromIndex, toIndex int, o *options.ColumnOptions) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) AutoFitColumns(bounds types.Bounds, metrics ...*FontMetrics) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) WriteStructs(data interface{}) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) CopyRange(source types.Ref, target types.CellRef, options ...CopyOption) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) MoveRange(source types.Ref, target types.CellRef, options ...CopyOption) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) Replace(old interface{}, replacement string, o *options.FindOptions) (int, error) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) Sort(bounds types.Bounds, keys ...*sorting.Key) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) SetOutlineSummary(below bool, right bool) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) SetView(o *options.ViewOptions) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) SetSelection(active types.CellRef, refs ...types.Ref) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) SetPageSetup(options ...page.Option) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) SetHeaderFooter(options ...page.HeaderFooterOption) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) InsertPageBreak(t PageBreakType, index int) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) RemovePageBreak(t PageBreakType, index int) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) IgnoreErrors(bounds types.Bounds, t errtype.Type) {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) Protect(password string, options ...protection.Option) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) Unprotect() {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) ChangePassword(password string) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) RegisterExtension(marshaler ExtensionMarshaler) error {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) Cells(o *options.IterateOptions) RangeIterator {
+	panic(errorNotSupported)
+}
+
+func (s *sheetReadStream) UsedRange() types.Bounds {
+	panic(errorNotSupported)
+}
@@ -2,7 +2,16 @@ package xlsx_test
 
 import (
 	"github.com/plandem/xlsx"
+	"github.com/plandem/xlsx/control"
+	"github.com/plandem/xlsx/errtype"
 	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/page"
+	"github.com/plandem/xlsx/pivot"
+	"github.com/plandem/xlsx/protection"
+	"github.com/plandem/xlsx/sorting"
+	"github.com/plandem/xlsx/sparkline"
+	"github.com/plandem/xlsx/table"
+	"github.com/plandem/xlsx/types"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -146,10 +155,59 @@ func TestSheetReadStream_notImplemented(t *testing.T) {
 	require.Panics(t, func() { sheet.InsertRow(0) })
 	require.Panics(t, func() { sheet.DeleteRow(0) })
 	require.Panics(t, func() { sheet.DeleteCol(0) })
+	require.Panics(t, func() { sheet.InsertRows(0, 1) })
+	require.Panics(t, func() { sheet.DeleteRows(0, 1) })
+	require.Panics(t, func() { sheet.InsertCols(0, 1) })
+	require.Panics(t, func() { sheet.DeleteCols(0, 1) })
 	require.Panics(t, func() { sheet.SetDimension(100, 100) })
-	require.Panics(t, func() { sheet.SetActive() })
+	require.Panics(t, func() { _ = sheet.SetActive() })
 	require.Panics(t, func() { sheet.Set(options.NewSheetOptions(options.Sheet.Visibility(options.VisibilityTypeVisible))) })
 	require.Panics(t, func() { sheet.SetName("aaa") })
+	require.Panics(t, func() { sheet.AddValidation(types.BoundsFromIndexes(0, 0, 0, 0), types.NewValidation()) })
+	require.Panics(t, func() { sheet.DeleteValidation(types.BoundsFromIndexes(0, 0, 0, 0)) })
+	require.Panics(t, func() { sheet.Validation("A1") })
+	require.Panics(t, func() { sheet.AddHyperlinks() })
+	require.Panics(t, func() { sheet.Hyperlinks() })
+	require.Panics(t, func() { sheet.DeleteHyperlinks() })
+	require.Panics(t, func() { sheet.AddImage("A1", nil, nil) })
+	require.Panics(t, func() { sheet.SetBackground(nil) })
+	require.Panics(t, func() { sheet.AddControl(types.BoundsFromIndexes(0, 0, 0, 0), control.CheckBox) })
+	require.Panics(t, func() { sheet.AddObject(types.BoundsFromIndexes(0, 0, 0, 0), "file.pdf", nil, nil) })
+	require.Panics(t, func() { sheet.AddChart(types.BoundsFromIndexes(0, 0, 0, 0), nil) })
+	require.Panics(t, func() { sheet.SetAutoFilter(types.BoundsFromIndexes(0, 0, 0, 0)) })
+	require.Panics(t, func() { sheet.DeleteAutoFilter() })
+	require.Panics(t, func() { sheet.AddTable(types.BoundsFromIndexes(0, 0, 0, 1), table.Name("Table1")) })
+	require.Panics(t, func() { sheet.DeleteTable("Table1") })
+	require.Panics(t, func() { sheet.AddPivotTable("E1", types.BoundsFromIndexes(0, 0, 1, 1), pivot.Value("A", pivot.Sum)) })
+	require.Panics(t, func() { sheet.AddSlicer(types.BoundsFromIndexes(4, 0, 6, 10), "Sales", "Region") })
+	require.Panics(t, func() { sheet.FreezeRows(1) })
+	require.Panics(t, func() { sheet.FreezeColumns(1) })
+	require.Panics(t, func() { sheet.SplitPanes(1000, 1000) })
+	require.Panics(t, func() { sheet.AddSparkline("F1", "A1:E1", sparkline.Line) })
+	require.Panics(t, func() { sheet.GroupRows(1, 2, 1, false) })
+	require.Panics(t, func() { sheet.GroupCols(1, 2, 1, false) })
+	require.Panics(t, func() { sheet.SetRowsOptions(1, 2, options.NewRowOptions(options.Row.Hidden(true))) })
+	require.Panics(t, func() { sheet.SetColsOptions(1, 2, options.NewColumnOptions(options.Column.Hidden(true))) })
+	require.Panics(t, func() { sheet.AutoFitColumns(types.BoundsFromIndexes(0, 0, 1, 1)) })
+	require.Panics(t, func() { sheet.WriteStructs([]struct{ Name string }{}) })
+	require.Panics(t, func() { sheet.CopyRange("A1:B2", "C1") })
+	require.Panics(t, func() { sheet.MoveRange("A1:B2", "C1") })
+	require.Panics(t, func() { sheet.Replace("A", "B", nil) })
+	require.Panics(t, func() { sheet.Sort(types.BoundsFromIndexes(0, 0, 1, 1), sorting.ByColumn(0, sorting.Asc)) })
+	require.Panics(t, func() { sheet.SetOutlineSummary(false, false) })
+	require.Panics(t, func() { sheet.SetView(options.NewViewOptions(options.View.ZoomScale(150))) })
+	require.Panics(t, func() { sheet.SetSelection("B2", "A1:C3") })
+	require.Panics(t, func() { sheet.SetPageSetup(page.Landscape) })
+	require.Panics(t, func() { sheet.SetHeaderFooter(page.Header("", "Title", "")) })
+	require.Panics(t, func() { sheet.InsertPageBreak(xlsx.PageBreakRow, 10) })
+	require.Panics(t, func() { sheet.RemovePageBreak(xlsx.PageBreakRow, 10) })
+	require.Panics(t, func() { sheet.IgnoreErrors(types.BoundsFromIndexes(0, 0, 1, 1), errtype.NumberStoredAsText) })
+	require.Panics(t, func() { sheet.Protect("secret", protection.AllowSort) })
+	require.Panics(t, func() { sheet.Unprotect() })
+	require.Panics(t, func() { sheet.ChangePassword("secret") })
+	require.Panics(t, func() { sheet.RegisterExtension(nil) })
+	require.Panics(t, func() { sheet.Cells(nil) })
+	require.Panics(t, func() { sheet.UsedRange() })
 }
 
 func TestSheetReadStream_access(t *testing.T) {
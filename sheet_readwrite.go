@@ -1,9 +1,11 @@
 package xlsx
 
 import (
+	"github.com/plandem/xlsx/internal"
 	"github.com/plandem/xlsx/internal/ml"
 	"github.com/plandem/xlsx/types"
 	"math"
+	"strconv"
 )
 
 type sheetReadWrite struct {
@@ -70,6 +72,7 @@ func (s *sheetReadWrite) Row(index int) *Row {
 	return &Row{
 		data,
 		newRange(s, 0, len(data.Cells)-1, index, index),
+		nil,
 	}
 }
 
@@ -99,36 +102,72 @@ func (s *sheetReadWrite) refreshColRefs(colIndex, rowIndex int) {
 
 //InsertRow inserts a row at 0-based index and returns it. Using to insert a row between other rows.
 func (s *sheetReadWrite) InsertRow(index int) *Row {
+	s.InsertRows(index, 1)
+	return s.Row(index)
+}
+
+//InsertRows inserts n rows at 0-based index and updates references to rows after it
+func (s *sheetReadWrite) InsertRows(index, n int) {
+	if n <= 0 {
+		return
+	}
+
+	s.beforeShift(index, n, false)
+
 	//getting current height
 	_, rows := s.Dimension()
+	if index > rows {
+		rows = index
+	}
 
 	//expand to a new height
-	s.expandIfRequired(0, rows)
+	s.expandIfRequired(0, rows+n-1)
 
 	//copy previous info
-	copy(s.ml.SheetData[index+1:], s.ml.SheetData[index:])
+	copy(s.ml.SheetData[index+n:], s.ml.SheetData[index:rows])
 
-	//clear previous info at this index
-	s.ml.SheetData[index] = &ml.Row{Cells: make([]*ml.Cell, len(s.ml.SheetData[index].Cells))}
+	//clear previous info at these indexes
+	for iRow := index; iRow < index+n; iRow++ {
+		s.ml.SheetData[iRow] = &ml.Row{Cells: make([]*ml.Cell, len(s.ml.SheetData[iRow].Cells))}
+	}
 
 	//refresh refs
 	s.refreshAllRefs(index)
-
-	return s.Row(index)
+	s.afterShift(index, n, false)
 }
 
 //DeleteRow deletes a row at 0-based index
 func (s *sheetReadWrite) DeleteRow(index int) {
-	s.expandIfRequired(0, index)
+	s.DeleteRows(index, 1)
+}
+
+//DeleteRows deletes n rows at 0-based index and updates references to deleted rows and rows after it
+func (s *sheetReadWrite) DeleteRows(index, n int) {
+	if n <= 0 {
+		return
+	}
+
+	s.beforeShift(index, -n, false)
+	s.expandIfRequired(0, index+n-1)
 
-	s.ml.SheetData = append(s.ml.SheetData[:index], s.ml.SheetData[index+1:]...)
+	//release formulas of deleted cells
+	for iRow := index; iRow < index+n; iRow++ {
+		for _, c := range s.ml.SheetData[iRow].Cells {
+			if c != nil {
+				s.formulas.release(c)
+			}
+		}
+	}
+
+	s.ml.SheetData = append(s.ml.SheetData[:index], s.ml.SheetData[index+n:]...)
 
 	//now we must updated refs
 	s.refreshAllRefs(index)
 
 	//update dimension for a new size
 	cols, rows := s.Dimension()
-	s.setDimension(cols, rows-1, false)
+	s.setDimension(cols, rows-n, false)
+	s.afterShift(index, -n, false)
 }
 
 //Col returns a col for 0-based index
@@ -140,47 +179,84 @@ func (s *sheetReadWrite) Col(index int) *Col {
 	return &Col{
 		s.columns.Resolve(index),
 		newRange(s, index, index, 0, rows-1),
+		nil,
 	}
 }
 
 //InsertCol inserts a col at 0-based index and returns it. Using to insert a col between other cols.
 func (s *sheetReadWrite) InsertCol(index int) *Col {
+	s.InsertCols(index, 1)
+	return s.Col(index)
+}
+
+//InsertCols inserts n cols at 0-based index and updates references to cols after it
+func (s *sheetReadWrite) InsertCols(index, n int) {
+	if n <= 0 {
+		return
+	}
+
+	s.beforeShift(index, n, true)
+
 	//getting current width
 	cols, _ := s.Dimension()
+	if index > cols {
+		cols = index
+	}
 
 	//expand to a new width
-	s.expandIfRequired(cols, 0)
+	s.expandIfRequired(cols+n-1, 0)
+	s.columns.Shift(index, n)
 
 	for iRow, row := range s.ml.SheetData {
 		//copy previous info
-		copy(s.ml.SheetData[iRow].Cells[index+1:], s.ml.SheetData[iRow].Cells[index:])
+		copy(row.Cells[index+n:], row.Cells[index:cols])
 
-		//clear previous info at this index
-		s.ml.SheetData[iRow].Cells[index] = nil
+		//clear previous info at these indexes
+		for iCol := index; iCol < index+n; iCol++ {
+			row.Cells[iCol] = nil
+		}
 
 		//refresh refs
-		s.refreshColRefs(index, row.Ref-1)
+		s.refreshColRefs(index, iRow)
 	}
 
-	return s.Col(index)
+	s.afterShift(index, n, true)
 }
 
 //DeleteCol deletes a col at 0-based index
 func (s *sheetReadWrite) DeleteCol(index int) {
-	s.expandIfRequired(index, 0)
-	s.columns.Delete(index)
+	s.DeleteCols(index, 1)
+}
+
+//DeleteCols deletes n cols at 0-based index and updates references to deleted cols and cols after it
+func (s *sheetReadWrite) DeleteCols(index, n int) {
+	if n <= 0 {
+		return
+	}
+
+	s.beforeShift(index, -n, true)
+	s.expandIfRequired(index+n-1, 0)
+	s.columns.Shift(index, -n)
 
 	for iRow, row := range s.ml.SheetData {
-		//delete col
-		s.ml.SheetData[iRow].Cells = append(s.ml.SheetData[iRow].Cells[:index], s.ml.SheetData[iRow].Cells[index+1:]...)
+		//release formulas of deleted cells
+		for _, c := range row.Cells[index : index+n] {
+			if c != nil {
+				s.formulas.release(c)
+			}
+		}
+
+		//delete cols
+		row.Cells = append(row.Cells[:index], row.Cells[index+n:]...)
 
 		//refresh refs
-		s.refreshColRefs(index, row.Ref-1)
+		s.refreshColRefs(index, iRow)
 	}
 
 	//update dimension for a new size
 	cols, rows := s.Dimension()
-	s.setDimension(cols-1, rows, false)
+	s.setDimension(cols-n, rows, false)
+	s.afterShift(index, -n, true)
 }
 
 //Cols returns iterator for all cols of sheet
@@ -227,7 +303,13 @@ func (s *sheetReadWrite) resolveDimension(force bool) {
 
 //expandOnInit expands grid to required dimension and copy existing data
 func (s *sheetReadWrite) expandOnInit() {
-	force := (s.sheetMode&SheetModeIgnoreDimension) != 0
+	force := (s.sheetMode & SheetModeIgnoreDimension) != 0
+
+	//dimension that covers whole columns or rows (e.g. A1:XFD1048576) is useless to allocate grid, so use actual data
+	if s.ml.Dimension != nil && (s.ml.Dimension.Bounds.ToCol >= internal.ExcelColumnLimit-1 || s.ml.Dimension.Bounds.ToRow >= internal.ExcelRowLimit-1) {
+		force = true
+	}
+
 	s.resolveDimension(force)
 
 	//during initialize phase we need to do hard work first time - expand grid to required size and copy it with existing data
@@ -340,6 +422,53 @@ func (s *sheetReadWrite) shrinkIfRequired() {
 	s.resolveDimension(true)
 }
 
+//snapshot returns copy of markup of sheet that is marshaled same way as for saving, but without side effects for sheet, e.g. grid of sheet is not shrunk
+func (s *sheetReadWrite) snapshot() *ml.Worksheet {
+	w := s.ml
+	w.SheetData = make([]*ml.Row, 0, len(s.ml.SheetData))
+
+	for _, row := range s.ml.SheetData {
+		if row == nil {
+			continue
+		}
+
+		nextRow := &ml.Row{}
+		*nextRow = *row
+		nextRow.Cells = make([]*ml.Cell, 0, len(row.Cells))
+
+		for iCol, cell := range row.Cells {
+			if isCellEmpty(cell) {
+				continue
+			}
+
+			nextCell := &ml.Cell{}
+			*nextCell = *cell
+			if s.isInitialized {
+				nextCell.Ref = types.CellRefFromIndexes(iCol, int(row.Ref-1))
+			}
+
+			if s.workbook.doc.stringsMode == StringsInline && nextCell.Type == types.CellTypeSharedString && len(nextCell.Value) > 0 {
+				sid, _ := strconv.Atoi(nextCell.Value)
+				nextCell.Type = types.CellTypeInlineString
+				nextCell.Value = ""
+				nextCell.InlineStr = s.workbook.doc.sharedStrings.inline(sid)
+			}
+
+			nextRow.Cells = append(nextRow.Cells, nextCell)
+		}
+
+		if !isRowEmpty(nextRow) {
+			w.SheetData = append(w.SheetData, nextRow)
+		}
+	}
+
+	if w.ConditionalFormatting != nil && len(*w.ConditionalFormatting) == 0 {
+		w.ConditionalFormatting = nil
+	}
+
+	return &w
+}
+
 //BeforeMarshalXML shrinks data to optimize output and returns related ML information for marshaling
 func (s *sheetReadWrite) BeforeMarshalXML() interface{} {
 	s.shrinkIfRequired()
@@ -347,13 +476,37 @@ func (s *sheetReadWrite) BeforeMarshalXML() interface{} {
 
 	s.conditionals.pack()
 
+	if s.workbook.doc.stringsMode == StringsInline {
+		s.inlineStrings()
+	}
+
 	return &s.ml
 }
 
+//AfterMarshalXML returns marshaled content as is, because there is nothing to fix for sheet that is fully loaded into memory
+func (s *sheetReadWrite) AfterMarshalXML(content []byte) []byte {
+	return content
+}
+
+//inlineStrings converts shared strings of sheet into inline strings
+func (s *sheetReadWrite) inlineStrings() {
+	for _, row := range s.ml.SheetData {
+		for _, c := range row.Cells {
+			if c != nil && c.Type == types.CellTypeSharedString && len(c.Value) > 0 {
+				sid, _ := strconv.Atoi(c.Value)
+				c.Type = types.CellTypeInlineString
+				c.Value = ""
+				c.InlineStr = s.workbook.doc.sharedStrings.inline(sid)
+			}
+		}
+	}
+}
+
 //afterOpen is callback that will be called right after requesting an already existing sheet. By default, it does nothing
 func (s *sheetReadWrite) afterOpen() {
 	//make a grid
 	s.file.LoadIfRequired(s.expandOnInit)
+	s.applyPatches()
 
 	//adds a styles for types
 	s.workbook.doc.styleSheet.addTypedStylesIfRequired()
@@ -0,0 +1,266 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/formula"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/plandem/xlsx/types"
+	"math"
+)
+
+//shiftBounds returns bounds after inserting (n > 0) or deleting (n < 0) of n rows or cols at 0-based index at. If bounds were deleted entirely, then false is returned
+func shiftBounds(bounds types.Bounds, at, n int, cols bool) (types.Bounds, bool) {
+	from, to, limit := &bounds.FromRow, &bounds.ToRow, internal.ExcelRowLimit
+	if cols {
+		from, to, limit = &bounds.FromCol, &bounds.ToCol, internal.ExcelColumnLimit
+	}
+
+	var ok bool
+	if *from, *to, ok = internal.ShiftIndexes(*from, *to, at, n); !ok || *from >= limit {
+		return bounds, false
+	}
+
+	if *to >= limit {
+		*to = limit - 1
+	}
+
+	return bounds, true
+}
+
+//shiftBoundsList returns list of bounds after inserting or deleting of rows or cols, deleted bounds are removed from list
+func shiftBoundsList(list types.BoundsList, at, n int, cols bool) types.BoundsList {
+	shifted := make(types.BoundsList, 0, len(list))
+	for _, b := range list {
+		if b, ok := shiftBounds(b, at, n, cols); ok {
+			shifted = append(shifted, b)
+		}
+	}
+
+	return shifted
+}
+
+//shiftFormula returns formula of this sheet with updated references to sheet with name after inserting or deleting of rows or cols
+func (s *sheetInfo) shiftFormula(content string, name string, at, n int, cols bool) string {
+	if cols {
+		return formula.ShiftCols(content, s.Name(), name, at, n)
+	}
+
+	return formula.ShiftRows(content, s.Name(), name, at, n)
+}
+
+//beforeShift unshares shared formulas of opened sheets that will be affected by inserting (n > 0) or deleting (n < 0) of n rows or cols at 0-based index at. Other sheets are updated after opening, so sheets are not opened for that
+func (s *sheetInfo) beforeShift(at, n int, cols bool) {
+	name := s.Name()
+	for _, sheet := range s.workbook.doc.sheets {
+		if sheet != nil && sheet.sheet != nil {
+			sheet.unshareFormulas(sheet == s, name, at, n, cols)
+		}
+	}
+}
+
+//unshareFormulas unshares shared formulas of this sheet that will be affected by inserting or deleting of rows or cols of sheet with name, where own is true for sheet with inserted or deleted rows or cols
+func (s *sheetInfo) unshareFormulas(own bool, name string, at, n int, cols bool) {
+	var shared []int
+	for _, row := range s.ml.SheetData {
+		for _, c := range row.Cells {
+			if c == nil || c.Formula == nil || c.Formula.Si == nil || !isSharedMaster(c, *c.Formula.Si) {
+				continue
+			}
+
+			//cells of shared formula must be unshared if references or positions of cells will be changed
+			from, end := c.Formula.Bounds.FromRow, c.Formula.Bounds.ToRow
+			if cols {
+				from, end = c.Formula.Bounds.FromCol, c.Formula.Bounds.ToCol
+			}
+
+			//relative references of other cells are moved by up to size of shared formula, so these references are checked too
+			affected := int(math.Max(0, float64(at-(end-from))))
+			if (own && end >= at) || s.shiftFormula(c.Formula.Content, name, affected, n, cols) != c.Formula.Content {
+				shared = append(shared, *c.Formula.Si)
+			}
+		}
+	}
+
+	for _, si := range shared {
+		s.formulas.unshare(si)
+	}
+}
+
+//afterShift updates formulas, merged cells, hyperlinks, conditional formatting, data validations, page breaks, ignored errors, defined names and dirty bounds after inserting (n > 0) or deleting (n < 0) of n rows or cols at 0-based index at. Sheets that were not opened are updated after opening
+func (s *sheetInfo) afterShift(at, n int, cols bool) {
+	name := s.Name()
+	for _, sheet := range s.workbook.doc.sheets {
+		if sheet == nil {
+			continue
+		}
+
+		if sheet.sheet != nil {
+			sheet.shiftRefs(sheet == s, name, at, n, cols)
+			continue
+		}
+
+		sheet.patch(func(sheet *sheetInfo) {
+			sheet.unshareFormulas(false, name, at, n, cols)
+			sheet.shiftRefs(false, name, at, n, cols)
+		})
+	}
+
+	mergedCells := make([]*ml.MergeCell, 0, len(s.ml.MergeCells.Items))
+	for _, mc := range s.ml.MergeCells.Items {
+		//merged cells that were reduced to the single cell are removed
+		if bounds, ok := shiftBounds(mc.Bounds, at, n, cols); ok {
+			if width, height := bounds.Dimension(); width*height == 1 {
+				continue
+			}
+
+			mc.Bounds = bounds
+			mergedCells = append(mergedCells, mc)
+		}
+	}
+
+	s.ml.MergeCells.Items = mergedCells
+
+	hyperlinks := make([]*ml.Hyperlink, 0, len(s.ml.Hyperlinks.Items))
+	for _, link := range s.ml.Hyperlinks.Items {
+		if bounds, ok := shiftBounds(link.Bounds, at, n, cols); ok {
+			link.Bounds = bounds
+			hyperlinks = append(hyperlinks, link)
+		} else {
+			s.hyperlinks.release(link)
+		}
+	}
+
+	s.ml.Hyperlinks.Items = hyperlinks
+
+	if s.ml.AutoFilter != nil {
+		if bounds, ok := shiftBounds(s.ml.AutoFilter.Bounds, at, n, cols); ok {
+			s.ml.AutoFilter.Bounds = bounds
+		} else {
+			s.ml.AutoFilter = nil
+		}
+	}
+
+	s.shiftPageBreaks(at, n, cols)
+	s.shiftIgnoredErrors(at, n, cols)
+	s.changes.shift(at, n, cols)
+	s.workbook.definedNames.shift(name, at, n, cols)
+}
+
+//shiftRefs updates formulas, conditional formatting and data validations of this sheet after inserting or deleting of rows or cols of sheet with name, where own is true for sheet with inserted or deleted rows or cols
+func (s *sheetInfo) shiftRefs(own bool, name string, at, n int, cols bool) {
+	for _, row := range s.ml.SheetData {
+		for _, c := range row.Cells {
+			if c == nil || c.Formula == nil || len(c.Formula.Content) == 0 {
+				continue
+			}
+
+			c.Formula.Content = s.shiftFormula(c.Formula.Content, name, at, n, cols)
+			if own && c.Formula.T == primitives.CellFormulaTypeArray {
+				c.Formula.Bounds, _ = shiftBounds(c.Formula.Bounds, at, n, cols)
+			}
+		}
+	}
+
+	if s.ml.ConditionalFormatting != nil {
+		conditionals := make([]*ml.ConditionalFormatting, 0, len(*s.ml.ConditionalFormatting))
+		for _, conditional := range *s.ml.ConditionalFormatting {
+			for _, rule := range conditional.Rules {
+				for i, f := range rule.Formula {
+					rule.Formula[i] = primitives.Formula(s.shiftFormula(string(f), name, at, n, cols))
+				}
+			}
+
+			if own {
+				conditional.Bounds = shiftBoundsList(conditional.Bounds, at, n, cols)
+			}
+
+			if len(conditional.Bounds) > 0 {
+				conditionals = append(conditionals, conditional)
+			}
+		}
+
+		*s.ml.ConditionalFormatting = conditionals
+	}
+
+	validations := make([]*ml.DataValidation, 0, len(s.ml.DataValidations.Items))
+	for _, validation := range s.ml.DataValidations.Items {
+		validation.Formula1 = primitives.Formula(s.shiftFormula(string(validation.Formula1), name, at, n, cols))
+		validation.Formula2 = primitives.Formula(s.shiftFormula(string(validation.Formula2), name, at, n, cols))
+
+		if own {
+			validation.Bounds = shiftBoundsList(validation.Bounds, at, n, cols)
+		}
+
+		if len(validation.Bounds) > 0 {
+			validations = append(validations, validation)
+		}
+	}
+
+	s.ml.DataValidations.Items = validations
+}
+
+//patch updates sheet with callback, e.g.: references after renaming of sheet. Opened sheets are updated right away, other sheets are updated after opening or right before saving, so sheets are not opened for that
+func (s *sheetInfo) patch(callback func(sheet *sheetInfo)) {
+	if s.sheet != nil {
+		callback(s)
+		return
+	}
+
+	s.patches = append(s.patches, callback)
+}
+
+//applyPatches updates sheet with changes that were made before opening of sheet
+func (s *sheetInfo) applyPatches() {
+	patches := s.patches
+	s.patches = nil
+	for _, callback := range patches {
+		callback(s)
+	}
+}
+
+//afterRename updates formulas, conditional formatting, data validations, hyperlinks, charts and defined names that refer to sheet after renaming it from name from to name to
+func (s *sheetInfo) afterRename(from, to string) {
+	for _, sheet := range s.workbook.doc.sheets {
+		if sheet != nil {
+			sheet.patch(func(sheet *sheetInfo) {
+				sheet.renameRefs(from, to)
+			})
+		}
+	}
+
+	s.workbook.doc.renameCharts(from, to)
+	s.workbook.definedNames.rename(from, to)
+}
+
+//renameRefs updates formulas, conditional formatting, data validations and hyperlinks of this sheet that refer to sheet after renaming it from name from to name to
+func (s *sheetInfo) renameRefs(from, to string) {
+	for _, row := range s.ml.SheetData {
+		for _, c := range row.Cells {
+			if c != nil && c.Formula != nil && len(c.Formula.Content) > 0 {
+				c.Formula.Content = formula.RenameSheet(c.Formula.Content, from, to)
+			}
+		}
+	}
+
+	if s.ml.ConditionalFormatting != nil {
+		for _, conditional := range *s.ml.ConditionalFormatting {
+			for _, rule := range conditional.Rules {
+				for i, f := range rule.Formula {
+					rule.Formula[i] = primitives.Formula(formula.RenameSheet(string(f), from, to))
+				}
+			}
+		}
+	}
+
+	for _, validation := range s.ml.DataValidations.Items {
+		validation.Formula1 = primitives.Formula(formula.RenameSheet(string(validation.Formula1), from, to))
+		validation.Formula2 = primitives.Formula(formula.RenameSheet(string(validation.Formula2), from, to))
+	}
+
+	for _, link := range s.ml.Hyperlinks.Items {
+		if len(link.Location) > 0 {
+			link.Location = formula.RenameSheet(link.Location, from, to)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package xlsx
+
+import (
+	"bytes"
+	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSheet_insertDeleteRows(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Data")
+	other := xl.AddSheet("Other")
+
+	for i := 0; i < 5; i++ {
+		sheet.Cell(0, i).SetValue(i + 1)
+	}
+
+	sheet.CellByRef("B1").SetFormula("SUM(A1:A5)")
+	sheet.CellByRef("B5").SetFormula("A5*2")
+	sheet.Range("C1:C5").SetSharedFormula("A1+1")
+	other.CellByRef("A1").SetFormula("Data!A4+A4")
+	require.Nil(t, xl.DefineName("Total", "Data!$A$1:$A$5"))
+	require.Nil(t, sheet.Range("D4:E5").Merge())
+	require.Nil(t, sheet.Range("F4").SetHyperlink("https://github.com/plandem/xlsx"))
+	require.Nil(t, sheet.AddConditional(format.NewConditions(format.Conditions.Rule(format.Condition.Priority(1), format.Condition.Type(format.ConditionTypeExpression), format.Condition.Formula("A4"))), "A4:A5"))
+	require.Nil(t, sheet.AddValidation(types.BoundsFromIndexes(6, 3, 6, 4), types.NewValidation(types.Validation.List("A", "B"))))
+
+	//insert
+	sheet.InsertRows(2, 2)
+	require.Equal(t, "3", sheet.CellByRef("A5").Value())
+	require.Equal(t, "", sheet.CellByRef("A3").Value())
+	require.Equal(t, "SUM(A1:A7)", sheet.CellByRef("B1").Formula())
+	require.Equal(t, "A7*2", sheet.CellByRef("B7").Formula())
+	require.Equal(t, "A2+1", sheet.CellByRef("C2").Formula())
+	require.Equal(t, "A5+1", sheet.CellByRef("C5").Formula())
+	require.Equal(t, "A7+1", sheet.CellByRef("C7").Formula())
+	require.Equal(t, "Data!A6+A4", other.CellByRef("A1").Formula())
+	require.Equal(t, "Data!$A$1:$A$7", xl.DefinedName("Total"))
+	require.Equal(t, []types.Bounds{types.BoundsFromIndexes(3, 5, 4, 6)}, sheet.MergedCells())
+	require.NotNil(t, sheet.CellByRef("F6").Hyperlink())
+	require.Nil(t, sheet.CellByRef("F4").Hyperlink())
+	require.Equal(t, types.BoundsList{types.BoundsFromIndexes(0, 5, 0, 6)}, (*sheet.info().ml.ConditionalFormatting)[0].Bounds)
+	require.Equal(t, []format.Formula{"A6"}, (*sheet.info().ml.ConditionalFormatting)[0].Rules[0].Formula)
+	require.NotNil(t, sheet.Validation("G6"))
+	require.Nil(t, sheet.Validation("G4"))
+
+	//delete
+	sheet.DeleteRows(1, 5)
+	require.Equal(t, "1", sheet.CellByRef("A1").Value())
+	require.Equal(t, "5", sheet.CellByRef("A2").Value())
+	require.Equal(t, "SUM(A1:A2)", sheet.CellByRef("B1").Formula())
+	require.Equal(t, "A2*2", sheet.CellByRef("B2").Formula())
+	require.Equal(t, "A2+1", sheet.CellByRef("C2").Formula())
+	require.Equal(t, "Data!#REF!+A4", other.CellByRef("A1").Formula())
+	require.Equal(t, "Data!$A$1:$A$2", xl.DefinedName("Total"))
+	require.Equal(t, []types.Bounds{types.BoundsFromIndexes(3, 1, 4, 1)}, sheet.MergedCells())
+	require.Nil(t, sheet.Validation("G1"))
+	require.NotNil(t, sheet.Validation("G2"))
+}
+
+func TestSheet_insertRowsLazy(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Data")
+	other := xl.AddSheet("Other")
+	other.Range("A1:A3").SetSharedFormula("Data!A1+1")
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, xl.SaveAs(buf))
+	xl.Close()
+
+	xl, err := OpenBytes(buf.Bytes())
+	require.Nil(t, err)
+	defer xl.Close()
+
+	//sheets that were not opened are updated after opening only
+	sheet = xl.Sheet(0)
+	sheet.InsertRows(1, 1)
+	require.Nil(t, xl.sheets[1].sheet)
+	require.Equal(t, 1, len(xl.sheets[1].patches))
+
+	other = xl.Sheet(1)
+	require.Equal(t, 0, len(xl.sheets[1].patches))
+	require.Equal(t, "Data!A1+1", other.CellByRef("A1").Formula())
+	require.Equal(t, "Data!A3+1", other.CellByRef("A2").Formula())
+	require.Equal(t, "Data!A4+1", other.CellByRef("A3").Formula())
+}
+
+func TestSheet_insertDeleteCols(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Data")
+	for i := 0; i < 5; i++ {
+		sheet.Cell(i, 0).SetValue(i + 1)
+	}
+
+	sheet.CellByRef("A2").SetFormula("SUM(A1:E1)+$C$1")
+	require.Nil(t, sheet.Range("D3:E3").Merge())
+	sheet.info().columns.Resolve(2).Width = 20
+
+	//insert
+	sheet.InsertCols(1, 2)
+	require.Equal(t, "1", sheet.CellByRef("A1").Value())
+	require.Equal(t, "2", sheet.CellByRef("D1").Value())
+	require.Equal(t, "SUM(A1:G1)+$E$1", sheet.CellByRef("A2").Formula())
+	require.Equal(t, []types.Bounds{types.BoundsFromIndexes(5, 2, 6, 2)}, sheet.MergedCells())
+	require.Equal(t, float32(20), sheet.info().columns.Get(4).Width)
+	require.Nil(t, sheet.info().columns.Get(2))
+
+	//delete
+	sheet.DeleteCols(2, 3)
+	require.Equal(t, "1", sheet.CellByRef("A1").Value())
+	require.Equal(t, "4", sheet.CellByRef("C1").Value())
+	require.Equal(t, "SUM(A1:D1)+#REF!", sheet.CellByRef("A2").Formula())
+	require.Equal(t, []types.Bounds{types.BoundsFromIndexes(2, 2, 3, 2)}, sheet.MergedCells())
+	require.Nil(t, sheet.info().columns.Get(4))
+}
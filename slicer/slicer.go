@@ -0,0 +1,136 @@
+package slicer
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml"
+)
+
+const (
+	//DefaultStyle is a name of built-in style that is used by default
+	DefaultStyle = "SlicerStyleLight1"
+
+	//defaultRowHeight is a height of buttons in EMU that Excel uses by default
+	defaultRowHeight = 241300
+
+	//maxColumns is a max number of columns with buttons that Excel allows
+	maxColumns = 20000
+)
+
+//Info is objects that holds information about slicer
+type Info struct {
+	slicer *ml.Slicer
+}
+
+//Option is a type of option for slicer
+type Option func(i *Info)
+
+//New creates and returns a new Info object that holds settings for slicer
+func New(options ...Option) *Info {
+	i := &Info{
+		slicer: &ml.Slicer{
+			Style:     DefaultStyle,
+			RowHeight: defaultRowHeight,
+		},
+	}
+
+	i.Set(options...)
+	return i
+}
+
+//Set sets new options for slicer
+func (i *Info) Set(options ...Option) {
+	for _, o := range options {
+		o(i)
+	}
+}
+
+//Name returns name of slicer
+func (i *Info) Name() string {
+	return i.slicer.Name
+}
+
+//Caption returns caption of slicer
+func (i *Info) Caption() string {
+	return i.slicer.Caption
+}
+
+//Style returns name of style of slicer
+func (i *Info) Style() string {
+	return i.slicer.Style
+}
+
+//Columns returns number of columns with buttons
+func (i *Info) Columns() uint {
+	if i.slicer.ColumnCount == 0 {
+		return 1
+	}
+
+	return i.slicer.ColumnCount
+}
+
+//Validate validates settings of slicer
+func (i *Info) Validate() error {
+	if i.slicer.ColumnCount > maxColumns {
+		return errors.New(fmt.Sprintf("slicer can't have more than %d columns", maxColumns))
+	}
+
+	if len(i.slicer.Style) == 0 {
+		return errors.New("style of slicer is required")
+	}
+
+	return nil
+}
+
+//Name sets name of slicer. Name must be unique across all slicers of workbook. By default, name of field is used
+func Name(name string) Option {
+	return func(i *Info) {
+		i.slicer.Name = name
+	}
+}
+
+//Caption sets caption of slicer. By default, name of field is used
+func Caption(caption string) Option {
+	return func(i *Info) {
+		i.slicer.Caption = caption
+	}
+}
+
+//Style sets name of style for slicer, e.g.: SlicerStyleDark2
+func Style(name string) Option {
+	return func(i *Info) {
+		i.slicer.Style = name
+	}
+}
+
+//Columns sets number of columns with buttons
+func Columns(n uint) Option {
+	return func(i *Info) {
+		i.slicer.ColumnCount = n
+	}
+}
+
+//HideCaption hides header with caption of slicer
+func HideCaption(i *Info) {
+	showCaption := false
+	i.slicer.ShowCaption = &showCaption
+}
+
+//LockPosition prevents slicer from being moved or resized
+func LockPosition(i *Info) {
+	i.slicer.LockedPosition = true
+}
+
+//private method used by slicers manager to unpack Info
+func fromSlicerInfo(info *Info) (*ml.Slicer, error) {
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+
+	s := *info.slicer
+	if s.ColumnCount == 1 {
+		s.ColumnCount = 0
+	}
+
+	return &s, nil
+}
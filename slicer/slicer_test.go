@@ -0,0 +1,47 @@
+package slicer
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSlicer(t *testing.T) {
+	//default settings
+	info := New()
+	require.Equal(t, "", info.Name())
+	require.Equal(t, "", info.Caption())
+	require.Equal(t, DefaultStyle, info.Style())
+	require.Equal(t, uint(1), info.Columns())
+
+	s, err := fromSlicerInfo(info)
+	require.Nil(t, err)
+	require.Equal(t, &ml.Slicer{Style: DefaultStyle, RowHeight: defaultRowHeight}, s)
+
+	//custom settings
+	showCaption := false
+	info = New(Name("Region"), Caption("Sales Region"), Style("SlicerStyleDark2"), Columns(3), HideCaption, LockPosition)
+	require.Equal(t, "Region", info.Name())
+	require.Equal(t, "Sales Region", info.Caption())
+	require.Equal(t, "SlicerStyleDark2", info.Style())
+	require.Equal(t, uint(3), info.Columns())
+
+	s, err = fromSlicerInfo(info)
+	require.Nil(t, err)
+	require.Equal(t, &ml.Slicer{
+		Name:           "Region",
+		Caption:        "Sales Region",
+		Style:          "SlicerStyleDark2",
+		ColumnCount:    3,
+		ShowCaption:    &showCaption,
+		LockedPosition: true,
+		RowHeight:      defaultRowHeight,
+	}, s)
+
+	//invalid settings
+	_, err = fromSlicerInfo(New(Columns(maxColumns + 1)))
+	require.NotNil(t, err)
+
+	_, err = fromSlicerInfo(New(Style("")))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,225 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/plandem/ooxml"
+	sharedML "github.com/plandem/ooxml/ml"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/slicer"
+	"github.com/plandem/xlsx/types"
+	"regexp"
+	"sort"
+	"strings"
+	_ "unsafe"
+)
+
+//go:linkname fromSlicerInfo github.com/plandem/xlsx/slicer.fromSlicerInfo
+func fromSlicerInfo(info *slicer.Info) (*ml.Slicer, error)
+
+var (
+	regExpSlicer           = regexp.MustCompile(`^xl/slicers/slicer\d+\.xml$`)
+	regExpSlicerCacheChars = regexp.MustCompile(`[^\p{L}\p{N}_]`)
+)
+
+type slicers struct {
+	doc      *Spreadsheet
+	names    []string
+	isLoaded bool
+}
+
+//newSlicers creates an object that implements slicers functionality
+func newSlicers(doc *Spreadsheet) *slicers {
+	return &slicers{doc: doc}
+}
+
+//loadIfRequired lookups for names of existing slicers of workbook
+func (s *slicers) loadIfRequired() {
+	if s.isLoaded {
+		return
+	}
+
+	s.isLoaded = true
+	files := s.doc.pkg.Files()
+	fileNames := make([]string, 0, len(files))
+	for fileName := range files {
+		fileNames = append(fileNames, fileName)
+	}
+
+	//N.B.: names of slicers must be in same order for each loading
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		if zf, ok := files[fileName].(*zip.File); ok && regExpSlicer.MatchString(zf.Name) {
+			if reader, err := zf.Open(); err == nil {
+				list := ml.Slicers{}
+				if err := xml.NewDecoder(reader).Decode(&list); err == nil {
+					for _, item := range list.Items {
+						s.names = append(s.names, item.Name)
+					}
+				}
+
+				_ = reader.Close()
+			}
+		}
+	}
+}
+
+//isUnique returns true if there is no slicer with name at workbook
+func (s *slicers) isUnique(name string) bool {
+	for _, n := range s.names {
+		if strings.EqualFold(n, name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+//Add adds a new slicer that fits bounds at sheet for field of table or pivot table with name. Table or pivot table must be at the same sheet
+func (s *slicers) Add(sheet *sheetInfo, bounds types.Bounds, source string, field string, info *slicer.Info) error {
+	s.loadIfRequired()
+
+	if info == nil {
+		return errors.New("no slicer info")
+	}
+
+	if bounds.IsEmpty() {
+		return errors.New("no bounds for slicer")
+	}
+
+	item, err := fromSlicerInfo(info)
+	if err != nil {
+		return err
+	}
+
+	cache := &ml.SlicerCacheDefinition{}
+	isTable := false
+	if idx := sheet.tables.index(source); idx != -1 {
+		tbl := &sheet.tables.items[idx].ml
+		for _, column := range tbl.Columns.Items {
+			if strings.EqualFold(column.Name, field) {
+				cache.SourceName = column.Name
+				cache.ExtLst = &sharedML.Reserved{InnerXML: &sharedML.InnerXML{
+					XML: fmt.Sprintf(`<x:ext uri="%s" xmlns:x="%s" xmlns:x15="%s"><x15:tableSlicerCache tableId="%d" column="%d"/></x:ext>`, ml.ExtURITableSlicerCache, ml.NamespaceMain, ml.NamespaceX15, tbl.ID, column.ID),
+				}}
+				break
+			}
+		}
+
+		isTable = true
+	} else if pc := sheet.pivotTables.cache(source); pc != nil {
+		for _, name := range pc.fields() {
+			if strings.EqualFold(name, field) {
+				cache.SourceName = name
+				break
+			}
+		}
+
+		for _, name := range sheet.pivotTables.names {
+			if strings.EqualFold(name, source) {
+				source = name
+				break
+			}
+		}
+
+		if len(cache.SourceName) > 0 {
+			//items will be populated by Excel during refreshing of pivot table
+			cache.PivotTables = &ml.SlicerCachePivotTables{Items: []*ml.SlicerCachePivotTable{{
+				TabID: sheet.workbook.ml.Sheets[sheet.index].SheetID,
+				Name:  source,
+			}}}
+			cache.Data = &ml.SlicerCacheData{Tabular: &ml.TabularSlicerCache{PivotCacheID: uint(pc.extensionID())}}
+		}
+	} else {
+		return errors.New(fmt.Sprintf("there is no table or pivot table with name '%s' at sheet", source))
+	}
+
+	if len(cache.SourceName) == 0 {
+		return errors.New(fmt.Sprintf("there is no field '%s' at '%s'", field, source))
+	}
+
+	//name of slicer must be unique across workbook
+	if len(item.Name) == 0 {
+		item.Name = cache.SourceName
+		for i := 1; !s.isUnique(item.Name); i++ {
+			item.Name = fmt.Sprintf("%s %d", cache.SourceName, i)
+		}
+	} else if !s.isUnique(item.Name) {
+		return errors.New(fmt.Sprintf("slicer with name '%s' already exists", item.Name))
+	}
+
+	if len(item.Caption) == 0 {
+		item.Caption = cache.SourceName
+	}
+
+	//name of cache is a defined name, so must be unique across defined names
+	definedNames := sheet.workbook.definedNames
+	cache.Name = "Slicer_" + regExpSlicerCacheChars.ReplaceAllString(cache.SourceName, "_")
+	for i, base := 1, cache.Name; definedNames.index(cache.Name, -1) != -1; i++ {
+		cache.Name = fmt.Sprintf("%s%d", base, i)
+	}
+
+	if err := definedNames.Add(cache.Name, "#N/A", -1); err != nil {
+		return err
+	}
+
+	item.Cache = cache.Name
+
+	doc := s.doc
+	cacheFileName := doc.uniqueFileName("xl/slicerCaches/slicerCache%d.xml")
+	ooxml.NewPackageFile(doc.pkg, cacheFileName, cache, nil).MarkAsUpdated()
+	doc.pkg.ContentTypes().RegisterContent(cacheFileName, internal.ContentTypeSlicerCache)
+	_, cacheRID := doc.relationships.AddFile(internal.RelationTypeSlicerCache, cacheFileName)
+
+	fileName := doc.uniqueFileName("xl/slicers/slicer%d.xml")
+	ooxml.NewPackageFile(doc.pkg, fileName, &ml.Slicers{Items: []*ml.Slicer{item}}, nil).MarkAsUpdated()
+	doc.pkg.ContentTypes().RegisterContent(fileName, internal.ContentTypeSlicer)
+	sheet.attachRelationshipsIfRequired()
+	_, rid := sheet.relationships.AddFile(internal.RelationTypeSlicer, fileName)
+
+	//slicers of tables were introduced by Excel 2013, slicers of pivot tables - by Excel 2010
+	if isTable {
+		namespaceX15 := fmt.Sprintf(` xmlns:x15="%s"`, ml.NamespaceX15)
+		sheet.workbook.addExtension(ml.ExtURITableSlicerCaches, "x15:slicerCaches", namespaceX15, fmt.Sprintf(`<x14:slicerCache r:id="%s"/>`, cacheRID))
+		sheet.addExtension(ml.ExtURITableSlicerList, "x14:slicerList", namespaceX15, fmt.Sprintf(`<x14:slicer r:id="%s"/>`, rid))
+	} else {
+		sheet.workbook.addExtension(ml.ExtURISlicerCaches, "x14:slicerCaches", "", fmt.Sprintf(`<x14:slicerCache r:id="%s"/>`, cacheRID))
+		sheet.addExtension(ml.ExtURISlicerList, "x14:slicerList", "", fmt.Sprintf(`<x14:slicer r:id="%s"/>`, rid))
+	}
+
+	sheet.drawings.addSlicer(bounds, item.Name)
+	s.names = append(s.names, item.Name)
+	return nil
+}
+
+//addSlicer adds a new graphic frame for slicer with name that fits bounds
+func (d *drawings) addSlicer(bounds types.Bounds, name string) {
+	d.initIfRequired()
+
+	d.ml.TwoCellAnchors = append(d.ml.TwoCellAnchors, &ml.TwoCellAnchor{
+		From:   &ml.DrawingMarker{Col: bounds.FromCol, Row: bounds.FromRow},
+		To:     &ml.DrawingMarker{Col: bounds.ToCol + 1, Row: bounds.ToRow + 1},
+		EditAs: "oneCell",
+		GraphicFrame: &ml.GraphicFrame{
+			NonVisual: ml.GraphicFrameNonVisual{
+				DrawingProperties:      ml.NonVisualDrawingProperties{ID: d.nextID(), Name: name},
+				GraphicFrameProperties: &sharedML.Reserved{},
+			},
+			Transform: ml.Transform2D{
+				Offset: &ml.Point2D{},
+				Ext:    &ml.PositiveSize2D{},
+			},
+			Graphic: ml.Graphic{
+				Data: ml.GraphicData{
+					URI:    "http://schemas.microsoft.com/office/drawing/2010/slicer",
+					Slicer: &ml.GraphicSlicer{Name: name},
+				},
+			},
+		},
+	})
+
+	d.file.MarkAsUpdated()
+}
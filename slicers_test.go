@@ -0,0 +1,82 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/pivot"
+	"github.com/plandem/xlsx/slicer"
+	"github.com/plandem/xlsx/table"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestSlicers(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Sheet1")
+	sheet.CellByRef("A1").SetValue("Region")
+	sheet.CellByRef("B1").SetValue("Amount")
+	sheet.CellByRef("A2").SetValue("North")
+	sheet.CellByRef("B2").SetValue(100)
+	sheet.CellByRef("A3").SetValue("South")
+	sheet.CellByRef("B3").SetValue(200)
+
+	source := types.BoundsFromIndexes(0, 0, 1, 2)
+	require.Nil(t, sheet.AddTable(source, table.Name("Sales")))
+	require.Nil(t, sheet.AddPivotTable("H1", source, pivot.Name("Report"), pivot.Rows("Region"), pivot.Value("Amount", pivot.Sum)))
+
+	//invalid slicers
+	bounds := types.BoundsFromIndexes(3, 0, 5, 10)
+	require.NotNil(t, sheet.AddSlicer(bounds, "Unknown", "Region"))
+	require.NotNil(t, sheet.AddSlicer(bounds, "Sales", "Unknown"))
+	require.NotNil(t, sheet.AddSlicer(bounds, "Report", "Unknown"))
+	require.NotNil(t, sheet.AddSlicer(bounds, "Sales", "Region", slicer.Style("")))
+	require.Nil(t, sheet.info().drawings.file)
+
+	//slicer for table
+	require.Nil(t, sheet.AddSlicer(bounds, "sales", "region", slicer.Caption("Sales Region"), slicer.Style("SlicerStyleDark2")))
+	require.NotNil(t, sheet.AddSlicer(bounds, "Sales", "Amount", slicer.Name("Region")))
+	require.Equal(t, []string{"Region"}, xl.slicers.names)
+	require.Equal(t, "#N/A", xl.workbook.ml.DefinedNames.Items[xl.workbook.definedNames.index("Slicer_Region", -1)].Formula)
+	require.Equal(t, internal.RelationTypeSlicerCache, xl.relationships.GetTypeById(string(xl.relationships.GetIdByTarget("xl/slicerCaches/slicerCache1.xml"))))
+	require.Equal(t, internal.RelationTypeSlicer, sheet.info().relationships.GetTypeById(string(sheet.info().relationships.GetIdByTarget("xl/slicers/slicer1.xml"))))
+	require.True(t, strings.Contains(xl.workbook.ml.ExtLst.InnerXML.XML, ml.ExtURITableSlicerCaches))
+	require.True(t, strings.Contains(sheet.info().ml.ExtLst.InnerXML.XML, ml.ExtURITableSlicerList))
+
+	anchor := sheet.info().drawings.ml.TwoCellAnchors[0]
+	require.Equal(t, &ml.DrawingMarker{Col: 3, Row: 0}, anchor.From)
+	require.Equal(t, &ml.DrawingMarker{Col: 6, Row: 11}, anchor.To)
+	require.Equal(t, "Region", anchor.GraphicFrame.NonVisual.DrawingProperties.Name)
+	require.Equal(t, &ml.GraphicSlicer{Name: "Region"}, anchor.GraphicFrame.Graphic.Data.Slicer)
+
+	//slicer for pivot table gets unique names
+	require.Nil(t, sheet.AddSlicer(types.BoundsFromIndexes(3, 12, 5, 20), "Report", "Region", slicer.Columns(2)))
+	require.Equal(t, []string{"Region", "Region 1"}, xl.slicers.names)
+	require.NotEqual(t, -1, xl.workbook.definedNames.index("Slicer_Region1", -1))
+	require.True(t, strings.Contains(xl.workbook.ml.ExtLst.InnerXML.XML, ml.ExtURISlicerCaches))
+	require.True(t, strings.Contains(sheet.info().ml.ExtLst.InnerXML.XML, ml.ExtURISlicerList))
+	require.Equal(t, 1, sheet.info().pivotTables.cache("Report").extensionID())
+	require.Equal(t, 2, len(sheet.info().drawings.ml.TwoCellAnchors))
+
+	//slicers are not copied with sheet
+	copied, err := xl.CopySheet(0, "Copy")
+	require.Nil(t, err)
+	require.False(t, strings.Contains(copied.info().ml.ExtLst.InnerXML.XML, ml.ExtURISlicerList))
+	require.False(t, strings.Contains(copied.info().ml.ExtLst.InnerXML.XML, ml.ExtURITableSlicerList))
+
+	//save and reopen
+	err = xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	require.NotNil(t, sheet.AddSlicer(types.BoundsFromIndexes(3, 22, 5, 30), "Sales", "Region", slicer.Name("region 1")))
+	require.Nil(t, sheet.AddSlicer(types.BoundsFromIndexes(3, 22, 5, 30), "Report", "Region"))
+	require.Equal(t, []string{"Region", "Region 1", "Region 2"}, xl.slicers.names)
+	require.Equal(t, 1, sheet.info().pivotTables.cache("Report").extensionID())
+}
@@ -0,0 +1,176 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/formula"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/plandem/xlsx/internal/number_format/convert"
+	"github.com/plandem/xlsx/sorting"
+	"github.com/plandem/xlsx/types"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//sortedRow is a snapshot of row of sorted range
+type sortedRow struct {
+	rIdx   int
+	values []sorting.Value
+	cells  []copiedCell
+}
+
+//sortValue returns resolved value of cell to compare cells during sorting
+func sortValue(c *Cell) sorting.Value {
+	value := c.Value()
+	if len(value) == 0 {
+		return sorting.Value{Kind: sorting.Empty}
+	}
+
+	switch c.ml.Type {
+	case types.CellTypeBool:
+		if b, err := convert.ToBool(value); err == nil {
+			if b {
+				return sorting.Value{Kind: sorting.Bool, Number: 1, Text: value}
+			}
+
+			return sorting.Value{Kind: sorting.Bool, Text: value}
+		}
+	case types.CellTypeError:
+		return sorting.Value{Kind: sorting.Error, Text: value}
+	case types.CellTypeDate:
+		if d, err := time.Parse(convert.ISO8601, value); err == nil {
+			return sorting.Value{Kind: sorting.Number, Number: c.sheet.workbook.toSerial(d), Text: value}
+		}
+	case types.CellTypeNumber, types.CellTypeGeneral:
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return sorting.Value{Kind: sorting.Number, Number: f, Text: value}
+		}
+	}
+
+	return sorting.Value{Kind: sorting.Text, Text: value}
+}
+
+//Sort sorts rows of bounds by values of columns with keys, e.g.: Sort(bounds, sorting.ByColumn(2, sorting.Desc), sorting.ByColumn(0, sorting.Asc)).
+//Styles, hyperlinks and merged cells of rows are moved together with values, relative references of formulas are shifted. Merged cells and hyperlinks that span few rows of bounds are not allowed
+func (s *sheetInfo) Sort(bounds types.Bounds, keys ...*sorting.Key) error {
+	if len(keys) == 0 {
+		return errors.New("at least one key is required for sorting")
+	}
+
+	for _, key := range keys {
+		if err := key.Validate(); err != nil {
+			return err
+		}
+
+		if bounds.FromCol+key.Column() > bounds.ToCol {
+			return errors.New(fmt.Sprintf("column %d for sorting is out of range %s", key.Column(), bounds))
+		}
+	}
+
+	var mergedCells []*ml.MergeCell
+	for _, mc := range s.ml.MergeCells.Items {
+		if mc.Bounds.Overlaps(bounds) {
+			if mc.Bounds.FromRow != mc.Bounds.ToRow || mc.Bounds.FromCol < bounds.FromCol || mc.Bounds.ToCol > bounds.ToCol {
+				return errors.New(fmt.Sprintf("can't sort range %s with merged cells %s that are not a part of single row", bounds, mc.Bounds))
+			}
+
+			mergedCells = append(mergedCells, mc)
+		}
+	}
+
+	var hyperlinks []*ml.Hyperlink
+	for _, link := range s.ml.Hyperlinks.Items {
+		if link.Bounds.Overlaps(bounds) {
+			if link.Bounds.FromRow != link.Bounds.ToRow || link.Bounds.FromCol < bounds.FromCol || link.Bounds.ToCol > bounds.ToCol {
+				return errors.New(fmt.Sprintf("can't sort range %s with hyperlink %s that is not a part of single row", bounds, link.Bounds))
+			}
+
+			hyperlinks = append(hyperlinks, link)
+		}
+	}
+
+	//expand grid to required size
+	s.sheet.Cell(bounds.ToCol, bounds.ToRow)
+
+	rows := make([]*sortedRow, 0, bounds.ToRow-bounds.FromRow+1)
+	for rIdx := bounds.FromRow; rIdx <= bounds.ToRow; rIdx++ {
+		row := &sortedRow{rIdx: rIdx}
+		for _, key := range keys {
+			row.values = append(row.values, sortValue(s.sheet.Cell(bounds.FromCol+key.Column(), rIdx)))
+		}
+
+		for cIdx := bounds.FromCol; cIdx <= bounds.ToCol; cIdx++ {
+			copied := copiedCell{cIdx: cIdx, rIdx: rIdx}
+
+			//N.B.: grid is used directly, because cells of merged range are resolved into the top left cell
+			if cell := s.ml.SheetData[rIdx].Cells[cIdx]; !isCellEmpty(cell) {
+				data := *cell
+				copied.ml = &data
+				copied.formula = s.formulas.resolve(cell)
+			}
+
+			row.cells = append(row.cells, copied)
+		}
+
+		rows = append(rows, row)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		for k, key := range keys {
+			if result := key.Compare(rows[i].values[k], rows[j].values[k]); result != 0 {
+				return result < 0
+			}
+		}
+
+		return false
+	})
+
+	//map old indexes of rows into the new indexes
+	moved := make(map[int]int, len(rows))
+	for i, row := range rows {
+		moved[row.rIdx] = bounds.FromRow + i
+	}
+
+	for _, row := range rows {
+		rIdx := moved[row.rIdx]
+		for _, copied := range row.cells {
+			//N.B.: grid is used directly, because cells of merged range are resolved into the top left cell
+			if copied.ml == nil {
+				s.ml.SheetData[rIdx].Cells[copied.cIdx] = nil
+				continue
+			}
+
+			target := copied.ml
+			target.Ref = types.CellRefFromIndexes(copied.cIdx, rIdx)
+			if target.Formula != nil {
+				//N.B.: cells of shared formula become cells with normal formula
+				array, arrayBounds := target.Formula.T == primitives.CellFormulaTypeArray, target.Formula.Bounds
+				target.Formula = &ml.CellFormula{Content: formula.Shift(copied.formula, 0, rIdx-row.rIdx)}
+				if array {
+					target.Formula.T = primitives.CellFormulaTypeArray
+					target.Formula.Bounds = arrayBounds
+					target.Formula.Bounds.FromRow += rIdx - row.rIdx
+					target.Formula.Bounds.ToRow += rIdx - row.rIdx
+				}
+			}
+
+			s.ml.SheetData[rIdx].Cells[copied.cIdx] = target
+		}
+	}
+
+	s.changes.extend(bounds)
+
+	for _, mc := range mergedCells {
+		mc.Bounds.FromRow = moved[mc.Bounds.FromRow]
+		mc.Bounds.ToRow = mc.Bounds.FromRow
+	}
+
+	for _, link := range hyperlinks {
+		link.Bounds.FromRow = moved[link.Bounds.FromRow]
+		link.Bounds.ToRow = link.Bounds.FromRow
+	}
+
+	return nil
+}
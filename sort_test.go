@@ -0,0 +1,73 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/sorting"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSort(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Sort")
+	bold := xl.AddFormatting(format.NewStyles(format.Font.Bold))
+
+	sheet.CellByRef("A1").SetValue("b")
+	sheet.CellByRef("B1").SetValue(10)
+	sheet.CellByRef("C1").SetFormula("B1*2")
+	sheet.CellByRef("A2").SetValue("a")
+	sheet.CellByRef("B2").SetValue(2)
+	sheet.CellByRef("B2").SetFormatting(bold)
+	require.Nil(t, sheet.CellByRef("A2").SetHyperlink("https://github.com/plandem/xlsx"))
+	sheet.CellByRef("A3").SetValue("C")
+	sheet.CellByRef("B3").SetValue(10)
+	require.Nil(t, sheet.Range("C3:D3").Merge())
+	sheet.CellByRef("A4").SetValue("d")
+
+	//multiple keys, empty values are last
+	require.Nil(t, sheet.Sort(types.BoundsFromIndexes(0, 0, 3, 3), sorting.ByColumn(1, sorting.Desc), sorting.ByColumn(0, sorting.Asc)))
+	require.Equal(t, "b", sheet.CellByRef("A1").Value())
+	require.Equal(t, "C", sheet.CellByRef("A2").Value())
+	require.Equal(t, "a", sheet.CellByRef("A3").Value())
+	require.Equal(t, "d", sheet.CellByRef("A4").Value())
+	require.Equal(t, "B1*2", sheet.CellByRef("C1").Formula())
+	require.Equal(t, bold, sheet.CellByRef("B3").Formatting())
+	require.Equal(t, sheet.CellByRef("B1").Formatting(), sheet.CellByRef("B2").Formatting())
+	require.Equal(t, "https://github.com/plandem/xlsx", sheet.CellByRef("A3").Hyperlink().String())
+	require.Nil(t, sheet.CellByRef("A2").Hyperlink())
+	require.Equal(t, []types.Bounds{types.BoundsFromIndexes(2, 1, 3, 1)}, sheet.MergedCells())
+
+	//formulas are shifted
+	require.Nil(t, sheet.Sort(types.BoundsFromIndexes(0, 0, 3, 3), sorting.ByColumn(0, sorting.Desc)))
+	require.Equal(t, "d", sheet.CellByRef("A1").Value())
+	require.Equal(t, "b", sheet.CellByRef("A3").Value())
+	require.Equal(t, "B3*2", sheet.CellByRef("C3").Formula())
+
+	//dates and custom comparator
+	sheet = xl.AddSheet("Dates")
+	sheet.CellByRef("A1").SetDate(time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC))
+	sheet.CellByRef("A2").SetDate(time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC))
+	sheet.CellByRef("B1").SetValue("x-2")
+	sheet.CellByRef("B2").SetValue("y-1")
+
+	require.Nil(t, sheet.Sort(types.BoundsFromIndexes(0, 0, 1, 1), sorting.ByColumn(0, sorting.Asc)))
+	require.Equal(t, "y-1", sheet.CellByRef("B1").Value())
+
+	bySuffix := func(a, b sorting.Value) int {
+		return strings.Compare(a.Text[strings.Index(a.Text, "-"):], b.Text[strings.Index(b.Text, "-"):])
+	}
+
+	require.Nil(t, sheet.Sort(types.BoundsFromIndexes(0, 0, 1, 1), sorting.ByColumnWith(1, sorting.Desc, bySuffix)))
+	require.Equal(t, "x-2", sheet.CellByRef("B1").Value())
+
+	//invalid settings
+	require.NotNil(t, sheet.Sort(types.BoundsFromIndexes(0, 0, 1, 1)))
+	require.NotNil(t, sheet.Sort(types.BoundsFromIndexes(0, 0, 1, 1), sorting.ByColumn(2, sorting.Asc)))
+	require.Nil(t, sheet.Range("A1:A2").Merge())
+	require.NotNil(t, sheet.Sort(types.BoundsFromIndexes(0, 0, 1, 1), sorting.ByColumn(1, sorting.Asc)))
+}
@@ -0,0 +1,123 @@
+package sorting
+
+import (
+	"errors"
+	"strings"
+)
+
+//Order is a type of order to sort values of column
+type Order byte
+
+//List of all possible values for Order
+const (
+	Asc  Order = iota //ascending order
+	Desc              //descending order
+)
+
+//Kind is a type of value of cell that is used to compare values of different types
+type Kind byte
+
+//List of all possible values for Kind, in ascending order of sorting (Excel behavior)
+const (
+	Number Kind = iota //numbers, dates and times
+	Text
+	Bool
+	Error
+	Empty
+)
+
+//Value is a resolved value of cell that is used to compare cells. Dates and times are resolved into serial numbers
+type Value struct {
+	Kind   Kind
+	Number float64
+	Text   string
+}
+
+//Comparator is a type of function that compares values a and b and returns negative number if a < b, zero if a == b and positive number if a > b
+type Comparator func(a, b Value) int
+
+//Key is objects that holds information about column to sort rows by
+type Key struct {
+	column  int
+	order   Order
+	compare Comparator
+}
+
+//ByColumn creates and returns a new Key to sort rows by values of column with 0-based index relative to the first column of sorted range
+func ByColumn(column int, order Order) *Key {
+	return &Key{column: column, order: order, compare: Compare}
+}
+
+//ByColumnWith creates and returns a new Key to sort rows by values of column with 0-based index relative to the first column of sorted range with custom comparator
+func ByColumnWith(column int, order Order, compare Comparator) *Key {
+	return &Key{column: column, order: order, compare: compare}
+}
+
+//Column returns 0-based index of column relative to the first column of sorted range
+func (k *Key) Column() int {
+	return k.column
+}
+
+//Order returns order of sorting
+func (k *Key) Order() Order {
+	return k.order
+}
+
+//Validate validates settings of key and return error in case of invalid settings
+func (k *Key) Validate() error {
+	if k.column < 0 {
+		return errors.New("index of column for sorting can't be negative")
+	}
+
+	if k.order != Asc && k.order != Desc {
+		return errors.New("unknown order of sorting")
+	}
+
+	if k.compare == nil {
+		return errors.New("comparator for sorting is required")
+	}
+
+	return nil
+}
+
+//Compare compares values a and b according to order of key. Empty values are always placed after other values (Excel behavior)
+func (k *Key) Compare(a, b Value) int {
+	switch {
+	case a.Kind == Empty && b.Kind == Empty:
+		return 0
+	case a.Kind == Empty:
+		return 1
+	case b.Kind == Empty:
+		return -1
+	}
+
+	result := k.compare(a, b)
+	if k.order == Desc {
+		result = -result
+	}
+
+	return result
+}
+
+//Compare is a default comparator that compares values a and b in ascending order of Excel: numbers, text, booleans, errors and empty values. Text is compared case-insensitive
+func Compare(a, b Value) int {
+	if a.Kind != b.Kind {
+		return int(a.Kind) - int(b.Kind)
+	}
+
+	switch a.Kind {
+	case Number, Bool:
+		switch {
+		case a.Number < b.Number:
+			return -1
+		case a.Number > b.Number:
+			return 1
+		}
+
+		return 0
+	case Text, Error:
+		return strings.Compare(strings.ToLower(a.Text), strings.ToLower(b.Text))
+	}
+
+	return 0
+}
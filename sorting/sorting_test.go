@@ -0,0 +1,31 @@
+package sorting
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSorting(t *testing.T) {
+	//invalid settings
+	require.NotNil(t, ByColumn(-1, Asc).Validate())
+	require.NotNil(t, ByColumn(0, Order(10)).Validate())
+	require.NotNil(t, ByColumnWith(0, Asc, nil).Validate())
+
+	key := ByColumn(1, Desc)
+	require.Nil(t, key.Validate())
+	require.Equal(t, 1, key.Column())
+	require.Equal(t, Desc, key.Order())
+
+	//default comparator
+	require.True(t, Compare(Value{Kind: Number, Number: 1}, Value{Kind: Number, Number: 2}) < 0)
+	require.True(t, Compare(Value{Kind: Number, Number: 100}, Value{Kind: Text, Text: "1"}) < 0)
+	require.True(t, Compare(Value{Kind: Text, Text: "a"}, Value{Kind: Text, Text: "B"}) < 0)
+	require.Equal(t, 0, Compare(Value{Kind: Text, Text: "a"}, Value{Kind: Text, Text: "A"}))
+	require.True(t, Compare(Value{Kind: Text, Text: "z"}, Value{Kind: Bool}) < 0)
+	require.True(t, Compare(Value{Kind: Bool, Number: 1}, Value{Kind: Error, Text: "#N/A"}) < 0)
+
+	//order and empty values
+	require.True(t, key.Compare(Value{Kind: Number, Number: 2}, Value{Kind: Number, Number: 1}) < 0)
+	require.True(t, key.Compare(Value{Kind: Number, Number: 2}, Value{Kind: Empty}) < 0)
+	require.True(t, ByColumn(0, Asc).Compare(Value{Kind: Empty}, Value{Kind: Text, Text: "a"}) > 0)
+}
@@ -0,0 +1,31 @@
+package sparkline
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+//AxisType is alias of original primitives.SparklineAxisType type to:
+// 1) make it public
+// 2) forbid usage of integers directly
+type AxisType = primitives.SparklineAxisType
+
+//List of all possible values for AxisType
+const (
+	_ AxisType = iota
+	AxisIndividual
+	AxisGroup
+	AxisCustom
+)
+
+func init() {
+	primitives.FromSparklineAxisType = map[primitives.SparklineAxisType]string{
+		AxisIndividual: "individual",
+		AxisGroup:      "group",
+		AxisCustom:     "custom",
+	}
+
+	primitives.ToSparklineAxisType = make(map[string]primitives.SparklineAxisType, len(primitives.FromSparklineAxisType))
+	for k, v := range primitives.FromSparklineAxisType {
+		primitives.ToSparklineAxisType[v] = k
+	}
+}
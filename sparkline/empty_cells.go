@@ -0,0 +1,31 @@
+package sparkline
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+//EmptyCells is alias of original primitives.DisplayBlanksAs type to:
+// 1) make it public
+// 2) forbid usage of integers directly
+type EmptyCells = primitives.DisplayBlanksAs
+
+//List of all possible values for EmptyCells
+const (
+	_ EmptyCells = iota
+	EmptyCellsGap
+	EmptyCellsZero
+	EmptyCellsSpan
+)
+
+func init() {
+	primitives.FromDisplayBlanksAs = map[primitives.DisplayBlanksAs]string{
+		EmptyCellsGap:  "gap",
+		EmptyCellsZero: "zero",
+		EmptyCellsSpan: "span",
+	}
+
+	primitives.ToDisplayBlanksAs = make(map[string]primitives.DisplayBlanksAs, len(primitives.FromDisplayBlanksAs))
+	for k, v := range primitives.FromDisplayBlanksAs {
+		primitives.ToDisplayBlanksAs[v] = k
+	}
+}
@@ -0,0 +1,238 @@
+package sparkline
+
+import (
+	"errors"
+	"github.com/plandem/xlsx/internal/color"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+)
+
+//Info is objects that holds information about group of sparklines with same settings
+type Info struct {
+	group *ml.X14SparklineGroup
+}
+
+//Sparkline is an information about location of sparkline and range with data for it
+type Sparkline struct {
+	Location types.CellRef
+	Data     string
+}
+
+//Option is a type of option for sparklines
+type Option func(i *Info)
+
+//New creates and returns a new Info object that holds settings for sparklines of type
+func New(t Type, options ...Option) *Info {
+	i := &Info{
+		group: &ml.X14SparklineGroup{
+			Type:                t,
+			DisplayEmptyCellsAs: EmptyCellsGap,
+			ColorSeries:         color.New("#376092"),
+			ColorNegative:       color.New("#D00000"),
+			ColorAxis:           color.New("#000000"),
+			ColorMarkers:        color.New("#D00000"),
+			ColorFirst:          color.New("#D00000"),
+			ColorLast:           color.New("#D00000"),
+			ColorHigh:           color.New("#D00000"),
+			ColorLow:            color.New("#D00000"),
+		},
+	}
+
+	i.Set(options...)
+	return i
+}
+
+//Set sets new options for sparklines
+func (i *Info) Set(options ...Option) {
+	for _, o := range options {
+		o(i)
+	}
+}
+
+//Type returns type of sparklines
+func (i *Info) Type() Type {
+	if i.group.Type == 0 {
+		return Line
+	}
+
+	return i.group.Type
+}
+
+//Sparklines returns locations and ranges with data of sparklines
+func (i *Info) Sparklines() []Sparkline {
+	list := make([]Sparkline, 0, len(i.group.Sparklines))
+	for _, s := range i.group.Sparklines {
+		list = append(list, Sparkline{Location: types.CellRef(s.Bounds), Data: s.Formula})
+	}
+
+	return list
+}
+
+//Validate validates settings of sparklines
+func (i *Info) Validate() error {
+	if i.group.ManualMin != nil && i.group.ManualMax != nil && *i.group.ManualMin > *i.group.ManualMax {
+		return errors.New("minimum of vertical axis can't be greater than maximum")
+	}
+
+	if i.group.LineWeight < 0 {
+		return errors.New("weight of line can't be negative")
+	}
+
+	return nil
+}
+
+//Markers shows markers for each point of line sparklines
+func Markers(i *Info) {
+	i.group.Markers = true
+}
+
+//HighPoint highlights the highest point
+func HighPoint(i *Info) {
+	i.group.High = true
+}
+
+//LowPoint highlights the lowest point
+func LowPoint(i *Info) {
+	i.group.Low = true
+}
+
+//FirstPoint highlights the first point
+func FirstPoint(i *Info) {
+	i.group.First = true
+}
+
+//LastPoint highlights the last point
+func LastPoint(i *Info) {
+	i.group.Last = true
+}
+
+//NegativePoints highlights negative points
+func NegativePoints(i *Info) {
+	i.group.Negative = true
+}
+
+//ShowAxis shows horizontal axis
+func ShowAxis(i *Info) {
+	i.group.DisplayXAxis = true
+}
+
+//ShowHidden shows data of hidden rows and columns
+func ShowHidden(i *Info) {
+	i.group.DisplayHidden = true
+}
+
+//RightToLeft plots data from right to left
+func RightToLeft(i *Info) {
+	i.group.RightToLeft = true
+}
+
+//Color sets color of sparklines
+func Color(rgb string) Option {
+	return func(i *Info) {
+		i.group.ColorSeries = color.New(rgb)
+	}
+}
+
+//NegativeColor sets color of negative points
+func NegativeColor(rgb string) Option {
+	return func(i *Info) {
+		i.group.ColorNegative = color.New(rgb)
+	}
+}
+
+//AxisColor sets color of horizontal axis
+func AxisColor(rgb string) Option {
+	return func(i *Info) {
+		i.group.ColorAxis = color.New(rgb)
+	}
+}
+
+//MarkersColor sets color of markers
+func MarkersColor(rgb string) Option {
+	return func(i *Info) {
+		i.group.ColorMarkers = color.New(rgb)
+	}
+}
+
+//HighColor sets color of the highest point
+func HighColor(rgb string) Option {
+	return func(i *Info) {
+		i.group.ColorHigh = color.New(rgb)
+	}
+}
+
+//LowColor sets color of the lowest point
+func LowColor(rgb string) Option {
+	return func(i *Info) {
+		i.group.ColorLow = color.New(rgb)
+	}
+}
+
+//FirstColor sets color of the first point
+func FirstColor(rgb string) Option {
+	return func(i *Info) {
+		i.group.ColorFirst = color.New(rgb)
+	}
+}
+
+//LastColor sets color of the last point
+func LastColor(rgb string) Option {
+	return func(i *Info) {
+		i.group.ColorLast = color.New(rgb)
+	}
+}
+
+//LineWeight sets weight of line in points for line sparklines
+func LineWeight(weight float64) Option {
+	return func(i *Info) {
+		i.group.LineWeight = weight
+	}
+}
+
+//EmptyCellsAs sets how empty cells are shown
+func EmptyCellsAs(as EmptyCells) Option {
+	return func(i *Info) {
+		i.group.DisplayEmptyCellsAs = as
+	}
+}
+
+//MinAxis sets custom minimum of vertical axis
+func MinAxis(min float64) Option {
+	return func(i *Info) {
+		i.group.MinAxisType = AxisCustom
+		i.group.ManualMin = &min
+	}
+}
+
+//MaxAxis sets custom maximum of vertical axis
+func MaxAxis(max float64) Option {
+	return func(i *Info) {
+		i.group.MaxAxisType = AxisCustom
+		i.group.ManualMax = &max
+	}
+}
+
+//SameMinAxis uses same minimum of vertical axis for all sparklines of group
+func SameMinAxis(i *Info) {
+	i.group.MinAxisType = AxisGroup
+	i.group.ManualMin = nil
+}
+
+//SameMaxAxis uses same maximum of vertical axis for all sparklines of group
+func SameMaxAxis(i *Info) {
+	i.group.MaxAxisType = AxisGroup
+	i.group.ManualMax = nil
+}
+
+//private method used by sparklines manager to unpack Info
+func fromSparklineInfo(info *Info) *ml.X14SparklineGroup {
+	group := *info.group
+	group.Sparklines = nil
+	return &group
+}
+
+//private method used by sparklines manager to pack Info
+func toSparklineInfo(group *ml.X14SparklineGroup) *Info {
+	g := *group
+	return &Info{group: &g}
+}
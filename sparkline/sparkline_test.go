@@ -0,0 +1,87 @@
+package sparkline
+
+import (
+	"github.com/plandem/xlsx/internal/color"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSparkline(t *testing.T) {
+	info := New(Column)
+	require.Equal(t, Column, info.Type())
+	require.Equal(t, []Sparkline{}, info.Sparklines())
+	require.Nil(t, info.Validate())
+	require.Equal(t, Line, toSparklineInfo(&ml.X14SparklineGroup{}).Type())
+
+	min, max := -1.5, 10.0
+	info = New(Line,
+		Markers,
+		HighPoint,
+		LowPoint,
+		FirstPoint,
+		LastPoint,
+		NegativePoints,
+		ShowAxis,
+		ShowHidden,
+		RightToLeft,
+		Color("#112233"),
+		NegativeColor("#223344"),
+		AxisColor("#334455"),
+		MarkersColor("#445566"),
+		HighColor("#556677"),
+		LowColor("#667788"),
+		FirstColor("#778899"),
+		LastColor("#8899AA"),
+		LineWeight(1.25),
+		EmptyCellsAs(EmptyCellsSpan),
+		MinAxis(min),
+		MaxAxis(max),
+	)
+
+	require.Equal(t, &ml.X14SparklineGroup{
+		ColorSeries:         color.New("#112233"),
+		ColorNegative:       color.New("#223344"),
+		ColorAxis:           color.New("#334455"),
+		ColorMarkers:        color.New("#445566"),
+		ColorHigh:           color.New("#556677"),
+		ColorLow:            color.New("#667788"),
+		ColorFirst:          color.New("#778899"),
+		ColorLast:           color.New("#8899AA"),
+		ManualMin:           &min,
+		ManualMax:           &max,
+		LineWeight:          1.25,
+		Type:                Line,
+		DisplayEmptyCellsAs: EmptyCellsSpan,
+		Markers:             true,
+		High:                true,
+		Low:                 true,
+		First:               true,
+		Last:                true,
+		Negative:            true,
+		DisplayXAxis:        true,
+		DisplayHidden:       true,
+		MinAxisType:         AxisCustom,
+		MaxAxisType:         AxisCustom,
+		RightToLeft:         true,
+	}, fromSparklineInfo(info))
+	require.Nil(t, info.Validate())
+
+	info.Set(SameMinAxis, SameMaxAxis)
+	group := fromSparklineInfo(info)
+	require.Equal(t, AxisGroup, group.MinAxisType)
+	require.Equal(t, AxisGroup, group.MaxAxisType)
+	require.Nil(t, group.ManualMin)
+	require.Nil(t, group.ManualMax)
+
+	require.NotNil(t, New(Line, MinAxis(10), MaxAxis(1)).Validate())
+	require.NotNil(t, New(Line, LineWeight(-1)).Validate())
+
+	info = toSparklineInfo(&ml.X14SparklineGroup{
+		Type:       WinLoss,
+		Sparklines: []*ml.X14Sparkline{{Formula: "'Data'!A1:E1", Bounds: "F1"}},
+	})
+	require.Equal(t, WinLoss, info.Type())
+	require.Equal(t, []Sparkline{{Location: "F1", Data: "'Data'!A1:E1"}}, info.Sparklines())
+	require.Nil(t, fromSparklineInfo(info).Sparklines)
+}
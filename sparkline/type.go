@@ -0,0 +1,31 @@
+package sparkline
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+//Type is alias of original primitives.SparklineType type to:
+// 1) make it public
+// 2) forbid usage of integers directly
+type Type = primitives.SparklineType
+
+//List of all possible values for Type
+const (
+	_ Type = iota
+	Line
+	Column
+	WinLoss
+)
+
+func init() {
+	primitives.FromSparklineType = map[primitives.SparklineType]string{
+		Line:    "line",
+		Column:  "column",
+		WinLoss: "stacked",
+	}
+
+	primitives.ToSparklineType = make(map[string]primitives.SparklineType, len(primitives.FromSparklineType))
+	for k, v := range primitives.FromSparklineType {
+		primitives.ToSparklineType[v] = k
+	}
+}
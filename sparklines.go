@@ -0,0 +1,100 @@
+package xlsx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/sparkline"
+	"github.com/plandem/xlsx/types"
+	"strings"
+	_ "unsafe"
+)
+
+//go:linkname fromSparklineInfo github.com/plandem/xlsx/sparkline.fromSparklineInfo
+func fromSparklineInfo(info *sparkline.Info) *ml.X14SparklineGroup
+
+//go:linkname toSparklineInfo github.com/plandem/xlsx/sparkline.toSparklineInfo
+func toSparklineInfo(group *ml.X14SparklineGroup) *sparkline.Info
+
+type sparklines struct {
+	sheet *sheetInfo
+}
+
+//newSparklines creates an object that implements sparklines functionality
+func newSparklines(sheet *sheetInfo) *sparklines {
+	return &sparklines{sheet: sheet}
+}
+
+//Add adds a group of sparklines at location for data. Each cell of location gets a sparkline for the related row or column of data
+func (s *sparklines) Add(location types.Ref, data types.Ref, info *sparkline.Info) error {
+	if err := info.Validate(); err != nil {
+		return err
+	}
+
+	//data can be at another sheet
+	sheetName, ref := s.sheet.Name(), string(data)
+	if idx := strings.LastIndex(ref, "!"); idx != -1 {
+		sheetName, ref = strings.Trim(strings.Replace(ref[:idx], `''`, `'`, -1), `'`), ref[idx+1:]
+	}
+
+	prefix := fmt.Sprintf("'%s'!", strings.Replace(sheetName, `'`, `''`, -1))
+	locationBounds, dataBounds := location.ToBounds(), types.Ref(ref).ToBounds()
+	width, height := locationBounds.Dimension()
+	dataWidth, dataHeight := dataBounds.Dimension()
+
+	group := fromSparklineInfo(info)
+	switch {
+	case width == 1 && height == 1 && (dataWidth == 1 || dataHeight == 1):
+		group.Sparklines = append(group.Sparklines, &ml.X14Sparkline{
+			Formula: prefix + string(dataBounds.ToRef()),
+			Bounds:  string(types.CellRefFromIndexes(locationBounds.FromCol, locationBounds.FromRow)),
+		})
+	case width == 1 && height == dataHeight:
+		for i := 0; i < height; i++ {
+			row := types.BoundsFromIndexes(dataBounds.FromCol, dataBounds.FromRow+i, dataBounds.ToCol, dataBounds.FromRow+i)
+			group.Sparklines = append(group.Sparklines, &ml.X14Sparkline{
+				Formula: prefix + string(row.ToRef()),
+				Bounds:  string(types.CellRefFromIndexes(locationBounds.FromCol, locationBounds.FromRow+i)),
+			})
+		}
+	case height == 1 && width == dataWidth:
+		for i := 0; i < width; i++ {
+			col := types.BoundsFromIndexes(dataBounds.FromCol+i, dataBounds.FromRow, dataBounds.FromCol+i, dataBounds.ToRow)
+			group.Sparklines = append(group.Sparklines, &ml.X14Sparkline{
+				Formula: prefix + string(col.ToRef()),
+				Bounds:  string(types.CellRefFromIndexes(locationBounds.FromCol+i, locationBounds.FromRow)),
+			})
+		}
+	default:
+		return errors.New(fmt.Sprintf("location %s doesn't match data %s, each cell of location must have a row or column of data", location, data))
+	}
+
+	var encoded bytes.Buffer
+	if err := xml.NewEncoder(&encoded).EncodeElement(group, xml.StartElement{Name: xml.Name{Local: "x14:sparklineGroup"}}); err != nil {
+		return err
+	}
+
+	s.sheet.addExtension(ml.ExtURISparklineGroups, "x14:sparklineGroups", fmt.Sprintf(` xmlns:xm="%s"`, ml.NamespaceXM), encoded.String())
+	return nil
+}
+
+//List returns information about all groups of sparklines of sheet
+func (s *sparklines) List() []*sparkline.Info {
+	extensions, err := s.sheet.extensions()
+	if err != nil {
+		return nil
+	}
+
+	var list []*sparkline.Info
+	for _, ext := range extensions.Extensions {
+		if ext.URI == ml.ExtURISparklineGroups && ext.SparklineGroups != nil {
+			for _, group := range ext.SparklineGroups.Groups {
+				list = append(list, toSparklineInfo(group))
+			}
+		}
+	}
+
+	return list
+}
@@ -0,0 +1,86 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/sparkline"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestSparklines(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Sales")
+	other := xl.AddSheet("Data's")
+
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 5; c++ {
+			sheet.Cell(c, r).SetInt(r*c - 3)
+			other.Cell(c, r).SetInt(r + c)
+		}
+	}
+
+	require.Equal(t, 0, len(sheet.Sparklines()))
+
+	//one sparkline for each row
+	require.Nil(t, sheet.AddSparkline("F1:F3", "A1:E3", sparkline.Line, sparkline.Markers, sparkline.HighPoint))
+
+	//one sparkline for each column, data at another sheet
+	require.Nil(t, sheet.AddSparkline("A5:E5", "'Data''s'!A1:E3", sparkline.Column))
+
+	//single sparkline
+	require.Nil(t, sheet.AddSparkline("G1", "A2:E2", sparkline.WinLoss, sparkline.NegativePoints))
+
+	require.NotNil(t, sheet.AddSparkline("F1:F2", "A1:E3", sparkline.Line))
+	require.NotNil(t, sheet.AddSparkline("F1:G2", "A1:E2", sparkline.Line))
+	require.NotNil(t, sheet.AddSparkline("G2", "A1:E2", sparkline.Line))
+	require.NotNil(t, sheet.AddSparkline("G2", "A1:E1", sparkline.Line, sparkline.LineWeight(-1)))
+
+	//x14 extensions of sparklines and conditional formatting can live together
+	require.Nil(t, sheet.AddConditional(format.NewConditions(
+		format.Conditions.Rule(format.Condition.Priority(1), format.Condition.ExtendedDataBar()),
+	), "A1:E3"))
+
+	content := sheet.info().ml.ExtLst.InnerXML.XML
+	require.Equal(t, 1, strings.Count(content, "<x14:sparklineGroups "))
+	require.Equal(t, 3, strings.Count(content, "<x14:sparklineGroup "))
+	require.Contains(t, content, `<xm:sqref>F2</xm:sqref>`)
+
+	verify := func(sheet Sheet) {
+		groups := sheet.Sparklines()
+		require.Equal(t, 3, len(groups))
+
+		require.Equal(t, sparkline.Line, groups[0].Type())
+		require.Equal(t, []sparkline.Sparkline{
+			{Location: "F1", Data: "'Sales'!A1:E1"},
+			{Location: "F2", Data: "'Sales'!A2:E2"},
+			{Location: "F3", Data: "'Sales'!A3:E3"},
+		}, groups[0].Sparklines())
+
+		require.Equal(t, sparkline.Column, groups[1].Type())
+		require.Equal(t, 5, len(groups[1].Sparklines()))
+		require.Equal(t, sparkline.Sparkline{Location: "B5", Data: "'Data''s'!B1:B3"}, groups[1].Sparklines()[1])
+
+		require.Equal(t, sparkline.WinLoss, groups[2].Type())
+		require.Equal(t, []sparkline.Sparkline{{Location: "G1", Data: "'Sales'!A2:E2"}}, groups[2].Sparklines())
+	}
+
+	verify(sheet)
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	sheet = xl.Sheet(0)
+	verify(sheet)
+
+	//new groups are added to existing extension
+	require.Nil(t, sheet.AddSparkline("H1", "A3:E3", sparkline.Line))
+	require.Equal(t, 4, len(sheet.Sparklines()))
+	require.Equal(t, 1, strings.Count(sheet.info().ml.ExtLst.InnerXML.XML, "<x14:sparklineGroups "))
+}
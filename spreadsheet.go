@@ -2,11 +2,20 @@ package xlsx
 
 import (
 	"archive/zip"
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/plandem/ooxml"
 	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/formula"
+	"github.com/plandem/xlsx/internal/color"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/internal/number_format"
+	"io"
 	"regexp"
+	"strings"
+	"sync"
 )
 
 //Spreadsheet is a higher level object that wraps OOXML package with XLSX functionality
@@ -18,24 +27,44 @@ type Spreadsheet struct {
 	relationships *ooxml.Relationships
 	sharedStrings *SharedStrings
 	styleSheet    *StyleSheet
+	theme         *theme
+	properties    *DocProperties
+	externalLinks *externalLinks
+	slicers       *slicers
+	persons       *persons
+	customXML     *CustomXML
+	fileNames     map[string]bool
+	evaluator     formula.Evaluator
+	mu            *sync.Mutex
+	stringsMode   StringsMode
+	progress      ProgressCallback
 }
 
 //newSpreadsheet creates an object that implements XLSX functionality
 func newSpreadsheet(pkg *ooxml.PackageInfo) (interface{}, error) {
-	xlDoc := &Spreadsheet{
-		pkg:     pkg,
-		Package: pkg,
-	}
+	return newSpreadsheetContext(context.Background(), nil)(pkg)
+}
 
-	pkg.Validator = xlDoc.IsValid
+//newSpreadsheetContext returns factory of document that checks ctx and reports progress to callback during reading of existing document
+func newSpreadsheetContext(ctx context.Context, progress ProgressCallback) ooxml.DocumentFactory {
+	return func(pkg *ooxml.PackageInfo) (interface{}, error) {
+		xlDoc := &Spreadsheet{
+			pkg:       pkg,
+			Package:   pkg,
+			fileNames: make(map[string]bool),
+			progress:  progress,
+		}
 
-	if pkg.IsNew() {
-		xlDoc.createSpreadsheet()
-	} else {
-		xlDoc.readSpreadsheet()
-	}
+		pkg.Validator = xlDoc.beforeSave
 
-	return xlDoc, nil
+		if pkg.IsNew() {
+			xlDoc.createSpreadsheet()
+		} else if err := xlDoc.readSpreadsheet(ctx); err != nil {
+			return nil, err
+		}
+
+		return xlDoc, nil
+	}
 }
 
 //GetSheetNames returns a names of all sheets
@@ -70,6 +99,7 @@ func (xl *Spreadsheet) Sheet(i int, options ...sheetMode) Sheet {
 		}
 
 		//for stream mode we create a copy of sheetInfo to prevent pollution
+		xl.sheets[i].isStreamed = true
 		si := *xl.sheets[i]
 		sheet := &sheetReadStream{sheetInfo: &si}
 		si.sheet = sheet
@@ -78,6 +108,24 @@ func (xl *Spreadsheet) Sheet(i int, options ...sheetMode) Sheet {
 		return sheet
 	}
 
+	//disk cache mode
+	if (mode&SheetModeDiskCache) != 0 || (prevMode&SheetModeDiskCache) != 0 {
+		//disk cache can be used only if sheet was not opened in normal mode before
+		if (prevMode&sheetModeRead) != 0 && (prevMode&SheetModeDiskCache) == 0 {
+			panic("You can't open sheet in disk cache mode after it was opened in normal mode.")
+		}
+
+		si := xl.sheets[i]
+		if prevMode == sheetModeUnknown {
+			sheet := &sheetDiskCache{sheetInfo: si}
+			si.sheet = sheet
+			si.sheetMode = mode | sheetModeWrite
+			sheet.afterOpen()
+		}
+
+		return si.sheet
+	}
+
 	//normal mode
 	if prevMode == sheetModeUnknown {
 		//to prevent mess with opening same sheet with different modes, we always use same mode as used first time
@@ -120,6 +168,13 @@ func (xl *Spreadsheet) DeleteSheet(i int) {
 			//remove from document
 			xl.sheets = append(xl.sheets[:i], xl.sheets[i+1:]...)
 
+			//update indexes of sheets after deleted
+			for idx := i; idx < len(xl.sheets); idx++ {
+				if xl.sheets[idx] != nil {
+					xl.sheets[idx].index = idx
+				}
+			}
+
 			//remove from workbook
 			xl.workbook.ml.Sheets = append(xl.workbook.ml.Sheets[:i], xl.workbook.ml.Sheets[i+1:]...)
 			xl.workbook.file.MarkAsUpdated()
@@ -127,22 +182,264 @@ func (xl *Spreadsheet) DeleteSheet(i int) {
 			//remove relation
 			xl.relationships.Remove(rid)
 
+			//remove defined names of sheet
+			xl.workbook.definedNames.removeSheet(i)
+
 			//remove file
 			xl.pkg.Remove(sheet.file.FileName())
 		}
 	}
 }
 
+//movedIndex returns a new 0-based index of sheet with index after moving of sheet from index from to index to
+func movedIndex(index, from, to int) int {
+	switch {
+	case index == from:
+		return to
+	case from < to && index > from && index <= to:
+		return index - 1
+	case from > to && index >= to && index < from:
+		return index + 1
+	}
+
+	return index
+}
+
+//MoveSheet moves the sheet with 0-based index from to 0-based index to, so order of sheets is changed. Sheet-level defined names and active sheet are updated
+func (xl *Spreadsheet) MoveSheet(from, to int) error {
+	if from < 0 || from >= len(xl.sheets) {
+		return errors.New(fmt.Sprintf("there is no sheet with index %d", from))
+	}
+
+	if to < 0 || to >= len(xl.sheets) {
+		return errors.New(fmt.Sprintf("sheet can't be moved to index %d", to))
+	}
+
+	if from == to {
+		return nil
+	}
+
+	sheets := make([]*sheetInfo, len(xl.sheets))
+	items := make([]*ml.Sheet, len(xl.workbook.ml.Sheets))
+	for i := range xl.sheets {
+		next := movedIndex(i, from, to)
+		sheets[next], items[next] = xl.sheets[i], xl.workbook.ml.Sheets[i]
+		if sheets[next] != nil {
+			sheets[next].index = next
+		}
+	}
+
+	xl.sheets, xl.workbook.ml.Sheets = sheets, items
+	xl.workbook.definedNames.moveSheet(from, to)
+
+	if len(xl.workbook.ml.BookViews.Items) > 0 {
+		view := xl.workbook.ml.BookViews.Items[0]
+		view.ActiveTab = movedIndex(view.ActiveTab, from, to)
+		if int(view.FirstSheet) > view.ActiveTab {
+			view.FirstSheet = uint(view.ActiveTab)
+		}
+	}
+
+	xl.workbook.file.MarkAsUpdated()
+	return nil
+}
+
+//DefineName adds a new or updates existing workbook-level defined name with formula, e.g.: DefineName("TaxRate", "Sheet1!$B$2")
+func (xl *Spreadsheet) DefineName(name string, formula string) error {
+	return xl.workbook.definedNames.Add(name, formula, -1)
+}
+
+//DefinedName returns formula of workbook-level defined name or empty string if there is no such name
+func (xl *Spreadsheet) DefinedName(name string) string {
+	return xl.workbook.definedNames.Get(name, -1)
+}
+
+//DeleteName deletes workbook-level defined name
+func (xl *Spreadsheet) DeleteName(name string) {
+	xl.workbook.definedNames.Remove(name, -1)
+}
+
+//DefinedNames returns names of all workbook-level defined names
+func (xl *Spreadsheet) DefinedNames() []string {
+	return xl.workbook.definedNames.List(-1)
+}
+
 //AddFormatting adds a new style formatting to document and return related ID that can be used lately
 func (xl *Spreadsheet) AddFormatting(style *format.StyleFormat) format.DirectStyleID {
 	return xl.styleSheet.addStyle(style)
 }
 
+//AddNamedStyle adds a new named style with name to document and returns ID of style that is based on it. Cells with named style can be restyled at once via Excel by updating named style
+func (xl *Spreadsheet) AddNamedStyle(name string, style *format.StyleFormat) format.DirectStyleID {
+	return xl.styleSheet.addNamedStyle(name, style)
+}
+
+//NamedStyles returns names of all named styles of document, including built-in styles, e.g.: Normal, Heading 1
+func (xl *Spreadsheet) NamedStyles() []string {
+	return xl.styleSheet.namedStyles()
+}
+
+//NamedStyle returns ID of style that is based on named style with name or error if there is no such named style. Names are case insensitive
+func (xl *Spreadsheet) NamedStyle(name string) (format.DirectStyleID, error) {
+	if styleID, ok := xl.styleSheet.resolveNamedStyle(name); ok {
+		return styleID, nil
+	}
+
+	return 0, errors.New(fmt.Sprintf("there is no named style with name %s", name))
+}
+
 //ResolveFormatting returns style formatting for styleID or nil if there is no any styles with such styleID
 func (xl *Spreadsheet) ResolveFormatting(styleID format.DirectStyleID) *format.StyleFormat {
 	return xl.workbook.doc.styleSheet.resolveDirectStyle(styleID)
 }
 
+//AddDiffFormatting adds a new differential style formatting to document and returns related ID that can be used lately, e.g. for conditional formatting. Only settings that were set for style are stored, so differential style changes only these settings of cells
+func (xl *Spreadsheet) AddDiffFormatting(style *format.StyleFormat) format.DiffStyleID {
+	return xl.styleSheet.addDiffStyle(style)
+}
+
+//ResolveDiffFormatting returns differential style formatting for styleID or nil if there is no any differential styles with such styleID
+func (xl *Spreadsheet) ResolveDiffFormatting(styleID format.DiffStyleID) *format.StyleFormat {
+	return xl.styleSheet.resolveDiffFormat(styleID)
+}
+
+//DiffFormattings returns all differential style formattings of document, where index of formatting is ID of differential style
+func (xl *Spreadsheet) DiffFormattings() []*format.StyleFormat {
+	styles := make([]*format.StyleFormat, 0, xl.styleSheet.diffStylesCount())
+	for id := 0; id < cap(styles); id++ {
+		styles = append(styles, xl.styleSheet.resolveDiffFormat(format.DiffStyleID(id)))
+	}
+
+	return styles
+}
+
+//AddNumberFormat adds a custom number format code to document and returns ID of number format that can be used lately. For built-in code, ID of built-in number format is returned
+func (xl *Spreadsheet) AddNumberFormat(code string) int {
+	number := numberFormat.New(-1, code)
+	xl.lock()
+	defer xl.unlock()
+	return xl.styleSheet.addNumFormatIfRequired(&number)
+}
+
+//ThemeColors returns #RGB colors of theme in order of theme color indexes: light 1, dark 1, light 2, dark 2, accent 1-6, hyperlink and followed hyperlink. If there is no theme, then colors of default Office theme are returned
+func (xl *Spreadsheet) ThemeColors() []string {
+	if xl.theme == nil {
+		return defaultThemeColors()
+	}
+
+	return xl.theme.Colors()
+}
+
+//SetThemeColors sets #RGB colors of theme in order of theme color indexes, e.g.: SetThemeColors("", "", "", "", "#FF0000") sets accent 1 color. Empty color keeps color of theme as is
+func (xl *Spreadsheet) SetThemeColors(colors ...string) error {
+	return xl.themeIfRequired().SetColors(colors...)
+}
+
+//SetTheme sets custom theme, e.g.: content of xl/theme/theme1.xml from another workbook
+func (xl *Spreadsheet) SetTheme(theme io.Reader) error {
+	return xl.themeIfRequired().Set(theme)
+}
+
+//ResolveThemeColor returns #RGB of theme color with index and tint, e.g.: ResolveThemeColor(4, 0.4) returns accent 1 color lighter by 40%
+func (xl *Spreadsheet) ResolveThemeColor(index int, tint float64) string {
+	colors := xl.ThemeColors()
+	if index < 0 || index >= len(colors) || len(colors[index]) == 0 {
+		return ""
+	}
+
+	if tint != 0 {
+		return color.Tint(colors[index], tint)
+	}
+
+	return colors[index]
+}
+
+//IndexedColors returns #RGB colors of palette that is used by indexed colors in order of indexes. If palette was not customized, then built-in palette is returned
+func (xl *Spreadsheet) IndexedColors() []string {
+	return xl.styleSheet.indexedColors()
+}
+
+//SetIndexedColors overrides #RGB colors of palette that is used by indexed colors in order of indexes, e.g.: SetIndexedColors("", "", "#FF6600") overrides color with index 2. Empty color keeps color of palette as is, without colors built-in palette is restored
+//N.B.: after overriding of palette, colors of new styles are saved as RGB colors instead of built-in indexed colors
+func (xl *Spreadsheet) SetIndexedColors(colors ...string) error {
+	return xl.styleSheet.setIndexedColors(colors...)
+}
+
+//themeIfRequired returns theme of workbook, theme will be added if required
+func (xl *Spreadsheet) themeIfRequired() *theme {
+	if xl.theme == nil {
+		xl.theme = newTheme(xl.uniqueFileName("xl/theme/theme%d.xml"), xl)
+	}
+
+	return xl.theme
+}
+
+//Properties returns core, extended and custom properties of document
+func (xl *Spreadsheet) Properties() *DocProperties {
+	return xl.properties
+}
+
+//CustomXML returns custom XML parts of document. Parts and extensions of document that are not supported are kept as is during saving
+func (xl *Spreadsheet) CustomXML() *CustomXML {
+	return xl.customXML
+}
+
+//SetEvaluator sets evaluator that will be used to compute values of formulas, e.g.: SetEvaluator(formula.New()). Use nil to get cached values only
+func (xl *Spreadsheet) SetEvaluator(evaluator formula.Evaluator) {
+	xl.evaluator = evaluator
+}
+
+//sheetByName returns sheet with name, opening it if required, or nil if there is no such sheet
+func (xl *Spreadsheet) sheetByName(name string) *sheetInfo {
+	for i, sheet := range xl.workbook.ml.Sheets {
+		if strings.EqualFold(sheet.Name, name) {
+			if xl.sheets[i] == nil || xl.sheets[i].sheet == nil {
+				xl.Sheet(i)
+			}
+
+			return xl.sheets[i]
+		}
+	}
+
+	return nil
+}
+
+//uniqueFileName returns a name of file for pattern that is not used by package yet and reserves it
+func (xl *Spreadsheet) uniqueFileName(pattern string) string {
+	for i := 1; ; i++ {
+		fileName := fmt.Sprintf(pattern, i)
+		if !xl.fileNames[fileName] && xl.pkg.File(fileName) == nil {
+			xl.fileNames[fileName] = true
+			return fileName
+		}
+	}
+}
+
+//SetStringsMode sets mode to store strings of cells, e.g.: SetStringsMode(xlsx.StringsInline). In inline mode, new strings are stored inline and shared strings of opened sheets are converted into inline strings during saving
+func (xl *Spreadsheet) SetStringsMode(mode StringsMode) {
+	xl.stringsMode = mode
+}
+
+//SaveAs saves document into file with name or io.Writer. Progress is reported after writing of each part, see OnProgress
+func (xl *Spreadsheet) SaveAs(f interface{}) error {
+	return xl.SaveAsContext(context.Background(), f)
+}
+
+//Write writes XLSX content of document into w without touching of filesystem
+func (xl *Spreadsheet) Write(w io.Writer) error {
+	return xl.SaveAs(w)
+}
+
+//Bytes returns XLSX content of document
+func (xl *Spreadsheet) Bytes() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := xl.Write(buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
 //IsValid validates document and return error if there is any error. Using right before saving.
 func (xl *Spreadsheet) IsValid() error {
 	if len(xl.sheets) == 0 {
@@ -152,10 +449,71 @@ func (xl *Spreadsheet) IsValid() error {
 	return nil
 }
 
-//readSpreadsheet reads required information from XLSX
-func (xl *Spreadsheet) readSpreadsheet() {
+//beforeSave removes unused relationships and orphaned parts of opened sheets, marshals custom extensions and validates document. Using right before saving.
+//Close frees allocated resources, e.g.: removes caches of sheets that were opened in disk cache mode, and closes the package
+func (xl *Spreadsheet) Close() error {
+	for _, sheet := range xl.sheets {
+		if sheet != nil && sheet.sheet != nil {
+			sheet.sheet.Close()
+		}
+	}
+
+	return xl.pkg.Close()
+}
+
+func (xl *Spreadsheet) beforeSave() error {
+	//sheets that were not opened still must be updated, e.g.: after renaming of sheets
+	for i, sheet := range xl.sheets {
+		if sheet != nil && sheet.sheet == nil && len(sheet.patches) > 0 {
+			xl.Sheet(i)
+		}
+	}
+
+	for _, sheet := range xl.sheets {
+		if sheet != nil && sheet.sheet != nil && (sheet.sheetMode&SheetModeDiskCache) == 0 {
+			sheet.hyperlinks.pack()
+			sheet.comments.pack()
+		}
+
+		if sheet != nil {
+			if cache, ok := sheet.sheet.(*sheetDiskCache); ok {
+				if err := cache.flush(); err != nil {
+					return err
+				}
+			}
+		}
+
+		if sheet != nil && len(sheet.extensionMarshalers) > 0 {
+			if err := sheet.marshalExtensions(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := xl.workbook.marshalExtensions(); err != nil {
+		return err
+	}
+
+	return xl.IsValid()
+}
+
+//readSpreadsheet reads required information from XLSX, reading is canceled when ctx is done
+func (xl *Spreadsheet) readSpreadsheet(ctx context.Context) error {
+	xl.properties = newDocProperties(xl)
+	xl.externalLinks = newExternalLinks(xl)
+	xl.slicers = newSlicers(xl)
+	xl.persons = newPersons(xl)
+	xl.customXML = newCustomXML(xl)
 	files := xl.pkg.Files()
+	reTheme := regexp.MustCompile(`^xl/theme/theme[\d]+\.xml$`)
+	parts := 0
 	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		parts++
+		xl.reportProgress(Progress{Parts: parts, TotalParts: len(files)})
 		if f, ok := file.(*zip.File); ok {
 			switch {
 			case f.Name == "xl/workbook.xml":
@@ -166,6 +524,10 @@ func (xl *Spreadsheet) readSpreadsheet() {
 				xl.sharedStrings = newSharedStrings(f, xl)
 			case f.Name == "xl/styles.xml":
 				xl.styleSheet = newStyleSheet(f, xl)
+			case xl.theme == nil && reTheme.MatchString(f.Name):
+				xl.theme = newTheme(f, xl)
+			case f.Name == "docProps/core.xml" || f.Name == "docProps/app.xml" || f.Name == "docProps/custom.xml":
+				xl.properties.attach(f, f.Name)
 			}
 		}
 	}
@@ -173,12 +535,18 @@ func (xl *Spreadsheet) readSpreadsheet() {
 	//we need populated 'relationships' to resolve index for sheet
 	reSheet := regexp.MustCompile(`xl/worksheets/[[:alpha:]]+[\d]+\.xml`)
 	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		if f, ok := file.(*zip.File); ok {
 			if reSheet.MatchString(f.Name) {
 				newSheetInfo(f, xl)
 			}
 		}
 	}
+
+	return nil
 }
 
 //createSpreadsheet initialize a new XLSX document
@@ -187,4 +555,9 @@ func (xl *Spreadsheet) createSpreadsheet() {
 	xl.workbook = newWorkbook("xl/workbook.xml", xl)
 	xl.sharedStrings = newSharedStrings("xl/sharedStrings.xml", xl)
 	xl.styleSheet = newStyleSheet("xl/styles.xml", xl)
+	xl.properties = newDocProperties(xl)
+	xl.externalLinks = newExternalLinks(xl)
+	xl.slicers = newSlicers(xl)
+	xl.persons = newPersons(xl)
+	xl.customXML = newCustomXML(xl)
 }
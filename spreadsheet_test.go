@@ -1,8 +1,14 @@
 package xlsx
 
 import (
+	"archive/zip"
+	"bytes"
 	"github.com/stretchr/testify/assert"
+	"os"
+	"runtime"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestSpreadsheet_Sheet(t *testing.T) {
@@ -42,3 +48,166 @@ func TestSpreadsheet_Sheet(t *testing.T) {
 	assert.Equal(t, sheetModeRead|sheetModeWrite, sheet.mode())
 
 }
+
+func TestSpreadsheet_MoveSheet(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	for _, name := range []string{"A", "B", "C", "D"} {
+		xl.AddSheet(name)
+	}
+
+	assert.Nil(t, xl.Sheet(1).DefineName("Local", "B!$A$1"))
+	assert.Nil(t, xl.SetActiveSheet(3))
+
+	assert.NotNil(t, xl.MoveSheet(4, 0))
+	assert.NotNil(t, xl.MoveSheet(0, -1))
+	assert.Nil(t, xl.MoveSheet(2, 2))
+
+	assert.Nil(t, xl.MoveSheet(3, 0))
+	assert.Equal(t, []string{"D", "A", "B", "C"}, xl.GetSheetNames())
+	assert.Equal(t, 0, xl.ActiveSheet())
+	assert.Equal(t, "B!$A$1", xl.Sheet(2).DefinedName("Local"))
+	assert.Equal(t, 2, xl.Sheet(2).info().index)
+
+	assert.Nil(t, xl.MoveSheet(1, 3))
+	assert.Equal(t, []string{"D", "B", "C", "A"}, xl.GetSheetNames())
+	assert.Equal(t, "B!$A$1", xl.Sheet(1).DefinedName("Local"))
+	assert.Equal(t, "", xl.Sheet(3).DefinedName("Local"))
+	assert.Equal(t, "A", xl.Sheet(3).Name())
+}
+
+//waitForUnpinnedRows runs garbage collector till cells and rows that refer loaded rows of sheet are collected
+func waitForUnpinnedRows(sheet *sheetDiskCache) {
+	for i := 0; i < 100; i++ {
+		pinned := false
+		for _, counter := range sheet.pins {
+			if atomic.LoadInt32(counter) > 0 {
+				pinned = true
+			}
+		}
+
+		if !pinned {
+			return
+		}
+
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSpreadsheet_SheetDiskCache(t *testing.T) {
+	xl, err := Open("./test_files/example_simple.xlsx")
+	assert.Nil(t, err)
+	defer xl.Close()
+
+	sheet := xl.Sheet(0, SheetModeDiskCache).(*sheetDiskCache)
+	fileName := sheet.file.FileName()
+
+	//reading of rows does not update sheet
+	for i := 0; i < 30; i++ {
+		_ = sheet.Cell(0, i).Value()
+	}
+
+	sheet.MergedCells()
+	assert.Nil(t, sheet.flush())
+	assert.IsType(t, &zip.File{}, xl.pkg.File(fileName))
+
+	//only limited number of rows is kept in memory and changed rows are stored into the cache
+	waitForUnpinnedRows(sheet)
+	sheet.row(0).Cells[0].Value = "updated"
+	for i := 1; i <= diskCacheRowsLimit; i++ {
+		_ = sheet.row(i)
+	}
+
+	assert.Equal(t, diskCacheRowsLimit, len(sheet.rows))
+	_, loaded := sheet.rows[0]
+	assert.Equal(t, false, loaded)
+	_, stored := xl.pkg.File(fileName).(*zip.File)
+	assert.Equal(t, false, stored)
+	assert.Equal(t, "updated", sheet.Cell(0, 0).Value())
+	assert.Equal(t, "    with leading space", sheet.Cell(0, 1).Value())
+
+	//rows are not evicted while cells or rows refer it
+	waitForUnpinnedRows(sheet)
+	cell, row := sheet.Cell(1, 2), sheet.Row(3)
+	for i := diskCacheRowsLimit + 1; i <= 3*diskCacheRowsLimit; i++ {
+		_ = sheet.row(i)
+	}
+
+	_, loaded = sheet.rows[2]
+	assert.Equal(t, true, loaded)
+	_, loaded = sheet.rows[3]
+	assert.Equal(t, true, loaded)
+	assert.Equal(t, diskCacheRowsLimit, len(sheet.rows))
+	cell.SetValue("pinned")
+	row.Cell(1).SetValue("pinned row")
+	assert.Equal(t, "pinned", sheet.Cell(1, 2).Value())
+	assert.Equal(t, "pinned row", sheet.Cell(1, 3).Value())
+
+	//rows are evicted after cells and rows that refer it are collected
+	cell, row = nil, nil
+	waitForUnpinnedRows(sheet)
+
+	_ = sheet.row(3*diskCacheRowsLimit + 1)
+	assert.Equal(t, diskCacheRowsLimit, len(sheet.rows))
+	_, loaded = sheet.rows[2]
+	assert.Equal(t, false, loaded)
+	assert.Equal(t, "pinned", sheet.Cell(1, 2).Value())
+
+	//cache is removed after closing
+	cacheName := sheet.cache.Name()
+	_, err = os.Stat(cacheName)
+	assert.Nil(t, err)
+	assert.Nil(t, xl.Close())
+	_, err = os.Stat(cacheName)
+	assert.Equal(t, true, os.IsNotExist(err))
+}
+
+func TestSpreadsheet_SheetDiskCacheError(t *testing.T) {
+	xl, err := Open("./test_files/example_simple.xlsx")
+	assert.Nil(t, err)
+	defer xl.Close()
+
+	//rows that can't be read from the cache are empty and saving fails with error of reading
+	sheet := xl.Sheet(0, SheetModeDiskCache).(*sheetDiskCache)
+	assert.Nil(t, sheet.cache.Close())
+	assert.NotPanics(t, func() {
+		assert.Equal(t, "", sheet.Cell(0, 1).Value())
+	})
+
+	assert.NotNil(t, sheet.err)
+	assert.Equal(t, sheet.err, xl.SaveAs(&bytes.Buffer{}))
+
+	//rows that were not loaded can't be read during marshaling
+	xl2, err := Open("./test_files/example_simple.xlsx")
+	assert.Nil(t, err)
+	defer xl2.Close()
+
+	sheet = xl2.Sheet(0, SheetModeDiskCache).(*sheetDiskCache)
+	sheet.CellByRef("A1").SetValue("updated")
+	assert.Nil(t, sheet.flush())
+	assert.Nil(t, sheet.cache.Close())
+	assert.NotNil(t, xl2.SaveAs(&bytes.Buffer{}))
+	assert.NotNil(t, sheet.err)
+}
+
+func TestSpreadsheet_SheetDiskCacheNew(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	si := newSheetInfo("xl/worksheets/sheet1.xml", xl)
+	si.afterCreate("Cached")
+	sheet := xl.Sheet(si.index, SheetModeDiskCache)
+	sheet.CellByRef("B2").SetValue("new")
+
+	buf := &bytes.Buffer{}
+	assert.Nil(t, xl.SaveAs(buf))
+
+	saved, err := OpenBytes(buf.Bytes())
+	assert.Nil(t, err)
+	defer saved.Close()
+
+	assert.Equal(t, []string{"Cached"}, saved.GetSheetNames())
+	assert.Equal(t, "new", saved.Sheet(0).CellByRef("B2").Value())
+}
@@ -0,0 +1,454 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/number_format/convert"
+	"github.com/plandem/xlsx/types"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//structTag is a name of tag that is used to annotate fields of structs
+const structTag = "xlsx"
+
+var structTagOption = regexp.MustCompile(`^(format|width|col):`)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+//structField is a field of struct that is mapped to the column
+type structField struct {
+	index  []int
+	header string
+	format string
+	width  float32
+	col    int
+}
+
+//parseStructTag parses tag of field, e.g.: `xlsx:"Total,format:#,##0.00,width:12"` or `xlsx:"Total,col:C"`
+//N.B.: code of number format can contain commas, so everything till next known option is a code
+func parseStructTag(f *structField, tag string) error {
+	parts := strings.Split(tag, ",")
+	if len(parts[0]) > 0 {
+		f.header = parts[0]
+	}
+
+	option := ""
+	for _, part := range parts[1:] {
+		if matches := structTagOption.FindStringSubmatch(part); matches != nil {
+			option = matches[1]
+			part = part[len(matches[0]):]
+		} else if option == "format" {
+			f.format += ","
+		} else {
+			return errors.New(fmt.Sprintf("unknown option of field %s: %s", f.header, part))
+		}
+
+		switch option {
+		case "format":
+			f.format += part
+		case "width":
+			width, err := strconv.ParseFloat(part, 32)
+			if err != nil || width <= 0 || width > internal.ExcelColumnWidthLimit {
+				return errors.New(fmt.Sprintf("invalid width of field %s: %s", f.header, part))
+			}
+
+			f.width = float32(width)
+		case "col":
+			colIndex, _ := types.CellRef(part + "1").ToIndexes()
+			if len(part) == 0 || strings.Trim(strings.ToUpper(part), "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" || colIndex >= internal.ExcelColumnLimit {
+				return errors.New(fmt.Sprintf("invalid column of field %s: %s", f.header, part))
+			}
+
+			f.col = colIndex
+		}
+	}
+
+	return nil
+}
+
+//structFields returns information about exported fields of struct that are mapped to the columns, fields of embedded structs are promoted
+func structFields(t reflect.Type, index []int) ([]*structField, error) {
+	var fields []*structField
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get(structTag)
+		if tag == "-" || len(field.PkgPath) > 0 && !field.Anonymous {
+			continue
+		}
+
+		fieldIndex := append(append([]int{}, index...), i)
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if field.Anonymous && len(tag) == 0 && fieldType.Kind() == reflect.Struct && fieldType != timeType {
+			embedded, err := structFields(fieldType, fieldIndex)
+			if err != nil {
+				return nil, err
+			}
+
+			fields = append(fields, embedded...)
+			continue
+		}
+
+		if len(field.PkgPath) > 0 {
+			continue
+		}
+
+		f := &structField{index: fieldIndex, header: field.Name, col: -1}
+		if err := parseStructTag(f, tag); err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, f)
+	}
+
+	return fields, nil
+}
+
+//structFieldValue returns value of field or invalid value if there is nil pointer to embedded struct
+func structFieldValue(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(i)
+	}
+
+	return v
+}
+
+//structValue returns value of field as one of types that are supported by cell, e.g. for types based on built-in types
+func structValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String:
+		return v.String()
+	}
+
+	return v.Interface()
+}
+
+//structSlice validates that data is a slice of structs or pointers to structs and returns type of struct
+func structSlice(data interface{}) (reflect.Value, reflect.Type, error) {
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return v, nil, errors.New(fmt.Sprintf("slice of structs is required, but %T was used", data))
+	}
+
+	t := v.Type().Elem()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return v, nil, errors.New(fmt.Sprintf("slice of structs is required, but %T was used", data))
+	}
+
+	return v, t, nil
+}
+
+//WriteStructs writes slice of structs into the sheet - first row is a header with names of fields and each struct is a row after it.
+//Fields can be annotated with tag to set header, number format or width of column, e.g.: `xlsx:"Total,format:#,##0.00,width:12"`, `xlsx:"-"` skips field
+func (s *sheetInfo) WriteStructs(data interface{}) error {
+	v, t, err := structSlice(data)
+	if err != nil {
+		return err
+	}
+
+	fields, err := structFields(t, nil)
+	if err != nil {
+		return err
+	}
+
+	if len(fields) > internal.ExcelColumnLimit || v.Len() >= internal.ExcelRowLimit {
+		return errors.New(fmt.Sprintf("data does not fit into the sheet: %d columns and %d rows", len(fields), v.Len()+1))
+	}
+
+	for cIdx, f := range fields {
+		s.sheet.Cell(cIdx, 0).SetValue(f.header)
+
+		var styleID format.DirectStyleID
+		if len(f.format) > 0 {
+			styleID = s.workbook.doc.AddFormatting(format.NewStyles(format.NumberFormat(f.format)))
+		}
+
+		if f.width > 0 || styleID > 0 {
+			col := s.columns.Resolve(cIdx)
+			if f.width > 0 {
+				col.Width, col.CustomWidth = f.width, true
+			}
+
+			if styleID > 0 {
+				col.Style = styleID
+			}
+		}
+
+		for i := 0; i < v.Len(); i++ {
+			c := s.sheet.Cell(cIdx, i+1)
+			value := structFieldValue(v.Index(i), f.index)
+			if !value.IsValid() || value.Kind() == reflect.Ptr && value.IsNil() {
+				c.Reset()
+				continue
+			}
+
+			c.SetValue(structValue(reflect.Indirect(value)))
+			if styleID > 0 {
+				c.SetFormatting(styleID)
+			}
+		}
+	}
+
+	return nil
+}
+
+//StructError is an error of conversion of cell's value into the field of struct
+type StructError struct {
+	Ref   types.CellRef
+	Field string
+	Err   error
+}
+
+//Error returns text of error
+func (e *StructError) Error() string {
+	return fmt.Sprintf("%s: field %s: %v", e.Ref, e.Field, e.Err)
+}
+
+//StructErrors is a list of errors of conversion that were collected during reading of structs
+type StructErrors []*StructError
+
+//Error returns text of all errors
+func (e StructErrors) Error() string {
+	list := make([]string, len(e))
+	for i, err := range e {
+		list[i] = err.Error()
+	}
+
+	return strings.Join(list, "; ")
+}
+
+//structFieldAlloc returns value of field to set, nil pointers to embedded structs are allocated
+func structFieldAlloc(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(i)
+	}
+
+	return v
+}
+
+//setStructValue converts value of cell into type of field and sets it. Numbers, booleans and dates stored as text are converted too
+func setStructValue(v reflect.Value, c *Cell) error {
+	if v.Kind() == reflect.Ptr {
+		value := reflect.New(v.Type().Elem())
+		if err := setStructValue(value.Elem(), c); err != nil {
+			return err
+		}
+
+		v.Set(value)
+		return nil
+	}
+
+	text := c.Value()
+	isText := c.ml.Type == types.CellTypeSharedString || c.ml.Type == types.CellTypeInlineString || c.ml.Type == types.CellTypeFormula
+	if isText {
+		text = strings.TrimSpace(text)
+	}
+
+	switch {
+	case v.Type() == timeType:
+		d, err := c.Time()
+		if err != nil && isText {
+			if d, err = time.Parse(convert.ISO8601, text); err != nil {
+				d, err = time.Parse("2006-01-02", text)
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		v.Set(reflect.ValueOf(d))
+	case v.Type() == durationType:
+		d, err := c.Duration()
+		if err != nil && isText {
+			d, err = time.ParseDuration(text)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(int64(d))
+	case v.Kind() == reflect.String:
+		v.SetString(c.Value())
+	case v.Kind() == reflect.Bool:
+		b, err := c.Bool()
+		if err != nil && isText {
+			b, err = strconv.ParseBool(text)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(b)
+	case v.Kind() >= reflect.Int && v.Kind() <= reflect.Int64:
+		i, err := c.Int64()
+		if err != nil && isText {
+			i, err = strconv.ParseInt(text, 10, 64)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if v.OverflowInt(i) {
+			return errors.New(fmt.Sprintf("value %d overflows %s", i, v.Type()))
+		}
+
+		v.SetInt(i)
+	case v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uint64:
+		i, err := c.Int64()
+		if err != nil && isText {
+			i, err = strconv.ParseInt(text, 10, 64)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if i < 0 || v.OverflowUint(uint64(i)) {
+			return errors.New(fmt.Sprintf("value %d overflows %s", i, v.Type()))
+		}
+
+		v.SetUint(uint64(i))
+	case v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64:
+		f, err := c.Float64()
+		if err != nil && isText {
+			f, err = strconv.ParseFloat(text, 64)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(f)
+	default:
+		return errors.New(fmt.Sprintf("unsupported type of field: %s", v.Type()))
+	}
+
+	return nil
+}
+
+//ReadStructs reads rows of sheet into the slice of structs that out points to. First row is a header and fields are mapped to the columns by names of header
+//or by column that was set via tag, e.g.: `xlsx:"Total"` or `xlsx:",col:C"`. Empty rows are skipped and errors of conversion are collected as StructErrors
+func (s *sheetInfo) ReadStructs(out interface{}) error {
+	ptr := reflect.ValueOf(out)
+	if ptr.Kind() != reflect.Ptr || ptr.Elem().Kind() != reflect.Slice {
+		return errors.New(fmt.Sprintf("pointer to slice of structs is required, but %T was used", out))
+	}
+
+	slice, t, err := structSlice(out)
+	if err != nil {
+		return err
+	}
+
+	fields, err := structFields(t, nil)
+	if err != nil {
+		return err
+	}
+
+	isPtr := slice.Type().Elem().Kind() == reflect.Ptr
+	slice.Set(reflect.MakeSlice(slice.Type(), 0, 0))
+
+	var errorList StructErrors
+	rows := s.sheet.Rows()
+	for rows.HasNext() {
+		rIdx, row := rows.Next()
+
+		//first row is a header, so map columns by names
+		if rIdx == 0 {
+			cols, _ := s.sheet.Dimension()
+			headers := make(map[string]int, cols)
+			for cIdx := cols - 1; cIdx >= 0; cIdx-- {
+				headers[strings.ToLower(strings.TrimSpace(row.Cell(cIdx).Value()))] = cIdx
+			}
+
+			for _, f := range fields {
+				if cIdx, ok := headers[strings.ToLower(f.header)]; ok && f.col == -1 {
+					f.col = cIdx
+				}
+			}
+
+			continue
+		}
+
+		item := reflect.New(t).Elem()
+		empty := true
+		for _, f := range fields {
+			if f.col == -1 {
+				continue
+			}
+
+			c := row.Cell(f.col)
+			if len(c.Value()) == 0 {
+				continue
+			}
+
+			empty = false
+			if err := setStructValue(structFieldAlloc(item, f.index), c); err != nil {
+				errorList = append(errorList, &StructError{Ref: types.CellRefFromIndexes(f.col, rIdx), Field: f.header, Err: err})
+			}
+		}
+
+		if empty {
+			continue
+		}
+
+		if isPtr {
+			item = item.Addr()
+		}
+
+		slice.Set(reflect.Append(slice, item))
+	}
+
+	if len(errorList) > 0 {
+		return errorList
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package xlsx
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type testAudit struct {
+	Created time.Time `xlsx:"Created At,format:yyyy-mm-dd"`
+	secret  string
+}
+
+type testInvoice struct {
+	testAudit
+	Number   string  `xlsx:"Invoice,width:20"`
+	Total    float64 `xlsx:",format:#,##0.00,width:12"`
+	Quantity *int
+	Paid     bool
+	Note     string `xlsx:"-"`
+}
+
+func TestWriteStructs(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Invoices")
+
+	//invalid data
+	require.NotNil(t, sheet.WriteStructs("invoices"))
+	require.NotNil(t, sheet.WriteStructs([]int{1, 2}))
+	require.NotNil(t, sheet.WriteStructs([]struct {
+		Total float64 `xlsx:",width:-1"`
+	}{}))
+	require.NotNil(t, sheet.WriteStructs([]struct {
+		Total float64 `xlsx:",unknown"`
+	}{}))
+
+	quantity := 5
+	created := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Nil(t, sheet.WriteStructs([]*testInvoice{
+		{testAudit{created, "a"}, "INV-1", 1234.5, &quantity, true, "skipped"},
+		{testAudit{created, "b"}, "INV-2", 10, nil, false, "skipped"},
+	}))
+
+	cols, rows := sheet.Dimension()
+	require.Equal(t, 5, cols)
+	require.Equal(t, 3, rows)
+
+	var headers []string
+	for cIdx := 0; cIdx < cols; cIdx++ {
+		headers = append(headers, sheet.Cell(cIdx, 0).Value())
+	}
+
+	require.Equal(t, []string{"Created At", "Invoice", "Total", "Quantity", "Paid"}, headers)
+	require.Equal(t, "2020-01-01", sheet.CellByRef("A2").FormattedValue())
+	require.Equal(t, "INV-1", sheet.CellByRef("B2").Value())
+	require.Equal(t, "1,234.50", sheet.CellByRef("C2").FormattedValue())
+	require.Equal(t, "5", sheet.CellByRef("D2").Value())
+	require.Equal(t, "", sheet.CellByRef("D3").Value())
+	require.Equal(t, "FALSE", sheet.CellByRef("E3").FormattedValue())
+
+	require.Equal(t, float32(20), sheet.ColOptions(1).Width)
+	require.Equal(t, float32(12), sheet.ColOptions(2).Width)
+	require.Equal(t, sheet.CellByRef("C2").Formatting(), sheet.ColOptions(2).Style)
+}
+
+func TestReadStructs(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Invoices")
+
+	quantity := 5
+	created := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Nil(t, sheet.WriteStructs([]testInvoice{
+		{testAudit{created, ""}, "INV-1", 1234.5, &quantity, true, ""},
+		{testAudit{created, ""}, "INV-2", 10, nil, false, ""},
+	}))
+
+	//values stored as text must be converted, invalid values must be reported
+	sheet.CellByRef("C5").SetValue("  42.5 ")
+	sheet.CellByRef("D5").SetValue("many")
+	sheet.CellByRef("F5").SetValue("extra")
+
+	var invoices []*testInvoice
+	require.NotNil(t, sheet.ReadStructs(invoices))
+
+	err := sheet.ReadStructs(&invoices)
+	require.IsType(t, StructErrors{}, err)
+	require.Equal(t, "D5: field Quantity: strconv.ParseInt: parsing \"many\": invalid syntax", err.Error())
+	require.Equal(t, 3, len(invoices))
+	require.Equal(t, &testInvoice{testAudit{created, ""}, "INV-1", 1234.5, &quantity, true, ""}, invoices[0])
+	require.Equal(t, &testInvoice{testAudit{created, ""}, "INV-2", 10, nil, false, ""}, invoices[1])
+	require.Equal(t, 42.5, invoices[2].Total)
+
+	//mapping by column
+	var totals []struct {
+		Amount float64 `xlsx:",col:C"`
+		Extra  string  `xlsx:",col:F"`
+	}
+
+	require.NotNil(t, sheet.ReadStructs(&[]struct {
+		Amount float64 `xlsx:",col:1A"`
+	}{}))
+
+	_ = sheet.ReadStructs(&totals)
+	require.Equal(t, 3, len(totals))
+	require.Equal(t, 10.0, totals[1].Amount)
+	require.Equal(t, "extra", totals[2].Extra)
+
+	//read as stream
+	require.Nil(t, xl.SaveAs("./test_files/tmp.xlsx"))
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	var paid []struct {
+		Invoice string
+		Paid    bool
+	}
+
+	//empty rows are skipped
+	require.Nil(t, xl.Sheet(0, SheetModeStream).ReadStructs(&paid))
+	require.Equal(t, 2, len(paid))
+	require.Equal(t, "INV-1", paid[0].Invoice)
+	require.Equal(t, true, paid[0].Paid)
+}
@@ -4,15 +4,20 @@ import (
 	"github.com/plandem/ooxml"
 	"github.com/plandem/xlsx/format"
 	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/color"
 	"github.com/plandem/xlsx/internal/hash"
 	"github.com/plandem/xlsx/internal/ml"
 	"github.com/plandem/xlsx/internal/number_format"
+	"strings"
 	_ "unsafe"
 )
 
 //go:linkname fromStyleFormat github.com/plandem/xlsx/format.fromStyleFormat
 func fromStyleFormat(f *format.StyleFormat) (font *ml.Font, fill *ml.Fill, alignment *ml.CellAlignment, numFormat *ml.NumberFormat, protection *ml.CellProtection, border *ml.Border, namedInfo *ml.NamedStyleInfo)
 
+//go:linkname toStyleFormat github.com/plandem/xlsx/format.toStyleFormat
+func toStyleFormat(font *ml.Font, fill *ml.Fill, alignment *ml.CellAlignment, numFormat *ml.NumberFormat, protection *ml.CellProtection, border *ml.Border, namedInfo *ml.NamedStyleInfo) *format.StyleFormat
+
 //StyleSheet is a higher level object that wraps ml.StyleSheet with functionality
 type StyleSheet struct {
 	ml ml.StyleSheet
@@ -172,6 +177,9 @@ func (ss *StyleSheet) buildIndexes() {
 
 //adds a number formats for each type of number format if required. These styles will be used by cell's typed SetXXX methods
 func (ss *StyleSheet) addTypedStylesIfRequired() {
+	ss.doc.lock()
+	defer ss.doc.unlock()
+
 	if len(ss.typedStyles) == 0 {
 		for _, t := range []numberFormat.Type{
 			numberFormat.General,
@@ -183,7 +191,7 @@ func (ss *StyleSheet) addTypedStylesIfRequired() {
 			numberFormat.DeltaTime,
 		} {
 			id, _ := numberFormat.Default(t)
-			ss.typedStyles[t] = ss.addStyle(format.NewStyles(format.NumberFormatID(id)))
+			ss.typedStyles[t] = ss.addStyleLocked(format.NewStyles(format.NumberFormatID(id)))
 		}
 
 		ss.file.MarkAsUpdated()
@@ -192,6 +200,9 @@ func (ss *StyleSheet) addTypedStylesIfRequired() {
 
 //resolveNumberFormat returns resolved NumberFormat code for styleID
 func (ss *StyleSheet) resolveNumberFormat(id ml.DirectStyleID) string {
+	ss.doc.lock()
+	defer ss.doc.unlock()
+
 	style := ss.ml.CellXfs.Items[id]
 
 	//return code for built-in number format
@@ -211,29 +222,161 @@ func (ss *StyleSheet) resolveNumberFormat(id ml.DirectStyleID) string {
 	return code
 }
 
-//resolveDirectStyle returns resolved StyleFormat for DirectStyleID
+//resolveFont returns font that is used by styleID or nil if there is no such font
+func (ss *StyleSheet) resolveFont(id ml.DirectStyleID) *ml.Font {
+	ss.doc.lock()
+	defer ss.doc.unlock()
+
+	ss.file.LoadIfRequired(ss.buildIndexes)
+
+	if int(id) >= len(ss.ml.CellXfs.Items) {
+		return nil
+	}
+
+	if fontID := ss.ml.CellXfs.Items[id].FontId; fontID >= 0 && fontID < len(ss.ml.Fonts.Items) {
+		return ss.ml.Fonts.Items[fontID]
+	}
+
+	return nil
+}
+
+//resolveStyle returns font, fill, border and alignment that are used by styleID. Missing parts are returned as nil
+func (ss *StyleSheet) resolveStyle(id ml.DirectStyleID) (font *ml.Font, fill *ml.Fill, border *ml.Border, alignment *ml.CellAlignment) {
+	ss.doc.lock()
+	defer ss.doc.unlock()
+
+	ss.file.LoadIfRequired(ss.buildIndexes)
+
+	if int(id) >= len(ss.ml.CellXfs.Items) {
+		return
+	}
+
+	style := ss.ml.CellXfs.Items[id]
+	if style.FontId >= 0 && style.FontId < len(ss.ml.Fonts.Items) {
+		font = ss.ml.Fonts.Items[style.FontId]
+	}
+
+	if style.FillId >= 0 && style.FillId < len(ss.ml.Fills.Items) {
+		fill = ss.ml.Fills.Items[style.FillId]
+	}
+
+	if style.BorderId >= 0 && style.BorderId < len(ss.ml.Borders.Items) {
+		border = ss.ml.Borders.Items[style.BorderId]
+	}
+
+	if palette := ss.customIndexedColors(); palette != nil {
+		font, fill, border = applyPalette(font, fill, border, palette)
+	}
+
+	return font, fill, border, style.Alignment
+}
+
+//resolveDiffStyle returns differential style for styleID or nil if there is no such style
+func (ss *StyleSheet) resolveDiffStyle(id ml.DiffStyleID) *ml.DiffStyle {
+	ss.doc.lock()
+	defer ss.doc.unlock()
+
+	ss.file.LoadIfRequired(ss.buildIndexes)
+
+	if id < 0 || int(id) >= len(ss.ml.Dxfs.Items) {
+		return nil
+	}
+
+	return ss.ml.Dxfs.Items[id]
+}
+
+//resolveDiffFormat returns StyleFormat for differential style with id or nil if there is no such style
+func (ss *StyleSheet) resolveDiffFormat(id ml.DiffStyleID) *format.StyleFormat {
+	dxf := ss.resolveDiffStyle(id)
+	if dxf == nil {
+		return nil
+	}
+
+	return toStyleFormat(dxf.Font, dxf.Fill, dxf.Alignment, dxf.NumberFormat, dxf.Protection, dxf.Border, nil)
+}
+
+//diffStylesCount returns number of differential styles
+func (ss *StyleSheet) diffStylesCount() int {
+	ss.doc.lock()
+	defer ss.doc.unlock()
+
+	ss.file.LoadIfRequired(ss.buildIndexes)
+	return len(ss.ml.Dxfs.Items)
+}
+
+//resolveDirectStyle returns resolved StyleFormat for DirectStyleID or nil if there is no such style.
+//N.B.: named style is not resolved, so StyleFormat is based on default named style
 func (ss *StyleSheet) resolveDirectStyle(id ml.DirectStyleID) *format.StyleFormat {
-	if id == 0 {
+	font, fill, border, alignment := ss.resolveStyle(id)
+
+	ss.doc.lock()
+	defer ss.doc.unlock()
+
+	if id < 0 || int(id) >= len(ss.ml.CellXfs.Items) {
 		return nil
 	}
 
-	cellStyle := ss.ml.CellXfs.Items[id]
-	style := &format.StyleFormat{}
-	_ = cellStyle
+	style := ss.ml.CellXfs.Items[id]
+
+	//N.B.: first items are defaults, so there is no need to copy them
+	if style.FontId <= 0 {
+		font = nil
+	}
+
+	if style.FillId <= 1 {
+		fill = nil
+	}
+
+	if style.BorderId <= 0 {
+		border = nil
+	}
+
+	var number *ml.NumberFormat
+	if style.NumFmtId > 0 {
+		number = &ml.NumberFormat{ID: style.NumFmtId}
+	}
+
+	return toStyleFormat(font, fill, alignment, number, style.Protection, border, nil)
+}
+
+//mergeStyle adds a style with settings of style with id, where settings of overlay are layered on top, and returns ID of added style.
+//Named style of style with id is kept, unless overlay has own named style
+func (ss *StyleSheet) mergeStyle(id ml.DirectStyleID, overlay *format.StyleFormat) format.DirectStyleID {
+	base := ss.resolveDirectStyle(id)
+	if base == nil {
+		return ss.addStyle(overlay)
+	}
+
+	ss.doc.lock()
+	defer ss.doc.unlock()
 
-	//TODO: Populate format.StyleFormat with required information
-	panic(errorNotSupported)
+	merged := format.Merge(base, overlay)
+	if _, _, _, _, _, _, namedInfo := fromStyleFormat(overlay); namedInfo != nil {
+		return ss.addStyleLocked(merged)
+	}
 
-	return style
+	style, _ := ss.buildStyle(merged)
+	return ss.addDirectStyleIfRequired(&ml.DirectStyle{
+		XfId:  ss.ml.CellXfs.Items[id].XfId,
+		Style: style,
+	})
 }
 
 //adds a differential style
 func (ss *StyleSheet) addDiffStyle(f *format.StyleFormat) format.DiffStyleID {
+	ss.doc.lock()
+	defer ss.doc.unlock()
+
 	ss.file.LoadIfRequired(ss.buildIndexes)
 
 	//get settings for style
 	font, fill, alignment, numFormat, protection, border, _ := fromStyleFormat(f)
 
+	//N.B.: built-in indexed colors are replaced with RGB colors, if palette was customized
+	if ss.customIndexedColors() != nil {
+		font, fill, border = applyPalette(font, fill, border, color.Indexed())
+	}
+
 	dXf := &ml.DiffStyle{
 		Font:         font,
 		Fill:         fill,
@@ -268,9 +411,12 @@ func (ss *StyleSheet) addNamedStyleIfRequired(namedInfo *ml.NamedStyleInfo, styl
 
 	//TODO: check if it's possible to have 2 same built-styles
 
-	//if there is already same styles, then use it
-	if id, ok := ss.namedStyleIndex[key]; ok {
+	//if there is already same styles with same name, then use it
+	if id, ok := ss.namedStyleIndex[key]; ok && ss.hasNamedStyle(namedInfo.Name, ml.NamedStyleID(id)) {
 		namedInfo.XfId = ml.NamedStyleID(id)
+	} else if info := ss.findNamedStyle(namedInfo.Name); info != nil {
+		//named style with same name already exists, so only direct style must be based on it
+		return info.XfId
 	} else {
 		//add a new style
 		nextID := format.NamedStyleID(len(ss.ml.CellStyleXfs.Items))
@@ -287,12 +433,128 @@ func (ss *StyleSheet) addNamedStyleIfRequired(namedInfo *ml.NamedStyleInfo, styl
 	return namedInfo.XfId
 }
 
+//addNamedStyle adds a named style with name and formatting of style and returns ID of direct style that is based on it. If there is already named style with such name, then formatting of that named style is replaced
+func (ss *StyleSheet) addNamedStyle(name string, f *format.StyleFormat) format.DirectStyleID {
+	ss.doc.lock()
+	defer ss.doc.unlock()
+
+	//built style holds a copy of information about named style, so formatting of caller is not changed
+	style, namedInfo := ss.buildStyle(f)
+	if namedInfo == nil {
+		namedInfo = &ml.NamedStyleInfo{}
+	}
+
+	namedInfo.BuiltinId = nil
+	namedInfo.Name = name
+
+	if existing := ss.findNamedStyle(name); existing != nil && int(existing.XfId) < len(ss.ml.CellStyleXfs.Items) {
+		namedStyle := ml.NamedStyle(style)
+		prev := hash.NamedStyle(ss.ml.CellStyleXfs.Items[existing.XfId]).Hash()
+		if id, ok := ss.namedStyleIndex[prev]; ok && ml.NamedStyleID(id) == existing.XfId {
+			delete(ss.namedStyleIndex, prev)
+		}
+
+		ss.ml.CellStyleXfs.Items[existing.XfId] = &namedStyle
+		ss.namedStyleIndex[hash.NamedStyle(&namedStyle).Hash()] = format.NamedStyleID(existing.XfId)
+		ss.file.MarkAsUpdated()
+	}
+
+	return ss.addDirectStyleIfRequired(&ml.DirectStyle{
+		XfId:  ss.addNamedStyleIfRequired(namedInfo, style),
+		Style: style,
+	})
+}
+
+//findNamedStyle returns information about named style with name or nil if there is no such named style. Names are case insensitive
+func (ss *StyleSheet) findNamedStyle(name string) *ml.NamedStyleInfo {
+	for _, info := range ss.ml.CellStyles.Items {
+		if strings.EqualFold(info.Name, name) {
+			return info
+		}
+	}
+
+	return nil
+}
+
+//hasNamedStyle returns true if there is named style with name that refers to xfId
+func (ss *StyleSheet) hasNamedStyle(name string, xfId ml.NamedStyleID) bool {
+	for _, info := range ss.ml.CellStyles.Items {
+		if info.XfId == xfId && strings.EqualFold(info.Name, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//namedStyles returns names of all named styles, including built-in styles
+func (ss *StyleSheet) namedStyles() []string {
+	ss.doc.lock()
+	defer ss.doc.unlock()
+
+	ss.file.LoadIfRequired(ss.buildIndexes)
+
+	names := make([]string, 0, len(ss.ml.CellStyles.Items))
+	for _, info := range ss.ml.CellStyles.Items {
+		names = append(names, info.Name)
+	}
+
+	return names
+}
+
+//resolveNamedStyle returns id of direct style that is based on named style with name, names are case insensitive as in Excel
+func (ss *StyleSheet) resolveNamedStyle(name string) (format.DirectStyleID, bool) {
+	ss.doc.lock()
+	defer ss.doc.unlock()
+
+	ss.file.LoadIfRequired(ss.buildIndexes)
+
+	for _, info := range ss.ml.CellStyles.Items {
+		if !strings.EqualFold(info.Name, name) || int(info.XfId) < 0 || int(info.XfId) >= len(ss.ml.CellStyleXfs.Items) {
+			continue
+		}
+
+		return ss.addDirectStyleIfRequired(&ml.DirectStyle{
+			XfId:  info.XfId,
+			Style: ml.Style(*ss.ml.CellStyleXfs.Items[info.XfId]),
+		}), true
+	}
+
+	return 0, false
+}
+
 //adds a style. Style can be Direct or Named. Depends on settings.
 func (ss *StyleSheet) addStyle(f *format.StyleFormat) format.DirectStyleID {
+	ss.doc.lock()
+	defer ss.doc.unlock()
+	return ss.addStyleLocked(f)
+}
+
+//addStyleLocked adds a style, caller must hold lock of document
+func (ss *StyleSheet) addStyleLocked(f *format.StyleFormat) format.DirectStyleID {
+	style, namedInfo := ss.buildStyle(f)
+
+	//add named style if required and get related XfId
+	XfId := ss.addNamedStyleIfRequired(namedInfo, style)
+
+	return ss.addDirectStyleIfRequired(&ml.DirectStyle{
+		XfId:  XfId,
+		Style: style,
+	})
+}
+
+//buildStyle adds fonts, fills, borders and number formats of settings if required and returns related style with information about named style, caller must hold lock of document
+func (ss *StyleSheet) buildStyle(f *format.StyleFormat) (ml.Style, *ml.NamedStyleInfo) {
 	ss.file.LoadIfRequired(ss.buildIndexes)
 
 	//get settings and add information if required
 	font, fill, alignment, numFormat, protection, border, namedInfo := fromStyleFormat(f)
+
+	//N.B.: built-in indexed colors are replaced with RGB colors, if palette was customized
+	if ss.customIndexedColors() != nil {
+		font, fill, border = applyPalette(font, fill, border, color.Indexed())
+	}
+
 	fontID := ss.addFontIfRequired(font)
 	fillID := ss.addFillIfRequired(fill)
 	borderID := ss.addBorderIfRequired(border)
@@ -306,7 +568,6 @@ func (ss *StyleSheet) addStyle(f *format.StyleFormat) format.DirectStyleID {
 		cellStyle.xfId = cellStyleXf.index => NamedStyleID
 	*/
 
-	XfId := ml.NamedStyleID(0)
 	style := ml.Style{
 		FontId:            fontID,
 		FillId:            fillID,
@@ -322,14 +583,11 @@ func (ss *StyleSheet) addStyle(f *format.StyleFormat) format.DirectStyleID {
 		ApplyProtection:   protection != nil,
 	}
 
-	//add named style if required and get related XfId
-	XfId = ss.addNamedStyleIfRequired(namedInfo, style)
-
-	cellXf := &ml.DirectStyle{
-		XfId:  XfId,
-		Style: style,
-	}
+	return style, namedInfo
+}
 
+//adds a direct style if required
+func (ss *StyleSheet) addDirectStyleIfRequired(cellXf *ml.DirectStyle) format.DirectStyleID {
 	//return id of already existing information
 	key := hash.DirectStyle(cellXf).Hash()
 	if id, ok := ss.directStyleIndex[key]; ok {
@@ -344,6 +602,64 @@ func (ss *StyleSheet) addStyle(f *format.StyleFormat) format.DirectStyleID {
 	return nextID
 }
 
+//copyDirectStyle copies direct style with id from other style sheet with fonts, fills, borders and number formats and returns id of copied style
+//N.B.: named styles are not copied, so copied style is based on default named style
+func (ss *StyleSheet) copyDirectStyle(from *StyleSheet, id ml.DirectStyleID) ml.DirectStyleID {
+	from.file.LoadIfRequired(from.buildIndexes)
+	ss.file.LoadIfRequired(ss.buildIndexes)
+
+	if id <= 0 || int(id) >= len(from.ml.CellXfs.Items) {
+		return 0
+	}
+
+	style := from.ml.CellXfs.Items[id].Style
+
+	//N.B.: first items are defaults that are same for all style sheets
+	if style.FontId > 0 && style.FontId < len(from.ml.Fonts.Items) {
+		font := *from.ml.Fonts.Items[style.FontId]
+		style.FontId = ss.addFontIfRequired(&font)
+	}
+
+	if style.FillId > 1 && style.FillId < len(from.ml.Fills.Items) {
+		fill := *from.ml.Fills.Items[style.FillId]
+		style.FillId = ss.addFillIfRequired(&fill)
+	}
+
+	if style.BorderId > 0 && style.BorderId < len(from.ml.Borders.Items) {
+		border := *from.ml.Borders.Items[style.BorderId]
+		style.BorderId = ss.addBorderIfRequired(&border)
+	}
+
+	if !numberFormat.IsBuiltIn(style.NumFmtId) {
+		number := numberFormat.New(-1, from.resolveNumberFormat(id))
+		style.NumFmtId = ss.addNumFormatIfRequired(&number)
+	}
+
+	return ss.addDirectStyleIfRequired(&ml.DirectStyle{Style: style})
+}
+
+//copyDiffStyle copies differential style with id from other style sheet and returns id of copied style
+func (ss *StyleSheet) copyDiffStyle(from *StyleSheet, id ml.DiffStyleID) ml.DiffStyleID {
+	from.file.LoadIfRequired(from.buildIndexes)
+	ss.file.LoadIfRequired(ss.buildIndexes)
+
+	if id < 0 || int(id) >= len(from.ml.Dxfs.Items) {
+		return 0
+	}
+
+	dXf := *from.ml.Dxfs.Items[id]
+	key := hash.DiffStyle(&dXf).Hash()
+	if id, ok := ss.diffStyleIndex[key]; ok {
+		return id
+	}
+
+	nextID := format.DiffStyleID(len(ss.ml.Dxfs.Items))
+	ss.ml.Dxfs.Items = append(ss.ml.Dxfs.Items, &dXf)
+	ss.diffStyleIndex[key] = nextID
+	ss.file.MarkAsUpdated()
+	return nextID
+}
+
 //adds a new font if required
 func (ss *StyleSheet) addFontIfRequired(font *ml.Font) int {
 	//if there is no information, then use default
@@ -419,6 +735,15 @@ func (ss *StyleSheet) addNumFormatIfRequired(number *ml.NumberFormat) int {
 		return number.ID
 	}
 
+	//id of already existing custom format without code
+	if len(number.Code) == 0 {
+		for _, f := range ss.ml.NumberFormats.Items {
+			if number.ID == f.ID {
+				return f.ID
+			}
+		}
+	}
+
 	//Return id of already existing information.
 	//N.B.: Supposed that for custom format we have -1 as code, so hash should be same for new/existing custom format
 	key := hash.NumberFormat(number).Hash()
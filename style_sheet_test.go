@@ -1,6 +1,7 @@
 package xlsx
 
 import (
+	"bytes"
 	"github.com/stretchr/testify/require"
 	"testing"
 
@@ -418,3 +419,169 @@ func TestStyleSheets_reopen(t *testing.T) {
 	checkStyles(xl, t)
 	xl.Close()
 }
+
+func TestStyleSheet_namedStyles(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Sheet1")
+	require.Equal(t, []string{"Normal"}, xl.NamedStyles())
+
+	header := xl.AddNamedStyle("Header", format.NewStyles(
+		format.Font.Bold,
+		format.Fill.Type(format.PatternTypeSolid),
+		format.Fill.Color("#FFFF00"),
+	))
+
+	require.Equal(t, header, xl.AddNamedStyle("Header", format.NewStyles(
+		format.Font.Bold,
+		format.Fill.Type(format.PatternTypeSolid),
+		format.Fill.Color("#FFFF00"),
+	)))
+
+	//same settings as default, but another name
+	plain := xl.AddNamedStyle("Plain", format.NewStyles())
+	require.Equal(t, []string{"Normal", "Header", "Plain"}, xl.NamedStyles())
+
+	//formatting of caller is not changed
+	accent := format.NewStyles(format.Font.Italic)
+	accentID := xl.AddNamedStyle("Accent", accent)
+	require.Equal(t, xl.AddFormatting(format.NewStyles(format.Font.Italic)), xl.AddFormatting(accent))
+	require.NotEqual(t, accentID, xl.AddFormatting(accent))
+
+	//same name with other formatting replaces formatting of named style
+	xfId := xl.styleSheet.ml.CellXfs.Items[accentID].XfId
+	redefined := xl.AddNamedStyle("accent", format.NewStyles(format.Font.Underline(format.UnderlineTypeSingle)))
+	require.Equal(t, []string{"Normal", "Header", "Plain", "Accent"}, xl.NamedStyles())
+	require.Equal(t, xfId, xl.styleSheet.ml.CellXfs.Items[redefined].XfId)
+	require.Equal(t, 4, len(xl.styleSheet.ml.CellStyleXfs.Items))
+	require.NotEqual(t, xl.styleSheet.ml.CellXfs.Items[0].XfId, xl.styleSheet.ml.CellXfs.Items[plain].XfId)
+
+	styleID, err := xl.NamedStyle("header")
+	require.Nil(t, err)
+	require.Equal(t, header, styleID)
+
+	_, err = xl.NamedStyle("Unknown")
+	require.NotNil(t, err)
+
+	require.Nil(t, sheet.Range("A1:B1").SetNamedStyle("Header"))
+	require.Nil(t, sheet.CellByRef("A2").SetNamedStyle("Normal"))
+	require.NotNil(t, sheet.CellByRef("A3").SetNamedStyle("Unknown"))
+	require.Equal(t, header, sheet.CellByRef("B1").Formatting())
+	require.Equal(t, format.DirectStyleID(0), sheet.CellByRef("A2").Formatting())
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, xl.SaveAs(buf))
+
+	saved, err := OpenBytes(buf.Bytes())
+	require.Nil(t, err)
+	defer saved.Close()
+
+	require.Equal(t, []string{"Normal", "Header", "Plain", "Accent"}, saved.NamedStyles())
+	styleID, err = saved.NamedStyle("Header")
+	require.Nil(t, err)
+	require.True(t, saved.Sheet(0).CellByRef("A1").Styles().Bold)
+	require.Equal(t, "#FFFF00", resolveCellStyle(saved.styleSheet, styleID, saved.ThemeColors()).Fill)
+}
+
+func TestStyleSheet_mergeStyle(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Sheet1")
+	require.Nil(t, xl.ResolveFormatting(format.DirectStyleID(100)))
+	require.Equal(t, format.NewStyles(), xl.ResolveFormatting(format.DefaultDirectStyle))
+
+	filled := xl.AddFormatting(format.NewStyles(
+		format.Font.Name("Arial"),
+		format.Fill.Type(format.PatternTypeSolid),
+		format.Fill.Color("#FFFF00"),
+		format.NumberFormat("0.00%"),
+	))
+
+	sheet.CellByRef("A1").SetFormatting(filled)
+	sheet.CellByRef("B1").SetFormatting(filled)
+	boldID := sheet.CellByRef("A1").AddStyles(format.NewStyles(format.Font.Bold))
+	require.NotEqual(t, filled, boldID)
+
+	style := sheet.CellByRef("A1").Styles()
+	require.Equal(t, "Arial", style.Font)
+	require.True(t, style.Bold)
+	require.Equal(t, "#FFFF00", style.Fill)
+	require.Equal(t, "0.00%", style.NumberFormat)
+
+	sheet.Range("A1:B2").AddStyles(format.NewStyles(format.Font.Bold))
+	require.Equal(t, boldID, sheet.CellByRef("A1").Formatting())
+	require.Equal(t, boldID, sheet.CellByRef("B1").Formatting())
+	require.True(t, sheet.CellByRef("A2").Styles().Bold)
+	require.Equal(t, "", sheet.CellByRef("A2").Styles().Fill)
+
+	//default style of hyperlink keeps existing style of cell
+	sheet.CellByRef("C1").SetFormatting(filled)
+	require.Nil(t, sheet.CellByRef("C1").SetHyperlink("https://example.com"))
+	style = sheet.CellByRef("C1").Styles()
+	require.Equal(t, "#FFFF00", style.Fill)
+	require.Equal(t, "#0563C1", style.Color)
+	require.Equal(t, format.UnderlineTypeSingle, style.Underline)
+
+	require.Nil(t, sheet.CellByRef("D1").SetHyperlink("https://example.com"))
+	require.Equal(t, "#0563C1", sheet.CellByRef("D1").Styles().Color)
+	require.Equal(t, "", sheet.CellByRef("D1").Styles().Fill)
+
+	//named style is kept
+	ss := xl.styleSheet
+	named := xl.AddFormatting(format.NewStyles(format.NamedStyle("Accent"), format.Font.Italic))
+	namedStyles := len(ss.ml.CellStyles.Items)
+	xfId := ss.ml.CellXfs.Items[named].XfId
+	require.NotEqual(t, 0, int(xfId))
+
+	sheet.CellByRef("E1").SetFormatting(named)
+	mergedID := sheet.CellByRef("E1").AddStyles(format.NewStyles(format.Font.Bold))
+	require.Equal(t, xfId, ss.ml.CellXfs.Items[mergedID].XfId)
+	require.Equal(t, namedStyles, len(ss.ml.CellStyles.Items))
+	require.True(t, sheet.CellByRef("E1").Styles().Bold)
+	require.True(t, sheet.CellByRef("E1").Styles().Italic)
+}
+
+func TestStyleSheet_diffStyles(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Sheet1")
+	require.Equal(t, 0, len(xl.DiffFormattings()))
+	require.Nil(t, xl.ResolveDiffFormatting(format.DiffStyleID(0)))
+
+	bold := format.NewStyles(format.Font.Bold, format.Font.Color("#FF0000"))
+	boldID := xl.AddDiffFormatting(bold)
+	require.Equal(t, boldID, xl.AddDiffFormatting(format.NewStyles(format.Font.Bold, format.Font.Color("#FF0000"))))
+	require.Equal(t, bold, xl.ResolveDiffFormatting(boldID))
+
+	filledID := xl.AddDiffFormatting(format.NewStyles(format.Fill.Color("#FFFF00")))
+	require.NotEqual(t, boldID, filledID)
+	require.Equal(t, 2, len(xl.DiffFormattings()))
+
+	//reuse of existing differential style
+	require.Nil(t, sheet.AddConditional(format.NewConditions(
+		format.Conditions.Rule(
+			format.Condition.Type(format.ConditionTypeExpression),
+			format.Condition.Formula("A1>0"),
+			format.Condition.Priority(1),
+			format.Condition.StyleID(boldID),
+		),
+	), "A1:A10"))
+
+	require.NotNil(t, sheet.AddConditional(format.NewConditions(
+		format.Conditions.Rule(
+			format.Condition.Type(format.ConditionTypeExpression),
+			format.Condition.Formula("B1>0"),
+			format.Condition.Priority(2),
+			format.Condition.StyleID(format.DiffStyleID(100)),
+		),
+	), "B1:B10"))
+
+	list := sheet.ConditionalFormats()
+	require.Equal(t, 1, len(list))
+	require.Equal(t, boldID, *list[0].Rules[0].StyleID)
+	require.True(t, list[0].Rules[0].Style.Bold)
+	require.Equal(t, 2, len(xl.DiffFormattings()))
+}
@@ -0,0 +1,139 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+)
+
+//ValueKind is a kind of value of cell, detected by type and number format of cell
+type ValueKind byte
+
+//List of all possible values for ValueKind
+const (
+	ValueEmpty  ValueKind = iota //cell has no value, but has style or formula without value
+	ValueText                    //text
+	ValueNumber                  //number
+	ValueDate                    //number with date or time format, or date
+	ValueBool                    //boolean
+	ValueError                   //error
+)
+
+//StyledCell is a non empty cell with resolved style and detected kind of value
+type StyledCell struct {
+	*Cell
+	Kind  ValueKind
+	Style *CellStyle
+}
+
+//StyledCellIterator is a interface for iterating non empty cells inside of range with resolved styles
+type StyledCellIterator interface {
+	//Next returns next non empty cell in range and corresponding indexes
+	Next() (cIdx int, rIdx int, cell *StyledCell)
+
+	//HasNext returns true if there are cells to iterate or false in other case
+	HasNext() bool
+}
+
+//resolvedStyles is a cache of resolved styles by id that is shared between iterators of rows or cols of sheet
+type resolvedStyles struct {
+	styles  map[ml.DirectStyleID]*CellStyle
+	palette []string
+}
+
+//styledCellIterator is object that holds required information for iterator of styled cells
+type styledCellIterator struct {
+	r      *Range
+	cIdx   int
+	rIdx   int
+	next   *StyledCell
+	styles *resolvedStyles
+}
+
+var _ StyledCellIterator = (*styledCellIterator)(nil)
+
+func newStyledCellIterator(r *Range, styles *resolvedStyles) StyledCellIterator {
+	if styles == nil {
+		styles = &resolvedStyles{}
+	}
+
+	i := &styledCellIterator{
+		r:      r,
+		cIdx:   r.bounds.FromCol,
+		rIdx:   r.bounds.FromRow,
+		styles: styles,
+	}
+
+	i.seek()
+	return i
+}
+
+//valueKind returns kind of value of cell
+func valueKind(c *Cell) ValueKind {
+	if len(c.ml.Value) == 0 && c.ml.InlineStr == nil {
+		return ValueEmpty
+	}
+
+	switch c.ml.Type {
+	case types.CellTypeBool:
+		return ValueBool
+	case types.CellTypeError:
+		return ValueError
+	case types.CellTypeDate:
+		return ValueDate
+	case types.CellTypeNumber, types.CellTypeGeneral:
+		if date, time := c.hasDateFormat(); date || time {
+			return ValueDate
+		}
+
+		return ValueNumber
+	}
+
+	return ValueText
+}
+
+//resolve returns resolved style of cell, styles are resolved once per id
+func (rs *resolvedStyles) resolve(c *Cell) *CellStyle {
+	if style, ok := rs.styles[c.ml.Style]; ok {
+		return style
+	}
+
+	if rs.styles == nil {
+		rs.styles = make(map[ml.DirectStyleID]*CellStyle)
+		rs.palette = c.sheet.workbook.doc.ThemeColors()
+	}
+
+	style := resolveCellStyle(c.sheet.workbook.doc.styleSheet, c.ml.Style, rs.palette)
+	rs.styles[c.ml.Style] = style
+	return style
+}
+
+//seek looks for next non empty cell, skipping cells that are covered by merged cells
+func (i *styledCellIterator) seek() {
+	i.next = nil
+	for ; i.rIdx <= i.r.bounds.ToRow; i.cIdx, i.rIdx = i.r.bounds.FromCol, i.rIdx+1 {
+		for ; i.cIdx <= i.r.bounds.ToCol; i.cIdx++ {
+			if cIdx, rIdx, _ := i.r.sheet.info().mergedCells.Resolve(i.cIdx, i.rIdx); cIdx != i.cIdx || rIdx != i.rIdx {
+				continue
+			}
+
+			if c := i.r.sheet.Cell(i.cIdx, i.rIdx); !isCellEmpty(c.ml) {
+				i.next = &StyledCell{Cell: c, Kind: valueKind(c), Style: i.styles.resolve(c)}
+				return
+			}
+		}
+	}
+}
+
+//Next returns next non empty cell in range and corresponding indexes
+func (i *styledCellIterator) Next() (cIdx int, rIdx int, cell *StyledCell) {
+	cIdx, rIdx, cell = i.cIdx, i.rIdx, i.next
+
+	i.cIdx++
+	i.seek()
+	return
+}
+
+//HasNext returns true if there are cells to iterate or false in other case
+func (i *styledCellIterator) HasNext() bool {
+	return i.next != nil
+}
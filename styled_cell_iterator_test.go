@@ -0,0 +1,75 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/format"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestStyledCellIterator(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Sheet1")
+	bold := xl.AddFormatting(format.NewStyles(
+		format.Font.Bold,
+		format.Font.Color("#FF0000"),
+		format.Fill.Type(format.PatternTypeSolid),
+		format.Fill.Color("#FFFF00"),
+	))
+
+	sheet.CellByRef("A1").SetValue("text")
+	sheet.CellByRef("A1").SetFormatting(bold)
+	sheet.CellByRef("C1").SetFloat(1.5)
+	sheet.CellByRef("D1").SetDate(time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC))
+	sheet.CellByRef("A2").SetBool(true)
+	sheet.CellByRef("B2").SetFormatting(bold)
+	sheet.CellByRef("C2").SetValue("merged")
+	require.Nil(t, sheet.Range("C2:D3").Merge())
+
+	type result struct {
+		ref  string
+		kind ValueKind
+	}
+
+	var cells []result
+	var styles []*CellStyle
+	for rows := sheet.Rows(); rows.HasNext(); {
+		_, row := rows.Next()
+		for it := row.StyledCells(); it.HasNext(); {
+			cIdx, rIdx, c := it.Next()
+			cells = append(cells, result{string(c.ml.Ref), c.Kind})
+			styles = append(styles, c.Style)
+			require.Equal(t, sheet.Cell(cIdx, rIdx).ml, c.ml)
+		}
+	}
+
+	require.Equal(t, []result{
+		{"A1", ValueText},
+		{"C1", ValueNumber},
+		{"D1", ValueDate},
+		{"A2", ValueBool},
+		{"B2", ValueEmpty},
+		{"C2", ValueText},
+	}, cells)
+
+	require.Equal(t, "#FF0000", styles[0].Color)
+	require.Equal(t, "#FFFF00", styles[0].Fill)
+	require.True(t, styles[0].Bold)
+	require.True(t, styles[0] == styles[4])
+	require.Equal(t, "@", styles[0].NumberFormat)
+	require.False(t, styles[1].Bold)
+	require.Equal(t, "", styles[1].Fill)
+
+	var refs []string
+	for cols := sheet.Cols(); cols.HasNext(); {
+		_, col := cols.Next()
+		for it := col.StyledCells(); it.HasNext(); {
+			_, _, c := it.Next()
+			refs = append(refs, string(c.ml.Ref))
+		}
+	}
+
+	require.Equal(t, []string{"A1", "A2", "B2", "C1", "C2", "D1"}, refs)
+}
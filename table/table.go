@@ -0,0 +1,178 @@
+package table
+
+import (
+	"errors"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+)
+
+//Info is objects that holds information about table
+type Info struct {
+	table     *ml.Table
+	columns   []string
+	functions []TotalsRowFunction
+	noFilter  bool
+}
+
+//Option is a type of option for table
+type Option func(i *Info)
+
+//New creates and returns a new Info object that holds settings for table
+func New(options ...Option) *Info {
+	i := &Info{
+		table: &ml.Table{
+			StyleInfo: &ml.TableStyleInfo{
+				Name:           "TableStyleMedium2",
+				ShowRowStripes: true,
+			},
+		},
+	}
+	i.Set(options...)
+	return i
+}
+
+//Set sets new options for table
+func (i *Info) Set(options ...Option) {
+	for _, o := range options {
+		o(i)
+	}
+}
+
+//Name returns name of table
+func (i *Info) Name() string {
+	return i.table.DisplayName
+}
+
+//Bounds returns bounds of table, including header and totals rows
+func (i *Info) Bounds() types.Bounds {
+	return i.table.Bounds
+}
+
+//Style returns name of style of table
+func (i *Info) Style() string {
+	if i.table.StyleInfo == nil {
+		return ""
+	}
+
+	return i.table.StyleInfo.Name
+}
+
+//Columns returns names of columns of table
+func (i *Info) Columns() []string {
+	if len(i.table.Columns.Items) == 0 {
+		return i.columns
+	}
+
+	columns := make([]string, 0, len(i.table.Columns.Items))
+	for _, column := range i.table.Columns.Items {
+		columns = append(columns, column.Name)
+	}
+
+	return columns
+}
+
+//TotalsRow returns true if table has totals row
+func (i *Info) TotalsRow() bool {
+	return i.table.TotalsRowCount > 0 || len(i.functions) > 0
+}
+
+//Name sets name of table. Name must be unique across all tables and defined names of workbook
+func Name(name string) Option {
+	return func(i *Info) {
+		i.table.Name = name
+		i.table.DisplayName = name
+	}
+}
+
+//Style sets name of built-in or custom table style, e.g.: TableStyleMedium9
+func Style(name string) Option {
+	return func(i *Info) {
+		i.table.StyleInfo.Name = name
+	}
+}
+
+//Columns sets names of columns. Header cells will be updated with these names
+func Columns(names ...string) Option {
+	return func(i *Info) {
+		i.columns = names
+	}
+}
+
+//TotalsRow adds totals row right after the last row of table with functions for columns in the same order as columns
+func TotalsRow(functions ...TotalsRowFunction) Option {
+	return func(i *Info) {
+		if len(functions) == 0 {
+			functions = []TotalsRowFunction{TotalsNone}
+		}
+
+		i.functions = functions
+	}
+}
+
+//FirstColumn highlights the first column of table
+func FirstColumn(i *Info) {
+	i.table.StyleInfo.ShowFirstColumn = true
+}
+
+//LastColumn highlights the last column of table
+func LastColumn(i *Info) {
+	i.table.StyleInfo.ShowLastColumn = true
+}
+
+//BandedColumns shows banded columns
+func BandedColumns(i *Info) {
+	i.table.StyleInfo.ShowColumnStripes = true
+}
+
+//NoBandedRows hides banded rows
+func NoBandedRows(i *Info) {
+	i.table.StyleInfo.ShowRowStripes = false
+}
+
+//NoFilterButton hides filter buttons of header
+func NoFilterButton(i *Info) {
+	i.noFilter = true
+}
+
+//Validate validates table info and return error in case of invalid settings
+func (i *Info) Validate() error {
+	if len(i.table.DisplayName) == 0 {
+		return errors.New("table has no name")
+	}
+
+	for _, f := range i.functions {
+		if f == TotalsCustom {
+			return errors.New("custom function for totals row is not supported")
+		}
+	}
+
+	return nil
+}
+
+//private method used by tables manager to unpack Info
+func fromTableInfo(info *Info) (table *ml.Table, columns []string, functions []TotalsRowFunction, err error) {
+	if err = info.Validate(); err != nil {
+		return
+	}
+
+	//copy info to prevent side effects of reusing Info for different tables
+	t := *info.table
+	if t.StyleInfo != nil {
+		styleInfo := *t.StyleInfo
+		t.StyleInfo = &styleInfo
+	}
+
+	//N.B.: bounds of auto filter will be resolved by tables manager
+	if !info.noFilter {
+		t.AutoFilter = &ml.AutoFilter{}
+	}
+
+	table, columns, functions = &t, info.columns, info.functions
+	return
+}
+
+//private method used by tables manager to pack Info
+func toTableInfo(table *ml.Table) *Info {
+	t := *table
+	return &Info{table: &t, noFilter: t.AutoFilter == nil}
+}
@@ -0,0 +1,68 @@
+package table
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTable(t *testing.T) {
+	//invalid settings
+	_, _, _, err := fromTableInfo(New())
+	require.NotNil(t, err)
+
+	_, _, _, err = fromTableInfo(New(Name("Sales"), TotalsRow(TotalsCustom)))
+	require.NotNil(t, err)
+
+	//default settings
+	info := New(Name("Sales"))
+	require.Equal(t, "Sales", info.Name())
+	require.Equal(t, "TableStyleMedium2", info.Style())
+	require.Equal(t, false, info.TotalsRow())
+
+	tbl, columns, functions, err := fromTableInfo(info)
+	require.Nil(t, err)
+	require.Nil(t, columns)
+	require.Nil(t, functions)
+	require.Equal(t, &ml.Table{
+		Name:        "Sales",
+		DisplayName: "Sales",
+		AutoFilter:  &ml.AutoFilter{},
+		StyleInfo:   &ml.TableStyleInfo{Name: "TableStyleMedium2", ShowRowStripes: true},
+	}, tbl)
+
+	//custom settings
+	info = New(
+		Name("Sales"),
+		Style("TableStyleMedium9"),
+		Columns("Region", "Amount"),
+		TotalsRow(),
+		FirstColumn,
+		LastColumn,
+		BandedColumns,
+		NoBandedRows,
+		NoFilterButton,
+	)
+	require.Equal(t, "TableStyleMedium9", info.Style())
+	require.Equal(t, []string{"Region", "Amount"}, info.Columns())
+	require.Equal(t, true, info.TotalsRow())
+
+	tbl, columns, functions, err = fromTableInfo(info)
+	require.Nil(t, err)
+	require.Equal(t, []string{"Region", "Amount"}, columns)
+	require.Equal(t, []TotalsRowFunction{TotalsNone}, functions)
+	require.Nil(t, tbl.AutoFilter)
+	require.Equal(t, &ml.TableStyleInfo{Name: "TableStyleMedium9", ShowFirstColumn: true, ShowLastColumn: true, ShowColumnStripes: true}, tbl.StyleInfo)
+
+	//unpacked table must not be affected by later changes of info
+	info.Set(Style("TableStyleLight1"))
+	require.Equal(t, "TableStyleMedium9", tbl.StyleInfo.Name)
+
+	//pack table
+	tbl.Columns.Items = []*ml.TableColumn{{ID: 1, Name: "Region"}, {ID: 2, Name: "Amount"}}
+	tbl.TotalsRowCount = 1
+	info = toTableInfo(tbl)
+	require.Equal(t, "Sales", info.Name())
+	require.Equal(t, []string{"Region", "Amount"}, info.Columns())
+	require.Equal(t, true, info.TotalsRow())
+}
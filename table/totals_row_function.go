@@ -0,0 +1,45 @@
+package table
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+//TotalsRowFunction is alias of original primitives.TotalsRowFunctionType type to:
+// 1) make it public
+// 2) forbid usage of integers directly
+type TotalsRowFunction = primitives.TotalsRowFunctionType
+
+//List of all possible values for TotalsRowFunction
+const (
+	_ TotalsRowFunction = iota
+	TotalsNone
+	TotalsSum
+	TotalsMin
+	TotalsMax
+	TotalsAverage
+	TotalsCount
+	TotalsCountNums
+	TotalsStdDev
+	TotalsVar
+	TotalsCustom
+)
+
+func init() {
+	primitives.FromTotalsRowFunctionType = map[TotalsRowFunction]string{
+		TotalsNone:      "none",
+		TotalsSum:       "sum",
+		TotalsMin:       "min",
+		TotalsMax:       "max",
+		TotalsAverage:   "average",
+		TotalsCount:     "count",
+		TotalsCountNums: "countNums",
+		TotalsStdDev:    "stdDev",
+		TotalsVar:       "var",
+		TotalsCustom:    "custom",
+	}
+
+	primitives.ToTotalsRowFunctionType = make(map[string]TotalsRowFunction, len(primitives.FromTotalsRowFunctionType))
+	for k, v := range primitives.FromTotalsRowFunctionType {
+		primitives.ToTotalsRowFunctionType[v] = k
+	}
+}
@@ -0,0 +1,301 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/plandem/ooxml"
+	sharedML "github.com/plandem/ooxml/ml"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/table"
+	"github.com/plandem/xlsx/types"
+	"regexp"
+	"strings"
+	_ "unsafe"
+)
+
+//go:linkname fromTableInfo github.com/plandem/xlsx/table.fromTableInfo
+func fromTableInfo(info *table.Info) (table *ml.Table, columns []string, functions []table.TotalsRowFunction, err error)
+
+//go:linkname toTableInfo github.com/plandem/xlsx/table.toTableInfo
+func toTableInfo(table *ml.Table) *table.Info
+
+var regExpTable = regexp.MustCompile(`^xl/tables/table\d+\.xml$`)
+
+//subtotalFunctions is a list of codes for SUBTOTAL function that is used by totals row, ignoring hidden values
+var subtotalFunctions = map[table.TotalsRowFunction]int{
+	table.TotalsAverage:   101,
+	table.TotalsCountNums: 102,
+	table.TotalsCount:     103,
+	table.TotalsMax:       104,
+	table.TotalsMin:       105,
+	table.TotalsStdDev:    107,
+	table.TotalsSum:       109,
+	table.TotalsVar:       110,
+}
+
+type tableItem struct {
+	ml   ml.Table
+	file *ooxml.PackageFile
+	rid  sharedML.RID
+}
+
+type tables struct {
+	sheet    *sheetInfo
+	items    []*tableItem
+	isLoaded bool
+}
+
+//newTables creates an object that implements tables functionality
+func newTables(sheet *sheetInfo) *tables {
+	return &tables{sheet: sheet}
+}
+
+//loadIfRequired lookups for existing tables of sheet and loads them
+func (t *tables) loadIfRequired() {
+	if t.isLoaded {
+		return
+	}
+
+	t.isLoaded = true
+
+	//only existing sheets can have existing tables
+	if t.sheet.file.IsNew() || len(t.sheet.ml.TableParts.Items) == 0 {
+		return
+	}
+
+	doc := t.sheet.workbook.doc
+	t.sheet.attachRelationshipsIfRequired()
+	for _, part := range t.sheet.ml.TableParts.Items {
+		fileName := t.sheet.relationships.GetTargetById(string(part.RID))
+		if zf, ok := doc.pkg.File(fileName).(*zip.File); ok {
+			item := &tableItem{rid: part.RID}
+			item.file = ooxml.NewPackageFile(doc.pkg, zf, &item.ml, nil)
+			item.file.LoadIfRequired(nil)
+			t.items = append(t.items, item)
+		}
+	}
+}
+
+//resolveWorkbook returns the max id and names of all tables of workbook
+func (t *tables) resolveWorkbook() (int, []string) {
+	id, names, loaded := 0, []string(nil), make(map[string]bool)
+	resolve := func(tbl *ml.Table) {
+		names = append(names, tbl.DisplayName)
+		if tbl.ID > id {
+			id = tbl.ID
+		}
+	}
+
+	//tables of already opened sheets
+	for _, sheet := range t.sheet.workbook.doc.sheets {
+		if sheet == nil || sheet.tables == nil {
+			continue
+		}
+
+		for _, item := range sheet.tables.items {
+			resolve(&item.ml)
+			loaded[item.file.FileName()] = true
+		}
+	}
+
+	//tables of sheets that were not opened yet
+	for _, f := range t.sheet.workbook.doc.pkg.Files() {
+		if zf, ok := f.(*zip.File); ok && regExpTable.MatchString(zf.Name) && !loaded[zf.Name] {
+			if reader, err := zf.Open(); err == nil {
+				tbl := ml.Table{}
+				if err := xml.NewDecoder(reader).Decode(&tbl); err == nil {
+					resolve(&tbl)
+				}
+
+				_ = reader.Close()
+			}
+		}
+	}
+
+	return id, names
+}
+
+//escapeColumn escapes name of column to use it in structured reference
+func escapeColumn(name string) string {
+	return strings.NewReplacer(`'`, `''`, `[`, `'[`, `]`, `']`, `#`, `'#`).Replace(name)
+}
+
+//Add adds a new table for bounds. The first row of bounds is a header and totals row, if any, will be added after bounds
+func (t *tables) Add(bounds types.Bounds, info *table.Info) error {
+	t.loadIfRequired()
+
+	if info == nil {
+		return errors.New("no settings for table")
+	}
+
+	tbl, columns, functions, err := fromTableInfo(info)
+	if err != nil {
+		return err
+	}
+
+	if err := t.sheet.workbook.definedNames.validateName(tbl.DisplayName); err != nil {
+		return errors.New(fmt.Sprintf("invalid name of table: %s", tbl.DisplayName))
+	}
+
+	width, height := bounds.Dimension()
+	if height < 2 {
+		return errors.New("table requires a header row and at least one row of data")
+	}
+
+	if len(columns) > width {
+		return errors.New(fmt.Sprintf("table has %d columns, but %d names of columns were provided", width, len(columns)))
+	}
+
+	if len(functions) > width {
+		return errors.New(fmt.Sprintf("table has %d columns, but %d functions for totals row were provided", width, len(functions)))
+	}
+
+	//name must be unique across all tables and defined names
+	id, names := t.resolveWorkbook()
+	for _, definedName := range t.sheet.workbook.ml.DefinedNames.Items {
+		names = append(names, definedName.Name)
+	}
+
+	for _, name := range names {
+		if strings.EqualFold(name, tbl.DisplayName) {
+			return errors.New(fmt.Sprintf("name is already in use: %s", tbl.DisplayName))
+		}
+	}
+
+	ref := bounds
+	if len(functions) > 0 {
+		ref.ToRow++
+		tbl.TotalsRowCount = 1
+	} else {
+		totalsRowShown := false
+		tbl.TotalsRowShown = &totalsRowShown
+	}
+
+	//tables can't overlap each other or auto filter of sheet
+	for _, item := range t.items {
+		if item.ml.Bounds.Overlaps(ref) {
+			return errors.New(fmt.Sprintf("intersection of tables is not allowed, %s intersects with %s", item.ml.Bounds, ref))
+		}
+	}
+
+	if t.sheet.ml.AutoFilter != nil && t.sheet.ml.AutoFilter.Bounds.Overlaps(ref) {
+		return errors.New(fmt.Sprintf("table %s intersects with auto filter %s", ref, t.sheet.ml.AutoFilter.Bounds))
+	}
+
+	//resolve unique names of columns from settings, existing header cells or default
+	unique := make(map[string]bool, width)
+	for i := 0; i < width; i++ {
+		header := t.sheet.sheet.Cell(bounds.FromCol+i, bounds.FromRow)
+
+		name := header.Value()
+		if i < len(columns) && len(columns[i]) > 0 {
+			name = columns[i]
+		}
+
+		if len(name) == 0 {
+			name = fmt.Sprintf("Column%d", i+1)
+		}
+
+		for n, base := 2, name; unique[strings.ToLower(name)]; n++ {
+			name = fmt.Sprintf("%s%d", base, n)
+		}
+
+		unique[strings.ToLower(name)] = true
+		if header.Value() != name {
+			header.SetString(name)
+		}
+
+		tbl.Columns.Items = append(tbl.Columns.Items, &ml.TableColumn{ID: i + 1, Name: name})
+	}
+
+	//add totals row
+	for i, f := range functions {
+		column := tbl.Columns.Items[i]
+		cell := t.sheet.sheet.Cell(bounds.FromCol+i, ref.ToRow)
+
+		if code, ok := subtotalFunctions[f]; ok {
+			column.TotalsRowFunction = f
+			cell.SetFormula(fmt.Sprintf("SUBTOTAL(%d,%s[%s])", code, tbl.DisplayName, escapeColumn(column.Name)))
+		} else if i == 0 {
+			column.TotalsRowLabel = "Total"
+			cell.SetString(column.TotalsRowLabel)
+		}
+	}
+
+	tbl.ID = id + 1
+	tbl.Bounds = ref
+	if tbl.AutoFilter != nil {
+		tbl.AutoFilter.Bounds = bounds
+	}
+
+	doc := t.sheet.workbook.doc
+	item := &tableItem{ml: *tbl}
+	fileName := doc.uniqueFileName("xl/tables/table%d.xml")
+	item.file = ooxml.NewPackageFile(doc.pkg, fileName, &item.ml, nil)
+	item.file.MarkAsUpdated()
+	doc.pkg.ContentTypes().RegisterContent(fileName, internal.ContentTypeTable)
+	t.sheet.attachRelationshipsIfRequired()
+	_, item.rid = t.sheet.relationships.AddFile(internal.RelationTypeTable, fileName)
+	t.sheet.ml.TableParts.Items = append(t.sheet.ml.TableParts.Items, &ml.TablePart{RID: item.rid})
+	t.items = append(t.items, item)
+	return nil
+}
+
+//index returns index of table with name or -1 if there is no such table
+func (t *tables) index(name string) int {
+	t.loadIfRequired()
+
+	for i, item := range t.items {
+		if strings.EqualFold(item.ml.DisplayName, name) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+//Get returns information about table with name or nil if there is no such table at sheet
+func (t *tables) Get(name string) *table.Info {
+	if idx := t.index(name); idx != -1 {
+		return toTableInfo(&t.items[idx].ml)
+	}
+
+	return nil
+}
+
+//List returns information about all tables of sheet
+func (t *tables) List() []*table.Info {
+	t.loadIfRequired()
+
+	list := make([]*table.Info, 0, len(t.items))
+	for _, item := range t.items {
+		list = append(list, toTableInfo(&item.ml))
+	}
+
+	return list
+}
+
+//Remove removes table with name. Content of cells will be kept as is
+func (t *tables) Remove(name string) {
+	idx := t.index(name)
+	if idx == -1 {
+		return
+	}
+
+	item := t.items[idx]
+	t.items = append(t.items[:idx], t.items[idx+1:]...)
+
+	parts := make([]*ml.TablePart, 0, len(t.sheet.ml.TableParts.Items))
+	for _, part := range t.sheet.ml.TableParts.Items {
+		if part.RID != item.rid {
+			parts = append(parts, part)
+		}
+	}
+
+	t.sheet.ml.TableParts.Items = parts
+	t.sheet.relationships.Remove(item.rid)
+	t.sheet.workbook.doc.pkg.Remove(item.file.FileName())
+}
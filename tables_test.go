@@ -0,0 +1,87 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/table"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTables(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Data")
+	sheet.CellByRef("A1").SetValue("Region")
+	sheet.CellByRef("B1").SetValue("Amount")
+	sheet.CellByRef("A2").SetValue("North")
+	sheet.CellByRef("B2").SetValue(100)
+	sheet.CellByRef("A3").SetValue("South")
+	sheet.CellByRef("B3").SetValue(200)
+
+	//invalid tables
+	require.NotNil(t, sheet.AddTable(types.BoundsFromIndexes(0, 0, 2, 2)))
+	require.NotNil(t, sheet.AddTable(types.BoundsFromIndexes(0, 0, 2, 2), table.Name("A1")))
+	require.NotNil(t, sheet.AddTable(types.BoundsFromIndexes(0, 0, 2, 0), table.Name("Sales")))
+	require.NotNil(t, sheet.AddTable(types.BoundsFromIndexes(0, 0, 1, 2), table.Name("Sales"), table.Columns("A", "B", "C")))
+	require.Equal(t, 0, len(sheet.Tables()))
+
+	//add table with totals row
+	require.Nil(t, sheet.AddTable(types.BoundsFromIndexes(0, 0, 2, 2), table.Name("Sales"), table.Style("TableStyleMedium9"), table.TotalsRow(table.TotalsNone, table.TotalsSum)))
+	info := sheet.Table("sales")
+	require.NotNil(t, info)
+	require.Equal(t, "Sales", info.Name())
+	require.Equal(t, "TableStyleMedium9", info.Style())
+	require.Equal(t, types.BoundsFromIndexes(0, 0, 2, 3), info.Bounds())
+	require.Equal(t, []string{"Region", "Amount", "Column3"}, info.Columns())
+	require.Equal(t, true, info.TotalsRow())
+	require.Equal(t, "Column3", sheet.CellByRef("C1").Value())
+	require.Equal(t, "Total", sheet.CellByRef("A4").Value())
+	require.Equal(t, "SUBTOTAL(109,Sales[Amount])", sheet.CellByRef("B4").Formula())
+
+	tbl := sheet.info().tables.items[0].ml
+	require.Equal(t, 1, tbl.ID)
+	require.Equal(t, types.BoundsFromIndexes(0, 0, 2, 2), tbl.AutoFilter.Bounds)
+	require.Equal(t, table.TotalsSum, tbl.Columns.Items[1].TotalsRowFunction)
+
+	//overlapping and duplicated tables
+	require.NotNil(t, sheet.AddTable(types.BoundsFromIndexes(2, 3, 4, 5), table.Name("Other")))
+	require.NotNil(t, sheet.AddTable(types.BoundsFromIndexes(5, 0, 6, 2), table.Name("SALES")))
+	require.Nil(t, xl.DefineName("Rate", "Data!$A$1"))
+	require.NotNil(t, sheet.AddTable(types.BoundsFromIndexes(5, 0, 6, 2), table.Name("Rate")))
+
+	//add table at another sheet with duplicated names of columns
+	other := xl.AddSheet("Other")
+	other.CellByRef("A1").SetValue("Name")
+	require.Nil(t, other.AddTable(types.BoundsFromIndexes(0, 0, 1, 1), table.Name("People"), table.Columns("", "name"), table.NoFilterButton))
+	require.Equal(t, []string{"Name", "name2"}, other.Table("People").Columns())
+	require.Equal(t, false, other.Table("People").TotalsRow())
+	require.Equal(t, 2, other.info().tables.items[0].ml.ID)
+	require.Nil(t, other.info().tables.items[0].ml.AutoFilter)
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	//id must be unique across all tables, including tables of sheets that were not opened yet
+	other = xl.Sheet(1)
+	require.Nil(t, other.AddTable(types.BoundsFromIndexes(3, 0, 4, 1), table.Name("Cities")))
+	require.Equal(t, 3, other.info().tables.items[1].ml.ID)
+	require.NotNil(t, other.AddTable(types.BoundsFromIndexes(6, 0, 7, 1), table.Name("Sales")))
+
+	sheet = xl.Sheet(0)
+	require.Equal(t, 1, len(sheet.Tables()))
+	info = sheet.Table("Sales")
+	require.Equal(t, types.BoundsFromIndexes(0, 0, 2, 3), info.Bounds())
+	require.Equal(t, []string{"Region", "Amount", "Column3"}, info.Columns())
+
+	//remove table
+	sheet.DeleteTable("Sales")
+	require.Nil(t, sheet.Table("Sales"))
+	require.Equal(t, 0, len(sheet.Tables()))
+	require.Equal(t, 0, len(sheet.info().ml.TableParts.Items))
+	require.Equal(t, "Region", sheet.CellByRef("A1").Value())
+}
@@ -0,0 +1,248 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+//templatePlaceholder is a regular expression for placeholders of template, e.g.: {{Title}} or {{Items.Price}}
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+
+//templateScope holds data of template and current items of collections for repeated rows
+type templateScope struct {
+	data  reflect.Value
+	items map[string]reflect.Value
+}
+
+//indirect returns value that pointers and interfaces of v are referring to
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+
+		v = v.Elem()
+	}
+
+	return v
+}
+
+//isCollection returns true if v is a slice or array that can be used for repeated rows
+func isCollection(v reflect.Value) bool {
+	return (v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8) || v.Kind() == reflect.Array
+}
+
+//templateField returns field of v with name, where name can be key of map, name of field of struct or name of field at the xlsx tag
+func templateField(v reflect.Value, name string) (reflect.Value, bool) {
+	switch v = indirect(v); v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+
+		field := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		return field, field.IsValid()
+	case reflect.Struct:
+		fields, err := structFields(v.Type(), nil)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+
+		for _, f := range fields {
+			if sf := v.Type().FieldByIndex(f.index); sf.Name == name || f.header == name {
+				return structFieldValue(v, f.index), true
+			}
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+//lookup returns value for path of placeholder, e.g.: Items.Price
+func (t *templateScope) lookup(path string) (reflect.Value, error) {
+	names := strings.Split(path, ".")
+
+	v, ok := t.items[names[0]]
+	if !ok {
+		if v, ok = templateField(t.data, names[0]); !ok {
+			return v, errors.New(fmt.Sprintf("unknown placeholder {{%s}}", path))
+		}
+	}
+
+	for _, name := range names[1:] {
+		if v, ok = templateField(v, name); !ok {
+			return v, errors.New(fmt.Sprintf("unknown placeholder {{%s}}", path))
+		}
+	}
+
+	return v, nil
+}
+
+//collection returns name and value of collection that is used by placeholders of row or empty name if row is not repeated
+func (t *templateScope) collection(rIdx int, row []*Cell) (string, reflect.Value, error) {
+	var name string
+	var collection reflect.Value
+	for _, c := range row {
+		for _, match := range templatePlaceholder.FindAllStringSubmatch(c.Value(), -1) {
+			root := strings.Split(match[1], ".")[0]
+			if _, ok := t.items[root]; ok || root == name {
+				continue
+			}
+
+			if v, ok := templateField(t.data, root); ok {
+				if v = indirect(v); v.IsValid() && isCollection(v) {
+					if len(name) > 0 {
+						return "", v, errors.New(fmt.Sprintf("row %d refers to collections %s and %s, but only one collection per row is allowed", rIdx+1, name, root))
+					}
+
+					name, collection = root, v
+				}
+			}
+		}
+	}
+
+	return name, collection, nil
+}
+
+//fill replaces placeholders of cell with values. Cell that holds a single placeholder only, gets a typed value, e.g. number or date
+func (t *templateScope) fill(c *Cell) error {
+	if c.HasFormula() {
+		return nil
+	}
+
+	content := c.Value()
+	matches := templatePlaceholder.FindAllStringSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	//single placeholder keeps type of value
+	if len(matches) == 1 && matches[0][0] == 0 && matches[0][1] == len(content) {
+		v, err := t.lookup(content[matches[0][2]:matches[0][3]])
+		if err != nil {
+			return err
+		}
+
+		if v = indirect(v); !v.IsValid() {
+			c.Clear()
+		} else {
+			c.SetValue(structValue(v))
+		}
+
+		return nil
+	}
+
+	var err error
+	c.SetString(templatePlaceholder.ReplaceAllStringFunc(content, func(placeholder string) string {
+		v, lookupErr := t.lookup(templatePlaceholder.FindStringSubmatch(placeholder)[1])
+		if lookupErr != nil {
+			err = lookupErr
+			return placeholder
+		}
+
+		if v = indirect(v); !v.IsValid() {
+			return ""
+		}
+
+		return fmt.Sprint(v.Interface())
+	}))
+
+	return err
+}
+
+//templateCells returns stored cells of row with placeholders, cells that are covered by merged cells are skipped. Only stored cells are visited, so no cells are created
+func templateCells(s *sheetInfo, rIdx int) []*Cell {
+	var cells []*Cell
+	s.walkStoredRow(rIdx, func(cIdx int, data *ml.Cell) {
+		if mcIdx, mrIdx, _ := s.mergedCells.Resolve(cIdx, rIdx); mcIdx != cIdx || mrIdx != rIdx {
+			return
+		}
+
+		if c := (&Cell{ml: data, sheet: s}); templatePlaceholder.MatchString(c.Value()) {
+			cells = append(cells, c)
+		}
+	})
+
+	return cells
+}
+
+//render replaces placeholders of sheet with values and repeats rows that refer to collections
+func (t *templateScope) render(s *sheetInfo) error {
+	sheet := s.sheet
+	for rIdx := 0; ; {
+		cols, rows := sheet.Dimension()
+		if rIdx >= rows {
+			break
+		}
+
+		cells := templateCells(s, rIdx)
+		name, collection, err := t.collection(rIdx, cells)
+		if err != nil {
+			return err
+		}
+
+		if len(name) == 0 {
+			for _, c := range cells {
+				if err := t.fill(c); err != nil {
+					return err
+				}
+			}
+
+			rIdx++
+			continue
+		}
+
+		n := collection.Len()
+		if n == 0 {
+			sheet.DeleteRow(rIdx)
+			continue
+		}
+
+		//clone row with styles, merged cells and height for each item of collection
+		if n > 1 {
+			sheet.InsertRows(rIdx+1, n-1)
+			options := sheet.Row(rIdx).Options()
+			for i := 1; i < n; i++ {
+				if err := sheet.CopyRange(types.RefFromCellRefs(types.CellRefFromIndexes(0, rIdx), types.CellRefFromIndexes(cols-1, rIdx)), types.CellRefFromIndexes(0, rIdx+i)); err != nil {
+					return err
+				}
+
+				sheet.Row(rIdx + i).Set(options)
+			}
+		}
+
+		for i := 0; i < n; i++ {
+			t.items[name] = collection.Index(i)
+			for _, c := range templateCells(s, rIdx+i) {
+				if err := t.fill(c); err != nil {
+					return err
+				}
+			}
+		}
+
+		delete(t.items, name)
+		rIdx += n
+	}
+
+	return nil
+}
+
+//RenderTemplate replaces {{placeholders}} of all sheets with values of data, where data is a map with string keys or a struct. Nested values are separated with dot, e.g.: {{Customer.Name}}.
+//Rows with placeholders that refer to slice or array, e.g.: {{Items.Price}}, are repeated for each item of collection with styles, merged cells and height of row and references of formulas are updated. Row is deleted if collection is empty.
+//Cell that holds a single placeholder only, gets a typed value, e.g. number or date, so number format of template is respected
+func (xl *Spreadsheet) RenderTemplate(data interface{}) error {
+	t := &templateScope{data: reflect.ValueOf(data), items: make(map[string]reflect.Value)}
+	for i := range xl.sheets {
+		xl.Sheet(i)
+		if err := t.render(xl.sheets[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
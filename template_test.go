@@ -0,0 +1,76 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/options"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Invoice")
+	sheet.CellByRef("A1").SetValue("Invoice #{{Number}} for {{ Customer.Name }}")
+	sheet.CellByRef("A2").SetValue("{{Total}}")
+	sheet.CellByRef("A4").SetValue("{{Items.Name}}")
+	sheet.CellByRef("B4").SetValue("{{Items.Price}}")
+	sheet.CellByRef("C4").SetFormula("B4*2")
+	sheet.CellByRef("E4").SetValue("{{Items.Name}}")
+	require.Nil(t, sheet.Range("E4:F4").Merge())
+	sheet.Row(3).Set(options.NewRowOptions(options.Row.Height(20)))
+	price := xl.AddFormatting(format.NewStyles(format.NumberFormat("#,##0.00")))
+	sheet.CellByRef("B4").SetFormatting(price)
+	sheet.CellByRef("A5").SetValue("{{Notes}}")
+	sheet.CellByRef("A6").SetValue("end")
+
+	type item struct {
+		Name  string
+		Price float64 `xlsx:"Cost"`
+	}
+
+	err := xl.RenderTemplate(map[string]interface{}{
+		"Number":   42,
+		"Customer": &struct{ Name string }{"ACME"},
+		"Total":    31.5,
+		"Items":    []item{{"Pen", 1.5}, {"Book", 10}, {"Bag", 20}},
+		"Notes":    []string{},
+	})
+
+	require.Nil(t, err)
+
+	//cells without placeholders are not created
+	require.Nil(t, sheet.info().storedCell(1, 1))
+	require.Equal(t, "Invoice #42 for ACME", sheet.CellByRef("A1").Value())
+	total, err := sheet.CellByRef("A2").Float()
+	require.Nil(t, err)
+	require.Equal(t, 31.5, total)
+
+	for i, it := range []item{{"Pen", 1.5}, {"Book", 10}, {"Bag", 20}} {
+		rIdx := 3 + i
+		require.Equal(t, it.Name, sheet.Cell(0, rIdx).Value())
+		v, err := sheet.Cell(1, rIdx).Float()
+		require.Nil(t, err)
+		require.Equal(t, it.Price, v)
+		require.Equal(t, price, sheet.Cell(1, rIdx).Formatting())
+		require.Equal(t, float32(20), sheet.Row(rIdx).Options().Height)
+		require.Equal(t, it.Name, sheet.Cell(4, rIdx).Value())
+	}
+
+	require.Equal(t, "B5*2", sheet.CellByRef("C5").Formula())
+	require.Equal(t, 3, len(sheet.MergedCells()))
+	require.Equal(t, "end", sheet.CellByRef("A7").Value())
+}
+
+func TestRenderTemplate_errors(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Sheet1")
+	sheet.CellByRef("A1").SetValue("{{Unknown}}")
+	require.NotNil(t, xl.RenderTemplate(map[string]interface{}{}))
+
+	sheet.CellByRef("A1").SetValue("{{A.Name}} {{B.Name}}")
+	require.NotNil(t, xl.RenderTemplate(map[string]interface{}{"A": []int{1}, "B": []int{2}}))
+}
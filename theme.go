@@ -0,0 +1,230 @@
+package xlsx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/plandem/ooxml"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+)
+
+//N.B.: theme color indexes have different order than color scheme - light colors go first
+const themeColorsTotal = 12
+
+var (
+	regExpThemeColorScheme = regexp.MustCompile(`(?s)<(\w+:)?clrScheme[\s>].*?</(\w+:)?clrScheme>`)
+	regExpThemeColor       = regexp.MustCompile(`^#?[0-9a-fA-F]{6}$`)
+)
+
+//defaultTheme is a content of default Office theme that is used for a new theme
+const defaultTheme = `<a:theme xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" name="Office Theme"><a:themeElements>` +
+	`<a:clrScheme name="Office"><a:dk1><a:sysClr val="windowText" lastClr="000000"/></a:dk1><a:lt1><a:sysClr val="window" lastClr="FFFFFF"/></a:lt1>` +
+	`<a:dk2><a:srgbClr val="44546A"/></a:dk2><a:lt2><a:srgbClr val="E7E6E6"/></a:lt2><a:accent1><a:srgbClr val="4472C4"/></a:accent1>` +
+	`<a:accent2><a:srgbClr val="ED7D31"/></a:accent2><a:accent3><a:srgbClr val="A5A5A5"/></a:accent3><a:accent4><a:srgbClr val="FFC000"/></a:accent4>` +
+	`<a:accent5><a:srgbClr val="5B9BD5"/></a:accent5><a:accent6><a:srgbClr val="70AD47"/></a:accent6><a:hlink><a:srgbClr val="0563C1"/></a:hlink>` +
+	`<a:folHlink><a:srgbClr val="954F72"/></a:folHlink></a:clrScheme>` +
+	`<a:fontScheme name="Office"><a:majorFont><a:latin typeface="Calibri Light"/><a:ea typeface=""/><a:cs typeface=""/></a:majorFont>` +
+	`<a:minorFont><a:latin typeface="Calibri"/><a:ea typeface=""/><a:cs typeface=""/></a:minorFont></a:fontScheme>` +
+	`<a:fmtScheme name="Office"><a:fillStyleLst>` + themePlaceholderFill + themePlaceholderFill + themePlaceholderFill + `</a:fillStyleLst>` +
+	`<a:lnStyleLst>` + themeLine6350 + themeLine12700 + themeLine19050 + `</a:lnStyleLst>` +
+	`<a:effectStyleLst><a:effectStyle><a:effectLst/></a:effectStyle><a:effectStyle><a:effectLst/></a:effectStyle><a:effectStyle><a:effectLst/></a:effectStyle></a:effectStyleLst>` +
+	`<a:bgFillStyleLst>` + themePlaceholderFill + themePlaceholderFill + themePlaceholderFill + `</a:bgFillStyleLst></a:fmtScheme>` +
+	`</a:themeElements><a:objectDefaults/><a:extraClrSchemeLst/></a:theme>`
+
+const themePlaceholderFill = `<a:solidFill><a:schemeClr val="phClr"/></a:solidFill>`
+const themeLine6350 = `<a:ln w="6350" cap="flat" cmpd="sng" algn="ctr"><a:solidFill><a:schemeClr val="phClr"/></a:solidFill><a:prstDash val="solid"/><a:miter lim="800000"/></a:ln>`
+const themeLine12700 = `<a:ln w="12700" cap="flat" cmpd="sng" algn="ctr"><a:solidFill><a:schemeClr val="phClr"/></a:solidFill><a:prstDash val="solid"/><a:miter lim="800000"/></a:ln>`
+const themeLine19050 = `<a:ln w="19050" cap="flat" cmpd="sng" algn="ctr"><a:solidFill><a:schemeClr val="phClr"/></a:solidFill><a:prstDash val="solid"/><a:miter lim="800000"/></a:ln>`
+
+type theme struct {
+	doc    *Spreadsheet
+	ml     ml.Theme
+	file   *ooxml.PackageFile
+	scheme ml.ThemeColorScheme
+}
+
+//newTheme creates an object that implements theme functionality, a new theme is a default Office theme
+func newTheme(f interface{}, doc *Spreadsheet) *theme {
+	t := &theme{
+		doc: doc,
+	}
+
+	t.file = ooxml.NewPackageFile(doc.pkg, f, &t.ml, nil)
+
+	if t.file.IsNew() {
+		_ = xml.Unmarshal([]byte(defaultTheme), &t.ml)
+		t.afterLoad()
+
+		t.doc.pkg.ContentTypes().RegisterContent(t.file.FileName(), internal.ContentTypeTheme)
+		t.doc.relationships.AddFile(internal.RelationTypeTheme, t.file.FileName())
+		t.file.MarkAsUpdated()
+	}
+
+	return t
+}
+
+//decodeColorScheme decodes color scheme of theme
+func decodeColorScheme(theme *ml.Theme) (*ml.ThemeColorScheme, error) {
+	start := `<theme`
+	for _, attr := range theme.Attrs {
+		value := &bytes.Buffer{}
+		_ = xml.EscapeText(value, []byte(attr.Value))
+		start += fmt.Sprintf(` %s="%s"`, attr.Name.Local, value)
+	}
+
+	content := struct {
+		Scheme *ml.ThemeColorScheme `xml:"themeElements>clrScheme"`
+	}{}
+
+	if err := xml.Unmarshal([]byte(start+">"+theme.InnerXML+"</theme>"), &content); err != nil {
+		return nil, err
+	}
+
+	if content.Scheme == nil {
+		return nil, errors.New("theme has no color scheme")
+	}
+
+	return content.Scheme, nil
+}
+
+func (t *theme) afterLoad() {
+	if scheme, err := decodeColorScheme(&t.ml); err == nil {
+		t.scheme = *scheme
+	}
+}
+
+//schemeColors returns pointers to colors of scheme in order of theme color indexes
+func schemeColors(s *ml.ThemeColorScheme) []*ml.ThemeColor {
+	return []*ml.ThemeColor{&s.Light1, &s.Dark1, &s.Light2, &s.Dark2, &s.Accent1, &s.Accent2, &s.Accent3, &s.Accent4, &s.Accent5, &s.Accent6, &s.Hyperlink, &s.FollowedHyperlink}
+}
+
+//toThemeColors returns #RGB colors of scheme in order of theme color indexes
+func toThemeColors(s *ml.ThemeColorScheme) []string {
+	colors := make([]string, 0, themeColorsTotal)
+	for _, c := range schemeColors(s) {
+		var rgb string
+		if c.RGB != nil {
+			rgb = c.RGB.Val
+		} else if c.System != nil {
+			rgb = c.System.LastColor
+		}
+
+		if len(rgb) == 6 {
+			rgb = "#" + strings.ToUpper(rgb)
+		} else {
+			rgb = ""
+		}
+
+		colors = append(colors, rgb)
+	}
+
+	return colors
+}
+
+//defaultThemeColors returns #RGB colors of default Office theme in order of theme color indexes
+func defaultThemeColors() []string {
+	var theme ml.Theme
+	_ = xml.Unmarshal([]byte(defaultTheme), &theme)
+
+	scheme, _ := decodeColorScheme(&theme)
+	return toThemeColors(scheme)
+}
+
+//Colors returns #RGB colors of theme in order of theme color indexes
+func (t *theme) Colors() []string {
+	t.file.LoadIfRequired(t.afterLoad)
+	return toThemeColors(&t.scheme)
+}
+
+//SetColors sets #RGB colors of theme in order of theme color indexes, empty color keeps existing color as is
+func (t *theme) SetColors(colors ...string) error {
+	if len(colors) > themeColorsTotal {
+		return errors.New(fmt.Sprintf("theme has only %d colors, but %d colors were used", themeColorsTotal, len(colors)))
+	}
+
+	for _, c := range colors {
+		if len(c) > 0 && !regExpThemeColor.MatchString(c) {
+			return errors.New(fmt.Sprintf("invalid color of theme: %s", c))
+		}
+	}
+
+	t.file.LoadIfRequired(t.afterLoad)
+
+	colorsOfScheme := schemeColors(&t.scheme)
+	for i, c := range colors {
+		if len(c) > 0 {
+			*colorsOfScheme[i] = ml.ThemeColor{RGB: &ml.ThemeRGBColor{Val: strings.ToUpper(strings.TrimPrefix(c, "#"))}}
+		}
+	}
+
+	//N.B.: DrawingML is kept as is, so replace only color scheme
+	prefix := t.ml.Prefix
+	if len(prefix) > 0 {
+		prefix += ":"
+	}
+
+	scheme := &bytes.Buffer{}
+	tag := func(name string, c *ml.ThemeColor) {
+		fmt.Fprintf(scheme, `<%s%s>`, prefix, name)
+		if c.RGB != nil {
+			fmt.Fprintf(scheme, `<%ssrgbClr val="%s"/>`, prefix, c.RGB.Val)
+		} else if c.System != nil {
+			fmt.Fprintf(scheme, `<%ssysClr val="%s" lastClr="%s"/>`, prefix, c.System.Val, c.System.LastColor)
+		}
+
+		fmt.Fprintf(scheme, `</%s%s>`, prefix, name)
+	}
+
+	s := &t.scheme
+	fmt.Fprintf(scheme, `<%sclrScheme name="`, prefix)
+	_ = xml.EscapeText(scheme, []byte(s.Name))
+	scheme.WriteString(`">`)
+	tag("dk1", &s.Dark1)
+	tag("lt1", &s.Light1)
+	tag("dk2", &s.Dark2)
+	tag("lt2", &s.Light2)
+	tag("accent1", &s.Accent1)
+	tag("accent2", &s.Accent2)
+	tag("accent3", &s.Accent3)
+	tag("accent4", &s.Accent4)
+	tag("accent5", &s.Accent5)
+	tag("accent6", &s.Accent6)
+	tag("hlink", &s.Hyperlink)
+	tag("folHlink", &s.FollowedHyperlink)
+	fmt.Fprintf(scheme, `</%sclrScheme>`, prefix)
+
+	t.ml.InnerXML = regExpThemeColorScheme.ReplaceAllLiteralString(t.ml.InnerXML, scheme.String())
+	t.file.MarkAsUpdated()
+	return nil
+}
+
+//Set replaces content of theme with a custom theme
+func (t *theme) Set(r io.Reader) error {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	var custom ml.Theme
+	if err := xml.Unmarshal(content, &custom); err != nil {
+		return err
+	}
+
+	scheme, err := decodeColorScheme(&custom)
+	if err != nil {
+		return err
+	}
+
+	//load current theme to replace it with a custom theme
+	t.file.LoadIfRequired(nil)
+	t.ml = custom
+	t.scheme = *scheme
+	t.file.MarkAsUpdated()
+	return nil
+}
@@ -0,0 +1,86 @@
+package xlsx
+
+import (
+	"archive/zip"
+	sharedML "github.com/plandem/ooxml/ml"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestTheme(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Report")
+
+	//default theme
+	colors := xl.ThemeColors()
+	require.Equal(t, 12, len(colors))
+	require.Equal(t, []string{"#FFFFFF", "#000000", "#E7E6E6", "#44546A", "#4472C4"}, colors[:5])
+	require.Equal(t, "#8FAADC", xl.ResolveThemeColor(4, 0.3999755851924192))
+	require.Equal(t, "", xl.ResolveThemeColor(12, 0))
+	require.Nil(t, xl.theme)
+
+	//themed colors of rich text are resolved
+	accent1 := 4
+	c := sheet.CellByRef("A1")
+	c.ml.Type = types.CellTypeInlineString
+	c.ml.InlineStr = &ml.StringItem{RichText: &[]*ml.RichText{
+		{Text: "themed", Font: &ml.RichFont{Color: &ml.Color{Theme: sharedML.OptionalIndex(&accent1), Tint: -0.249977111117893}}},
+	}}
+	require.Equal(t, "#2F5597", c.RichText()[0].Color)
+
+	//custom colors
+	require.NotNil(t, xl.SetThemeColors("red"))
+	require.NotNil(t, xl.SetThemeColors(make([]string, 13)...))
+	require.Nil(t, xl.SetThemeColors("", "", "", "", "#FF0000"))
+	require.Equal(t, "#FF0000", xl.ThemeColors()[4])
+	require.Equal(t, "#FFFFFF", xl.ThemeColors()[0])
+	require.Equal(t, "#BF0000", c.RichText()[0].Color)
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	zr, err := zip.OpenReader("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	for _, f := range zr.File {
+		switch f.Name {
+		case "xl/theme/theme1.xml":
+			r, _ := f.Open()
+			content, _ := ioutil.ReadAll(r)
+			r.Close()
+			require.True(t, strings.HasPrefix(string(content), `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+`<a:theme xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" name="Office Theme"><a:themeElements><a:clrScheme name="Office"><a:dk1><a:sysClr val="windowText" lastClr="000000"/></a:dk1>`))
+			require.True(t, strings.Contains(string(content), `<a:accent1><a:srgbClr val="FF0000"/></a:accent1>`))
+			require.True(t, strings.Contains(string(content), `<a:fontScheme name="Office"><a:majorFont><a:latin typeface="Calibri Light"/>`))
+		case "xl/_rels/workbook.xml.rels":
+			r, _ := f.Open()
+			content, _ := ioutil.ReadAll(r)
+			r.Close()
+			require.True(t, strings.Contains(string(content), `Target="theme/theme1.xml"`))
+		}
+	}
+	zr.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	require.Equal(t, "#FF0000", xl.ThemeColors()[4])
+
+	//custom theme
+	require.NotNil(t, xl.SetTheme(strings.NewReader(`<theme xmlns="http://schemas.openxmlformats.org/drawingml/2006/main"><themeElements></themeElements></theme>`)))
+	require.Nil(t, xl.SetTheme(strings.NewReader(`<theme xmlns="http://schemas.openxmlformats.org/drawingml/2006/main" name="Custom &amp; Co"><themeElements><clrScheme name="Custom">`+
+		`<dk1><srgbClr val="111111"/></dk1><lt1><srgbClr val="EEEEEE"/></lt1><dk2><srgbClr val="222222"/></dk2><lt2><srgbClr val="DDDDDD"/></lt2>`+
+		`<accent1><srgbClr val="00FF00"/></accent1><accent2><srgbClr val="000001"/></accent2><accent3><srgbClr val="000002"/></accent3><accent4><srgbClr val="000003"/></accent4>`+
+		`<accent5><srgbClr val="000004"/></accent5><accent6><srgbClr val="000005"/></accent6><hlink><srgbClr val="0000FF"/></hlink><folHlink><srgbClr val="FF00FF"/></folHlink>`+
+		`</clrScheme></themeElements></theme>`)))
+
+	require.Equal(t, []string{"#EEEEEE", "#111111", "#DDDDDD", "#222222", "#00FF00"}, xl.ThemeColors()[:5])
+	require.Nil(t, xl.SetThemeColors("", "", "", "", "#ABCDEF"))
+	require.Equal(t, "#ABCDEF", xl.ResolveThemeColor(4, 0))
+	require.Nil(t, xl.SaveAs("./test_files/tmp.xlsx"))
+}
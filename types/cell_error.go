@@ -0,0 +1,30 @@
+package types
+
+//CellError is a type of error value of cell, e.g.: #DIV/0!
+type CellError string
+
+//List of all possible values for CellError
+const (
+	ErrorNull        CellError = "#NULL!"
+	ErrorDivide      CellError = "#DIV/0!"
+	ErrorValue       CellError = "#VALUE!"
+	ErrorRef         CellError = "#REF!"
+	ErrorName        CellError = "#NAME?"
+	ErrorNum         CellError = "#NUM!"
+	ErrorNA          CellError = "#N/A"
+	ErrorGettingData CellError = "#GETTING_DATA"
+)
+
+//IsValid returns true if value is a known error value
+func (e CellError) IsValid() bool {
+	switch e {
+	case ErrorNull, ErrorDivide, ErrorValue, ErrorRef, ErrorName, ErrorNum, ErrorNA, ErrorGettingData:
+		return true
+	}
+
+	return false
+}
+
+func (e CellError) String() string {
+	return string(e)
+}
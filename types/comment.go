@@ -0,0 +1,172 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/internal"
+)
+
+//CommentInfo is objects that holds information about comment (note) of cell
+type CommentInfo struct {
+	author   string
+	text     []interface{}
+	visible  bool
+	threaded bool
+	replies  []*CommentInfo
+}
+
+type commentOption func(o *CommentInfo)
+
+//Comment is a 'namespace' for all possible settings for comment
+var Comment commentOption
+
+//NewComment creates and returns a new CommentInfo object that holds settings for comment
+func NewComment(options ...commentOption) *CommentInfo {
+	i := &CommentInfo{}
+	i.Set(options...)
+	return i
+}
+
+//Set sets new options for comment
+func (i *CommentInfo) Set(options ...commentOption) {
+	for _, o := range options {
+		o(i)
+	}
+}
+
+//Validate validates comment info and return error in case of invalid settings
+func (i *CommentInfo) Validate() error {
+	if len(i.text) == 0 {
+		return errors.New("comment has no text")
+	}
+
+	length := 0
+	for _, part := range i.text {
+		switch v := part.(type) {
+		case string:
+			length += len(v)
+		case *format.StyleFormat:
+		default:
+			return errors.New(fmt.Sprintf("unsupported type of comment's text part: %T", part))
+		}
+	}
+
+	if length > internal.ExcelCellLimit {
+		return errors.New(fmt.Sprintf("text of comment exceeds allowed length = %d", internal.ExcelCellLimit))
+	}
+
+	if i.threaded {
+		for _, part := range i.text {
+			if _, ok := part.(string); !ok {
+				return errors.New("threaded comment supports only plain text")
+			}
+		}
+	}
+
+	for _, reply := range i.replies {
+		if len(reply.String()) == 0 {
+			return errors.New("reply of comment has no text")
+		}
+
+		if err := reply.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//Author returns author of comment
+func (i *CommentInfo) Author() string {
+	return i.author
+}
+
+//Visible returns true if comment is always visible
+func (i *CommentInfo) Visible() bool {
+	return i.visible
+}
+
+//Threaded returns true if comment is a threaded comment that can have replies
+func (i *CommentInfo) Threaded() bool {
+	return i.threaded
+}
+
+//Replies returns replies of threaded comment
+func (i *CommentInfo) Replies() []*CommentInfo {
+	return i.replies
+}
+
+//String returns text of comment without any formatting
+func (i *CommentInfo) String() string {
+	var s string
+	for _, part := range i.text {
+		if text, ok := part.(string); ok {
+			s += text
+		}
+	}
+
+	return s
+}
+
+//Author sets author of comment
+func (o *commentOption) Author(author string) commentOption {
+	return func(i *CommentInfo) {
+		i.author = author
+	}
+}
+
+//Text sets text of comment. Parts can be strings or rich text in the same format as for cell's SetText
+func (o *commentOption) Text(parts ...interface{}) commentOption {
+	return func(i *CommentInfo) {
+		i.text = parts
+	}
+}
+
+//Visible sets comment to be always visible
+func (o *commentOption) Visible(i *CommentInfo) {
+	i.visible = true
+}
+
+//Threaded sets comment to be a threaded comment. Threaded comment supports only plain text
+func (o *commentOption) Threaded(i *CommentInfo) {
+	i.threaded = true
+}
+
+//Reply adds a reply with author and text to comment and sets comment to be a threaded comment
+func (o *commentOption) Reply(author string, text string) commentOption {
+	return func(i *CommentInfo) {
+		i.threaded = true
+		i.replies = append(i.replies, &CommentInfo{author: author, text: []interface{}{text}, threaded: true})
+	}
+}
+
+//private method used by comments manager to unpack CommentInfo
+func fromCommentInfo(info *CommentInfo) (author string, text []interface{}, visible bool, err error) {
+	if err = info.Validate(); err != nil {
+		return
+	}
+
+	author, text, visible = info.author, info.text, info.visible
+	return
+}
+
+//private method used by comments manager to pack CommentInfo
+func toCommentInfo(author string, text string, visible bool) *CommentInfo {
+	return &CommentInfo{
+		author:  author,
+		text:    []interface{}{text},
+		visible: visible,
+	}
+}
+
+//private method used by comments manager to unpack thread of CommentInfo
+func fromCommentThread(info *CommentInfo) (threaded bool, replies []*CommentInfo) {
+	return info.threaded, info.replies
+}
+
+//private method used by comments manager to pack thread of CommentInfo
+func toCommentThread(info *CommentInfo, replies []*CommentInfo) *CommentInfo {
+	info.threaded, info.replies = true, replies
+	return info
+}
@@ -0,0 +1,61 @@
+package types
+
+import (
+	"github.com/plandem/xlsx/format"
+	"github.com/plandem/xlsx/internal"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestCommentOption(t *testing.T) {
+	//no text
+	comment := NewComment()
+	require.NotNil(t, comment.Validate())
+
+	bold := format.NewStyles(format.Font.Bold)
+	comment.Set(
+		Comment.Author("John Doe"),
+		Comment.Text("Hello ", bold, "World"),
+		Comment.Visible,
+	)
+
+	require.IsType(t, &CommentInfo{}, comment)
+	require.Equal(t, &CommentInfo{
+		author:  "John Doe",
+		text:    []interface{}{"Hello ", bold, "World"},
+		visible: true,
+	}, comment)
+	require.Nil(t, comment.Validate())
+	require.Equal(t, "Hello World", comment.String())
+	require.Equal(t, "John Doe", comment.Author())
+	require.Equal(t, true, comment.Visible())
+
+	//unsupported part
+	comment.Set(Comment.Text("Hello", 123))
+	require.NotNil(t, comment.Validate())
+
+	//too large
+	comment.Set(Comment.Text(strings.Repeat("a", internal.ExcelCellLimit+1)))
+	require.NotNil(t, comment.Validate())
+
+	//threaded
+	comment = NewComment(
+		Comment.Author("John Doe"),
+		Comment.Text("Question"),
+		Comment.Reply("Jane Doe", "Answer"),
+	)
+	require.Nil(t, comment.Validate())
+	require.Equal(t, true, comment.Threaded())
+	require.Equal(t, 1, len(comment.Replies()))
+	require.Equal(t, "Jane Doe", comment.Replies()[0].Author())
+	require.Equal(t, "Answer", comment.Replies()[0].String())
+
+	//threaded comment with rich text
+	comment.Set(Comment.Text("Hello ", bold, "World"))
+	require.NotNil(t, comment.Validate())
+
+	//reply without text
+	comment = NewComment(Comment.Text("Question"), Comment.Threaded, Comment.Reply("Jane Doe", ""))
+	require.NotNil(t, comment.Validate())
+}
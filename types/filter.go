@@ -0,0 +1,214 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml"
+	"strconv"
+)
+
+//FilterInfo is objects that holds information about criteria of auto filter for column
+type FilterInfo struct {
+	column *ml.FilterColumn
+}
+
+type filterOption func(o *FilterInfo)
+
+//Filter is a 'namespace' for all possible settings for criteria of auto filter
+var Filter filterOption
+
+//NewFilter creates and returns a new FilterInfo object that holds criteria of auto filter for column with 0-based index relative to the first column of auto filter
+func NewFilter(column int, options ...filterOption) *FilterInfo {
+	i := &FilterInfo{
+		column: &ml.FilterColumn{ColID: column},
+	}
+	i.Set(options...)
+	return i
+}
+
+//Set sets new options for criteria of auto filter
+func (i *FilterInfo) Set(options ...filterOption) {
+	for _, o := range options {
+		o(i)
+	}
+}
+
+//Validate validates criteria of auto filter and return error in case of invalid settings
+func (i *FilterInfo) Validate() error {
+	c := i.column
+
+	if c.ColID < 0 {
+		return errors.New("index of column for filter can't be negative")
+	}
+
+	total := 0
+	if c.Filters != nil {
+		total++
+	}
+
+	if c.Top10 != nil {
+		total++
+	}
+
+	if c.CustomFilters != nil {
+		total++
+
+		if len(c.CustomFilters.Items) == 0 {
+			return errors.New("no any custom filters")
+		}
+
+		if len(c.CustomFilters.Items) > 2 {
+			return errors.New("only two custom filters are allowed for each column")
+		}
+	}
+
+	if total > 1 {
+		return errors.New("only one type of criteria is allowed for each column")
+	}
+
+	return nil
+}
+
+//Column returns 0-based index of column relative to the first column of auto filter
+func (i *FilterInfo) Column() int {
+	return i.column.ColID
+}
+
+//Values returns list of values to filter or nil if there is no such criteria
+func (i *FilterInfo) Values() []string {
+	if i.column.Filters == nil {
+		return nil
+	}
+
+	values := make([]string, 0, len(i.column.Filters.Items))
+	for _, filter := range i.column.Filters.Items {
+		values = append(values, filter.Val)
+	}
+
+	return values
+}
+
+//Equals sets list of values that should be shown
+func (o *filterOption) Equals(values ...string) filterOption {
+	return func(i *FilterInfo) {
+		if i.column.Filters == nil {
+			i.column.Filters = &ml.Filters{}
+		}
+
+		for _, value := range values {
+			i.column.Filters.Items = append(i.column.Filters.Items, &ml.Filter{Val: value})
+		}
+	}
+}
+
+//Blank shows cells with blank values
+func (o *filterOption) Blank(i *FilterInfo) {
+	if i.column.Filters == nil {
+		i.column.Filters = &ml.Filters{}
+	}
+
+	i.column.Filters.Blank = true
+}
+
+//Between shows values between min and max, inclusive
+func (o *filterOption) Between(min, max float64) filterOption {
+	return func(i *FilterInfo) {
+		i.column.CustomFilters = &ml.CustomFilters{
+			And: true,
+			Items: []*ml.CustomFilter{
+				{Operator: FilterOperatorGreaterThanOrEqual, Val: strconv.FormatFloat(min, 'f', -1, 64)},
+				{Operator: FilterOperatorLessThanOrEqual, Val: strconv.FormatFloat(max, 'f', -1, 64)},
+			},
+		}
+	}
+}
+
+//Custom adds a custom criteria with operator and value. Value can contain wildcards '*' and '?' for text. Only two custom criteria are allowed
+func (o *filterOption) Custom(operator FilterOperatorType, value interface{}) filterOption {
+	return func(i *FilterInfo) {
+		if i.column.CustomFilters == nil {
+			i.column.CustomFilters = &ml.CustomFilters{}
+		}
+
+		var val string
+		switch v := value.(type) {
+		case string:
+			val = v
+		case float64:
+			val = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			val = fmt.Sprintf("%v", v)
+		}
+
+		//N.B.: operator 'equal' is default value, so can be omitted
+		if operator == FilterOperatorEqual {
+			operator = 0
+		}
+
+		i.column.CustomFilters.Items = append(i.column.CustomFilters.Items, &ml.CustomFilter{Operator: operator, Val: val})
+	}
+}
+
+//And sets that both custom criteria must be true, by default only one of criteria must be true
+func (o *filterOption) And(i *FilterInfo) {
+	if i.column.CustomFilters == nil {
+		i.column.CustomFilters = &ml.CustomFilters{}
+	}
+
+	i.column.CustomFilters.And = true
+}
+
+//Top shows top n items
+func (o *filterOption) Top(n float64) filterOption {
+	return func(i *FilterInfo) {
+		i.setTop10(true, n)
+	}
+}
+
+//Bottom shows bottom n items
+func (o *filterOption) Bottom(n float64) filterOption {
+	return func(i *FilterInfo) {
+		i.setTop10(false, n)
+	}
+}
+
+//Percent sets that n of Top/Bottom criteria is a percent of items, rather than number of items
+func (o *filterOption) Percent(i *FilterInfo) {
+	if i.column.Top10 == nil {
+		i.setTop10(true, 10)
+	}
+
+	i.column.Top10.Percent = true
+}
+
+//HideButton hides filter button for column
+func (o *filterOption) HideButton(i *FilterInfo) {
+	i.column.HiddenButton = true
+}
+
+func (i *FilterInfo) setTop10(top bool, n float64) {
+	percent := i.column.Top10 != nil && i.column.Top10.Percent
+	i.column.Top10 = &ml.Top10Filter{Val: n, Percent: percent}
+
+	//N.B.: 'top' is true by default, so can be omitted
+	if !top {
+		i.column.Top10.Top = &top
+	}
+}
+
+//private method used by auto filter manager to unpack FilterInfo
+func fromFilterInfo(info *FilterInfo) (*ml.FilterColumn, error) {
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+
+	//copy info to prevent side effects of reusing FilterInfo
+	column := *info.column
+	return &column, nil
+}
+
+//private method used by auto filter manager to pack FilterInfo
+func toFilterInfo(column *ml.FilterColumn) *FilterInfo {
+	info := *column
+	return &FilterInfo{column: &info}
+}
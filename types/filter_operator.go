@@ -0,0 +1,37 @@
+package types
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+//FilterOperatorType is alias of original primitives.FilterOperatorType type to:
+// 1) make it public
+// 2) forbid usage of integers directly
+type FilterOperatorType = primitives.FilterOperatorType
+
+//List of all possible values for FilterOperatorType
+const (
+	_ FilterOperatorType = iota
+	FilterOperatorEqual
+	FilterOperatorLessThan
+	FilterOperatorLessThanOrEqual
+	FilterOperatorNotEqual
+	FilterOperatorGreaterThanOrEqual
+	FilterOperatorGreaterThan
+)
+
+func init() {
+	primitives.FromFilterOperatorType = map[FilterOperatorType]string{
+		FilterOperatorEqual:              "equal",
+		FilterOperatorLessThan:           "lessThan",
+		FilterOperatorLessThanOrEqual:    "lessThanOrEqual",
+		FilterOperatorNotEqual:           "notEqual",
+		FilterOperatorGreaterThanOrEqual: "greaterThanOrEqual",
+		FilterOperatorGreaterThan:        "greaterThan",
+	}
+
+	primitives.ToFilterOperatorType = make(map[string]FilterOperatorType, len(primitives.FromFilterOperatorType))
+	for k, v := range primitives.FromFilterOperatorType {
+		primitives.ToFilterOperatorType[v] = k
+	}
+}
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFilterOption(t *testing.T) {
+	//text equals
+	column, err := fromFilterInfo(NewFilter(1, Filter.Equals("a", "b"), Filter.Blank))
+	require.Nil(t, err)
+	require.Equal(t, &ml.FilterColumn{
+		ColID: 1,
+		Filters: &ml.Filters{
+			Blank: true,
+			Items: []*ml.Filter{{Val: "a"}, {Val: "b"}},
+		},
+	}, column)
+
+	//number between
+	column, err = fromFilterInfo(NewFilter(0, Filter.Between(10, 20.5)))
+	require.Nil(t, err)
+	require.Equal(t, &ml.FilterColumn{
+		CustomFilters: &ml.CustomFilters{
+			And: true,
+			Items: []*ml.CustomFilter{
+				{Operator: FilterOperatorGreaterThanOrEqual, Val: "10"},
+				{Operator: FilterOperatorLessThanOrEqual, Val: "20.5"},
+			},
+		},
+	}, column)
+
+	//top 10
+	column, err = fromFilterInfo(NewFilter(0, Filter.Top(5), Filter.Percent))
+	require.Nil(t, err)
+	require.Equal(t, &ml.FilterColumn{
+		Top10: &ml.Top10Filter{Val: 5, Percent: true},
+	}, column)
+
+	bottom := false
+	column, err = fromFilterInfo(NewFilter(0, Filter.Percent, Filter.Bottom(3)))
+	require.Nil(t, err)
+	require.Equal(t, &ml.FilterColumn{
+		Top10: &ml.Top10Filter{Top: &bottom, Val: 3, Percent: true},
+	}, column)
+
+	//custom
+	column, err = fromFilterInfo(NewFilter(2, Filter.Custom(FilterOperatorEqual, "a*"), Filter.Custom(FilterOperatorNotEqual, 5), Filter.HideButton))
+	require.Nil(t, err)
+	require.Equal(t, &ml.FilterColumn{
+		ColID:        2,
+		HiddenButton: true,
+		CustomFilters: &ml.CustomFilters{
+			Items: []*ml.CustomFilter{
+				{Val: "a*"},
+				{Operator: FilterOperatorNotEqual, Val: "5"},
+			},
+		},
+	}, column)
+
+	//invalid criteria
+	_, err = fromFilterInfo(NewFilter(-1))
+	require.NotNil(t, err)
+
+	_, err = fromFilterInfo(NewFilter(0, Filter.Equals("a"), Filter.Top(10)))
+	require.NotNil(t, err)
+
+	_, err = fromFilterInfo(NewFilter(0, Filter.And))
+	require.NotNil(t, err)
+
+	_, err = fromFilterInfo(NewFilter(0, Filter.Custom(FilterOperatorEqual, 1), Filter.Custom(FilterOperatorEqual, 2), Filter.Custom(FilterOperatorEqual, 3)))
+	require.NotNil(t, err)
+
+	//getters
+	info := toFilterInfo(&ml.FilterColumn{ColID: 3, Filters: &ml.Filters{Items: []*ml.Filter{{Val: "a"}}}})
+	require.Equal(t, 3, info.Column())
+	require.Equal(t, []string{"a"}, info.Values())
+	require.Nil(t, NewFilter(0).Values())
+}
@@ -120,18 +120,74 @@ func (i *HyperlinkInfo) String() string {
 	return target
 }
 
+//Target returns target of hyperlink without location, e.g.: url, mailto or file. Empty string is returned for hyperlink to location inside of the document
+func (i *HyperlinkInfo) Target() string {
+	return string(i.hyperlink.RID)
+}
+
+//Location returns location at target or inside of the document if there is no target, e.g.: Sheet2!A1
+func (i *HyperlinkInfo) Location() string {
+	return i.hyperlink.Location
+}
+
+//Tooltip returns text of tooltip that is shown when mouse is over the hyperlink
+func (i *HyperlinkInfo) Tooltip() string {
+	return i.hyperlink.Tooltip
+}
+
+//Display returns display string of hyperlink
+func (i *HyperlinkInfo) Display() string {
+	return i.hyperlink.Display
+}
+
+//Email returns address and subject of email for hyperlink to email or empty strings for other types of hyperlinks
+func (i *HyperlinkInfo) Email() (address string, subject string) {
+	if i.linkType != hyperlinkTypeEmail {
+		return
+	}
+
+	if ok, info := validator.IsMailTo(string(i.hyperlink.RID)); ok {
+		return info["email"], info["subject"]
+	}
+
+	return string(i.hyperlink.RID), ""
+}
+
+//IsURL returns true if hyperlink targets a web site
+func (i *HyperlinkInfo) IsURL() bool {
+	return i.linkType == hyperlinkTypeWeb
+}
+
+//IsEmail returns true if hyperlink targets an email
+func (i *HyperlinkInfo) IsEmail() bool {
+	return i.linkType == hyperlinkTypeEmail
+}
+
+//IsFile returns true if hyperlink targets an external file
+func (i *HyperlinkInfo) IsFile() bool {
+	return i.linkType == hyperlinkTypeFile
+}
+
+//IsInternal returns true if hyperlink targets a location inside of the document, e.g.: Sheet2!A1
+func (i *HyperlinkInfo) IsInternal() bool {
+	return i.linkType == hyperlinkTypeUnknown && len(i.hyperlink.Location) > 0
+}
+
+//Formatting sets style that will be used by hyperlink
 func (o *hyperlinkOption) Formatting(styleID format.DirectStyleID) hyperlinkOption {
 	return func(i *HyperlinkInfo) {
 		i.styleID = styleID
 	}
 }
 
+//Tooltip sets text of tooltip that is shown when mouse is over the hyperlink
 func (o *hyperlinkOption) Tooltip(tip string) hyperlinkOption {
 	return func(i *HyperlinkInfo) {
 		i.hyperlink.Tooltip = tip
 	}
 }
 
+//Display sets display string of hyperlink
 func (o *hyperlinkOption) Display(display string) hyperlinkOption {
 	return func(i *HyperlinkInfo) {
 		i.hyperlink.Display = display
@@ -208,6 +264,13 @@ func (o *hyperlinkOption) ToBookmark(location string) hyperlinkOption {
 	}
 }
 
+//ToLocation sets target to location inside of the document as is, e.g.: Sheet2!A1, 'Sheet 2'!A1:B2 or name of defined name
+func (o *hyperlinkOption) ToLocation(location string) hyperlinkOption {
+	return func(i *HyperlinkInfo) {
+		i.hyperlink.Location = strings.TrimPrefix(location, "#")
+	}
+}
+
 /*
 ToTarget is very close to HYPERLINK function of Excel
  https://support.office.com/en-us/article/hyperlink-function-333c7ce6-c5ae-4164-9c47-7de9b76f577f
@@ -258,7 +321,7 @@ func (o *hyperlinkOption) ToTarget(target string) hyperlinkOption {
 		if i := strings.LastIndexByte(target, '#'); i != -1 {
 			location = target[i+1:]
 			target = target[:i]
-		} else if i = strings.LastIndexByte(target, ']'); target[0] == '[' && i != -1 {
+		} else if i = strings.LastIndexByte(target, ']'); len(target) > 0 && target[0] == '[' && i != -1 {
 			location = target[i+1:]
 			target = target[1:i]
 		}
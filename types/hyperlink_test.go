@@ -608,3 +608,32 @@ func TestHyperlinkOption_ToTarget(t *testing.T) {
 		require.Equal(t, test.expected, NewHyperlink(Hyperlink.ToTarget(test.target)), "ToTarget(%q) should be %v", test.target, test.expected)
 	}
 }
+
+func TestHyperlinkOption_ToLocation(t *testing.T) {
+	link := NewHyperlink(
+		Hyperlink.ToLocation("#Sheet2!A1"),
+		Hyperlink.Tooltip("Details"),
+	)
+
+	require.Equal(t, &HyperlinkInfo{
+		hyperlink: &ml.Hyperlink{
+			Location: "Sheet2!A1",
+			Tooltip:  "Details",
+		},
+		linkType: hyperlinkTypeUnknown,
+	}, link)
+	require.Nil(t, link.Validate())
+	require.Equal(t, true, link.IsInternal())
+	require.Equal(t, "Sheet2!A1", link.Location())
+	require.Equal(t, "Details", link.Tooltip())
+
+	address, subject := link.Email()
+	require.Equal(t, "", address)
+	require.Equal(t, "", subject)
+
+	link = NewHyperlink(Hyperlink.ToMail("spam@spam.it", "topic"))
+	address, subject = link.Email()
+	require.Equal(t, "spam@spam.it", address)
+	require.Equal(t, "topic", subject)
+	require.Equal(t, false, link.IsInternal())
+}
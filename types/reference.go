@@ -0,0 +1,372 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	regExpSheetName    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+	regExpSheetNameRef = regexp.MustCompile(`^(?:[A-Za-z]{1,3}[0-9]+|[Rr][0-9]*[Cc][0-9]*|[Tt][Rr][Uu][Ee]|[Ff][Aa][Ll][Ss][Ee])$`)
+	regExpA1Part       = regexp.MustCompile(`^(\$?)([A-Za-z]{1,3})?(\$?)([0-9]+)?$`)
+	regExpR1C1Part     = regexp.MustCompile(`^(?:([Rr])(\[-?[0-9]+\]|[0-9]+)?)?(?:([Cc])(\[-?[0-9]+\]|[0-9]+)?)?$`)
+)
+
+//Reference is a parsed reference to a cell or a range of cells with optional sheets, e.g.: A1, $A$1:B2, A:C, 1:3, Sheet1!A1 or 3-D reference Sheet1:Sheet3!A1
+type Reference struct {
+	Sheet      string //name of sheet or the first sheet of 3-D reference, empty for reference without sheet
+	ToSheet    string //name of the last sheet of 3-D reference, empty for other references
+	Bounds     Bounds //0-based indexes of cells
+	AbsFromCol bool   //column of the first cell is absolute, e.g.: $A1
+	AbsFromRow bool   //row of the first cell is absolute, e.g.: A$1
+	AbsToCol   bool   //column of the last cell is absolute
+	AbsToRow   bool   //row of the last cell is absolute
+	Cols       bool   //reference to whole columns, e.g.: A:C
+	Rows       bool   //reference to whole rows, e.g.: 1:3
+}
+
+//SheetPrefix returns prefix with name of sheet for references, name is quoted if required, e.g.: SheetPrefix("My Sheet") => "'My Sheet'!"
+func SheetPrefix(sheet string) string {
+	if regExpSheetName.MatchString(sheet) && !regExpSheetNameRef.MatchString(sheet) {
+		return sheet + "!"
+	}
+
+	return "'" + strings.Replace(sheet, "'", "''", -1) + "'!"
+}
+
+//SheetRangePrefix returns prefix with names of sheets for 3-D references, names are quoted as a whole if required, e.g.: SheetRangePrefix("Sheet1", "My Sheet") => "'Sheet1:My Sheet'!". Prefix of a single sheet is returned if toSheet is empty
+func SheetRangePrefix(sheet string, toSheet string) string {
+	if len(toSheet) == 0 {
+		return SheetPrefix(sheet)
+	}
+
+	if from, to := SheetPrefix(sheet), SheetPrefix(toSheet); from[0] != '\'' && to[0] != '\'' {
+		return sheet + ":" + to
+	}
+
+	//N.B.: 3-D reference is quoted as a whole
+	return SheetPrefix(sheet + ":" + toSheet)
+}
+
+//splitSheetPrefix splits reference into names of sheets and rest of reference
+func splitSheetPrefix(ref string) (sheet string, toSheet string, rest string, err error) {
+	if strings.HasPrefix(ref, "'") {
+		end := 1
+		for ; end < len(ref); end++ {
+			if ref[end] == '\'' {
+				if end+1 < len(ref) && ref[end+1] == '\'' {
+					end++
+					continue
+				}
+
+				break
+			}
+		}
+
+		if end+1 >= len(ref) || ref[end+1] != '!' {
+			return "", "", ref, errors.New(fmt.Sprintf("invalid name of sheet at reference %s", ref))
+		}
+
+		sheet, rest = strings.Replace(ref[1:end], "''", "'", -1), ref[end+2:]
+	} else if idx := strings.LastIndex(ref, "!"); idx >= 0 {
+		sheet, rest = ref[:idx], ref[idx+1:]
+	} else {
+		return "", "", ref, nil
+	}
+
+	//N.B.: colon is not allowed for names of sheets, so it always splits 3-D reference
+	if idx := strings.Index(sheet, ":"); idx >= 0 {
+		sheet, toSheet = sheet[:idx], sheet[idx+1:]
+	}
+
+	if len(sheet) == 0 || (len(toSheet) == 0 && strings.Contains(rest, "!")) {
+		return "", "", ref, errors.New(fmt.Sprintf("invalid name of sheet at reference %s", ref))
+	}
+
+	return sheet, toSheet, rest, nil
+}
+
+//ParseReference parses A1 style reference, e.g.: A1, $A$1:B2, A:C, 1:3, 'My Sheet'!A1 or Sheet1:Sheet3!A1
+func ParseReference(ref string) (*Reference, error) {
+	sheet, toSheet, rest, err := splitSheetPrefix(strings.TrimSpace(ref))
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(rest, ":")
+	if len(parts) > 2 {
+		return nil, errors.New(fmt.Sprintf("invalid reference %s", ref))
+	}
+
+	r := &Reference{Sheet: sheet, ToSheet: toSheet}
+	cols, rows, abs := make([]int, 2), make([]int, 2), make([]bool, 4)
+	kinds := make([]string, 0, 2)
+	for i, part := range parts {
+		m := regExpA1Part.FindStringSubmatch(part)
+		if m == nil || (len(m[2]) == 0 && len(m[4]) == 0) {
+			return nil, errors.New(fmt.Sprintf("invalid reference %s", ref))
+		}
+
+		//for whole rows, the only '$' belongs to row
+		if len(m[2]) == 0 && len(m[1]) > 0 {
+			m[1], m[3] = "", m[1]
+		}
+
+		switch {
+		case len(m[2]) > 0 && len(m[4]) > 0:
+			kinds = append(kinds, "cell")
+			cols[i], rows[i] = CellRef(strings.ToUpper(m[2]) + m[4]).ToIndexes()
+		case len(m[2]) > 0:
+			kinds = append(kinds, "col")
+			cols[i], _ = CellRef(strings.ToUpper(m[2]) + "1").ToIndexes()
+			rows[i] = 0
+			if i == 1 {
+				rows[i] = internal.ExcelRowLimit - 1
+			}
+		default:
+			kinds = append(kinds, "row")
+			rows[i], _ = strconv.Atoi(m[4])
+			rows[i]--
+			cols[i] = 0
+			if i == 1 {
+				cols[i] = internal.ExcelColumnLimit - 1
+			}
+		}
+
+		if (len(m[3]) > 0 && len(m[4]) == 0) || cols[i] >= internal.ExcelColumnLimit || rows[i] < 0 || rows[i] >= internal.ExcelRowLimit {
+			return nil, errors.New(fmt.Sprintf("invalid reference %s", ref))
+		}
+
+		abs[i*2], abs[i*2+1] = len(m[1]) > 0, len(m[3]) > 0
+	}
+
+	if len(parts) == 1 {
+		if kinds[0] != "cell" {
+			return nil, errors.New(fmt.Sprintf("invalid reference %s", ref))
+		}
+
+		cols[1], rows[1], abs[2], abs[3] = cols[0], rows[0], abs[0], abs[1]
+	} else if kinds[0] != kinds[1] {
+		return nil, errors.New(fmt.Sprintf("invalid reference %s", ref))
+	}
+
+	r.Cols, r.Rows = kinds[0] == "col", kinds[0] == "row"
+	r.Bounds = BoundsFromIndexes(cols[0], rows[0], cols[1], rows[1])
+	r.AbsFromCol, r.AbsFromRow, r.AbsToCol, r.AbsToRow = abs[0], abs[1], abs[2], abs[3]
+	return r, nil
+}
+
+//parseR1C1Index returns 0-based index for part of R1C1 reference, e.g.: 5 or [-1], where base is 0-based index of cell that reference is relative to
+func parseR1C1Index(part string, base int, limit int) (index int, absolute bool, err error) {
+	switch {
+	case len(part) == 0:
+		index = base
+	case part[0] == '[':
+		var offset int
+		if offset, err = strconv.Atoi(part[1 : len(part)-1]); err != nil {
+			return
+		}
+
+		index = base + offset
+	default:
+		if index, err = strconv.Atoi(part); err != nil {
+			return
+		}
+
+		index, absolute = index-1, true
+	}
+
+	if index < 0 || index >= limit {
+		err = errors.New(fmt.Sprintf("index of R1C1 reference is out of range: %s", part))
+	}
+
+	return
+}
+
+//ParseR1C1 parses R1C1 style reference that is relative to cell with 0-based indexes col and row, e.g.: R1C1, R[-1]C[2], RC, R1:R3, C[1] or Sheet1!R1C1:R2C2
+func ParseR1C1(ref string, col, row int) (*Reference, error) {
+	sheet, toSheet, rest, err := splitSheetPrefix(strings.TrimSpace(ref))
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(rest, ":")
+	if len(parts) > 2 {
+		return nil, errors.New(fmt.Sprintf("invalid reference %s", ref))
+	}
+
+	r := &Reference{Sheet: sheet, ToSheet: toSheet}
+	cols, rows, abs := make([]int, 2), make([]int, 2), make([]bool, 4)
+	kinds := make([]string, 0, 2)
+	for i, part := range parts {
+		m := regExpR1C1Part.FindStringSubmatch(part)
+		if m == nil || (len(m[1]) == 0 && len(m[3]) == 0) {
+			return nil, errors.New(fmt.Sprintf("invalid reference %s", ref))
+		}
+
+		if len(m[1]) > 0 {
+			if rows[i], abs[i*2+1], err = parseR1C1Index(m[2], row, internal.ExcelRowLimit); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(m[3]) > 0 {
+			if cols[i], abs[i*2], err = parseR1C1Index(m[4], col, internal.ExcelColumnLimit); err != nil {
+				return nil, err
+			}
+		}
+
+		switch {
+		case len(m[1]) > 0 && len(m[3]) > 0:
+			kinds = append(kinds, "cell")
+		case len(m[3]) > 0:
+			kinds = append(kinds, "col")
+			rows[i] = 0
+			if i == 1 || len(parts) == 1 {
+				rows[1] = internal.ExcelRowLimit - 1
+			}
+		default:
+			kinds = append(kinds, "row")
+			cols[i] = 0
+			if i == 1 || len(parts) == 1 {
+				cols[1] = internal.ExcelColumnLimit - 1
+			}
+		}
+	}
+
+	//N.B.: unlike A1 style, single row or column is a valid R1C1 reference, e.g.: R1 or C[1]
+	if len(parts) == 1 {
+		switch kinds[0] {
+		case "cell":
+			cols[1], rows[1] = cols[0], rows[0]
+		case "col":
+			cols[1] = cols[0]
+		case "row":
+			rows[1] = rows[0]
+		}
+
+		abs[2], abs[3] = abs[0], abs[1]
+	} else if kinds[0] != kinds[1] {
+		return nil, errors.New(fmt.Sprintf("invalid reference %s", ref))
+	}
+
+	r.Cols, r.Rows = kinds[0] == "col", kinds[0] == "row"
+	r.Bounds = BoundsFromIndexes(cols[0], rows[0], cols[1], rows[1])
+	r.AbsFromCol, r.AbsFromRow, r.AbsToCol, r.AbsToRow = abs[0], abs[1], abs[2], abs[3]
+	return r, nil
+}
+
+//prefix returns prefix with names of sheets or empty string for reference without sheet
+func (r *Reference) prefix() string {
+	if len(r.Sheet) == 0 {
+		return ""
+	}
+
+	return SheetRangePrefix(r.Sheet, r.ToSheet)
+}
+
+//a1Part returns A1 style part of reference for 0-based indexes
+func (r *Reference) a1Part(col, row int, absCol, absRow bool) string {
+	var part string
+	if !r.Rows {
+		if absCol {
+			part += "$"
+		}
+
+		part += strings.TrimSuffix(string(CellRefFromIndexes(col, 0)), "1")
+	}
+
+	if !r.Cols {
+		if absRow {
+			part += "$"
+		}
+
+		part += strconv.Itoa(row + 1)
+	}
+
+	return part
+}
+
+//String returns A1 style reference, e.g.: Sheet1!$A$1:B2
+func (r *Reference) String() string {
+	b := r.Bounds
+	from := r.a1Part(b.FromCol, b.FromRow, r.AbsFromCol, r.AbsFromRow)
+	to := r.a1Part(b.ToCol, b.ToRow, r.AbsToCol, r.AbsToRow)
+	if from == to && !r.Cols && !r.Rows {
+		return r.prefix() + from
+	}
+
+	return r.prefix() + from + ":" + to
+}
+
+//r1c1Index returns part of R1C1 reference for 0-based index, e.g.: 5 or [-1]
+func r1c1Index(index, base int, absolute bool) string {
+	if absolute {
+		return strconv.Itoa(index + 1)
+	}
+
+	if index == base {
+		return ""
+	}
+
+	return "[" + strconv.Itoa(index-base) + "]"
+}
+
+//r1c1Part returns R1C1 style part of reference for 0-based indexes that is relative to cell with 0-based indexes baseCol and baseRow
+func (r *Reference) r1c1Part(col, row int, absCol, absRow bool, baseCol, baseRow int) string {
+	var part string
+	if !r.Cols {
+		part += "R" + r1c1Index(row, baseRow, absRow)
+	}
+
+	if !r.Rows {
+		part += "C" + r1c1Index(col, baseCol, absCol)
+	}
+
+	return part
+}
+
+//R1C1 returns R1C1 style reference that is relative to cell with 0-based indexes col and row, e.g.: A1 for cell B2 => R[-1]C[-1], $A$1 => R1C1
+func (r *Reference) R1C1(col, row int) string {
+	b := r.Bounds
+	from := r.r1c1Part(b.FromCol, b.FromRow, r.AbsFromCol, r.AbsFromRow, col, row)
+	to := r.r1c1Part(b.ToCol, b.ToRow, r.AbsToCol, r.AbsToRow, col, row)
+	if from == to {
+		return r.prefix() + from
+	}
+
+	return r.prefix() + from + ":" + to
+}
+
+//SetAbsolute sets anchoring of columns and rows for both cells of reference, e.g.: SetAbsolute(true, false) for A1:B2 => $A1:$B2
+func (r *Reference) SetAbsolute(cols, rows bool) *Reference {
+	r.AbsFromCol, r.AbsToCol = cols, cols
+	r.AbsFromRow, r.AbsToRow = rows, rows
+	return r
+}
+
+//ToR1C1 converts A1 style reference to R1C1 style reference that is relative to cell with cellRef, e.g.: ToR1C1("A1", "B2") => R[-1]C[-1]
+func ToR1C1(ref string, cellRef CellRef) (string, error) {
+	r, err := ParseReference(ref)
+	if err != nil {
+		return "", err
+	}
+
+	col, row := cellRef.ToIndexes()
+	return r.R1C1(col, row), nil
+}
+
+//FromR1C1 converts R1C1 style reference that is relative to cell with cellRef to A1 style reference, e.g.: FromR1C1("R[-1]C[-1]", "B2") => A1
+func FromR1C1(ref string, cellRef CellRef) (string, error) {
+	col, row := cellRef.ToIndexes()
+	r, err := ParseR1C1(ref, col, row)
+	if err != nil {
+		return "", err
+	}
+
+	return r.String(), nil
+}
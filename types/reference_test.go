@@ -0,0 +1,133 @@
+package types
+
+import (
+	"github.com/plandem/xlsx/internal"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSheetPrefix(t *testing.T) {
+	require.Equal(t, "Sheet1!", SheetPrefix("Sheet1"))
+	require.Equal(t, "'My Sheet'!", SheetPrefix("My Sheet"))
+	require.Equal(t, "'John''s'!", SheetPrefix("John's"))
+	require.Equal(t, "'A1'!", SheetPrefix("A1"))
+	require.Equal(t, "'R1C1'!", SheetPrefix("R1C1"))
+	require.Equal(t, "'2019'!", SheetPrefix("2019"))
+}
+
+func TestSheetRangePrefix(t *testing.T) {
+	require.Equal(t, "Sheet1!", SheetRangePrefix("Sheet1", ""))
+	require.Equal(t, "Sheet1:Sheet3!", SheetRangePrefix("Sheet1", "Sheet3"))
+	require.Equal(t, "'Sheet1:My Sheet'!", SheetRangePrefix("Sheet1", "My Sheet"))
+	require.Equal(t, "'John''s:Sheet3'!", SheetRangePrefix("John's", "Sheet3"))
+}
+
+func TestParseReference(t *testing.T) {
+	//cell
+	r, err := ParseReference("B2")
+	require.Nil(t, err)
+	require.Equal(t, BoundsFromIndexes(1, 1, 1, 1), r.Bounds)
+	require.Equal(t, "B2", r.String())
+
+	//range with anchoring
+	r, err = ParseReference("$A$1:B$2")
+	require.Nil(t, err)
+	require.Equal(t, BoundsFromIndexes(0, 0, 1, 1), r.Bounds)
+	require.Equal(t, []bool{true, true, false, true}, []bool{r.AbsFromCol, r.AbsFromRow, r.AbsToCol, r.AbsToRow})
+	require.Equal(t, "$A$1:B$2", r.String())
+
+	//whole columns and rows
+	r, err = ParseReference("$A:C")
+	require.Nil(t, err)
+	require.Equal(t, true, r.Cols)
+	require.Equal(t, BoundsFromIndexes(0, 0, 2, internal.ExcelRowLimit-1), r.Bounds)
+	require.Equal(t, "$A:C", r.String())
+
+	r, err = ParseReference("2:$3")
+	require.Nil(t, err)
+	require.Equal(t, true, r.Rows)
+	require.Equal(t, BoundsFromIndexes(0, 1, internal.ExcelColumnLimit-1, 2), r.Bounds)
+	require.Equal(t, "2:$3", r.String())
+
+	//sheets
+	r, err = ParseReference("'John''s Sheet'!A1")
+	require.Nil(t, err)
+	require.Equal(t, "John's Sheet", r.Sheet)
+	require.Equal(t, "'John''s Sheet'!A1", r.String())
+
+	r, err = ParseReference("Sheet1:Sheet3!A1:B2")
+	require.Nil(t, err)
+	require.Equal(t, "Sheet1", r.Sheet)
+	require.Equal(t, "Sheet3", r.ToSheet)
+	require.Equal(t, "Sheet1:Sheet3!A1:B2", r.String())
+
+	r, err = ParseReference("'Sheet 1:Sheet 3'!A1")
+	require.Nil(t, err)
+	require.Equal(t, "Sheet 1", r.Sheet)
+	require.Equal(t, "Sheet 3", r.ToSheet)
+	require.Equal(t, "'Sheet 1:Sheet 3'!A1", r.String())
+
+	//invalid
+	for _, ref := range []string{"", "A", "1", "A1:B", "A1:B2:C3", "A0", "XFE1", "A1048577", "A$:B", "'Sheet1!A1", "!A1"} {
+		_, err = ParseReference(ref)
+		require.NotNil(t, err, ref)
+	}
+}
+
+func TestParseR1C1(t *testing.T) {
+	//relative to B2
+	r, err := ParseR1C1("R[-1]C[1]", 1, 1)
+	require.Nil(t, err)
+	require.Equal(t, BoundsFromIndexes(2, 0, 2, 0), r.Bounds)
+	require.Equal(t, "C1", r.String())
+
+	r, err = ParseR1C1("R1C1:RC", 1, 1)
+	require.Nil(t, err)
+	require.Equal(t, "$A$1:B2", r.String())
+
+	r, err = ParseR1C1("R1", 1, 1)
+	require.Nil(t, err)
+	require.Equal(t, true, r.Rows)
+	require.Equal(t, "$1:$1", r.String())
+
+	r, err = ParseR1C1("C[-1]:C", 1, 1)
+	require.Nil(t, err)
+	require.Equal(t, true, r.Cols)
+	require.Equal(t, "A:B", r.String())
+
+	r, err = ParseR1C1("Sheet1:Sheet3!R2C2", 0, 0)
+	require.Nil(t, err)
+	require.Equal(t, "Sheet1:Sheet3!$B$2", r.String())
+
+	//invalid
+	for _, ref := range []string{"", "R0C1", "R[-1]C", "R1:C1", "X1", "R1C1:R2C2:R3C3"} {
+		_, err = ParseR1C1(ref, 0, 0)
+		require.NotNil(t, err, ref)
+	}
+}
+
+func TestReference_R1C1(t *testing.T) {
+	for a1, r1c1 := range map[string]string{
+		"A1":            "R[-1]C[-1]",
+		"$A$1":          "R1C1",
+		"B2":            "RC",
+		"A$1:$C3":       "R1C[-1]:R[1]C3",
+		"A:B":           "C[-1]:C",
+		"$2:$2":         "R2",
+		"'My Sheet'!B2": "'My Sheet'!RC",
+	} {
+		converted, err := ToR1C1(a1, "B2")
+		require.Nil(t, err)
+		require.Equal(t, r1c1, converted, a1)
+
+		converted, err = FromR1C1(r1c1, "B2")
+		require.Nil(t, err)
+		require.Equal(t, a1, converted, r1c1)
+	}
+
+	r, err := ParseReference("A1:B2")
+	require.Nil(t, err)
+	require.Equal(t, "$A1:$B2", r.SetAbsolute(true, false).String())
+	require.Equal(t, "$A$1:$B$2", r.SetAbsolute(true, true).String())
+	require.Equal(t, "A1:B2", r.SetAbsolute(false, false).String())
+}
@@ -0,0 +1,291 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+//List of all possible special items of structured reference
+const (
+	StructuredItemAll     = "#All"
+	StructuredItemData    = "#Data"
+	StructuredItemHeaders = "#Headers"
+	StructuredItemTotals  = "#Totals"
+	StructuredItemThisRow = "#This Row"
+)
+
+var regExpStructuredColumn = regexp.MustCompile(`^[A-Za-z0-9_.\\]+$`)
+
+//StructuredRef is a parsed structured reference to a table, e.g.: Sales[Amount], Sales[[#Headers],[Price]:[Tax]] or [@Amount]
+type StructuredRef struct {
+	Table      string   //name of table, empty for references inside of table, e.g.: [@Amount]
+	Items      []string //special items, e.g.: #All, #Data, #Headers, #Totals or #This Row. No items means data of table
+	FromColumn string   //name of the first column, empty for all columns
+	ToColumn   string   //name of the last column, same as FromColumn for a single column
+}
+
+//unescapeStructured returns name of column without escaping, e.g.: Price'[USD'] => Price[USD]
+func unescapeStructured(s string) string {
+	var result strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\'' && i+1 < len(s) {
+			i++
+		}
+
+		result.WriteByte(s[i])
+	}
+
+	return result.String()
+}
+
+//escapeStructured returns name of column with escaped special characters, e.g.: Price[USD] => Price'[USD']
+func escapeStructured(s string) string {
+	var result strings.Builder
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte("[]#'", s[i]) >= 0 {
+			result.WriteByte('\'')
+		}
+
+		result.WriteByte(s[i])
+	}
+
+	return result.String()
+}
+
+//splitStructured splits content of brackets into bracketed items and separators, e.g.: [#Headers],[A]:[B] => #Headers , A : B
+func splitStructured(s string) ([]string, error) {
+	var tokens []string
+	for pos := 0; pos < len(s); {
+		switch c := s[pos]; {
+		case c == ' ':
+			pos++
+		case c == ',' || c == ':':
+			tokens = append(tokens, string(c))
+			pos++
+		case c == '[':
+			end := pos + 1
+			for ; end < len(s) && s[end] != ']'; end++ {
+				if s[end] == '\'' {
+					end++
+				}
+			}
+
+			if end >= len(s) {
+				return nil, errors.New(fmt.Sprintf("unbalanced brackets at structured reference %s", s))
+			}
+
+			tokens = append(tokens, s[pos+1:end])
+			pos = end + 1
+		default:
+			return nil, errors.New(fmt.Sprintf("invalid structured reference %s", s))
+		}
+	}
+
+	return tokens, nil
+}
+
+//specialItem returns special item with canonical case or empty string if s is not a special item
+func specialItem(s string) string {
+	for _, item := range []string{StructuredItemAll, StructuredItemData, StructuredItemHeaders, StructuredItemTotals, StructuredItemThisRow} {
+		if strings.EqualFold(s, item) {
+			return item
+		}
+	}
+
+	return ""
+}
+
+//ParseStructuredRef parses structured reference to a table, e.g.: Sales[Amount], Sales[#All], Sales[[#Headers],[Price]:[Tax]], Sales[@Amount] or [@[Unit Price]]
+func ParseStructuredRef(ref string) (*StructuredRef, error) {
+	ref = strings.TrimSpace(ref)
+	start := strings.Index(ref, "[")
+	if start < 0 || !strings.HasSuffix(ref, "]") {
+		return nil, errors.New(fmt.Sprintf("invalid structured reference %s", ref))
+	}
+
+	r := &StructuredRef{Table: ref[:start]}
+	content := ref[start+1 : len(ref)-1]
+
+	//shorthand of this row, e.g.: [@Amount], [@[Unit Price]] or [@]
+	if strings.HasPrefix(content, "@") {
+		r.Items = []string{StructuredItemThisRow}
+		content = strings.TrimSpace(content[1:])
+		if !strings.HasPrefix(content, "[") {
+			r.FromColumn = unescapeStructured(content)
+			r.ToColumn = r.FromColumn
+			return r, nil
+		}
+	} else if !strings.HasPrefix(content, "[") {
+		//simple form, e.g.: Sales[Amount], Sales[#All] or Sales[]
+		if item := specialItem(content); len(item) > 0 {
+			r.Items = []string{item}
+		} else if len(content) > 0 {
+			r.FromColumn = unescapeStructured(content)
+			r.ToColumn = r.FromColumn
+		}
+
+		return r, nil
+	}
+
+	tokens, err := splitStructured(content)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if i%2 == 1 {
+			if token != "," {
+				return nil, errors.New(fmt.Sprintf("invalid structured reference %s", ref))
+			}
+
+			continue
+		}
+
+		if item := specialItem(token); len(item) > 0 {
+			if len(r.FromColumn) > 0 {
+				return nil, errors.New(fmt.Sprintf("special items must be before columns at structured reference %s", ref))
+			}
+
+			r.Items = append(r.Items, item)
+			continue
+		}
+
+		if len(r.FromColumn) > 0 || token == "," || token == ":" {
+			return nil, errors.New(fmt.Sprintf("invalid structured reference %s", ref))
+		}
+
+		r.FromColumn = unescapeStructured(token)
+		r.ToColumn = r.FromColumn
+
+		//range of columns, e.g.: [Price]:[Tax]
+		if i+2 < len(tokens) && tokens[i+1] == ":" {
+			r.ToColumn = unescapeStructured(tokens[i+2])
+			i += 2
+		}
+	}
+
+	return r, nil
+}
+
+//structuredColumn returns column of structured reference in simple or bracketed form
+func structuredColumn(column string) string {
+	if regExpStructuredColumn.MatchString(column) {
+		return column
+	}
+
+	return "[" + escapeStructured(column) + "]"
+}
+
+//String returns structured reference, e.g.: Sales[[#Headers],[Price]:[Tax]]
+func (r *StructuredRef) String() string {
+	single := r.FromColumn == r.ToColumn
+
+	switch {
+	case len(r.Items) == 0 && len(r.FromColumn) == 0:
+		return r.Table + "[]"
+	case len(r.Items) == 0 && single:
+		return r.Table + "[" + structuredColumn(r.FromColumn) + "]"
+	case len(r.Items) == 1 && len(r.FromColumn) == 0:
+		if r.Items[0] == StructuredItemThisRow {
+			return r.Table + "[@]"
+		}
+
+		return r.Table + "[" + r.Items[0] + "]"
+	case len(r.Items) == 1 && r.Items[0] == StructuredItemThisRow && single:
+		return r.Table + "[@" + structuredColumn(r.FromColumn) + "]"
+	}
+
+	parts := make([]string, 0, len(r.Items)+1)
+	for _, item := range r.Items {
+		parts = append(parts, "["+item+"]")
+	}
+
+	if len(r.FromColumn) > 0 {
+		columns := "[" + escapeStructured(r.FromColumn) + "]"
+		if !single {
+			columns += ":[" + escapeStructured(r.ToColumn) + "]"
+		}
+
+		parts = append(parts, columns)
+	}
+
+	return r.Table + "[" + strings.Join(parts, ",") + "]"
+}
+
+//ToBounds returns bounds of cells for structured reference to a table with bounds, names of columns and flags of header and totals rows. Row is a 0-based index of row for #This Row item
+func (r *StructuredRef) ToBounds(table Bounds, columns []string, headers bool, totals bool, row int) (Bounds, error) {
+	fromCol, toCol := table.FromCol, table.ToCol
+	if len(r.FromColumn) > 0 {
+		fromCol, toCol = -1, -1
+		for i, column := range columns {
+			if strings.EqualFold(column, r.FromColumn) {
+				fromCol = table.FromCol + i
+			}
+
+			if strings.EqualFold(column, r.ToColumn) {
+				toCol = table.FromCol + i
+			}
+		}
+
+		if fromCol < 0 || toCol < 0 {
+			return Bounds{}, errors.New(fmt.Sprintf("there is no column %s at table %s", r.FromColumn, r.Table))
+		}
+	}
+
+	dataFrom, dataTo := table.FromRow, table.ToRow
+	if headers {
+		dataFrom++
+	}
+
+	if totals {
+		dataTo--
+	}
+
+	items := r.Items
+	if len(items) == 0 {
+		items = []string{StructuredItemData}
+	}
+
+	fromRow, toRow := -1, -1
+	include := func(from, to int) {
+		if fromRow < 0 || from < fromRow {
+			fromRow = from
+		}
+
+		if to > toRow {
+			toRow = to
+		}
+	}
+
+	for _, item := range items {
+		switch item {
+		case StructuredItemAll:
+			include(table.FromRow, table.ToRow)
+		case StructuredItemData:
+			include(dataFrom, dataTo)
+		case StructuredItemHeaders:
+			if !headers {
+				return Bounds{}, errors.New(fmt.Sprintf("there is no header row at table %s", r.Table))
+			}
+
+			include(table.FromRow, table.FromRow)
+		case StructuredItemTotals:
+			if !totals {
+				return Bounds{}, errors.New(fmt.Sprintf("there is no totals row at table %s", r.Table))
+			}
+
+			include(table.ToRow, table.ToRow)
+		case StructuredItemThisRow:
+			if row < dataFrom || row > dataTo {
+				return Bounds{}, errors.New(fmt.Sprintf("row %d is out of data of table %s", row+1, r.Table))
+			}
+
+			include(row, row)
+		}
+	}
+
+	return BoundsFromIndexes(fromCol, fromRow, toCol, toRow), nil
+}
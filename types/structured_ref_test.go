@@ -0,0 +1,70 @@
+package types
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseStructuredRef(t *testing.T) {
+	for ref, parsed := range map[string]*StructuredRef{
+		"Sales[]":                           {Table: "Sales"},
+		"Sales[Amount]":                     {Table: "Sales", FromColumn: "Amount", ToColumn: "Amount"},
+		"Sales[#All]":                       {Table: "Sales", Items: []string{StructuredItemAll}},
+		"Sales[@]":                          {Table: "Sales", Items: []string{StructuredItemThisRow}},
+		"[@Amount]":                         {Items: []string{StructuredItemThisRow}, FromColumn: "Amount", ToColumn: "Amount"},
+		"[@[Unit Price]]":                   {Items: []string{StructuredItemThisRow}, FromColumn: "Unit Price", ToColumn: "Unit Price"},
+		"Sales[[Price]:[Tax]]":              {Table: "Sales", FromColumn: "Price", ToColumn: "Tax"},
+		"Sales[[#Headers],[Price]:[Tax]]":   {Table: "Sales", Items: []string{StructuredItemHeaders}, FromColumn: "Price", ToColumn: "Tax"},
+		"Sales[[#Data],[#Totals],[Amount]]": {Table: "Sales", Items: []string{StructuredItemData, StructuredItemTotals}, FromColumn: "Amount", ToColumn: "Amount"},
+		"Sales[[Price'[USD']]]":             {Table: "Sales", FromColumn: "Price[USD]", ToColumn: "Price[USD]"},
+	} {
+		r, err := ParseStructuredRef(ref)
+		require.Nil(t, err, ref)
+		require.Equal(t, parsed, r, ref)
+		require.Equal(t, ref, r.String(), ref)
+	}
+
+	//case of special items is normalized
+	r, err := ParseStructuredRef("Sales[[#this row],[Amount]]")
+	require.Nil(t, err)
+	require.Equal(t, "Sales[@Amount]", r.String())
+
+	//invalid
+	for _, ref := range []string{"Sales", "Sales[[Amount]", "Sales[[A],[B]]", "Sales[[A],[#All]]", "Sales[[A][B]]"} {
+		_, err = ParseStructuredRef(ref)
+		require.NotNil(t, err, ref)
+	}
+}
+
+func TestStructuredRef_ToBounds(t *testing.T) {
+	//table at B2:D10 with header row at 2 and totals row at 10
+	table := BoundsFromIndexes(1, 1, 3, 9)
+	columns := []string{"Name", "Price", "Tax"}
+
+	for ref, bounds := range map[string]string{
+		"Sales[]":                         "B3:D9",
+		"Sales[#All]":                     "B2:D10",
+		"Sales[Price]":                    "C3:C9",
+		"Sales[[#Headers],[Price]:[Tax]]": "C2:D2",
+		"Sales[[#Data],[#Totals],[Tax]]":  "D3:D10",
+		"[@Name]":                         "B5",
+	} {
+		r, err := ParseStructuredRef(ref)
+		require.Nil(t, err)
+		b, err := r.ToBounds(table, columns, true, true, 4)
+		require.Nil(t, err, ref)
+		require.Equal(t, bounds, b.String(), ref)
+	}
+
+	r, _ := ParseStructuredRef("Sales[Unknown]")
+	_, err := r.ToBounds(table, columns, true, true, 4)
+	require.NotNil(t, err)
+
+	r, _ = ParseStructuredRef("Sales[#Totals]")
+	_, err = r.ToBounds(table, columns, true, false, 4)
+	require.NotNil(t, err)
+
+	r, _ = ParseStructuredRef("[@Name]")
+	_, err = r.ToBounds(table, columns, true, true, 1)
+	require.NotNil(t, err)
+}
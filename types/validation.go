@@ -0,0 +1,297 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/plandem/xlsx/internal/number_format/convert"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//ValidationInfo is objects that holds information about data validation
+type ValidationInfo struct {
+	validation *ml.DataValidation
+	serials    [2]bool //flags of formulas that hold serial dates of 1900 date system
+}
+
+type validationOption func(o *ValidationInfo)
+
+//Validation is a 'namespace' for all possible settings for data validation
+var Validation validationOption
+
+//NewValidation creates and returns a new ValidationInfo object that holds settings for data validation
+func NewValidation(options ...validationOption) *ValidationInfo {
+	i := &ValidationInfo{
+		validation: &ml.DataValidation{},
+	}
+	i.Set(options...)
+	return i
+}
+
+//Set sets new options for data validation
+func (i *ValidationInfo) Set(options ...validationOption) {
+	for _, o := range options {
+		o(i)
+	}
+}
+
+//Validate validates data validation info and return error in case of invalid settings
+func (i *ValidationInfo) Validate() error {
+	v := i.validation
+
+	switch v.Type {
+	case 0:
+		return errors.New("unknown type of validation")
+	case ValidationTypeNone:
+	case ValidationTypeList:
+		if len(v.Formula1) == 0 {
+			return errors.New("no any values for list validation")
+		}
+
+		if v.Formula1[0] == '"' && len(v.Formula1)-2 > internal.ExcelValidationListLimit {
+			return errors.New(fmt.Sprintf("list of values exceeded maximum allowed length (%d chars)", internal.ExcelValidationListLimit))
+		}
+	case ValidationTypeCustom:
+		if len(v.Formula1) == 0 {
+			return errors.New("no formula for custom validation")
+		}
+	default:
+		if len(v.Formula1) == 0 {
+			return errors.New(fmt.Sprintf("no value for %s validation", v.Type))
+		}
+
+		if (v.Operator == 0 || v.Operator == ValidationOperatorBetween || v.Operator == ValidationOperatorNotBetween) && len(v.Formula2) == 0 {
+			return errors.New(fmt.Sprintf("no second value for %s validation with '%s' operator", v.Type, ValidationOperatorBetween))
+		}
+	}
+
+	if len(v.PromptTitle) > internal.ExcelValidationTitleLimit || len(v.ErrorTitle) > internal.ExcelValidationTitleLimit {
+		return errors.New(fmt.Sprintf("title exceeded maximum allowed length (%d chars)", internal.ExcelValidationTitleLimit))
+	}
+
+	if len(v.Prompt) > internal.ExcelValidationMessageLimit || len(v.Error) > internal.ExcelValidationMessageLimit {
+		return errors.New(fmt.Sprintf("message exceeded maximum allowed length (%d chars)", internal.ExcelValidationMessageLimit))
+	}
+
+	return nil
+}
+
+//Type returns type of data validation
+func (i *ValidationInfo) Type() ValidationType {
+	return i.validation.Type
+}
+
+//Operator returns operator of data validation
+func (i *ValidationInfo) Operator() ValidationOperatorType {
+	return i.validation.Operator
+}
+
+//Formulas returns formulas of data validation
+func (i *ValidationInfo) Formulas() (string, string) {
+	return string(i.validation.Formula1), string(i.validation.Formula2)
+}
+
+//List returns list of allowed values for list validation with values, or nil for any other type
+func (i *ValidationInfo) List() []string {
+	formula := string(i.validation.Formula1)
+	if i.validation.Type != ValidationTypeList || len(formula) < 2 || formula[0] != '"' {
+		return nil
+	}
+
+	formula = strings.Replace(formula[1:len(formula)-1], `""`, `"`, -1)
+	return strings.Split(formula, ",")
+}
+
+//Input returns title and message of input prompt
+func (i *ValidationInfo) Input() (string, string) {
+	return i.validation.PromptTitle, i.validation.Prompt
+}
+
+//Error returns style, title and message of error alert
+func (i *ValidationInfo) Error() (ValidationErrorStyle, string, string) {
+	return i.validation.ErrorStyle, i.validation.ErrorTitle, i.validation.Error
+}
+
+//List sets list of allowed values
+func (o *validationOption) List(values ...string) validationOption {
+	return func(i *ValidationInfo) {
+		escaped := make([]string, len(values))
+		for idx, value := range values {
+			escaped[idx] = strings.Replace(value, `"`, `""`, -1)
+		}
+
+		i.setRule(ValidationTypeList, 0, fmt.Sprintf(`"%s"`, strings.Join(escaped, ",")))
+	}
+}
+
+//ListRef sets ref of sheet with sheetName as source of allowed values. Omit sheetName to use ref of active sheet
+func (o *validationOption) ListRef(ref Ref, sheetName string) validationOption {
+	return func(i *ValidationInfo) {
+		source := ref.ToAbsolute()
+
+		if len(sheetName) > 0 {
+			source = fmt.Sprintf("'%s'!%s", strings.Replace(sheetName, `'`, `''`, -1), source)
+		}
+
+		i.setRule(ValidationTypeList, 0, source)
+	}
+}
+
+//Whole sets constraint for whole numbers
+func (o *validationOption) Whole(operator ValidationOperatorType, values ...interface{}) validationOption {
+	return func(i *ValidationInfo) {
+		i.setRule(ValidationTypeWhole, operator, toValidationFormulas(ValidationTypeWhole, values)...)
+	}
+}
+
+//Decimal sets constraint for decimal numbers
+func (o *validationOption) Decimal(operator ValidationOperatorType, values ...interface{}) validationOption {
+	return func(i *ValidationInfo) {
+		i.setRule(ValidationTypeDecimal, operator, toValidationFormulas(ValidationTypeDecimal, values)...)
+	}
+}
+
+//Date sets constraint for dates. Values can be time.Time or formula. Dates are converted into serial dates of date system of workbook when validation is added to the sheet
+func (o *validationOption) Date(operator ValidationOperatorType, values ...interface{}) validationOption {
+	return func(i *ValidationInfo) {
+		i.setRule(ValidationTypeDate, operator, toValidationFormulas(ValidationTypeDate, values)...)
+
+		for index, value := range values {
+			if _, ok := value.(time.Time); ok && index < len(i.serials) {
+				i.serials[index] = true
+			}
+		}
+	}
+}
+
+//Time sets constraint for time. Values can be time.Time or formula
+func (o *validationOption) Time(operator ValidationOperatorType, values ...interface{}) validationOption {
+	return func(i *ValidationInfo) {
+		i.setRule(ValidationTypeTime, operator, toValidationFormulas(ValidationTypeTime, values)...)
+	}
+}
+
+//TextLength sets constraint for length of text
+func (o *validationOption) TextLength(operator ValidationOperatorType, values ...interface{}) validationOption {
+	return func(i *ValidationInfo) {
+		i.setRule(ValidationTypeTextLength, operator, toValidationFormulas(ValidationTypeTextLength, values)...)
+	}
+}
+
+//Custom sets formula that should be evaluated to TRUE for valid values
+func (o *validationOption) Custom(formula string) validationOption {
+	return func(i *ValidationInfo) {
+		i.setRule(ValidationTypeCustom, 0, strings.TrimPrefix(formula, "="))
+	}
+}
+
+//AllowBlank allows blank values
+func (o *validationOption) AllowBlank(i *ValidationInfo) {
+	i.validation.AllowBlank = true
+}
+
+//HideDropDown hides dropdown with allowed values for list validation
+func (o *validationOption) HideDropDown(i *ValidationInfo) {
+	//N.B.: Excel uses inverted logic for 'showDropDown' flag
+	i.validation.ShowDropDown = true
+}
+
+//Input sets title and message of input prompt that will be shown when cell is selected
+func (o *validationOption) Input(title, message string) validationOption {
+	return func(i *ValidationInfo) {
+		i.validation.PromptTitle = title
+		i.validation.Prompt = message
+		i.validation.ShowInputMessage = len(title) > 0 || len(message) > 0
+	}
+}
+
+//Error sets style, title and message of error alert that will be shown when invalid data was entered
+func (o *validationOption) Error(style ValidationErrorStyle, title, message string) validationOption {
+	return func(i *ValidationInfo) {
+		i.validation.ErrorStyle = style
+		i.validation.ErrorTitle = title
+		i.validation.Error = message
+		i.validation.ShowErrorMessage = true
+	}
+}
+
+func (i *ValidationInfo) setRule(t ValidationType, operator ValidationOperatorType, formulas ...string) {
+	i.validation.Type = t
+	i.validation.Operator = operator
+	i.validation.Formula1 = ""
+	i.validation.Formula2 = ""
+	i.serials = [2]bool{}
+
+	if len(formulas) > 0 {
+		i.validation.Formula1 = primitives.Formula(formulas[0])
+	}
+
+	if len(formulas) > 1 {
+		i.validation.Formula2 = primitives.Formula(formulas[1])
+	}
+}
+
+//toValidationFormulas converts values into formulas that can be used by validation of type t
+func toValidationFormulas(t ValidationType, values []interface{}) []string {
+	formulas := make([]string, 0, len(values))
+
+	for _, value := range values {
+		var formula string
+
+		switch v := value.(type) {
+		case string:
+			formula = strings.TrimPrefix(v, "=")
+		case time.Time:
+			if t == ValidationTypeTime {
+				//time is a fraction of day
+				seconds := v.Hour()*3600 + v.Minute()*60 + v.Second()
+				formula = strconv.FormatFloat(float64(seconds)/86400, 'f', -1, 64)
+			} else {
+				//date is a serial number of days since 1900, wall clock of date is used
+				formula = strconv.FormatFloat(convert.ToSerial(v), 'f', -1, 64)
+			}
+		case float32:
+			formula = strconv.FormatFloat(float64(v), 'f', -1, 32)
+		case float64:
+			formula = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			formula = fmt.Sprintf("%v", v)
+		}
+
+		formulas = append(formulas, formula)
+	}
+
+	return formulas
+}
+
+//private method used by validations manager to unpack ValidationInfo for workbook with 1900 or 1904 date system
+func fromValidationInfo(info *ValidationInfo, date1904 bool) (*ml.DataValidation, error) {
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+
+	//copy info to prevent side effects of reusing ValidationInfo for different bounds
+	validation := *info.validation
+
+	if date1904 {
+		formulas := []*primitives.Formula{&validation.Formula1, &validation.Formula2}
+		for index, formula := range formulas {
+			if serial, err := strconv.ParseFloat(string(*formula), 64); err == nil && info.serials[index] {
+				*formula = primitives.Formula(strconv.FormatFloat(serial-convert.Date1904Offset, 'f', -1, 64))
+			}
+		}
+	}
+
+	return &validation, nil
+}
+
+//private method used by validations manager to pack ValidationInfo
+func toValidationInfo(validation *ml.DataValidation) *ValidationInfo {
+	info := *validation
+	info.Bounds = nil
+	return &ValidationInfo{validation: &info}
+}
@@ -0,0 +1,31 @@
+package types
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+//ValidationErrorStyle is alias of original primitives.DataValidationErrorStyle type to:
+// 1) make it public
+// 2) forbid usage of integers directly
+type ValidationErrorStyle = primitives.DataValidationErrorStyle
+
+//List of all possible values for ValidationErrorStyle
+const (
+	_ ValidationErrorStyle = iota
+	ValidationErrorStyleStop
+	ValidationErrorStyleWarning
+	ValidationErrorStyleInformation
+)
+
+func init() {
+	primitives.FromDataValidationErrorStyle = map[ValidationErrorStyle]string{
+		ValidationErrorStyleStop:        "stop",
+		ValidationErrorStyleWarning:     "warning",
+		ValidationErrorStyleInformation: "information",
+	}
+
+	primitives.ToDataValidationErrorStyle = make(map[string]ValidationErrorStyle, len(primitives.FromDataValidationErrorStyle))
+	for k, v := range primitives.FromDataValidationErrorStyle {
+		primitives.ToDataValidationErrorStyle[v] = k
+	}
+}
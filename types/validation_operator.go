@@ -0,0 +1,41 @@
+package types
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+//ValidationOperatorType is alias of original primitives.DataValidationOperatorType type to:
+// 1) make it public
+// 2) forbid usage of integers directly
+type ValidationOperatorType = primitives.DataValidationOperatorType
+
+//List of all possible values for ValidationOperatorType
+const (
+	_ ValidationOperatorType = iota
+	ValidationOperatorBetween
+	ValidationOperatorNotBetween
+	ValidationOperatorEqual
+	ValidationOperatorNotEqual
+	ValidationOperatorLessThan
+	ValidationOperatorLessThanOrEqual
+	ValidationOperatorGreaterThan
+	ValidationOperatorGreaterThanOrEqual
+)
+
+func init() {
+	primitives.FromDataValidationOperatorType = map[ValidationOperatorType]string{
+		ValidationOperatorBetween:            "between",
+		ValidationOperatorNotBetween:         "notBetween",
+		ValidationOperatorEqual:              "equal",
+		ValidationOperatorNotEqual:           "notEqual",
+		ValidationOperatorLessThan:           "lessThan",
+		ValidationOperatorLessThanOrEqual:    "lessThanOrEqual",
+		ValidationOperatorGreaterThan:        "greaterThan",
+		ValidationOperatorGreaterThanOrEqual: "greaterThanOrEqual",
+	}
+
+	primitives.ToDataValidationOperatorType = make(map[string]ValidationOperatorType, len(primitives.FromDataValidationOperatorType))
+	for k, v := range primitives.FromDataValidationOperatorType {
+		primitives.ToDataValidationOperatorType[v] = k
+	}
+}
@@ -0,0 +1,135 @@
+package types
+
+import (
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidationOption_List(t *testing.T) {
+	//unknown type
+	v := NewValidation()
+	require.NotNil(t, v.Validate())
+
+	v.Set(Validation.List("A", "B", `"C"`))
+	require.IsType(t, &ValidationInfo{}, v)
+	require.Equal(t, &ValidationInfo{
+		validation: &ml.DataValidation{
+			Type:     ValidationTypeList,
+			Formula1: `"A,B,""C"""`,
+		},
+	}, v)
+	require.Nil(t, v.Validate())
+	require.Equal(t, []string{"A", "B", `"C"`}, v.List())
+
+	v.Set(Validation.ListRef("A1:A10", "Sheet 1"))
+	require.Equal(t, &ValidationInfo{
+		validation: &ml.DataValidation{
+			Type:     ValidationTypeList,
+			Formula1: `'Sheet 1'!$A$1:$A$10`,
+		},
+	}, v)
+	require.Nil(t, v.Validate())
+	require.Nil(t, v.List())
+
+	v.Set(Validation.ListRef("B1", ""))
+	f1, _ := v.Formulas()
+	require.Equal(t, `$B$1`, f1)
+
+	//too large
+	v.Set(Validation.List(strings.Repeat("a", internal.ExcelValidationListLimit+1)))
+	require.NotNil(t, v.Validate())
+}
+
+func TestValidationOption_Constraints(t *testing.T) {
+	v := NewValidation(Validation.Whole(ValidationOperatorBetween, 1, 10))
+	require.Equal(t, &ValidationInfo{
+		validation: &ml.DataValidation{
+			Type:     ValidationTypeWhole,
+			Operator: ValidationOperatorBetween,
+			Formula1: "1",
+			Formula2: "10",
+		},
+	}, v)
+	require.Nil(t, v.Validate())
+
+	//between requires 2 values
+	v.Set(Validation.Decimal(ValidationOperatorNotBetween, 1.5))
+	require.NotNil(t, v.Validate())
+
+	v.Set(Validation.Decimal(ValidationOperatorLessThan, 1.5))
+	require.Nil(t, v.Validate())
+	f1, f2 := v.Formulas()
+	require.Equal(t, "1.5", f1)
+	require.Equal(t, "", f2)
+
+	v.Set(Validation.Date(ValidationOperatorGreaterThan, time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)))
+	f1, _ = v.Formulas()
+	require.Equal(t, ValidationTypeDate, v.Type())
+	require.Equal(t, "43101", f1)
+
+	//wall clock of date is used regardless of location
+	v.Set(Validation.Date(ValidationOperatorGreaterThan, time.Date(2018, 1, 1, 0, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60))))
+	f1, _ = v.Formulas()
+	require.Equal(t, "43101", f1)
+
+	v.Set(Validation.Time(ValidationOperatorLessThan, time.Date(0, 0, 0, 12, 0, 0, 0, time.UTC)))
+	f1, _ = v.Formulas()
+	require.Equal(t, ValidationTypeTime, v.Type())
+	require.Equal(t, "0.5", f1)
+
+	v.Set(Validation.TextLength(ValidationOperatorLessThanOrEqual, "=B1"))
+	f1, _ = v.Formulas()
+	require.Equal(t, ValidationTypeTextLength, v.Type())
+	require.Equal(t, "B1", f1)
+	require.Nil(t, v.Validate())
+
+	v.Set(Validation.Custom("=ISNUMBER(A1)"))
+	f1, _ = v.Formulas()
+	require.Equal(t, ValidationTypeCustom, v.Type())
+	require.Equal(t, "ISNUMBER(A1)", f1)
+	require.Nil(t, v.Validate())
+}
+
+func TestValidationOption_Messages(t *testing.T) {
+	v := NewValidation(
+		Validation.List("A"),
+		Validation.AllowBlank,
+		Validation.HideDropDown,
+		Validation.Input("Input title", "Input message"),
+		Validation.Error(ValidationErrorStyleWarning, "Error title", "Error message"),
+	)
+
+	require.Equal(t, &ValidationInfo{
+		validation: &ml.DataValidation{
+			Type:             ValidationTypeList,
+			Formula1:         `"A"`,
+			AllowBlank:       true,
+			ShowDropDown:     true,
+			ShowInputMessage: true,
+			ShowErrorMessage: true,
+			PromptTitle:      "Input title",
+			Prompt:           "Input message",
+			ErrorStyle:       ValidationErrorStyleWarning,
+			ErrorTitle:       "Error title",
+			Error:            "Error message",
+		},
+	}, v)
+	require.Nil(t, v.Validate())
+
+	title, message := v.Input()
+	require.Equal(t, "Input title", title)
+	require.Equal(t, "Input message", message)
+
+	style, title, message := v.Error()
+	require.Equal(t, ValidationErrorStyleWarning, style)
+	require.Equal(t, "Error title", title)
+	require.Equal(t, "Error message", message)
+
+	//too large
+	v.Set(Validation.Input(strings.Repeat("a", internal.ExcelValidationTitleLimit+1), ""))
+	require.NotNil(t, v.Validate())
+}
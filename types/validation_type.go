@@ -0,0 +1,41 @@
+package types
+
+import (
+	"github.com/plandem/xlsx/internal/ml/primitives"
+)
+
+//ValidationType is alias of original primitives.DataValidationType type to:
+// 1) make it public
+// 2) forbid usage of integers directly
+type ValidationType = primitives.DataValidationType
+
+//List of all possible values for ValidationType
+const (
+	_ ValidationType = iota
+	ValidationTypeNone
+	ValidationTypeWhole
+	ValidationTypeDecimal
+	ValidationTypeList
+	ValidationTypeDate
+	ValidationTypeTime
+	ValidationTypeTextLength
+	ValidationTypeCustom
+)
+
+func init() {
+	primitives.FromDataValidationType = map[ValidationType]string{
+		ValidationTypeNone:       "none",
+		ValidationTypeWhole:      "whole",
+		ValidationTypeDecimal:    "decimal",
+		ValidationTypeList:       "list",
+		ValidationTypeDate:       "date",
+		ValidationTypeTime:       "time",
+		ValidationTypeTextLength: "textLength",
+		ValidationTypeCustom:     "custom",
+	}
+
+	primitives.ToDataValidationType = make(map[string]ValidationType, len(primitives.FromDataValidationType))
+	for k, v := range primitives.FromDataValidationType {
+		primitives.ToDataValidationType[v] = k
+	}
+}
@@ -0,0 +1,261 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"github.com/plandem/ooxml"
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/types"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+//DiagnosticKind is a type of problem that was found during validation of workbook
+type DiagnosticKind byte
+
+//List of all possible values for DiagnosticKind
+const (
+	DiagnosticWorkbook   DiagnosticKind = iota //workbook can't be saved, e.g. there is no any sheet
+	DiagnosticSheetName                        //name of sheet is empty, too long, not unique or has invalid chars
+	DiagnosticReference                        //reference is out of limits of sheet
+	DiagnosticTextLength                       //text of cell exceeds allowed length
+	DiagnosticStyles                           //number of styles or number formats exceeds limits of Excel
+	DiagnosticWellFormed                       //marshalled part of package is not a well-formed XML
+	DiagnosticSchema                           //marshalled part of package does not conform to schema of ECMA-376
+)
+
+//Diagnostic is information about a single problem of workbook. Sheet, Ref and Part are empty if not applicable
+type Diagnostic struct {
+	Kind    DiagnosticKind
+	Sheet   string
+	Ref     types.Ref
+	Part    string
+	Message string
+}
+
+//String returns human readable representation of diagnostic, e.g.: Sheet1!A1: text of cell exceeds allowed length = 32767
+func (d Diagnostic) String() string {
+	switch {
+	case len(d.Part) > 0:
+		return fmt.Sprintf("%s: %s", d.Part, d.Message)
+	case len(d.Sheet) > 0 && len(d.Ref) > 0:
+		return fmt.Sprintf("%s!%s: %s", d.Sheet, d.Ref, d.Message)
+	case len(d.Sheet) > 0:
+		return fmt.Sprintf("%s: %s", d.Sheet, d.Message)
+	}
+
+	return d.Message
+}
+
+//validateSheetName returns a problem of sheet name or empty string if name is valid
+func validateSheetName(name string) string {
+	switch {
+	case len(name) == 0:
+		return "name of sheet is empty"
+	case utf8.RuneCountInString(name) > internal.ExcelSheetNameLimit:
+		return fmt.Sprintf("name of sheet exceeds allowed length = %d", internal.ExcelSheetNameLimit)
+	case strings.ContainsAny(name, `:\/?*[]`):
+		return "name of sheet contains invalid chars"
+	case name[0] == '\'' || name[len(name)-1] == '\'':
+		return "name of sheet begins or ends with apostrophe"
+	case strings.EqualFold(name, "History"):
+		return "name of sheet is reserved by Excel"
+	}
+
+	return ""
+}
+
+//validateBounds returns diagnostic if bounds are out of limits of sheet
+func validateBounds(sheet string, b types.Bounds, kind string) []Diagnostic {
+	if b.FromCol < 0 || b.FromRow < 0 || b.ToCol >= internal.ExcelColumnLimit || b.ToRow >= internal.ExcelRowLimit {
+		return []Diagnostic{{Kind: DiagnosticReference, Sheet: sheet, Ref: b.ToRef(), Message: fmt.Sprintf("%s is out of limits of sheet", kind)}}
+	}
+
+	return nil
+}
+
+//validateSheet returns problems of content of sheet
+func validateSheet(s *sheetInfo) []Diagnostic {
+	var list []Diagnostic
+	name := s.Name()
+
+	s.walkStoredCells(func(cIdx, rIdx int, data *ml.Cell) {
+		if cIdx >= internal.ExcelColumnLimit || rIdx >= internal.ExcelRowLimit {
+			list = append(list, Diagnostic{Kind: DiagnosticReference, Sheet: name, Ref: types.RefFromIndexes(cIdx, rIdx), Message: "cell is out of limits of sheet"})
+			return
+		}
+
+		if value := (&Cell{ml: data, sheet: s}).Value(); utf8.RuneCountInString(value) > internal.ExcelCellLimit {
+			list = append(list, Diagnostic{Kind: DiagnosticTextLength, Sheet: name, Ref: types.RefFromIndexes(cIdx, rIdx), Message: fmt.Sprintf("text of cell exceeds allowed length = %d", internal.ExcelCellLimit)})
+		}
+	})
+
+	for _, merged := range s.ml.MergeCells.Items {
+		list = append(list, validateBounds(name, merged.Bounds, "merged cell")...)
+	}
+
+	for _, link := range s.ml.Hyperlinks.Items {
+		list = append(list, validateBounds(name, link.Bounds, "hyperlink")...)
+	}
+
+	for _, validation := range s.ml.DataValidations.Items {
+		for _, b := range validation.Bounds {
+			list = append(list, validateBounds(name, b, "data validation")...)
+		}
+	}
+
+	if s.ml.ConditionalFormatting != nil {
+		for _, conditional := range *s.ml.ConditionalFormatting {
+			for _, b := range conditional.Bounds {
+				list = append(list, validateBounds(name, b, "conditional formatting")...)
+			}
+		}
+	}
+
+	if s.ml.AutoFilter != nil {
+		list = append(list, validateBounds(name, s.ml.AutoFilter.Bounds, "auto filter")...)
+	}
+
+	return list
+}
+
+//markupOfPart returns marshalled content of part without saving of document, so there are no side effects for document. Parts that can't be marshalled that way, e.g. sheets in disk cache mode, are skipped
+func markupOfPart(content interface{}) ([]byte, bool, error) {
+	switch c := content.(type) {
+	case *zip.File:
+		reader, err := c.Open()
+		if err != nil {
+			return nil, true, err
+		}
+
+		defer reader.Close()
+		data, err := ioutil.ReadAll(reader)
+		return data, true, err
+	case []byte:
+		return c, true, nil
+	case *sheetInfo:
+		if sheet, ok := c.sheet.(*sheetReadWrite); ok {
+			data, err := xml.Marshal(sheet.snapshot())
+			return data, true, err
+		}
+
+		return nil, false, nil
+	case ooxml.MarshalPreparation:
+		return nil, false, nil
+	}
+
+	data, err := xml.Marshal(content)
+	return data, true, err
+}
+
+//validateMarkup returns problems of marshalled parts of document that are not well-formed XML or do not conform to bundled schemas. Document is not saved for validation
+func validateMarkup(xl *Spreadsheet, o *options.ValidateOptions) []Diagnostic {
+	//legacy drawings and related lists of shapes are refreshed before saving, so do same for validation
+	for _, sheet := range xl.sheets {
+		if sheet != nil && sheet.sheet != nil && (sheet.sheetMode&SheetModeDiskCache) == 0 {
+			sheet.comments.updateIfRequired()
+		}
+	}
+
+	files := xl.pkg.Files()
+	fileNames := make([]string, 0, len(files))
+	for fileName, content := range files {
+		//N.B.: legacy drawings are not validated, because Excel uses HTML-like markup for it
+		if ext := filepath.Ext(fileName); content != nil && (ext == ".xml" || ext == ".rels") {
+			fileNames = append(fileNames, fileName)
+		}
+	}
+
+	sort.Strings(fileNames)
+
+	var list []Diagnostic
+	for _, fileName := range fileNames {
+		data, ok, err := markupOfPart(files[fileName])
+		if err != nil {
+			list = append(list, Diagnostic{Kind: DiagnosticWellFormed, Part: fileName, Message: err.Error()})
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
+		decoder := xml.NewDecoder(bytes.NewReader(data))
+		for {
+			if _, err = decoder.Token(); err != nil {
+				break
+			}
+		}
+
+		if err != io.EOF {
+			list = append(list, Diagnostic{Kind: DiagnosticWellFormed, Part: fileName, Message: err.Error()})
+			continue
+		}
+
+		if o.Schema {
+			list = append(list, validateSchema(fileName, bytes.NewReader(data))...)
+		}
+	}
+
+	return list
+}
+
+//Validate checks names of sheets, references of opened sheets, length of texts and number of styles against limits of Excel and returns found problems. Marshalled parts can be checked for well-formed XML and against bundled schemas of ECMA-376 too. If options is nil, then default options are used
+//N.B.: only opened sheets are validated for content, because other sheets are saved as is
+func (xl *Spreadsheet) Validate(o *options.ValidateOptions) []Diagnostic {
+	if o == nil {
+		o = options.NewValidateOptions()
+	}
+
+	var list []Diagnostic
+	if err := xl.IsValid(); err != nil {
+		list = append(list, Diagnostic{Kind: DiagnosticWorkbook, Message: err.Error()})
+	}
+
+	names := make(map[string]bool)
+	for _, name := range xl.GetSheetNames() {
+		if problem := validateSheetName(name); len(problem) > 0 {
+			list = append(list, Diagnostic{Kind: DiagnosticSheetName, Sheet: name, Message: problem})
+		}
+
+		if key := strings.ToLower(name); names[key] {
+			list = append(list, Diagnostic{Kind: DiagnosticSheetName, Sheet: name, Message: "name of sheet is not unique"})
+		} else {
+			names[key] = true
+		}
+	}
+
+	for _, sheet := range xl.sheets {
+		if sheet != nil && sheet.sheet != nil && (sheet.sheetMode&SheetModeDiskCache) == 0 {
+			list = append(list, validateSheet(sheet)...)
+		}
+	}
+
+	ss := xl.styleSheet
+	ss.doc.lock()
+	ss.file.LoadIfRequired(ss.buildIndexes)
+	styles, numbers := len(ss.ml.CellXfs.Items), len(ss.ml.NumberFormats.Items)
+	ss.doc.unlock()
+
+	if styles > internal.ExcelStyleLimit {
+		list = append(list, Diagnostic{Kind: DiagnosticStyles, Message: fmt.Sprintf("number of styles exceeds limit = %d", internal.ExcelStyleLimit)})
+	}
+
+	if numbers > internal.ExcelNumberFormatLimit {
+		list = append(list, Diagnostic{Kind: DiagnosticStyles, Message: fmt.Sprintf("number of custom number formats exceeds limit = %d", internal.ExcelNumberFormatLimit)})
+	}
+
+	if (o.WellFormed || o.Schema) && len(xl.sheets) > 0 {
+		list = append(list, validateMarkup(xl, o)...)
+	}
+
+	return list
+}
@@ -0,0 +1,208 @@
+package xlsx
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml"
+	"io"
+)
+
+const (
+	namespaceRelationships = "http://schemas.openxmlformats.org/package/2006/relationships"
+	namespaceContentTypes  = "http://schemas.openxmlformats.org/package/2006/content-types"
+)
+
+//schemaType is a compact form of complex type of ECMA-376 schema - allowed child elements in order of sequence, required child elements and required attributes
+type schemaType struct {
+	children  []string
+	required  []string
+	attrs     []string
+	unordered bool //child elements are choice, so order is not important
+}
+
+//schemaTypes is a bundled compact form of ECMA-376 schemas (Transitional) for main parts of SpreadsheetML package. Elements without type are not checked for content
+var schemaTypes = map[xml.Name]*schemaType{
+	//worksheet
+	{Space: ml.NamespaceMain, Local: "worksheet"}: {
+		children: []string{
+			"sheetPr", "dimension", "sheetViews", "sheetFormatPr", "cols", "sheetData", "sheetCalcPr", "sheetProtection", "protectedRanges",
+			"scenarios", "autoFilter", "sortState", "dataConsolidate", "customSheetViews", "mergeCells", "phoneticPr", "conditionalFormatting",
+			"dataValidations", "hyperlinks", "printOptions", "pageMargins", "pageSetup", "headerFooter", "rowBreaks", "colBreaks", "customProperties",
+			"cellWatches", "ignoredErrors", "smartTags", "drawing", "legacyDrawing", "legacyDrawingHF", "drawingHF", "picture", "oleObjects",
+			"controls", "webPublishItems", "tableParts", "extLst",
+		},
+		required: []string{"sheetData"},
+	},
+	{Space: ml.NamespaceMain, Local: "sheetViews"}:            {children: []string{"sheetView", "extLst"}, required: []string{"sheetView"}},
+	{Space: ml.NamespaceMain, Local: "sheetView"}:             {children: []string{"pane", "selection", "pivotSelection", "extLst"}, attrs: []string{"workbookViewId"}},
+	{Space: ml.NamespaceMain, Local: "cols"}:                  {children: []string{"col"}, required: []string{"col"}},
+	{Space: ml.NamespaceMain, Local: "col"}:                   {attrs: []string{"min", "max"}},
+	{Space: ml.NamespaceMain, Local: "sheetData"}:             {children: []string{"row"}},
+	{Space: ml.NamespaceMain, Local: "row"}:                   {children: []string{"c", "extLst"}},
+	{Space: ml.NamespaceMain, Local: "c"}:                     {children: []string{"f", "v", "is", "extLst"}},
+	{Space: ml.NamespaceMain, Local: "is"}:                    {children: []string{"t", "r", "rPh", "phoneticPr"}},
+	{Space: ml.NamespaceMain, Local: "mergeCells"}:            {children: []string{"mergeCell"}, required: []string{"mergeCell"}},
+	{Space: ml.NamespaceMain, Local: "mergeCell"}:             {attrs: []string{"ref"}},
+	{Space: ml.NamespaceMain, Local: "conditionalFormatting"}: {children: []string{"cfRule", "extLst"}, required: []string{"cfRule"}},
+	{Space: ml.NamespaceMain, Local: "cfRule"}:                {children: []string{"formula", "colorScale", "dataBar", "iconSet", "extLst"}, attrs: []string{"priority"}},
+	{Space: ml.NamespaceMain, Local: "dataValidations"}:       {children: []string{"dataValidation"}, required: []string{"dataValidation"}},
+	{Space: ml.NamespaceMain, Local: "dataValidation"}:        {children: []string{"formula1", "formula2"}, attrs: []string{"sqref"}},
+	{Space: ml.NamespaceMain, Local: "hyperlinks"}:            {children: []string{"hyperlink"}, required: []string{"hyperlink"}},
+	{Space: ml.NamespaceMain, Local: "hyperlink"}:             {attrs: []string{"ref"}},
+	{Space: ml.NamespaceMain, Local: "pageMargins"}:           {attrs: []string{"left", "right", "top", "bottom", "header", "footer"}},
+	{Space: ml.NamespaceMain, Local: "tableParts"}:            {children: []string{"tablePart"}},
+
+	//workbook
+	{Space: ml.NamespaceMain, Local: "workbook"}: {
+		children: []string{
+			"fileVersion", "fileSharing", "workbookPr", "workbookProtection", "bookViews", "sheets", "functionGroups", "externalReferences",
+			"definedNames", "calcPr", "oleSize", "customWorkbookViews", "pivotCaches", "smartTagPr", "smartTagTypes", "webPublishing",
+			"fileRecoveryPr", "webPublishObjects", "extLst",
+		},
+		required: []string{"sheets"},
+	},
+	{Space: ml.NamespaceMain, Local: "bookViews"}:    {children: []string{"workbookView"}, required: []string{"workbookView"}},
+	{Space: ml.NamespaceMain, Local: "sheets"}:       {children: []string{"sheet"}, required: []string{"sheet"}},
+	{Space: ml.NamespaceMain, Local: "sheet"}:        {attrs: []string{"name", "sheetId", "id"}},
+	{Space: ml.NamespaceMain, Local: "definedNames"}: {children: []string{"definedName"}},
+	{Space: ml.NamespaceMain, Local: "definedName"}:  {attrs: []string{"name"}},
+
+	//styles
+	{Space: ml.NamespaceMain, Local: "styleSheet"}: {
+		children: []string{"numFmts", "fonts", "fills", "borders", "cellStyleXfs", "cellXfs", "cellStyles", "dxfs", "tableStyles", "colors", "extLst"},
+	},
+	{Space: ml.NamespaceMain, Local: "numFmts"}:      {children: []string{"numFmt"}},
+	{Space: ml.NamespaceMain, Local: "numFmt"}:       {attrs: []string{"numFmtId", "formatCode"}},
+	{Space: ml.NamespaceMain, Local: "fonts"}:        {children: []string{"font"}},
+	{Space: ml.NamespaceMain, Local: "font"}:         {children: []string{"b", "i", "strike", "condense", "extend", "outline", "shadow", "u", "vertAlign", "sz", "color", "name", "family", "charset", "scheme"}, unordered: true},
+	{Space: ml.NamespaceMain, Local: "fills"}:        {children: []string{"fill"}},
+	{Space: ml.NamespaceMain, Local: "fill"}:         {children: []string{"patternFill", "gradientFill"}, unordered: true},
+	{Space: ml.NamespaceMain, Local: "patternFill"}:  {children: []string{"fgColor", "bgColor"}},
+	{Space: ml.NamespaceMain, Local: "borders"}:      {children: []string{"border"}},
+	{Space: ml.NamespaceMain, Local: "border"}:       {children: []string{"start", "end", "left", "right", "top", "bottom", "diagonal", "vertical", "horizontal"}},
+	{Space: ml.NamespaceMain, Local: "cellStyleXfs"}: {children: []string{"xf"}, required: []string{"xf"}},
+	{Space: ml.NamespaceMain, Local: "cellXfs"}:      {children: []string{"xf"}, required: []string{"xf"}},
+	{Space: ml.NamespaceMain, Local: "xf"}:           {children: []string{"alignment", "protection", "extLst"}},
+	{Space: ml.NamespaceMain, Local: "cellStyles"}:   {children: []string{"cellStyle"}, required: []string{"cellStyle"}},
+	{Space: ml.NamespaceMain, Local: "cellStyle"}:    {children: []string{"extLst"}, attrs: []string{"xfId"}},
+	{Space: ml.NamespaceMain, Local: "dxfs"}:         {children: []string{"dxf"}},
+	{Space: ml.NamespaceMain, Local: "dxf"}:          {children: []string{"font", "numFmt", "fill", "alignment", "border", "protection", "extLst"}},
+
+	//shared strings and comments
+	{Space: ml.NamespaceMain, Local: "sst"}:         {children: []string{"si", "extLst"}},
+	{Space: ml.NamespaceMain, Local: "si"}:          {children: []string{"t", "r", "rPh", "phoneticPr"}},
+	{Space: ml.NamespaceMain, Local: "r"}:           {children: []string{"rPr", "t"}, required: []string{"t"}},
+	{Space: ml.NamespaceMain, Local: "rPr"}:         {children: []string{"rFont", "charset", "family", "b", "i", "strike", "outline", "shadow", "condense", "extend", "color", "sz", "u", "vertAlign", "scheme"}, unordered: true},
+	{Space: ml.NamespaceMain, Local: "comments"}:    {children: []string{"authors", "commentList", "extLst"}, required: []string{"authors", "commentList"}},
+	{Space: ml.NamespaceMain, Local: "authors"}:     {children: []string{"author"}},
+	{Space: ml.NamespaceMain, Local: "commentList"}: {children: []string{"comment"}},
+	{Space: ml.NamespaceMain, Local: "comment"}:     {children: []string{"text", "commentPr"}, required: []string{"text"}, attrs: []string{"ref", "authorId"}},
+	{Space: ml.NamespaceMain, Local: "text"}:        {children: []string{"t", "r", "rPh", "phoneticPr"}},
+
+	//package
+	{Space: namespaceRelationships, Local: "Relationships"}: {children: []string{"Relationship"}},
+	{Space: namespaceRelationships, Local: "Relationship"}:  {attrs: []string{"Id", "Type", "Target"}},
+	{Space: namespaceContentTypes, Local: "Types"}:          {children: []string{"Default", "Override"}, unordered: true},
+	{Space: namespaceContentTypes, Local: "Default"}:        {attrs: []string{"Extension", "ContentType"}},
+	{Space: namespaceContentTypes, Local: "Override"}:       {attrs: []string{"PartName", "ContentType"}},
+}
+
+//schemaNamespaces is a list of namespaces with bundled schemas. Elements of other namespaces, e.g. extensions and markup compatibility, are ignorable and not checked
+var schemaNamespaces = map[string]bool{
+	ml.NamespaceMain:       true,
+	namespaceRelationships: true,
+	namespaceContentTypes:  true,
+}
+
+//schemaFrame is a state of checking of element
+type schemaFrame struct {
+	name   xml.Name
+	t      *schemaType
+	last   int
+	seen   map[string]bool
+	ignore bool
+}
+
+//validateSchema returns problems of markup of part against bundled schemas. Parts with root element without bundled schema are not checked. Reader must hold well-formed XML
+func validateSchema(part string, reader io.Reader) []Diagnostic {
+	var list []Diagnostic
+	var stack []*schemaFrame
+
+	decoder := xml.NewDecoder(reader)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			frame := &schemaFrame{name: t.Name, seen: make(map[string]bool)}
+
+			switch {
+			case len(stack) == 0:
+				if frame.t = schemaTypes[t.Name]; frame.t == nil {
+					//unknown type of part
+					return nil
+				}
+			case stack[len(stack)-1].ignore || !schemaNamespaces[t.Name.Space]:
+				frame.ignore = true
+			default:
+				parent := stack[len(stack)-1]
+				frame.t = schemaTypes[t.Name]
+
+				if parent.t != nil {
+					index := -1
+					for i, child := range parent.t.children {
+						if child == t.Name.Local {
+							index = i
+							break
+						}
+					}
+
+					switch {
+					case index == -1:
+						list = append(list, Diagnostic{Kind: DiagnosticSchema, Part: part, Message: fmt.Sprintf("element <%s> is not allowed in <%s>", t.Name.Local, parent.name.Local)})
+					case index < parent.last && !parent.t.unordered:
+						list = append(list, Diagnostic{Kind: DiagnosticSchema, Part: part, Message: fmt.Sprintf("element <%s> is out of order in <%s>", t.Name.Local, parent.name.Local)})
+					default:
+						parent.last = index
+					}
+
+					parent.seen[t.Name.Local] = true
+				}
+			}
+
+			if frame.t != nil {
+				for _, required := range frame.t.attrs {
+					found := false
+					for _, attr := range t.Attr {
+						if attr.Name.Local == required {
+							found = true
+							break
+						}
+					}
+
+					if !found {
+						list = append(list, Diagnostic{Kind: DiagnosticSchema, Part: part, Message: fmt.Sprintf("element <%s> requires attribute '%s'", t.Name.Local, required)})
+					}
+				}
+			}
+
+			stack = append(stack, frame)
+		case xml.EndElement:
+			frame := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			if frame.t != nil {
+				for _, required := range frame.t.required {
+					if !frame.seen[required] {
+						list = append(list, Diagnostic{Kind: DiagnosticSchema, Part: part, Message: fmt.Sprintf("element <%s> requires element <%s>", frame.name.Local, required)})
+					}
+				}
+			}
+		}
+	}
+
+	return list
+}
@@ -0,0 +1,120 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/internal"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	//no sheets
+	list := xl.Validate(nil)
+	require.Equal(t, 1, len(list))
+	require.Equal(t, DiagnosticWorkbook, list[0].Kind)
+
+	//valid workbook
+	sheet := xl.AddSheet("Sheet1")
+	sheet.CellByRef("A1").SetValue("valid")
+	require.Equal(t, 0, len(xl.Validate(nil)))
+
+	//invalid names of sheets
+	xl.AddSheet("Sheet2")
+	xl.AddSheet("Sheet3")
+	xl.workbook.ml.Sheets[1].Name = "Q1/Q2"
+	xl.workbook.ml.Sheets[2].Name = "SHEET1"
+
+	list = xl.Validate(nil)
+	require.Equal(t, []Diagnostic{
+		{Kind: DiagnosticSheetName, Sheet: "Q1/Q2", Message: "name of sheet contains invalid chars"},
+		{Kind: DiagnosticSheetName, Sheet: "SHEET1", Message: "name of sheet is not unique"},
+	}, list)
+
+	require.Equal(t, "", validateSheetName("Report"))
+	require.NotEqual(t, "", validateSheetName(""))
+	require.NotEqual(t, "", validateSheetName(strings.Repeat("a", 32)))
+	require.NotEqual(t, "", validateSheetName("'Report"))
+	require.NotEqual(t, "", validateSheetName("history"))
+
+	xl.workbook.ml.Sheets[1].Name = "Sheet2"
+	xl.workbook.ml.Sheets[2].Name = "Sheet3"
+
+	//texts and references out of limits
+	c := sheet.CellByRef("B2")
+	c.ml.Type = types.CellTypeInlineString
+	c.ml.InlineStr = &ml.StringItem{Text: types.Text(strings.Repeat("a", internal.ExcelCellLimit+1))}
+	sheet.info().ml.MergeCells.Items = append(sheet.info().ml.MergeCells.Items, &ml.MergeCell{Bounds: types.BoundsFromIndexes(0, 0, internal.ExcelColumnLimit, 1)})
+
+	list = xl.Validate(nil)
+	require.Equal(t, 2, len(list))
+	require.Equal(t, DiagnosticTextLength, list[0].Kind)
+	require.Equal(t, "Sheet1!B2: text of cell exceeds allowed length = 32767", list[0].String())
+	require.Equal(t, DiagnosticReference, list[1].Kind)
+	require.Equal(t, "merged cell is out of limits of sheet", list[1].Message)
+
+	//cells are checked by references after saving too
+	_, err := xl.Bytes()
+	require.Nil(t, err)
+	require.False(t, sheet.info().isInitialized)
+
+	list = xl.Validate(nil)
+	require.Equal(t, 2, len(list))
+	require.Equal(t, "Sheet1!B2: text of cell exceeds allowed length = 32767", list[0].String())
+
+	//markup of parts
+	xl2 := New()
+	defer xl2.Close()
+
+	xl2.AddSheet("Sheet1").CellByRef("A1").SetValue("valid")
+	require.Nil(t, xl2.Sheet(0).CellByRef("A2").SetComment("comment"))
+	require.Equal(t, 0, len(xl2.Validate(options.NewValidateOptions(options.Validate.WellFormed(true)))))
+
+	//document is not saved for validation of markup
+	sheet2 := xl2.Sheet(0)
+	sheet2.CellByRef("C3").SetValue("valid")
+	require.Nil(t, sheet2.Range("D4:E5").Merge())
+	require.Nil(t, sheet2.AddValidation(types.BoundsFromIndexes(0, 5, 0, 5), types.NewValidation(types.Validation.List("A", "B"))))
+	require.True(t, sheet2.info().isInitialized)
+	require.Equal(t, 0, len(xl2.Validate(options.NewValidateOptions(options.Validate.WellFormed(true), options.Validate.Schema(true)))))
+	require.True(t, sheet2.info().isInitialized)
+	require.Equal(t, 5, len(sheet2.info().ml.SheetData))
+}
+
+func TestValidateSchema(t *testing.T) {
+	markup := `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:x14ac="http://schemas.microsoft.com/office/spreadsheetml/2009/9/ac">` +
+		`<sheetData><row r="1" x14ac:dyDescent="0.25"><c r="A1"><v>1</v><f>A2</f></c></row></sheetData>` +
+		`<dimension ref="A1"/><mergeCells><mergeCell/></mergeCells><sheetFormat/>` +
+		`<extLst><ext uri="{x}"><x14ac:any/></ext></extLst>` +
+		`</worksheet>`
+
+	require.Equal(t, []string{
+		"sheet1.xml: element <f> is out of order in <c>",
+		"sheet1.xml: element <dimension> is out of order in <worksheet>",
+		"sheet1.xml: element <mergeCell> requires attribute 'ref'",
+		"sheet1.xml: element <sheetFormat> is not allowed in <worksheet>",
+	}, diagnosticStrings(validateSchema("sheet1.xml", strings.NewReader(markup))))
+
+	markup = `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><bookViews><workbookView/></bookViews></workbook>`
+	require.Equal(t, []string{
+		"workbook.xml: element <workbook> requires element <sheets>",
+	}, diagnosticStrings(validateSchema("workbook.xml", strings.NewReader(markup))))
+
+	//parts without bundled schema are not checked
+	require.Nil(t, validateSchema("theme1.xml", strings.NewReader(`<a:theme xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"><unknown/></a:theme>`)))
+}
+
+//diagnosticStrings returns human readable representation of diagnostics
+func diagnosticStrings(list []Diagnostic) []string {
+	result := make([]string, 0, len(list))
+	for _, d := range list {
+		result = append(result, d.String())
+	}
+
+	return result
+}
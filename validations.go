@@ -0,0 +1,98 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/internal/ml/primitives"
+	"github.com/plandem/xlsx/types"
+	_ "unsafe"
+)
+
+//go:linkname fromValidationInfo github.com/plandem/xlsx/types.fromValidationInfo
+func fromValidationInfo(info *types.ValidationInfo, date1904 bool) (*ml.DataValidation, error)
+
+//go:linkname toValidationInfo github.com/plandem/xlsx/types.toValidationInfo
+func toValidationInfo(validation *ml.DataValidation) *types.ValidationInfo
+
+type validations struct {
+	sheet *sheetInfo
+}
+
+//newValidations creates an object that implements data validations functionality
+func newValidations(sheet *sheetInfo) *validations {
+	return &validations{sheet: sheet}
+}
+
+//Add adds a new data validation for provided bounds
+func (v *validations) Add(bounds types.Bounds, info *types.ValidationInfo) error {
+	if info == nil {
+		return errors.New("no validation info")
+	}
+
+	validation, err := fromValidationInfo(info, v.sheet.workbook.isDate1904())
+	if err != nil {
+		return err
+	}
+
+	//let's check existing validations for overlapping bounds
+	validationIndex := -1
+	for index, existing := range v.sheet.ml.DataValidations.Items {
+		for _, b := range existing.Bounds {
+			if len(existing.Bounds) == 1 && b.Equals(bounds) {
+				validationIndex = index
+			} else if b.Overlaps(bounds) {
+				return errors.New(fmt.Sprintf("intersection of different validations is not allowed, %s intersects with %s", b, bounds))
+			}
+		}
+	}
+
+	validation.Bounds = primitives.BoundsList{bounds}
+	if validationIndex == -1 {
+		//add a new validation
+		v.sheet.ml.DataValidations.Items = append(v.sheet.ml.DataValidations.Items, validation)
+	} else {
+		//update existing validation
+		v.sheet.ml.DataValidations.Items[validationIndex] = validation
+	}
+
+	return nil
+}
+
+//Get returns a resolved data validation info for provided ref or nil if there is no any validation
+func (v *validations) Get(ref types.CellRef) *types.ValidationInfo {
+	cIdx, rIdx := ref.ToIndexes()
+	for _, validation := range v.sheet.ml.DataValidations.Items {
+		for _, b := range validation.Bounds {
+			if b.Contains(cIdx, rIdx) {
+				return toValidationInfo(validation)
+			}
+		}
+	}
+
+	return nil
+}
+
+//Remove removes data validation info for bounds
+func (v *validations) Remove(bounds types.Bounds) {
+	if len(v.sheet.ml.DataValidations.Items) > 0 {
+		newValidations := make([]*ml.DataValidation, 0, len(v.sheet.ml.DataValidations.Items))
+
+		for _, validation := range v.sheet.ml.DataValidations.Items {
+			//copy only non overlapping bounds
+			newBounds := make(primitives.BoundsList, 0, len(validation.Bounds))
+			for _, b := range validation.Bounds {
+				if !b.Overlaps(bounds) {
+					newBounds = append(newBounds, b)
+				}
+			}
+
+			if len(newBounds) > 0 {
+				validation.Bounds = newBounds
+				newValidations = append(newValidations, validation)
+			}
+		}
+
+		v.sheet.ml.DataValidations.Items = newValidations
+	}
+}
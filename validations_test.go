@@ -0,0 +1,82 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestValidations(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	sheet := xl.AddSheet("Sheet1")
+	validations := sheet.info().validations
+
+	//add a new validation
+	err := sheet.AddValidation(types.BoundsFromIndexes(0, 0, 0, 9), types.NewValidation(
+		types.Validation.List("A", "B", "C"),
+		types.Validation.Input("Title", "Pick a value"),
+	))
+	require.Nil(t, err)
+	require.Equal(t, 1, len(validations.sheet.ml.DataValidations.Items))
+	require.Equal(t, &ml.DataValidation{
+		Type:             types.ValidationTypeList,
+		Formula1:         `"A,B,C"`,
+		PromptTitle:      "Title",
+		Prompt:           "Pick a value",
+		ShowInputMessage: true,
+		Bounds:           types.BoundsList{types.BoundsFromIndexes(0, 0, 0, 9)},
+	}, validations.sheet.ml.DataValidations.Items[0])
+
+	//update validation with same bounds
+	err = sheet.AddValidation(types.BoundsFromIndexes(0, 0, 0, 9), types.NewValidation(
+		types.Validation.Whole(types.ValidationOperatorGreaterThan, 10),
+	))
+	require.Nil(t, err)
+	require.Equal(t, 1, len(validations.sheet.ml.DataValidations.Items))
+	require.Equal(t, types.ValidationTypeWhole, validations.sheet.ml.DataValidations.Items[0].Type)
+
+	//overlapping bounds are not allowed
+	err = sheet.AddValidation(types.BoundsFromIndexes(0, 5, 1, 5), types.NewValidation(
+		types.Validation.Custom("=A1>0"),
+	))
+	require.NotNil(t, err)
+
+	//invalid validation
+	err = sheet.AddValidation(types.BoundsFromIndexes(5, 5, 5, 5), types.NewValidation())
+	require.NotNil(t, err)
+
+	//get validation
+	info := sheet.Validation("A5")
+	require.NotNil(t, info)
+	require.Equal(t, types.ValidationTypeWhole, info.Type())
+	require.Equal(t, types.ValidationOperatorGreaterThan, info.Operator())
+	require.Nil(t, sheet.Validation("B5"))
+
+	//remove validation
+	sheet.DeleteValidation(types.BoundsFromIndexes(0, 0, 0, 0))
+	require.Equal(t, 0, len(validations.sheet.ml.DataValidations.Items))
+	require.Nil(t, sheet.Validation("A5"))
+}
+
+func TestValidations_date1904(t *testing.T) {
+	xl := New()
+	defer xl.Close()
+
+	xl.SetDateSystem(DateSystem1904)
+	sheet := xl.AddSheet("Sheet1")
+
+	info := types.NewValidation(types.Validation.Date(types.ValidationOperatorBetween, time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), "=B1"))
+	require.Nil(t, sheet.AddValidation(types.BoundsFromIndexes(0, 0, 0, 9), info))
+
+	validation := sheet.info().ml.DataValidations.Items[0]
+	require.Equal(t, "41639", string(validation.Formula1))
+	require.Equal(t, "B1", string(validation.Formula2))
+
+	//info is not changed, so it can be reused for workbooks with other date system
+	f1, _ := info.Formulas()
+	require.Equal(t, "43101", f1)
+}
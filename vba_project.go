@@ -0,0 +1,54 @@
+package xlsx
+
+import (
+	"errors"
+	"github.com/plandem/xlsx/internal"
+	"io"
+	"io/ioutil"
+)
+
+//vbaProjectFileName is a name of file with VBA project
+const vbaProjectFileName = "xl/vbaProject.bin"
+
+//HasVBAProject returns true if document has VBA project, i.e. document is macro-enabled
+func (xl *Spreadsheet) HasVBAProject() bool {
+	return xl.pkg.File(vbaProjectFileName) != nil || xl.relationships.GetIdByTarget(vbaProjectFileName) != ""
+}
+
+//SetVBAProject adds a new or replaces existing VBA project with content of reader, e.g.: vbaProject.bin of another macro-enabled workbook. Document with VBA project must be saved with .xlsm extension
+func (xl *Spreadsheet) SetVBAProject(reader io.Reader) error {
+	if reader == nil {
+		return errors.New("no VBA project")
+	}
+
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	if len(content) == 0 {
+		return errors.New("VBA project is empty")
+	}
+
+	xl.pkg.Remove(vbaProjectFileName)
+	xl.pkg.Add(vbaProjectFileName, content)
+	xl.pkg.ContentTypes().RegisterContent(vbaProjectFileName, internal.ContentTypeVBAProject)
+	if xl.relationships.GetIdByTarget(vbaProjectFileName) == "" {
+		xl.relationships.AddFile(internal.RelationTypeVBAProject, vbaProjectFileName)
+	}
+
+	//macro-enabled workbook has own content type
+	xl.pkg.ContentTypes().RegisterContent(xl.workbook.file.FileName(), internal.ContentTypeWorkbookMacro)
+	return nil
+}
+
+//DeleteVBAProject deletes VBA project, so document can be saved with .xlsx extension
+func (xl *Spreadsheet) DeleteVBAProject() {
+	if rid := xl.relationships.GetIdByTarget(vbaProjectFileName); rid != "" {
+		xl.relationships.Remove(rid)
+	}
+
+	xl.pkg.Remove(vbaProjectFileName)
+	xl.pkg.ContentTypes().Remove(vbaProjectFileName)
+	xl.pkg.ContentTypes().RegisterContent(xl.workbook.file.FileName(), internal.ContentTypeWorkbook)
+}
@@ -0,0 +1,37 @@
+package xlsx
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestVBAProject(t *testing.T) {
+	xl := New()
+	xl.AddSheet("Macro")
+	require.Equal(t, false, xl.HasVBAProject())
+	require.NotNil(t, xl.SetVBAProject(nil))
+	require.NotNil(t, xl.SetVBAProject(bytes.NewReader(nil)))
+	require.Nil(t, xl.SetVBAProject(bytes.NewReader([]byte("vba"))))
+	require.Equal(t, true, xl.HasVBAProject())
+	require.Nil(t, xl.SaveAs("./test_files/tmp.xlsm"))
+	xl.Close()
+
+	//VBA project is preserved after saving of opened document
+	xl, err := Open("./test_files/tmp.xlsm")
+	require.Nil(t, err)
+	require.Equal(t, true, xl.HasVBAProject())
+	xl.Sheet(0).Cell(0, 0).SetValue("macro")
+	require.Nil(t, xl.SaveAs("./test_files/tmp2.xlsm"))
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp2.xlsm")
+	require.Nil(t, err)
+	require.Equal(t, true, xl.HasVBAProject())
+	require.Equal(t, "macro", xl.Sheet(0).Cell(0, 0).Value())
+
+	xl.DeleteVBAProject()
+	require.Equal(t, false, xl.HasVBAProject())
+	require.Nil(t, xl.SaveAs("./test_files/tmp.xlsx"))
+	xl.Close()
+}
@@ -0,0 +1,81 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/internal/color"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/options"
+)
+
+//SetView sets presentation settings of sheet, e.g.: zoom, grid lines, headers of rows/cols, type of view, right-to-left mode and color of tab. If options is nil, then default options are used
+func (s *sheetInfo) SetView(o *options.ViewOptions) {
+	if o == nil {
+		o = options.NewViewOptions()
+	}
+
+	view := s.sheetView()
+
+	//zero value and 100% are same for zoom
+	view.ZoomScale = 0
+	if o.ZoomScale != 100 {
+		view.ZoomScale = o.ZoomScale
+	}
+
+	//true is a default value
+	view.ShowGridLines, view.ShowRowColHeaders = nil, nil
+	if !o.ShowGridLines {
+		view.ShowGridLines = &o.ShowGridLines
+	}
+
+	if !o.ShowRowColHeaders {
+		view.ShowRowColHeaders = &o.ShowRowColHeaders
+	}
+
+	view.View = ""
+	if o.Type != options.ViewTypeNormal {
+		view.View = string(o.Type)
+	}
+
+	view.RightToLeft = o.RightToLeft
+
+	if len(o.TabColor) > 0 {
+		if s.ml.SheetPr == nil {
+			s.ml.SheetPr = &ml.SheetPr{}
+		}
+
+		s.ml.SheetPr.TabColor = color.New(o.TabColor)
+	} else if s.ml.SheetPr != nil {
+		s.ml.SheetPr.TabColor = nil
+	}
+}
+
+//View returns presentation settings of sheet
+func (s *sheetInfo) View() *options.ViewOptions {
+	o := options.NewViewOptions()
+
+	if len(s.ml.SheetViews.Items) > 0 {
+		view := s.ml.SheetViews.Items[0]
+		if view.ZoomScale > 0 {
+			o.ZoomScale = view.ZoomScale
+		}
+
+		if view.ShowGridLines != nil {
+			o.ShowGridLines = *view.ShowGridLines
+		}
+
+		if view.ShowRowColHeaders != nil {
+			o.ShowRowColHeaders = *view.ShowRowColHeaders
+		}
+
+		if len(view.View) > 0 {
+			o.Type = options.ViewType(view.View)
+		}
+
+		o.RightToLeft = view.RightToLeft
+	}
+
+	if s.ml.SheetPr != nil {
+		o.TabColor = color.ToRGB(s.ml.SheetPr.TabColor)
+	}
+
+	return o
+}
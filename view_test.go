@@ -0,0 +1,55 @@
+package xlsx
+
+import (
+	"encoding/xml"
+	"github.com/plandem/xlsx/internal/color"
+	"github.com/plandem/xlsx/options"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestView(t *testing.T) {
+	xl := New()
+	sheet := xl.AddSheet("Report")
+
+	encode := func() string {
+		encoded, err := xml.Marshal(sheet.info().ml.SheetViews.Items[0])
+		require.Nil(t, err)
+		return string(encoded)
+	}
+
+	require.Equal(t, options.NewViewOptions(), sheet.View())
+
+	o := options.NewViewOptions(
+		options.View.ZoomScale(150),
+		options.View.ShowGridLines(false),
+		options.View.ShowRowColHeaders(false),
+		options.View.RightToLeft(true),
+		options.View.Type(options.ViewTypePageLayout),
+		options.View.TabColor("#FF0000"),
+	)
+
+	sheet.SetView(o)
+	require.Equal(t, `<SheetView showGridLines="false" showRowColHeaders="false" rightToLeft="true" view="pageLayout" zoomScale="150" workbookViewId="0"></SheetView>`, encode())
+	require.Equal(t, color.New("#FF0000"), sheet.info().ml.SheetPr.TabColor)
+	require.Equal(t, o, sheet.View())
+
+	//defaults
+	sheet.SetView(nil)
+	require.Equal(t, `<SheetView workbookViewId="0"></SheetView>`, encode())
+	require.Nil(t, sheet.info().ml.SheetPr.TabColor)
+	require.Equal(t, options.NewViewOptions(), sheet.View())
+
+	sheet.SetView(o)
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	require.Equal(t, o, xl.Sheet(0).View())
+}
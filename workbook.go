@@ -8,9 +8,12 @@ import (
 
 //Workbook is a higher level object that wraps ml.Workbook with functionality
 type Workbook struct {
-	ml   ml.Workbook
-	doc  *Spreadsheet
-	file *ooxml.PackageFile
+	ml           ml.Workbook
+	doc          *Spreadsheet
+	file         *ooxml.PackageFile
+	definedNames *definedNames
+
+	extensionMarshalers []ExtensionMarshaler
 }
 
 func newWorkbook(f interface{}, doc *Spreadsheet) *Workbook {
@@ -19,6 +22,7 @@ func newWorkbook(f interface{}, doc *Spreadsheet) *Workbook {
 	}
 
 	doc.workbook = wb
+	wb.definedNames = newDefinedNames(wb)
 
 	wb.file = ooxml.NewPackageFile(doc.pkg, f, &wb.ml, nil)
 	wb.file.LoadIfRequired(nil)
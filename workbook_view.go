@@ -0,0 +1,102 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/plandem/xlsx/internal/ml"
+	"github.com/plandem/xlsx/options"
+)
+
+//bookView returns the first view of workbook, view will be added if required
+func (wb *Workbook) bookView() *ml.BookView {
+	if len(wb.ml.BookViews.Items) == 0 {
+		wb.ml.BookViews.Items = append(wb.ml.BookViews.Items, &ml.BookView{})
+	}
+
+	return wb.ml.BookViews.Items[0]
+}
+
+//SetActiveSheet sets the sheet with 0-based index as active, so the file will be opened with that sheet. N.B.: sheets are loaded to unselect tabs of other sheets
+func (xl *Spreadsheet) SetActiveSheet(i int) error {
+	if i < 0 || i >= len(xl.sheets) {
+		return errors.New(fmt.Sprintf("there is no sheet with index %d", i))
+	}
+
+	if state := xl.workbook.ml.Sheets[i].State; state == options.VisibilityTypeHidden || state == options.VisibilityTypeVeryHidden {
+		return errors.New(fmt.Sprintf("hidden sheet %s can't be active", xl.workbook.ml.Sheets[i].Name))
+	}
+
+	//set active from workbook side
+	view := xl.workbook.bookView()
+	view.ActiveTab = i
+
+	//tab of active sheet must be visible
+	if int(view.FirstSheet) > i {
+		view.FirstSheet = uint(i)
+	}
+
+	//set active from worksheets side, sheets that were not opened are updated right before saving
+	for _, sheet := range xl.sheets {
+		if sheet != nil {
+			sheet.patch((*sheetInfo).selectTab)
+		}
+	}
+
+	xl.workbook.file.MarkAsUpdated()
+	return nil
+}
+
+//ActiveSheet returns 0-based index of active sheet
+func (xl *Spreadsheet) ActiveSheet() int {
+	if len(xl.workbook.ml.BookViews.Items) > 0 {
+		return xl.workbook.ml.BookViews.Items[0].ActiveTab
+	}
+
+	return 0
+}
+
+//SetFirstVisibleSheet sets 0-based index of the first sheet which tab is visible in the tab bar. Active sheet is set as the first visible if required
+func (xl *Spreadsheet) SetFirstVisibleSheet(i int) error {
+	if i < 0 || i >= len(xl.sheets) {
+		return errors.New(fmt.Sprintf("there is no sheet with index %d", i))
+	}
+
+	view := xl.workbook.bookView()
+	view.FirstSheet = uint(i)
+
+	if view.ActiveTab < i {
+		if err := xl.SetActiveSheet(i); err != nil {
+			return err
+		}
+	}
+
+	xl.workbook.file.MarkAsUpdated()
+	return nil
+}
+
+//FirstVisibleSheet returns 0-based index of the first sheet which tab is visible in the tab bar
+func (xl *Spreadsheet) FirstVisibleSheet() int {
+	if len(xl.workbook.ml.BookViews.Items) > 0 {
+		return int(xl.workbook.ml.BookViews.Items[0].FirstSheet)
+	}
+
+	return 0
+}
+
+//SetWindow sets position of upper-left corner and size of workbook window in twips (1/20th of a point), zero size means default size of Excel
+func (xl *Spreadsheet) SetWindow(x, y int, width, height uint) {
+	view := xl.workbook.bookView()
+	view.XWindow, view.YWindow = x, y
+	view.WindowWidth, view.WindowHeight = width, height
+	xl.workbook.file.MarkAsUpdated()
+}
+
+//Window returns position of upper-left corner and size of workbook window in twips (1/20th of a point)
+func (xl *Spreadsheet) Window() (x, y int, width, height uint) {
+	if len(xl.workbook.ml.BookViews.Items) > 0 {
+		view := xl.workbook.ml.BookViews.Items[0]
+		x, y, width, height = view.XWindow, view.YWindow, view.WindowWidth, view.WindowHeight
+	}
+
+	return
+}
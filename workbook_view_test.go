@@ -0,0 +1,106 @@
+package xlsx
+
+import (
+	"github.com/plandem/xlsx/options"
+	"github.com/plandem/xlsx/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestWorkbookView(t *testing.T) {
+	xl := New()
+	first := xl.AddSheet("First")
+	second := xl.AddSheet("Second")
+	third := xl.AddSheet("Third")
+
+	require.Equal(t, 0, xl.ActiveSheet())
+	require.Equal(t, 0, xl.FirstVisibleSheet())
+
+	//invalid sheets
+	require.NotNil(t, xl.SetActiveSheet(-1))
+	require.NotNil(t, xl.SetActiveSheet(3))
+	require.NotNil(t, xl.SetFirstVisibleSheet(3))
+
+	third.Set(options.NewSheetOptions(options.Sheet.Visibility(options.VisibilityTypeHidden)))
+	require.NotNil(t, xl.SetActiveSheet(2))
+	require.NotNil(t, third.SetActive())
+	third.Set(options.NewSheetOptions(options.Sheet.Visibility(options.VisibilityTypeVisible)))
+
+	//only one tab is selected
+	require.Nil(t, xl.SetActiveSheet(0))
+	require.Equal(t, true, first.info().ml.SheetViews.Items[0].TabSelected)
+
+	require.Nil(t, second.SetActive())
+	require.Equal(t, 1, xl.ActiveSheet())
+	require.Equal(t, false, first.info().ml.SheetViews.Items[0].TabSelected)
+	require.Equal(t, true, second.info().ml.SheetViews.Items[0].TabSelected)
+
+	//active sheet follows the first visible sheet
+	require.Nil(t, xl.SetFirstVisibleSheet(2))
+	require.Equal(t, 2, xl.FirstVisibleSheet())
+	require.Equal(t, 2, xl.ActiveSheet())
+	require.Equal(t, false, second.info().ml.SheetViews.Items[0].TabSelected)
+
+	//the first visible sheet follows active sheet
+	require.Nil(t, xl.SetActiveSheet(1))
+	require.Equal(t, 1, xl.FirstVisibleSheet())
+
+	xl.SetWindow(120, 240, 28800, 12300)
+	x, y, width, height := xl.Window()
+	require.Equal(t, []interface{}{120, 240, uint(28800), uint(12300)}, []interface{}{x, y, width, height})
+
+	//selection
+	active, refs := second.Selection()
+	require.Equal(t, types.CellRef("A1"), active)
+	require.Equal(t, []types.Ref{"A1"}, refs)
+
+	require.NotNil(t, second.SetSelection("D4", "A1:C3"))
+	require.Nil(t, second.SetSelection("B2", "E5", "A1:C3"))
+	require.Equal(t, uint(1), second.info().ml.SheetViews.Items[0].Selection[0].ActiveCellID)
+
+	//selection of active pane is updated for frozen panes
+	first.FreezeRows(1)
+	require.Nil(t, first.SetSelection("C5"))
+	require.Equal(t, 1, len(first.info().ml.SheetViews.Items[0].Selection))
+	require.Equal(t, "bottomLeft", first.info().ml.SheetViews.Items[0].Selection[0].Pane)
+
+	//save and reopen
+	err := xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	xl.Close()
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	require.Equal(t, 1, xl.ActiveSheet())
+	require.Equal(t, 1, xl.FirstVisibleSheet())
+	x, y, width, height = xl.Window()
+	require.Equal(t, []interface{}{120, 240, uint(28800), uint(12300)}, []interface{}{x, y, width, height})
+
+	active, refs = xl.Sheet(1).Selection()
+	require.Equal(t, types.CellRef("B2"), active)
+	require.Equal(t, []types.Ref{"E5", "A1:C3"}, refs)
+
+	active, refs = xl.Sheet(0).Selection()
+	require.Equal(t, types.CellRef("C5"), active)
+	require.Equal(t, []types.Ref{"C5"}, refs)
+
+	//sheets are not opened to change active sheet
+	require.Nil(t, xl.SetActiveSheet(2))
+	require.Nil(t, xl.sheets[2].sheet)
+	require.Equal(t, false, xl.sheets[1].ml.SheetViews.Items[0].TabSelected)
+
+	err = xl.SaveAs("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+
+	xl, err = Open("./test_files/tmp.xlsx")
+	require.Nil(t, err)
+	defer xl.Close()
+
+	require.Equal(t, 2, xl.ActiveSheet())
+	for i, selected := range []bool{false, false, true} {
+		sheet := xl.Sheet(i).info()
+		require.Equal(t, selected, len(sheet.ml.SheetViews.Items) > 0 && sheet.ml.SheetViews.Items[0].TabSelected)
+	}
+}
@@ -1,7 +1,9 @@
 package xlsx
 
 import (
+	"bytes"
 	"github.com/plandem/ooxml"
+	"io"
 
 	//init enums for marshal/unmarshal
 	_ "github.com/plandem/xlsx/format"
@@ -23,6 +25,16 @@ func Open(f interface{}) (*Spreadsheet, error) {
 	return nil, ooxml.ErrorUnknownPackage(Spreadsheet{})
 }
 
+//OpenReader opens a XLSX file from io.ReaderAt with size, e.g.: content of HTTP upload or S3 object, so file is processed entirely in memory
+func OpenReader(r io.ReaderAt, size int64) (*Spreadsheet, error) {
+	return Open(io.NewSectionReader(r, 0, size))
+}
+
+//OpenBytes opens a XLSX file from content
+func OpenBytes(content []byte) (*Spreadsheet, error) {
+	return OpenReader(bytes.NewReader(content), int64(len(content)))
+}
+
 //New creates and returns a new XLSX document
 func New() *Spreadsheet {
 	if doc, err := newSpreadsheet(ooxml.NewPackage(nil)); err == nil {
@@ -1,6 +1,7 @@
 package xlsx_test
 
 import (
+	"bytes"
 	"github.com/plandem/xlsx"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -95,3 +96,30 @@ func TestSaving(t *testing.T) {
 	require.IsType(t, &xlsx.Spreadsheet{}, xl)
 	require.Equal(t, []string{"Sheet1", "new sheet"}, xl.GetSheetNames())
 }
+
+func TestInMemory(t *testing.T) {
+	xl := xlsx.New()
+	xl.AddSheet("Memory").Cell(0, 0).SetValue("in memory")
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, xl.Write(buf))
+	content, err := xl.Bytes()
+	require.Nil(t, err)
+	require.Equal(t, buf.Len(), len(content))
+	xl.Close()
+
+	xl, err = xlsx.OpenReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.Nil(t, err)
+	require.Equal(t, "in memory", xl.Sheet(0).Cell(0, 0).Value())
+	xl.Close()
+
+	xl, err = xlsx.OpenBytes(content)
+	require.Nil(t, err)
+	require.Equal(t, "in memory", xl.Sheet(0).Cell(0, 0).Value())
+	xl.Close()
+
+	//non zip
+	xl, err = xlsx.OpenBytes([]byte("xlsx"))
+	require.NotNil(t, err)
+	require.Nil(t, xl)
+}